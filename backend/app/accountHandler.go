@@ -69,6 +69,12 @@ func (h AccountHandler) transactionHandler(w http.ResponseWriter, r *http.Reques
 		writeJsonResponse(w, appErr.Code, appErr.AsMessage())
 		return
 	}
+	if transactionRequest.OverrideScreening && !isAdminRequest(r) {
+		logger.Error("Non-admin tried to override fraud screening of transaction")
+		writeJsonResponse(w, http.StatusForbidden, errs.NewMessageObject("Only admins may override fraud screening."))
+		return
+	}
+	transactionRequest.ActingAdmin = isAdminRequest(r)
 
 	response, appErr := h.service.MakeTransaction(transactionRequest)
 	if appErr != nil {
@@ -79,6 +85,106 @@ func (h AccountHandler) transactionHandler(w http.ResponseWriter, r *http.Reques
 	writeJsonResponse(w, http.StatusCreated, response)
 }
 
+func (h AccountHandler) transferHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	transferRequest := dto.TransferRequest{
+		AccountId:  vars["account_id"],
+		CustomerId: vars["customer_id"],
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&transferRequest); err != nil {
+		logger.Error("Error while decoding json body of transfer request: " + err.Error())
+		writeJsonResponse(w, http.StatusBadRequest, errs.NewMessageObject("Please check that all fields are correctly filled."))
+		return
+	}
+
+	if appErr := transferRequest.Validate(); appErr != nil {
+		writeJsonResponse(w, appErr.Code, appErr.AsMessage())
+		return
+	}
+	if transferRequest.OverrideBeneficiaryCheck && !isAdminRequest(r) {
+		logger.Error("Non-admin tried to override beneficiary check of transfer")
+		writeJsonResponse(w, http.StatusForbidden, errs.NewMessageObject("Only admins may override the beneficiary check."))
+		return
+	}
+	transferRequest.ActingAdmin = isAdminRequest(r)
+
+	response, appErr := h.service.MakeTransfer(transferRequest)
+	if appErr != nil {
+		writeJsonResponse(w, appErr.Code, appErr.AsMessage())
+		return
+	}
+
+	writeJsonResponse(w, http.StatusCreated, response)
+}
+
+func (h AccountHandler) overdraftHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	overdraftRequest := dto.OverdraftRequest{
+		AccountId:  vars["account_id"],
+		CustomerId: vars["customer_id"],
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&overdraftRequest); err != nil {
+		logger.Error("Error while decoding json body of overdraft request: " + err.Error())
+		writeJsonResponse(w, http.StatusBadRequest, errs.NewMessageObject("Please check that all fields are correctly filled."))
+		return
+	}
+
+	if appErr := overdraftRequest.Validate(); appErr != nil {
+		writeJsonResponse(w, appErr.Code, appErr.AsMessage())
+		return
+	}
+
+	response, appErr := h.service.SetOverdraftLimit(overdraftRequest)
+	if appErr != nil {
+		writeJsonResponse(w, appErr.Code, appErr.AsMessage())
+		return
+	}
+
+	writeJsonResponse(w, http.StatusOK, response)
+}
+
+func (h AccountHandler) transactionsHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	response, appErr := h.service.GetTransactions(vars["customer_id"], vars["account_id"])
+	if appErr != nil {
+		writeJsonResponse(w, appErr.Code, appErr.AsMessage())
+		return
+	}
+
+	writeJsonResponse(w, http.StatusOK, response)
+}
+
+func (h AccountHandler) reversalHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	reversalRequest := dto.ReversalRequest{
+		AccountId:     vars["account_id"],
+		CustomerId:    vars["customer_id"],
+		TransactionId: vars["transaction_id"],
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&reversalRequest); err != nil {
+		logger.Error("Error while decoding json body of reversal request: " + err.Error())
+		writeJsonResponse(w, http.StatusBadRequest, errs.NewMessageObject("Please check that all fields are correctly filled."))
+		return
+	}
+
+	if appErr := reversalRequest.Validate(); appErr != nil {
+		writeJsonResponse(w, appErr.Code, appErr.AsMessage())
+		return
+	}
+
+	response, appErr := h.service.ReverseTransaction(reversalRequest)
+	if appErr != nil {
+		writeJsonResponse(w, appErr.Code, appErr.AsMessage())
+		return
+	}
+
+	writeJsonResponse(w, http.StatusCreated, response)
+}
+
 // (*)
 //json.Decoder.Decode uses json.Unmarshal internally
 //json.Unmarshal docs: "By default, object keys which don't have a corresponding struct field are ignored
@@ -2,9 +2,11 @@ package app
 
 import (
 	"bytes"
+	"context"
 	"github.com/aliciatay-zls/banking-lib/errs"
 	"github.com/aliciatay-zls/banking-lib/formValidator"
 	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/domain"
 	"github.com/aliciatay-zls/banking/backend/dto"
 	"github.com/aliciatay-zls/banking/backend/mocks/service"
 	"github.com/gorilla/mux"
@@ -39,6 +41,10 @@ const newTransactionPath = "/customers/{customer_id:[0-9]+}/account/{account_id:
 const dummyNewTransactionPath = "/customers/2/account/1977"
 const dummyNewTransactionPayload = `{"transaction_type": "deposit", "amount": 6000}`
 const dummyTransactionId = "7791"
+
+const overdraftPath = "/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}/overdraft"
+const dummyOverdraftPath = "/customers/2/account/1977/overdraft"
+const dummyOverdraftPayload = `{"overdraft_limit": 500}`
 const dummyBalance float64 = 12000
 
 func init() {
@@ -115,8 +121,8 @@ func TestAccountHandler_accountsHandler_respondsWith_accountsAndStatusCode200_wh
 	router.HandleFunc(getAccountsPath, ah.accountsHandler).Methods(http.MethodGet)
 
 	dummyAccounts := []dto.AccountResponse{
-		{dummyAccountId, dummyDate, dummyAccountType, dummyAmount},
-		{"1980", dummyDate, dto.AccountTypeChecking, 7000},
+		{AccountId: dummyAccountId, OpeningDate: dummyDate, AccountType: dummyAccountType, Amount: dummyAmount},
+		{AccountId: "1980", OpeningDate: dummyDate, AccountType: dto.AccountTypeChecking, Amount: 7000},
 	}
 	mockAccountService.EXPECT().GetAllAccounts(dummyCustomerId).Return(dummyAccounts, nil)
 
@@ -286,3 +292,255 @@ func TestAccountHandler_transactionHandler_respondsWith_errorStatusCode_when_ser
 		t.Errorf("Expecting response to contain %s but got %s", dummyAppError.Message, actualResponse)
 	}
 }
+
+func TestAccountHandler_overdraftHandler_respondsWith_errorStatusCode_when_limit_invalid(t *testing.T) {
+	//Arrange
+	teardown := setupAccountHandlerTest(t, dummyOverdraftPath, `{"overdraft_limit": -1}`)
+	defer teardown()
+	router.HandleFunc(overdraftPath, ah.overdraftHandler)
+
+	mockAccountService.EXPECT().SetOverdraftLimit(gomock.Any()).Times(0)
+	expectedStatusCode := http.StatusUnprocessableEntity
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != expectedStatusCode {
+		t.Errorf("Expected status code %d but got %d", expectedStatusCode, recorder.Result().StatusCode)
+	}
+}
+
+func TestAccountHandler_overdraftHandler_respondsWith_updatedAccountAndStatusCode200_when_service_succeeds(t *testing.T) {
+	//Arrange
+	teardown := setupAccountHandlerTest(t, dummyOverdraftPath, dummyOverdraftPayload)
+	defer teardown()
+	router.HandleFunc(overdraftPath, ah.overdraftHandler)
+
+	dummyOverdraftRequest := dto.OverdraftRequest{AccountId: dummyAccountId, CustomerId: dummyCustomerId, OverdraftLimit: 500}
+	dummyAccount := dto.AccountResponse{AccountId: dummyAccountId, AccountType: dto.AccountTypeChecking, OverdraftLimit: 500}
+	mockAccountService.EXPECT().SetOverdraftLimit(dummyOverdraftRequest).Return(&dummyAccount, nil)
+	expectedStatusCode := http.StatusOK
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != expectedStatusCode {
+		t.Errorf("Expected status code %d but got %d", expectedStatusCode, recorder.Result().StatusCode)
+	}
+	actualResponse, _ := io.ReadAll(recorder.Result().Body)
+	if !strings.Contains(string(actualResponse), `"overdraft_limit":500`) {
+		t.Errorf("Expected response to contain the new overdraft limit but got %s", actualResponse)
+	}
+}
+
+func TestAccountHandler_overdraftHandler_ignores_ids_in_body(t *testing.T) {
+	//Arrange
+	teardown := setupAccountHandlerTest(t, dummyOverdraftPath,
+		`{"account_id": "95470", "customer_id": "2000", "overdraft_limit": 500}`)
+	defer teardown()
+	router.HandleFunc(overdraftPath, ah.overdraftHandler)
+
+	dummyOverdraftRequest := dto.OverdraftRequest{AccountId: dummyAccountId, CustomerId: dummyCustomerId, OverdraftLimit: 500}
+	mockAccountService.EXPECT().SetOverdraftLimit(dummyOverdraftRequest).
+		Return(&dto.AccountResponse{AccountId: dummyAccountId, OverdraftLimit: 500}, nil)
+	expectedStatusCode := http.StatusOK
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != expectedStatusCode {
+		t.Errorf("Expected status code %d but got %d", expectedStatusCode, recorder.Result().StatusCode)
+	}
+}
+
+func TestAccountHandler_transferHandler_respondsWith_errorStatusCode_when_destination_sameAsSource(t *testing.T) {
+	//Arrange
+	teardown := setupAccountHandlerTest(t, "/customers/2/account/1977/transfer", `{"destination_account_id": "1977", "amount": 100}`)
+	defer teardown()
+	router.HandleFunc("/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}/transfer", ah.transferHandler)
+
+	mockAccountService.EXPECT().MakeTransfer(gomock.Any()).Times(0)
+	expectedStatusCode := http.StatusUnprocessableEntity
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != expectedStatusCode {
+		t.Errorf("Expected status code %d but got %d", expectedStatusCode, recorder.Result().StatusCode)
+	}
+}
+
+func TestAccountHandler_transferHandler_respondsWith_newTransactionAndStatusCode201_when_service_succeeds(t *testing.T) {
+	//Arrange
+	teardown := setupAccountHandlerTest(t, "/customers/2/account/1977/transfer", `{"destination_account_id": "1980", "amount": 100}`)
+	defer teardown()
+	router.HandleFunc("/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}/transfer", ah.transferHandler)
+
+	dummyTransferRequest := dto.TransferRequest{AccountId: dummyAccountId, CustomerId: dummyCustomerId, DestinationAccountId: "1980", Amount: 100}
+	dummyTransaction := dto.TransactionResponse{TransactionId: dummyTransactionId, Balance: dummyBalance}
+	mockAccountService.EXPECT().MakeTransfer(dummyTransferRequest).Return(&dummyTransaction, nil)
+	expectedStatusCode := http.StatusCreated
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != expectedStatusCode {
+		t.Errorf("Expected status code %d but got %d", expectedStatusCode, recorder.Result().StatusCode)
+	}
+	actualResponse, _ := io.ReadAll(recorder.Result().Body)
+	if !strings.Contains(string(actualResponse), dummyTransaction.TransactionId) {
+		t.Errorf("Expecting response to contain %s but got %s", dummyTransaction.TransactionId, actualResponse)
+	}
+}
+
+func TestAccountHandler_transferHandler_ignores_ids_in_body(t *testing.T) {
+	//Arrange
+	teardown := setupAccountHandlerTest(t, "/customers/2/account/1977/transfer",
+		`{"account_id": "95470", "customer_id": "2000", "destination_account_id": "1980", "amount": 100}`)
+	defer teardown()
+	router.HandleFunc("/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}/transfer", ah.transferHandler)
+
+	dummyTransferRequest := dto.TransferRequest{AccountId: dummyAccountId, CustomerId: dummyCustomerId, DestinationAccountId: "1980", Amount: 100}
+	mockAccountService.EXPECT().MakeTransfer(dummyTransferRequest).
+		Return(&dto.TransactionResponse{TransactionId: dummyTransactionId}, nil)
+	expectedStatusCode := http.StatusCreated
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != expectedStatusCode {
+		t.Errorf("Expected status code %d but got %d", expectedStatusCode, recorder.Result().StatusCode)
+	}
+}
+
+func TestAccountHandler_transactionHandler_respondsWith_403_when_nonAdmin_overridesScreening(t *testing.T) {
+	//Arrange
+	teardown := setupAccountHandlerTest(t, dummyNewTransactionPath,
+		`{"transaction_type": "withdrawal", "amount": 100, "override_screening": true}`)
+	defer teardown()
+	router.HandleFunc(newTransactionPath, ah.transactionHandler)
+	request = request.WithContext(context.WithValue(request.Context(), authorizedActorContextKey{},
+		domain.Actor{Role: domain.RoleUser, CustomerId: dummyCustomerId}))
+
+	mockAccountService.EXPECT().MakeTransaction(gomock.Any()).Times(0)
+	expectedStatusCode := http.StatusForbidden
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != expectedStatusCode {
+		t.Errorf("Expected status code %d but got %d", expectedStatusCode, recorder.Result().StatusCode)
+	}
+}
+
+func TestAccountHandler_transferHandler_overridesBeneficiaryCheck_only_for_admin(t *testing.T) {
+	tests := []struct {
+		name               string
+		actor              *domain.Actor
+		expectedStatusCode int
+	}{
+		{"no actor", nil, http.StatusForbidden},
+		{"user", &domain.Actor{Role: domain.RoleUser, CustomerId: dummyCustomerId}, http.StatusForbidden},
+		{"admin", &domain.Actor{Role: domain.RoleAdmin}, http.StatusCreated},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Arrange
+			teardown := setupAccountHandlerTest(t, "/customers/2/account/1977/transfer",
+				`{"destination_account_id": "1980", "amount": 100, "override_beneficiary_check": true}`)
+			defer teardown()
+			router.HandleFunc("/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}/transfer", ah.transferHandler)
+			if tc.actor != nil {
+				request = request.WithContext(context.WithValue(request.Context(), authorizedActorContextKey{}, *tc.actor))
+			}
+
+			if tc.expectedStatusCode == http.StatusCreated {
+				dummyTransferRequest := dto.TransferRequest{AccountId: dummyAccountId, CustomerId: dummyCustomerId,
+					DestinationAccountId: "1980", Amount: 100, OverrideBeneficiaryCheck: true, ActingAdmin: true}
+				mockAccountService.EXPECT().MakeTransfer(dummyTransferRequest).
+					Return(&dto.TransactionResponse{TransactionId: dummyTransactionId}, nil)
+			} else {
+				mockAccountService.EXPECT().MakeTransfer(gomock.Any()).Times(0)
+			}
+
+			//Act
+			router.ServeHTTP(recorder, request)
+
+			//Assert
+			if recorder.Result().StatusCode != tc.expectedStatusCode {
+				t.Errorf("Expected status code %d but got %d", tc.expectedStatusCode, recorder.Result().StatusCode)
+			}
+		})
+	}
+}
+
+func TestAccountHandler_reversalHandler_respondsWith_reversalAndStatusCode201_when_service_succeeds(t *testing.T) {
+	//Arrange
+	teardown := setupAccountHandlerTest(t, "/customers/2/account/1977/transactions/7790/reverse", `{"reason_code": "duplicate"}`)
+	defer teardown()
+	router.HandleFunc("/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}/transactions/{transaction_id:[0-9]+}/reverse", ah.reversalHandler)
+
+	dummyReversalRequest := dto.ReversalRequest{AccountId: dummyAccountId, CustomerId: dummyCustomerId, TransactionId: "7790", ReasonCode: dto.ReversalReasonDuplicate}
+	dummyTransaction := dto.TransactionResponse{TransactionId: dummyTransactionId, Balance: dummyBalance, ReversalOf: "7790"}
+	mockAccountService.EXPECT().ReverseTransaction(dummyReversalRequest).Return(&dummyTransaction, nil)
+	expectedStatusCode := http.StatusCreated
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != expectedStatusCode {
+		t.Errorf("Expected status code %d but got %d", expectedStatusCode, recorder.Result().StatusCode)
+	}
+	actualResponse, _ := io.ReadAll(recorder.Result().Body)
+	if !strings.Contains(string(actualResponse), `"reversal_of":"7790"`) {
+		t.Errorf("Expected response to contain the link to the reversed transaction but got %s", actualResponse)
+	}
+}
+
+func TestAccountHandler_reversalHandler_ignores_ids_in_body(t *testing.T) {
+	//Arrange
+	teardown := setupAccountHandlerTest(t, "/customers/2/account/1977/transactions/7790/reverse",
+		`{"account_id": "95470", "customer_id": "2000", "transaction_id": "9", "reason_code": "duplicate"}`)
+	defer teardown()
+	router.HandleFunc("/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}/transactions/{transaction_id:[0-9]+}/reverse", ah.reversalHandler)
+
+	dummyReversalRequest := dto.ReversalRequest{AccountId: dummyAccountId, CustomerId: dummyCustomerId, TransactionId: "7790", ReasonCode: dto.ReversalReasonDuplicate}
+	mockAccountService.EXPECT().ReverseTransaction(dummyReversalRequest).
+		Return(&dto.TransactionResponse{TransactionId: dummyTransactionId, ReversalOf: "7790"}, nil)
+	expectedStatusCode := http.StatusCreated
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != expectedStatusCode {
+		t.Errorf("Expected status code %d but got %d", expectedStatusCode, recorder.Result().StatusCode)
+	}
+}
+
+func TestAccountHandler_reversalHandler_respondsWith_errorStatusCode_when_service_fails(t *testing.T) {
+	//Arrange
+	teardown := setupAccountHandlerTest(t, "/customers/2/account/1977/transactions/7790/reverse", `{"reason_code": "duplicate"}`)
+	defer teardown()
+	router.HandleFunc("/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}/transactions/{transaction_id:[0-9]+}/reverse", ah.reversalHandler)
+
+	dummyAppError := errs.NewConflictError("Transaction has already been reversed")
+	mockAccountService.EXPECT().ReverseTransaction(gomock.Any()).Return(nil, dummyAppError)
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != dummyAppError.Code {
+		t.Errorf("Expected status code %d but got %d", dummyAppError.Code, recorder.Result().StatusCode)
+	}
+}
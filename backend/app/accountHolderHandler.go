@@ -0,0 +1,64 @@
+package app
+
+import (
+	"encoding/json"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/service"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+type AccountHolderHandler struct {
+	service service.AccountHolderService
+}
+
+func (h AccountHolderHandler) accountHoldersHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	response, appErr := h.service.GetAccountHolders(vars["customer_id"], vars["account_id"])
+	if appErr != nil {
+		writeJsonResponse(w, appErr.Code, appErr.AsMessage())
+		return
+	}
+
+	writeJsonResponse(w, http.StatusOK, response)
+}
+
+func (h AccountHolderHandler) newAccountHolderHandler(w http.ResponseWriter, r *http.Request) {
+	var holderRequest dto.AccountHolderRequest
+	if err := json.NewDecoder(r.Body).Decode(&holderRequest); err != nil {
+		logger.Error("Error while decoding json body of account holder request: " + err.Error())
+		writeJsonResponse(w, http.StatusBadRequest, errs.NewMessageObject("Please check that all fields are correctly filled."))
+		return
+	}
+	vars := mux.Vars(r)
+	holderRequest.CustomerId = vars["customer_id"]
+	holderRequest.AccountId = vars["account_id"]
+
+	if appErr := holderRequest.Validate(); appErr != nil {
+		writeJsonResponse(w, appErr.Code, appErr.AsMessage())
+		return
+	}
+
+	response, appErr := h.service.AddAccountHolder(holderRequest)
+	if appErr != nil {
+		writeJsonResponse(w, appErr.Code, appErr.AsMessage())
+		return
+	}
+
+	writeJsonResponse(w, http.StatusCreated, response)
+}
+
+func (h AccountHolderHandler) removeAccountHolderHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	appErr := h.service.RemoveAccountHolder(vars["customer_id"], vars["account_id"], vars["holder_customer_id"])
+	if appErr != nil {
+		writeJsonResponse(w, appErr.Code, appErr.AsMessage())
+		return
+	}
+
+	writeJsonResponse(w, http.StatusOK, errs.NewMessageObject("Account holder removed"))
+}
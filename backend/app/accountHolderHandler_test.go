@@ -0,0 +1,96 @@
+package app
+
+import (
+	"bytes"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/mocks/service"
+	"github.com/gorilla/mux"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// Test common variables and inputs
+var mockAccountHolderService *service.MockAccountHolderService
+var ahh AccountHolderHandler
+
+const accountHoldersPath = "/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}/holders"
+const dummyAccountHoldersPath = "/customers/2/account/1977/holders"
+const removeAccountHolderPath = "/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}/holders/{holder_customer_id:[0-9]+}/remove"
+const dummyRemoveAccountHolderPath = "/customers/2/account/1977/holders/3/remove"
+
+func setupAccountHolderHandlerTest(t *testing.T, path string, payload string) func() {
+	ctrl := gomock.NewController(t)
+	mockAccountHolderService = service.NewMockAccountHolderService(ctrl)
+	ahh = AccountHolderHandler{mockAccountHolderService}
+
+	router = mux.NewRouter()
+
+	recorder = httptest.NewRecorder()
+	request = httptest.NewRequest(http.MethodPost, path, bytes.NewBuffer([]byte(payload)))
+
+	return func() {
+		router = nil
+		recorder = nil
+		request = nil
+		defer ctrl.Finish()
+	}
+}
+
+func TestAccountHolderHandler_newAccountHolderHandler_respondsWith_statusCode201_when_service_succeeds(t *testing.T) {
+	//Arrange
+	teardown := setupAccountHolderHandlerTest(t, dummyAccountHoldersPath, `{"customer_id": "3", "role": "joint"}`)
+	defer teardown()
+	router.HandleFunc(accountHoldersPath, ahh.newAccountHolderHandler)
+
+	dummyRequest := dto.AccountHolderRequest{AccountId: dummyAccountId, CustomerId: dummyCustomerId,
+		HolderCustomerId: "3", Role: dto.AccountHolderRoleJoint}
+	mockAccountHolderService.EXPECT().AddAccountHolder(dummyRequest).Return(&dto.AccountHolderResponse{
+		AccountId: dummyAccountId, CustomerId: "3", Role: dto.AccountHolderRoleJoint}, nil)
+	expectedStatusCode := http.StatusCreated
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != expectedStatusCode {
+		t.Errorf("Expected status code %d but got %d", expectedStatusCode, recorder.Result().StatusCode)
+	}
+}
+
+func TestAccountHolderHandler_newAccountHolderHandler_respondsWith_errorStatusCode_when_role_primary(t *testing.T) {
+	//Arrange
+	teardown := setupAccountHolderHandlerTest(t, dummyAccountHoldersPath, `{"customer_id": "3", "role": "primary"}`)
+	defer teardown()
+	router.HandleFunc(accountHoldersPath, ahh.newAccountHolderHandler)
+
+	mockAccountHolderService.EXPECT().AddAccountHolder(gomock.Any()).Times(0)
+	expectedStatusCode := http.StatusUnprocessableEntity
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != expectedStatusCode {
+		t.Errorf("Expected status code %d but got %d", expectedStatusCode, recorder.Result().StatusCode)
+	}
+}
+
+func TestAccountHolderHandler_removeAccountHolderHandler_passes_holderCustomerId_from_route(t *testing.T) {
+	//Arrange
+	teardown := setupAccountHolderHandlerTest(t, dummyRemoveAccountHolderPath, "")
+	defer teardown()
+	router.HandleFunc(removeAccountHolderPath, ahh.removeAccountHolderHandler)
+
+	mockAccountHolderService.EXPECT().RemoveAccountHolder(dummyCustomerId, dummyAccountId, "3").Return(nil)
+	expectedStatusCode := http.StatusOK
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != expectedStatusCode {
+		t.Errorf("Expected status code %d but got %d", expectedStatusCode, recorder.Result().StatusCode)
+	}
+}
@@ -10,12 +10,18 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/jmoiron/sqlx"
 	"github.com/joho/godotenv"
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
 	"net/http"
+	"net/url"
 	"os"
+	"strconv"
 	"time"
 )
 
-func checkEnvVars() {
+// checkEnvVars checks the environment variables needed to start the app. In demo mode, the app has no database or
+// auth server to connect to, so their environment variables are not needed.
+func checkEnvVars(demo bool) {
 	val, ok := os.LookupEnv("APP_ENV")
 	if !ok {
 		logger.Fatal("Environment variable APP_ENV not defined")
@@ -25,24 +31,22 @@ func checkEnvVars() {
 		"SERVER_ADDRESS",
 		"SERVER_PORT",
 		"SERVER_DOMAIN",
-		"AUTH_SERVER_ADDRESS",
-		"AUTH_SERVER_DOMAIN",
 		"FRONTEND_SERVER_ADDRESS",
 		"FRONTEND_SERVER_DOMAIN",
-		"DB_USER",
-		"DB_PASSWORD",
-		"DB_HOST",
-		"DB_PORT",
-		"DB_NAME",
 	}
 
 	if val == "production" {
-		err := godotenv.Load(".env")
-		if err != nil {
-			logger.Fatal("Error loading .env file (needed in production mode)")
+		loadDotEnv()
+	}
+	if !demo {
+		envVars = append(envVars, "AUTH_SERVER_ADDRESS", "AUTH_SERVER_DOMAIN")
+		envVars = append(envVars, requiredDbEnvVars()...)
+	}
+	if val != "production" {
+		envVars = append(envVars, "FRONTEND_SERVER_PORT")
+		if !demo {
+			envVars = append(envVars, "AUTH_SERVER_PORT")
 		}
-	} else {
-		envVars = append(envVars, "AUTH_SERVER_PORT", "FRONTEND_SERVER_PORT")
 	}
 
 	for _, key := range envVars {
@@ -52,17 +56,82 @@ func checkEnvVars() {
 	}
 }
 
-func Start() {
-	checkEnvVars()
+var dbEnvVars = []string{
+	"DB_USER",
+	"DB_PASSWORD",
+	"DB_HOST",
+	"DB_PORT",
+	"DB_NAME",
+}
+
+// requiredDbEnvVars returns the environment variables needed to connect to the database. SQLite databases are files
+// opened in-process, so only DB_NAME (the path of the file) is needed for them.
+func requiredDbEnvVars() []string {
+	if os.Getenv("DB_DRIVER") == dbDriverSQLite {
+		return []string{"DB_NAME"}
+	}
+	return dbEnvVars
+}
+
+// checkDbEnvVars checks only the environment variables needed to connect to the database, for commands such as
+// migrate that do not start the server.
+func checkDbEnvVars() {
+	if os.Getenv("APP_ENV") == "production" {
+		loadDotEnv()
+	}
+
+	for _, key := range requiredDbEnvVars() {
+		if os.Getenv(key) == "" {
+			logger.Fatal(fmt.Sprintf("Environment variable %s was not defined", key))
+		}
+	}
+}
+
+func loadDotEnv() {
+	err := godotenv.Load(".env")
+	if err != nil {
+		logger.Fatal("Error loading .env file (needed in production mode)")
+	}
+}
+
+// Start wires up and starts the app. In demo mode, the app keeps its data in memory and verifies requests itself
+// instead of connecting to a database and the auth server.
+func Start(demo bool) {
+	checkEnvVars(demo)
 
 	router := mux.NewRouter()
 
-	dbClient := getDbClient()
+	var dbClient *sqlx.DB
+	var customerRepository domain.CustomerRepository
+	var accountRepository domain.AccountRepository
+	var authRepository domain.AuthRepository
+	var unitOfWork domain.UnitOfWork
+	if demo {
+		customerRepository, accountRepository, authRepository = getDemoRepositories()
+		unitOfWork = domain.NewUnitOfWorkStub(domain.Repositories{Accounts: accountRepository})
+	} else {
+		dbClient = getDbClient()
+		prepareSchema(dbClient)
+		customerRepository, accountRepository = getRepositories(dbClient)
+		//the auth server only knows the primary holder of each account
+		authRepository = domain.NewHolderAuthRepository(domain.NewDefaultAuthRepository(), accountRepository)
+		unitOfWork = domain.NewUnitOfWorkDb(dbClient)
+	}
 	clk := clock.RealClock{}
-	customerRepositoryDb := domain.NewCustomerRepositoryDb(dbClient)
-	accountRepositoryDb := domain.NewAccountRepositoryDb(dbClient)
-	ch := CustomerHandlers{service.NewCustomerService(customerRepositoryDb)}
-	ah := AccountHandler{service.NewAccountService(accountRepositoryDb, clk)}
+	var delegationRepository domain.DelegationRepository //has no in-memory adapter so far
+	if dbClient != nil {
+		delegationRepository = getDelegationRepository(dbClient)
+		authRepository = domain.NewDelegationAuthRepository(authRepository, delegationRepository, clk)
+	}
+	fxRateProvider := getFxRateProvider()
+	beneficiaryPolicy := getBeneficiaryPolicy()
+	watchlist := getWatchlist()
+	accountService := service.NewAccountService(accountRepository, unitOfWork, fxRateProvider, beneficiaryPolicy,
+		getScreeningPipeline(), watchlist, clk)
+	ch := CustomerHandlers{service.NewCustomerService(customerRepository, clk)}
+	ah := AccountHandler{accountService}
+	sth := StatementHandler{service.NewStatementService(accountRepository, delegationRepository, clk)}
+	ph := PaymentInitiationHandler{service.NewPaymentInitiationService(accountRepository, accountService, clk)}
 
 	router.
 		HandleFunc("/customers", ch.customersHandler).
@@ -76,6 +145,10 @@ func Start() {
 		HandleFunc("/customers/{customer_id:[0-9]+}/profile", ch.customerProfileHandler).
 		Methods(http.MethodGet, http.MethodOptions).
 		Name("GetCustomer")
+	router.
+		HandleFunc("/customers/{customer_id:[0-9]+}/status", ch.customerStatusHandler).
+		Methods(http.MethodPost, http.MethodOptions).
+		Name("SetCustomerStatus")
 	router.
 		HandleFunc("/customers/{customer_id:[0-9]+}/account/new", ah.newAccountHandler).
 		Methods(http.MethodPost, http.MethodOptions).
@@ -84,8 +157,244 @@ func Start() {
 		HandleFunc("/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}", ah.transactionHandler).
 		Methods(http.MethodPost, http.MethodOptions).
 		Name("NewTransaction")
+	router.
+		HandleFunc("/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}/overdraft", ah.overdraftHandler).
+		Methods(http.MethodPost, http.MethodOptions).
+		Name("SetOverdraftLimit")
+	router.
+		HandleFunc("/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}/transfer", ah.transferHandler).
+		Methods(http.MethodPost, http.MethodOptions).
+		Name("NewTransfer")
+	router.
+		HandleFunc("/customers/{customer_id:[0-9]+}/payments", ph.paymentInitiationHandler).
+		Methods(http.MethodPost, http.MethodOptions).
+		Name("InitiatePayments")
+	router.
+		HandleFunc("/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}/transactions", ah.transactionsHandler).
+		Methods(http.MethodGet, http.MethodOptions).
+		Name("GetTransactions")
+	router.
+		HandleFunc("/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}/statement", sth.statementHandler).
+		Methods(http.MethodGet, http.MethodOptions).
+		Name("GetStatement")
+	router.
+		HandleFunc("/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}/export", sth.exportHandler).
+		Methods(http.MethodGet, http.MethodOptions).
+		Name("ExportTransactions")
+	router.
+		HandleFunc("/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}/transactions/{transaction_id:[0-9]+}/reverse", ah.reversalHandler).
+		Methods(http.MethodPost, http.MethodOptions).
+		Name("ReverseTransaction")
+
+	eventPublishers := domain.EventPublishers{getEventPublisher()}
 
-	amw := AuthMiddleware{domain.NewDefaultAuthRepository()}
+	if dbClient != nil {
+		auditService := service.NewAuditService(getAuditRepository(dbClient), clk)
+		adh := AuditHandler{auditService}
+		aumw := AuditMiddleware{auditService, os.Getenv("APP_ENV") == "production"}
+		router.Use(aumw.AuditMiddlewareHandler) //runs before the auth middleware, so that rejected requests are recorded too
+
+		router.
+			HandleFunc("/audit", adh.auditLogHandler).
+			Methods(http.MethodGet, http.MethodOptions).
+			Name("GetAuditLog")
+		router.
+			HandleFunc("/audit/verify", adh.verifyAuditLogHandler).
+			Methods(http.MethodGet, http.MethodOptions).
+			Name("VerifyAuditLog")
+	}
+
+	//the repositories of these features have no in-memory adapters so far
+	if dbClient != nil {
+		interestService := service.NewInterestService(getInterestRepository(dbClient), unitOfWork, getInterestRates(),
+			getOverdraftCharges(), clk)
+		startJob("InterestAccrual", jobInterval, interestService.RunDailyJob)
+
+		standingOrderService := service.NewStandingOrderService(getStandingOrderRepository(dbClient), accountRepository,
+			accountService, unitOfWork, clk)
+		soh := StandingOrderHandler{standingOrderService}
+		startJob("StandingOrders", standingOrderJobInterval, standingOrderService.RunDueOrders)
+
+		holdService := service.NewHoldService(getHoldRepository(dbClient), accountRepository, delegationRepository,
+			unitOfWork, clk)
+		hh := HoldHandler{holdService}
+		startJob("HoldExpiry", holdExpiryJobInterval, holdService.ExpireHolds)
+
+		webhookService := service.NewWebhookService(getWebhookRepository(dbClient), accountRepository,
+			domain.NewWebhookSenderHttp(), clk)
+		wh := WebhookHandler{webhookService}
+		eventPublishers = append(eventPublishers, webhookService)
+		startJob("WebhookDelivery", webhookJobInterval, webhookService.DeliverDue)
+
+		sh := SearchHandler{service.NewSearchService(getSearchRepository(dbClient))}
+		ih := TransactionImportHandler{service.NewTransactionImportService(getImportRepository(dbClient),
+			accountService, unitOfWork, clk)}
+		fh := FraudCaseHandler{service.NewFraudCaseService(getScreeningRepository(dbClient), clk)}
+		sanctionsService := service.NewSanctionsService(getSanctionsRepository(dbClient), customerRepository,
+			accountService, unitOfWork, watchlist, clk)
+		sah := SanctionsHandler{sanctionsService}
+		if !watchlist.IsEmpty() {
+			startJob("SanctionsScreening", sanctionsJobInterval, sanctionsService.ScreenCustomers)
+		}
+		if dir := os.Getenv("CASH_REPORT_DIR"); dir != "" {
+			cashReportService := service.NewCashReportService(getCashReportRepository(dbClient),
+				customerRepository, domain.NewCashReportWriterFile(dir), getCashReportThreshold(), clk)
+			startJob("CashReport", jobInterval, cashReportService.RunDailyJob)
+		}
+		ahh := AccountHolderHandler{service.NewAccountHolderService(getAccountHolderRepository(dbClient),
+			accountRepository, customerRepository, clk)}
+		bh := BeneficiaryHandler{service.NewBeneficiaryService(getBeneficiaryRepository(dbClient),
+			unitOfWork, beneficiaryPolicy, clk)}
+		dh := DelegationHandler{service.NewDelegationService(delegationRepository, accountRepository,
+			customerRepository, clk)}
+
+		router.
+			HandleFunc("/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}/holds", hh.newHoldHandler).
+			Methods(http.MethodPost, http.MethodOptions).
+			Name("NewHold")
+		router.
+			HandleFunc("/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}/holds", hh.holdsHandler).
+			Methods(http.MethodGet, http.MethodOptions).
+			Name("GetHolds")
+		router.
+			HandleFunc("/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}/holds/{hold_id:[0-9]+}/capture", hh.captureHoldHandler).
+			Methods(http.MethodPost, http.MethodOptions).
+			Name("CaptureHold")
+		router.
+			HandleFunc("/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}/holds/{hold_id:[0-9]+}/release", hh.releaseHoldHandler).
+			Methods(http.MethodPost, http.MethodOptions).
+			Name("ReleaseHold")
+		router.
+			HandleFunc("/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}/standing-orders", soh.newStandingOrderHandler).
+			Methods(http.MethodPost, http.MethodOptions).
+			Name("NewStandingOrder")
+		router.
+			HandleFunc("/customers/{customer_id:[0-9]+}/standing-orders", soh.standingOrdersHandler).
+			Methods(http.MethodGet, http.MethodOptions).
+			Name("GetStandingOrders")
+		router.
+			HandleFunc("/customers/{customer_id:[0-9]+}/standing-orders/{standing_order_id:[0-9]+}/executions", soh.standingOrderExecutionsHandler).
+			Methods(http.MethodGet, http.MethodOptions).
+			Name("GetStandingOrderExecutions")
+		router.
+			HandleFunc("/customers/{customer_id:[0-9]+}/standing-orders/{standing_order_id:[0-9]+}/cancel", soh.cancelStandingOrderHandler).
+			Methods(http.MethodPost, http.MethodOptions).
+			Name("CancelStandingOrder")
+		router.
+			HandleFunc("/customers/{customer_id:[0-9]+}/webhooks", wh.newWebhookHandler).
+			Methods(http.MethodPost, http.MethodOptions).
+			Name("NewWebhook")
+		router.
+			HandleFunc("/customers/{customer_id:[0-9]+}/webhooks", wh.webhooksHandler).
+			Methods(http.MethodGet, http.MethodOptions).
+			Name("GetWebhooks")
+		router.
+			HandleFunc("/customers/{customer_id:[0-9]+}/webhooks/{webhook_id:[0-9]+}/disable", wh.disableWebhookHandler).
+			Methods(http.MethodPost, http.MethodOptions).
+			Name("DisableWebhook")
+		router.
+			HandleFunc("/customers/{customer_id:[0-9]+}/webhooks/{webhook_id:[0-9]+}/deliveries", wh.webhookDeliveriesHandler).
+			Methods(http.MethodGet, http.MethodOptions).
+			Name("GetWebhookDeliveries")
+		router.
+			HandleFunc("/customers/{customer_id:[0-9]+}/webhooks/{webhook_id:[0-9]+}/deliveries/{delivery_id:[0-9]+}/replay", wh.replayDeliveryHandler).
+			Methods(http.MethodPost, http.MethodOptions).
+			Name("ReplayWebhookDelivery")
+		router.
+			HandleFunc("/customers/search", sh.customerSearchHandler).
+			Methods(http.MethodGet, http.MethodOptions).
+			Name("SearchCustomers")
+		router.
+			HandleFunc("/accounts/search", sh.accountSearchHandler).
+			Methods(http.MethodGet, http.MethodOptions).
+			Name("SearchAccounts")
+		router.
+			HandleFunc("/transactions/import", ih.importHandler).
+			Methods(http.MethodPost, http.MethodOptions).
+			Name("ImportTransactions")
+		router.
+			HandleFunc("/customers/{customer_id:[0-9]+}/beneficiaries", bh.newBeneficiaryHandler).
+			Methods(http.MethodPost, http.MethodOptions).
+			Name("NewBeneficiary")
+		router.
+			HandleFunc("/customers/{customer_id:[0-9]+}/beneficiaries", bh.beneficiariesHandler).
+			Methods(http.MethodGet, http.MethodOptions).
+			Name("GetBeneficiaries")
+		router.
+			HandleFunc("/customers/{customer_id:[0-9]+}/beneficiaries/{beneficiary_id:[0-9]+}", bh.updateBeneficiaryHandler).
+			Methods(http.MethodPost, http.MethodOptions).
+			Name("UpdateBeneficiary")
+		router.
+			HandleFunc("/customers/{customer_id:[0-9]+}/beneficiaries/{beneficiary_id:[0-9]+}/delete", bh.deleteBeneficiaryHandler).
+			Methods(http.MethodPost, http.MethodOptions).
+			Name("DeleteBeneficiary")
+		router.
+			HandleFunc("/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}/holders", ahh.accountHoldersHandler).
+			Methods(http.MethodGet, http.MethodOptions).
+			Name("GetAccountHolders")
+		router.
+			HandleFunc("/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}/holders", ahh.newAccountHolderHandler).
+			Methods(http.MethodPost, http.MethodOptions).
+			Name("AddAccountHolder")
+		router.
+			HandleFunc("/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}/holders/{holder_customer_id:[0-9]+}/remove", ahh.removeAccountHolderHandler).
+			Methods(http.MethodPost, http.MethodOptions).
+			Name("RemoveAccountHolder")
+		router.
+			HandleFunc("/customers/{customer_id:[0-9]+}/delegations", dh.receivedDelegationsHandler).
+			Methods(http.MethodGet, http.MethodOptions).
+			Name("GetReceivedDelegations")
+		router.
+			HandleFunc("/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}/delegations", dh.delegationsHandler).
+			Methods(http.MethodGet, http.MethodOptions).
+			Name("GetDelegations")
+		router.
+			HandleFunc("/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}/delegations", dh.newDelegationHandler).
+			Methods(http.MethodPost, http.MethodOptions).
+			Name("GrantDelegation")
+		router.
+			HandleFunc("/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}/delegations/{delegation_id:[0-9]+}/revoke", dh.revokeDelegationHandler).
+			Methods(http.MethodPost, http.MethodOptions).
+			Name("RevokeDelegation")
+		router.
+			HandleFunc("/fraud-cases", fh.fraudCasesHandler).
+			Methods(http.MethodGet, http.MethodOptions).
+			Name("GetFraudCases")
+		router.
+			HandleFunc("/fraud-cases/{case_id:[0-9]+}", fh.fraudCaseHandler).
+			Methods(http.MethodGet, http.MethodOptions).
+			Name("GetFraudCase")
+		router.
+			HandleFunc("/fraud-cases/{case_id:[0-9]+}/resolve", fh.resolveFraudCaseHandler).
+			Methods(http.MethodPost, http.MethodOptions).
+			Name("ResolveFraudCase")
+		router.
+			HandleFunc("/customers/{customer_id:[0-9]+}/screen", sah.screenCustomerHandler).
+			Methods(http.MethodPost, http.MethodOptions).
+			Name("ScreenCustomer")
+		router.
+			HandleFunc("/sanctions-hits", sah.sanctionsHitsHandler).
+			Methods(http.MethodGet, http.MethodOptions).
+			Name("GetSanctionsHits")
+		router.
+			HandleFunc("/sanctions-hits/{hit_id:[0-9]+}", sah.sanctionsHitHandler).
+			Methods(http.MethodGet, http.MethodOptions).
+			Name("GetSanctionsHit")
+		router.
+			HandleFunc("/sanctions-hits/{hit_id:[0-9]+}/resolve", sah.resolveSanctionsHitHandler).
+			Methods(http.MethodPost, http.MethodOptions).
+			Name("ResolveSanctionsHit")
+	} else {
+		logger.Info("Interest, standing orders, holds, webhooks, the audit log, search, imports, beneficiaries, account holder management, delegations, fraud screening, sanctions screening and cash reports are not available in demo mode")
+	}
+
+	//events are only written to the outbox by the database adapters, so there is nothing to publish in demo mode
+	if dbClient != nil {
+		relayService := service.NewEventRelayService(getOutboxRepository(dbClient), eventPublishers, clk)
+		startJob("OutboxRelay", outboxRelayInterval, relayService.PublishPending)
+	}
+
+	amw := AuthMiddleware{authRepository}
 	router.Use(amw.AuthMiddlewareHandler)
 
 	address := os.Getenv("SERVER_ADDRESS")
@@ -106,6 +415,18 @@ func Start() {
 	}
 }
 
+const dbDriverMySQL = "mysql"
+const dbDriverPostgres = "postgres"
+const dbDriverSQLite = "sqlite3"
+
+// sqliteOptions turns on foreign key checks, which SQLite leaves off by default, and makes a connection wait for
+// another connection's write to finish instead of failing straight away.
+const sqliteOptions = "?_foreign_keys=on&_busy_timeout=5000&_journal_mode=WAL"
+
+// getDbClient connects to the database using the driver named by the optional DB_DRIVER environment variable, which
+// is either "mysql" (the default), "postgres" or "sqlite3". For PostgreSQL, the optional DB_SSLMODE environment
+// variable sets the SSL mode of the connection ("disable" by default). For SQLite, DB_NAME is the path of the
+// database file, which is created if it does not exist.
 func getDbClient() *sqlx.DB {
 	dbUser := os.Getenv("DB_USER")
 	dbPassword := os.Getenv("DB_PASSWORD")
@@ -113,20 +434,315 @@ func getDbClient() *sqlx.DB {
 	dbPort := os.Getenv("DB_PORT")
 	dbName := os.Getenv("DB_NAME")
 
-	dataSource := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s", dbUser, dbPassword, dbHost, dbPort, dbName)
-	db, err := sqlx.Open("mysql", dataSource)
+	driver := os.Getenv("DB_DRIVER")
+	var dataSource string
+	switch driver {
+	case "", dbDriverMySQL:
+		driver = dbDriverMySQL
+		dataSource = fmt.Sprintf("%s:%s@tcp(%s:%s)/%s", dbUser, dbPassword, dbHost, dbPort, dbName)
+	case dbDriverPostgres:
+		sslMode := os.Getenv("DB_SSLMODE")
+		if sslMode == "" {
+			sslMode = "disable"
+		}
+		dataSource = fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=%s",
+			url.PathEscape(dbUser), url.PathEscape(dbPassword), dbHost, dbPort, dbName, sslMode)
+	case dbDriverSQLite:
+		dataSource = dbName + sqliteOptions
+	default:
+		logger.Fatal(fmt.Sprintf("Database driver %s is not supported (should be %s, %s or %s)",
+			driver, dbDriverMySQL, dbDriverPostgres, dbDriverSQLite))
+	}
+
+	db, err := sqlx.Open(driver, dataSource)
 	if err != nil {
 		logger.Fatal("Error while opening connection to database: " + err.Error())
 	}
 	db.SetConnMaxLifetime(time.Minute * 3)
 	db.SetMaxOpenConns(10)
 	db.SetMaxIdleConns(10)
+	if driver == dbDriverSQLite { //SQLite only allows one writer at a time
+		db.SetMaxOpenConns(1)
+	}
 
 	return db
 }
 
+// getOutboxRepository returns the outbox repository adapter for the driver of the given database handle, which, like
+// the other MySQL adapters, is also used for SQLite.
+func getOutboxRepository(dbClient *sqlx.DB) domain.OutboxRepository {
+	if dbClient.DriverName() == dbDriverPostgres {
+		return domain.NewOutboxRepositoryPostgres(dbClient)
+	}
+	return domain.NewOutboxRepositoryDb(dbClient)
+}
+
+// getAuditRepository returns the audit repository adapter for the driver of the given database handle.
+func getAuditRepository(dbClient *sqlx.DB) domain.AuditRepository {
+	if dbClient.DriverName() == dbDriverPostgres {
+		return domain.NewAuditRepositoryPostgres(dbClient)
+	}
+	return domain.NewAuditRepositoryDb(dbClient)
+}
+
+// getInterestRepository returns the interest repository adapter for the driver of the given database handle.
+func getInterestRepository(dbClient *sqlx.DB) domain.InterestRepository {
+	if dbClient.DriverName() == dbDriverPostgres {
+		return domain.NewInterestRepositoryPostgres(dbClient)
+	}
+	return domain.NewInterestRepositoryDb(dbClient)
+}
+
+// getStandingOrderRepository returns the standing order repository adapter for the driver of the given database handle.
+func getStandingOrderRepository(dbClient *sqlx.DB) domain.StandingOrderRepository {
+	if dbClient.DriverName() == dbDriverPostgres {
+		return domain.NewStandingOrderRepositoryPostgres(dbClient)
+	}
+	return domain.NewStandingOrderRepositoryDb(dbClient)
+}
+
+// getHoldRepository returns the hold repository adapter for the driver of the given database handle.
+func getHoldRepository(dbClient *sqlx.DB) domain.HoldRepository {
+	if dbClient.DriverName() == dbDriverPostgres {
+		return domain.NewHoldRepositoryPostgres(dbClient)
+	}
+	return domain.NewHoldRepositoryDb(dbClient)
+}
+
+// getWebhookRepository returns the webhook repository adapter for the driver of the given database handle.
+func getWebhookRepository(dbClient *sqlx.DB) domain.WebhookRepository {
+	if dbClient.DriverName() == dbDriverPostgres {
+		return domain.NewWebhookRepositoryPostgres(dbClient)
+	}
+	return domain.NewWebhookRepositoryDb(dbClient)
+}
+
+// getSearchRepository returns the search repository adapter for the driver of the given database handle.
+func getSearchRepository(dbClient *sqlx.DB) domain.SearchRepository {
+	if dbClient.DriverName() == dbDriverPostgres {
+		return domain.NewSearchRepositoryPostgres(dbClient)
+	}
+	return domain.NewSearchRepositoryDb(dbClient)
+}
+
+// getImportRepository returns the import repository adapter for the driver of the given database handle.
+func getImportRepository(dbClient *sqlx.DB) domain.ImportRepository {
+	if dbClient.DriverName() == dbDriverPostgres {
+		return domain.NewImportRepositoryPostgres(dbClient)
+	}
+	return domain.NewImportRepositoryDb(dbClient)
+}
+
+// getScreeningRepository returns the screening repository adapter for the driver of the given database handle.
+func getScreeningRepository(dbClient *sqlx.DB) domain.ScreeningRepository {
+	if dbClient.DriverName() == dbDriverPostgres {
+		return domain.NewScreeningRepositoryPostgres(dbClient)
+	}
+	return domain.NewScreeningRepositoryDb(dbClient)
+}
+
+// getSanctionsRepository returns the sanctions repository adapter for the driver of the given database handle.
+func getSanctionsRepository(dbClient *sqlx.DB) domain.SanctionsRepository {
+	if dbClient.DriverName() == dbDriverPostgres {
+		return domain.NewSanctionsRepositoryPostgres(dbClient)
+	}
+	return domain.NewSanctionsRepositoryDb(dbClient)
+}
+
+// getCashReportRepository returns the cash report repository adapter for the driver of the given database handle.
+func getCashReportRepository(dbClient *sqlx.DB) domain.CashReportRepository {
+	if dbClient.DriverName() == dbDriverPostgres {
+		return domain.NewCashReportRepositoryPostgres(dbClient)
+	}
+	return domain.NewCashReportRepositoryDb(dbClient)
+}
+
+// getAccountHolderRepository returns the account holder repository adapter for the driver of the given database handle.
+func getAccountHolderRepository(dbClient *sqlx.DB) domain.AccountHolderRepository {
+	if dbClient.DriverName() == dbDriverPostgres {
+		return domain.NewAccountHolderRepositoryPostgres(dbClient)
+	}
+	return domain.NewAccountHolderRepositoryDb(dbClient)
+}
+
+// getBeneficiaryRepository returns the beneficiary repository adapter for the driver of the given database handle.
+func getBeneficiaryRepository(dbClient *sqlx.DB) domain.BeneficiaryRepository {
+	if dbClient.DriverName() == dbDriverPostgres {
+		return domain.NewBeneficiaryRepositoryPostgres(dbClient)
+	}
+	return domain.NewBeneficiaryRepositoryDb(dbClient)
+}
+
+// getDelegationRepository returns the delegation repository adapter for the driver of the given database handle.
+func getDelegationRepository(dbClient *sqlx.DB) domain.DelegationRepository {
+	if dbClient.DriverName() == dbDriverPostgres {
+		return domain.NewDelegationRepositoryPostgres(dbClient)
+	}
+	return domain.NewDelegationRepositoryDb(dbClient)
+}
+
+// getEventPublisher returns the publisher of the events in the outbox. Events are written as lines of JSON to the file
+// named by the optional environment variable EVENTS_FILE, or to standard output if it is not set.
+func getEventPublisher() domain.EventPublisher {
+	fileName := os.Getenv("EVENTS_FILE")
+	if fileName == "" {
+		return domain.NewEventPublisherFile(os.Stdout)
+	}
+	file, err := os.OpenFile(fileName, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		logger.Fatal("Error while opening events file: " + err.Error())
+	}
+	return domain.NewEventPublisherFile(file)
+}
+
+// getRepositories returns the customer and account repository adapters for the driver of the given database handle.
+// The MySQL adapters only use SQL that SQLite also understands, so they are used for SQLite as well.
+func getRepositories(dbClient *sqlx.DB) (domain.CustomerRepository, domain.AccountRepository) {
+	if dbClient.DriverName() == dbDriverPostgres {
+		return domain.NewCustomerRepositoryPostgres(dbClient), domain.NewAccountRepositoryPostgres(dbClient)
+	}
+	return domain.NewCustomerRepositoryDb(dbClient), domain.NewAccountRepositoryDb(dbClient)
+}
+
+// getInterestRates loads the interest rate tiers from the JSON file named by the optional INTEREST_RATES_FILE
+// environment variable, falling back to the default rates if it is not set.
+func getInterestRates() domain.InterestRates {
+	path := os.Getenv("INTEREST_RATES_FILE")
+	if path == "" {
+		return domain.DefaultInterestRates()
+	}
+
+	rates, err := domain.LoadInterestRates(path)
+	if err != nil {
+		logger.Fatal("Error while loading interest rates file: " + err.Error())
+	}
+	return rates
+}
+
+// getFxRateProvider loads the exchange rates used for cross-currency transfers from the JSON file named by the
+// optional FX_RATES_FILE environment variable (see build/package/fx/rates.json). If it is not set, only transfers
+// between accounts in the same currency can be made.
+func getFxRateProvider() domain.FxRateProviderFile {
+	provider, err := domain.NewFxRateProviderFile(os.Getenv("FX_RATES_FILE"))
+	if err != nil {
+		logger.Fatal("Error while loading exchange rates file: " + err.Error())
+	}
+	return provider
+}
+
+// getOverdraftCharges reads the annual overdraft interest rate and monthly overdraft fee from the optional
+// OVERDRAFT_ANNUAL_RATE and OVERDRAFT_MONTHLY_FEE environment variables, falling back to the default charges for
+// any that are not set.
+func getOverdraftCharges() domain.OverdraftCharges {
+	charges := domain.DefaultOverdraftCharges()
+
+	if val := os.Getenv("OVERDRAFT_ANNUAL_RATE"); val != "" {
+		rate, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			logger.Fatal("Environment variable OVERDRAFT_ANNUAL_RATE is not a number")
+		}
+		charges.AnnualRate = rate
+	}
+	if val := os.Getenv("OVERDRAFT_MONTHLY_FEE"); val != "" {
+		fee, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			logger.Fatal("Environment variable OVERDRAFT_MONTHLY_FEE is not a number")
+		}
+		charges.MonthlyFee = fee
+	}
+
+	return charges
+}
+
+// getBeneficiaryPolicy reads the cooling-off period of new beneficiaries (in hours) and the limit of the first
+// transfer to them from the optional BENEFICIARY_COOLING_OFF_HOURS and BENEFICIARY_FIRST_TRANSFER_LIMIT environment
+// variables, falling back to the default policy for any that are not set.
+func getBeneficiaryPolicy() domain.BeneficiaryPolicy {
+	policy := domain.DefaultBeneficiaryPolicy()
+
+	if val := os.Getenv("BENEFICIARY_COOLING_OFF_HOURS"); val != "" {
+		hours, err := strconv.ParseFloat(val, 64)
+		if err != nil || hours < 0 {
+			logger.Fatal("Environment variable BENEFICIARY_COOLING_OFF_HOURS is not a number of hours")
+		}
+		policy.CoolingOffPeriod = time.Duration(hours * float64(time.Hour))
+	}
+	if val := os.Getenv("BENEFICIARY_FIRST_TRANSFER_LIMIT"); val != "" {
+		limit, err := strconv.ParseFloat(val, 64)
+		if err != nil || limit <= 0 {
+			logger.Fatal("Environment variable BENEFICIARY_FIRST_TRANSFER_LIMIT is not a positive number")
+		}
+		policy.FirstTransferLimit = limit
+	}
+
+	return policy
+}
+
+// getScreeningPipeline loads the fraud screening rules from the JSON file named by the optional FRAUD_RULES_FILE
+// environment variable (see build/package/fraud/rules.json), falling back to the default rules if it is not set.
+func getScreeningPipeline() domain.ScreeningPipeline {
+	path := os.Getenv("FRAUD_RULES_FILE")
+	if path == "" {
+		return domain.DefaultScreeningPipeline()
+	}
+
+	pipeline, err := domain.LoadScreeningPipeline(path)
+	if err != nil {
+		logger.Fatal("Error while loading fraud rules file: " + err.Error())
+	}
+	return pipeline
+}
+
+// getWatchlist loads the sanctions watchlist from the CSV or XML file named by the optional SANCTIONS_LIST_FILE
+// environment variable (see build/package/sanctions), with the name thresholds read from the optional
+// SANCTIONS_NAME_THRESHOLD and SANCTIONS_STRONG_NAME_THRESHOLD environment variables, falling back to the default
+// thresholds for any that are not set. If no file is given, customers and transfers are not screened.
+func getWatchlist() domain.Watchlist {
+	thresholds := domain.DefaultSanctionsThresholds()
+	if val := os.Getenv("SANCTIONS_NAME_THRESHOLD"); val != "" {
+		threshold, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			logger.Fatal("Environment variable SANCTIONS_NAME_THRESHOLD is not a number")
+		}
+		thresholds.Name = threshold
+	}
+	if val := os.Getenv("SANCTIONS_STRONG_NAME_THRESHOLD"); val != "" {
+		threshold, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			logger.Fatal("Environment variable SANCTIONS_STRONG_NAME_THRESHOLD is not a number")
+		}
+		thresholds.StrongName = threshold
+	}
+
+	path := os.Getenv("SANCTIONS_LIST_FILE")
+	if path == "" {
+		return domain.Watchlist{Thresholds: thresholds}
+	}
+	watchlist, err := domain.LoadWatchlist(path, thresholds)
+	if err != nil {
+		logger.Fatal("Error while loading sanctions watchlist file: " + err.Error())
+	}
+	return watchlist
+}
+
+// getCashReportThreshold reads the amount above which cash transactions are reported from the optional
+// CASH_REPORT_THRESHOLD environment variable, falling back to the default threshold if it is not set.
+func getCashReportThreshold() float64 {
+	val := os.Getenv("CASH_REPORT_THRESHOLD")
+	if val == "" {
+		return domain.DefaultCashReportThreshold
+	}
+
+	threshold, err := strconv.ParseFloat(val, 64)
+	if err != nil || threshold <= 0 {
+		logger.Fatal("Environment variable CASH_REPORT_THRESHOLD is not a positive number")
+	}
+	return threshold
+}
+
 //Notes
 //once the app is started, check that environment variables required for the app to function have been set
+//and that the database schema has been migrated to the version the app expects
 
 //create custom multiplexer/handler using mux package
 
@@ -142,5 +758,7 @@ func getDbClient() *sqlx.DB {
 
 //introduce middleware
 
+//start background jobs (e.g. interest accrual) that run periodically alongside the server
+
 //start and run server
 //listen on localhost and pass multiplexer to Serve()
@@ -0,0 +1,41 @@
+package app
+
+import (
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/service"
+	"net/http"
+)
+
+type AuditHandler struct {
+	service service.AuditService
+}
+
+func (h AuditHandler) auditLogHandler(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	auditLogRequest := dto.AuditLogRequest{
+		CustomerId: q.Get("customer_id"),
+		RouteName:  q.Get("route_name"),
+		From:       q.Get("from"),
+		To:         q.Get("to"),
+		AfterId:    q.Get("after_id"),
+		Limit:      q.Get("limit"),
+	}
+
+	response, appErr := h.service.GetAuditLog(auditLogRequest)
+	if appErr != nil {
+		writeJsonResponse(w, appErr.Code, appErr.AsMessage())
+		return
+	}
+
+	writeJsonResponse(w, http.StatusOK, response)
+}
+
+func (h AuditHandler) verifyAuditLogHandler(w http.ResponseWriter, r *http.Request) {
+	response, appErr := h.service.VerifyAuditLog()
+	if appErr != nil {
+		writeJsonResponse(w, appErr.Code, appErr.AsMessage())
+		return
+	}
+
+	writeJsonResponse(w, http.StatusOK, response)
+}
@@ -0,0 +1,104 @@
+package app
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/service"
+	"github.com/gorilla/mux"
+	"hash"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// actorContextKey is the key of the request context value through which the auth middleware tells the audit
+// middleware who made the request.
+type actorContextKey struct{}
+
+type AuditMiddleware struct {
+	service    service.AuditService
+	trustProxy bool //whether to take the client's IP address from the X-Forwarded-For header set by a proxy
+}
+
+// AuditMiddlewareHandler is a middleware that records every POST request in the audit log once it has been handled,
+// including requests that the auth middleware rejected. It should run before the auth middleware, which fills in the
+// actor of authorized requests. The request body is hashed as it is read, so that large bodies are not held in memory.
+// Failing to record a request is logged but does not change the response, which has already been sent.
+func (m AuditMiddleware) AuditMiddlewareHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		body := &hashingBody{ReadCloser: r.Body, hash: sha256.New()}
+		r.Body = body
+		actor := &domain.Actor{}
+		r = r.WithContext(context.WithValue(r.Context(), actorContextKey{}, actor))
+		recorder := &statusRecorder{ResponseWriter: w, statusCode: http.StatusOK}
+
+		next.ServeHTTP(recorder, r)
+
+		if _, err := io.Copy(io.Discard, body); err != nil { //the hash covers the whole body, even the unread part
+			logger.Error("Error while reading rest of request body for audit log: " + err.Error())
+		}
+		entry := domain.NewAuditEntry(*actor, mux.CurrentRoute(r).GetName(), mux.Vars(r),
+			hex.EncodeToString(body.hash.Sum(nil)), recorder.statusCode, m.clientIp(r))
+		if appErr := m.service.Record(entry); appErr != nil {
+			logger.Error("Error while recording request in audit log: " + appErr.Message)
+		}
+	})
+}
+
+// clientIp returns the IP address of the client that made the given request. Behind a proxy, such as Render's, it
+// is the first address in the X-Forwarded-For header, which cannot be trusted without one.
+func (m AuditMiddleware) clientIp(r *http.Request) string {
+	if forwarded := r.Header.Get("X-Forwarded-For"); m.trustProxy && forwarded != "" {
+		first, _, _ := strings.Cut(forwarded, ",")
+		return strings.TrimSpace(first)
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// hashingBody is a request body that hashes what is read from it.
+type hashingBody struct {
+	io.ReadCloser
+	hash hash.Hash
+}
+
+func (b *hashingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.hash.Write(p[:n])
+	return n, err
+}
+
+// statusRecorder is a response writer that notes the status code of the response.
+type statusRecorder struct {
+	http.ResponseWriter
+	statusCode  int
+	wroteHeader bool
+}
+
+func (s *statusRecorder) WriteHeader(statusCode int) {
+	if !s.wroteHeader {
+		s.statusCode = statusCode
+		s.wroteHeader = true
+	}
+	s.ResponseWriter.WriteHeader(statusCode)
+}
+
+// Flush sends any buffered data to the client, so that streamed responses such as import reports still work behind
+// the audit middleware.
+func (s *statusRecorder) Flush() {
+	if flusher, ok := s.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,113 @@
+package app
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	realDomain "github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/mocks/domain"
+	"github.com/aliciatay-zls/banking/backend/mocks/service"
+	"github.com/gorilla/mux"
+	"go.uber.org/mock/gomock"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// Test common variables and inputs
+var mockAuditService *service.MockAuditService
+
+func setupAuditMiddlewareTest(t *testing.T, method string, trustProxy bool) func() {
+	ctrl := gomock.NewController(t)
+	mockAuditService = service.NewMockAuditService(ctrl)
+	mockAuthRepo = domain.NewMockAuthRepository(ctrl)
+
+	router = mux.NewRouter()
+	router.HandleFunc(newTransactionPath, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.ReadAll(io.LimitReader(r.Body, 5)) //leaves part of the body unread
+		w.WriteHeader(http.StatusCreated)
+	}).Name("NewTransaction")
+	aumw := AuditMiddleware{mockAuditService, trustProxy}
+	router.Use(aumw.AuditMiddlewareHandler)
+	router.Use(AuthMiddleware{mockAuthRepo}.AuthMiddlewareHandler)
+
+	recorder = httptest.NewRecorder()
+	request = httptest.NewRequest(method, dummyNewTransactionPath, bytes.NewBuffer([]byte(dummyNewTransactionPayload)))
+	request.Header.Add("Authorization", dummyToken)
+	request.RemoteAddr = "192.0.2.1:54321"
+
+	return func() {
+		router = nil
+		recorder = nil
+		request = nil
+		defer ctrl.Finish()
+	}
+}
+
+func TestAuditMiddleware_AuditMiddlewareHandler_records_authorized_request(t *testing.T) {
+	//Arrange
+	teardown := setupAuditMiddlewareTest(t, http.MethodPost, false)
+	defer teardown()
+
+	routeVars := map[string]string{"customer_id": dummyCustomerId, "account_id": dummyAccountId}
+	actor := realDomain.Actor{Role: realDomain.RoleUser, CustomerId: dummyCustomerId}
+	bodyHash := sha256.Sum256([]byte(dummyNewTransactionPayload))
+	expectedEntry := realDomain.NewAuditEntry(actor, "NewTransaction", routeVars, hex.EncodeToString(bodyHash[:]),
+		http.StatusCreated, "192.0.2.1")
+	mockAuthRepo.EXPECT().IsAuthorized(dummyToken, "NewTransaction", routeVars).Return(&actor, nil)
+	mockAuditService.EXPECT().Record(expectedEntry).Return(nil)
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != http.StatusCreated {
+		t.Errorf("Expected status code %d but got %d", http.StatusCreated, recorder.Result().StatusCode)
+	}
+}
+
+func TestAuditMiddleware_AuditMiddlewareHandler_records_rejected_request_without_actor(t *testing.T) {
+	//Arrange
+	teardown := setupAuditMiddlewareTest(t, http.MethodPost, true)
+	defer teardown()
+
+	request.Header.Add("X-Forwarded-For", "203.0.113.7, 10.0.0.1")
+	dummyAppErr := errs.NewAuthorizationError("Access denied")
+	mockAuthRepo.EXPECT().IsAuthorized(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, dummyAppErr)
+	mockAuditService.EXPECT().Record(gomock.Any()).DoAndReturn(func(entry realDomain.AuditEntry) *errs.AppError {
+		if entry.ActorRole != "" || entry.StatusCode != http.StatusForbidden || entry.Outcome != realDomain.AuditOutcomeFailure {
+			t.Errorf("Expected failed request without actor but got %+v", entry)
+		}
+		if entry.IpAddress != "203.0.113.7" {
+			t.Errorf("Expected IP address of client behind proxy but got %s", entry.IpAddress)
+		}
+		return nil
+	})
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != http.StatusForbidden {
+		t.Errorf("Expected status code %d but got %d", http.StatusForbidden, recorder.Result().StatusCode)
+	}
+}
+
+func TestAuditMiddleware_AuditMiddlewareHandler_skips_getRequests(t *testing.T) {
+	//Arrange
+	teardown := setupAuditMiddlewareTest(t, http.MethodGet, false)
+	defer teardown()
+
+	mockAuthRepo.EXPECT().IsAuthorized(gomock.Any(), gomock.Any(), gomock.Any()).Return(&realDomain.Actor{}, nil)
+	mockAuditService.EXPECT().Record(gomock.Any()).Times(0)
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != http.StatusCreated {
+		t.Errorf("Expected status code %d but got %d", http.StatusCreated, recorder.Result().StatusCode)
+	}
+}
@@ -1,6 +1,7 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	"github.com/aliciatay-zls/banking-lib/errs"
 	"github.com/aliciatay-zls/banking-lib/logger"
@@ -10,6 +11,10 @@ import (
 	"os"
 )
 
+// authorizedActorContextKey is the key of the request context value through which the auth middleware tells route
+// handlers who made the request.
+type authorizedActorContextKey struct{}
+
 type AuthMiddleware struct {
 	repo domain.AuthRepository //middleware handler has dependency on repo (server side) directly, skipped service
 }
@@ -36,15 +41,29 @@ func (m AuthMiddleware) AuthMiddlewareHandler(next http.Handler) http.Handler {
 		routeName := mux.CurrentRoute(r).GetName()
 		routeVars := mux.Vars(r)
 
-		if appErr := m.repo.IsAuthorized(tokenString, routeName, routeVars); appErr != nil {
+		actor, appErr := m.repo.IsAuthorized(tokenString, routeName, routeVars)
+		if appErr != nil {
 			writeJsonResponse(w, appErr.Code, appErr.AsMessage())
 			return
 		}
+		if auditedActor, ok := r.Context().Value(actorContextKey{}).(*domain.Actor); ok && actor != nil {
+			*auditedActor = *actor //tells the audit middleware who made the request
+		}
+		if actor != nil {
+			r = r.WithContext(context.WithValue(r.Context(), authorizedActorContextKey{}, *actor))
+		}
 
 		next.ServeHTTP(w, r)
 	})
 }
 
+// isAdminRequest checks whether the given request was made by an admin, as far as the auth server told the auth
+// middleware.
+func isAdminRequest(r *http.Request) bool {
+	actor, ok := r.Context().Value(authorizedActorContextKey{}).(domain.Actor)
+	return ok && actor.Role == domain.RoleAdmin
+}
+
 func enableCORS(w http.ResponseWriter) {
 	w.Header().Add("Access-Control-Allow-Origin",
 		fmt.Sprintf("https://%s", os.Getenv("FRONTEND_SERVER_DOMAIN")))
@@ -3,6 +3,7 @@ package app
 import (
 	"github.com/aliciatay-zls/banking-lib/errs"
 	"github.com/aliciatay-zls/banking-lib/logger"
+	realDomain "github.com/aliciatay-zls/banking/backend/domain"
 	"github.com/aliciatay-zls/banking/backend/mocks/domain"
 	"github.com/gorilla/mux"
 	"go.uber.org/mock/gomock"
@@ -121,7 +122,7 @@ func TestAuthMiddleware_AuthMiddlewareHandler_respondsWith_errorStatusCode_when_
 	//dummyErrStatusCode := http.StatusForbidden
 	//dummyErrMessage := "some error message"
 	dummyAppErr := errs.NewAppError(http.StatusForbidden, "some error message")
-	mockAuthRepo.EXPECT().IsAuthorized(dummyToken, dummyRouteName, dummyRouteVars).Return(dummyAppErr)
+	mockAuthRepo.EXPECT().IsAuthorized(dummyToken, dummyRouteName, dummyRouteVars).Return(nil, dummyAppErr)
 
 	//Act
 	router.ServeHTTP(recorder, request)
@@ -141,7 +142,7 @@ func TestAuthMiddleware_AuthMiddlewareHandler_runsNextHandlerFunc_when_repo_succ
 	teardownAll := setupAuthMiddlewareTest(t, true)
 	defer teardownAll()
 
-	mockAuthRepo.EXPECT().IsAuthorized(dummyToken, dummyRouteName, dummyRouteVars).Return(nil)
+	mockAuthRepo.EXPECT().IsAuthorized(dummyToken, dummyRouteName, dummyRouteVars).Return(&realDomain.Actor{}, nil)
 
 	//Act
 	router.ServeHTTP(recorder, request)
@@ -0,0 +1,88 @@
+package app
+
+import (
+	"encoding/json"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/service"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+type BeneficiaryHandler struct {
+	service service.BeneficiaryService
+}
+
+func (h BeneficiaryHandler) newBeneficiaryHandler(w http.ResponseWriter, r *http.Request) {
+	beneficiaryRequest, ok := decodeBeneficiaryRequest(w, r)
+	if !ok {
+		return
+	}
+
+	response, appErr := h.service.AddBeneficiary(beneficiaryRequest)
+	if appErr != nil {
+		writeJsonResponse(w, appErr.Code, appErr.AsMessage())
+		return
+	}
+
+	writeJsonResponse(w, http.StatusCreated, response)
+}
+
+func (h BeneficiaryHandler) beneficiariesHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	response, appErr := h.service.GetBeneficiaries(vars["customer_id"])
+	if appErr != nil {
+		writeJsonResponse(w, appErr.Code, appErr.AsMessage())
+		return
+	}
+
+	writeJsonResponse(w, http.StatusOK, response)
+}
+
+func (h BeneficiaryHandler) updateBeneficiaryHandler(w http.ResponseWriter, r *http.Request) {
+	beneficiaryRequest, ok := decodeBeneficiaryRequest(w, r)
+	if !ok {
+		return
+	}
+
+	response, appErr := h.service.UpdateBeneficiary(beneficiaryRequest)
+	if appErr != nil {
+		writeJsonResponse(w, appErr.Code, appErr.AsMessage())
+		return
+	}
+
+	writeJsonResponse(w, http.StatusOK, response)
+}
+
+func (h BeneficiaryHandler) deleteBeneficiaryHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	if appErr := h.service.DeleteBeneficiary(vars["customer_id"], vars["beneficiary_id"]); appErr != nil {
+		writeJsonResponse(w, appErr.Code, appErr.AsMessage())
+		return
+	}
+
+	writeJsonResponse(w, http.StatusOK, errs.NewMessageObject("Beneficiary deleted"))
+}
+
+// decodeBeneficiaryRequest reads and validates the beneficiary in the request body, responding with an error if it
+// is invalid. The customer and beneficiary IDs are taken from the route.
+func decodeBeneficiaryRequest(w http.ResponseWriter, r *http.Request) (dto.BeneficiaryRequest, bool) {
+	var beneficiaryRequest dto.BeneficiaryRequest
+	if err := json.NewDecoder(r.Body).Decode(&beneficiaryRequest); err != nil {
+		logger.Error("Error while decoding json body of beneficiary request: " + err.Error())
+		writeJsonResponse(w, http.StatusBadRequest, errs.NewMessageObject("Please check that all fields are correctly filled."))
+		return beneficiaryRequest, false
+	}
+	vars := mux.Vars(r)
+	beneficiaryRequest.CustomerId = vars["customer_id"]
+	beneficiaryRequest.BeneficiaryId = vars["beneficiary_id"]
+
+	if appErr := beneficiaryRequest.Validate(); appErr != nil {
+		writeJsonResponse(w, appErr.Code, appErr.AsMessage())
+		return beneficiaryRequest, false
+	}
+	return beneficiaryRequest, true
+}
@@ -0,0 +1,97 @@
+package app
+
+import (
+	"bytes"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/mocks/service"
+	"github.com/gorilla/mux"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// Test common variables and inputs
+var mockBeneficiaryService *service.MockBeneficiaryService
+var bh BeneficiaryHandler
+
+const beneficiariesPath = "/customers/{customer_id:[0-9]+}/beneficiaries"
+const dummyBeneficiariesPath = "/customers/2/beneficiaries"
+const updateBeneficiaryPath = "/customers/{customer_id:[0-9]+}/beneficiaries/{beneficiary_id:[0-9]+}"
+const dummyUpdateBeneficiaryPath = "/customers/2/beneficiaries/3"
+
+func setupBeneficiaryHandlerTest(t *testing.T, path string, payload string) func() {
+	ctrl := gomock.NewController(t)
+	mockBeneficiaryService = service.NewMockBeneficiaryService(ctrl)
+	bh = BeneficiaryHandler{mockBeneficiaryService}
+
+	router = mux.NewRouter()
+
+	recorder = httptest.NewRecorder()
+	request = httptest.NewRequest(http.MethodPost, path, bytes.NewBuffer([]byte(payload)))
+
+	return func() {
+		router = nil
+		recorder = nil
+		request = nil
+		defer ctrl.Finish()
+	}
+}
+
+func TestBeneficiaryHandler_newBeneficiaryHandler_respondsWith_statusCode201_when_service_succeeds(t *testing.T) {
+	//Arrange
+	teardown := setupBeneficiaryHandlerTest(t, dummyBeneficiariesPath, `{"nickname": "landlord", "account_id": "1980"}`)
+	defer teardown()
+	router.HandleFunc(beneficiariesPath, bh.newBeneficiaryHandler)
+
+	dummyRequest := dto.BeneficiaryRequest{CustomerId: dummyCustomerId, Nickname: "landlord", AccountId: "1980"}
+	mockBeneficiaryService.EXPECT().AddBeneficiary(dummyRequest).Return(&dto.BeneficiaryResponse{BeneficiaryId: "3"}, nil)
+	expectedStatusCode := http.StatusCreated
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != expectedStatusCode {
+		t.Errorf("Expected status code %d but got %d", expectedStatusCode, recorder.Result().StatusCode)
+	}
+}
+
+func TestBeneficiaryHandler_newBeneficiaryHandler_respondsWith_errorStatusCode_when_accountId_invalid(t *testing.T) {
+	//Arrange
+	teardown := setupBeneficiaryHandlerTest(t, dummyBeneficiariesPath, `{"nickname": "landlord", "account_id": "DE89370400440532013000"}`)
+	defer teardown()
+	router.HandleFunc(beneficiariesPath, bh.newBeneficiaryHandler)
+
+	mockBeneficiaryService.EXPECT().AddBeneficiary(gomock.Any()).Times(0)
+	expectedStatusCode := http.StatusUnprocessableEntity
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != expectedStatusCode {
+		t.Errorf("Expected status code %d but got %d", expectedStatusCode, recorder.Result().StatusCode)
+	}
+}
+
+func TestBeneficiaryHandler_updateBeneficiaryHandler_passes_beneficiaryId_from_route(t *testing.T) {
+	//Arrange
+	teardown := setupBeneficiaryHandlerTest(t, dummyUpdateBeneficiaryPath,
+		`{"nickname": "sister", "account_id": "DE89370400440532013000", "bank_code": "DEUTDEFF"}`)
+	defer teardown()
+	router.HandleFunc(updateBeneficiaryPath, bh.updateBeneficiaryHandler)
+
+	dummyRequest := dto.BeneficiaryRequest{BeneficiaryId: "3", CustomerId: dummyCustomerId, Nickname: "sister",
+		AccountId: "DE89370400440532013000", BankCode: "DEUTDEFF"}
+	mockBeneficiaryService.EXPECT().UpdateBeneficiary(dummyRequest).Return(&dto.BeneficiaryResponse{BeneficiaryId: "3"}, nil)
+	expectedStatusCode := http.StatusOK
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != expectedStatusCode {
+		t.Errorf("Expected status code %d but got %d", expectedStatusCode, recorder.Result().StatusCode)
+	}
+}
@@ -0,0 +1,50 @@
+package app
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/service"
+	"os"
+	"time"
+)
+
+// GenerateCashReport runs the cash-report subcommand with the given arguments, which writes the report of the large
+// cash transactions made from one day to another to standard output, e.g. to redo the report of a past day after the
+// threshold changed. Both days default to yesterday.
+func GenerateCashReport(args []string) {
+	yesterday := time.Now().AddDate(0, 0, -1).Format(domain.FormatDate)
+	flags := flag.NewFlagSet("cash-report", flag.ExitOnError)
+	from := flags.String("from", yesterday, "first day of the report, as YYYY-MM-DD")
+	to := flags.String("to", yesterday, "last day of the report, as YYYY-MM-DD")
+	format := flags.String("format", dto.CashReportFormatCsv, "format of the report, csv or json")
+	_ = flags.Parse(args)
+
+	checkDbEnvVars()
+	dbClient := getDbClient()
+	checkSchemaVersion(dbClient) //unlike the server, never migrates, so that standard output only has the report
+
+	customerRepository, _ := getRepositories(dbClient)
+	cashReportService := service.NewCashReportService(getCashReportRepository(dbClient), customerRepository,
+		nil, getCashReportThreshold(), clock.RealClock{})
+	report, appErr := cashReportService.GenerateReport(dto.CashReportRequest{From: *from, To: *to, Format: *format})
+	if appErr != nil {
+		logger.Fatal(appErr.Message)
+	}
+
+	if *format == dto.CashReportFormatJson {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			logger.Fatal("Error while writing cash report: " + err.Error())
+		}
+		return
+	}
+	if err := csv.NewWriter(os.Stdout).WriteAll(report.CsvRecords()); err != nil {
+		logger.Fatal("Error while writing cash report: " + err.Error())
+	}
+}
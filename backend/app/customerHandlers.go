@@ -2,6 +2,9 @@ package app
 
 import (
 	"encoding/json"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
 	"github.com/aliciatay-zls/banking/backend/service"
 	"github.com/gorilla/mux"
 	"net/http"
@@ -32,6 +35,30 @@ func (h CustomerHandlers) customerProfileHandler(w http.ResponseWriter, r *http.
 	}
 }
 
+func (h CustomerHandlers) customerStatusHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	statusRequest := dto.CustomerStatusRequest{CustomerId: vars["customer_id"]}
+
+	if err := json.NewDecoder(r.Body).Decode(&statusRequest); err != nil {
+		logger.Error("Error while decoding json body of customer status request: " + err.Error())
+		writeJsonResponse(w, http.StatusBadRequest, errs.NewMessageObject("Please check that all fields are correctly filled."))
+		return
+	}
+
+	if appErr := statusRequest.Validate(); appErr != nil {
+		writeJsonResponse(w, appErr.Code, appErr.AsMessage())
+		return
+	}
+
+	customer, appErr := h.customerService.SetCustomerStatus(statusRequest)
+	if appErr != nil {
+		writeJsonResponse(w, appErr.Code, appErr.AsMessage())
+		return
+	}
+
+	writeJsonResponse(w, http.StatusOK, customer)
+}
+
 func writeJsonResponse(w http.ResponseWriter, code int, data interface{}) {
 	w.Header().Add("Content-Type", "application/json") // (**)
 	w.WriteHeader(code)
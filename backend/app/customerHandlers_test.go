@@ -29,6 +29,8 @@ var dummyCustomers []dto.CustomerResponse
 const customersPath = "/customers"
 const customerProfilePath = "/customers/{customer_id:[0-9]+}/profile"
 const dummyCustomerProfilePath = "/customers/2/profile"
+const customerStatusPath = "/customers/{customer_id:[0-9]+}/status"
+const dummyCustomerStatusPath = "/customers/2/status"
 
 // setupCustomerHandlersTest initializes the above variables and returns a function that should be called at the end
 // of each test to reset the variables and other cleanup tasks. setup takes in a path string for building request.
@@ -148,6 +150,45 @@ func TestCustomerHandlers_customerProfileHandler_respondsWith_errorStatusCode_wh
 	}
 }
 
+func TestCustomerHandlers_customerStatusHandler_respondsWith_customerAndStatusCode200_when_service_succeeds(t *testing.T) {
+	//Arrange
+	teardown := setupCustomerHandlersTest(t, dummyCustomerStatusPath)
+	defer teardown()
+	router.HandleFunc(customerStatusPath, ch.customerStatusHandler)
+	request = httptest.NewRequest(http.MethodPost, dummyCustomerStatusPath, strings.NewReader(`{"status": "inactive"}`))
+
+	dummyRequest := dto.CustomerStatusRequest{CustomerId: dummyCustomerId, Status: dto.CustomerStatusInactive}
+	mockCustomerService.EXPECT().SetCustomerStatus(dummyRequest).Return(&dummyCustomers[1], nil)
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != http.StatusOK {
+		t.Errorf("Expected status code %d but got %d", http.StatusOK, recorder.Result().StatusCode)
+	}
+	actualResponse, _ := io.ReadAll(recorder.Result().Body)
+	if !strings.Contains(string(actualResponse), dummyCustomers[1].Name) {
+		t.Errorf("Expecting response to contain %s but got %s", dummyCustomers[1].Name, actualResponse)
+	}
+}
+
+func TestCustomerHandlers_customerStatusHandler_respondsWith_statusCode422_when_status_invalid(t *testing.T) {
+	//Arrange
+	teardown := setupCustomerHandlersTest(t, dummyCustomerStatusPath)
+	defer teardown()
+	router.HandleFunc(customerStatusPath, ch.customerStatusHandler)
+	request = httptest.NewRequest(http.MethodPost, dummyCustomerStatusPath, strings.NewReader(`{"status": "closed"}`))
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status code %d but got %d", http.StatusUnprocessableEntity, recorder.Result().StatusCode)
+	}
+}
+
 func TestCustomerHandlers_writeJsonResponse(t *testing.T) {
 	//Arrange
 	setVariableDummyCustomers()
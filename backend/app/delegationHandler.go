@@ -0,0 +1,76 @@
+package app
+
+import (
+	"encoding/json"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/service"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+type DelegationHandler struct {
+	service service.DelegationService
+}
+
+func (h DelegationHandler) delegationsHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	response, appErr := h.service.GetDelegations(vars["customer_id"], vars["account_id"])
+	if appErr != nil {
+		writeJsonResponse(w, appErr.Code, appErr.AsMessage())
+		return
+	}
+
+	writeJsonResponse(w, http.StatusOK, response)
+}
+
+func (h DelegationHandler) receivedDelegationsHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	response, appErr := h.service.GetReceivedDelegations(vars["customer_id"])
+	if appErr != nil {
+		writeJsonResponse(w, appErr.Code, appErr.AsMessage())
+		return
+	}
+
+	writeJsonResponse(w, http.StatusOK, response)
+}
+
+func (h DelegationHandler) newDelegationHandler(w http.ResponseWriter, r *http.Request) {
+	var delegationRequest dto.DelegationRequest
+	if err := json.NewDecoder(r.Body).Decode(&delegationRequest); err != nil {
+		logger.Error("Error while decoding json body of delegation request: " + err.Error())
+		writeJsonResponse(w, http.StatusBadRequest, errs.NewMessageObject("Please check that all fields are correctly filled."))
+		return
+	}
+	vars := mux.Vars(r)
+	delegationRequest.CustomerId = vars["customer_id"]
+	delegationRequest.AccountId = vars["account_id"]
+
+	if appErr := delegationRequest.Validate(); appErr != nil {
+		writeJsonResponse(w, appErr.Code, appErr.AsMessage())
+		return
+	}
+
+	response, appErr := h.service.GrantDelegation(delegationRequest)
+	if appErr != nil {
+		writeJsonResponse(w, appErr.Code, appErr.AsMessage())
+		return
+	}
+
+	writeJsonResponse(w, http.StatusCreated, response)
+}
+
+func (h DelegationHandler) revokeDelegationHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	response, appErr := h.service.RevokeDelegation(vars["customer_id"], vars["account_id"], vars["delegation_id"])
+	if appErr != nil {
+		writeJsonResponse(w, appErr.Code, appErr.AsMessage())
+		return
+	}
+
+	writeJsonResponse(w, http.StatusOK, response)
+}
@@ -0,0 +1,99 @@
+package app
+
+import (
+	"bytes"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/mocks/service"
+	"github.com/gorilla/mux"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// Test common variables and inputs
+var mockDelegationService *service.MockDelegationService
+var dh DelegationHandler
+
+const delegationsPath = "/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}/delegations"
+const dummyDelegationsPath = "/customers/2/account/1977/delegations"
+const revokeDelegationPath = "/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}/delegations/{delegation_id:[0-9]+}/revoke"
+const dummyRevokeDelegationPath = "/customers/2/account/1977/delegations/4/revoke"
+
+func setupDelegationHandlerTest(t *testing.T, path string, payload string) func() {
+	ctrl := gomock.NewController(t)
+	mockDelegationService = service.NewMockDelegationService(ctrl)
+	dh = DelegationHandler{mockDelegationService}
+
+	router = mux.NewRouter()
+
+	recorder = httptest.NewRecorder()
+	request = httptest.NewRequest(http.MethodPost, path, bytes.NewBuffer([]byte(payload)))
+
+	return func() {
+		router = nil
+		recorder = nil
+		request = nil
+		defer ctrl.Finish()
+	}
+}
+
+func TestDelegationHandler_newDelegationHandler_respondsWith_statusCode201_when_service_succeeds(t *testing.T) {
+	//Arrange
+	teardown := setupDelegationHandlerTest(t, dummyDelegationsPath,
+		`{"customer_id": "3", "scope": "withdraw", "daily_limit": 500, "end_date": "2023-03-31"}`)
+	defer teardown()
+	router.HandleFunc(delegationsPath, dh.newDelegationHandler)
+
+	dummyRequest := dto.DelegationRequest{AccountId: dummyAccountId, CustomerId: dummyCustomerId,
+		DelegateCustomerId: "3", Scope: dto.DelegationScopeWithdraw, DailyLimit: 500, EndDate: "2023-03-31"}
+	mockDelegationService.EXPECT().GrantDelegation(dummyRequest).Return(&dto.DelegationResponse{DelegationId: "4",
+		AccountId: dummyAccountId, DelegateCustomerId: "3", Scope: dto.DelegationScopeWithdraw}, nil)
+	expectedStatusCode := http.StatusCreated
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != expectedStatusCode {
+		t.Errorf("Expected status code %d but got %d", expectedStatusCode, recorder.Result().StatusCode)
+	}
+}
+
+func TestDelegationHandler_newDelegationHandler_respondsWith_errorStatusCode_when_dailyLimit_missing(t *testing.T) {
+	//Arrange
+	teardown := setupDelegationHandlerTest(t, dummyDelegationsPath,
+		`{"customer_id": "3", "scope": "withdraw", "end_date": "2023-03-31"}`)
+	defer teardown()
+	router.HandleFunc(delegationsPath, dh.newDelegationHandler)
+
+	mockDelegationService.EXPECT().GrantDelegation(gomock.Any()).Times(0)
+	expectedStatusCode := http.StatusUnprocessableEntity
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != expectedStatusCode {
+		t.Errorf("Expected status code %d but got %d", expectedStatusCode, recorder.Result().StatusCode)
+	}
+}
+
+func TestDelegationHandler_revokeDelegationHandler_passes_delegationId_from_route(t *testing.T) {
+	//Arrange
+	teardown := setupDelegationHandlerTest(t, dummyRevokeDelegationPath, "")
+	defer teardown()
+	router.HandleFunc(revokeDelegationPath, dh.revokeDelegationHandler)
+
+	mockDelegationService.EXPECT().RevokeDelegation(dummyCustomerId, dummyAccountId, "4").Return(
+		&dto.DelegationResponse{DelegationId: "4", RevocationDate: "2023-03-15 09:00:00"}, nil)
+	expectedStatusCode := http.StatusOK
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != expectedStatusCode {
+		t.Errorf("Expected status code %d but got %d", expectedStatusCode, recorder.Result().StatusCode)
+	}
+}
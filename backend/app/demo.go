@@ -0,0 +1,22 @@
+package app
+
+import (
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"os"
+)
+
+// getDemoRepositories returns in-memory repositories loaded with the fixtures from the JSON file named by the optional
+// DEMO_FIXTURES_FILE environment variable, falling back to the default fixtures if it is not set. Nothing is saved
+// when the app stops.
+func getDemoRepositories() (domain.CustomerRepository, domain.AccountRepository, domain.AuthRepository) {
+	fixtures, err := domain.LoadFixtures(os.Getenv("DEMO_FIXTURES_FILE"))
+	if err != nil {
+		logger.Fatal("Error while loading demo fixtures: " + err.Error())
+	}
+
+	accountRepository := domain.NewAccountRepositoryStub(fixtures.Accounts)
+	logger.Info("Running in demo mode, log in by sending the token of one of the demo users as the bearer token")
+	return domain.NewCustomerRepositoryStub(fixtures.Customers), accountRepository,
+		domain.NewAuthRepositoryStub(fixtures.Users, accountRepository)
+}
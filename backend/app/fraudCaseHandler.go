@@ -0,0 +1,67 @@
+package app
+
+import (
+	"encoding/json"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/service"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+type FraudCaseHandler struct {
+	service service.FraudCaseService
+}
+
+func (h FraudCaseHandler) fraudCasesHandler(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	fraudCasesRequest := dto.FraudCasesRequest{
+		Status:  q.Get("status"),
+		AfterId: q.Get("after_id"),
+		Limit:   q.Get("limit"),
+	}
+
+	response, appErr := h.service.GetFraudCases(fraudCasesRequest)
+	if appErr != nil {
+		writeJsonResponse(w, appErr.Code, appErr.AsMessage())
+		return
+	}
+
+	writeJsonResponse(w, http.StatusOK, response)
+}
+
+func (h FraudCaseHandler) fraudCaseHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	response, appErr := h.service.GetFraudCase(vars["case_id"])
+	if appErr != nil {
+		writeJsonResponse(w, appErr.Code, appErr.AsMessage())
+		return
+	}
+
+	writeJsonResponse(w, http.StatusOK, response)
+}
+
+func (h FraudCaseHandler) resolveFraudCaseHandler(w http.ResponseWriter, r *http.Request) {
+	var resolveRequest dto.ResolveFraudCaseRequest
+	if err := json.NewDecoder(r.Body).Decode(&resolveRequest); err != nil {
+		logger.Error("Error while decoding json body of resolve fraud case request: " + err.Error())
+		writeJsonResponse(w, http.StatusBadRequest, errs.NewMessageObject("Please check that all fields are correctly filled."))
+		return
+	}
+	resolveRequest.CaseId = mux.Vars(r)["case_id"]
+
+	if appErr := resolveRequest.Validate(); appErr != nil {
+		writeJsonResponse(w, appErr.Code, appErr.AsMessage())
+		return
+	}
+
+	response, appErr := h.service.ResolveFraudCase(resolveRequest)
+	if appErr != nil {
+		writeJsonResponse(w, appErr.Code, appErr.AsMessage())
+		return
+	}
+
+	writeJsonResponse(w, http.StatusOK, response)
+}
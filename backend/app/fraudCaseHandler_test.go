@@ -0,0 +1,94 @@
+package app
+
+import (
+	"bytes"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/mocks/service"
+	"github.com/gorilla/mux"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// Test common variables and inputs
+var mockFraudCaseService *service.MockFraudCaseService
+var fh FraudCaseHandler
+
+const resolveFraudCasePath = "/fraud-cases/{case_id:[0-9]+}/resolve"
+const dummyResolveFraudCasePath = "/fraud-cases/5/resolve"
+
+func setupFraudCaseHandlerTest(t *testing.T, method string, path string, payload string) func() {
+	ctrl := gomock.NewController(t)
+	mockFraudCaseService = service.NewMockFraudCaseService(ctrl)
+	fh = FraudCaseHandler{mockFraudCaseService}
+
+	router = mux.NewRouter()
+
+	recorder = httptest.NewRecorder()
+	request = httptest.NewRequest(method, path, bytes.NewBuffer([]byte(payload)))
+
+	return func() {
+		router = nil
+		recorder = nil
+		request = nil
+		defer ctrl.Finish()
+	}
+}
+
+func TestFraudCaseHandler_fraudCasesHandler_passes_queryParameters(t *testing.T) {
+	//Arrange
+	teardown := setupFraudCaseHandlerTest(t, http.MethodGet, "/fraud-cases?status=open&after_id=4&limit=10", "")
+	defer teardown()
+	router.HandleFunc("/fraud-cases", fh.fraudCasesHandler)
+
+	dummyRequest := dto.FraudCasesRequest{Status: dto.FraudCaseStatusOpen, AfterId: "4", Limit: "10"}
+	mockFraudCaseService.EXPECT().GetFraudCases(dummyRequest).Return(&dto.FraudCasesResponse{}, nil)
+	expectedStatusCode := http.StatusOK
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != expectedStatusCode {
+		t.Errorf("Expected status code %d but got %d", expectedStatusCode, recorder.Result().StatusCode)
+	}
+}
+
+func TestFraudCaseHandler_resolveFraudCaseHandler_respondsWith_errorStatusCode_when_resolution_invalid(t *testing.T) {
+	//Arrange
+	teardown := setupFraudCaseHandlerTest(t, http.MethodPost, dummyResolveFraudCasePath, `{"resolution": "unsure"}`)
+	defer teardown()
+	router.HandleFunc(resolveFraudCasePath, fh.resolveFraudCaseHandler)
+
+	mockFraudCaseService.EXPECT().ResolveFraudCase(gomock.Any()).Times(0)
+	expectedStatusCode := http.StatusUnprocessableEntity
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != expectedStatusCode {
+		t.Errorf("Expected status code %d but got %d", expectedStatusCode, recorder.Result().StatusCode)
+	}
+}
+
+func TestFraudCaseHandler_resolveFraudCaseHandler_respondsWith_errorStatusCode_when_case_closed(t *testing.T) {
+	//Arrange
+	teardown := setupFraudCaseHandlerTest(t, http.MethodPost, dummyResolveFraudCasePath, `{"resolution": "fraud"}`)
+	defer teardown()
+	router.HandleFunc(resolveFraudCasePath, fh.resolveFraudCaseHandler)
+
+	dummyRequest := dto.ResolveFraudCaseRequest{CaseId: "5", Resolution: dto.FraudCaseResolutionFraud}
+	mockFraudCaseService.EXPECT().ResolveFraudCase(dummyRequest).Return(nil, errs.NewConflictError("Fraud case is already closed"))
+	expectedStatusCode := http.StatusConflict
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != expectedStatusCode {
+		t.Errorf("Expected status code %d but got %d", expectedStatusCode, recorder.Result().StatusCode)
+	}
+}
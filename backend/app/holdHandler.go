@@ -0,0 +1,96 @@
+package app
+
+import (
+	"encoding/json"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/service"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+type HoldHandler struct {
+	service service.HoldService
+}
+
+func (h HoldHandler) newHoldHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	newHoldRequest := dto.NewHoldRequest{
+		AccountId:  vars["account_id"],
+		CustomerId: vars["customer_id"],
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&newHoldRequest); err != nil {
+		logger.Error("Error while decoding json body of new hold request: " + err.Error())
+		writeJsonResponse(w, http.StatusBadRequest, errs.NewMessageObject("Please check that all fields are correctly filled."))
+		return
+	}
+
+	if appErr := newHoldRequest.Validate(); appErr != nil {
+		writeJsonResponse(w, appErr.Code, appErr.AsMessage())
+		return
+	}
+
+	response, appErr := h.service.PlaceHold(newHoldRequest)
+	if appErr != nil {
+		writeJsonResponse(w, appErr.Code, appErr.AsMessage())
+		return
+	}
+
+	writeJsonResponse(w, http.StatusCreated, response)
+}
+
+func (h HoldHandler) holdsHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	response, appErr := h.service.GetHolds(vars["customer_id"], vars["account_id"])
+	if appErr != nil {
+		writeJsonResponse(w, appErr.Code, appErr.AsMessage())
+		return
+	}
+
+	writeJsonResponse(w, http.StatusOK, response)
+}
+
+func (h HoldHandler) captureHoldHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	captureHoldRequest := dto.CaptureHoldRequest{
+		AccountId:  vars["account_id"],
+		CustomerId: vars["customer_id"],
+		HoldId:     vars["hold_id"],
+	}
+
+	if r.ContentLength != 0 { //body is optional since the full amount is captured by default
+		if err := json.NewDecoder(r.Body).Decode(&captureHoldRequest); err != nil {
+			logger.Error("Error while decoding json body of capture hold request: " + err.Error())
+			writeJsonResponse(w, http.StatusBadRequest, errs.NewMessageObject("Please check that all fields are correctly filled."))
+			return
+		}
+	}
+
+	if appErr := captureHoldRequest.Validate(); appErr != nil {
+		writeJsonResponse(w, appErr.Code, appErr.AsMessage())
+		return
+	}
+
+	response, appErr := h.service.CaptureHold(captureHoldRequest)
+	if appErr != nil {
+		writeJsonResponse(w, appErr.Code, appErr.AsMessage())
+		return
+	}
+
+	writeJsonResponse(w, http.StatusCreated, response)
+}
+
+func (h HoldHandler) releaseHoldHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	response, appErr := h.service.ReleaseHold(vars["customer_id"], vars["account_id"], vars["hold_id"])
+	if appErr != nil {
+		writeJsonResponse(w, appErr.Code, appErr.AsMessage())
+		return
+	}
+
+	writeJsonResponse(w, http.StatusOK, response)
+}
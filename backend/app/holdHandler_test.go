@@ -0,0 +1,114 @@
+package app
+
+import (
+	"bytes"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/mocks/service"
+	"github.com/gorilla/mux"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// Test common variables and inputs
+var mockHoldService *service.MockHoldService
+var hh HoldHandler
+
+const captureHoldPath = "/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}/holds/{hold_id:[0-9]+}/capture"
+const dummyCaptureHoldPath = "/customers/2/account/1977/holds/3/capture"
+
+func setupHoldHandlerTest(t *testing.T, path string, payload string) func() {
+	ctrl := gomock.NewController(t)
+	mockHoldService = service.NewMockHoldService(ctrl)
+	hh = HoldHandler{mockHoldService}
+
+	router = mux.NewRouter()
+
+	recorder = httptest.NewRecorder()
+	request = httptest.NewRequest(http.MethodPost, path, bytes.NewBuffer([]byte(payload)))
+
+	return func() {
+		router = nil
+		recorder = nil
+		request = nil
+		defer ctrl.Finish()
+	}
+}
+
+func TestHoldHandler_captureHoldHandler_capturesFullAmount_when_body_empty(t *testing.T) {
+	//Arrange
+	teardown := setupHoldHandlerTest(t, dummyCaptureHoldPath, "")
+	defer teardown()
+	router.HandleFunc(captureHoldPath, hh.captureHoldHandler)
+
+	dummyRequest := dto.CaptureHoldRequest{AccountId: dummyAccountId, CustomerId: dummyCustomerId, HoldId: "3"}
+	mockHoldService.EXPECT().CaptureHold(dummyRequest).Return(&dto.TransactionResponse{TransactionId: dummyTransactionId}, nil)
+	expectedStatusCode := http.StatusCreated
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != expectedStatusCode {
+		t.Errorf("Expected status code %d but got %d", expectedStatusCode, recorder.Result().StatusCode)
+	}
+}
+
+func TestHoldHandler_captureHoldHandler_respondsWith_errorStatusCode_when_amount_invalid(t *testing.T) {
+	//Arrange
+	teardown := setupHoldHandlerTest(t, dummyCaptureHoldPath, `{"amount": -5}`)
+	defer teardown()
+	router.HandleFunc(captureHoldPath, hh.captureHoldHandler)
+
+	mockHoldService.EXPECT().CaptureHold(gomock.Any()).Times(0)
+	expectedStatusCode := http.StatusUnprocessableEntity
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != expectedStatusCode {
+		t.Errorf("Expected status code %d but got %d", expectedStatusCode, recorder.Result().StatusCode)
+	}
+}
+
+func TestHoldHandler_captureHoldHandler_ignores_ids_in_body(t *testing.T) {
+	//Arrange
+	teardown := setupHoldHandlerTest(t, dummyCaptureHoldPath,
+		`{"account_id": "95470", "customer_id": "2000", "hold_id": "9", "amount": 40}`)
+	defer teardown()
+	router.HandleFunc(captureHoldPath, hh.captureHoldHandler)
+
+	dummyRequest := dto.CaptureHoldRequest{AccountId: dummyAccountId, CustomerId: dummyCustomerId, HoldId: "3", Amount: 40}
+	mockHoldService.EXPECT().CaptureHold(dummyRequest).Return(&dto.TransactionResponse{TransactionId: dummyTransactionId}, nil)
+	expectedStatusCode := http.StatusCreated
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != expectedStatusCode {
+		t.Errorf("Expected status code %d but got %d", expectedStatusCode, recorder.Result().StatusCode)
+	}
+}
+
+func TestHoldHandler_newHoldHandler_ignores_ids_in_body(t *testing.T) {
+	//Arrange
+	teardown := setupHoldHandlerTest(t, "/customers/2/account/1977/holds",
+		`{"account_id": "95470", "customer_id": "2000", "amount": 40}`)
+	defer teardown()
+	router.HandleFunc("/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}/holds", hh.newHoldHandler)
+
+	dummyRequest := dto.NewHoldRequest{AccountId: dummyAccountId, CustomerId: dummyCustomerId, Amount: 40}
+	mockHoldService.EXPECT().PlaceHold(dummyRequest).Return(&dto.HoldResponse{HoldId: "3"}, nil)
+	expectedStatusCode := http.StatusCreated
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != expectedStatusCode {
+		t.Errorf("Expected status code %d but got %d", expectedStatusCode, recorder.Result().StatusCode)
+	}
+}
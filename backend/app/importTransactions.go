@@ -0,0 +1,84 @@
+package app
+
+import (
+	"encoding/json"
+	"flag"
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/service"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const importUsage = "Usage: import [-mode best_effort | all_or_nothing] [-format csv | jsonl] [-batch id] " +
+	"[-concurrency n] file"
+
+// ImportTransactions runs the import subcommand with the given arguments, which imports a CSV or JSON-lines file of
+// transactions straight into the database, the same way as the import endpoint. The report is written to standard
+// output as JSON lines. With -batch, an earlier import of the same file that stopped part way is resumed.
+func ImportTransactions(args []string) {
+	flags := flag.NewFlagSet("import", flag.ExitOnError)
+	mode := flags.String("mode", domain.ImportModeBestEffort, "best_effort to skip rejected rows, or all_or_nothing")
+	format := flags.String("format", "", "format of the file, csv or jsonl (taken from the file extension by default)")
+	batchId := flags.String("batch", "", "ID of the batch to resume")
+	concurrency := flags.Int("concurrency", dto.ImportDefaultConcurrency, "number of rows to post at the same time")
+	_ = flags.Parse(args)
+	if flags.NArg() < 1 {
+		logger.Fatal(importUsage)
+	}
+	path := flags.Arg(0)
+	_ = flags.Parse(flags.Args()[1:]) //allows flags to also be given after the file
+	if *format == "" {
+		*format = importFormatOf(path)
+	}
+
+	checkDbEnvVars()
+	dbClient := getDbClient()
+	checkSchemaVersion(dbClient) //unlike the server, never migrates, so that standard output only has the report
+
+	file, err := os.Open(path)
+	if err != nil {
+		logger.Fatal("Error while opening import file: " + err.Error())
+	}
+	defer file.Close()
+
+	clk := clock.RealClock{}
+	_, accountRepository := getRepositories(dbClient)
+	unitOfWork := domain.NewUnitOfWorkDb(dbClient)
+	accountService := service.NewAccountService(accountRepository, unitOfWork, getFxRateProvider(),
+		getBeneficiaryPolicy(), getScreeningPipeline(), getWatchlist(), clk)
+	importService := service.NewTransactionImportService(getImportRepository(dbClient), accountService,
+		unitOfWork, clk)
+
+	request := dto.TransactionImportRequest{
+		BatchId:     *batchId,
+		Mode:        *mode,
+		Format:      *format,
+		Concurrency: strconv.Itoa(*concurrency),
+	}
+	encoder := json.NewEncoder(os.Stdout)
+	appErr := importService.Import(request, file, func(line dto.ImportReportLine) {
+		if err := encoder.Encode(line); err != nil {
+			logger.Error("Error while writing line of import report: " + err.Error())
+		}
+	})
+	if appErr != nil {
+		file.Close()
+		logger.Fatal(appErr.Message)
+	}
+}
+
+// importFormatOf guesses the format of the file at the given path from its extension, taking it as CSV unless it is
+// a JSON-lines file.
+func importFormatOf(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".jsonl", ".ndjson":
+		return dto.ImportFormatJsonl
+	default:
+		return dto.ImportFormatCsv
+	}
+}
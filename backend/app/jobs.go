@@ -0,0 +1,33 @@
+package app
+
+import (
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"time"
+)
+
+const jobInterval = time.Hour
+const standingOrderJobInterval = time.Minute //cron schedules can run as often as every minute
+const holdExpiryJobInterval = time.Minute
+const outboxRelayInterval = 5 * time.Second
+const webhookJobInterval = 15 * time.Second
+const sanctionsJobInterval = 5 * time.Minute
+
+// startJob runs the given job once immediately and then once every interval in a separate goroutine, for as long as
+// the app is running. Jobs are expected to be idempotent, so that running them more often than needed is harmless.
+func startJob(name string, interval time.Duration, job func() *errs.AppError) {
+	run := func() {
+		if appErr := job(); appErr != nil {
+			logger.Error("Error while running job " + name + ": " + appErr.Message)
+		}
+	}
+
+	go func() {
+		run()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			run()
+		}
+	}()
+}
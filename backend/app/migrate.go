@@ -0,0 +1,77 @@
+package app
+
+import (
+	"flag"
+	"fmt"
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/migrations"
+	"github.com/jmoiron/sqlx"
+	"os"
+)
+
+const migrateUsage = "Usage: migrate [-dry-run] up | down [-steps n] | status"
+
+// Migrate runs the migrate subcommand with the given arguments: "up" applies all pending migrations, "down" reverts
+// the last migration (or the last n with -steps n) and "status" lists the migrations and whether each was applied.
+// With -dry-run, the statements are only printed out instead of being executed.
+func Migrate(args []string) {
+	flags := flag.NewFlagSet("migrate", flag.ExitOnError)
+	dryRun := flags.Bool("dry-run", false, "print the statements instead of executing them")
+	steps := flags.Int("steps", 1, "number of migrations to revert (down only)")
+	_ = flags.Parse(args)
+	if flags.NArg() < 1 {
+		logger.Fatal(migrateUsage)
+	}
+	command := flags.Arg(0)
+	_ = flags.Parse(flags.Args()[1:]) //allows flags to also be given after the command
+
+	checkDbEnvVars()
+	migrator := newMigrator(getDbClient(), *dryRun)
+
+	var err error
+	switch command {
+	case "up":
+		err = migrator.Up()
+	case "down":
+		if *steps < 1 {
+			logger.Fatal("Number of steps to revert must be at least 1")
+		}
+		err = migrator.Down(*steps)
+	case "status":
+		err = migrator.Status()
+	default:
+		logger.Fatal(migrateUsage)
+	}
+	if err != nil {
+		logger.Fatal(err.Error())
+	}
+}
+
+// prepareSchema gets the database schema ready before the app starts. An in-process SQLite database is brought up to
+// date (and seeded, for a new database file) by applying all pending migrations, since nobody else would run them. For
+// other databases, the app only checks the schema version, leaving migrations to be applied as a separate step.
+func prepareSchema(dbClient *sqlx.DB) {
+	if dbClient.DriverName() == dbDriverSQLite {
+		if err := newMigrator(dbClient, false).Up(); err != nil {
+			logger.Fatal("Error while bootstrapping SQLite database: " + err.Error())
+		}
+		return
+	}
+	checkSchemaVersion(dbClient)
+}
+
+// checkSchemaVersion stops the app if the database schema is older than the migrations built into the app expect.
+func checkSchemaVersion(dbClient *sqlx.DB) {
+	if err := newMigrator(dbClient, false).CheckVersion(); err != nil {
+		logger.Fatal(fmt.Sprintf("Refusing to start: %s", err.Error()))
+	}
+}
+
+func newMigrator(dbClient *sqlx.DB, dryRun bool) migrations.Migrator {
+	migrationsList, err := migrations.Load(dbClient.DriverName()) //dialects are named after their drivers
+	if err != nil {
+		logger.Fatal("Error while loading migrations: " + err.Error())
+	}
+	return migrations.NewMigrator(dbClient, migrationsList, clock.RealClock{}, os.Stdout, dryRun)
+}
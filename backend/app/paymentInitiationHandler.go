@@ -0,0 +1,42 @@
+package app
+
+import (
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/service"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+// paymentInitiationMaxSize is the size in bytes of the largest pain.001 message that can be submitted.
+const paymentInitiationMaxSize = 5 << 20
+
+type PaymentInitiationHandler struct {
+	service service.PaymentInitiationService
+}
+
+// paymentInitiationHandler makes the credit transfers of the pain.001 message in the request body and responds with
+// a pain.002 status report of them. Messages that cannot be read are responded to with an error as usual.
+func (h PaymentInitiationHandler) paymentInitiationHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	request := dto.PaymentInitiationRequest{CustomerId: vars["customer_id"]}
+
+	report, appErr := h.service.InitiatePayments(request, http.MaxBytesReader(w, r.Body, paymentInitiationMaxSize))
+	if appErr != nil {
+		writeJsonResponse(w, appErr.Code, appErr.AsMessage())
+		return
+	}
+
+	content, err := report.ToXml()
+	if err != nil {
+		logger.Error("Error while rendering pain.002 status report: " + err.Error())
+		writeJsonResponse(w, http.StatusInternalServerError, errs.NewMessageObject("Unexpected server error"))
+		return
+	}
+	w.Header().Add("Content-Type", "application/xml; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	if _, err = w.Write(content); err != nil {
+		logger.Error("Error while writing pain.002 status report: " + err.Error())
+	}
+}
@@ -0,0 +1,79 @@
+package app
+
+import (
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/mocks/service"
+	"github.com/gorilla/mux"
+	"go.uber.org/mock/gomock"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// Test common variables and inputs
+var mockPaymentInitiationService *service.MockPaymentInitiationService
+var ph PaymentInitiationHandler
+
+func setupPaymentInitiationHandlerTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	mockPaymentInitiationService = service.NewMockPaymentInitiationService(ctrl)
+	ph = PaymentInitiationHandler{mockPaymentInitiationService}
+
+	router = mux.NewRouter()
+	router.HandleFunc("/customers/{customer_id:[0-9]+}/payments", ph.paymentInitiationHandler)
+
+	recorder = httptest.NewRecorder()
+	request = httptest.NewRequest(http.MethodPost, "/customers/2000/payments", strings.NewReader("<Document/>"))
+
+	return func() {
+		router = nil
+		recorder = nil
+		request = nil
+		defer ctrl.Finish()
+	}
+}
+
+func TestPaymentInitiationHandler_paymentInitiationHandler_respondsWith_statusReport_when_service_succeeds(t *testing.T) {
+	//Arrange
+	teardown := setupPaymentInitiationHandlerTest(t)
+	defer teardown()
+
+	report := dto.NewPain002Document(dto.Pain002Report{
+		OriginalGroup: dto.Pain002OriginalGroup{MessageId: "MSG-1", Status: dto.PaymentStatusAccepted}})
+	mockPaymentInitiationService.EXPECT().InitiatePayments(dto.PaymentInitiationRequest{CustomerId: "2000"}, gomock.Any()).
+		Return(&report, nil)
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	body, _ := io.ReadAll(recorder.Result().Body)
+	if recorder.Result().StatusCode != http.StatusOK ||
+		recorder.Header().Get("Content-Type") != "application/xml; charset=utf-8" {
+		t.Errorf("Expected status code %d and XML but got %d and %s", http.StatusOK, recorder.Result().StatusCode,
+			recorder.Header().Get("Content-Type"))
+	}
+	if !strings.Contains(string(body), "<CstmrPmtStsRpt>") || !strings.Contains(string(body), "<GrpSts>ACSC</GrpSts>") {
+		t.Errorf("Expected pain.002 status report but got %s", body)
+	}
+}
+
+func TestPaymentInitiationHandler_paymentInitiationHandler_respondsWith_error_when_message_invalid(t *testing.T) {
+	//Arrange
+	teardown := setupPaymentInitiationHandlerTest(t)
+	defer teardown()
+
+	mockPaymentInitiationService.EXPECT().InitiatePayments(gomock.Any(), gomock.Any()).
+		Return(nil, errs.NewValidationError("Group header should have a message ID."))
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status code %d but got %d", http.StatusUnprocessableEntity, recorder.Result().StatusCode)
+	}
+}
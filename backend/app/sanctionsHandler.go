@@ -0,0 +1,79 @@
+package app
+
+import (
+	"encoding/json"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/service"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+type SanctionsHandler struct {
+	service service.SanctionsService
+}
+
+func (h SanctionsHandler) screenCustomerHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	response, appErr := h.service.ScreenCustomer(vars["customer_id"])
+	if appErr != nil {
+		writeJsonResponse(w, appErr.Code, appErr.AsMessage())
+		return
+	}
+
+	writeJsonResponse(w, http.StatusOK, response)
+}
+
+func (h SanctionsHandler) sanctionsHitsHandler(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	hitsRequest := dto.SanctionsHitsRequest{
+		Status:  q.Get("status"),
+		AfterId: q.Get("after_id"),
+		Limit:   q.Get("limit"),
+	}
+
+	response, appErr := h.service.GetSanctionsHits(hitsRequest)
+	if appErr != nil {
+		writeJsonResponse(w, appErr.Code, appErr.AsMessage())
+		return
+	}
+
+	writeJsonResponse(w, http.StatusOK, response)
+}
+
+func (h SanctionsHandler) sanctionsHitHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	response, appErr := h.service.GetSanctionsHit(vars["hit_id"])
+	if appErr != nil {
+		writeJsonResponse(w, appErr.Code, appErr.AsMessage())
+		return
+	}
+
+	writeJsonResponse(w, http.StatusOK, response)
+}
+
+func (h SanctionsHandler) resolveSanctionsHitHandler(w http.ResponseWriter, r *http.Request) {
+	var resolveRequest dto.ResolveSanctionsHitRequest
+	if err := json.NewDecoder(r.Body).Decode(&resolveRequest); err != nil {
+		logger.Error("Error while decoding json body of resolve sanctions hit request: " + err.Error())
+		writeJsonResponse(w, http.StatusBadRequest, errs.NewMessageObject("Please check that all fields are correctly filled."))
+		return
+	}
+	resolveRequest.HitId = mux.Vars(r)["hit_id"]
+
+	if appErr := resolveRequest.Validate(); appErr != nil {
+		writeJsonResponse(w, appErr.Code, appErr.AsMessage())
+		return
+	}
+
+	response, appErr := h.service.ResolveSanctionsHit(resolveRequest)
+	if appErr != nil {
+		writeJsonResponse(w, appErr.Code, appErr.AsMessage())
+		return
+	}
+
+	writeJsonResponse(w, http.StatusOK, response)
+}
@@ -0,0 +1,113 @@
+package app
+
+import (
+	"bytes"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/mocks/service"
+	"github.com/gorilla/mux"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// Test common variables and inputs
+var mockSanctionsService *service.MockSanctionsService
+var sah SanctionsHandler
+
+const resolveSanctionsHitPath = "/sanctions-hits/{hit_id:[0-9]+}/resolve"
+const dummyResolveSanctionsHitPath = "/sanctions-hits/4/resolve"
+
+func setupSanctionsHandlerTest(t *testing.T, method string, path string, payload string) func() {
+	ctrl := gomock.NewController(t)
+	mockSanctionsService = service.NewMockSanctionsService(ctrl)
+	sah = SanctionsHandler{mockSanctionsService}
+
+	router = mux.NewRouter()
+
+	recorder = httptest.NewRecorder()
+	request = httptest.NewRequest(method, path, bytes.NewBuffer([]byte(payload)))
+
+	return func() {
+		router = nil
+		recorder = nil
+		request = nil
+		defer ctrl.Finish()
+	}
+}
+
+func TestSanctionsHandler_sanctionsHitsHandler_passes_queryParameters(t *testing.T) {
+	//Arrange
+	teardown := setupSanctionsHandlerTest(t, http.MethodGet, "/sanctions-hits?status=pending&after_id=3&limit=10", "")
+	defer teardown()
+	router.HandleFunc("/sanctions-hits", sah.sanctionsHitsHandler)
+
+	dummyRequest := dto.SanctionsHitsRequest{Status: dto.SanctionsHitStatusPending, AfterId: "3", Limit: "10"}
+	mockSanctionsService.EXPECT().GetSanctionsHits(dummyRequest).Return(&dto.SanctionsHitsResponse{}, nil)
+	expectedStatusCode := http.StatusOK
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != expectedStatusCode {
+		t.Errorf("Expected status code %d but got %d", expectedStatusCode, recorder.Result().StatusCode)
+	}
+}
+
+func TestSanctionsHandler_screenCustomerHandler_passes_customerId(t *testing.T) {
+	//Arrange
+	teardown := setupSanctionsHandlerTest(t, http.MethodPost, "/customers/2001/screen", "")
+	defer teardown()
+	router.HandleFunc("/customers/{customer_id:[0-9]+}/screen", sah.screenCustomerHandler)
+
+	mockSanctionsService.EXPECT().ScreenCustomer("2001").Return(&dto.CustomerScreeningResponse{CustomerId: "2001"}, nil)
+	expectedStatusCode := http.StatusOK
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != expectedStatusCode {
+		t.Errorf("Expected status code %d but got %d", expectedStatusCode, recorder.Result().StatusCode)
+	}
+}
+
+func TestSanctionsHandler_resolveSanctionsHitHandler_respondsWith_errorStatusCode_when_resolution_invalid(t *testing.T) {
+	//Arrange
+	teardown := setupSanctionsHandlerTest(t, http.MethodPost, dummyResolveSanctionsHitPath, `{"resolution": "pending"}`)
+	defer teardown()
+	router.HandleFunc(resolveSanctionsHitPath, sah.resolveSanctionsHitHandler)
+
+	mockSanctionsService.EXPECT().ResolveSanctionsHit(gomock.Any()).Times(0)
+	expectedStatusCode := http.StatusUnprocessableEntity
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != expectedStatusCode {
+		t.Errorf("Expected status code %d but got %d", expectedStatusCode, recorder.Result().StatusCode)
+	}
+}
+
+func TestSanctionsHandler_resolveSanctionsHitHandler_respondsWith_errorStatusCode_when_hit_resolved(t *testing.T) {
+	//Arrange
+	teardown := setupSanctionsHandlerTest(t, http.MethodPost, dummyResolveSanctionsHitPath, `{"resolution": "cleared"}`)
+	defer teardown()
+	router.HandleFunc(resolveSanctionsHitPath, sah.resolveSanctionsHitHandler)
+
+	dummyRequest := dto.ResolveSanctionsHitRequest{HitId: "4", Resolution: dto.SanctionsHitStatusCleared}
+	mockSanctionsService.EXPECT().ResolveSanctionsHit(dummyRequest).
+		Return(nil, errs.NewConflictError("Sanctions hit is already resolved"))
+	expectedStatusCode := http.StatusConflict
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != expectedStatusCode {
+		t.Errorf("Expected status code %d but got %d", expectedStatusCode, recorder.Result().StatusCode)
+	}
+}
@@ -0,0 +1,83 @@
+package app
+
+import (
+	"encoding/csv"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/service"
+	"net/http"
+)
+
+type SearchHandler struct {
+	service service.SearchService
+}
+
+func (h SearchHandler) customerSearchHandler(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	request := dto.CustomerSearchRequest{
+		Name:    q.Get("name"),
+		Email:   q.Get("email"),
+		Country: q.Get("country"),
+		Status:  q.Get("status"),
+		Sort:    q.Get("sort"),
+		Cursor:  q.Get("cursor"),
+		Limit:   q.Get("limit"),
+		Format:  q.Get("format"),
+	}
+
+	response, appErr := h.service.SearchCustomers(request)
+	if appErr != nil {
+		writeJsonResponse(w, appErr.Code, appErr.AsMessage())
+		return
+	}
+
+	if request.Format == dto.SearchFormatCsv {
+		writeCsvResponse(w, "customers.csv", response.CsvRecords(), response.NextCursor)
+		return
+	}
+	writeJsonResponse(w, http.StatusOK, response)
+}
+
+func (h SearchHandler) accountSearchHandler(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	request := dto.AccountSearchRequest{
+		CustomerId:  q.Get("customer_id"),
+		AccountType: q.Get("account_type"),
+		Currency:    q.Get("currency"),
+		Status:      q.Get("status"),
+		MinBalance:  q.Get("min_balance"),
+		MaxBalance:  q.Get("max_balance"),
+		OpenedFrom:  q.Get("opened_from"),
+		OpenedTo:    q.Get("opened_to"),
+		Sort:        q.Get("sort"),
+		Cursor:      q.Get("cursor"),
+		Limit:       q.Get("limit"),
+		Format:      q.Get("format"),
+	}
+
+	response, appErr := h.service.SearchAccounts(request)
+	if appErr != nil {
+		writeJsonResponse(w, appErr.Code, appErr.AsMessage())
+		return
+	}
+
+	if request.Format == dto.SearchFormatCsv {
+		writeCsvResponse(w, "accounts.csv", response.CsvRecords(), response.NextCursor)
+		return
+	}
+	writeJsonResponse(w, http.StatusOK, response)
+}
+
+// writeCsvResponse writes the given records as a CSV file to download. As a CSV file has no room for it, the cursor
+// of the next page is sent in the X-Next-Cursor header instead.
+func writeCsvResponse(w http.ResponseWriter, filename string, records [][]string, nextCursor string) {
+	w.Header().Add("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Add("Content-Disposition", `attachment; filename="`+filename+`"`)
+	if nextCursor != "" {
+		w.Header().Add("X-Next-Cursor", nextCursor)
+	}
+	w.WriteHeader(http.StatusOK)
+	if err := csv.NewWriter(w).WriteAll(records); err != nil {
+		logger.Error("Error while writing CSV response: " + err.Error())
+	}
+}
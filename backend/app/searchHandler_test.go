@@ -0,0 +1,82 @@
+package app
+
+import (
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/mocks/service"
+	"github.com/gorilla/mux"
+	"go.uber.org/mock/gomock"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// Test common variables and inputs
+var mockSearchService *service.MockSearchService
+var sh SearchHandler
+
+func setupSearchHandlerTest(t *testing.T, path string) func() {
+	ctrl := gomock.NewController(t)
+	mockSearchService = service.NewMockSearchService(ctrl)
+	sh = SearchHandler{mockSearchService}
+
+	router = mux.NewRouter()
+
+	recorder = httptest.NewRecorder()
+	request = httptest.NewRequest(http.MethodGet, path, nil)
+
+	return func() {
+		router = nil
+		recorder = nil
+		request = nil
+		defer ctrl.Finish()
+	}
+}
+
+func TestSearchHandler_customerSearchHandler_respondsWith_csv_when_format_csv(t *testing.T) {
+	//Arrange
+	teardown := setupSearchHandlerTest(t, "/customers/search?country=india&format=csv")
+	defer teardown()
+	router.HandleFunc("/customers/search", sh.customerSearchHandler)
+
+	expectedRequest := dto.CustomerSearchRequest{Country: "india", Format: dto.SearchFormatCsv}
+	response := dto.CustomerSearchResponse{
+		Customers:  []dto.CustomerResponse{{Id: "2000", Name: "Steve", Country: "India", Status: "active"}},
+		NextCursor: "abc",
+	}
+	mockSearchService.EXPECT().SearchCustomers(expectedRequest).Return(&response, nil)
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	body, _ := io.ReadAll(recorder.Result().Body)
+	if recorder.Result().StatusCode != http.StatusOK || recorder.Header().Get("Content-Type") != "text/csv; charset=utf-8" {
+		t.Errorf("Expected status code %d and CSV but got %d and %s", http.StatusOK, recorder.Result().StatusCode,
+			recorder.Header().Get("Content-Type"))
+	}
+	if recorder.Header().Get("X-Next-Cursor") != "abc" || !strings.HasPrefix(string(body), "customer_id,full_name") ||
+		!strings.Contains(string(body), "2000,Steve,,,India,,active") {
+		t.Errorf("Expected CSV of customer 2000 with next cursor but got %s", string(body))
+	}
+}
+
+func TestSearchHandler_accountSearchHandler_passes_query_parameters(t *testing.T) {
+	//Arrange
+	teardown := setupSearchHandlerTest(t, "/accounts/search?min_balance=100&max_balance=500&opened_from=2020-08-01&sort=-amount&limit=10")
+	defer teardown()
+	router.HandleFunc("/accounts/search", sh.accountSearchHandler)
+
+	expectedRequest := dto.AccountSearchRequest{MinBalance: "100", MaxBalance: "500", OpenedFrom: "2020-08-01",
+		Sort: "-amount", Limit: "10"}
+	mockSearchService.EXPECT().SearchAccounts(expectedRequest).Return(&dto.AccountSearchResponse{}, nil)
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != http.StatusOK || recorder.Header().Get("Content-Type") != "application/json" {
+		t.Errorf("Expected status code %d and JSON but got %d", http.StatusOK, recorder.Result().StatusCode)
+	}
+}
@@ -0,0 +1,78 @@
+package app
+
+import (
+	"encoding/json"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/service"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+type StandingOrderHandler struct {
+	service service.StandingOrderService
+}
+
+func (h StandingOrderHandler) newStandingOrderHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	newStandingOrderRequest := dto.NewStandingOrderRequest{
+		AccountId:  vars["account_id"],
+		CustomerId: vars["customer_id"],
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&newStandingOrderRequest); err != nil {
+		logger.Error("Error while decoding json body of new standing order request: " + err.Error())
+		writeJsonResponse(w, http.StatusBadRequest, errs.NewMessageObject("Please check that all fields are correctly filled."))
+		return
+	}
+
+	if appErr := newStandingOrderRequest.Validate(); appErr != nil {
+		writeJsonResponse(w, appErr.Code, appErr.AsMessage())
+		return
+	}
+
+	response, appErr := h.service.CreateStandingOrder(newStandingOrderRequest)
+	if appErr != nil {
+		writeJsonResponse(w, appErr.Code, appErr.AsMessage())
+		return
+	}
+
+	writeJsonResponse(w, http.StatusCreated, response)
+}
+
+func (h StandingOrderHandler) standingOrdersHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	response, appErr := h.service.GetStandingOrders(vars["customer_id"])
+	if appErr != nil {
+		writeJsonResponse(w, appErr.Code, appErr.AsMessage())
+		return
+	}
+
+	writeJsonResponse(w, http.StatusOK, response)
+}
+
+func (h StandingOrderHandler) standingOrderExecutionsHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	response, appErr := h.service.GetStandingOrderExecutions(vars["customer_id"], vars["standing_order_id"])
+	if appErr != nil {
+		writeJsonResponse(w, appErr.Code, appErr.AsMessage())
+		return
+	}
+
+	writeJsonResponse(w, http.StatusOK, response)
+}
+
+func (h StandingOrderHandler) cancelStandingOrderHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	response, appErr := h.service.CancelStandingOrder(vars["customer_id"], vars["standing_order_id"])
+	if appErr != nil {
+		writeJsonResponse(w, appErr.Code, appErr.AsMessage())
+		return
+	}
+
+	writeJsonResponse(w, http.StatusOK, response)
+}
@@ -0,0 +1,128 @@
+package app
+
+import (
+	"bytes"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/mocks/service"
+	"github.com/gorilla/mux"
+	"go.uber.org/mock/gomock"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// Test common variables and inputs
+var mockStandingOrderService *service.MockStandingOrderService
+var soh StandingOrderHandler
+
+const newStandingOrderPath = "/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}/standing-orders"
+const dummyNewStandingOrderPath = "/customers/2/account/1977/standing-orders"
+const dummyNewStandingOrderPayload = `{"destination_account_id": "1980", "amount": 100, "schedule_type": "monthly", "day_of_month": 15}`
+const cancelStandingOrderPath = "/customers/{customer_id:[0-9]+}/standing-orders/{standing_order_id:[0-9]+}/cancel"
+const dummyCancelStandingOrderPath = "/customers/2/standing-orders/5/cancel"
+const dummyStandingOrderId = "5"
+
+func setupStandingOrderHandlerTest(t *testing.T, path string, payload string) func() {
+	ctrl := gomock.NewController(t)
+	mockStandingOrderService = service.NewMockStandingOrderService(ctrl)
+	soh = StandingOrderHandler{mockStandingOrderService}
+
+	router = mux.NewRouter()
+
+	recorder = httptest.NewRecorder()
+	request = httptest.NewRequest(http.MethodPost, path, bytes.NewBuffer([]byte(payload)))
+
+	return func() {
+		router = nil
+		recorder = nil
+		request = nil
+		defer ctrl.Finish()
+	}
+}
+
+func TestStandingOrderHandler_newStandingOrderHandler_respondsWith_errorStatusCode_when_dayOfMonth_missing(t *testing.T) {
+	//Arrange
+	teardown := setupStandingOrderHandlerTest(t, dummyNewStandingOrderPath,
+		`{"destination_account_id": "1980", "amount": 100, "schedule_type": "monthly"}`)
+	defer teardown()
+	router.HandleFunc(newStandingOrderPath, soh.newStandingOrderHandler)
+
+	mockStandingOrderService.EXPECT().CreateStandingOrder(gomock.Any()).Times(0)
+	expectedStatusCode := http.StatusUnprocessableEntity
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != expectedStatusCode {
+		t.Errorf("Expected status code %d but got %d", expectedStatusCode, recorder.Result().StatusCode)
+	}
+}
+
+func TestStandingOrderHandler_newStandingOrderHandler_respondsWith_newStandingOrderAndStatusCode201_when_service_succeeds(t *testing.T) {
+	//Arrange
+	teardown := setupStandingOrderHandlerTest(t, dummyNewStandingOrderPath, dummyNewStandingOrderPayload)
+	defer teardown()
+	router.HandleFunc(newStandingOrderPath, soh.newStandingOrderHandler)
+
+	dummyRequest := dto.NewStandingOrderRequest{AccountId: dummyAccountId, CustomerId: dummyCustomerId,
+		DestinationAccountId: "1980", Amount: 100, ScheduleType: dto.StandingOrderScheduleMonthly, DayOfMonth: 15}
+	dummyResponse := dto.StandingOrderResponse{StandingOrderId: dummyStandingOrderId, Status: "active"}
+	mockStandingOrderService.EXPECT().CreateStandingOrder(dummyRequest).Return(&dummyResponse, nil)
+	expectedStatusCode := http.StatusCreated
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != expectedStatusCode {
+		t.Errorf("Expected status code %d but got %d", expectedStatusCode, recorder.Result().StatusCode)
+	}
+	actualResponse, _ := io.ReadAll(recorder.Result().Body)
+	if !strings.Contains(string(actualResponse), `"standing_order_id":"5"`) {
+		t.Errorf("Expected response to contain the new standing order but got %s", actualResponse)
+	}
+}
+
+func TestStandingOrderHandler_newStandingOrderHandler_ignores_ids_in_body(t *testing.T) {
+	//Arrange
+	teardown := setupStandingOrderHandlerTest(t, dummyNewStandingOrderPath, `{"account_id": "95470", "customer_id": "2000", `+
+		`"destination_account_id": "1980", "amount": 100, "schedule_type": "monthly", "day_of_month": 15}`)
+	defer teardown()
+	router.HandleFunc(newStandingOrderPath, soh.newStandingOrderHandler)
+
+	dummyRequest := dto.NewStandingOrderRequest{AccountId: dummyAccountId, CustomerId: dummyCustomerId,
+		DestinationAccountId: "1980", Amount: 100, ScheduleType: dto.StandingOrderScheduleMonthly, DayOfMonth: 15}
+	mockStandingOrderService.EXPECT().CreateStandingOrder(dummyRequest).
+		Return(&dto.StandingOrderResponse{StandingOrderId: dummyStandingOrderId}, nil)
+	expectedStatusCode := http.StatusCreated
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != expectedStatusCode {
+		t.Errorf("Expected status code %d but got %d", expectedStatusCode, recorder.Result().StatusCode)
+	}
+}
+
+func TestStandingOrderHandler_cancelStandingOrderHandler_respondsWith_errorStatusCode_when_service_fails(t *testing.T) {
+	//Arrange
+	teardown := setupStandingOrderHandlerTest(t, dummyCancelStandingOrderPath, "")
+	defer teardown()
+	router.HandleFunc(cancelStandingOrderPath, soh.cancelStandingOrderHandler)
+
+	dummyAppError := errs.NewConflictError("Standing order is already cancelled")
+	mockStandingOrderService.EXPECT().CancelStandingOrder(dummyCustomerId, dummyStandingOrderId).Return(nil, dummyAppError)
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != dummyAppError.Code {
+		t.Errorf("Expected status code %d but got %d", dummyAppError.Code, recorder.Result().StatusCode)
+	}
+}
@@ -0,0 +1,63 @@
+package app
+
+import (
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/service"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+type StatementHandler struct {
+	service service.StatementService
+}
+
+func (h StatementHandler) statementHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	q := r.URL.Query()
+	request := dto.StatementRequest{
+		CustomerId: vars["customer_id"],
+		AccountId:  vars["account_id"],
+		Format:     q.Get("format"),
+		From:       q.Get("from"),
+		To:         q.Get("to"),
+	}
+
+	response, appErr := h.service.GetStatement(request)
+	if appErr != nil {
+		writeJsonResponse(w, appErr.Code, appErr.AsMessage())
+		return
+	}
+
+	writeFileResponse(w, *response)
+}
+
+func (h StatementHandler) exportHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	q := r.URL.Query()
+	request := dto.TransactionExportRequest{
+		CustomerId: vars["customer_id"],
+		AccountId:  vars["account_id"],
+		Format:     q.Get("format"),
+		From:       q.Get("from"),
+		To:         q.Get("to"),
+	}
+
+	response, appErr := h.service.ExportTransactions(request)
+	if appErr != nil {
+		writeJsonResponse(w, appErr.Code, appErr.AsMessage())
+		return
+	}
+
+	writeFileResponse(w, *response)
+}
+
+// writeFileResponse writes the given statement or export as a file to download.
+func writeFileResponse(w http.ResponseWriter, file dto.StatementResponse) {
+	w.Header().Add("Content-Type", file.ContentType)
+	w.Header().Add("Content-Disposition", `attachment; filename="`+file.Filename+`"`)
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(file.Content); err != nil {
+		logger.Error("Error while writing file response: " + err.Error())
+	}
+}
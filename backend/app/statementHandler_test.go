@@ -0,0 +1,104 @@
+package app
+
+import (
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/mocks/service"
+	"github.com/gorilla/mux"
+	"go.uber.org/mock/gomock"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// Test common variables and inputs
+var mockStatementService *service.MockStatementService
+var sth StatementHandler
+
+const dummyStatementPath = "/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}/statement"
+
+func setupStatementHandlerTest(t *testing.T, path string) func() {
+	ctrl := gomock.NewController(t)
+	mockStatementService = service.NewMockStatementService(ctrl)
+	sth = StatementHandler{mockStatementService}
+
+	router = mux.NewRouter()
+	router.HandleFunc(dummyStatementPath, sth.statementHandler)
+
+	recorder = httptest.NewRecorder()
+	request = httptest.NewRequest(http.MethodGet, path, nil)
+
+	return func() {
+		router = nil
+		recorder = nil
+		request = nil
+		defer ctrl.Finish()
+	}
+}
+
+func TestStatementHandler_statementHandler_respondsWith_file_when_service_succeeds(t *testing.T) {
+	//Arrange
+	teardown := setupStatementHandlerTest(t, "/customers/2000/account/95470/statement?format=mt940&from=2020-08-01&to=2020-08-31")
+	defer teardown()
+
+	expectedRequest := dto.StatementRequest{CustomerId: "2000", AccountId: "95470", Format: dto.StatementFormatMt940,
+		From: "2020-08-01", To: "2020-08-31"}
+	response := dto.StatementResponse{ContentType: "text/plain; charset=us-ascii", Filename: "STMT-95470.sta",
+		Content: []byte(":20:95470-200831\r\n-\r\n")}
+	mockStatementService.EXPECT().GetStatement(expectedRequest).Return(&response, nil)
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	body, _ := io.ReadAll(recorder.Result().Body)
+	if recorder.Result().StatusCode != http.StatusOK || recorder.Header().Get("Content-Type") != response.ContentType {
+		t.Errorf("Expected status code %d and MT940 but got %d and %s", http.StatusOK, recorder.Result().StatusCode,
+			recorder.Header().Get("Content-Type"))
+	}
+	if recorder.Header().Get("Content-Disposition") != `attachment; filename="STMT-95470.sta"` ||
+		string(body) != string(response.Content) {
+		t.Errorf("Expected statement file to download but got %s", body)
+	}
+}
+
+func TestStatementHandler_statementHandler_respondsWith_error_when_service_fails(t *testing.T) {
+	//Arrange
+	teardown := setupStatementHandlerTest(t, "/customers/2000/account/95470/statement?format=ofx")
+	defer teardown()
+
+	mockStatementService.EXPECT().GetStatement(gomock.Any()).Return(nil, errs.NewValidationError("Format should be camt053 or mt940."))
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status code %d but got %d", http.StatusUnprocessableEntity, recorder.Result().StatusCode)
+	}
+}
+
+func TestStatementHandler_exportHandler_respondsWith_file_when_service_succeeds(t *testing.T) {
+	//Arrange
+	teardown := setupStatementHandlerTest(t, "/customers/2000/account/95470/export?format=ofx&from=2020-08-01")
+	defer teardown()
+	router.HandleFunc("/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}/export", sth.exportHandler)
+
+	expectedRequest := dto.TransactionExportRequest{CustomerId: "2000", AccountId: "95470", Format: dto.ExportFormatOfx,
+		From: "2020-08-01"}
+	response := dto.StatementResponse{ContentType: "application/x-ofx; charset=utf-8", Filename: "STMT-95470.ofx",
+		Content: []byte("<OFX></OFX>")}
+	mockStatementService.EXPECT().ExportTransactions(expectedRequest).Return(&response, nil)
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	body, _ := io.ReadAll(recorder.Result().Body)
+	if recorder.Result().StatusCode != http.StatusOK || recorder.Header().Get("Content-Type") != response.ContentType ||
+		string(body) != "<OFX></OFX>" {
+		t.Errorf("Expected OFX file but got %d, %s and %s", recorder.Result().StatusCode,
+			recorder.Header().Get("Content-Type"), body)
+	}
+}
@@ -0,0 +1,55 @@
+package app
+
+import (
+	"encoding/json"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/service"
+	"net/http"
+)
+
+// importMaxFileSize is the size in bytes of the largest file of transactions that can be uploaded at once.
+const importMaxFileSize = 10 << 20
+
+type TransactionImportHandler struct {
+	service service.TransactionImportService
+}
+
+// importHandler imports the file of transactions in the request body, streaming the report back as JSON lines as the
+// rows are imported. Errors found before the import starts are responded with as usual, while errors after the
+// report has started end the report instead.
+func (h TransactionImportHandler) importHandler(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	importRequest := dto.TransactionImportRequest{
+		BatchId:     q.Get("batch_id"),
+		Mode:        q.Get("mode"),
+		Format:      q.Get("format"),
+		Concurrency: q.Get("concurrency"),
+	}
+
+	started := false
+	encoder := json.NewEncoder(w)
+	flusher, _ := w.(http.Flusher)
+	writeLine := func(line dto.ImportReportLine) {
+		if !started {
+			w.Header().Add("Content-Type", "application/x-ndjson")
+			w.WriteHeader(http.StatusOK)
+			started = true
+		}
+		if err := encoder.Encode(line); err != nil {
+			logger.Error("Error while writing line of import report: " + err.Error())
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+
+	appErr := h.service.Import(importRequest, http.MaxBytesReader(w, r.Body, importMaxFileSize), writeLine)
+	if appErr != nil {
+		if !started {
+			writeJsonResponse(w, appErr.Code, appErr.AsMessage())
+			return
+		}
+		writeLine(dto.ImportReportLine{Error: appErr.Message})
+	}
+}
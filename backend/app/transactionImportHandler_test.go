@@ -0,0 +1,84 @@
+package app
+
+import (
+	"bytes"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/mocks/service"
+	"github.com/gorilla/mux"
+	"go.uber.org/mock/gomock"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// Test common variables and inputs
+var mockImportService *service.MockTransactionImportService
+var ih TransactionImportHandler
+
+const dummyImportPath = "/transactions/import?mode=best_effort&format=csv&concurrency=2"
+
+func setupTransactionImportHandlerTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	mockImportService = service.NewMockTransactionImportService(ctrl)
+	ih = TransactionImportHandler{mockImportService}
+
+	router = mux.NewRouter()
+	router.HandleFunc("/transactions/import", ih.importHandler)
+
+	recorder = httptest.NewRecorder()
+	request = httptest.NewRequest(http.MethodPost, dummyImportPath, bytes.NewBufferString("account_id,amount\n"))
+
+	return func() {
+		router = nil
+		recorder = nil
+		request = nil
+		defer ctrl.Finish()
+	}
+}
+
+func TestTransactionImportHandler_importHandler_streams_report_lines(t *testing.T) {
+	//Arrange
+	teardown := setupTransactionImportHandlerTest(t)
+	defer teardown()
+
+	expectedRequest := dto.TransactionImportRequest{Mode: "best_effort", Format: "csv", Concurrency: "2"}
+	mockImportService.EXPECT().Import(expectedRequest, gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ dto.TransactionImportRequest, _ io.Reader, report func(dto.ImportReportLine)) *errs.AppError {
+			report(dto.ImportReportLine{Batch: &dto.ImportBatchResponse{BatchId: "7"}})
+			report(dto.ImportReportLine{Row: &dto.ImportRowResponse{RowNumber: 2, Status: "posted"}})
+			return errs.NewValidationError("File could not be read to the end. Resume the batch to import the rest.")
+		})
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	lines := strings.Split(strings.TrimSpace(recorder.Body.String()), "\n")
+	if recorder.Result().StatusCode != http.StatusOK || recorder.Header().Get("Content-Type") != "application/x-ndjson" {
+		t.Errorf("Expected status code %d and JSON lines but got %d", http.StatusOK, recorder.Result().StatusCode)
+	}
+	if len(lines) != 3 || !strings.Contains(lines[1], `"row":2`) || !strings.Contains(lines[2], `"error":"File could not`) {
+		t.Errorf("Expected batch, row and error lines but got %v", lines)
+	}
+}
+
+func TestTransactionImportHandler_importHandler_respondsWith_errorStatusCode_when_import_not_started(t *testing.T) {
+	//Arrange
+	teardown := setupTransactionImportHandlerTest(t)
+	defer teardown()
+
+	mockImportService.EXPECT().Import(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(errs.NewValidationError("CSV header is missing the transaction_type column."))
+	expectedStatusCode := http.StatusUnprocessableEntity
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != expectedStatusCode || recorder.Header().Get("Content-Type") != "application/json" {
+		t.Errorf("Expected status code %d but got %d", expectedStatusCode, recorder.Result().StatusCode)
+	}
+}
@@ -0,0 +1,124 @@
+package app
+
+import (
+	"bytes"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/mocks/service"
+	"github.com/gorilla/mux"
+	"go.uber.org/mock/gomock"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// Test common variables and inputs
+var mockWebhookService *service.MockWebhookService
+var wh WebhookHandler
+
+const newWebhookPath = "/customers/{customer_id:[0-9]+}/webhooks"
+const dummyNewWebhookPath = "/customers/2/webhooks"
+const dummyNewWebhookPayload = `{"url": "https://example.com/hooks", "event_types": ["TransactionPosted"], "secret": "0123456789abcdef"}`
+const replayDeliveryPath = "/customers/{customer_id:[0-9]+}/webhooks/{webhook_id:[0-9]+}/deliveries/{delivery_id:[0-9]+}/replay"
+const dummyReplayDeliveryPath = "/customers/2/webhooks/5/deliveries/8/replay"
+
+func setupWebhookHandlerTest(t *testing.T, path string, payload string) func() {
+	ctrl := gomock.NewController(t)
+	mockWebhookService = service.NewMockWebhookService(ctrl)
+	wh = WebhookHandler{mockWebhookService}
+
+	router = mux.NewRouter()
+
+	recorder = httptest.NewRecorder()
+	request = httptest.NewRequest(http.MethodPost, path, bytes.NewBuffer([]byte(payload)))
+
+	return func() {
+		router = nil
+		recorder = nil
+		request = nil
+		defer ctrl.Finish()
+	}
+}
+
+func TestWebhookHandler_newWebhookHandler_respondsWith_errorStatusCode_when_secret_too_short(t *testing.T) {
+	//Arrange
+	teardown := setupWebhookHandlerTest(t, dummyNewWebhookPath, `{"url": "https://example.com/hooks", "secret": "secret"}`)
+	defer teardown()
+	router.HandleFunc(newWebhookPath, wh.newWebhookHandler)
+
+	mockWebhookService.EXPECT().CreateWebhook(gomock.Any()).Times(0)
+	expectedStatusCode := http.StatusUnprocessableEntity
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != expectedStatusCode {
+		t.Errorf("Expected status code %d but got %d", expectedStatusCode, recorder.Result().StatusCode)
+	}
+}
+
+func TestWebhookHandler_newWebhookHandler_respondsWith_newWebhookAndStatusCode201_when_service_succeeds(t *testing.T) {
+	//Arrange
+	teardown := setupWebhookHandlerTest(t, dummyNewWebhookPath, dummyNewWebhookPayload)
+	defer teardown()
+	router.HandleFunc(newWebhookPath, wh.newWebhookHandler)
+
+	dummyRequest := dto.NewWebhookRequest{CustomerId: dummyCustomerId, Url: "https://example.com/hooks",
+		EventTypes: []string{"TransactionPosted"}, Secret: "0123456789abcdef"}
+	dummyResponse := dto.WebhookResponse{WebhookId: "5", Url: dummyRequest.Url, EventTypes: dummyRequest.EventTypes, Status: "active"}
+	mockWebhookService.EXPECT().CreateWebhook(dummyRequest).Return(&dummyResponse, nil)
+	expectedStatusCode := http.StatusCreated
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != expectedStatusCode {
+		t.Errorf("Expected status code %d but got %d", expectedStatusCode, recorder.Result().StatusCode)
+	}
+	actualResponse, _ := io.ReadAll(recorder.Result().Body)
+	if !strings.Contains(string(actualResponse), `"webhook_id":"5"`) || strings.Contains(string(actualResponse), "secret") {
+		t.Errorf("Expected response to contain the new webhook without its secret but got %s", actualResponse)
+	}
+}
+
+func TestWebhookHandler_newWebhookHandler_ignores_customerId_in_body(t *testing.T) {
+	//Arrange
+	teardown := setupWebhookHandlerTest(t, dummyNewWebhookPath,
+		`{"customer_id": "2000", "url": "https://example.com/hooks", "secret": "0123456789abcdef"}`)
+	defer teardown()
+	router.HandleFunc(newWebhookPath, wh.newWebhookHandler)
+
+	dummyRequest := dto.NewWebhookRequest{CustomerId: dummyCustomerId, Url: "https://example.com/hooks", Secret: "0123456789abcdef"}
+	mockWebhookService.EXPECT().CreateWebhook(dummyRequest).Return(&dto.WebhookResponse{WebhookId: "5"}, nil)
+	expectedStatusCode := http.StatusCreated
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != expectedStatusCode {
+		t.Errorf("Expected status code %d but got %d", expectedStatusCode, recorder.Result().StatusCode)
+	}
+}
+
+func TestWebhookHandler_replayDeliveryHandler_respondsWith_errorStatusCode_when_service_fails(t *testing.T) {
+	//Arrange
+	teardown := setupWebhookHandlerTest(t, dummyReplayDeliveryPath, "")
+	defer teardown()
+	router.HandleFunc(replayDeliveryPath, wh.replayDeliveryHandler)
+
+	dummyAppErr := errs.NewConflictError("Delivery is still pending")
+	mockWebhookService.EXPECT().ReplayDelivery(dummyCustomerId, "5", "8").Return(nil, dummyAppErr)
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != dummyAppErr.Code {
+		t.Errorf("Expected status code %d but got %d", dummyAppErr.Code, recorder.Result().StatusCode)
+	}
+}
@@ -0,0 +1,2 @@
+-- The schema and seed data are created by the backend's migrations, e.g. "go run main.go migrate up".
+CREATE DATABASE IF NOT EXISTS banking;
@@ -9,19 +9,24 @@ import (
 //Business Domain
 
 type Account struct { //business/domain object
-	AccountId   string  `db:"account_id"`
-	CustomerId  string  `db:"customer_id"`
-	OpeningDate string  `db:"opening_date"`
-	AccountType string  `db:"account_type"`
-	Amount      float64 `db:"amount"`
-	Status      string  `db:"status"`
+	AccountId      string  `db:"account_id" json:"account_id"`
+	CustomerId     string  `db:"customer_id" json:"customer_id"`
+	OpeningDate    string  `db:"opening_date" json:"opening_date"`
+	AccountType    string  `db:"account_type" json:"account_type"`
+	Currency       string  `db:"currency" json:"currency"` //ISO 4217 code of the currency that all amounts of the account are in
+	Amount         float64 `db:"amount" json:"amount"`
+	Status         string  `db:"status" json:"status"`
+	OverdraftLimit float64 `db:"overdraft_limit" json:"overdraft_limit"`
+	HeldAmount     float64 `db:"held_amount" json:"held_amount"` //total amount reserved by active holds
+	HolderRole     string  `db:"holder_role" json:"-"`           //role of the customer the account was listed for, if any
 }
 
-func NewAccount(customerId string, accountType string, amount float64, c clock.Clock) Account {
+func NewAccount(customerId string, accountType string, currency string, amount float64, c clock.Clock) Account {
 	return Account{
 		CustomerId:  customerId,
 		OpeningDate: c.NowAsString(),
 		AccountType: accountType,
+		Currency:    currency,
 		Amount:      amount,
 		Status:      "1", //default for newly-created account
 	}
@@ -29,10 +34,31 @@ func NewAccount(customerId string, accountType string, amount float64, c clock.C
 
 func (a Account) ToDTO() *dto.AccountResponse {
 	return &dto.AccountResponse{
-		AccountId:   a.AccountId,
-		OpeningDate: a.OpeningDate,
-		AccountType: a.AccountType,
-		Amount:      a.Amount,
+		AccountId:        a.AccountId,
+		OpeningDate:      a.OpeningDate,
+		AccountType:      a.AccountType,
+		Currency:         a.Currency,
+		Amount:           a.Amount,
+		LedgerBalance:    a.Amount,
+		AvailableBalance: a.AvailableBalance(),
+		OverdraftLimit:   a.OverdraftLimit,
+		OverdraftUsed:    a.OverdraftUsed(),
+		Role:             a.HolderRole,
+	}
+}
+
+// ToSearchResultDTO converts the account to how it is shown to admins searching for accounts.
+func (a Account) ToSearchResultDTO() dto.AccountSearchResult {
+	return dto.AccountSearchResult{
+		AccountId:        a.AccountId,
+		CustomerId:       a.CustomerId,
+		OpeningDate:      a.OpeningDate,
+		AccountType:      a.AccountType,
+		Currency:         a.Currency,
+		Amount:           a.Amount,
+		AvailableBalance: a.AvailableBalance(),
+		OverdraftLimit:   a.OverdraftLimit,
+		Status:           Customer{Status: a.Status}.AsStatusName(), //accounts use the same status values as customers
 	}
 }
 
@@ -40,8 +66,33 @@ func (a Account) ToNewAccountResponseDTO() *dto.NewAccountResponse {
 	return &dto.NewAccountResponse{AccountId: a.AccountId, OpeningDate: a.OpeningDate}
 }
 
+// CanWithdraw checks whether the account has enough available funds for the given withdrawal amount. Checking
+// accounts can go down to the negative of their overdraft limit, while all other accounts can only go down to zero.
 func (a Account) CanWithdraw(withdrawalAmount float64) bool {
-	return a.Amount >= withdrawalAmount
+	return a.AvailableBalance()+a.AvailableOverdraftLimit() >= withdrawalAmount
+}
+
+// AvailableBalance returns the balance that can be spent, which is the ledger balance less the amount reserved by
+// active holds.
+func (a Account) AvailableBalance() float64 {
+	return a.Amount - a.HeldAmount
+}
+
+// AvailableOverdraftLimit returns the overdraft limit that applies to the account, which is always zero for accounts
+// that are not checking accounts.
+func (a Account) AvailableOverdraftLimit() float64 {
+	if a.AccountType != dto.AccountTypeChecking {
+		return 0
+	}
+	return a.OverdraftLimit
+}
+
+// OverdraftUsed returns how much the account is overdrawn by, or zero if the balance is not negative.
+func (a Account) OverdraftUsed() float64 {
+	if a.Amount >= 0 {
+		return 0
+	}
+	return -a.Amount
 }
 
 //Server
@@ -51,5 +102,11 @@ type AccountRepository interface { //repo (secondary port)
 	Save(Account) (*Account, *errs.AppError)
 	FindAll(string) ([]Account, *errs.AppError)
 	FindById(string) (*Account, *errs.AppError)
+	FindHolder(string, string) (*AccountHolder, *errs.AppError)
 	Transact(Transaction) (*Transaction, *errs.AppError)
+	Transfer(Transaction, Transaction) (*Transaction, *errs.AppError)
+	FindTransactionById(string) (*Transaction, *errs.AppError)
+	FindTransactions(string) ([]Transaction, *errs.AppError)
+	Reverse(Transaction, Reversal) (*Transaction, *errs.AppError)
+	UpdateOverdraftLimit(string, float64) *errs.AppError
 }
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/dto"
+)
+
+//Business Domain
+
+// AccountHolder is a customer who holds an account. Every account has one primary holder, the customer it was opened
+// for, and can have any number of joint and view-only holders.
+type AccountHolder struct {
+	AccountId    string `db:"account_id"`
+	CustomerId   string `db:"customer_id"`
+	Role         string `db:"role"`
+	CreationDate string `db:"creation_date"`
+}
+
+func NewAccountHolder(request dto.AccountHolderRequest, clk clock.Clock) AccountHolder {
+	return AccountHolder{
+		AccountId:    request.AccountId,
+		CustomerId:   request.HolderCustomerId,
+		Role:         request.Role,
+		CreationDate: clk.NowAsString(),
+	}
+}
+
+// IsPrimary checks whether the holder is the account's primary holder, who may manage its holders.
+func (h AccountHolder) IsPrimary() bool {
+	return h.Role == dto.AccountHolderRolePrimary
+}
+
+// CanTransact checks whether the holder may move money in and out of the account, which view-only holders may not.
+func (h AccountHolder) CanTransact() bool {
+	return h.Role == dto.AccountHolderRolePrimary || h.Role == dto.AccountHolderRoleJoint
+}
+
+func (h AccountHolder) ToDTO() dto.AccountHolderResponse {
+	return dto.AccountHolderResponse{
+		AccountId:    h.AccountId,
+		CustomerId:   h.CustomerId,
+		Role:         h.Role,
+		CreationDate: h.CreationDate,
+	}
+}
+
+//Server
+
+//go:generate mockgen -destination=../mocks/domain/mock_accountHolderRepository.go -package=domain github.com/aliciatay-zls/banking/backend/domain AccountHolderRepository
+type AccountHolderRepository interface { //repo (secondary port)
+	FindHolders(string) ([]AccountHolder, *errs.AppError)
+	SaveHolder(AccountHolder) *errs.AppError
+	RemoveHolder(string, string) *errs.AppError
+}
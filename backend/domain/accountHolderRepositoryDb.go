@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/jmoiron/sqlx"
+)
+
+//Server
+
+type AccountHolderRepositoryDb struct { //DB (adapter)
+	client dbExecutor
+}
+
+func NewAccountHolderRepositoryDb(dbClient *sqlx.DB) AccountHolderRepositoryDb {
+	return AccountHolderRepositoryDb{dbClient}
+}
+
+// FindHolders retrieves all holders of the account with the given id, in the order they were added, so the primary
+// holder comes first.
+func (d AccountHolderRepositoryDb) FindHolders(accountId string) ([]AccountHolder, *errs.AppError) { //DB implements repo
+	return findHolders(d.client, "SELECT * FROM account_holders", accountId)
+}
+
+// SaveHolder creates a new entry in the database for the given account holder. It returns a conflict error if the
+// customer already holds the account.
+func (d AccountHolderRepositoryDb) SaveHolder(h AccountHolder) *errs.AppError { //DB implements repo
+	return saveHolder(d.client, h)
+}
+
+// RemoveHolder removes the customer with the given customer id from the holders of the account with the given account
+// id. The primary holder is never removed, and a not found error is returned if the customer is not another holder.
+func (d AccountHolderRepositoryDb) RemoveHolder(accountId string, customerId string) *errs.AppError { //DB implements repo
+	return removeHolder(d.client, accountId, customerId)
+}
+
+// findHolders retrieves all holders of the account with the given id with the given select statement, in the order
+// they were added.
+func findHolders(client dbExecutor, selectSql string, accountId string) ([]AccountHolder, *errs.AppError) {
+	holders := make([]AccountHolder, 0)
+	selectSql += " WHERE account_id = ? ORDER BY creation_date, customer_id"
+	if err := client.Select(&holders, client.Rebind(selectSql), accountId); err != nil {
+		logger.Error("Error while retrieving holders of account: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return holders, nil
+}
+
+func saveHolder(client dbExecutor, h AccountHolder) *errs.AppError {
+	var count int
+	countSql := "SELECT COUNT(*) FROM account_holders WHERE account_id = ? AND customer_id = ?"
+	if err := client.Get(&count, client.Rebind(countSql), h.AccountId, h.CustomerId); err != nil {
+		logger.Error("Error while checking for existing account holder: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	if count > 0 {
+		return errs.NewConflictError("Customer already holds this account")
+	}
+
+	insertSql := "INSERT INTO account_holders (account_id, customer_id, role, creation_date) VALUES (?, ?, ?, ?)"
+	if _, err := client.Exec(client.Rebind(insertSql), h.AccountId, h.CustomerId, h.Role, h.CreationDate); err != nil {
+		logger.Error("Error while creating new account holder: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	return nil
+}
+
+func removeHolder(client dbExecutor, accountId string, customerId string) *errs.AppError {
+	deleteSql := "DELETE FROM account_holders WHERE account_id = ? AND customer_id = ? AND role <> ?"
+	result, err := client.Exec(client.Rebind(deleteSql), accountId, customerId, dto.AccountHolderRolePrimary)
+	if err != nil {
+		logger.Error("Error while removing account holder: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		logger.Error("Error while getting number of account holders removed: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	if rowsAffected == 0 {
+		return errs.NewNotFoundError("Account holder not found")
+	}
+	return nil
+}
@@ -0,0 +1,196 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"net/http"
+	"testing"
+)
+
+// These tests run on a real SQLite database seeded with the demo data, since the uniqueness of holders and the
+// protection of primary holders are only enforced when the SQL is executed, which go-sqlmock never does.
+
+var accountHolderRepoDb AccountHolderRepositoryDb
+var accountHolderAccRepoDb AccountRepositoryDb
+
+func setupAccountHolderRepoDbTest(t *testing.T) {
+	logger.MuteLogger()
+	client := openSQLiteDb(t)
+	accountHolderRepoDb = NewAccountHolderRepositoryDb(client)
+	accountHolderAccRepoDb = NewAccountRepositoryDb(client)
+}
+
+// saveDummyJointHolder adds customer 2000 as a joint holder of account 95472 of customer 2001.
+func saveDummyJointHolder(t *testing.T) AccountHolder {
+	joint := NewAccountHolder(dto.AccountHolderRequest{AccountId: "95472", CustomerId: "2001", HolderCustomerId: "2000",
+		Role: dto.AccountHolderRoleJoint}, clock.StaticClock{})
+	if err := accountHolderRepoDb.SaveHolder(joint); err != nil {
+		t.Fatal("Expected no error but got error while saving holder: " + err.Message)
+	}
+	return joint
+}
+
+func TestAccountHolderRepositoryDb_SaveHolder_returns_conflictError_when_customer_alreadyHolder(t *testing.T) {
+	//Arrange
+	setupAccountHolderRepoDbTest(t)
+	joint := saveDummyJointHolder(t)
+
+	//Act
+	err := accountHolderRepoDb.SaveHolder(joint)
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none")
+	}
+	if err.Code != http.StatusConflict {
+		t.Errorf("Expected status code %d but got %d", http.StatusConflict, err.Code)
+	}
+}
+
+func TestAccountHolderRepositoryDb_FindHolders_returns_allHolders_in_orderOfCreation(t *testing.T) {
+	//Arrange
+	setupAccountHolderRepoDbTest(t)
+	joint := saveDummyJointHolder(t)
+
+	//Act
+	holders, err := accountHolderRepoDb.FindHolders("95472")
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if len(holders) != 2 || holders[0] != joint || holders[1].CustomerId != "2001" || !holders[1].IsPrimary() {
+		t.Errorf("Expected joint holder 2000 added in 2006 and primary holder 2001 but got %+v", holders)
+	}
+}
+
+func TestAccountHolderRepositoryDb_RemoveHolder_removes_jointHolder(t *testing.T) {
+	//Arrange
+	setupAccountHolderRepoDbTest(t)
+	saveDummyJointHolder(t)
+
+	//Act
+	err := accountHolderRepoDb.RemoveHolder("95472", "2000")
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	_, err = accountHolderAccRepoDb.FindHolder("95472", "2000")
+	if err == nil || err.Code != http.StatusNotFound {
+		t.Errorf("Expected not found error for removed holder but got %v", err)
+	}
+}
+
+func TestAccountHolderRepositoryDb_RemoveHolder_returns_notFoundError_when_holder_primary(t *testing.T) {
+	//Arrange
+	setupAccountHolderRepoDbTest(t)
+	saveDummyJointHolder(t)
+
+	//Act
+	err := accountHolderRepoDb.RemoveHolder("95472", "2001")
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none")
+	}
+	if err.Code != http.StatusNotFound {
+		t.Errorf("Expected status code %d but got %d", http.StatusNotFound, err.Code)
+	}
+}
+
+func TestAccountRepositoryDb_FindAll_includes_jointAccounts(t *testing.T) {
+	//Arrange
+	setupAccountHolderRepoDbTest(t)
+	saveDummyJointHolder(t)
+
+	//Act
+	accounts, err := accountHolderAccRepoDb.FindAll("2000")
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if len(accounts) != 2 || accounts[0].AccountId != "95470" || accounts[0].HolderRole != dto.AccountHolderRolePrimary ||
+		accounts[1].AccountId != "95472" || accounts[1].HolderRole != dto.AccountHolderRoleJoint {
+		t.Errorf("Expected own account 95470 and joint account 95472 but got %+v", accounts)
+	}
+}
+
+func TestAccountRepositoryDb_FindHolder_returns_jointHolder(t *testing.T) {
+	//Arrange
+	setupAccountHolderRepoDbTest(t)
+	joint := saveDummyJointHolder(t)
+
+	//Act
+	holder, err := accountHolderAccRepoDb.FindHolder("95472", "2000")
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if *holder != joint {
+		t.Errorf("Expected holder %+v but got %+v", joint, *holder)
+	}
+}
+
+func TestAccountRepositoryDb_FindHolder_returns_notFoundError_when_customer_notHolder(t *testing.T) {
+	//Arrange
+	setupAccountHolderRepoDbTest(t)
+
+	//Act
+	_, err := accountHolderAccRepoDb.FindHolder("95471", "2000")
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none")
+	}
+	if err.Code != http.StatusNotFound {
+		t.Errorf("Expected status code %d but got %d", http.StatusNotFound, err.Code)
+	}
+}
+
+func TestAccountRepositoryDb_Save_adds_primaryHolder_of_newAccount(t *testing.T) {
+	//Arrange
+	setupAccountHolderRepoDbTest(t)
+	account := NewAccount("2004", dto.AccountTypeSaving, dto.DefaultCurrency, 5000, clock.StaticClock{})
+
+	//Act
+	saved, err := accountHolderAccRepoDb.Save(account)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	holder, err := accountHolderAccRepoDb.FindHolder(saved.AccountId, "2004")
+	if err != nil {
+		t.Fatal("Expected no error but got error while finding holder: " + err.Message)
+	}
+	if !holder.IsPrimary() {
+		t.Errorf("Expected customer 2004 to be primary holder but got %+v", *holder)
+	}
+}
+
+func TestAccountRepositoryDb_Transact_records_actingCustomer(t *testing.T) {
+	//Arrange
+	setupAccountHolderRepoDbTest(t)
+	saveDummyJointHolder(t)
+	transaction := NewTransaction("95472", 100, dto.TransactionTypeDeposit, clock.StaticClock{})
+	transaction.ActedBy("2000")
+
+	//Act
+	_, err := accountHolderAccRepoDb.Transact(transaction)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	transactions, err := accountHolderAccRepoDb.FindTransactions("95472")
+	if err != nil {
+		t.Fatal("Expected no error but got error while finding transactions: " + err.Message)
+	}
+	if len(transactions) == 0 || transactions[len(transactions)-1].ActingCustomer.String != "2000" {
+		t.Errorf("Expected last transaction to be made by customer 2000 but got %+v", transactions)
+	}
+}
@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/jmoiron/sqlx"
+)
+
+//Server
+
+// selectAccountHoldersPostgresSql selects the columns of account holders, formatting dates the same way MySQL returns
+// them.
+const selectAccountHoldersPostgresSql = "SELECT account_id, customer_id, role, " +
+	"to_char(creation_date, 'YYYY-MM-DD HH24:MI:SS') AS creation_date FROM account_holders"
+
+type AccountHolderRepositoryPostgres struct { //DB (adapter)
+	client dbExecutor
+}
+
+func NewAccountHolderRepositoryPostgres(dbClient *sqlx.DB) AccountHolderRepositoryPostgres {
+	return AccountHolderRepositoryPostgres{dbClient}
+}
+
+// FindHolders retrieves all holders of the account with the given id, in the order they were added, so the primary
+// holder comes first.
+func (d AccountHolderRepositoryPostgres) FindHolders(accountId string) ([]AccountHolder, *errs.AppError) { //DB implements repo
+	return findHolders(d.client, selectAccountHoldersPostgresSql, accountId)
+}
+
+// SaveHolder creates a new entry in the database for the given account holder. It returns a conflict error if the
+// customer already holds the account.
+func (d AccountHolderRepositoryPostgres) SaveHolder(h AccountHolder) *errs.AppError { //DB implements repo
+	return saveHolder(d.client, h)
+}
+
+// RemoveHolder removes the customer with the given customer id from the holders of the account with the given account
+// id. The primary holder is never removed, and a not found error is returned if the customer is not another holder.
+func (d AccountHolderRepositoryPostgres) RemoveHolder(accountId string, customerId string) *errs.AppError { //DB implements repo
+	return removeHolder(d.client, accountId, customerId)
+}
@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/jmoiron/sqlx"
+	"testing"
+)
+
+// Test common variables and inputs
+var accountHolderRepoPostgres AccountHolderRepositoryPostgres
+
+func setupAccountHolderRepoPostgresTest(t *testing.T) func() {
+	teardown := setupDB(t)
+	accountHolderRepoPostgres = NewAccountHolderRepositoryPostgres(sqlx.NewDb(db, "postgres"))
+	return teardown
+}
+
+func TestAccountHolderRepositoryPostgres_FindHolders_returns_holders_with_formattedDates(t *testing.T) {
+	//Arrange
+	teardown := setupAccountHolderRepoPostgresTest(t)
+	defer teardown()
+
+	rows := sqlmock.NewRows([]string{"account_id", "customer_id", "role", "creation_date"}).
+		AddRow(dummyAccountId, dummyCustomerId, dto.AccountHolderRolePrimary, dummyDate)
+	mockDB.ExpectQuery(selectAccountHoldersPostgresSql + " WHERE account_id = $1 ORDER BY creation_date, customer_id").
+		WithArgs(dummyAccountId).WillReturnRows(rows)
+
+	//Act
+	holders, err := accountHolderRepoPostgres.FindHolders(dummyAccountId)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if len(holders) != 1 || holders[0].Role != dto.AccountHolderRolePrimary || holders[0].CreationDate != dummyDate {
+		t.Errorf("Expected the primary holder added on %s but got %+v", dummyDate, holders)
+	}
+}
+
+func TestAccountHolderRepositoryPostgres_RemoveHolder_returns_notFoundError_when_customer_notOtherHolder(t *testing.T) {
+	//Arrange
+	teardown := setupAccountHolderRepoPostgresTest(t)
+	defer teardown()
+
+	mockDB.ExpectExec("DELETE FROM account_holders WHERE account_id = $1 AND customer_id = $2 AND role <> $3").
+		WithArgs(dummyAccountId, dummyCustomerId, dto.AccountHolderRolePrimary).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	//Act
+	err := accountHolderRepoPostgres.RemoveHolder(dummyAccountId, dummyCustomerId)
+
+	//Assert
+	if err == nil || err.Message != "Account holder not found" {
+		t.Errorf("Expected not found error but got %v", err)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"github.com/aliciatay-zls/banking-lib/errs"
 	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
 	"github.com/jmoiron/sqlx"
 	"strconv"
 )
@@ -12,38 +13,54 @@ import (
 //Server
 
 type AccountRepositoryDb struct { //DB (adapter)
-	client *sqlx.DB
+	client dbExecutor
 }
 
 func NewAccountRepositoryDb(dbClient *sqlx.DB) AccountRepositoryDb {
 	return AccountRepositoryDb{dbClient}
 }
 
-// Save creates a new entry in the database for the given account, sets its ID using the database-generated ID
-// and returns the account.
+// Save starts a database transaction, creates a new entry in the database for the given account, sets its ID using
+// the database-generated ID, writes the event for the opening of the account to the outbox and commits the database
+// transaction. Save returns the account.
 func (d AccountRepositoryDb) Save(account Account) (*Account, *errs.AppError) { //DB implements repo
-	addAccountSql := "INSERT INTO accounts (customer_id, opening_date, account_type, amount, status) VALUES (?, ?, ?, ?, ?)"
-	result, err := d.client.Exec(addAccountSql,
-		account.CustomerId, account.OpeningDate, account.AccountType, account.Amount, account.Status)
-	if err != nil {
-		logger.Error("Error while creating new account: " + err.Error())
-		return nil, errs.NewUnexpectedError("Unexpected database error")
-	}
+	appErr := inTransaction(d.client, "creating new account", func(tx dbExecutor) *errs.AppError {
+		addAccountSql := "INSERT INTO accounts (customer_id, opening_date, account_type, currency, amount, status) VALUES (?, ?, ?, ?, ?, ?)"
+		result, err := tx.Exec(addAccountSql,
+			account.CustomerId, account.OpeningDate, account.AccountType, account.Currency, account.Amount, account.Status)
+		if err != nil {
+			logger.Error("Error while creating new account: " + err.Error())
+			return errs.NewUnexpectedError("Unexpected database error")
+		}
 
-	id, err := result.LastInsertId()
-	if err != nil {
-		logger.Error("Error while getting id of newly inserted account: " + err.Error())
-		return nil, errs.NewUnexpectedError("Unexpected database error")
+		id, err := result.LastInsertId()
+		if err != nil {
+			logger.Error("Error while getting id of newly inserted account: " + err.Error())
+			return errs.NewUnexpectedError("Unexpected database error")
+		}
+		account.AccountId = strconv.FormatInt(id, 10)
+
+		addHolderSql := "INSERT INTO account_holders (account_id, customer_id, role, creation_date) VALUES (?, ?, ?, ?)"
+		if _, err = tx.Exec(addHolderSql, account.AccountId, account.CustomerId, dto.AccountHolderRolePrimary,
+			account.OpeningDate); err != nil {
+			logger.Error("Error while adding primary holder of new account: " + err.Error())
+			return errs.NewUnexpectedError("Unexpected database error")
+		}
+
+		return saveEvent(tx, NewAccountOpenedEvent(account))
+	})
+	if appErr != nil {
+		return nil, appErr
 	}
-	account.AccountId = strconv.FormatInt(id, 10)
 
 	return &account, nil
 }
 
-// FindAll retrieves all accounts belonging to the customer with the given id.
+// FindAll retrieves all accounts held by the customer with the given id, whatever their role, along with the role.
 func (d AccountRepositoryDb) FindAll(customerId string) ([]Account, *errs.AppError) {
 	accounts := make([]Account, 0)
-	selectSql := "SELECT * FROM accounts WHERE customer_id = ?"
+	selectSql := "SELECT a.*, h.role AS holder_role FROM accounts a " +
+		"JOIN account_holders h ON h.account_id = a.account_id WHERE h.customer_id = ? ORDER BY a.account_id"
 	err := d.client.Select(&accounts, selectSql, customerId)
 	if err != nil {
 		logger.Error("Error while retrieving all accounts belonging to this customer: " + err.Error())
@@ -73,61 +90,216 @@ func (d AccountRepositoryDb) FindById(accountId string) (*Account, *errs.AppErro
 	return &account, nil
 }
 
+// FindHolder retrieves the customer with the given customer id as a holder of the account with the given account id.
+// It returns a not found error if the customer does not hold the account.
+func (d AccountRepositoryDb) FindHolder(accountId string, customerId string) (*AccountHolder, *errs.AppError) {
+	var holder AccountHolder
+	selectSql := "SELECT * FROM account_holders WHERE account_id = ? AND customer_id = ?"
+	if err := d.client.Get(&holder, selectSql, accountId, customerId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.NewNotFoundError("Account not found")
+		}
+		logger.Error("Error while retrieving account holder: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return &holder, nil
+}
+
 // Transact starts a database transaction, updates the account balance, creates a new entry in the database for
-// the given bank transaction and commits the database transaction. It then fills the missing fields of the given
-// bank transaction by retrieving the ID of the new entry as well as the new account balance.
+// the given bank transaction, writes the event for it to the outbox and commits the database transaction. It fills
+// the missing fields of the given bank transaction by retrieving the ID of the new entry as well as the new account
+// balance before committing, so that the balance is not affected by other bank transactions made in the meantime.
 // Transact returns the modified given bank transaction.
 func (d AccountRepositoryDb) Transact(transaction Transaction) (*Transaction, *errs.AppError) { //DB implements repo
-	tx, err := d.client.Begin()
-	if err != nil {
-		logger.Error("Error while starting db transaction for making transaction in bank account: " + err.Error())
-		return nil, errs.NewUnexpectedError("Unexpected database error")
-	}
+	appErr := inTransaction(d.client, "making transaction in bank account", func(tx dbExecutor) *errs.AppError {
+		var updateAccountSql string
+		if transaction.IsDebit() {
+			updateAccountSql = "UPDATE accounts SET amount = amount - ? WHERE account_id = ?"
+		} else {
+			updateAccountSql = "UPDATE accounts SET amount = amount + ? WHERE account_id = ?"
+		}
+		if _, err := tx.Exec(updateAccountSql, transaction.Amount, transaction.AccountId); err != nil {
+			logger.Error("Error while updating account: " + err.Error())
+			return errs.NewUnexpectedError("Unexpected database error")
+		}
 
-	var updateAccountSql string
-	if transaction.IsWithdrawal() {
-		updateAccountSql = "UPDATE accounts SET amount = amount - ? WHERE account_id = ?"
-	} else {
-		updateAccountSql = "UPDATE accounts SET amount = amount + ? WHERE account_id = ?"
-	}
-	_, err = tx.Exec(updateAccountSql, transaction.Amount, transaction.AccountId)
-	if err != nil {
-		logger.Error("Error while updating account: " + err.Error())
-		if rollbackErr := tx.Rollback(); rollbackErr != nil {
-			logger.Fatal("Error while rolling back updating of account: " + rollbackErr.Error())
+		addTransactionSql := "INSERT INTO transactions (account_id, amount, transaction_type, transaction_date, " +
+			"acting_customer_id) VALUES (?, ?, ?, ?, ?)"
+		result, err := tx.Exec(addTransactionSql, transaction.AccountId, transaction.Amount, transaction.TransactionType,
+			transaction.TransactionDate, transaction.ActingCustomer)
+		if err != nil {
+			logger.Error("Error while creating new bank account transaction: " + err.Error())
+			return errs.NewUnexpectedError("Unexpected database error")
 		}
-		return nil, errs.NewUnexpectedError("Unexpected database error")
+
+		id, err := result.LastInsertId()
+		if err != nil {
+			logger.Error("Error while getting id of newly inserted transaction: " + err.Error())
+			return errs.NewUnexpectedError("Unexpected database error")
+		}
+		transaction.TransactionId = strconv.FormatInt(id, 10)
+
+		var appErr *errs.AppError
+		if transaction.Balance, appErr = findBalance(tx, transaction.AccountId); appErr != nil {
+			return appErr
+		}
+		return saveEvent(tx, NewTransactionPostedEvent(transaction))
+	})
+	if appErr != nil {
+		return nil, appErr
 	}
 
-	var result sql.Result
-	addTransactionSql := "INSERT INTO transactions (account_id, amount, transaction_type, transaction_date) VALUES (?, ?, ?, ?)"
-	result, err = tx.Exec(addTransactionSql,
-		transaction.AccountId, transaction.Amount, transaction.TransactionType, transaction.TransactionDate)
-	if err != nil {
-		logger.Error("Error while creating new bank account transaction: " + err.Error())
-		if rollbackErr := tx.Rollback(); rollbackErr != nil {
-			logger.Fatal("Error while rolling back creating of new bank account transaction: " + rollbackErr.Error())
+	return &transaction, nil
+}
+
+// Transfer starts a database transaction, makes the given debit on the source account and the given credit on the
+// destination account, creating an entry in the database and writing an event to the outbox for each of them, and
+// commits the database transaction, so that either both bank transactions are made or neither is. It fills the
+// missing fields of the given debit in the same way as Transact and returns it. For cross-currency transfers, the
+// exchange rate and converted amount are recorded with both bank transactions.
+func (d AccountRepositoryDb) Transfer(debit Transaction, credit Transaction) (*Transaction, *errs.AppError) {
+	appErr := inTransaction(d.client, "making transfer between bank accounts", func(tx dbExecutor) *errs.AppError {
+		for _, transaction := range []*Transaction{&debit, &credit} {
+			var updateAccountSql string
+			if transaction.IsDebit() {
+				updateAccountSql = "UPDATE accounts SET amount = amount - ? WHERE account_id = ?"
+			} else {
+				updateAccountSql = "UPDATE accounts SET amount = amount + ? WHERE account_id = ?"
+			}
+			if _, err := tx.Exec(updateAccountSql, transaction.Amount, transaction.AccountId); err != nil {
+				logger.Error("Error while updating account for transfer: " + err.Error())
+				return errs.NewUnexpectedError("Unexpected database error")
+			}
+
+			addTransactionSql := "INSERT INTO transactions (account_id, amount, transaction_type, transaction_date, fx_rate, converted_amount, " +
+				"acting_customer_id) VALUES (?, ?, ?, ?, ?, ?, ?)"
+			result, err := tx.Exec(addTransactionSql, transaction.AccountId, transaction.Amount, transaction.TransactionType,
+				transaction.TransactionDate, transaction.FxRate, transaction.ConvertedAmount, transaction.ActingCustomer)
+			if err != nil {
+				logger.Error("Error while creating new bank account transaction for transfer: " + err.Error())
+				return errs.NewUnexpectedError("Unexpected database error")
+			}
+
+			id, err := result.LastInsertId()
+			if err != nil {
+				logger.Error("Error while getting id of newly inserted transaction: " + err.Error())
+				return errs.NewUnexpectedError("Unexpected database error")
+			}
+			transaction.TransactionId = strconv.FormatInt(id, 10)
 		}
-		return nil, errs.NewUnexpectedError("Unexpected database error")
+
+		return savePostedTransfer(tx, &debit, &credit)
+	})
+	if appErr != nil {
+		return nil, appErr
 	}
 
-	if err = tx.Commit(); err != nil {
-		logger.Error("Error while committing db transaction: " + err.Error())
+	return &debit, nil
+}
+
+// selectTransactionsSql selects transactions along with the links to their reversals. It is shared by the methods
+// that retrieve transactions so that they are always shown with their reversals.
+const selectTransactionsSql = "SELECT t.transaction_id, t.account_id, t.amount, t.transaction_type, t.transaction_date, " +
+	"t.fx_rate, t.converted_amount, t.acting_customer_id, r1.original_transaction_id AS reversal_of, " +
+	"r2.reversal_transaction_id AS reversed_by, " +
+	"COALESCE(r1.reason_code, r2.reason_code) AS reversal_reason FROM transactions t " +
+	"LEFT JOIN transaction_reversals r1 ON r1.reversal_transaction_id = t.transaction_id " +
+	"LEFT JOIN transaction_reversals r2 ON r2.original_transaction_id = t.transaction_id"
+
+// FindTransactionById retrieves the bank transaction with the given id.
+func (d AccountRepositoryDb) FindTransactionById(transactionId string) (*Transaction, *errs.AppError) {
+	var transaction Transaction
+	if err := d.client.Get(&transaction, selectTransactionsSql+" WHERE t.transaction_id = ?", transactionId); err != nil {
+		logger.Error("Error while retrieving transaction: " + err.Error())
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.NewNotFoundError("Transaction not found")
+		}
 		return nil, errs.NewUnexpectedError("Unexpected database error")
 	}
+	return &transaction, nil
+}
 
-	id, err := result.LastInsertId()
-	if err != nil {
-		logger.Error("Error while getting id of newly inserted transaction: " + err.Error())
+// FindTransactions retrieves all bank transactions made on the account with the given id, oldest first.
+func (d AccountRepositoryDb) FindTransactions(accountId string) ([]Transaction, *errs.AppError) {
+	transactions := make([]Transaction, 0)
+	selectSql := selectTransactionsSql + " WHERE t.account_id = ? ORDER BY t.transaction_date, t.transaction_id"
+	if err := d.client.Select(&transactions, selectSql, accountId); err != nil {
+		logger.Error("Error while retrieving transactions of account: " + err.Error())
 		return nil, errs.NewUnexpectedError("Unexpected database error")
 	}
-	transaction.TransactionId = strconv.FormatInt(id, 10)
+	return transactions, nil
+}
 
-	account, appErr := d.FindById(transaction.AccountId)
+// Reverse starts a database transaction, makes the given compensating bank transaction in the same way as Transact,
+// records the given reversal linking it to the original bank transaction and commits the database transaction.
+// The compensating bank transaction is written to the outbox like any other.
+// Since each bank transaction can only have one reversal record, it returns a conflict error without doing anything
+// if the original bank transaction was already reversed. Reverse returns the completed compensating transaction.
+func (d AccountRepositoryDb) Reverse(transaction Transaction, reversal Reversal) (*Transaction, *errs.AppError) {
+	appErr := inTransaction(d.client, "reversing bank account transaction", func(tx dbExecutor) *errs.AppError {
+		var count int
+		countSql := "SELECT COUNT(*) FROM transaction_reversals WHERE original_transaction_id = ?"
+		if err := tx.Get(&count, countSql, reversal.OriginalTransactionId); err != nil {
+			logger.Error("Error while checking for existing reversal: " + err.Error())
+			return errs.NewUnexpectedError("Unexpected database error")
+		}
+		if count > 0 {
+			return errs.NewConflictError("Transaction has already been reversed")
+		}
+
+		var updateAccountSql string
+		if transaction.IsDebit() {
+			updateAccountSql = "UPDATE accounts SET amount = amount - ? WHERE account_id = ?"
+		} else {
+			updateAccountSql = "UPDATE accounts SET amount = amount + ? WHERE account_id = ?"
+		}
+		if _, err := tx.Exec(updateAccountSql, transaction.Amount, transaction.AccountId); err != nil {
+			logger.Error("Error while updating account for reversal: " + err.Error())
+			return errs.NewUnexpectedError("Unexpected database error")
+		}
+
+		addTransactionSql := "INSERT INTO transactions (account_id, amount, transaction_type, transaction_date) VALUES (?, ?, ?, ?)"
+		result, err := tx.Exec(addTransactionSql,
+			transaction.AccountId, transaction.Amount, transaction.TransactionType, transaction.TransactionDate)
+		if err != nil {
+			logger.Error("Error while creating new bank account transaction for reversal: " + err.Error())
+			return errs.NewUnexpectedError("Unexpected database error")
+		}
+		id, err := result.LastInsertId()
+		if err != nil {
+			logger.Error("Error while getting id of newly inserted transaction: " + err.Error())
+			return errs.NewUnexpectedError("Unexpected database error")
+		}
+		transaction.TransactionId = strconv.FormatInt(id, 10)
+
+		addReversalSql := "INSERT INTO transaction_reversals (original_transaction_id, reversal_transaction_id, reason_code, " +
+			"note, reversal_date) VALUES (?, ?, ?, ?, ?)"
+		_, err = tx.Exec(addReversalSql,
+			reversal.OriginalTransactionId, transaction.TransactionId, reversal.ReasonCode, reversal.Note, reversal.ReversalDate)
+		if err != nil {
+			logger.Error("Error while creating new reversal: " + err.Error())
+			return errs.NewUnexpectedError("Unexpected database error")
+		}
+
+		var appErr *errs.AppError
+		if transaction.Balance, appErr = findBalance(tx, transaction.AccountId); appErr != nil {
+			return appErr
+		}
+		return saveEvent(tx, NewTransactionPostedEvent(transaction))
+	})
 	if appErr != nil {
 		return nil, appErr
 	}
-	transaction.Balance = account.Amount
 
 	return &transaction, nil
 }
+
+// UpdateOverdraftLimit sets the overdraft limit of the account with the given id to the given limit.
+func (d AccountRepositoryDb) UpdateOverdraftLimit(accountId string, limit float64) *errs.AppError {
+	updateSql := "UPDATE accounts SET overdraft_limit = ? WHERE account_id = ?"
+	if _, err := d.client.Exec(updateSql, limit, accountId); err != nil {
+		logger.Error("Error while updating overdraft limit of account: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	return nil
+}
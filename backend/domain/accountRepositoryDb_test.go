@@ -7,12 +7,13 @@ import (
 	"github.com/aliciatay-zls/banking-lib/logger"
 	"github.com/aliciatay-zls/banking/backend/dto"
 	"github.com/jmoiron/sqlx"
+	"net/http"
 	"testing"
 )
 
 // Test common variables and inputs
 var accRepoDb AccountRepositoryDb
-var accountsTableColumns = []string{"account_id", "customer_id", "opening_date", "account_type", "amount", "status"}
+var accountsTableColumns = []string{"account_id", "customer_id", "opening_date", "account_type", "currency", "amount", "status"}
 
 const dummyDate = "2006-01-02 15:04:05"
 const dummyAmount float64 = 6000
@@ -27,12 +28,26 @@ const dummyTransactionIdAsInt int64 = 7791
 const dummyBalance float64 = 12000
 const dummyBalanceAfterWithdrawal float64 = 0
 
-const insertAccountsSql = "INSERT INTO accounts (customer_id, opening_date, account_type, amount, status) VALUES (?, ?, ?, ?, ?)"
-const selectAccountsOfCustomerSql = "SELECT * FROM accounts WHERE customer_id = ?"
+const insertAccountsSql = "INSERT INTO accounts (customer_id, opening_date, account_type, currency, amount, status) VALUES (?, ?, ?, ?, ?, ?)"
+const insertAccountHoldersSql = "INSERT INTO account_holders (account_id, customer_id, role, creation_date) VALUES (?, ?, ?, ?)"
+const selectAccountsOfCustomerSql = "SELECT a.*, h.role AS holder_role FROM accounts a " +
+	"JOIN account_holders h ON h.account_id = a.account_id WHERE h.customer_id = ? ORDER BY a.account_id"
 const selectAccountsSql = "SELECT * FROM accounts WHERE account_id = ?"
+const selectBalanceSql = "SELECT amount FROM accounts WHERE account_id = ?"
 const updateAccountsDepositSql = "UPDATE accounts SET amount = amount + ? WHERE account_id = ?"
 const updateAccountsWithdrawalSql = "UPDATE accounts SET amount = amount - ? WHERE account_id = ?"
+const updateAccountsOverdraftLimitSql = "UPDATE accounts SET overdraft_limit = ? WHERE account_id = ?"
 const insertTransactionsSql = "INSERT INTO transactions (account_id, amount, transaction_type, transaction_date) VALUES (?, ?, ?, ?)"
+const insertActedTransactionsSql = "INSERT INTO transactions (account_id, amount, transaction_type, transaction_date, acting_customer_id) VALUES (?, ?, ?, ?, ?)"
+const insertTransferTransactionsSql = "INSERT INTO transactions (account_id, amount, transaction_type, transaction_date, fx_rate, converted_amount, acting_customer_id) VALUES (?, ?, ?, ?, ?, ?, ?)"
+const countReversalsSql = "SELECT COUNT(*) FROM transaction_reversals WHERE original_transaction_id = ?"
+const insertReversalsSql = "INSERT INTO transaction_reversals (original_transaction_id, reversal_transaction_id, reason_code, note, reversal_date) VALUES (?, ?, ?, ?, ?)"
+const selectTransactionsOfAccountSql = "SELECT t.transaction_id, t.account_id, t.amount, t.transaction_type, t.transaction_date, " +
+	"t.fx_rate, t.converted_amount, t.acting_customer_id, r1.original_transaction_id AS reversal_of, " +
+	"r2.reversal_transaction_id AS reversed_by, COALESCE(r1.reason_code, r2.reason_code) AS reversal_reason FROM transactions t " +
+	"LEFT JOIN transaction_reversals r1 ON r1.reversal_transaction_id = t.transaction_id " +
+	"LEFT JOIN transaction_reversals r2 ON r2.original_transaction_id = t.transaction_id " +
+	"WHERE t.account_id = ? ORDER BY t.transaction_date, t.transaction_id"
 
 func setupAccountRepoDbTest(t *testing.T) func() {
 	teardown := setupDB(t)
@@ -47,6 +62,7 @@ func getDefaultAccountBeforeSave() Account {
 		CustomerId:  dummyCustomerId,
 		OpeningDate: dummyDate,
 		AccountType: dummyAccountType,
+		Currency:    dto.DefaultCurrency,
 		Amount:      dummyAmount,
 		Status:      "1",
 	}
@@ -86,9 +102,11 @@ func TestAccountRepositoryDb_Save_returns_error_when_insertAccounts_fails(t *tes
 
 	dummyAccount := getDefaultAccountBeforeSave()
 	dummyDbErr := errors.New("not connected to database yet")
+	mockDB.ExpectBegin()
 	mockDB.ExpectExec(insertAccountsSql).
-		WithArgs(dummyAccount.CustomerId, dummyAccount.OpeningDate, dummyAccount.AccountType, dummyAccount.Amount, dummyAccount.Status).
+		WithArgs(dummyAccount.CustomerId, dummyAccount.OpeningDate, dummyAccount.AccountType, dummyAccount.Currency, dummyAccount.Amount, dummyAccount.Status).
 		WillReturnError(dummyDbErr)
+	mockDB.ExpectRollback()
 
 	logs := logger.ReplaceWithTestLogger()
 	expectedLogMessage := "Error while creating new account: " + dummyDbErr.Error()
@@ -120,9 +138,11 @@ func TestAccountRepositoryDb_Save_returns_error_when_gettingInsertId_fails(t *te
 	dummyAccount := getDefaultAccountBeforeSave()
 	dummyErr := errors.New("some error message")
 	dummyErrorResult := sqlmock.NewErrorResult(dummyErr)
+	mockDB.ExpectBegin()
 	mockDB.ExpectExec(insertAccountsSql).
-		WithArgs(dummyAccount.CustomerId, dummyAccount.OpeningDate, dummyAccount.AccountType, dummyAccount.Amount, dummyAccount.Status).
+		WithArgs(dummyAccount.CustomerId, dummyAccount.OpeningDate, dummyAccount.AccountType, dummyAccount.Currency, dummyAccount.Amount, dummyAccount.Status).
 		WillReturnResult(dummyErrorResult)
+	mockDB.ExpectRollback()
 
 	logs := logger.ReplaceWithTestLogger()
 	expectedLogMessage := "Error while getting id of newly inserted account: " + dummyErr.Error()
@@ -146,7 +166,7 @@ func TestAccountRepositoryDb_Save_returns_error_when_gettingInsertId_fails(t *te
 	}
 }
 
-func TestAccountRepositoryDb_Save_returns_newAccount_when_insertAccounts_and_getInsertionId_succeed(t *testing.T) {
+func TestAccountRepositoryDb_Save_returns_newAccount_and_savesEvent_when_insertAccounts_and_getInsertionId_succeed(t *testing.T) {
 	//Arrange
 	teardown := setupAccountRepoDbTest(t)
 	defer teardown()
@@ -155,11 +175,17 @@ func TestAccountRepositoryDb_Save_returns_newAccount_when_insertAccounts_and_get
 	var lastInsertID int64 = dummyAccountIdAsInt
 	var rowsAffected int64 = 1
 	dummyResult := sqlmock.NewResult(lastInsertID, rowsAffected)
+	mockDB.ExpectBegin()
 	mockDB.ExpectExec(insertAccountsSql).
-		WithArgs(dummyAccount.CustomerId, dummyAccount.OpeningDate, dummyAccount.AccountType, dummyAccount.Amount, dummyAccount.Status).
+		WithArgs(dummyAccount.CustomerId, dummyAccount.OpeningDate, dummyAccount.AccountType, dummyAccount.Currency, dummyAccount.Amount, dummyAccount.Status).
 		WillReturnResult(dummyResult)
+	mockDB.ExpectExec(insertAccountHoldersSql).
+		WithArgs(dummyAccountId, dummyAccount.CustomerId, dto.AccountHolderRolePrimary, dummyAccount.OpeningDate).
+		WillReturnResult(sqlmock.NewResult(0, 1))
 
 	expectedNewAccount := getDefaultAccountAfterSave()
+	expectEventSaved(NewAccountOpenedEvent(expectedNewAccount))
+	mockDB.ExpectCommit()
 
 	//Act
 	actualNewAccount, err := accRepoDb.Save(dummyAccount)
@@ -229,15 +255,17 @@ func TestAccountRepositoryDb_FindAll_returns_accounts_when_select_succeeds(t *te
 	dummyAccount1 := getDefaultAccountAfterSave()
 	dummyAccount2 := Account{
 		AccountId:   "1980",
-		CustomerId:  dummyCustomerId,
+		CustomerId:  "3",
 		OpeningDate: dummyDate,
 		AccountType: dto.AccountTypeChecking,
 		Amount:      7000,
 		Status:      "0",
+		HolderRole:  dto.AccountHolderRoleJoint,
 	}
-	dummyRows := sqlmock.NewRows(accountsTableColumns).
-		AddRow(dummyAccount1.AccountId, dummyAccount1.CustomerId, dummyAccount1.OpeningDate, dummyAccount1.AccountType, dummyAccount1.Amount, dummyAccount1.Status).
-		AddRow(dummyAccount2.AccountId, dummyAccount2.CustomerId, dummyAccount2.OpeningDate, dummyAccount2.AccountType, dummyAccount2.Amount, dummyAccount2.Status)
+	dummyAccount1.HolderRole = dto.AccountHolderRolePrimary
+	dummyRows := sqlmock.NewRows(append(accountsTableColumns, "holder_role")).
+		AddRow(dummyAccount1.AccountId, dummyAccount1.CustomerId, dummyAccount1.OpeningDate, dummyAccount1.AccountType, dummyAccount1.Currency, dummyAccount1.Amount, dummyAccount1.Status, dummyAccount1.HolderRole).
+		AddRow(dummyAccount2.AccountId, "3", dummyAccount2.OpeningDate, dummyAccount2.AccountType, dummyAccount2.Currency, dummyAccount2.Amount, dummyAccount2.Status, dummyAccount2.HolderRole)
 	mockDB.ExpectQuery(selectAccountsOfCustomerSql).
 		WithArgs(dummyCustomerId).
 		WillReturnRows(dummyRows)
@@ -314,7 +342,7 @@ func TestAccountRepositoryDb_FindById_returns_account_when_selectAccounts_succee
 
 	dummyNewAccount := getDefaultAccountAfterSave()
 	dummyRows := sqlmock.NewRows(accountsTableColumns).
-		AddRow(dummyNewAccount.AccountId, dummyNewAccount.CustomerId, dummyNewAccount.OpeningDate, dummyNewAccount.AccountType, dummyNewAccount.Amount, dummyNewAccount.Status)
+		AddRow(dummyNewAccount.AccountId, dummyNewAccount.CustomerId, dummyNewAccount.OpeningDate, dummyNewAccount.AccountType, dummyNewAccount.Currency, dummyNewAccount.Amount, dummyNewAccount.Status)
 	mockDB.ExpectQuery(selectAccountsSql).
 		WithArgs(dummyNewAccount.AccountId).
 		WillReturnRows(dummyRows)
@@ -415,8 +443,8 @@ func TestAccountRepositoryDb_Transact_returns_error_when_insertTransactions_fail
 		WillReturnResult(dummyUpdateResult)
 
 	dummyDbErr := errors.New("some error message")
-	mockDB.ExpectExec(insertTransactionsSql).
-		WithArgs(dummyTransaction.AccountId, dummyTransaction.Amount, dummyTransaction.TransactionType, dummyTransaction.TransactionDate).
+	mockDB.ExpectExec(insertActedTransactionsSql).
+		WithArgs(dummyTransaction.AccountId, dummyTransaction.Amount, dummyTransaction.TransactionType, dummyTransaction.TransactionDate, dummyTransaction.ActingCustomer).
 		WillReturnError(dummyDbErr)
 
 	mockDB.ExpectRollback()
@@ -460,10 +488,15 @@ func TestAccountRepositoryDb_Transact_returns_error_when_failure_committingDbTra
 
 	lastInsertID = dummyTransactionIdAsInt //dummyTransaction.TransactionId
 	dummyInsertResult := sqlmock.NewResult(lastInsertID, rowsAffected)
-	mockDB.ExpectExec(insertTransactionsSql).
-		WithArgs(dummyTransaction.AccountId, dummyTransaction.Amount, dummyTransaction.TransactionType, dummyTransaction.TransactionDate).
+	mockDB.ExpectExec(insertActedTransactionsSql).
+		WithArgs(dummyTransaction.AccountId, dummyTransaction.Amount, dummyTransaction.TransactionType, dummyTransaction.TransactionDate, dummyTransaction.ActingCustomer).
 		WillReturnResult(dummyInsertResult)
 
+	mockDB.ExpectQuery(selectBalanceSql).
+		WithArgs(dummyAccountId).
+		WillReturnRows(sqlmock.NewRows([]string{"amount"}).AddRow(dummyBalance))
+	expectEventSaved(NewTransactionPostedEvent(getDefaultTransactionAfterTransact()))
+
 	dummyErr := errors.New("some error message")
 	mockDB.ExpectCommit().WillReturnError(dummyErr)
 
@@ -506,11 +539,11 @@ func TestAccountRepositoryDb_Transact_returns_error_when_gettingInsertId_fails(t
 
 	dummyErr := errors.New("some error message")
 	dummyErrorResult := sqlmock.NewErrorResult(dummyErr)
-	mockDB.ExpectExec(insertTransactionsSql).
-		WithArgs(dummyTransaction.AccountId, dummyTransaction.Amount, dummyTransaction.TransactionType, dummyTransaction.TransactionDate).
+	mockDB.ExpectExec(insertActedTransactionsSql).
+		WithArgs(dummyTransaction.AccountId, dummyTransaction.Amount, dummyTransaction.TransactionType, dummyTransaction.TransactionDate, dummyTransaction.ActingCustomer).
 		WillReturnResult(dummyErrorResult)
 
-	mockDB.ExpectCommit()
+	mockDB.ExpectRollback()
 
 	logs := logger.ReplaceWithTestLogger()
 	expectedLogMessage := "Error while getting id of newly inserted transaction: " + dummyErr.Error()
@@ -534,7 +567,7 @@ func TestAccountRepositoryDb_Transact_returns_error_when_gettingInsertId_fails(t
 	}
 }
 
-func TestAccountRepositoryDb_Transact_returns_error_when_cannotFindAccount(t *testing.T) {
+func TestAccountRepositoryDb_Transact_rollsBack_when_retrievingBalance_fails(t *testing.T) {
 	//Arrange
 	teardown := setupAccountRepoDbTest(t)
 	defer teardown()
@@ -551,14 +584,13 @@ func TestAccountRepositoryDb_Transact_returns_error_when_cannotFindAccount(t *te
 
 	lastInsertID = dummyTransactionIdAsInt //dummyTransaction.TransactionId
 	dummyInsertResult := sqlmock.NewResult(lastInsertID, rowsAffected)
-	mockDB.ExpectExec(insertTransactionsSql).
-		WithArgs(dummyTransaction.AccountId, dummyTransaction.Amount, dummyTransaction.TransactionType, dummyTransaction.TransactionDate).
+	mockDB.ExpectExec(insertActedTransactionsSql).
+		WithArgs(dummyTransaction.AccountId, dummyTransaction.Amount, dummyTransaction.TransactionType, dummyTransaction.TransactionDate, dummyTransaction.ActingCustomer).
 		WillReturnResult(dummyInsertResult)
 
-	mockDB.ExpectCommit()
-
 	dummyErr := errors.New("some error message")
-	mockDB.ExpectQuery(selectAccountsSql).WithArgs(dummyAccountId).WillReturnError(dummyErr)
+	mockDB.ExpectQuery(selectBalanceSql).WithArgs(dummyAccountId).WillReturnError(dummyErr)
+	mockDB.ExpectRollback()
 
 	//Act
 	logger.MuteLogger()
@@ -566,11 +598,14 @@ func TestAccountRepositoryDb_Transact_returns_error_when_cannotFindAccount(t *te
 
 	//Assert
 	if actualErr == nil {
-		t.Fatal("Expected error but got none while testing failed finding of newly inserted account")
+		t.Fatal("Expected error but got none while testing failed retrieving of new balance")
 	}
 	if actualErr.Message != defaultExpectedErrMessage {
 		t.Errorf("Expected error message to be \"%s\" but got \"%s\"", defaultExpectedErrMessage, actualErr.Message)
 	}
+	if err := mockDB.ExpectationsWereMet(); err != nil {
+		t.Error(err.Error())
+	}
 }
 
 func TestAccountRepositoryDb_Transact_returns_newTransaction_when_transactionType_deposit(t *testing.T) {
@@ -590,21 +625,17 @@ func TestAccountRepositoryDb_Transact_returns_newTransaction_when_transactionTyp
 
 	lastInsertID = dummyTransactionIdAsInt //dummyTransaction.TransactionId
 	dummyInsertResult := sqlmock.NewResult(lastInsertID, rowsAffected)
-	mockDB.ExpectExec(insertTransactionsSql).
-		WithArgs(dummyTransaction.AccountId, dummyTransaction.Amount, dummyTransaction.TransactionType, dummyTransaction.TransactionDate).
+	mockDB.ExpectExec(insertActedTransactionsSql).
+		WithArgs(dummyTransaction.AccountId, dummyTransaction.Amount, dummyTransaction.TransactionType, dummyTransaction.TransactionDate, dummyTransaction.ActingCustomer).
 		WillReturnResult(dummyInsertResult)
 
-	mockDB.ExpectCommit()
-
-	dummyExistentAccount := getDefaultAccountAfterSave()
-	dummyExistentAccount.Amount = dummyBalance
-	dummyRows := sqlmock.NewRows(accountsTableColumns).
-		AddRow(dummyExistentAccount.AccountId, dummyExistentAccount.CustomerId, dummyExistentAccount.OpeningDate, dummyExistentAccount.AccountType, dummyExistentAccount.Amount, dummyExistentAccount.Status)
-	mockDB.ExpectQuery(selectAccountsSql).
-		WithArgs(dummyExistentAccount.AccountId).
-		WillReturnRows(dummyRows)
+	mockDB.ExpectQuery(selectBalanceSql).
+		WithArgs(dummyAccountId).
+		WillReturnRows(sqlmock.NewRows([]string{"amount"}).AddRow(dummyBalance))
 
 	expectedNewTransaction := getDefaultTransactionAfterTransact()
+	expectEventSaved(NewTransactionPostedEvent(expectedNewTransaction))
+	mockDB.ExpectCommit()
 
 	//Act
 	actualNewTransaction, err := accRepoDb.Transact(dummyTransaction)
@@ -640,23 +671,19 @@ func TestAccountRepositoryDb_Transact_returns_newTransaction_when_transactionTyp
 
 	lastInsertID = dummyTransactionIdAsInt //dummyTransaction.TransactionId
 	dummyInsertResult := sqlmock.NewResult(lastInsertID, rowsAffected)
-	mockDB.ExpectExec(insertTransactionsSql).
-		WithArgs(dummyTransaction.AccountId, dummyTransaction.Amount, dummyTransaction.TransactionType, dummyTransaction.TransactionDate).
+	mockDB.ExpectExec(insertActedTransactionsSql).
+		WithArgs(dummyTransaction.AccountId, dummyTransaction.Amount, dummyTransaction.TransactionType, dummyTransaction.TransactionDate, dummyTransaction.ActingCustomer).
 		WillReturnResult(dummyInsertResult)
 
-	mockDB.ExpectCommit()
-
-	dummyExistentAccount := getDefaultAccountAfterSave()
-	dummyExistentAccount.Amount = dummyBalanceAfterWithdrawal
-	dummyRows := sqlmock.NewRows(accountsTableColumns).
-		AddRow(dummyExistentAccount.AccountId, dummyExistentAccount.CustomerId, dummyExistentAccount.OpeningDate, dummyExistentAccount.AccountType, dummyExistentAccount.Amount, dummyExistentAccount.Status)
-	mockDB.ExpectQuery(selectAccountsSql).
-		WithArgs(dummyExistentAccount.AccountId).
-		WillReturnRows(dummyRows)
+	mockDB.ExpectQuery(selectBalanceSql).
+		WithArgs(dummyAccountId).
+		WillReturnRows(sqlmock.NewRows([]string{"amount"}).AddRow(dummyBalanceAfterWithdrawal))
 
 	expectedNewTransaction := dummyTransaction
 	expectedNewTransaction.TransactionId = dummyTransactionId
 	expectedNewTransaction.Balance = dummyBalanceAfterWithdrawal
+	expectEventSaved(NewTransactionPostedEvent(expectedNewTransaction))
+	mockDB.ExpectCommit()
 
 	//Act
 	actualNewTransaction, err := accRepoDb.Transact(dummyTransaction)
@@ -669,3 +696,242 @@ func TestAccountRepositoryDb_Transact_returns_newTransaction_when_transactionTyp
 		t.Errorf("Expected transaction %v but got %v", expectedNewTransaction, *actualNewTransaction)
 	}
 }
+
+func TestAccountRepositoryDb_UpdateOverdraftLimit_returns_error_when_update_fails(t *testing.T) {
+	//Arrange
+	teardown := setupAccountRepoDbTest(t)
+	defer teardown()
+
+	var dummyLimit float64 = 500
+	dummyDbErr := errors.New("some error message")
+	mockDB.ExpectExec(updateAccountsOverdraftLimitSql).WithArgs(dummyLimit, dummyAccountId).WillReturnError(dummyDbErr)
+
+	logs := logger.ReplaceWithTestLogger()
+	expectedLogMessage := "Error while updating overdraft limit of account: " + dummyDbErr.Error()
+
+	//Act
+	actualErr := accRepoDb.UpdateOverdraftLimit(dummyAccountId, dummyLimit)
+
+	//Assert
+	if actualErr == nil {
+		t.Fatal("Expected error but got none while testing failed update")
+	}
+	if actualErr.Message != defaultExpectedErrMessage {
+		t.Errorf("Expected error message to be \"%s\" but got \"%s\"", defaultExpectedErrMessage, actualErr.Message)
+	}
+	if logs.Len() != 1 {
+		t.Fatalf("Expected 1 message to be logged but got %d logs", logs.Len())
+	}
+	if logs.All()[0].Message != expectedLogMessage {
+		t.Errorf("Expected log message to be \"%s\" but got \"%s\"", expectedLogMessage, logs.All()[0].Message)
+	}
+}
+
+func TestAccountRepositoryDb_UpdateOverdraftLimit_returns_nil_when_update_succeeds(t *testing.T) {
+	//Arrange
+	teardown := setupAccountRepoDbTest(t)
+	defer teardown()
+
+	var dummyLimit float64 = 500
+	mockDB.ExpectExec(updateAccountsOverdraftLimitSql).WithArgs(dummyLimit, dummyAccountId).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	//Act
+	err := accRepoDb.UpdateOverdraftLimit(dummyAccountId, dummyLimit)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing successful update: " + err.Message)
+	}
+}
+
+func TestAccountRepositoryDb_Transfer_rollsBack_when_insertTransactions_fails(t *testing.T) {
+	//Arrange
+	teardown := setupAccountRepoDbTest(t)
+	defer teardown()
+
+	debit := Transaction{AccountId: dummyAccountId, Amount: dummyAmount, TransactionType: dto.TransactionTypeTransferOut, TransactionDate: dummyDate}
+	credit := Transaction{AccountId: "1980", Amount: dummyAmount, TransactionType: dto.TransactionTypeTransferIn, TransactionDate: dummyDate}
+	dummyDbErr := errors.New("some error message")
+
+	mockDB.ExpectBegin()
+	mockDB.ExpectExec(updateAccountsWithdrawalSql).
+		WithArgs(debit.Amount, debit.AccountId).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mockDB.ExpectExec(insertTransferTransactionsSql).
+		WithArgs(debit.AccountId, debit.Amount, debit.TransactionType, debit.TransactionDate, debit.FxRate, debit.ConvertedAmount, debit.ActingCustomer).
+		WillReturnResult(sqlmock.NewResult(dummyTransactionIdAsInt, 1))
+	mockDB.ExpectExec(updateAccountsDepositSql).
+		WithArgs(credit.Amount, credit.AccountId).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mockDB.ExpectExec(insertTransferTransactionsSql).
+		WithArgs(credit.AccountId, credit.Amount, credit.TransactionType, credit.TransactionDate, credit.FxRate, credit.ConvertedAmount, credit.ActingCustomer).
+		WillReturnError(dummyDbErr)
+	mockDB.ExpectRollback()
+
+	//Act
+	_, actualErr := accRepoDb.Transfer(debit, credit)
+
+	//Assert
+	if actualErr == nil {
+		t.Fatal("Expected error but got none while testing failed transfer")
+	}
+	if actualErr.Message != defaultExpectedErrMessage {
+		t.Errorf("Expected error message to be \"%s\" but got \"%s\"", defaultExpectedErrMessage, actualErr.Message)
+	}
+	if err := mockDB.ExpectationsWereMet(); err != nil {
+		t.Error(err.Error())
+	}
+}
+
+func TestAccountRepositoryDb_Transfer_returns_debit_when_bothTransactions_succeed(t *testing.T) {
+	//Arrange
+	teardown := setupAccountRepoDbTest(t)
+	defer teardown()
+
+	debit := Transaction{AccountId: dummyAccountId, Amount: dummyAmount, TransactionType: dto.TransactionTypeTransferOut, TransactionDate: dummyDate}
+	credit := Transaction{AccountId: "1980", Amount: dummyAmount, TransactionType: dto.TransactionTypeTransferIn, TransactionDate: dummyDate}
+
+	mockDB.ExpectBegin()
+	mockDB.ExpectExec(updateAccountsWithdrawalSql).
+		WithArgs(debit.Amount, debit.AccountId).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mockDB.ExpectExec(insertTransferTransactionsSql).
+		WithArgs(debit.AccountId, debit.Amount, debit.TransactionType, debit.TransactionDate, debit.FxRate, debit.ConvertedAmount, debit.ActingCustomer).
+		WillReturnResult(sqlmock.NewResult(dummyTransactionIdAsInt, 1))
+	mockDB.ExpectExec(updateAccountsDepositSql).
+		WithArgs(credit.Amount, credit.AccountId).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mockDB.ExpectExec(insertTransferTransactionsSql).
+		WithArgs(credit.AccountId, credit.Amount, credit.TransactionType, credit.TransactionDate, credit.FxRate, credit.ConvertedAmount, credit.ActingCustomer).
+		WillReturnResult(sqlmock.NewResult(dummyTransactionIdAsInt+1, 1))
+
+	expectedDebit := debit
+	expectedDebit.TransactionId = dummyTransactionId
+	expectedDebit.Balance = dummyBalanceAfterWithdrawal
+	expectedCredit := credit
+	expectedCredit.TransactionId = "7792"
+	expectedCredit.Balance = dummyBalance
+	mockDB.ExpectQuery(selectBalanceSql).
+		WithArgs(dummyAccountId).
+		WillReturnRows(sqlmock.NewRows([]string{"amount"}).AddRow(dummyBalanceAfterWithdrawal))
+	expectEventSaved(NewTransactionPostedEvent(expectedDebit))
+	mockDB.ExpectQuery(selectBalanceSql).
+		WithArgs(credit.AccountId).
+		WillReturnRows(sqlmock.NewRows([]string{"amount"}).AddRow(dummyBalance))
+	expectEventSaved(NewTransactionPostedEvent(expectedCredit))
+	mockDB.ExpectCommit()
+
+	//Act
+	actualDebit, err := accRepoDb.Transfer(debit, credit)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing successful transfer: " + err.Message)
+	}
+	if *actualDebit != expectedDebit {
+		t.Errorf("Expected transaction %v but got %v", expectedDebit, *actualDebit)
+	}
+	if err := mockDB.ExpectationsWereMet(); err != nil {
+		t.Error(err.Error())
+	}
+}
+
+func TestAccountRepositoryDb_Reverse_returns_conflictError_when_transaction_alreadyReversed(t *testing.T) {
+	//Arrange
+	teardown := setupAccountRepoDbTest(t)
+	defer teardown()
+
+	transaction := Transaction{AccountId: dummyAccountId, Amount: dummyAmount, TransactionType: dto.TransactionTypeReversalDebit, TransactionDate: dummyDate}
+	reversal := Reversal{OriginalTransactionId: "7790", ReasonCode: dto.ReversalReasonDuplicate, ReversalDate: dummyDate}
+
+	mockDB.ExpectBegin()
+	mockDB.ExpectQuery(countReversalsSql).WithArgs(reversal.OriginalTransactionId).
+		WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(1))
+	mockDB.ExpectRollback()
+
+	//Act
+	_, actualErr := accRepoDb.Reverse(transaction, reversal)
+
+	//Assert
+	if actualErr == nil {
+		t.Fatal("Expected error but got none while testing reversing a reversed transaction")
+	}
+	if actualErr.Code != http.StatusConflict {
+		t.Errorf("Expected status code %d but got %d", http.StatusConflict, actualErr.Code)
+	}
+	if err := mockDB.ExpectationsWereMet(); err != nil {
+		t.Error(err.Error())
+	}
+}
+
+func TestAccountRepositoryDb_Reverse_returns_reversalTransaction_when_allStatements_succeed(t *testing.T) {
+	//Arrange
+	teardown := setupAccountRepoDbTest(t)
+	defer teardown()
+
+	transaction := Transaction{AccountId: dummyAccountId, Amount: dummyAmount, TransactionType: dto.TransactionTypeReversalDebit, TransactionDate: dummyDate}
+	reversal := Reversal{OriginalTransactionId: "7790", ReasonCode: dto.ReversalReasonDuplicate, ReversalDate: dummyDate}
+
+	mockDB.ExpectBegin()
+	mockDB.ExpectQuery(countReversalsSql).WithArgs(reversal.OriginalTransactionId).
+		WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(0))
+	mockDB.ExpectExec(updateAccountsWithdrawalSql).
+		WithArgs(transaction.Amount, transaction.AccountId).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mockDB.ExpectExec(insertTransactionsSql).
+		WithArgs(transaction.AccountId, transaction.Amount, transaction.TransactionType, transaction.TransactionDate).
+		WillReturnResult(sqlmock.NewResult(dummyTransactionIdAsInt, 1))
+	mockDB.ExpectExec(insertReversalsSql).
+		WithArgs(reversal.OriginalTransactionId, dummyTransactionId, reversal.ReasonCode, reversal.Note, reversal.ReversalDate).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mockDB.ExpectQuery(selectBalanceSql).
+		WithArgs(dummyAccountId).
+		WillReturnRows(sqlmock.NewRows([]string{"amount"}).AddRow(dummyBalanceAfterWithdrawal))
+	expectedTransaction := transaction
+	expectedTransaction.TransactionId = dummyTransactionId
+	expectedTransaction.Balance = dummyBalanceAfterWithdrawal
+	expectEventSaved(NewTransactionPostedEvent(expectedTransaction))
+	mockDB.ExpectCommit()
+
+	//Act
+	actualTransaction, err := accRepoDb.Reverse(transaction, reversal)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing successful reversal: " + err.Message)
+	}
+	if actualTransaction.TransactionId != dummyTransactionId || actualTransaction.Balance != dummyBalanceAfterWithdrawal {
+		t.Errorf("Expected reversal %s with new balance %v but got %+v", dummyTransactionId, dummyBalanceAfterWithdrawal, *actualTransaction)
+	}
+}
+
+func TestAccountRepositoryDb_FindTransactions_returns_transactions_withReversalLinks(t *testing.T) {
+	//Arrange
+	teardown := setupAccountRepoDbTest(t)
+	defer teardown()
+
+	rows := sqlmock.NewRows([]string{"transaction_id", "account_id", "amount", "transaction_type", "transaction_date", "acting_customer_id", "reversal_of", "reversed_by", "reversal_reason"}).
+		AddRow("7790", dummyAccountId, dummyAmount, dto.TransactionTypeDeposit, dummyDate, dummyCustomerId, nil, dummyTransactionId, dto.ReversalReasonDuplicate).
+		AddRow(dummyTransactionId, dummyAccountId, dummyAmount, dto.TransactionTypeReversalDebit, dummyDate, nil, "7790", nil, dto.ReversalReasonDuplicate)
+	mockDB.ExpectQuery(selectTransactionsOfAccountSql).WithArgs(dummyAccountId).WillReturnRows(rows)
+
+	//Act
+	transactions, err := accRepoDb.FindTransactions(dummyAccountId)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing successful select: " + err.Message)
+	}
+	if len(transactions) != 2 {
+		t.Fatalf("Expected 2 transactions but got %d", len(transactions))
+	}
+	if !transactions[0].IsReversed() || transactions[0].ReversedBy.String != dummyTransactionId {
+		t.Errorf("Expected first transaction to be reversed by %s but got %+v", dummyTransactionId, transactions[0])
+	}
+	if transactions[0].ActingCustomer.String != dummyCustomerId {
+		t.Errorf("Expected first transaction to be made by customer %s but got %+v", dummyCustomerId, transactions[0])
+	}
+	if transactions[1].ReversalOf.String != "7790" {
+		t.Errorf("Expected second transaction to be a reversal of 7790 but got %+v", transactions[1])
+	}
+}
@@ -0,0 +1,246 @@
+package domain
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/jmoiron/sqlx"
+)
+
+//Server
+
+// selectAccountsPostgresSql selects the columns of accounts, formatting dates the same way MySQL returns them.
+const selectAccountsPostgresSql = "SELECT account_id, customer_id, " +
+	"to_char(opening_date, 'YYYY-MM-DD HH24:MI:SS') AS opening_date, account_type, currency, amount, status, " +
+	"overdraft_limit, held_amount FROM accounts"
+
+// selectTransactionsPostgresSql is the PostgreSQL equivalent of selectTransactionsSql.
+const selectTransactionsPostgresSql = "SELECT t.transaction_id, t.account_id, t.amount, t.transaction_type, " +
+	"to_char(t.transaction_date, 'YYYY-MM-DD HH24:MI:SS') AS transaction_date, t.fx_rate, t.converted_amount, " +
+	"t.acting_customer_id, " +
+	"r1.original_transaction_id AS reversal_of, r2.reversal_transaction_id AS reversed_by, " +
+	"COALESCE(r1.reason_code, r2.reason_code) AS reversal_reason FROM transactions t " +
+	"LEFT JOIN transaction_reversals r1 ON r1.reversal_transaction_id = t.transaction_id " +
+	"LEFT JOIN transaction_reversals r2 ON r2.original_transaction_id = t.transaction_id"
+
+type AccountRepositoryPostgres struct { //DB (adapter)
+	client dbExecutor
+}
+
+func NewAccountRepositoryPostgres(dbClient *sqlx.DB) AccountRepositoryPostgres {
+	return AccountRepositoryPostgres{dbClient}
+}
+
+// Save starts a database transaction, creates a new entry in the database for the given account, sets its ID using
+// the ID returned by the database, writes the event for the opening of the account to the outbox and commits the
+// database transaction. Save returns the account.
+func (d AccountRepositoryPostgres) Save(account Account) (*Account, *errs.AppError) {
+	appErr := inTransaction(d.client, "creating new account", func(tx dbExecutor) *errs.AppError {
+		addAccountSql := "INSERT INTO accounts (customer_id, opening_date, account_type, currency, amount, status) " +
+			"VALUES ($1, $2, $3, $4, $5, $6) RETURNING account_id"
+		err := tx.Get(&account.AccountId, addAccountSql, account.CustomerId, account.OpeningDate, account.AccountType,
+			account.Currency, account.Amount, account.Status)
+		if err != nil {
+			logger.Error("Error while creating new account: " + err.Error())
+			return errs.NewUnexpectedError("Unexpected database error")
+		}
+
+		addHolderSql := "INSERT INTO account_holders (account_id, customer_id, role, creation_date) VALUES ($1, $2, $3, $4)"
+		if _, err = tx.Exec(addHolderSql, account.AccountId, account.CustomerId, dto.AccountHolderRolePrimary,
+			account.OpeningDate); err != nil {
+			logger.Error("Error while adding primary holder of new account: " + err.Error())
+			return errs.NewUnexpectedError("Unexpected database error")
+		}
+		return saveEvent(tx, NewAccountOpenedEvent(account))
+	})
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return &account, nil
+}
+
+// FindAll retrieves all accounts held by the customer with the given id, whatever their role, along with the role.
+func (d AccountRepositoryPostgres) FindAll(customerId string) ([]Account, *errs.AppError) {
+	accounts := make([]Account, 0)
+	selectSql := "SELECT a.account_id, a.customer_id, " +
+		"to_char(a.opening_date, 'YYYY-MM-DD HH24:MI:SS') AS opening_date, a.account_type, a.currency, a.amount, " +
+		"a.status, a.overdraft_limit, a.held_amount, h.role AS holder_role FROM accounts a " +
+		"JOIN account_holders h ON h.account_id = a.account_id WHERE h.customer_id = $1 ORDER BY a.account_id"
+	if err := d.client.Select(&accounts, selectSql, customerId); err != nil {
+		logger.Error("Error while retrieving all accounts belonging to this customer: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return accounts, nil
+}
+
+// FindById retrieves the account with the given id.
+func (d AccountRepositoryPostgres) FindById(accountId string) (*Account, *errs.AppError) {
+	var account Account
+	if err := d.client.Get(&account, selectAccountsPostgresSql+" WHERE account_id = $1", accountId); err != nil {
+		logger.Error("Error while retrieving account: " + err.Error())
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.NewNotFoundError("Account not found")
+		}
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return &account, nil
+}
+
+// FindHolder retrieves the customer with the given customer id as a holder of the account with the given account id.
+// It returns a not found error if the customer does not hold the account.
+func (d AccountRepositoryPostgres) FindHolder(accountId string, customerId string) (*AccountHolder, *errs.AppError) {
+	var holder AccountHolder
+	selectSql := "SELECT account_id, customer_id, role, " +
+		"to_char(creation_date, 'YYYY-MM-DD HH24:MI:SS') AS creation_date FROM account_holders " +
+		"WHERE account_id = $1 AND customer_id = $2"
+	if err := d.client.Get(&holder, selectSql, accountId, customerId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.NewNotFoundError("Account not found")
+		}
+		logger.Error("Error while retrieving account holder: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return &holder, nil
+}
+
+// Transact starts a database transaction, updates the account balance, creates a new entry in the database for
+// the given bank transaction, writes the event for it to the outbox and commits the database transaction. It fills in
+// the ID of the new entry and the new account balance before committing, and returns the bank transaction.
+func (d AccountRepositoryPostgres) Transact(transaction Transaction) (*Transaction, *errs.AppError) {
+	appErr := inTransaction(d.client, "making transaction in bank account", func(tx dbExecutor) *errs.AppError {
+		if appErr := d.post(tx, &transaction); appErr != nil {
+			return appErr
+		}
+		var appErr *errs.AppError
+		if transaction.Balance, appErr = findBalance(tx, transaction.AccountId); appErr != nil {
+			return appErr
+		}
+		return saveEvent(tx, NewTransactionPostedEvent(transaction))
+	})
+	if appErr != nil {
+		return nil, appErr
+	}
+	return &transaction, nil
+}
+
+// Transfer starts a database transaction, makes the given debit on the source account and the given credit on the
+// destination account, writing an event to the outbox for each of them, and commits the database transaction, so that
+// either both bank transactions are made or neither is. It returns the debit in the same way as Transact.
+func (d AccountRepositoryPostgres) Transfer(debit Transaction, credit Transaction) (*Transaction, *errs.AppError) {
+	appErr := inTransaction(d.client, "making transfer between bank accounts", func(tx dbExecutor) *errs.AppError {
+		for _, transaction := range []*Transaction{&debit, &credit} {
+			if appErr := d.post(tx, transaction); appErr != nil {
+				return appErr
+			}
+		}
+		return savePostedTransfer(tx, &debit, &credit)
+	})
+	if appErr != nil {
+		return nil, appErr
+	}
+	return &debit, nil
+}
+
+// FindTransactionById retrieves the bank transaction with the given id.
+func (d AccountRepositoryPostgres) FindTransactionById(transactionId string) (*Transaction, *errs.AppError) {
+	var transaction Transaction
+	selectSql := selectTransactionsPostgresSql + " WHERE t.transaction_id = $1"
+	if err := d.client.Get(&transaction, selectSql, transactionId); err != nil {
+		logger.Error("Error while retrieving transaction: " + err.Error())
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.NewNotFoundError("Transaction not found")
+		}
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return &transaction, nil
+}
+
+// FindTransactions retrieves all bank transactions made on the account with the given id, oldest first.
+func (d AccountRepositoryPostgres) FindTransactions(accountId string) ([]Transaction, *errs.AppError) {
+	transactions := make([]Transaction, 0)
+	selectSql := selectTransactionsPostgresSql + " WHERE t.account_id = $1 ORDER BY t.transaction_date, t.transaction_id"
+	if err := d.client.Select(&transactions, selectSql, accountId); err != nil {
+		logger.Error("Error while retrieving transactions of account: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return transactions, nil
+}
+
+// Reverse starts a database transaction, makes the given compensating bank transaction, records the given reversal
+// linking it to the original bank transaction and commits the database transaction. It returns a conflict error
+// without doing anything if the original bank transaction was already reversed.
+func (d AccountRepositoryPostgres) Reverse(transaction Transaction, reversal Reversal) (*Transaction, *errs.AppError) {
+	appErr := inTransaction(d.client, "reversing bank account transaction", func(tx dbExecutor) *errs.AppError {
+		var count int
+		countSql := "SELECT COUNT(*) FROM transaction_reversals WHERE original_transaction_id = $1"
+		if err := tx.Get(&count, countSql, reversal.OriginalTransactionId); err != nil {
+			logger.Error("Error while checking for existing reversal: " + err.Error())
+			return errs.NewUnexpectedError("Unexpected database error")
+		}
+		if count > 0 {
+			return errs.NewConflictError("Transaction has already been reversed")
+		}
+
+		if appErr := d.post(tx, &transaction); appErr != nil {
+			return appErr
+		}
+
+		addReversalSql := "INSERT INTO transaction_reversals (original_transaction_id, reversal_transaction_id, reason_code, " +
+			"note, reversal_date) VALUES ($1, $2, $3, $4, $5)"
+		_, err := tx.Exec(addReversalSql,
+			reversal.OriginalTransactionId, transaction.TransactionId, reversal.ReasonCode, reversal.Note, reversal.ReversalDate)
+		if err != nil {
+			logger.Error("Error while creating new reversal: " + err.Error())
+			return errs.NewUnexpectedError("Unexpected database error")
+		}
+
+		var appErr *errs.AppError
+		if transaction.Balance, appErr = findBalance(tx, transaction.AccountId); appErr != nil {
+			return appErr
+		}
+		return saveEvent(tx, NewTransactionPostedEvent(transaction))
+	})
+	if appErr != nil {
+		return nil, appErr
+	}
+	return &transaction, nil
+}
+
+// UpdateOverdraftLimit sets the overdraft limit of the account with the given id to the given limit.
+func (d AccountRepositoryPostgres) UpdateOverdraftLimit(accountId string, limit float64) *errs.AppError {
+	updateSql := "UPDATE accounts SET overdraft_limit = $1 WHERE account_id = $2"
+	if _, err := d.client.Exec(updateSql, limit, accountId); err != nil {
+		logger.Error("Error while updating overdraft limit of account: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	return nil
+}
+
+// post updates the balance of the account of the given bank transaction and creates a new entry in the database for
+// it within the given database transaction, setting its ID using the ID returned by the database.
+func (d AccountRepositoryPostgres) post(tx dbExecutor, transaction *Transaction) *errs.AppError {
+	updateAccountSql := "UPDATE accounts SET amount = amount + $1 WHERE account_id = $2"
+	if transaction.IsDebit() {
+		updateAccountSql = "UPDATE accounts SET amount = amount - $1 WHERE account_id = $2"
+	}
+	if _, err := tx.Exec(updateAccountSql, transaction.Amount, transaction.AccountId); err != nil {
+		logger.Error("Error while updating account: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	addTransactionSql := "INSERT INTO transactions (account_id, amount, transaction_type, transaction_date, fx_rate, " +
+		"converted_amount, acting_customer_id) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING transaction_id"
+	err := tx.Get(&transaction.TransactionId, addTransactionSql, transaction.AccountId, transaction.Amount,
+		transaction.TransactionType, transaction.TransactionDate, transaction.FxRate, transaction.ConvertedAmount,
+		transaction.ActingCustomer)
+	if err != nil {
+		logger.Error("Error while creating new bank account transaction: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package domain
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/jmoiron/sqlx"
+	"net/http"
+	"testing"
+)
+
+// Test common variables and inputs
+var accRepoPostgres AccountRepositoryPostgres
+
+const insertAccountsPostgresSql = "INSERT INTO accounts (customer_id, opening_date, account_type, currency, amount, status) VALUES ($1, $2, $3, $4, $5, $6) RETURNING account_id"
+const selectAccountsPostgresByIdSql = selectAccountsPostgresSql + " WHERE account_id = $1"
+const updateAccountsDepositPostgresSql = "UPDATE accounts SET amount = amount + $1 WHERE account_id = $2"
+const insertOutboxPostgresSql = "INSERT INTO outbox (event_type, aggregate_type, aggregate_id, payload, occurred_on) VALUES ($1, $2, $3, $4, $5)"
+const insertTransactionsPostgresSql = "INSERT INTO transactions (account_id, amount, transaction_type, transaction_date, fx_rate, converted_amount, acting_customer_id) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING transaction_id"
+
+func setupAccountRepoPostgresTest(t *testing.T) func() {
+	teardown := setupDB(t)
+	accRepoPostgres = NewAccountRepositoryPostgres(sqlx.NewDb(db, "postgres"))
+	return teardown
+}
+
+// expectEventSavedPostgres expects the given event to be written to the outbox in PostgreSQL.
+func expectEventSavedPostgres(event Event) {
+	mockDB.ExpectExec(insertOutboxPostgresSql).
+		WithArgs(event.EventType, event.AggregateType, event.AggregateId, event.Payload, event.OccurredOn).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+}
+
+func TestAccountRepositoryPostgres_Save_returns_error_when_insertAccounts_fails(t *testing.T) {
+	//Arrange
+	teardown := setupAccountRepoPostgresTest(t)
+	defer teardown()
+
+	dummyAccount := getDefaultAccountBeforeSave()
+	dummyDbErr := errors.New("some error message")
+	mockDB.ExpectBegin()
+	mockDB.ExpectQuery(insertAccountsPostgresSql).WillReturnError(dummyDbErr)
+	mockDB.ExpectRollback()
+
+	logs := logger.ReplaceWithTestLogger()
+	expectedLogMessage := "Error while creating new account: " + dummyDbErr.Error()
+
+	//Act
+	_, err := accRepoPostgres.Save(dummyAccount)
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing failed insert of account")
+	}
+	if err.Message != defaultExpectedErrMessage {
+		t.Errorf("Expected error message to be \"%s\" but got \"%s\"", defaultExpectedErrMessage, err.Message)
+	}
+	if logs.Len() != 1 || logs.All()[0].Message != expectedLogMessage {
+		t.Errorf("Expected log message \"%s\" but got %v", expectedLogMessage, logs.All())
+	}
+}
+
+func TestAccountRepositoryPostgres_Save_returns_newAccount_with_returnedId_when_insertAccounts_succeeds(t *testing.T) {
+	//Arrange
+	teardown := setupAccountRepoPostgresTest(t)
+	defer teardown()
+
+	dummyAccount := getDefaultAccountBeforeSave()
+	mockDB.ExpectBegin()
+	mockDB.ExpectQuery(insertAccountsPostgresSql).
+		WithArgs(dummyAccount.CustomerId, dummyAccount.OpeningDate, dummyAccount.AccountType, dummyAccount.Currency, dummyAccount.Amount, dummyAccount.Status).
+		WillReturnRows(sqlmock.NewRows([]string{"account_id"}).AddRow(dummyAccountIdAsInt))
+	mockDB.ExpectExec("INSERT INTO account_holders (account_id, customer_id, role, creation_date) VALUES ($1, $2, $3, $4)").
+		WithArgs(dummyAccountId, dummyAccount.CustomerId, dto.AccountHolderRolePrimary, dummyAccount.OpeningDate).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	expectedNewAccount := getDefaultAccountAfterSave()
+	expectEventSavedPostgres(NewAccountOpenedEvent(expectedNewAccount))
+	mockDB.ExpectCommit()
+
+	//Act
+	actualNewAccount, err := accRepoPostgres.Save(dummyAccount)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing successful saving of account: " + err.Message)
+	}
+	if *actualNewAccount != expectedNewAccount {
+		t.Errorf("Expected account %v but got account %v", expectedNewAccount, *actualNewAccount)
+	}
+}
+
+func TestAccountRepositoryPostgres_FindById_returns_notFoundError_when_noRows(t *testing.T) {
+	//Arrange
+	teardown := setupAccountRepoPostgresTest(t)
+	defer teardown()
+
+	mockDB.ExpectQuery(selectAccountsPostgresByIdSql).WithArgs(dummyAccountId).WillReturnError(sql.ErrNoRows)
+	logger.MuteLogger()
+
+	//Act
+	_, err := accRepoPostgres.FindById(dummyAccountId)
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing select of non-existent account")
+	}
+	if err.Code != http.StatusNotFound {
+		t.Errorf("Expected status code %d but got %d", http.StatusNotFound, err.Code)
+	}
+}
+
+func TestAccountRepositoryPostgres_Transact_returns_newTransaction_with_returnedId_when_transactionType_deposit(t *testing.T) {
+	//Arrange
+	teardown := setupAccountRepoPostgresTest(t)
+	defer teardown()
+
+	mockDB.ExpectBegin()
+
+	dummyTransaction := getDefaultTransactionBeforeTransact()
+	mockDB.ExpectExec(updateAccountsDepositPostgresSql).
+		WithArgs(dummyTransaction.Amount, dummyTransaction.AccountId).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mockDB.ExpectQuery(insertTransactionsPostgresSql).
+		WithArgs(dummyTransaction.AccountId, dummyTransaction.Amount, dummyTransaction.TransactionType, dummyTransaction.TransactionDate, nil, nil, nil).
+		WillReturnRows(sqlmock.NewRows([]string{"transaction_id"}).AddRow(dummyTransactionIdAsInt))
+
+	mockDB.ExpectQuery("SELECT amount FROM accounts WHERE account_id = $1").
+		WithArgs(dummyAccountId).
+		WillReturnRows(sqlmock.NewRows([]string{"amount"}).AddRow(dummyBalance))
+
+	expectedNewTransaction := getDefaultTransactionAfterTransact()
+	expectEventSavedPostgres(NewTransactionPostedEvent(expectedNewTransaction))
+	mockDB.ExpectCommit()
+
+	//Act
+	actualNewTransaction, err := accRepoPostgres.Transact(dummyTransaction)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing successful deposit: " + err.Message)
+	}
+	if *actualNewTransaction != expectedNewTransaction {
+		t.Errorf("Expected transaction %v but got %v", expectedNewTransaction, *actualNewTransaction)
+	}
+	if err := mockDB.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,216 @@
+package domain
+
+import (
+	"database/sql"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+//Server
+
+// AccountRepositoryStub keeps accounts and their transactions in memory instead of in the database, generating IDs
+// the way the database does. It is safe for concurrent use, and like a database transaction, each method either
+// makes all of its changes or none of them.
+type AccountRepositoryStub struct { //stub (adapter)
+	mu                *sync.Mutex
+	accounts          map[string]Account
+	transactions      []Transaction //oldest first
+	nextAccountId     int
+	nextTransactionId int
+}
+
+func NewAccountRepositoryStub(accounts []Account) *AccountRepositoryStub {
+	s := AccountRepositoryStub{mu: &sync.Mutex{}, accounts: make(map[string]Account), nextAccountId: 1, nextTransactionId: 1}
+	for _, a := range accounts {
+		s.accounts[a.AccountId] = a
+		if id, _ := strconv.Atoi(a.AccountId); id >= s.nextAccountId {
+			s.nextAccountId = id + 1
+		}
+	}
+	return &s
+}
+
+// Save stores the given account under a new ID and returns it.
+func (s *AccountRepositoryStub) Save(account Account) (*Account, *errs.AppError) { //stub implements repo
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	account.AccountId = strconv.Itoa(s.nextAccountId)
+	s.nextAccountId++
+	s.accounts[account.AccountId] = account
+	return &account, nil
+}
+
+// FindAll returns all accounts held by the customer with the given id, ordered by ID. The stub has no joint or
+// view-only holders, so these are the accounts opened for the customer.
+func (s *AccountRepositoryStub) FindAll(customerId string) ([]Account, *errs.AppError) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	accounts := make([]Account, 0)
+	for _, a := range s.accounts {
+		if a.CustomerId == customerId {
+			a.HolderRole = dto.AccountHolderRolePrimary
+			accounts = append(accounts, a)
+		}
+	}
+	sort.Slice(accounts, func(i, j int) bool {
+		first, _ := strconv.Atoi(accounts[i].AccountId)
+		second, _ := strconv.Atoi(accounts[j].AccountId)
+		return first < second
+	})
+	return accounts, nil
+}
+
+func (s *AccountRepositoryStub) FindById(accountId string) (*Account, *errs.AppError) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.findById(accountId)
+}
+
+// FindHolder returns the customer with the given customer id as the primary holder of the account with the given
+// account id, or a not found error if the account was not opened for the customer.
+func (s *AccountRepositoryStub) FindHolder(accountId string, customerId string) (*AccountHolder, *errs.AppError) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	account, appErr := s.findById(accountId)
+	if appErr != nil || account.CustomerId != customerId {
+		return nil, errs.NewNotFoundError("Account not found")
+	}
+	return &AccountHolder{AccountId: accountId, CustomerId: customerId, Role: dto.AccountHolderRolePrimary,
+		CreationDate: account.OpeningDate}, nil
+}
+
+// Transact updates the account balance and stores the given bank transaction under a new ID, then returns it along
+// with the new account balance.
+func (s *AccountRepositoryStub) Transact(transaction Transaction) (*Transaction, *errs.AppError) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, appErr := s.findById(transaction.AccountId); appErr != nil {
+		return nil, appErr
+	}
+	return s.post(transaction), nil
+}
+
+// Transfer makes the given debit on the source account and the given credit on the destination account, then returns
+// the debit in the same way as Transact.
+func (s *AccountRepositoryStub) Transfer(debit Transaction, credit Transaction) (*Transaction, *errs.AppError) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, accountId := range []string{debit.AccountId, credit.AccountId} {
+		if _, appErr := s.findById(accountId); appErr != nil {
+			return nil, appErr
+		}
+	}
+	completed := s.post(debit)
+	s.post(credit)
+	return completed, nil
+}
+
+func (s *AccountRepositoryStub) FindTransactionById(transactionId string) (*Transaction, *errs.AppError) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if k := s.indexOfTransaction(transactionId); k >= 0 {
+		transaction := s.transactions[k]
+		return &transaction, nil
+	}
+	logger.Error("Error while finding transaction by id using stub for AccountRepository: not found")
+	return nil, errs.NewNotFoundError("Transaction not found")
+}
+
+// FindTransactions returns all bank transactions made on the account with the given id, oldest first.
+func (s *AccountRepositoryStub) FindTransactions(accountId string) ([]Transaction, *errs.AppError) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	transactions := make([]Transaction, 0)
+	for _, t := range s.transactions {
+		if t.AccountId == accountId {
+			transactions = append(transactions, t)
+		}
+	}
+	return transactions, nil
+}
+
+// Reverse makes the given compensating bank transaction and links it to the original bank transaction. It returns a
+// conflict error without doing anything if the original bank transaction was already reversed.
+func (s *AccountRepositoryStub) Reverse(transaction Transaction, reversal Reversal) (*Transaction, *errs.AppError) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	k := s.indexOfTransaction(reversal.OriginalTransactionId)
+	if k < 0 {
+		logger.Error("Error while reversing transaction using stub for AccountRepository: original not found")
+		return nil, errs.NewNotFoundError("Transaction not found")
+	}
+	if s.transactions[k].IsReversed() {
+		return nil, errs.NewConflictError("Transaction has already been reversed")
+	}
+
+	completed := s.post(transaction)
+	s.transactions[k].ReversedBy = sql.NullString{String: completed.TransactionId, Valid: true}
+	s.transactions[k].ReversalReason = sql.NullString{String: reversal.ReasonCode, Valid: true}
+	return completed, nil
+}
+
+func (s *AccountRepositoryStub) UpdateOverdraftLimit(accountId string, limit float64) *errs.AppError {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	account, appErr := s.findById(accountId)
+	if appErr != nil {
+		return appErr
+	}
+	account.OverdraftLimit = limit
+	s.accounts[accountId] = *account
+	return nil
+}
+
+func (s *AccountRepositoryStub) findById(accountId string) (*Account, *errs.AppError) {
+	account, ok := s.accounts[accountId]
+	if !ok {
+		logger.Error("Error while finding account by id using stub for AccountRepository: not found")
+		return nil, errs.NewNotFoundError("Account not found")
+	}
+	return &account, nil
+}
+
+func (s *AccountRepositoryStub) indexOfTransaction(transactionId string) int {
+	for k, t := range s.transactions {
+		if t.TransactionId == transactionId {
+			return k
+		}
+	}
+	return -1
+}
+
+// post updates the balance of the account of the given bank transaction, rounding it to the nearest cent like the
+// database does, and stores the bank transaction under a new ID. It returns the bank transaction along with the new
+// balance. The account must exist.
+func (s *AccountRepositoryStub) post(transaction Transaction) *Transaction {
+	account := s.accounts[transaction.AccountId]
+	if transaction.IsDebit() {
+		account.Amount -= transaction.Amount
+	} else {
+		account.Amount += transaction.Amount
+	}
+	account.Amount = math.Round(account.Amount*100) / 100
+	s.accounts[transaction.AccountId] = account
+
+	transaction.TransactionId = strconv.Itoa(s.nextTransactionId)
+	s.nextTransactionId++
+	s.transactions = append(s.transactions, transaction)
+
+	transaction.Balance = account.Amount
+	return &transaction
+}
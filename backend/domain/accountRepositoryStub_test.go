@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"net/http"
+	"sync"
+	"testing"
+)
+
+func getDefaultStubAccounts() []Account {
+	return []Account{
+		{AccountId: "95470", CustomerId: "2000", AccountType: dto.AccountTypeSaving, Currency: "USD", Amount: 6000, Status: "1"},
+		{AccountId: "95471", CustomerId: "2000", AccountType: dto.AccountTypeChecking, Currency: "USD", Amount: 100, Status: "1"},
+	}
+}
+
+func TestAccountRepositoryStub_Save_generates_id_after_largestExistingId(t *testing.T) {
+	//Arrange
+	accountRepositoryStub := NewAccountRepositoryStub(getDefaultStubAccounts())
+
+	//Act
+	first, _ := accountRepositoryStub.Save(NewAccount("2000", dto.AccountTypeSaving, "USD", 5000, clock.StaticClock{}))
+	second, _ := accountRepositoryStub.Save(NewAccount("2001", dto.AccountTypeSaving, "USD", 5000, clock.StaticClock{}))
+
+	//Assert
+	if first.AccountId != "95472" || second.AccountId != "95473" {
+		t.Errorf("Expected account ids 95472 and 95473 but got %s and %s", first.AccountId, second.AccountId)
+	}
+	accounts, _ := accountRepositoryStub.FindAll("2000")
+	if len(accounts) != 3 || accounts[2].AccountId != first.AccountId {
+		t.Errorf("Expected 3 accounts ending with the new account but got %v", accounts)
+	}
+}
+
+func TestAccountRepositoryStub_Transact_returns_notFoundError_when_nonExistentAccount(t *testing.T) {
+	//Arrange
+	accountRepositoryStub := NewAccountRepositoryStub(getDefaultStubAccounts())
+	logger.MuteLogger()
+
+	//Act
+	_, err := accountRepositoryStub.Transact(NewTransaction("1", 100, dto.TransactionTypeDeposit, clock.StaticClock{}))
+
+	//Assert
+	if err == nil || err.Code != http.StatusNotFound {
+		t.Errorf("Expected not found error but got %v", err)
+	}
+	if transactions, _ := accountRepositoryStub.FindTransactions("1"); len(transactions) != 0 {
+		t.Errorf("Expected no transaction to be stored but got %d", len(transactions))
+	}
+}
+
+func TestAccountRepositoryStub_Transfer_changesNothing_when_destination_nonExistent(t *testing.T) {
+	//Arrange
+	accountRepositoryStub := NewAccountRepositoryStub(getDefaultStubAccounts())
+	logger.MuteLogger()
+	debit, credit := NewTransfer(getDefaultStubAccounts()[0], Account{AccountId: "1", Currency: "USD"}, 100, 1, clock.StaticClock{})
+
+	//Act
+	_, err := accountRepositoryStub.Transfer(debit, credit)
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing transfer to non-existent account")
+	}
+	if account, _ := accountRepositoryStub.FindById("95470"); account.Amount != 6000 {
+		t.Errorf("Expected balance of source account to stay 6000 but got %v", account.Amount)
+	}
+}
+
+func TestAccountRepositoryStub_Transact_updates_balance_correctly_when_concurrent(t *testing.T) {
+	//Arrange
+	accountRepositoryStub := NewAccountRepositoryStub(getDefaultStubAccounts())
+	var wg sync.WaitGroup
+
+	//Act
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, _ = accountRepositoryStub.Transact(NewTransaction("95470", 10, dto.TransactionTypeDeposit, clock.StaticClock{}))
+		}()
+		go func() {
+			defer wg.Done()
+			_, _ = accountRepositoryStub.Transact(NewTransaction("95470", 0.1, dto.TransactionTypeWithdrawal, clock.StaticClock{}))
+		}()
+	}
+	wg.Wait()
+
+	//Assert
+	account, _ := accountRepositoryStub.FindById("95470")
+	if account.Amount != 6495 {
+		t.Errorf("Expected balance 6495 but got %v", account.Amount)
+	}
+	transactions, _ := accountRepositoryStub.FindTransactions("95470")
+	ids := make(map[string]bool)
+	for _, tr := range transactions {
+		ids[tr.TransactionId] = true
+	}
+	if len(transactions) != 100 || len(ids) != 100 {
+		t.Errorf("Expected 100 transactions with unique ids but got %d with %d unique ids", len(transactions), len(ids))
+	}
+}
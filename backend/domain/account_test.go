@@ -1,6 +1,9 @@
 package domain
 
-import "testing"
+import (
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"testing"
+)
 
 func TestAccount_CanWithdraw_returns_true_when_accountBalance_sufficient(t *testing.T) {
 	//Arrange
@@ -32,3 +35,97 @@ func TestAccount_CanWithdraw_returns_false_when_accountBalance_insufficient(t *t
 
 	}
 }
+
+func TestAccount_CanWithdraw_allows_overdraft_onlyForCheckingAccounts(t *testing.T) {
+	//Arrange
+	tests := []struct {
+		name             string
+		account          Account
+		withdrawalAmount float64
+		expectedResult   bool
+	}{
+		{"checking within overdraft limit", Account{AccountType: dto.AccountTypeChecking, Amount: 100, OverdraftLimit: 500}, 600, true},
+		{"checking beyond overdraft limit", Account{AccountType: dto.AccountTypeChecking, Amount: 100, OverdraftLimit: 500}, 600.01, false},
+		{"checking already overdrawn", Account{AccountType: dto.AccountTypeChecking, Amount: -400, OverdraftLimit: 500}, 100, true},
+		{"saving with overdraft limit", Account{AccountType: dto.AccountTypeSaving, Amount: 100, OverdraftLimit: 500}, 600, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Act
+			actualResult := tc.account.CanWithdraw(tc.withdrawalAmount)
+
+			//Assert
+			if actualResult != tc.expectedResult {
+				t.Errorf("expected %v but got %v", tc.expectedResult, actualResult)
+			}
+		})
+	}
+}
+
+func TestAccount_OverdraftUsed_returns_amountOverdrawn(t *testing.T) {
+	//Arrange
+	tests := []struct {
+		name           string
+		amount         float64
+		expectedResult float64
+	}{
+		{"positive balance", 100, 0},
+		{"zero balance", 0, 0},
+		{"negative balance", -250.5, 250.5},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			account := Account{AccountType: dto.AccountTypeChecking, Amount: tc.amount}
+
+			//Act
+			actualResult := account.OverdraftUsed()
+
+			//Assert
+			if actualResult != tc.expectedResult {
+				t.Errorf("expected %v but got %v", tc.expectedResult, actualResult)
+			}
+		})
+	}
+}
+
+func TestAccount_CanWithdraw_excludes_heldAmount(t *testing.T) {
+	//Arrange
+	tests := []struct {
+		name             string
+		account          Account
+		withdrawalAmount float64
+		expectedResult   bool
+	}{
+		{"within available balance", Account{AccountType: dto.AccountTypeSaving, Amount: 1000, HeldAmount: 400}, 600, true},
+		{"beyond available balance", Account{AccountType: dto.AccountTypeSaving, Amount: 1000, HeldAmount: 400}, 600.01, false},
+		{"within overdraft after holds", Account{AccountType: dto.AccountTypeChecking, Amount: 1000, HeldAmount: 400, OverdraftLimit: 500}, 1100, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Act
+			actualResult := tc.account.CanWithdraw(tc.withdrawalAmount)
+
+			//Assert
+			if actualResult != tc.expectedResult {
+				t.Errorf("expected %v but got %v", tc.expectedResult, actualResult)
+			}
+		})
+	}
+}
+
+func TestAccount_ToDTO_returns_ledgerAndAvailableBalances(t *testing.T) {
+	//Arrange
+	account := Account{Amount: 1000, HeldAmount: 250}
+
+	//Act
+	response := account.ToDTO()
+
+	//Assert
+	if response.LedgerBalance != 1000 || response.AvailableBalance != 750 {
+		t.Errorf("expected ledger balance 1000 and available balance 750 but got %v and %v",
+			response.LedgerBalance, response.AvailableBalance)
+	}
+}
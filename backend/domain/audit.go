@@ -0,0 +1,141 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"strings"
+)
+
+//Business Domain
+
+const AuditOutcomeSuccess = "success"
+const AuditOutcomeFailure = "failure"
+
+// maxIpAddressLength is the length of the longest IPv6 address in text form.
+const maxIpAddressLength = 45
+
+// AuditGenesisHash is the previous hash of the first entry of the audit log.
+var AuditGenesisHash = strings.Repeat("0", sha256.Size*2)
+
+// AuditEntry records a state-changing request. Each entry's hash covers its contents and the hash of the entry before
+// it, so changing, removing or reordering entries breaks the chain from that entry on.
+type AuditEntry struct { //business/domain object
+	EntryId         string `db:"entry_id"`
+	ActorRole       string `db:"actor_role"`        //empty if the auth server gave no details
+	ActorCustomerId string `db:"actor_customer_id"` //empty for admins
+	RouteName       string `db:"route_name"`
+	RouteVars       string `db:"route_vars"`   //JSON object of the variables in the route
+	RequestHash     string `db:"request_hash"` //hex-encoded SHA-256 of the request body
+	StatusCode      int    `db:"status_code"`
+	Outcome         string `db:"outcome"`
+	IpAddress       string `db:"ip_address"`
+	CreatedOn       string `db:"created_on"`
+	PreviousHash    string `db:"previous_hash"`
+	EntryHash       string `db:"entry_hash"`
+}
+
+// NewAuditEntry creates the entry for a request to the given route whose body has the given hash, made by the given
+// actor from the given IP address and answered with the given status code. Its creation time is set when it is
+// recorded, and it is chained once it is appended to the log.
+func NewAuditEntry(actor Actor, routeName string, routeVars map[string]string, requestHash string, statusCode int,
+	ipAddress string) AuditEntry {
+	vars, _ := json.Marshal(routeVars) //maps of strings can always be marshalled, with their keys sorted
+	if len(ipAddress) > maxIpAddressLength {
+		ipAddress = ipAddress[:maxIpAddressLength]
+	}
+	outcome := AuditOutcomeSuccess
+	if statusCode >= 400 {
+		outcome = AuditOutcomeFailure
+	}
+	return AuditEntry{
+		ActorRole:       actor.Role,
+		ActorCustomerId: actor.CustomerId,
+		RouteName:       routeName,
+		RouteVars:       string(vars),
+		RequestHash:     requestHash,
+		StatusCode:      statusCode,
+		Outcome:         outcome,
+		IpAddress:       ipAddress,
+	}
+}
+
+// auditContents are the contents of an entry covered by its hash, in a fixed order. The entry ID is left out since it
+// is only known after the entry is saved, and the position of the entry is already covered by the previous hash.
+type auditContents struct {
+	ActorRole       string `json:"actor_role"`
+	ActorCustomerId string `json:"actor_customer_id"`
+	RouteName       string `json:"route_name"`
+	RouteVars       string `json:"route_vars"`
+	RequestHash     string `json:"request_hash"`
+	StatusCode      int    `json:"status_code"`
+	Outcome         string `json:"outcome"`
+	IpAddress       string `json:"ip_address"`
+	CreatedOn       string `json:"created_on"`
+}
+
+// ComputeHash computes the hex-encoded SHA-256 of the entry's previous hash, a newline and its contents as JSON.
+func (e AuditEntry) ComputeHash() string {
+	contents, _ := json.Marshal(auditContents{e.ActorRole, e.ActorCustomerId, e.RouteName, e.RouteVars, e.RequestHash,
+		e.StatusCode, e.Outcome, e.IpAddress, e.CreatedOn})
+	hash := sha256.Sum256([]byte(e.PreviousHash + "\n" + string(contents)))
+	return hex.EncodeToString(hash[:])
+}
+
+// Chain links the entry to the entry with the given hash, which should be the last entry of the log.
+func (e *AuditEntry) Chain(previousHash string) {
+	e.PreviousHash = previousHash
+	e.EntryHash = e.ComputeHash()
+}
+
+// Verify checks that the entry follows the entry with the given hash and that its contents have not changed since it
+// was chained. It returns why the entry is invalid, or an empty string if it is valid.
+func (e AuditEntry) Verify(previousHash string) string {
+	if e.PreviousHash != previousHash {
+		return "entry does not follow the previous entry"
+	}
+	if e.EntryHash != e.ComputeHash() {
+		return "entry does not match its hash"
+	}
+	return ""
+}
+
+func (e AuditEntry) ToDTO() dto.AuditEntryResponse {
+	routeVars := make(map[string]string)
+	_ = json.Unmarshal([]byte(e.RouteVars), &routeVars) //shown as empty if tampered with, which Verify reports
+	return dto.AuditEntryResponse{
+		EntryId:         e.EntryId,
+		ActorRole:       e.ActorRole,
+		ActorCustomerId: e.ActorCustomerId,
+		RouteName:       e.RouteName,
+		RouteVars:       routeVars,
+		RequestHash:     e.RequestHash,
+		StatusCode:      e.StatusCode,
+		Outcome:         e.Outcome,
+		IpAddress:       e.IpAddress,
+		CreatedOn:       e.CreatedOn,
+		EntryHash:       e.EntryHash,
+	}
+}
+
+// AuditFilter selects entries of the audit log. Empty fields match every entry.
+type AuditFilter struct {
+	ActorCustomerId string
+	RouteName       string
+	From            string //earliest creation time
+	To              string //latest creation time
+	AfterId         string //only entries after this one, for paging through the log
+	Limit           int
+}
+
+//Server
+
+//go:generate mockgen -destination=../mocks/domain/mock_auditRepository.go -package=domain github.com/aliciatay-zls/banking/backend/domain AuditRepository
+type AuditRepository interface { //repo (secondary port)
+	// Append chains the given entry to the last entry of the log and saves it.
+	Append(AuditEntry) (*AuditEntry, *errs.AppError)
+	// FindEntries retrieves the entries matching the given filter, oldest first.
+	FindEntries(AuditFilter) ([]AuditEntry, *errs.AppError)
+}
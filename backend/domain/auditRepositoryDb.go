@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/jmoiron/sqlx"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+//Server
+
+type AuditRepositoryDb struct { //DB (adapter)
+	client dbExecutor
+	mu     *sync.Mutex //appends one entry at a time, since each needs the hash of the one before
+}
+
+func NewAuditRepositoryDb(dbClient *sqlx.DB) AuditRepositoryDb {
+	return AuditRepositoryDb{dbClient, &sync.Mutex{}}
+}
+
+// Append starts a database transaction, chains the given entry to the last entry in the database, saves it and
+// commits the database transaction. It returns the entry with its ID and hashes. Within this app, entries are
+// appended one at a time; should another app append an entry to the same log at the same time, the unique key on the
+// previous hash makes one of them fail instead of forking the chain.
+func (d AuditRepositoryDb) Append(entry AuditEntry) (*AuditEntry, *errs.AppError) { //DB implements repo
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	err := inTransaction(d.client, "appending audit entry", func(tx dbExecutor) *errs.AppError {
+		previousHash := AuditGenesisHash
+		selectSql := "SELECT entry_hash FROM audit_log ORDER BY entry_id DESC LIMIT 1"
+		if err := tx.Get(&previousHash, selectSql); err != nil && !errors.Is(err, sql.ErrNoRows) {
+			logger.Error("Error while retrieving last audit entry: " + err.Error())
+			return errs.NewUnexpectedError("Unexpected database error")
+		}
+		entry.Chain(previousHash)
+
+		insertSql := "INSERT INTO audit_log (actor_role, actor_customer_id, route_name, route_vars, request_hash, " +
+			"status_code, outcome, ip_address, created_on, previous_hash, entry_hash) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+		result, err := tx.Exec(insertSql, entry.ActorRole, entry.ActorCustomerId, entry.RouteName, entry.RouteVars,
+			entry.RequestHash, entry.StatusCode, entry.Outcome, entry.IpAddress, entry.CreatedOn, entry.PreviousHash,
+			entry.EntryHash)
+		if err != nil {
+			logger.Error("Error while creating new audit entry: " + err.Error())
+			return errs.NewUnexpectedError("Unexpected database error")
+		}
+
+		id, err := result.LastInsertId()
+		if err != nil {
+			logger.Error("Error while getting id of newly inserted audit entry: " + err.Error())
+			return errs.NewUnexpectedError("Unexpected database error")
+		}
+		entry.EntryId = strconv.FormatInt(id, 10)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// FindEntries retrieves up to the filter's limit of entries matching the given filter, oldest first.
+func (d AuditRepositoryDb) FindEntries(filter AuditFilter) ([]AuditEntry, *errs.AppError) {
+	return findAuditEntries(d.client, "SELECT * FROM audit_log", filter)
+}
+
+// findAuditEntries retrieves up to the filter's limit of entries matching the given filter with the given select
+// statement, oldest first. The conditions only ever come from the fixed SQL below, and the values of the filter are
+// passed as query arguments.
+func findAuditEntries(client dbExecutor, selectSql string, filter AuditFilter) ([]AuditEntry, *errs.AppError) {
+	conditions := make([]string, 0)
+	args := make([]interface{}, 0)
+	if filter.ActorCustomerId != "" {
+		conditions = append(conditions, "actor_customer_id = ?")
+		args = append(args, filter.ActorCustomerId)
+	}
+	if filter.RouteName != "" {
+		conditions = append(conditions, "route_name = ?")
+		args = append(args, filter.RouteName)
+	}
+	if filter.From != "" {
+		conditions = append(conditions, "created_on >= ?")
+		args = append(args, filter.From)
+	}
+	if filter.To != "" {
+		conditions = append(conditions, "created_on <= ?")
+		args = append(args, filter.To)
+	}
+	if filter.AfterId != "" {
+		conditions = append(conditions, "entry_id > ?")
+		args = append(args, filter.AfterId)
+	}
+
+	if len(conditions) > 0 {
+		selectSql += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	selectSql += " ORDER BY entry_id LIMIT ?"
+	args = append(args, filter.Limit)
+
+	entries := make([]AuditEntry, 0)
+	if err := client.Select(&entries, client.Rebind(selectSql), args...); err != nil {
+		logger.Error("Error while retrieving audit entries: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return entries, nil
+}
@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"fmt"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/jmoiron/sqlx"
+	"net/http"
+	"testing"
+)
+
+// These tests run on a real SQLite database seeded with the demo data, since the chaining of entries and their
+// filters are only worked out when the SQL is executed, which go-sqlmock never does.
+
+var auditRepoDb AuditRepositoryDb
+
+var dummyAuditRoutes = []string{"NewAccount", "NewTransaction", "NewTransfer"}
+
+// setupAuditRepoDbTest opens a new SQLite database and appends an entry for each of dummyAuditRoutes to the audit log
+// in it, one on each day from 2024-01-01.
+func setupAuditRepoDbTest(t *testing.T) *sqlx.DB {
+	logger.MuteLogger()
+	client := openSQLiteDb(t)
+	auditRepoDb = NewAuditRepositoryDb(client)
+
+	for k, route := range dummyAuditRoutes {
+		entry := NewAuditEntry(Actor{Role: RoleUser, CustomerId: "2001"}, route, map[string]string{"customer_id": "2001"},
+			"abc", http.StatusCreated, "127.0.0.1")
+		entry.CreatedOn = fmt.Sprintf("2024-01-0%d 10:00:00", k+1)
+		if _, err := auditRepoDb.Append(entry); err != nil {
+			t.Fatal("Expected no error but got error while appending audit entry: " + err.Message)
+		}
+	}
+	return client
+}
+
+func TestAuditRepositoryDb_Append_chains_entries_in_order(t *testing.T) {
+	//Arrange
+	setupAuditRepoDbTest(t)
+
+	//Act
+	entries, err := auditRepoDb.FindEntries(AuditFilter{Limit: 10})
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if len(entries) != len(dummyAuditRoutes) {
+		t.Fatalf("Expected %d entries but got %d", len(dummyAuditRoutes), len(entries))
+	}
+	previousHash := AuditGenesisHash
+	for _, e := range entries {
+		if reason := e.Verify(previousHash); reason != "" {
+			t.Errorf("Expected entry %s to be valid but got \"%s\"", e.EntryId, reason)
+		}
+		previousHash = e.EntryHash
+	}
+}
+
+func TestAuditRepositoryDb_FindEntries_filters_by_route(t *testing.T) {
+	//Arrange
+	setupAuditRepoDbTest(t)
+
+	//Act
+	entries, err := auditRepoDb.FindEntries(AuditFilter{RouteName: "NewTransaction", Limit: 10})
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if len(entries) != 1 || entries[0].RouteName != "NewTransaction" {
+		t.Errorf("Expected only NewTransaction entry but got %+v", entries)
+	}
+}
+
+func TestAuditRepositoryDb_FindEntries_filters_by_dateRange(t *testing.T) {
+	//Arrange
+	setupAuditRepoDbTest(t)
+
+	//Act
+	entries, err := auditRepoDb.FindEntries(AuditFilter{From: "2024-01-02 00:00:00", To: "2024-01-03 23:59:59", Limit: 10})
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if len(entries) != 2 {
+		t.Errorf("Expected 2 entries but got %+v", entries)
+	}
+}
+
+func TestAuditRepositoryDb_FindEntries_returns_page_after_givenId(t *testing.T) {
+	//Arrange
+	setupAuditRepoDbTest(t)
+	first, err := auditRepoDb.FindEntries(AuditFilter{RouteName: "NewTransaction", Limit: 10})
+	if err != nil {
+		t.Fatal("Expected no error but got error while finding entries: " + err.Message)
+	}
+
+	//Act
+	page, err := auditRepoDb.FindEntries(AuditFilter{ActorCustomerId: "2001", AfterId: first[0].EntryId, Limit: 1})
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if len(page) != 1 || page[0].RouteName != "NewTransfer" {
+		t.Errorf("Expected page of NewTransfer entry but got %+v", page)
+	}
+}
+
+func TestAuditRepositoryDb_FindEntries_returns_invalidEntry_when_entry_changedInDatabase(t *testing.T) {
+	//Arrange
+	client := setupAuditRepoDbTest(t)
+	client.MustExec("UPDATE audit_log SET status_code = 200 WHERE route_name = 'NewTransaction'")
+
+	//Act
+	entries, err := auditRepoDb.FindEntries(AuditFilter{RouteName: "NewTransaction", Limit: 10})
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if reason := entries[0].Verify(entries[0].PreviousHash); reason == "" {
+		t.Error("Expected changed entry to be invalid")
+	}
+}
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/jmoiron/sqlx"
+	"sync"
+)
+
+//Server
+
+// selectAuditLogPostgresSql selects the columns of the audit log, formatting dates the same way MySQL returns them,
+// which the hash of each entry covers.
+const selectAuditLogPostgresSql = "SELECT entry_id, actor_role, actor_customer_id, route_name, route_vars, " +
+	"request_hash, status_code, outcome, ip_address, to_char(created_on, 'YYYY-MM-DD HH24:MI:SS') AS created_on, " +
+	"previous_hash, entry_hash FROM audit_log"
+
+type AuditRepositoryPostgres struct { //DB (adapter)
+	client dbExecutor
+	mu     *sync.Mutex //appends one entry at a time, since each needs the hash of the one before
+}
+
+func NewAuditRepositoryPostgres(dbClient *sqlx.DB) AuditRepositoryPostgres {
+	return AuditRepositoryPostgres{dbClient, &sync.Mutex{}}
+}
+
+// Append starts a database transaction, chains the given entry to the last entry in the database, saves it, sets its
+// ID using the ID returned by the database and commits the database transaction. Like AuditRepositoryDb.Append, it
+// relies on the unique key on the previous hash should another app append an entry to the same log at the same time.
+func (d AuditRepositoryPostgres) Append(entry AuditEntry) (*AuditEntry, *errs.AppError) { //DB implements repo
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	err := inTransaction(d.client, "appending audit entry", func(tx dbExecutor) *errs.AppError {
+		previousHash := AuditGenesisHash
+		selectSql := "SELECT entry_hash FROM audit_log ORDER BY entry_id DESC LIMIT 1"
+		if err := tx.Get(&previousHash, selectSql); err != nil && !errors.Is(err, sql.ErrNoRows) {
+			logger.Error("Error while retrieving last audit entry: " + err.Error())
+			return errs.NewUnexpectedError("Unexpected database error")
+		}
+		entry.Chain(previousHash)
+
+		insertSql := "INSERT INTO audit_log (actor_role, actor_customer_id, route_name, route_vars, request_hash, " +
+			"status_code, outcome, ip_address, created_on, previous_hash, entry_hash) " +
+			"VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) RETURNING entry_id"
+		err := tx.Get(&entry.EntryId, insertSql, entry.ActorRole, entry.ActorCustomerId, entry.RouteName,
+			entry.RouteVars, entry.RequestHash, entry.StatusCode, entry.Outcome, entry.IpAddress, entry.CreatedOn,
+			entry.PreviousHash, entry.EntryHash)
+		if err != nil {
+			logger.Error("Error while creating new audit entry: " + err.Error())
+			return errs.NewUnexpectedError("Unexpected database error")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// FindEntries retrieves up to the filter's limit of entries matching the given filter, oldest first.
+func (d AuditRepositoryPostgres) FindEntries(filter AuditFilter) ([]AuditEntry, *errs.AppError) {
+	return findAuditEntries(d.client, selectAuditLogPostgresSql, filter)
+}
@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"net/http"
+	"testing"
+)
+
+// Test common variables and inputs
+var auditRepoPostgres AuditRepositoryPostgres
+
+const selectLastAuditEntryHashSql = "SELECT entry_hash FROM audit_log ORDER BY entry_id DESC LIMIT 1"
+const insertAuditLogPostgresSql = "INSERT INTO audit_log (actor_role, actor_customer_id, route_name, route_vars, " +
+	"request_hash, status_code, outcome, ip_address, created_on, previous_hash, entry_hash) " +
+	"VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) RETURNING entry_id"
+
+func setupAuditRepoPostgresTest(t *testing.T) func() {
+	teardown := setupDB(t)
+	auditRepoPostgres = NewAuditRepositoryPostgres(sqlx.NewDb(db, "postgres"))
+	return teardown
+}
+
+func TestAuditRepositoryPostgres_Append_returns_chainedEntry_with_returnedId(t *testing.T) {
+	//Arrange
+	teardown := setupAuditRepoPostgresTest(t)
+	defer teardown()
+
+	entry := NewAuditEntry(Actor{Role: RoleUser, CustomerId: "2001"}, "NewTransaction",
+		map[string]string{"customer_id": "2001"}, "abc", http.StatusCreated, "127.0.0.1")
+	entry.CreatedOn = dummyDate
+	previousHash := AuditGenesisHash[1:] + "1"
+	expectedEntry := entry
+	expectedEntry.Chain(previousHash)
+	expectedEntry.EntryId = "8"
+
+	mockDB.ExpectBegin()
+	mockDB.ExpectQuery(selectLastAuditEntryHashSql).
+		WillReturnRows(sqlmock.NewRows([]string{"entry_hash"}).AddRow(previousHash))
+	mockDB.ExpectQuery(insertAuditLogPostgresSql).
+		WithArgs(entry.ActorRole, entry.ActorCustomerId, entry.RouteName, entry.RouteVars, entry.RequestHash,
+			entry.StatusCode, entry.Outcome, entry.IpAddress, entry.CreatedOn, previousHash, expectedEntry.EntryHash).
+		WillReturnRows(sqlmock.NewRows([]string{"entry_id"}).AddRow(8))
+	mockDB.ExpectCommit()
+
+	//Act
+	actualEntry, err := auditRepoPostgres.Append(entry)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if *actualEntry != expectedEntry {
+		t.Errorf("Expected entry %+v but got %+v", expectedEntry, *actualEntry)
+	}
+}
+
+func TestAuditRepositoryPostgres_FindEntries_numbers_placeholders_of_filter(t *testing.T) {
+	//Arrange
+	teardown := setupAuditRepoPostgresTest(t)
+	defer teardown()
+
+	filter := AuditFilter{RouteName: "NewTransaction", AfterId: "100", Limit: 10}
+	mockDB.ExpectQuery(selectAuditLogPostgresSql+" WHERE route_name = $1 AND entry_id > $2 ORDER BY entry_id LIMIT $3").
+		WithArgs(filter.RouteName, filter.AfterId, filter.Limit).
+		WillReturnRows(sqlmock.NewRows([]string{"entry_id", "route_name", "created_on"}).
+			AddRow(101, filter.RouteName, dummyDate))
+
+	//Act
+	entries, err := auditRepoPostgres.FindEntries(filter)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if len(entries) != 1 || entries[0].EntryId != "101" || entries[0].CreatedOn != dummyDate {
+		t.Errorf("Expected entry 101 created on %s but got %+v", dummyDate, entries)
+	}
+}
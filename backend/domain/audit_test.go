@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"net/http"
+	"testing"
+)
+
+func getDummyAuditEntry(routeName string) AuditEntry {
+	entry := NewAuditEntry(Actor{Role: RoleUser, CustomerId: dummyCustomerId}, routeName,
+		map[string]string{"customer_id": dummyCustomerId, "account_id": dummyAccountId}, "abc", http.StatusCreated, "127.0.0.1")
+	entry.CreatedOn = dummyDate
+	return entry
+}
+
+func TestNewAuditEntry_sets_outcome_from_statusCode(t *testing.T) {
+	tests := []struct {
+		statusCode int
+		expected   string
+	}{
+		{http.StatusOK, AuditOutcomeSuccess},
+		{http.StatusCreated, AuditOutcomeSuccess},
+		{http.StatusForbidden, AuditOutcomeFailure},
+		{http.StatusInternalServerError, AuditOutcomeFailure},
+	}
+
+	for _, tc := range tests {
+		t.Run(http.StatusText(tc.statusCode), func(t *testing.T) {
+			entry := NewAuditEntry(Actor{}, "NewTransaction", nil, "", tc.statusCode, "")
+			if entry.Outcome != tc.expected {
+				t.Errorf("Expected outcome %s but got %s", tc.expected, entry.Outcome)
+			}
+		})
+	}
+}
+
+func TestAuditEntry_Verify_accepts_chained_entries(t *testing.T) {
+	//Arrange
+	first, second := getDummyAuditEntry("NewAccount"), getDummyAuditEntry("NewTransaction")
+	first.Chain(AuditGenesisHash)
+	second.Chain(first.EntryHash)
+
+	//Act
+	firstReason, secondReason := first.Verify(AuditGenesisHash), second.Verify(first.EntryHash)
+
+	//Assert
+	if firstReason != "" || secondReason != "" {
+		t.Errorf("Expected both entries to be valid but got \"%s\" and \"%s\"", firstReason, secondReason)
+	}
+	if first.EntryHash == second.EntryHash {
+		t.Error("Expected entries to have different hashes")
+	}
+}
+
+func TestAuditEntry_Verify_detects_tampering(t *testing.T) {
+	tests := []struct {
+		name           string
+		tamper         func(*AuditEntry)
+		previousHash   string
+		expectedReason string
+	}{
+		{"changed contents", func(e *AuditEntry) { e.StatusCode = http.StatusOK }, AuditGenesisHash,
+			"entry does not match its hash"},
+		{"changed actor", func(e *AuditEntry) { e.ActorCustomerId = "2001" }, AuditGenesisHash,
+			"entry does not match its hash"},
+		{"removed entry before", func(e *AuditEntry) {}, "some other hash", "entry does not follow the previous entry"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Arrange
+			entry := getDummyAuditEntry("NewTransaction")
+			entry.Chain(AuditGenesisHash)
+			tc.tamper(&entry)
+
+			//Act
+			reason := entry.Verify(tc.previousHash)
+
+			//Assert
+			if reason != tc.expectedReason {
+				t.Errorf("Expected \"%s\" but got \"%s\"", tc.expectedReason, reason)
+			}
+		})
+	}
+}
@@ -12,9 +12,15 @@ import (
 
 const AuthorizationHeaderPrefix = "Bearer "
 
+// Actor is who made a request, as far as the auth server tells.
+type Actor struct {
+	Role       string `json:"role"`
+	CustomerId string `json:"customer_id"` //customer the user is, for users with the "user" role
+}
+
 //go:generate mockgen -destination=../mocks/domain/mock_authRepository.go -package=domain github.com/aliciatay-zls/banking/backend/domain AuthRepository
 type AuthRepository interface { //repo (secondary port)
-	IsAuthorized(string, string, map[string]string) *errs.AppError
+	IsAuthorized(string, string, map[string]string) (*Actor, *errs.AppError)
 }
 
 type DefaultAuthRepository struct { //adapter
@@ -25,7 +31,11 @@ func NewDefaultAuthRepository() DefaultAuthRepository {
 	return DefaultAuthRepository{}
 }
 
-func (r DefaultAuthRepository) IsAuthorized(tokenString string, routeName string, routeVars map[string]string) *errs.AppError { //adapter implements repo
+// IsAuthorized asks the auth server whether the given token may access the given route, returning the actor described
+// by the auth server's response if so. The auth server must respond to an authorized request with status 200 and a
+// JSON body naming the actor's "role" and, for the "user" role, their "customer_id". A response without them is
+// treated as an error, since the actor is needed to tell admins apart and to record who made the request.
+func (r DefaultAuthRepository) IsAuthorized(tokenString string, routeName string, routeVars map[string]string) (*Actor, *errs.AppError) { //adapter implements repo
 	token := extractToken(tokenString)
 
 	verifyURL := buildURL(token, routeName, routeVars)
@@ -33,20 +43,29 @@ func (r DefaultAuthRepository) IsAuthorized(tokenString string, routeName string
 	response, err := http.Get(verifyURL)
 	if err != nil {
 		logger.Error("Error while sending request to verification URL: " + err.Error())
-		return errs.NewUnexpectedError("Internal server error")
+		return nil, errs.NewUnexpectedError("Internal server error")
 	}
 	if response.StatusCode != http.StatusOK {
 		responseData := map[string]string{}
 		if err = json.NewDecoder(response.Body).Decode(&responseData); err != nil {
 			logger.Error("Error while reading response from auth server: " + err.Error())
-			return errs.NewUnexpectedError("Internal server error")
+			return nil, errs.NewUnexpectedError("Internal server error")
 		}
 
 		logger.Error("Verification failed: " + responseData["message"])
-		return errs.NewAppError(response.StatusCode, responseData["message"])
+		return nil, errs.NewAppError(response.StatusCode, responseData["message"])
 	}
 
-	return nil
+	var actor Actor
+	if err = json.NewDecoder(response.Body).Decode(&actor); err != nil {
+		logger.Error("Error while reading actor from auth server response: " + err.Error())
+		return nil, errs.NewUnexpectedError("Internal server error")
+	}
+	if actor.Role == "" || (actor.Role == RoleUser && actor.CustomerId == "") {
+		logger.Error("Auth server response does not describe the actor")
+		return nil, errs.NewUnexpectedError("Internal server error")
+	}
+	return &actor, nil
 }
 
 // extractToken converts the value of the Authorization header from the form "Bearer <token>" to "<token>"
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"net/http"
+)
+
+// DelegationAuthRepository authorizes requests made by delegates to the accounts delegated to them, which the given
+// auth repository knows nothing about, and leaves all other requests to it.
+type DelegationAuthRepository struct { //adapter
+	auth        AuthRepository
+	delegations DelegationRepository
+	clk         clock.Clock
+}
+
+func NewDelegationAuthRepository(auth AuthRepository, delegations DelegationRepository,
+	clk clock.Clock) DelegationAuthRepository {
+	return DelegationAuthRepository{auth, delegations, clk}
+}
+
+// IsAuthorized checks whether the account in the given route vars is delegated to the customer in them, with a scope
+// that allows the given route. If so, the given auth repository is only asked whether the token may access the route
+// for the customer, without the account. Otherwise, it is asked as usual. The services check the delegation again,
+// since the auth server does not.
+func (r DelegationAuthRepository) IsAuthorized(tokenString string, routeName string, routeVars map[string]string) (*Actor, *errs.AppError) { //adapter implements repo
+	accountId, hasAccount := routeVars["account_id"]
+	customerId, hasCustomer := routeVars["customer_id"]
+	if !hasAccount || !hasCustomer {
+		return r.auth.IsAuthorized(tokenString, routeName, routeVars)
+	}
+
+	delegation, appErr := r.delegations.FindActive(accountId, customerId, r.clk.NowAsString())
+	if appErr != nil {
+		if appErr.Code != http.StatusNotFound {
+			return nil, appErr
+		}
+		return r.auth.IsAuthorized(tokenString, routeName, routeVars)
+	}
+	if !delegation.AllowsRoute(routeName) {
+		return r.auth.IsAuthorized(tokenString, routeName, routeVars)
+	}
+
+	customerVars := make(map[string]string)
+	for k, v := range routeVars {
+		if k != "account_id" {
+			customerVars[k] = v
+		}
+	}
+	return r.auth.IsAuthorized(tokenString, routeName, customerVars)
+}
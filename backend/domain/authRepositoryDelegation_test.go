@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"testing"
+)
+
+// authRepositoryRecorder is an AuthRepository that authorizes every request and records the route vars it was given.
+type authRepositoryRecorder struct {
+	routeVars map[string]string
+}
+
+func (r *authRepositoryRecorder) IsAuthorized(_ string, _ string, routeVars map[string]string) (*Actor, *errs.AppError) {
+	r.routeVars = routeVars
+	return &Actor{Role: RoleUser, CustomerId: routeVars["customer_id"]}, nil
+}
+
+// delegationRepositoryWithDelegation is a DelegationRepository that only finds the given delegation as active.
+type delegationRepositoryWithDelegation struct {
+	DelegationRepository
+	delegation Delegation
+}
+
+func (r delegationRepositoryWithDelegation) FindActive(accountId string, customerId string, _ string) (*Delegation, *errs.AppError) {
+	if r.delegation.AccountId == accountId && r.delegation.DelegateCustomerId == customerId {
+		return &r.delegation, nil
+	}
+	return nil, errs.NewNotFoundError("Delegation not found")
+}
+
+func TestDelegationAuthRepository_IsAuthorized_leaves_account_out_only_for_delegatedRoutes(t *testing.T) {
+	//Arrange
+	delegation := getDummyDelegation(dto.DelegationScopeDepositOnly)
+
+	tests := []struct {
+		name          string
+		routeName     string
+		routeVars     map[string]string
+		expectAccount bool
+	}{
+		{"delegated route", "NewTransaction", map[string]string{"customer_id": "3", "account_id": dummyAccountId}, false},
+		{"route outside scope", "NewTransfer", map[string]string{"customer_id": "3", "account_id": dummyAccountId}, true},
+		{"account not delegated", "NewTransaction", map[string]string{"customer_id": "3", "account_id": "1980"}, true},
+		{"other customer", "NewTransaction", map[string]string{"customer_id": "4", "account_id": dummyAccountId}, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			auth := &authRepositoryRecorder{}
+			repo := NewDelegationAuthRepository(auth, delegationRepositoryWithDelegation{delegation: delegation},
+				clock.StaticClock{})
+
+			//Act
+			_, err := repo.IsAuthorized("token", tc.routeName, tc.routeVars)
+
+			//Assert
+			if err != nil {
+				t.Fatal("Expected no error but got error: " + err.Message)
+			}
+			_, hasAccount := auth.routeVars["account_id"]
+			if hasAccount != tc.expectAccount || auth.routeVars["customer_id"] != tc.routeVars["customer_id"] {
+				t.Errorf("Expected auth server to be asked with account %v but got %v", tc.expectAccount, auth.routeVars)
+			}
+		})
+	}
+}
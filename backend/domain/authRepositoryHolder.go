@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"net/http"
+)
+
+// HolderAuthRepository authorizes requests made by joint and view-only holders to the accounts they hold, which the
+// given auth repository only knows the primary holders of, and leaves all other requests to it.
+type HolderAuthRepository struct { //adapter
+	auth     AuthRepository
+	accounts AccountRepository
+}
+
+func NewHolderAuthRepository(auth AuthRepository, accounts AccountRepository) HolderAuthRepository {
+	return HolderAuthRepository{auth, accounts}
+}
+
+// IsAuthorized checks whether the customer in the given route vars is a joint or view-only holder of the account in
+// them. If so, the given auth repository is only asked whether the token may access the route for the customer,
+// without the account, and a view-only holder is also limited to the routes in viewOnlyRoutes unless the token is an
+// admin's. Otherwise, it is asked as usual. The services check again that the customer holds the account, since the
+// auth server does not.
+func (r HolderAuthRepository) IsAuthorized(tokenString string, routeName string, routeVars map[string]string) (*Actor, *errs.AppError) { //adapter implements repo
+	accountId, hasAccount := routeVars["account_id"]
+	customerId, hasCustomer := routeVars["customer_id"]
+	if !hasAccount || !hasCustomer {
+		return r.auth.IsAuthorized(tokenString, routeName, routeVars)
+	}
+
+	holder, appErr := r.accounts.FindHolder(accountId, customerId)
+	if appErr != nil {
+		if appErr.Code != http.StatusNotFound {
+			return nil, appErr
+		}
+		return r.auth.IsAuthorized(tokenString, routeName, routeVars)
+	}
+	if holder.IsPrimary() {
+		return r.auth.IsAuthorized(tokenString, routeName, routeVars)
+	}
+
+	customerVars := make(map[string]string)
+	for k, v := range routeVars {
+		if k != "account_id" {
+			customerVars[k] = v
+		}
+	}
+	actor, appErr := r.auth.IsAuthorized(tokenString, routeName, customerVars)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if !holder.CanTransact() && !viewOnlyRoutes[routeName] && actor.Role != RoleAdmin {
+		logger.Error("View-only holder " + customerId + " cannot access route " + routeName)
+		return nil, errs.NewAuthorizationError("Access denied")
+	}
+	return actor, nil
+}
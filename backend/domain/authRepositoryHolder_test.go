@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"net/http"
+	"testing"
+)
+
+func TestHolderAuthRepository_IsAuthorized_leaves_account_out_only_for_jointAndViewOnlyHolders(t *testing.T) {
+	//Arrange
+	accountRepo := accountRepositoryWithHolders{
+		NewAccountRepositoryStub([]Account{{AccountId: "95470", CustomerId: "2000"}}),
+		[]AccountHolder{
+			{AccountId: "95470", CustomerId: "2001", Role: dto.AccountHolderRoleJoint},
+			{AccountId: "95470", CustomerId: "2002", Role: dto.AccountHolderRoleViewOnly},
+		},
+	}
+	logger.MuteLogger()
+
+	tests := []struct {
+		name          string
+		routeName     string
+		customerId    string
+		expectAccount bool
+		expectedCode  int //0 if authorized
+	}{
+		{"primary holder", "NewTransaction", "2000", true, 0},
+		{"joint holder making transaction", "NewTransaction", "2001", false, 0},
+		{"view-only holder getting transactions", "GetTransactions", "2002", false, 0},
+		{"view-only holder making transaction", "NewTransaction", "2002", false, http.StatusForbidden},
+		{"not a holder", "NewTransaction", "2003", true, 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			auth := &authRepositoryRecorder{}
+			repo := NewHolderAuthRepository(auth, accountRepo)
+
+			//Act
+			_, err := repo.IsAuthorized("token", tc.routeName, map[string]string{"customer_id": tc.customerId,
+				"account_id": "95470"})
+
+			//Assert
+			if tc.expectedCode == 0 && err != nil {
+				t.Fatal("Expected no error but got error: " + err.Message)
+			}
+			if tc.expectedCode != 0 && (err == nil || err.Code != tc.expectedCode) {
+				t.Fatalf("Expected error with status code %d but got %v", tc.expectedCode, err)
+			}
+			_, hasAccount := auth.routeVars["account_id"]
+			if hasAccount != tc.expectAccount || auth.routeVars["customer_id"] != tc.customerId {
+				t.Errorf("Expected auth server to be asked with account %v but got %v", tc.expectAccount, auth.routeVars)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+)
+
+//Server
+
+const RoleAdmin = "admin"
+const RoleUser = "user"
+
+// userRoutes are the routes that users with the "user" role may access, as long as the customer and account in the
+// route are their own. Admins may access every route.
+var userRoutes = map[string]bool{
+	"GetAccountsForCustomer":     true,
+	"GetCustomer":                true,
+	"NewAccount":                 true,
+	"NewTransaction":             true,
+	"NewTransfer":                true,
+	"InitiatePayments":           true,
+	"NewBeneficiary":             true,
+	"GetBeneficiaries":           true,
+	"UpdateBeneficiary":          true,
+	"DeleteBeneficiary":          true,
+	"GetTransactions":            true,
+	"GetStatement":               true,
+	"ExportTransactions":         true,
+	"NewHold":                    true,
+	"GetHolds":                   true,
+	"CaptureHold":                true,
+	"ReleaseHold":                true,
+	"NewStandingOrder":           true,
+	"GetStandingOrders":          true,
+	"GetStandingOrderExecutions": true,
+	"CancelStandingOrder":        true,
+	"NewWebhook":                 true,
+	"GetWebhooks":                true,
+	"DisableWebhook":             true,
+	"GetWebhookDeliveries":       true,
+	"ReplayWebhookDelivery":      true,
+	"GetAccountHolders":          true,
+	"AddAccountHolder":           true,
+	"RemoveAccountHolder":        true,
+	"GetReceivedDelegations":     true,
+	"GetDelegations":             true,
+	"GrantDelegation":            true,
+	"RevokeDelegation":           true,
+}
+
+// viewOnlyRoutes are the routes of an account that its view-only holders may access, which only look at the account,
+// apart from leaving it.
+var viewOnlyRoutes = map[string]bool{
+	"GetTransactions":     true,
+	"GetStatement":        true,
+	"ExportTransactions":  true,
+	"GetHolds":            true,
+	"GetAccountHolders":   true,
+	"RemoveAccountHolder": true,
+	"GetDelegations":      true,
+}
+
+// AuthRepositoryStub verifies requests in place of the auth server, so that the app can run without it. Instead of
+// access tokens issued on login, each user has a fixed token. It never changes its users after it is created, so it is
+// safe for concurrent use.
+type AuthRepositoryStub struct { //stub (adapter)
+	users       map[string]StubUser
+	accountRepo AccountRepository
+}
+
+// NewAuthRepositoryStub creates a stub for the given users, which checks that the customer holds the accounts in routes
+// using the given account repository.
+func NewAuthRepositoryStub(users []StubUser, accountRepo AccountRepository) AuthRepositoryStub {
+	usersByToken := make(map[string]StubUser)
+	for _, u := range users {
+		usersByToken[u.Token] = u
+	}
+	return AuthRepositoryStub{usersByToken, accountRepo}
+}
+
+// IsAuthorized checks the given token the same way the auth server does: admins may access every route, while users
+// may only access the routes in userRoutes for their own customer ID and the accounts they hold, and only the routes in
+// viewOnlyRoutes for the accounts they are a view-only holder of.
+func (s AuthRepositoryStub) IsAuthorized(tokenString string, routeName string, routeVars map[string]string) (*Actor, *errs.AppError) { //stub implements repo
+	user, ok := s.users[extractToken(tokenString)]
+	if !ok {
+		logger.Error("Error while verifying token using stub for AuthRepository: unknown token")
+		return nil, errs.NewAuthenticationErrorDueToInvalidAccessToken()
+	}
+	actor := Actor{Role: user.Role, CustomerId: user.CustomerId}
+	if user.Role == RoleAdmin {
+		return &actor, nil
+	}
+
+	if !userRoutes[routeName] || routeVars["customer_id"] != user.CustomerId {
+		logger.Error("Error while verifying token using stub for AuthRepository: user cannot access route " + routeName)
+		return nil, errs.NewAuthorizationError("Access denied")
+	}
+	if accountId, ok := routeVars["account_id"]; ok {
+		holder, appErr := s.accountRepo.FindHolder(accountId, user.CustomerId)
+		if appErr != nil {
+			logger.Error("Error while verifying token using stub for AuthRepository: user does not hold account")
+			return nil, errs.NewAuthorizationError("Access denied")
+		}
+		if !holder.CanTransact() && !viewOnlyRoutes[routeName] {
+			logger.Error("Error while verifying token using stub for AuthRepository: view-only holder cannot access route " + routeName)
+			return nil, errs.NewAuthorizationError("Access denied")
+		}
+	}
+	return &actor, nil
+}
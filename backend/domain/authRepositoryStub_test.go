@@ -0,0 +1,134 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"net/http"
+	"testing"
+)
+
+func TestAuthRepositoryStub_IsAuthorized(t *testing.T) {
+	//Arrange
+	users := []StubUser{
+		{Token: "admin-token", Role: RoleAdmin},
+		{Token: "user-token", Role: RoleUser, CustomerId: "2000"},
+	}
+	authRepositoryStub := NewAuthRepositoryStub(users, NewAccountRepositoryStub([]Account{
+		{AccountId: "95470", CustomerId: "2000"},
+		{AccountId: "95471", CustomerId: "2001"},
+	}))
+	logger.MuteLogger()
+
+	tests := []struct {
+		name         string
+		token        string
+		routeName    string
+		routeVars    map[string]string
+		expectedCode int //0 if authorized
+	}{
+		{"unknown token", "Bearer other-token", "GetCustomer", map[string]string{"customer_id": "2000"}, http.StatusUnauthorized},
+		{"admin on admin route", "Bearer admin-token", "GetAllCustomers", map[string]string{}, 0},
+		{"admin on other customer", "Bearer admin-token", "GetCustomer", map[string]string{"customer_id": "2001"}, 0},
+		{"user on own customer", "Bearer user-token", "GetCustomer", map[string]string{"customer_id": "2000"}, 0},
+		{"user on own account", "Bearer user-token", "NewTransaction", map[string]string{"customer_id": "2000", "account_id": "95470"}, 0},
+		{"user on admin route", "Bearer user-token", "GetAllCustomers", map[string]string{}, http.StatusForbidden},
+		{"user on other customer", "Bearer user-token", "GetCustomer", map[string]string{"customer_id": "2001"}, http.StatusForbidden},
+		{"user on other customer's account", "Bearer user-token", "NewTransaction", map[string]string{"customer_id": "2000", "account_id": "95471"}, http.StatusForbidden},
+		{"user on non-existent account", "Bearer user-token", "NewTransaction", map[string]string{"customer_id": "2000", "account_id": "1"}, http.StatusForbidden},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Act
+			_, err := authRepositoryStub.IsAuthorized(tc.token, tc.routeName, tc.routeVars)
+
+			//Assert
+			if tc.expectedCode == 0 && err != nil {
+				t.Errorf("Expected no error but got error: %s", err.Message)
+			}
+			if tc.expectedCode != 0 && (err == nil || err.Code != tc.expectedCode) {
+				t.Errorf("Expected error with status code %d but got %v", tc.expectedCode, err)
+			}
+		})
+	}
+}
+
+func TestAuthRepositoryStub_IsAuthorized_returns_user_as_actor(t *testing.T) {
+	//Arrange
+	authRepositoryStub := NewAuthRepositoryStub([]StubUser{{Token: "user-token", Role: RoleUser, CustomerId: "2000"}},
+		NewAccountRepositoryStub(nil))
+	expected := Actor{Role: RoleUser, CustomerId: "2000"}
+
+	//Act
+	actor, err := authRepositoryStub.IsAuthorized("Bearer user-token", "GetCustomer", map[string]string{"customer_id": "2000"})
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if *actor != expected {
+		t.Errorf("Expected actor %+v but got %+v", expected, *actor)
+	}
+}
+
+// accountRepositoryWithHolders is an AccountRepositoryStub with extra holders, which the stub itself does not keep.
+type accountRepositoryWithHolders struct {
+	*AccountRepositoryStub
+	holders []AccountHolder
+}
+
+func (r accountRepositoryWithHolders) FindHolder(accountId string, customerId string) (*AccountHolder, *errs.AppError) {
+	for _, h := range r.holders {
+		if h.AccountId == accountId && h.CustomerId == customerId {
+			return &h, nil
+		}
+	}
+	return r.AccountRepositoryStub.FindHolder(accountId, customerId)
+}
+
+func TestAuthRepositoryStub_IsAuthorized_checks_holderRole(t *testing.T) {
+	//Arrange
+	users := []StubUser{
+		{Token: "joint-token", Role: RoleUser, CustomerId: "2001"},
+		{Token: "viewer-token", Role: RoleUser, CustomerId: "2002"},
+	}
+	accountRepo := accountRepositoryWithHolders{
+		NewAccountRepositoryStub([]Account{{AccountId: "95470", CustomerId: "2000"}}),
+		[]AccountHolder{
+			{AccountId: "95470", CustomerId: "2001", Role: dto.AccountHolderRoleJoint},
+			{AccountId: "95470", CustomerId: "2002", Role: dto.AccountHolderRoleViewOnly},
+		},
+	}
+	authRepositoryStub := NewAuthRepositoryStub(users, accountRepo)
+	logger.MuteLogger()
+
+	tests := []struct {
+		name         string
+		token        string
+		routeName    string
+		customerId   string
+		expectedCode int //0 if authorized
+	}{
+		{"joint holder making transaction", "Bearer joint-token", "NewTransaction", "2001", 0},
+		{"view-only holder getting transactions", "Bearer viewer-token", "GetTransactions", "2002", 0},
+		{"view-only holder making transaction", "Bearer viewer-token", "NewTransaction", "2002", http.StatusForbidden},
+		{"view-only holder making transfer", "Bearer viewer-token", "NewTransfer", "2002", http.StatusForbidden},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Act
+			_, err := authRepositoryStub.IsAuthorized(tc.token, tc.routeName,
+				map[string]string{"customer_id": tc.customerId, "account_id": "95470"})
+
+			//Assert
+			if tc.expectedCode == 0 && err != nil {
+				t.Errorf("Expected no error but got error: %s", err.Message)
+			}
+			if tc.expectedCode != 0 && (err == nil || err.Code != tc.expectedCode) {
+				t.Errorf("Expected error with status code %d but got %v", tc.expectedCode, err)
+			}
+		})
+	}
+}
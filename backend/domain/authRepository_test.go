@@ -100,7 +100,7 @@ func TestDefaultAuthRepository_IsAuthorized_returns_error_when_error_sending_req
 	expectedLogMessagePrefix := "Error while sending request to verification URL: "
 
 	//Act
-	actualErr := authRepo.IsAuthorized(dummyToken, dummyRouteName, dummyRouteVars)
+	_, actualErr := authRepo.IsAuthorized(dummyToken, dummyRouteName, dummyRouteVars)
 
 	//Assert
 	if actualErr == nil {
@@ -135,7 +135,7 @@ func TestDefaultAuthRepository_IsAuthorized_returns_error_when_error_decoding_au
 	startDummyAuthServer(dummyVerifyAPIHandler)
 
 	//Act
-	actualErr := authRepo.IsAuthorized(dummyToken, dummyRouteName, dummyRouteVars)
+	_, actualErr := authRepo.IsAuthorized(dummyToken, dummyRouteName, dummyRouteVars)
 
 	//Assert
 	if actualErr == nil {
@@ -174,7 +174,7 @@ func TestDefaultAuthRepository_IsAuthorized_returns_error_when_authServer_respon
 	startDummyAuthServer(dummyVerifyAPIHandler)
 
 	//Act
-	actualErr := authRepo.IsAuthorized(dummyToken, dummyRouteName, dummyRouteVars)
+	_, actualErr := authRepo.IsAuthorized(dummyToken, dummyRouteName, dummyRouteVars)
 
 	//Assert
 	if actualErr == nil {
@@ -196,24 +196,55 @@ func TestDefaultAuthRepository_IsAuthorized_returns_error_when_authServer_respon
 	<-channelWaitForShutDown
 }
 
-func TestDefaultAuthRepository_IsAuthorized_returns_nil_when_authServer_respondsWith_200(t *testing.T) {
+func TestDefaultAuthRepository_IsAuthorized_returns_actor_when_authServer_respondsWith_200(t *testing.T) {
 	//Arrange
 	setupAuthRepositoryTest(t)
 	dummyStatusCode := http.StatusOK
 	dummyResponse := map[string]string{
-		"message": "",
+		"role":        RoleUser,
+		"customer_id": dummyCustomerId,
 	}
 
 	dummyVerifyAPIHandler := getDummyVerifyAPIHandler(t, dummyStatusCode, dummyResponse)
 	startDummyAuthServer(dummyVerifyAPIHandler)
 
 	//Act
-	actualErr := authRepo.IsAuthorized(dummyToken, dummyRouteName, dummyRouteVars)
+	actor, actualErr := authRepo.IsAuthorized(dummyToken, dummyRouteName, dummyRouteVars)
 
 	//Assert
 	if actualErr != nil {
 		t.Error("Expected no error but got error while testing successful case: " + actualErr.Message)
 	}
+	if actor == nil || *actor != (Actor{Role: RoleUser, CustomerId: dummyCustomerId}) {
+		t.Errorf("Expected actor of customer %s but got %v", dummyCustomerId, actor)
+	}
+
+	//Cleanup
+	channelDoShutDown <- 1
+	<-channelWaitForShutDown
+}
+
+func TestDefaultAuthRepository_IsAuthorized_returns_error_when_authServer_respondsWith_200_without_actor(t *testing.T) {
+	//Arrange
+	setupAuthRepositoryTest(t)
+	dummyStatusCode := http.StatusOK
+	dummyResponse := map[string]string{
+		"message": "",
+	}
+
+	dummyVerifyAPIHandler := getDummyVerifyAPIHandler(t, dummyStatusCode, dummyResponse)
+	startDummyAuthServer(dummyVerifyAPIHandler)
+
+	//Act
+	actor, actualErr := authRepo.IsAuthorized(dummyToken, dummyRouteName, dummyRouteVars)
+
+	//Assert
+	if actualErr == nil || actualErr.Code != http.StatusInternalServerError {
+		t.Errorf("Expected unexpected error but got %v", actualErr)
+	}
+	if actor != nil {
+		t.Errorf("Expected no actor but got %v", actor)
+	}
 
 	//Cleanup
 	channelDoShutDown <- 1
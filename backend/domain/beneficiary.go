@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"time"
+)
+
+//Business Domain
+
+// BeneficiaryPolicy is how transfers to newly added beneficiaries are restricted: not at all during their cooling-off
+// period, and up to a lower limit for their first transfer.
+type BeneficiaryPolicy struct { //business/domain object
+	CoolingOffPeriod   time.Duration
+	FirstTransferLimit float64
+}
+
+// DefaultBeneficiaryPolicy returns the policy used when no beneficiary policy is configured.
+func DefaultBeneficiaryPolicy() BeneficiaryPolicy {
+	return BeneficiaryPolicy{CoolingOffPeriod: 24 * time.Hour, FirstTransferLimit: 1000}
+}
+
+// Beneficiary is a payee that a customer may transfer to. Its cooling-off period starts when it is added, and again
+// whenever the account it pays to is changed.
+type Beneficiary struct { //business/domain object
+	BeneficiaryId     string         `db:"beneficiary_id"`
+	CustomerId        string         `db:"customer_id"`
+	Nickname          string         `db:"nickname"`
+	AccountId         string         `db:"account_id"`
+	BankCode          sql.NullString `db:"bank_code"` //NULL for accounts of this bank
+	CreationDate      string         `db:"creation_date"`
+	CoolingOffStart   string         `db:"cooling_off_start"`
+	FirstTransferDate sql.NullString `db:"first_transfer_date"`
+}
+
+func NewBeneficiary(request dto.BeneficiaryRequest, c clock.Clock) Beneficiary {
+	now := c.NowAsString()
+	return Beneficiary{
+		CustomerId:      request.CustomerId,
+		Nickname:        request.Nickname,
+		AccountId:       request.AccountId,
+		BankCode:        sql.NullString{String: request.BankCode, Valid: request.BankCode != ""},
+		CreationDate:    now,
+		CoolingOffStart: now,
+	}
+}
+
+// Update changes the beneficiary to the one in the given request. If it pays to another account, it is treated as
+// newly added: its cooling-off period starts again and its next transfer is a first transfer.
+func (b Beneficiary) Update(request dto.BeneficiaryRequest, c clock.Clock) Beneficiary {
+	updated := NewBeneficiary(request, c)
+	updated.BeneficiaryId = b.BeneficiaryId
+	updated.CreationDate = b.CreationDate
+	if updated.IsSameAccount(b.AccountId, b.BankCode.String) {
+		updated.CoolingOffStart = b.CoolingOffStart
+		updated.FirstTransferDate = b.FirstTransferDate
+	}
+	return updated
+}
+
+// IsSameAccount checks whether the beneficiary pays to the account with the given ID at the bank with the given code,
+// which is empty for this bank.
+func (b Beneficiary) IsSameAccount(accountId string, bankCode string) bool {
+	return b.AccountId == accountId && b.BankCode.String == bankCode
+}
+
+// CoolingOffEnd returns when the beneficiary's cooling-off period under the given policy ends.
+func (b Beneficiary) CoolingOffEnd(policy BeneficiaryPolicy) time.Time {
+	start, _ := time.Parse(clock.FormatDateTime, b.CoolingOffStart)
+	return start.Add(policy.CoolingOffPeriod)
+}
+
+// TransferLimit returns the most that can be transferred to the beneficiary at once under the given policy.
+func (b Beneficiary) TransferLimit(policy BeneficiaryPolicy) float64 {
+	if b.FirstTransferDate.Valid || policy.FirstTransferLimit > dto.TransactionMaxAmountAllowed {
+		return dto.TransactionMaxAmountAllowed
+	}
+	return policy.FirstTransferLimit
+}
+
+// CheckTransfer checks whether the given amount can be transferred to the beneficiary at the given time under the
+// given policy.
+func (b Beneficiary) CheckTransfer(amount float64, policy BeneficiaryPolicy, now time.Time) *errs.AppError {
+	if end := b.CoolingOffEnd(policy); now.Before(end) {
+		return errs.NewValidationError("Transfers to beneficiary " + b.Nickname + " can only be made from " +
+			end.Format(clock.FormatDateTime) + ", once its cooling-off period is over")
+	}
+	if limit := b.TransferLimit(policy); amount > limit {
+		return errs.NewValidationError(fmt.Sprintf("The first transfer to beneficiary %s can be at most %.2f",
+			b.Nickname, limit))
+	}
+	return nil
+}
+
+func (b Beneficiary) ToDTO(policy BeneficiaryPolicy) *dto.BeneficiaryResponse {
+	return &dto.BeneficiaryResponse{
+		BeneficiaryId:     b.BeneficiaryId,
+		Nickname:          b.Nickname,
+		AccountId:         b.AccountId,
+		BankCode:          b.BankCode.String,
+		CreationDate:      b.CreationDate,
+		CoolingOffEndDate: b.CoolingOffEnd(policy).Format(clock.FormatDateTime),
+		FirstTransferDate: b.FirstTransferDate.String,
+		TransferLimit:     b.TransferLimit(policy),
+	}
+}
+
+//Server
+
+//go:generate mockgen -destination=../mocks/domain/mock_beneficiaryRepository.go -package=domain github.com/aliciatay-zls/banking/backend/domain BeneficiaryRepository
+type BeneficiaryRepository interface { //repo (secondary port)
+	Save(Beneficiary) (*Beneficiary, *errs.AppError)
+	FindById(string) (*Beneficiary, *errs.AppError)
+	FindAll(customerId string) ([]Beneficiary, *errs.AppError)
+	FindByAccount(customerId string, accountId string) (*Beneficiary, *errs.AppError)
+	Update(Beneficiary) *errs.AppError
+	Delete(string) *errs.AppError
+	SetFirstTransferDate(beneficiaryId string, date string) *errs.AppError
+}
@@ -0,0 +1,139 @@
+package domain
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/jmoiron/sqlx"
+	"strconv"
+)
+
+//Server
+
+type BeneficiaryRepositoryDb struct { //DB (adapter)
+	client dbExecutor
+}
+
+func NewBeneficiaryRepositoryDb(dbClient *sqlx.DB) BeneficiaryRepositoryDb {
+	return BeneficiaryRepositoryDb{dbClient}
+}
+
+// Save creates a new entry in the database for the given beneficiary, sets its ID using the database-generated ID and
+// returns the beneficiary.
+func (d BeneficiaryRepositoryDb) Save(b Beneficiary) (*Beneficiary, *errs.AppError) { //DB implements repo
+	insertSql := "INSERT INTO beneficiaries (customer_id, nickname, account_id, bank_code, creation_date, " +
+		"cooling_off_start, first_transfer_date) VALUES (?, ?, ?, ?, ?, ?, ?)"
+	result, err := d.client.Exec(insertSql, b.CustomerId, b.Nickname, b.AccountId, b.BankCode, b.CreationDate,
+		b.CoolingOffStart, b.FirstTransferDate)
+	if err != nil {
+		logger.Error("Error while creating new beneficiary: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		logger.Error("Error while getting id of newly inserted beneficiary: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	b.BeneficiaryId = strconv.FormatInt(id, 10)
+
+	return &b, nil
+}
+
+// FindById retrieves the beneficiary with the given id.
+func (d BeneficiaryRepositoryDb) FindById(beneficiaryId string) (*Beneficiary, *errs.AppError) { //DB implements repo
+	return findBeneficiary(d.client, "SELECT * FROM beneficiaries", beneficiaryId)
+}
+
+// FindAll retrieves all beneficiaries of the customer with the given id, oldest first.
+func (d BeneficiaryRepositoryDb) FindAll(customerId string) ([]Beneficiary, *errs.AppError) { //DB implements repo
+	return findBeneficiaries(d.client, "SELECT * FROM beneficiaries", customerId)
+}
+
+// FindByAccount retrieves the beneficiary of the customer with the given id that pays to the account of this bank
+// with the given id.
+func (d BeneficiaryRepositoryDb) FindByAccount(customerId string, accountId string) (*Beneficiary, *errs.AppError) { //DB implements repo
+	return findBeneficiaryByAccount(d.client, "SELECT * FROM beneficiaries", customerId, accountId)
+}
+
+// Update saves the nickname, account, cooling-off start and first transfer date of the given beneficiary.
+func (d BeneficiaryRepositoryDb) Update(b Beneficiary) *errs.AppError { //DB implements repo
+	return updateBeneficiary(d.client, b)
+}
+
+// Delete removes the beneficiary with the given id.
+func (d BeneficiaryRepositoryDb) Delete(beneficiaryId string) *errs.AppError { //DB implements repo
+	return deleteBeneficiary(d.client, beneficiaryId)
+}
+
+// SetFirstTransferDate records that the first transfer to the beneficiary with the given id was made on the given
+// date, unless an earlier one was already recorded.
+func (d BeneficiaryRepositoryDb) SetFirstTransferDate(beneficiaryId string, date string) *errs.AppError { //DB implements repo
+	return setFirstTransferDate(d.client, beneficiaryId, date)
+}
+
+// findBeneficiary, findBeneficiaries and findBeneficiaryByAccount retrieve beneficiaries with the given select
+// statement, so that each adapter only needs to say how its database returns the columns of beneficiaries.
+func findBeneficiary(client dbExecutor, selectSql string, beneficiaryId string) (*Beneficiary, *errs.AppError) {
+	var b Beneficiary
+	if err := client.Get(&b, client.Rebind(selectSql+" WHERE beneficiary_id = ?"), beneficiaryId); err != nil {
+		logger.Error("Error while retrieving beneficiary: " + err.Error())
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.NewNotFoundError("Beneficiary not found")
+		}
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return &b, nil
+}
+
+func findBeneficiaries(client dbExecutor, selectSql string, customerId string) ([]Beneficiary, *errs.AppError) {
+	beneficiaries := make([]Beneficiary, 0)
+	selectSql += " WHERE customer_id = ? ORDER BY beneficiary_id"
+	if err := client.Select(&beneficiaries, client.Rebind(selectSql), customerId); err != nil {
+		logger.Error("Error while retrieving beneficiaries of customer: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return beneficiaries, nil
+}
+
+func findBeneficiaryByAccount(client dbExecutor, selectSql string, customerId string, accountId string) (*Beneficiary, *errs.AppError) {
+	var b Beneficiary
+	selectSql += " WHERE customer_id = ? AND account_id = ? AND bank_code IS NULL ORDER BY beneficiary_id LIMIT 1"
+	if err := client.Get(&b, client.Rebind(selectSql), customerId, accountId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.NewNotFoundError("Beneficiary not found")
+		}
+		logger.Error("Error while retrieving beneficiary by account: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return &b, nil
+}
+
+func updateBeneficiary(client dbExecutor, b Beneficiary) *errs.AppError {
+	updateSql := "UPDATE beneficiaries SET nickname = ?, account_id = ?, bank_code = ?, cooling_off_start = ?, " +
+		"first_transfer_date = ? WHERE beneficiary_id = ?"
+	if _, err := client.Exec(client.Rebind(updateSql), b.Nickname, b.AccountId, b.BankCode, b.CoolingOffStart,
+		b.FirstTransferDate, b.BeneficiaryId); err != nil {
+		logger.Error("Error while updating beneficiary: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	return nil
+}
+
+func deleteBeneficiary(client dbExecutor, beneficiaryId string) *errs.AppError {
+	if _, err := client.Exec(client.Rebind("DELETE FROM beneficiaries WHERE beneficiary_id = ?"), beneficiaryId); err != nil {
+		logger.Error("Error while deleting beneficiary: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	return nil
+}
+
+func setFirstTransferDate(client dbExecutor, beneficiaryId string, date string) *errs.AppError {
+	updateSql := "UPDATE beneficiaries SET first_transfer_date = ? WHERE beneficiary_id = ? AND first_transfer_date IS NULL"
+	if _, err := client.Exec(client.Rebind(updateSql), date, beneficiaryId); err != nil {
+		logger.Error("Error while setting first transfer date of beneficiary: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	return nil
+}
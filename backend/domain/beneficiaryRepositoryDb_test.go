@@ -0,0 +1,157 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"net/http"
+	"testing"
+)
+
+// These tests run on a real SQLite database seeded with the demo data, since which beneficiaries are accounts of this
+// bank is only decided when the SQL is executed, which go-sqlmock never does.
+
+var beneficiaryRepoDb BeneficiaryRepositoryDb
+
+// setupBeneficiaryRepoDbTest opens a new SQLite database and saves two beneficiaries of customer 2000 for account
+// 95472 in it, the first at this bank and the second at another bank.
+func setupBeneficiaryRepoDbTest(t *testing.T) (*Beneficiary, *Beneficiary) {
+	logger.MuteLogger()
+	beneficiaryRepoDb = NewBeneficiaryRepositoryDb(openSQLiteDb(t))
+	clk := clock.StaticClock{}
+
+	local, err := beneficiaryRepoDb.Save(NewBeneficiary(dto.BeneficiaryRequest{CustomerId: "2000",
+		Nickname: "landlord", AccountId: "95472"}, clk))
+	if err != nil {
+		t.Fatal("Expected no error but got error while saving beneficiary: " + err.Message)
+	}
+	abroad, err := beneficiaryRepoDb.Save(NewBeneficiary(dto.BeneficiaryRequest{CustomerId: "2000",
+		Nickname: "sister", AccountId: "95472", BankCode: "DEUTDEFF"}, clk))
+	if err != nil {
+		t.Fatal("Expected no error but got error while saving beneficiary: " + err.Message)
+	}
+	return local, abroad
+}
+
+func TestBeneficiaryRepositoryDb_FindById_returns_savedBeneficiary(t *testing.T) {
+	//Arrange
+	local, _ := setupBeneficiaryRepoDbTest(t)
+
+	//Act
+	found, err := beneficiaryRepoDb.FindById(local.BeneficiaryId)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if *found != *local {
+		t.Errorf("Expected beneficiary %+v but got %+v", *local, *found)
+	}
+}
+
+func TestBeneficiaryRepositoryDb_FindAll_returns_beneficiaries_of_customer(t *testing.T) {
+	//Arrange
+	setupBeneficiaryRepoDbTest(t)
+
+	//Act
+	all, err := beneficiaryRepoDb.FindAll("2000")
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if len(all) != 2 {
+		t.Errorf("Expected 2 beneficiaries but got %+v", all)
+	}
+}
+
+func TestBeneficiaryRepositoryDb_FindByAccount_returns_beneficiary_at_thisBank(t *testing.T) {
+	//Arrange
+	local, _ := setupBeneficiaryRepoDbTest(t)
+
+	//Act
+	found, err := beneficiaryRepoDb.FindByAccount("2000", "95472")
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if found.BeneficiaryId != local.BeneficiaryId {
+		t.Errorf("Expected beneficiary %s but got %+v", local.BeneficiaryId, *found)
+	}
+}
+
+func TestBeneficiaryRepositoryDb_FindByAccount_returns_notFoundError_when_account_notBeneficiary(t *testing.T) {
+	//Arrange
+	setupBeneficiaryRepoDbTest(t)
+
+	//Act
+	_, err := beneficiaryRepoDb.FindByAccount("2001", "95472")
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none")
+	}
+	if err.Code != http.StatusNotFound {
+		t.Errorf("Expected status code %d but got %d", http.StatusNotFound, err.Code)
+	}
+}
+
+func TestBeneficiaryRepositoryDb_Update_saves_nickname(t *testing.T) {
+	//Arrange
+	local, _ := setupBeneficiaryRepoDbTest(t)
+	local.Nickname = "old landlord"
+
+	//Act
+	err := beneficiaryRepoDb.Update(*local)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	found, err := beneficiaryRepoDb.FindById(local.BeneficiaryId)
+	if err != nil {
+		t.Fatal("Expected no error but got error while finding beneficiary: " + err.Message)
+	}
+	if found.Nickname != "old landlord" {
+		t.Errorf("Expected nickname old landlord but got %+v", *found)
+	}
+}
+
+func TestBeneficiaryRepositoryDb_SetFirstTransferDate_saves_date(t *testing.T) {
+	//Arrange
+	local, _ := setupBeneficiaryRepoDbTest(t)
+	now := clock.StaticClock{}.NowAsString()
+
+	//Act
+	err := beneficiaryRepoDb.SetFirstTransferDate(local.BeneficiaryId, now)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	found, err := beneficiaryRepoDb.FindById(local.BeneficiaryId)
+	if err != nil {
+		t.Fatal("Expected no error but got error while finding beneficiary: " + err.Message)
+	}
+	if found.FirstTransferDate.String != now {
+		t.Errorf("Expected first transfer date %s but got %+v", now, *found)
+	}
+}
+
+func TestBeneficiaryRepositoryDb_Delete_removes_beneficiary(t *testing.T) {
+	//Arrange
+	_, abroad := setupBeneficiaryRepoDbTest(t)
+
+	//Act
+	err := beneficiaryRepoDb.Delete(abroad.BeneficiaryId)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	_, err = beneficiaryRepoDb.FindById(abroad.BeneficiaryId)
+	if err == nil || err.Code != http.StatusNotFound {
+		t.Errorf("Expected not found error for deleted beneficiary but got %v", err)
+	}
+}
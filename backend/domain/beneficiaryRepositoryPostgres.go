@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/jmoiron/sqlx"
+)
+
+//Server
+
+// selectBeneficiariesPostgresSql selects the columns of beneficiaries, formatting dates the same way MySQL returns
+// them.
+const selectBeneficiariesPostgresSql = "SELECT beneficiary_id, customer_id, nickname, account_id, bank_code, " +
+	"to_char(creation_date, 'YYYY-MM-DD HH24:MI:SS') AS creation_date, " +
+	"to_char(cooling_off_start, 'YYYY-MM-DD HH24:MI:SS') AS cooling_off_start, " +
+	"to_char(first_transfer_date, 'YYYY-MM-DD HH24:MI:SS') AS first_transfer_date FROM beneficiaries"
+
+type BeneficiaryRepositoryPostgres struct { //DB (adapter)
+	client dbExecutor
+}
+
+func NewBeneficiaryRepositoryPostgres(dbClient *sqlx.DB) BeneficiaryRepositoryPostgres {
+	return BeneficiaryRepositoryPostgres{dbClient}
+}
+
+// Save creates a new entry in the database for the given beneficiary, sets its ID using the ID returned by the
+// database and returns the beneficiary.
+func (d BeneficiaryRepositoryPostgres) Save(b Beneficiary) (*Beneficiary, *errs.AppError) { //DB implements repo
+	insertSql := "INSERT INTO beneficiaries (customer_id, nickname, account_id, bank_code, creation_date, " +
+		"cooling_off_start, first_transfer_date) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING beneficiary_id"
+	err := d.client.Get(&b.BeneficiaryId, insertSql, b.CustomerId, b.Nickname, b.AccountId, b.BankCode,
+		b.CreationDate, b.CoolingOffStart, b.FirstTransferDate)
+	if err != nil {
+		logger.Error("Error while creating new beneficiary: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return &b, nil
+}
+
+// FindById retrieves the beneficiary with the given id.
+func (d BeneficiaryRepositoryPostgres) FindById(beneficiaryId string) (*Beneficiary, *errs.AppError) { //DB implements repo
+	return findBeneficiary(d.client, selectBeneficiariesPostgresSql, beneficiaryId)
+}
+
+// FindAll retrieves all beneficiaries of the customer with the given id, oldest first.
+func (d BeneficiaryRepositoryPostgres) FindAll(customerId string) ([]Beneficiary, *errs.AppError) { //DB implements repo
+	return findBeneficiaries(d.client, selectBeneficiariesPostgresSql, customerId)
+}
+
+// FindByAccount retrieves the beneficiary of the customer with the given id that pays to the account of this bank
+// with the given id.
+func (d BeneficiaryRepositoryPostgres) FindByAccount(customerId string, accountId string) (*Beneficiary, *errs.AppError) { //DB implements repo
+	return findBeneficiaryByAccount(d.client, selectBeneficiariesPostgresSql, customerId, accountId)
+}
+
+// Update saves the nickname, account, cooling-off start and first transfer date of the given beneficiary.
+func (d BeneficiaryRepositoryPostgres) Update(b Beneficiary) *errs.AppError { //DB implements repo
+	return updateBeneficiary(d.client, b)
+}
+
+// Delete removes the beneficiary with the given id.
+func (d BeneficiaryRepositoryPostgres) Delete(beneficiaryId string) *errs.AppError { //DB implements repo
+	return deleteBeneficiary(d.client, beneficiaryId)
+}
+
+// SetFirstTransferDate records that the first transfer to the beneficiary with the given id was made on the given
+// date, unless an earlier one was already recorded.
+func (d BeneficiaryRepositoryPostgres) SetFirstTransferDate(beneficiaryId string, date string) *errs.AppError { //DB implements repo
+	return setFirstTransferDate(d.client, beneficiaryId, date)
+}
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"testing"
+)
+
+// Test common variables and inputs
+var beneficiaryRepoPostgres BeneficiaryRepositoryPostgres
+
+func setupBeneficiaryRepoPostgresTest(t *testing.T) func() {
+	teardown := setupDB(t)
+	beneficiaryRepoPostgres = NewBeneficiaryRepositoryPostgres(sqlx.NewDb(db, "postgres"))
+	return teardown
+}
+
+func TestBeneficiaryRepositoryPostgres_Save_returns_newBeneficiary_with_returnedId(t *testing.T) {
+	//Arrange
+	teardown := setupBeneficiaryRepoPostgresTest(t)
+	defer teardown()
+
+	b := getDummyBeneficiary()
+	b.BeneficiaryId = ""
+	mockDB.ExpectQuery("INSERT INTO beneficiaries (customer_id, nickname, account_id, bank_code, creation_date, "+
+		"cooling_off_start, first_transfer_date) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING beneficiary_id").
+		WithArgs(b.CustomerId, b.Nickname, b.AccountId, b.BankCode, b.CreationDate, b.CoolingOffStart,
+			b.FirstTransferDate).
+		WillReturnRows(sqlmock.NewRows([]string{"beneficiary_id"}).AddRow(3))
+
+	//Act
+	saved, err := beneficiaryRepoPostgres.Save(b)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if saved.BeneficiaryId != "3" {
+		t.Errorf("Expected beneficiary ID 3 but got %s", saved.BeneficiaryId)
+	}
+}
+
+func TestBeneficiaryRepositoryPostgres_FindByAccount_returns_notFoundError_when_none(t *testing.T) {
+	//Arrange
+	teardown := setupBeneficiaryRepoPostgresTest(t)
+	defer teardown()
+
+	mockDB.ExpectQuery(selectBeneficiariesPostgresSql+" WHERE customer_id = $1 AND account_id = $2 "+
+		"AND bank_code IS NULL ORDER BY beneficiary_id LIMIT 1").
+		WithArgs(dummyCustomerId, dummyAccountId).
+		WillReturnRows(sqlmock.NewRows([]string{"beneficiary_id"}))
+
+	//Act
+	_, err := beneficiaryRepoPostgres.FindByAccount(dummyCustomerId, dummyAccountId)
+
+	//Assert
+	if err == nil || err.Message != "Beneficiary not found" {
+		t.Errorf("Expected not found error but got %v", err)
+	}
+}
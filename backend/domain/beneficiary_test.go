@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"database/sql"
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"testing"
+	"time"
+)
+
+func getDummyBeneficiary() Beneficiary {
+	return Beneficiary{
+		BeneficiaryId:   "3",
+		CustomerId:      dummyCustomerId,
+		Nickname:        "landlord",
+		AccountId:       "1980",
+		CreationDate:    "2006-01-02 15:04:05",
+		CoolingOffStart: "2006-01-02 15:04:05",
+	}
+}
+
+func TestBeneficiary_CheckTransfer(t *testing.T) {
+	//Arrange
+	policy := BeneficiaryPolicy{CoolingOffPeriod: 24 * time.Hour, FirstTransferLimit: 1000}
+	afterCoolingOff := time.Date(2006, 1, 3, 15, 4, 5, 0, time.UTC)
+	transferred := getDummyBeneficiary()
+	transferred.FirstTransferDate = sql.NullString{String: "2006-01-04 10:00:00", Valid: true}
+
+	tests := []struct {
+		name        string
+		beneficiary Beneficiary
+		amount      float64
+		now         time.Time
+		expectErr   bool
+	}{
+		{"during cooling-off period", getDummyBeneficiary(), 100, afterCoolingOff.Add(-time.Second), true},
+		{"first transfer within limit", getDummyBeneficiary(), 1000, afterCoolingOff, false},
+		{"first transfer above limit", getDummyBeneficiary(), 1000.01, afterCoolingOff, true},
+		{"later transfer above first limit", transferred, 5000, afterCoolingOff, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Act
+			err := tc.beneficiary.CheckTransfer(tc.amount, policy, tc.now)
+
+			//Assert
+			if tc.expectErr && err == nil {
+				t.Error("Expected error but got none")
+			}
+			if !tc.expectErr && err != nil {
+				t.Error("Expected no error but got error: " + err.Message)
+			}
+		})
+	}
+}
+
+func TestBeneficiary_Update_restartsCoolingOff_only_when_account_changed(t *testing.T) {
+	//Arrange
+	beneficiary := getDummyBeneficiary()
+	beneficiary.FirstTransferDate = sql.NullString{String: "2006-01-01 10:00:00", Valid: true}
+	beneficiary.CoolingOffStart = "2006-01-01 00:00:00"
+	clk := clock.StaticClock{}
+
+	//Act
+	renamed := beneficiary.Update(dto.BeneficiaryRequest{Nickname: "flat", AccountId: "1980"}, clk)
+	moved := beneficiary.Update(dto.BeneficiaryRequest{Nickname: "flat", AccountId: "1981"}, clk)
+
+	//Assert
+	if renamed.CoolingOffStart != beneficiary.CoolingOffStart || !renamed.FirstTransferDate.Valid || renamed.Nickname != "flat" {
+		t.Errorf("Expected renamed beneficiary to keep its cooling-off period but got %+v", renamed)
+	}
+	if moved.CoolingOffStart != clk.NowAsString() || moved.FirstTransferDate.Valid ||
+		moved.CreationDate != beneficiary.CreationDate || moved.BeneficiaryId != beneficiary.BeneficiaryId {
+		t.Errorf("Expected beneficiary with new account to start cooling-off again but got %+v", moved)
+	}
+}
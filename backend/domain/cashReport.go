@@ -0,0 +1,143 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"sort"
+)
+
+//Business Domain
+
+// DefaultCashReportThreshold is the amount above which cash transactions are reported, in the account's currency.
+const DefaultCashReportThreshold float64 = 10000
+
+// CashTransaction is a deposit or withdrawal, along with the customer and currency of its account.
+type CashTransaction struct {
+	TransactionId   string  `db:"transaction_id"`
+	AccountId       string  `db:"account_id"`
+	CustomerId      string  `db:"customer_id"`
+	Currency        string  `db:"currency"`
+	Amount          float64 `db:"amount"`
+	TransactionType string  `db:"transaction_type"`
+	TransactionDate string  `db:"transaction_date"`
+}
+
+// CashReportEntry is a cash transaction above the threshold, or a set of cash transactions of a customer on the same
+// day that are each below the threshold but together above it, as when a deposit is split to stay under it.
+type CashReportEntry struct {
+	Kind            string //dto.CashReportKindSingle or dto.CashReportKindAggregate
+	Date            string
+	CustomerId      string
+	TransactionType string
+	Currency        string
+	Total           float64
+	Transactions    []CashTransaction
+}
+
+// cashGroup is what cash transactions are aggregated by: deposits and withdrawals are added up separately, and so are
+// amounts in different currencies.
+type cashGroup struct {
+	date            string
+	customerId      string
+	transactionType string
+	currency        string
+}
+
+// BuildCashReport returns the entries to report for the given cash transactions, grouped per customer per day. Every
+// transaction above the given threshold is an entry of its own. The transactions of a group that are not above it are
+// an aggregate entry if together they are above it.
+func BuildCashReport(transactions []CashTransaction, threshold float64) []CashReportEntry {
+	groups := make([]cashGroup, 0)
+	below := make(map[cashGroup][]CashTransaction)
+	entries := make([]CashReportEntry, 0)
+
+	for _, t := range transactions {
+		group := cashGroup{t.TransactionDate[:len(FormatDate)], t.CustomerId, t.TransactionType, t.Currency}
+		if t.Amount > threshold {
+			entries = append(entries, newCashReportEntry(dto.CashReportKindSingle, group, []CashTransaction{t}))
+			continue
+		}
+		if _, ok := below[group]; !ok {
+			groups = append(groups, group)
+		}
+		below[group] = append(below[group], t)
+	}
+
+	for _, group := range groups {
+		entry := newCashReportEntry(dto.CashReportKindAggregate, group, below[group])
+		if len(entry.Transactions) > 1 && entry.Total > threshold {
+			entries = append(entries, entry)
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.Date != b.Date {
+			return a.Date < b.Date
+		}
+		if a.CustomerId != b.CustomerId {
+			return a.CustomerId < b.CustomerId
+		}
+		return a.Transactions[0].TransactionId < b.Transactions[0].TransactionId
+	})
+	return entries
+}
+
+func newCashReportEntry(kind string, group cashGroup, transactions []CashTransaction) CashReportEntry {
+	var total float64
+	for _, t := range transactions {
+		total += t.Amount
+	}
+	return CashReportEntry{
+		Kind:            kind,
+		Date:            group.date,
+		CustomerId:      group.customerId,
+		TransactionType: group.transactionType,
+		Currency:        group.currency,
+		Total:           roundAmount(total),
+		Transactions:    transactions,
+	}
+}
+
+// ToDTO converts the entry to its Data Transfer Object, with the details of the given customer, who made it.
+func (e CashReportEntry) ToDTO(c Customer) dto.CashReportEntryResponse {
+	transactionIds := make([]string, 0)
+	accountIds := make([]string, 0)
+	seenAccounts := make(map[string]bool)
+	for _, t := range e.Transactions {
+		transactionIds = append(transactionIds, t.TransactionId)
+		if !seenAccounts[t.AccountId] {
+			seenAccounts[t.AccountId] = true
+			accountIds = append(accountIds, t.AccountId)
+		}
+	}
+
+	return dto.CashReportEntryResponse{
+		Kind:            e.Kind,
+		Date:            e.Date,
+		CustomerId:      c.Id,
+		Name:            c.Name,
+		DateOfBirth:     c.DateOfBirth,
+		Email:           c.Email,
+		Country:         c.Country,
+		Zipcode:         c.Zipcode,
+		TransactionType: e.TransactionType,
+		Currency:        e.Currency,
+		Total:           e.Total,
+		AccountIds:      accountIds,
+		TransactionIds:  transactionIds,
+	}
+}
+
+//Server
+
+//go:generate mockgen -destination=../mocks/domain/mock_cashReportRepository.go -package=domain github.com/aliciatay-zls/banking/backend/domain CashReportRepository
+type CashReportRepository interface { //repo (secondary port)
+	FindCashTransactions(from string, to string) ([]CashTransaction, *errs.AppError)
+}
+
+//go:generate mockgen -destination=../mocks/domain/mock_cashReportWriter.go -package=domain github.com/aliciatay-zls/banking/backend/domain CashReportWriter
+type CashReportWriter interface { //repo (secondary port)
+	HasReport(string) (bool, *errs.AppError)
+	WriteReport(string, dto.CashReportResponse) *errs.AppError
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/jmoiron/sqlx"
+)
+
+//Server
+
+// selectCashTransactionsSql selects the columns of deposits and withdrawals together with the account they were made
+// to or from.
+const selectCashTransactionsSql = "SELECT t.transaction_id, t.account_id, a.customer_id, a.currency, t.amount, " +
+	"t.transaction_type, t.transaction_date FROM transactions t JOIN accounts a ON t.account_id = a.account_id"
+
+type CashReportRepositoryDb struct { //DB (adapter)
+	client dbExecutor
+}
+
+func NewCashReportRepositoryDb(dbClient *sqlx.DB) CashReportRepositoryDb {
+	return CashReportRepositoryDb{dbClient}
+}
+
+// FindCashTransactions retrieves the deposits and withdrawals made from the start of the given from date up to but not
+// including the start of the given to date, oldest first.
+func (d CashReportRepositoryDb) FindCashTransactions(from string, to string) ([]CashTransaction, *errs.AppError) { //DB implements repo
+	return findCashTransactions(d.client, selectCashTransactionsSql, from, to)
+}
+
+// findCashTransactions retrieves the deposits and withdrawals made in the given range with the given select statement,
+// oldest first.
+func findCashTransactions(client dbExecutor, selectSql string, from string, to string) ([]CashTransaction, *errs.AppError) {
+	transactions := make([]CashTransaction, 0)
+	selectSql += " WHERE t.transaction_type IN (?, ?) AND t.transaction_date >= ? AND t.transaction_date < ? " +
+		"ORDER BY t.transaction_id"
+	if err := client.Select(&transactions, client.Rebind(selectSql), dto.TransactionTypeDeposit,
+		dto.TransactionTypeWithdrawal, from, to); err != nil {
+		logger.Error("Error while retrieving cash transactions: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return transactions, nil
+}
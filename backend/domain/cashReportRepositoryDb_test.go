@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"testing"
+)
+
+// This test runs on a real SQLite database seeded with the demo data, since the transactions of a day are only
+// selected when the SQL is executed, which go-sqlmock never does.
+
+func TestCashReportRepositoryDb_FindCashTransactions_returns_depositsAndWithdrawals_of_givenDay(t *testing.T) {
+	//Arrange
+	logger.MuteLogger()
+	client := openSQLiteDb(t)
+	cashReportRepoDb := NewCashReportRepositoryDb(client)
+
+	insertSql := "INSERT INTO transactions (account_id, amount, transaction_type, transaction_date) VALUES (?, ?, ?, ?)"
+	client.MustExec(insertSql, 95470, 9000, dto.TransactionTypeDeposit, "2006-01-01 23:59:59")
+	client.MustExec(insertSql, 95470, 6000, dto.TransactionTypeDeposit, "2006-01-02 00:00:00")
+	client.MustExec(insertSql, 95472, 4000, dto.TransactionTypeWithdrawal, "2006-01-02 15:04:05")
+	client.MustExec(insertSql, 95472, 20000, dto.TransactionTypeTransferOut, "2006-01-02 15:04:05")
+	client.MustExec(insertSql, 95471, 12000, dto.TransactionTypeDeposit, "2006-01-03 00:00:00")
+
+	//Act
+	transactions, err := cashReportRepoDb.FindCashTransactions("2006-01-02", "2006-01-03")
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if len(transactions) != 2 {
+		t.Fatalf("Expected deposit and withdrawal of 2006-01-02 but got %+v", transactions)
+	}
+	if transactions[0].CustomerId != "2000" || transactions[0].Currency != "USD" || transactions[0].Amount != 6000 ||
+		transactions[1].CustomerId != "2001" || transactions[1].TransactionType != dto.TransactionTypeWithdrawal {
+		t.Errorf("Expected transactions with their customers and currencies but got %+v", transactions)
+	}
+}
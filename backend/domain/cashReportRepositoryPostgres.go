@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/jmoiron/sqlx"
+)
+
+//Server
+
+// selectCashTransactionsPostgresSql is the PostgreSQL equivalent of selectCashTransactionsSql.
+const selectCashTransactionsPostgresSql = "SELECT t.transaction_id, t.account_id, a.customer_id, a.currency, " +
+	"t.amount, t.transaction_type, to_char(t.transaction_date, 'YYYY-MM-DD HH24:MI:SS') AS transaction_date " +
+	"FROM transactions t JOIN accounts a ON t.account_id = a.account_id"
+
+type CashReportRepositoryPostgres struct { //DB (adapter)
+	client dbExecutor
+}
+
+func NewCashReportRepositoryPostgres(dbClient *sqlx.DB) CashReportRepositoryPostgres {
+	return CashReportRepositoryPostgres{dbClient}
+}
+
+// FindCashTransactions retrieves the deposits and withdrawals made from the start of the given from date up to but not
+// including the start of the given to date, oldest first.
+func (d CashReportRepositoryPostgres) FindCashTransactions(from string, to string) ([]CashTransaction, *errs.AppError) { //DB implements repo
+	return findCashTransactions(d.client, selectCashTransactionsPostgresSql, from, to)
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/jmoiron/sqlx"
+	"testing"
+)
+
+func TestCashReportRepositoryPostgres_FindCashTransactions_returns_transactions_with_formattedDates(t *testing.T) {
+	//Arrange
+	teardown := setupDB(t)
+	defer teardown()
+	cashReportRepoPostgres := NewCashReportRepositoryPostgres(sqlx.NewDb(db, "postgres"))
+
+	rows := sqlmock.NewRows([]string{"transaction_id", "account_id", "customer_id", "currency", "amount",
+		"transaction_type", "transaction_date"}).
+		AddRow(dummyTransactionId, dummyAccountId, dummyCustomerId, "SGD", 9000, dto.TransactionTypeDeposit, dummyDate)
+	mockDB.ExpectQuery(selectCashTransactionsPostgresSql+" WHERE t.transaction_type IN ($1, $2) "+
+		"AND t.transaction_date >= $3 AND t.transaction_date < $4 ORDER BY t.transaction_id").
+		WithArgs(dto.TransactionTypeDeposit, dto.TransactionTypeWithdrawal, "2006-01-02", "2006-01-03").
+		WillReturnRows(rows)
+
+	//Act
+	transactions, err := cashReportRepoPostgres.FindCashTransactions("2006-01-02", "2006-01-03")
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if len(transactions) != 1 || transactions[0].TransactionDate != dummyDate {
+		t.Errorf("Expected 1 transaction made on %s but got %+v", dummyDate, transactions)
+	}
+}
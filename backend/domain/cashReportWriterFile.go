@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+//Server
+
+// CashReportWriterFile writes each cash report as a CSV file and a JSON file named after it, e.g.
+// cash-report-2006-01-02.csv and cash-report-2006-01-02.json, into a directory.
+type CashReportWriterFile struct { //file (adapter)
+	dir string
+}
+
+func NewCashReportWriterFile(dir string) CashReportWriterFile {
+	return CashReportWriterFile{dir}
+}
+
+// HasReport checks whether the report with the given name has been written. Since the JSON file is written last, a
+// report whose writing stopped part way is not taken as written.
+func (w CashReportWriterFile) HasReport(name string) (bool, *errs.AppError) { //file implements repo
+	_, err := os.Stat(w.pathOf(name, dto.CashReportFormatJson))
+	if err == nil {
+		return true, nil
+	}
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	logger.Error("Error while checking for cash report " + name + ": " + err.Error())
+	return false, errs.NewUnexpectedError("Unexpected server error")
+}
+
+// WriteReport writes the given report under the given name, replacing any earlier one. Each file is written to a
+// temporary file first and then renamed, so that a file that is there is always complete.
+func (w CashReportWriterFile) WriteReport(name string, report dto.CashReportResponse) *errs.AppError { //file implements repo
+	var csvContent bytes.Buffer
+	csvWriter := csv.NewWriter(&csvContent)
+	if err := csvWriter.WriteAll(report.CsvRecords()); err != nil {
+		logger.Error("Error while encoding cash report " + name + " as CSV: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected server error")
+	}
+	jsonContent, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		logger.Error("Error while encoding cash report " + name + " as JSON: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected server error")
+	}
+
+	if appErr := w.writeFile(w.pathOf(name, dto.CashReportFormatCsv), csvContent.Bytes()); appErr != nil {
+		return appErr
+	}
+	return w.writeFile(w.pathOf(name, dto.CashReportFormatJson), append(jsonContent, '\n'))
+}
+
+func (w CashReportWriterFile) pathOf(name string, format string) string {
+	return filepath.Join(w.dir, name+"."+format)
+}
+
+func (w CashReportWriterFile) writeFile(path string, content []byte) *errs.AppError {
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, content, 0640); err != nil {
+		logger.Error("Error while writing cash report file " + path + ": " + err.Error())
+		return errs.NewUnexpectedError("Unexpected server error")
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		logger.Error("Error while writing cash report file " + path + ": " + err.Error())
+		return errs.NewUnexpectedError("Unexpected server error")
+	}
+	return nil
+}
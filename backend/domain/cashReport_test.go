@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"reflect"
+	"testing"
+)
+
+func TestBuildCashReport(t *testing.T) {
+	deposit := func(id string, customerId string, amount float64, date string) CashTransaction {
+		return CashTransaction{TransactionId: id, AccountId: "95470", CustomerId: customerId, Currency: "INR",
+			Amount: amount, TransactionType: dto.TransactionTypeDeposit, TransactionDate: date + " 10:00:00"}
+	}
+	withdrawal := deposit("9", "2000", 6000, "2006-01-02")
+	withdrawal.TransactionType = dto.TransactionTypeWithdrawal
+	inOtherCurrency := deposit("10", "2000", 6000, "2006-01-02")
+	inOtherCurrency.Currency = "USD"
+
+	tests := []struct {
+		name         string
+		transactions []CashTransaction
+		expected     [][]string //transaction IDs of each entry
+		expectedKind []string
+	}{
+		{"single transaction above threshold",
+			[]CashTransaction{deposit("1", "2000", 10000.01, "2006-01-02")},
+			[][]string{{"1"}}, []string{dto.CashReportKindSingle}},
+		{"single transaction at threshold",
+			[]CashTransaction{deposit("1", "2000", 10000, "2006-01-02")},
+			[][]string{}, []string{}},
+		{"structured deposits on same day",
+			[]CashTransaction{deposit("1", "2000", 6000, "2006-01-02"), deposit("2", "2000", 4000.01, "2006-01-02")},
+			[][]string{{"1", "2"}}, []string{dto.CashReportKindAggregate}},
+		{"deposits on different days",
+			[]CashTransaction{deposit("1", "2000", 6000, "2006-01-02"), deposit("2", "2000", 6000, "2006-01-03")},
+			[][]string{}, []string{}},
+		{"deposits of different customers",
+			[]CashTransaction{deposit("1", "2000", 6000, "2006-01-02"), deposit("2", "2001", 6000, "2006-01-02")},
+			[][]string{}, []string{}},
+		{"deposit and withdrawal or other currency",
+			[]CashTransaction{deposit("1", "2000", 6000, "2006-01-02"), withdrawal, inOtherCurrency},
+			[][]string{}, []string{}},
+		{"large transaction not counted in aggregate",
+			[]CashTransaction{deposit("1", "2000", 20000, "2006-01-02"), deposit("2", "2000", 6000, "2006-01-02")},
+			[][]string{{"1"}}, []string{dto.CashReportKindSingle}},
+		{"sorted by date then customer",
+			[]CashTransaction{deposit("1", "2001", 11000, "2006-01-03"), deposit("2", "2001", 11000, "2006-01-02"),
+				deposit("3", "2000", 6000, "2006-01-02"), deposit("4", "2000", 6000, "2006-01-02")},
+			[][]string{{"3", "4"}, {"2"}, {"1"}},
+			[]string{dto.CashReportKindAggregate, dto.CashReportKindSingle, dto.CashReportKindSingle}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Act
+			entries := BuildCashReport(tc.transactions, DefaultCashReportThreshold)
+
+			//Assert
+			actual := make([][]string, 0)
+			actualKind := make([]string, 0)
+			for _, e := range entries {
+				ids := make([]string, 0)
+				for _, tr := range e.Transactions {
+					ids = append(ids, tr.TransactionId)
+				}
+				actual = append(actual, ids)
+				actualKind = append(actualKind, e.Kind)
+			}
+			if !reflect.DeepEqual(actual, tc.expected) || !reflect.DeepEqual(actualKind, tc.expectedKind) {
+				t.Errorf("Expected entries %v of kinds %v but got %+v", tc.expected, tc.expectedKind, entries)
+			}
+		})
+	}
+}
+
+func TestCashReportWriterFile_WriteReport_then_HasReport(t *testing.T) {
+	//Arrange
+	writer := NewCashReportWriterFile(t.TempDir())
+	report := dto.CashReportResponse{From: "2006-01-02", To: "2006-01-02", Threshold: DefaultCashReportThreshold}
+
+	//Act
+	before, _ := writer.HasReport("cash-report-2006-01-02")
+	err := writer.WriteReport("cash-report-2006-01-02", report)
+	after, _ := writer.HasReport("cash-report-2006-01-02")
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if before || !after {
+		t.Errorf("Expected report to be written only after writing it but got %t and %t", before, after)
+	}
+}
@@ -8,13 +8,13 @@ import (
 //Business Domain
 
 type Customer struct { //business/domain object
-	Id          string `db:"customer_id"`
-	Name        string
-	DateOfBirth string `db:"date_of_birth"`
-	Email       string
-	Country     string
-	Zipcode     string
-	Status      string
+	Id          string `db:"customer_id" json:"customer_id"`
+	Name        string `json:"name"`
+	DateOfBirth string `db:"date_of_birth" json:"date_of_birth"`
+	Email       string `json:"email"`
+	Country     string `json:"country"`
+	Zipcode     string `json:"zipcode"`
+	Status      string `json:"status"`
 }
 
 // ToDTO does the conversion of domain object to Data Transfer Object.
@@ -30,6 +30,14 @@ func (c Customer) ToDTO() *dto.CustomerResponse {
 	}
 }
 
+// AsStatusValue gets the database value for the given customer status name.
+func AsStatusValue(statusName string) string {
+	if statusName == dto.CustomerStatusInactive {
+		return "0"
+	}
+	return "1"
+}
+
 // AsStatusName gets the string representation of database values for customer status.
 func (c Customer) AsStatusName() string {
 	statusName := "active"
@@ -46,4 +54,5 @@ func (c Customer) AsStatusName() string {
 type CustomerRepository interface { //repo (secondary port)
 	FindAll(string) ([]Customer, *errs.AppError)
 	FindById(string) (*Customer, *errs.AppError) //allows nil customer, useful for checking
+	UpdateStatus(string, string, string) *errs.AppError
 }
@@ -11,7 +11,7 @@ import (
 //Server
 
 type CustomerRepositoryDb struct { //DB (adapter)
-	client *sqlx.DB
+	client dbExecutor
 }
 
 // NewCustomerRepositoryDb initializes a new DB adapter with the given database handle and returns DB.
@@ -56,6 +56,36 @@ func (d CustomerRepositoryDb) FindById(id string) (*Customer, *errs.AppError) {
 	return &c, nil
 }
 
+// UpdateStatus starts a database transaction, sets the status of the customer with the given id to the given database
+// value, writes the event for the change to the outbox and commits the database transaction. It does nothing if the
+// customer already has the given status.
+func (d CustomerRepositoryDb) UpdateStatus(id string, status string, changedOn string) *errs.AppError {
+	return inTransaction(d.client, "updating customer status", func(tx dbExecutor) *errs.AppError {
+		return updateCustomerStatus(tx, id, status, changedOn)
+	})
+}
+
+// updateCustomerStatus does the work of UpdateStatus within the given database transaction.
+func updateCustomerStatus(tx dbExecutor, id string, status string, changedOn string) *errs.AppError {
+	var previousStatus string
+	if err := tx.Get(&previousStatus, tx.Rebind("SELECT status FROM customers WHERE customer_id = ?"), id); err != nil {
+		logger.Error("Error while retrieving customer status: " + err.Error())
+		if errors.Is(err, sql.ErrNoRows) {
+			return errs.NewNotFoundError("Customer not found")
+		}
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	if previousStatus == status {
+		return nil
+	}
+
+	if _, err := tx.Exec(tx.Rebind("UPDATE customers SET status = ? WHERE customer_id = ?"), status, id); err != nil {
+		logger.Error("Error while updating customer status: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	return saveEvent(tx, NewCustomerStatusChangedEvent(id, status, previousStatus, changedOn))
+}
+
 // (*)
 //diff error types and hence the diff error message and status code pairs will be reflected later in the REST handler
 //(will read the fields of the custom app error received from calling this method)
@@ -196,3 +196,69 @@ func TestCustomerRepositoryDb_FindById_returns_customer_when_selectCustomers_suc
 		t.Errorf("Expected customer %v but got %v", dummyCustomer, *actualCustomer)
 	}
 }
+
+const selectCustomerStatusSql = "SELECT status FROM customers WHERE customer_id = ?"
+const updateCustomerStatusSql = "UPDATE customers SET status = ? WHERE customer_id = ?"
+
+func TestCustomerRepositoryDb_UpdateStatus_updates_status_and_savesEvent_when_status_changes(t *testing.T) {
+	//Arrange
+	teardown := setupCustomerRepositoryDbTest(t)
+	defer teardown()
+
+	mockDB.ExpectBegin()
+	mockDB.ExpectQuery(selectCustomerStatusSql).WithArgs("2000").WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("1"))
+	mockDB.ExpectExec(updateCustomerStatusSql).WithArgs("0", "2000").WillReturnResult(sqlmock.NewResult(0, 1))
+	expectEventSaved(NewCustomerStatusChangedEvent("2000", "0", "1", dummyDate))
+	mockDB.ExpectCommit()
+
+	//Act
+	err := cusRepoDb.UpdateStatus("2000", "0", dummyDate)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if err := mockDB.ExpectationsWereMet(); err != nil {
+		t.Error(err.Error())
+	}
+}
+
+func TestCustomerRepositoryDb_UpdateStatus_does_nothing_when_status_unchanged(t *testing.T) {
+	//Arrange
+	teardown := setupCustomerRepositoryDbTest(t)
+	defer teardown()
+
+	mockDB.ExpectBegin()
+	mockDB.ExpectQuery(selectCustomerStatusSql).WithArgs("2000").WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("1"))
+	mockDB.ExpectCommit()
+
+	//Act
+	err := cusRepoDb.UpdateStatus("2000", "1", dummyDate)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if err := mockDB.ExpectationsWereMet(); err != nil {
+		t.Error(err.Error())
+	}
+}
+
+func TestCustomerRepositoryDb_UpdateStatus_returns_notFoundError_when_customer_doesNotExist(t *testing.T) {
+	//Arrange
+	teardown := setupCustomerRepositoryDbTest(t)
+	defer teardown()
+
+	mockDB.ExpectBegin()
+	mockDB.ExpectQuery(selectCustomerStatusSql).WithArgs("1").WillReturnError(sql.ErrNoRows)
+	mockDB.ExpectRollback()
+	logger.MuteLogger()
+
+	//Act
+	err := cusRepoDb.UpdateStatus("1", "0", dummyDate)
+
+	//Assert
+	if err == nil || err.Message != "Customer not found" {
+		t.Errorf("Expected not found error but got %v", err)
+	}
+}
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/jmoiron/sqlx"
+)
+
+//Server
+
+// selectCustomersPostgresSql selects the columns of customers, formatting dates the same way MySQL returns them.
+const selectCustomersPostgresSql = "SELECT customer_id, name, to_char(date_of_birth, 'YYYY-MM-DD') AS date_of_birth, " +
+	"email, country, zipcode, status FROM customers"
+
+type CustomerRepositoryPostgres struct { //DB (adapter)
+	client dbExecutor
+}
+
+// NewCustomerRepositoryPostgres initializes a new PostgreSQL adapter with the given database handle.
+func NewCustomerRepositoryPostgres(dbClient *sqlx.DB) CustomerRepositoryPostgres {
+	return CustomerRepositoryPostgres{dbClient}
+}
+
+// FindAll retrieves from database all customers with the given status.
+func (d CustomerRepositoryPostgres) FindAll(status string) ([]Customer, *errs.AppError) {
+	var err error
+	customers := make([]Customer, 0)
+
+	if status == "" {
+		err = d.client.Select(&customers, selectCustomersPostgresSql+" ORDER BY customer_id")
+	} else {
+		err = d.client.Select(&customers, selectCustomersPostgresSql+" WHERE status = $1 ORDER BY customer_id", status)
+	}
+	if err != nil {
+		logger.Error("Error while querying/scanning customer table: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return customers, nil
+}
+
+func (d CustomerRepositoryPostgres) FindById(id string) (*Customer, *errs.AppError) {
+	var c Customer
+
+	err := d.client.Get(&c, selectCustomersPostgresSql+" WHERE customer_id = $1", id)
+	if err != nil {
+		logger.Error("Error while querying/scanning customer: " + err.Error())
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.NewNotFoundError("Customer not found")
+		}
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return &c, nil
+}
+
+// UpdateStatus sets the status of the customer with the given id in the same way as CustomerRepositoryDb.UpdateStatus.
+func (d CustomerRepositoryPostgres) UpdateStatus(id string, status string, changedOn string) *errs.AppError {
+	return inTransaction(d.client, "updating customer status", func(tx dbExecutor) *errs.AppError {
+		return updateCustomerStatus(tx, id, status, changedOn)
+	})
+}
@@ -3,27 +3,39 @@ package domain
 import (
 	"github.com/aliciatay-zls/banking-lib/errs"
 	"github.com/aliciatay-zls/banking-lib/logger"
+	"sync"
 )
 
 //Server
 
+// CustomerRepositoryStub keeps customers in memory instead of in the database. It is safe for concurrent use.
 type CustomerRepositoryStub struct { //stub (adapter)
+	mu        *sync.RWMutex
 	customers []Customer
 }
 
-func NewCustomerRepositoryStub() CustomerRepositoryStub { //helper function to create and initialize a stub
-	customers := []Customer{ //default dummy data
-		{"1", "Dorothy", "11/11/2011", "dorothy_gale@somemail.com", "Emerald City", "12345", "1"},
-		{"2", "Luke", "12/12/2012", "luke.skywalker@tsomemail.com", "Tatooine", "67890", "0"},
-	}
-	return CustomerRepositoryStub{customers}
+func NewCustomerRepositoryStub(customers []Customer) CustomerRepositoryStub { //helper function to create and initialize a stub
+	return CustomerRepositoryStub{&sync.RWMutex{}, append([]Customer{}, customers...)}
 }
 
+// FindAll returns all customers with the given status, or all customers if the status is empty.
 func (s CustomerRepositoryStub) FindAll(status string) ([]Customer, *errs.AppError) { //stub implements repo
-	return s.customers, nil
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	customers := make([]Customer, 0)
+	for _, c := range s.customers {
+		if status == "" || c.Status == status {
+			customers = append(customers, c)
+		}
+	}
+	return customers, nil
 }
 
 func (s CustomerRepositoryStub) FindById(id string) (*Customer, *errs.AppError) { //stub implements repo
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
 	for _, v := range s.customers {
 		if v.Id == id {
 			return &v, nil
@@ -32,3 +44,19 @@ func (s CustomerRepositoryStub) FindById(id string) (*Customer, *errs.AppError)
 	logger.Error("Error while finding customer by id using stub for CustomerRepository: not found")
 	return nil, errs.NewNotFoundError("Customer not found")
 }
+
+// UpdateStatus sets the status of the customer with the given id to the given database value. Unlike the database
+// adapters, it does not write an event for the change, since there is no outbox in demo mode.
+func (s CustomerRepositoryStub) UpdateStatus(id string, status string, _ string) *errs.AppError { //stub implements repo
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for k := range s.customers {
+		if s.customers[k].Id == id {
+			s.customers[k].Status = status
+			return nil
+		}
+	}
+	logger.Error("Error while updating customer status using stub for CustomerRepository: not found")
+	return errs.NewNotFoundError("Customer not found")
+}
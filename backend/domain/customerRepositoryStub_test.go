@@ -14,7 +14,7 @@ func getDefaultCustomers() []Customer {
 
 func TestCustomerRepositoryStub_FindAll_returns_allCustomers(t *testing.T) {
 	//Arrange
-	customerRepositoryStub := NewCustomerRepositoryStub()
+	customerRepositoryStub := NewCustomerRepositoryStub(getDefaultCustomers())
 	status := ""
 	expectedCustomers := getDefaultCustomers()
 
@@ -36,9 +36,34 @@ func TestCustomerRepositoryStub_FindAll_returns_allCustomers(t *testing.T) {
 	}
 }
 
+func TestCustomerRepositoryStub_FindAll_returns_customers_withGivenStatus(t *testing.T) {
+	//Arrange
+	customerRepositoryStub := NewCustomerRepositoryStub(getDefaultCustomers())
+	tests := []struct {
+		status      string
+		expectedIds []string
+	}{
+		{"1", []string{"1"}},
+		{"0", []string{"2"}},
+	}
+
+	for _, tc := range tests {
+		//Act
+		actualCustomers, err := customerRepositoryStub.FindAll(tc.status)
+
+		//Assert
+		if err != nil {
+			t.Fatal("expected no error but got error while testing status filter: " + err.Message)
+		}
+		if len(actualCustomers) != len(tc.expectedIds) || actualCustomers[0].Id != tc.expectedIds[0] {
+			t.Errorf("expected customers %v for status %s but got %v", tc.expectedIds, tc.status, actualCustomers)
+		}
+	}
+}
+
 func TestCustomerRepositoryStub_FindById_returns_customer_when_id_exists(t *testing.T) {
 	//Arrange
-	customerRepositoryStub := NewCustomerRepositoryStub()
+	customerRepositoryStub := NewCustomerRepositoryStub(getDefaultCustomers())
 	expectedCustomer := &getDefaultCustomers()[0]
 
 	//Act
@@ -55,7 +80,7 @@ func TestCustomerRepositoryStub_FindById_returns_customer_when_id_exists(t *test
 
 func TestCustomerRepositoryStub_FindById_returns_error_when_nonExistentCustomer(t *testing.T) {
 	//Arrange
-	customerRepositoryStub := NewCustomerRepositoryStub()
+	customerRepositoryStub := NewCustomerRepositoryStub(getDefaultCustomers())
 	nonExistentCustomerId := "321"
 	expectedErrMessage := "Customer not found"
 
@@ -80,3 +105,21 @@ func TestCustomerRepositoryStub_FindById_returns_error_when_nonExistentCustomer(
 		t.Errorf("Expected log message to be \"%s\" but got \"%s\"", expectedLogMessage, actualLogMessage)
 	}
 }
+
+func TestCustomerRepositoryStub_UpdateStatus_changes_status_of_customer(t *testing.T) {
+	//Arrange
+	customerRepositoryStub := NewCustomerRepositoryStub(getDefaultCustomers())
+	customer := getDefaultCustomers()[0]
+
+	//Act
+	err := customerRepositoryStub.UpdateStatus(customer.Id, "0", dummyDate)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	updated, _ := customerRepositoryStub.FindById(customer.Id)
+	if updated.Status != "0" {
+		t.Errorf("Expected status 0 but got %s", updated.Status)
+	}
+}
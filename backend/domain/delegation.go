@@ -0,0 +1,144 @@
+package domain
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"math"
+	"time"
+)
+
+//Business Domain
+
+// delegationViewRoutes are the routes of an account that delegates of every scope may access, which only look at the
+// account.
+var delegationViewRoutes = map[string]bool{
+	"GetTransactions":    true,
+	"GetStatement":       true,
+	"ExportTransactions": true,
+	"GetHolds":           true,
+}
+
+// delegationScopeRoutes are the routes of an account that delegates of each scope may access besides
+// delegationViewRoutes. Which transactions they may make is checked by Delegation.CheckTransaction.
+var delegationScopeRoutes = map[string]map[string]bool{
+	dto.DelegationScopeViewOnly:    {},
+	dto.DelegationScopeDepositOnly: {"NewTransaction": true},
+	dto.DelegationScopeWithdraw:    {"NewTransaction": true, "NewTransfer": true},
+}
+
+// Delegation lets a customer who does not hold an account operate it within a scope, from its start date to its end
+// date, unless it is revoked earlier. It is granted by the account's primary holder.
+type Delegation struct { //business/domain object
+	DelegationId       string          `db:"delegation_id"`
+	AccountId          string          `db:"account_id"`
+	GrantorCustomerId  string          `db:"grantor_customer_id"`
+	DelegateCustomerId string          `db:"delegate_customer_id"`
+	Scope              string          `db:"scope"`
+	DailyLimit         sql.NullFloat64 `db:"daily_limit"` //NULL unless the scope is withdraw
+	StartDate          string          `db:"start_date"`
+	EndDate            string          `db:"end_date"`
+	CreationDate       string          `db:"creation_date"`
+	RevocationDate     sql.NullString  `db:"revocation_date"`
+}
+
+// NewDelegation creates the delegation in the given request, starting at the beginning of its start date, or now if
+// it has none, and ending at the end of its end date. Neither date can be in the past.
+func NewDelegation(request dto.DelegationRequest, c clock.Clock) (*Delegation, *errs.AppError) {
+	delegation := Delegation{
+		AccountId:          request.AccountId,
+		GrantorCustomerId:  request.CustomerId,
+		DelegateCustomerId: request.DelegateCustomerId,
+		Scope:              request.Scope,
+		StartDate:          c.NowAsString(),
+		EndDate:            request.EndDate + " 23:59:59",
+		CreationDate:       c.NowAsString(),
+	}
+	if request.Scope == dto.DelegationScopeWithdraw {
+		delegation.DailyLimit = sql.NullFloat64{Float64: request.DailyLimit, Valid: true}
+	}
+
+	today := truncateToDate(c.Now())
+	if request.StartDate != "" {
+		startDate, _ := time.Parse(FormatDate, request.StartDate)
+		if startDate.Before(today) {
+			return nil, errs.NewValidationError("Start date cannot be in the past.")
+		}
+		if startDate.After(today) {
+			delegation.StartDate = request.StartDate + " 00:00:00"
+		}
+	}
+	endDate, _ := time.Parse(FormatDate, request.EndDate)
+	if endDate.Before(today) {
+		return nil, errs.NewValidationError("End date cannot be in the past.")
+	}
+	return &delegation, nil
+}
+
+// IsActive checks whether the delegation can be used at the given time.
+func (d Delegation) IsActive(now string) bool {
+	return !d.RevocationDate.Valid && d.StartDate <= now && now <= d.EndDate
+}
+
+// IsOver checks whether the delegation can no longer be used from the given time on, because it was revoked or
+// has ended.
+func (d Delegation) IsOver(now string) bool {
+	return d.RevocationDate.Valid || d.EndDate < now
+}
+
+// AllowsRoute checks whether the delegate may access the route with the given name for the account.
+func (d Delegation) AllowsRoute(routeName string) bool {
+	return delegationViewRoutes[routeName] || delegationScopeRoutes[d.Scope][routeName]
+}
+
+// CheckTransaction checks whether the delegate may make a transaction of the given type and amount on the account,
+// given how much they already took out of it today.
+func (d Delegation) CheckTransaction(transactionType string, amount float64, withdrawnToday float64) *errs.AppError {
+	if transactionType == dto.TransactionTypeDeposit {
+		if d.Scope == dto.DelegationScopeViewOnly {
+			return errs.NewAuthorizationError("Your delegation for this account does not allow deposits")
+		}
+		return nil
+	}
+
+	if d.Scope != dto.DelegationScopeWithdraw {
+		return errs.NewAuthorizationError("Your delegation for this account does not allow withdrawals or transfers")
+	}
+	if withdrawnToday+amount > d.DailyLimit.Float64 {
+		return errs.NewValidationError(fmt.Sprintf("Amount exceeds the daily limit of your delegation for this "+
+			"account (%.2f left today)", math.Max(d.DailyLimit.Float64-withdrawnToday, 0)))
+	}
+	return nil
+}
+
+// ToDTO converts the delegation into its response, telling whether it is active at the given time.
+func (d Delegation) ToDTO(now string) dto.DelegationResponse {
+	return dto.DelegationResponse{
+		DelegationId:       d.DelegationId,
+		AccountId:          d.AccountId,
+		GrantorCustomerId:  d.GrantorCustomerId,
+		DelegateCustomerId: d.DelegateCustomerId,
+		Scope:              d.Scope,
+		DailyLimit:         d.DailyLimit.Float64,
+		StartDate:          d.StartDate,
+		EndDate:            d.EndDate,
+		CreationDate:       d.CreationDate,
+		RevocationDate:     d.RevocationDate.String,
+		Active:             d.IsActive(now),
+	}
+}
+
+//Server
+
+//go:generate mockgen -destination=../mocks/domain/mock_delegationRepository.go -package=domain github.com/aliciatay-zls/banking/backend/domain DelegationRepository
+type DelegationRepository interface { //repo (secondary port)
+	Save(Delegation) (*Delegation, *errs.AppError)
+	FindById(string) (*Delegation, *errs.AppError)
+	FindAll(string) ([]Delegation, *errs.AppError)
+	FindReceived(string) ([]Delegation, *errs.AppError)
+	FindActive(string, string, string) (*Delegation, *errs.AppError)
+	Revoke(string, string) *errs.AppError
+	SumWithdrawn(string, string, string) (float64, *errs.AppError)
+}
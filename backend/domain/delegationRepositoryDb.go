@@ -0,0 +1,142 @@
+package domain
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/jmoiron/sqlx"
+	"strconv"
+)
+
+//Server
+
+type DelegationRepositoryDb struct { //DB (adapter)
+	client dbExecutor
+}
+
+func NewDelegationRepositoryDb(dbClient *sqlx.DB) DelegationRepositoryDb {
+	return DelegationRepositoryDb{dbClient}
+}
+
+// Save creates a new entry in the database for the given delegation, sets its ID using the database-generated ID and
+// returns the delegation.
+func (d DelegationRepositoryDb) Save(g Delegation) (*Delegation, *errs.AppError) { //DB implements repo
+	insertSql := "INSERT INTO delegations (account_id, grantor_customer_id, delegate_customer_id, scope, daily_limit, " +
+		"start_date, end_date, creation_date) VALUES (?, ?, ?, ?, ?, ?, ?, ?)"
+	result, err := d.client.Exec(insertSql, g.AccountId, g.GrantorCustomerId, g.DelegateCustomerId, g.Scope,
+		g.DailyLimit, g.StartDate, g.EndDate, g.CreationDate)
+	if err != nil {
+		logger.Error("Error while creating new delegation: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		logger.Error("Error while getting id of newly inserted delegation: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	g.DelegationId = strconv.FormatInt(id, 10)
+
+	return &g, nil
+}
+
+// FindById retrieves the delegation with the given id.
+func (d DelegationRepositoryDb) FindById(delegationId string) (*Delegation, *errs.AppError) { //DB implements repo
+	return findDelegation(d.client, "SELECT * FROM delegations", delegationId)
+}
+
+// FindAll retrieves all delegations granted on the account with the given id, oldest first.
+func (d DelegationRepositoryDb) FindAll(accountId string) ([]Delegation, *errs.AppError) { //DB implements repo
+	return findDelegations(d.client, "SELECT * FROM delegations WHERE account_id = ?", accountId, "account")
+}
+
+// FindReceived retrieves all delegations granted to the customer with the given id, oldest first.
+func (d DelegationRepositoryDb) FindReceived(customerId string) ([]Delegation, *errs.AppError) { //DB implements repo
+	return findDelegations(d.client, "SELECT * FROM delegations WHERE delegate_customer_id = ?", customerId, "delegate")
+}
+
+// FindActive retrieves the delegation of the account with the given account id to the customer with the given
+// customer id that is active at the given time. It returns a not found error if there is none.
+func (d DelegationRepositoryDb) FindActive(accountId string, customerId string, now string) (*Delegation, *errs.AppError) { //DB implements repo
+	return findActiveDelegation(d.client, "SELECT * FROM delegations", accountId, customerId, now)
+}
+
+// Revoke ends the delegation with the given id at the given date. It returns a conflict error if the delegation was
+// already revoked.
+func (d DelegationRepositoryDb) Revoke(delegationId string, date string) *errs.AppError { //DB implements repo
+	return revokeDelegation(d.client, delegationId, date)
+}
+
+// SumWithdrawn returns the total that the customer with the given customer id withdrew or transferred out of the
+// account with the given account id since the given time.
+func (d DelegationRepositoryDb) SumWithdrawn(accountId string, customerId string, since string) (float64, *errs.AppError) { //DB implements repo
+	return sumWithdrawnByDelegate(d.client, accountId, customerId, since)
+}
+
+// findDelegation retrieves the delegation with the given id with the given select statement.
+func findDelegation(client dbExecutor, selectSql string, delegationId string) (*Delegation, *errs.AppError) {
+	var g Delegation
+	if err := client.Get(&g, client.Rebind(selectSql+" WHERE delegation_id = ?"), delegationId); err != nil {
+		logger.Error("Error while retrieving delegation: " + err.Error())
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.NewNotFoundError("Delegation not found")
+		}
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return &g, nil
+}
+
+// findDelegations retrieves the delegations matched by the given select statement, which filters them by the given
+// id, oldest first. The given owner describes what the id is of, for logging.
+func findDelegations(client dbExecutor, selectSql string, id string, owner string) ([]Delegation, *errs.AppError) {
+	delegations := make([]Delegation, 0)
+	if err := client.Select(&delegations, client.Rebind(selectSql+" ORDER BY delegation_id"), id); err != nil {
+		logger.Error("Error while retrieving delegations of " + owner + ": " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return delegations, nil
+}
+
+func findActiveDelegation(client dbExecutor, selectSql string, accountId string, customerId string, now string) (*Delegation, *errs.AppError) {
+	var g Delegation
+	selectSql += " WHERE account_id = ? AND delegate_customer_id = ? " +
+		"AND revocation_date IS NULL AND start_date <= ? AND end_date >= ? ORDER BY delegation_id DESC LIMIT 1"
+	if err := client.Get(&g, client.Rebind(selectSql), accountId, customerId, now, now); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.NewNotFoundError("Delegation not found")
+		}
+		logger.Error("Error while retrieving active delegation: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return &g, nil
+}
+
+func revokeDelegation(client dbExecutor, delegationId string, date string) *errs.AppError {
+	updateSql := "UPDATE delegations SET revocation_date = ? WHERE delegation_id = ? AND revocation_date IS NULL"
+	result, err := client.Exec(client.Rebind(updateSql), date, delegationId)
+	if err != nil {
+		logger.Error("Error while revoking delegation: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		logger.Error("Error while getting number of delegations revoked: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	} else if n == 0 {
+		return errs.NewConflictError("Delegation was already revoked")
+	}
+	return nil
+}
+
+func sumWithdrawnByDelegate(client dbExecutor, accountId string, customerId string, since string) (float64, *errs.AppError) {
+	var total float64
+	selectSql := "SELECT COALESCE(SUM(amount), 0) FROM transactions WHERE account_id = ? AND acting_customer_id = ? " +
+		"AND transaction_type IN (?, ?) AND transaction_date >= ?"
+	if err := client.Get(&total, client.Rebind(selectSql), accountId, customerId, dto.TransactionTypeWithdrawal,
+		dto.TransactionTypeTransferOut, since); err != nil {
+		logger.Error("Error while retrieving amount withdrawn by delegate: " + err.Error())
+		return 0, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return total, nil
+}
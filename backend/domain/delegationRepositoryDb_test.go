@@ -0,0 +1,175 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"net/http"
+	"testing"
+)
+
+// These tests run on a real SQLite database seeded with the demo data, since the window of a delegation and the
+// withdrawals counted against its limit are only checked when the SQL is executed, which go-sqlmock never does.
+
+var delegationRepoDb DelegationRepositoryDb
+var delegationAccRepoDb AccountRepositoryDb
+
+func setupDelegationRepoDbTest(t *testing.T) {
+	logger.MuteLogger()
+	client := openSQLiteDb(t)
+	delegationRepoDb = NewDelegationRepositoryDb(client)
+	delegationAccRepoDb = NewAccountRepositoryDb(client)
+}
+
+// saveDummyDelegation lets customer 2000 withdraw up to 500 a day from account 95472 of customer 2001 until the end of
+// January 2006.
+func saveDummyDelegation(t *testing.T) *Delegation {
+	delegation, err := NewDelegation(dto.DelegationRequest{AccountId: "95472", CustomerId: "2001",
+		DelegateCustomerId: "2000", Scope: dto.DelegationScopeWithdraw, DailyLimit: 500, EndDate: "2006-01-31"},
+		clock.StaticClock{})
+	if err != nil {
+		t.Fatal("Expected no error but got error while creating delegation: " + err.Message)
+	}
+	saved, err := delegationRepoDb.Save(*delegation)
+	if err != nil {
+		t.Fatal("Expected no error but got error while saving delegation: " + err.Message)
+	}
+	return saved
+}
+
+func TestDelegationRepositoryDb_FindAll_returns_delegations_of_account(t *testing.T) {
+	//Arrange
+	setupDelegationRepoDbTest(t)
+	saved := saveDummyDelegation(t)
+
+	//Act
+	granted, err := delegationRepoDb.FindAll("95472")
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if len(granted) != 1 || granted[0] != *saved {
+		t.Errorf("Expected only delegation %+v but got %+v", *saved, granted)
+	}
+}
+
+func TestDelegationRepositoryDb_FindReceived_returns_delegations_to_customer(t *testing.T) {
+	//Arrange
+	setupDelegationRepoDbTest(t)
+	saved := saveDummyDelegation(t)
+
+	//Act
+	received, err := delegationRepoDb.FindReceived("2000")
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if len(received) != 1 || received[0] != *saved {
+		t.Errorf("Expected only delegation %+v but got %+v", *saved, received)
+	}
+}
+
+func TestDelegationRepositoryDb_FindActive_returns_delegation_when_within_window(t *testing.T) {
+	//Arrange
+	setupDelegationRepoDbTest(t)
+	saved := saveDummyDelegation(t)
+
+	//Act
+	actual, err := delegationRepoDb.FindActive("95472", "2000", clock.StaticClock{}.NowAsString())
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if *actual != *saved {
+		t.Errorf("Expected delegation %+v but got %+v", *saved, *actual)
+	}
+}
+
+func TestDelegationRepositoryDb_FindActive_returns_notFoundError_when_after_endDate(t *testing.T) {
+	//Arrange
+	setupDelegationRepoDbTest(t)
+	saveDummyDelegation(t)
+
+	//Act
+	_, err := delegationRepoDb.FindActive("95472", "2000", "2006-02-01 00:00:00")
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none")
+	}
+	if err.Code != http.StatusNotFound {
+		t.Errorf("Expected status code %d but got %d", http.StatusNotFound, err.Code)
+	}
+}
+
+func TestDelegationRepositoryDb_SumWithdrawn_only_counts_withdrawals_by_delegate_since_givenTime(t *testing.T) {
+	//Arrange
+	setupDelegationRepoDbTest(t)
+	saveDummyDelegation(t)
+	for _, transactionType := range []string{dto.TransactionTypeWithdrawal, dto.TransactionTypeDeposit} {
+		transaction := NewTransaction("95472", 100, transactionType, clock.StaticClock{})
+		transaction.ActedBy("2000")
+		if _, err := delegationAccRepoDb.Transact(transaction); err != nil {
+			t.Fatal("Expected no error but got error while making transaction: " + err.Message)
+		}
+	}
+
+	//Act
+	total, err := delegationRepoDb.SumWithdrawn("95472", "2000", "2006-01-02 00:00:00")
+	laterTotal, laterErr := delegationRepoDb.SumWithdrawn("95472", "2000", "2006-01-03 00:00:00")
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if laterErr != nil {
+		t.Fatal("Expected no error but got error: " + laterErr.Message)
+	}
+	if total != 100 || laterTotal != 0 {
+		t.Errorf("Expected 100 withdrawn since 2006-01-02 and nothing since 2006-01-03 but got %v and %v",
+			total, laterTotal)
+	}
+}
+
+func TestDelegationRepositoryDb_Revoke_ends_delegation(t *testing.T) {
+	//Arrange
+	setupDelegationRepoDbTest(t)
+	saved := saveDummyDelegation(t)
+	now := clock.StaticClock{}.NowAsString()
+
+	//Act
+	err := delegationRepoDb.Revoke(saved.DelegationId, now)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	_, err = delegationRepoDb.FindActive("95472", "2000", now)
+	if err == nil || err.Code != http.StatusNotFound {
+		t.Errorf("Expected not found error for revoked delegation but got %v", err)
+	}
+}
+
+func TestDelegationRepositoryDb_Revoke_returns_conflictError_when_delegation_alreadyRevoked(t *testing.T) {
+	//Arrange
+	setupDelegationRepoDbTest(t)
+	saved := saveDummyDelegation(t)
+	now := clock.StaticClock{}.NowAsString()
+	if err := delegationRepoDb.Revoke(saved.DelegationId, now); err != nil {
+		t.Fatal("Expected no error but got error while revoking delegation: " + err.Message)
+	}
+
+	//Act
+	err := delegationRepoDb.Revoke(saved.DelegationId, now)
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none")
+	}
+	if err.Code != http.StatusConflict {
+		t.Errorf("Expected status code %d but got %d", http.StatusConflict, err.Code)
+	}
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/jmoiron/sqlx"
+)
+
+//Server
+
+// selectDelegationsPostgresSql selects the columns of delegations, formatting dates the same way MySQL returns them.
+const selectDelegationsPostgresSql = "SELECT delegation_id, account_id, grantor_customer_id, delegate_customer_id, " +
+	"scope, daily_limit, to_char(start_date, 'YYYY-MM-DD HH24:MI:SS') AS start_date, " +
+	"to_char(end_date, 'YYYY-MM-DD HH24:MI:SS') AS end_date, " +
+	"to_char(creation_date, 'YYYY-MM-DD HH24:MI:SS') AS creation_date, " +
+	"to_char(revocation_date, 'YYYY-MM-DD HH24:MI:SS') AS revocation_date FROM delegations"
+
+type DelegationRepositoryPostgres struct { //DB (adapter)
+	client dbExecutor
+}
+
+func NewDelegationRepositoryPostgres(dbClient *sqlx.DB) DelegationRepositoryPostgres {
+	return DelegationRepositoryPostgres{dbClient}
+}
+
+// Save creates a new entry in the database for the given delegation, sets its ID using the ID returned by the
+// database and returns the delegation.
+func (d DelegationRepositoryPostgres) Save(g Delegation) (*Delegation, *errs.AppError) { //DB implements repo
+	insertSql := "INSERT INTO delegations (account_id, grantor_customer_id, delegate_customer_id, scope, daily_limit, " +
+		"start_date, end_date, creation_date) VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING delegation_id"
+	err := d.client.Get(&g.DelegationId, insertSql, g.AccountId, g.GrantorCustomerId, g.DelegateCustomerId, g.Scope,
+		g.DailyLimit, g.StartDate, g.EndDate, g.CreationDate)
+	if err != nil {
+		logger.Error("Error while creating new delegation: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return &g, nil
+}
+
+// FindById retrieves the delegation with the given id.
+func (d DelegationRepositoryPostgres) FindById(delegationId string) (*Delegation, *errs.AppError) { //DB implements repo
+	return findDelegation(d.client, selectDelegationsPostgresSql, delegationId)
+}
+
+// FindAll retrieves all delegations granted on the account with the given id, oldest first.
+func (d DelegationRepositoryPostgres) FindAll(accountId string) ([]Delegation, *errs.AppError) { //DB implements repo
+	return findDelegations(d.client, selectDelegationsPostgresSql+" WHERE account_id = ?", accountId, "account")
+}
+
+// FindReceived retrieves all delegations granted to the customer with the given id, oldest first.
+func (d DelegationRepositoryPostgres) FindReceived(customerId string) ([]Delegation, *errs.AppError) { //DB implements repo
+	return findDelegations(d.client, selectDelegationsPostgresSql+" WHERE delegate_customer_id = ?", customerId, "delegate")
+}
+
+// FindActive retrieves the delegation of the account with the given account id to the customer with the given
+// customer id that is active at the given time. It returns a not found error if there is none.
+func (d DelegationRepositoryPostgres) FindActive(accountId string, customerId string, now string) (*Delegation, *errs.AppError) { //DB implements repo
+	return findActiveDelegation(d.client, selectDelegationsPostgresSql, accountId, customerId, now)
+}
+
+// Revoke ends the delegation with the given id at the given date. It returns a conflict error if the delegation was
+// already revoked.
+func (d DelegationRepositoryPostgres) Revoke(delegationId string, date string) *errs.AppError { //DB implements repo
+	return revokeDelegation(d.client, delegationId, date)
+}
+
+// SumWithdrawn returns the total that the customer with the given customer id withdrew or transferred out of the
+// account with the given account id since the given time.
+func (d DelegationRepositoryPostgres) SumWithdrawn(accountId string, customerId string, since string) (float64, *errs.AppError) { //DB implements repo
+	return sumWithdrawnByDelegate(d.client, accountId, customerId, since)
+}
@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/jmoiron/sqlx"
+	"testing"
+)
+
+// Test common variables and inputs
+var delegationRepoPostgres DelegationRepositoryPostgres
+
+func setupDelegationRepoPostgresTest(t *testing.T) func() {
+	teardown := setupDB(t)
+	delegationRepoPostgres = NewDelegationRepositoryPostgres(sqlx.NewDb(db, "postgres"))
+	return teardown
+}
+
+func TestDelegationRepositoryPostgres_Save_returns_newDelegation_with_returnedId(t *testing.T) {
+	//Arrange
+	teardown := setupDelegationRepoPostgresTest(t)
+	defer teardown()
+
+	g := getDummyDelegation(dto.DelegationScopeWithdraw)
+	g.DelegationId = ""
+	mockDB.ExpectQuery("INSERT INTO delegations (account_id, grantor_customer_id, delegate_customer_id, scope, "+
+		"daily_limit, start_date, end_date, creation_date) VALUES ($1, $2, $3, $4, $5, $6, $7, $8) "+
+		"RETURNING delegation_id").
+		WithArgs(g.AccountId, g.GrantorCustomerId, g.DelegateCustomerId, g.Scope, g.DailyLimit, g.StartDate,
+			g.EndDate, g.CreationDate).
+		WillReturnRows(sqlmock.NewRows([]string{"delegation_id"}).AddRow(4))
+
+	//Act
+	saved, err := delegationRepoPostgres.Save(g)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if saved.DelegationId != "4" {
+		t.Errorf("Expected delegation ID 4 but got %s", saved.DelegationId)
+	}
+}
+
+func TestDelegationRepositoryPostgres_FindActive_returns_delegation_activeAtGivenTime(t *testing.T) {
+	//Arrange
+	teardown := setupDelegationRepoPostgresTest(t)
+	defer teardown()
+
+	rows := sqlmock.NewRows([]string{"delegation_id", "account_id", "delegate_customer_id", "scope", "end_date"}).
+		AddRow(4, dummyAccountId, "3", dto.DelegationScopeViewOnly, "2006-01-31 23:59:59")
+	mockDB.ExpectQuery(selectDelegationsPostgresSql+" WHERE account_id = $1 AND delegate_customer_id = $2 "+
+		"AND revocation_date IS NULL AND start_date <= $3 AND end_date >= $4 ORDER BY delegation_id DESC LIMIT 1").
+		WithArgs(dummyAccountId, "3", dummyDate, dummyDate).
+		WillReturnRows(rows)
+
+	//Act
+	delegation, err := delegationRepoPostgres.FindActive(dummyAccountId, "3", dummyDate)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if delegation.DelegationId != "4" || delegation.EndDate != "2006-01-31 23:59:59" {
+		t.Errorf("Expected delegation 4 ending on 2006-01-31 23:59:59 but got %+v", delegation)
+	}
+}
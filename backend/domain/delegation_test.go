@@ -0,0 +1,158 @@
+package domain
+
+import (
+	"database/sql"
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"net/http"
+	"testing"
+)
+
+func getDummyDelegation(scope string) Delegation {
+	delegation := Delegation{
+		DelegationId:       "4",
+		AccountId:          dummyAccountId,
+		GrantorCustomerId:  dummyCustomerId,
+		DelegateCustomerId: "3",
+		Scope:              scope,
+		StartDate:          "2006-01-01 00:00:00",
+		EndDate:            "2006-01-31 23:59:59",
+		CreationDate:       "2006-01-01 00:00:00",
+	}
+	if scope == dto.DelegationScopeWithdraw {
+		delegation.DailyLimit = sql.NullFloat64{Float64: 500, Valid: true}
+	}
+	return delegation
+}
+
+func TestNewDelegation_checks_window(t *testing.T) {
+	tests := []struct {
+		name              string
+		startDate         string
+		endDate           string
+		expectErr         bool
+		expectedStartDate string
+	}{
+		{"no start date starts now", "", "2006-01-02", false, "2006-01-02 15:04:05"},
+		{"start date today starts now", "2006-01-02", "2006-01-31", false, "2006-01-02 15:04:05"},
+		{"later start date starts at its beginning", "2006-01-10", "2006-01-31", false, "2006-01-10 00:00:00"},
+		{"start date in past", "2006-01-01", "2006-01-31", true, ""},
+		{"end date in past", "", "2006-01-01", true, ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Arrange
+			request := dto.DelegationRequest{AccountId: dummyAccountId, CustomerId: dummyCustomerId,
+				DelegateCustomerId: "3", Scope: dto.DelegationScopeViewOnly, StartDate: tc.startDate, EndDate: tc.endDate}
+
+			//Act
+			delegation, err := NewDelegation(request, clock.StaticClock{})
+
+			//Assert
+			if tc.expectErr {
+				if err == nil || err.Code != http.StatusUnprocessableEntity {
+					t.Errorf("Expected validation error but got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal("Expected no error but got error: " + err.Message)
+			}
+			if delegation.StartDate != tc.expectedStartDate || delegation.EndDate != tc.endDate+" 23:59:59" ||
+				delegation.DailyLimit.Valid {
+				t.Errorf("Expected delegation from %s to the end of %s without limit but got %+v",
+					tc.expectedStartDate, tc.endDate, *delegation)
+			}
+		})
+	}
+}
+
+func TestDelegation_IsActive(t *testing.T) {
+	//Arrange
+	revoked := getDummyDelegation(dto.DelegationScopeViewOnly)
+	revoked.RevocationDate = sql.NullString{String: "2006-01-02 15:04:05", Valid: true}
+
+	tests := []struct {
+		name       string
+		delegation Delegation
+		now        string
+		expected   bool
+	}{
+		{"within window", getDummyDelegation(dto.DelegationScopeViewOnly), "2006-01-31 23:59:59", true},
+		{"before window", getDummyDelegation(dto.DelegationScopeViewOnly), "2005-12-31 23:59:59", false},
+		{"after window", getDummyDelegation(dto.DelegationScopeViewOnly), "2006-02-01 00:00:00", false},
+		{"revoked", revoked, "2006-01-10 00:00:00", false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Act
+			actual := tc.delegation.IsActive(tc.now)
+
+			//Assert
+			if actual != tc.expected {
+				t.Errorf("Expected active to be %v but got %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestDelegation_AllowsRoute(t *testing.T) {
+	tests := []struct {
+		scope     string
+		routeName string
+		expected  bool
+	}{
+		{dto.DelegationScopeViewOnly, "GetTransactions", true},
+		{dto.DelegationScopeViewOnly, "NewTransaction", false},
+		{dto.DelegationScopeDepositOnly, "NewTransaction", true},
+		{dto.DelegationScopeDepositOnly, "NewTransfer", false},
+		{dto.DelegationScopeWithdraw, "NewTransfer", true},
+		{dto.DelegationScopeWithdraw, "GrantDelegation", false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.scope+" "+tc.routeName, func(t *testing.T) {
+			//Act
+			actual := getDummyDelegation(tc.scope).AllowsRoute(tc.routeName)
+
+			//Assert
+			if actual != tc.expected {
+				t.Errorf("Expected route to be allowed %v but got %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestDelegation_CheckTransaction(t *testing.T) {
+	tests := []struct {
+		name            string
+		scope           string
+		transactionType string
+		amount          float64
+		withdrawnToday  float64
+		expectedCode    int
+	}{
+		{"deposit with view-only scope", dto.DelegationScopeViewOnly, dto.TransactionTypeDeposit, 100, 0, http.StatusForbidden},
+		{"deposit with deposit-only scope", dto.DelegationScopeDepositOnly, dto.TransactionTypeDeposit, 100, 0, 0},
+		{"withdrawal with deposit-only scope", dto.DelegationScopeDepositOnly, dto.TransactionTypeWithdrawal, 100, 0, http.StatusForbidden},
+		{"withdrawal up to daily limit", dto.DelegationScopeWithdraw, dto.TransactionTypeWithdrawal, 200, 300, 0},
+		{"transfer above daily limit", dto.DelegationScopeWithdraw, dto.TransactionTypeTransferOut, 200.01, 300, http.StatusUnprocessableEntity},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Act
+			err := getDummyDelegation(tc.scope).CheckTransaction(tc.transactionType, tc.amount, tc.withdrawnToday)
+
+			//Assert
+			if tc.expectedCode == 0 && err != nil {
+				t.Error("Expected no error but got error: " + err.Message)
+			}
+			if tc.expectedCode != 0 && (err == nil || err.Code != tc.expectedCode) {
+				t.Errorf("Expected error with status code %d but got %v", tc.expectedCode, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package domain
+
+import (
+	"database/sql"
+	"encoding/json"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+)
+
+//Business Domain
+
+const EventAccountOpened = "AccountOpened"
+const EventTransactionPosted = "TransactionPosted"
+const EventCustomerStatusChanged = "CustomerStatusChanged"
+
+const AggregateAccount = "account"
+const AggregateCustomer = "customer"
+
+// Event is a domain event describing a change that other services may want to know about. Events are written to the
+// outbox in the same database transaction as the change, and published later by the outbox relay.
+type Event struct { //business/domain object
+	EventId       string         `db:"event_id"`
+	EventType     string         `db:"event_type"`
+	AggregateType string         `db:"aggregate_type"` //type of the object that changed, either "account" or "customer"
+	AggregateId   string         `db:"aggregate_id"`   //id of the object that changed
+	Payload       string         `db:"payload"`        //JSON describing the change
+	OccurredOn    string         `db:"occurred_on"`
+	PublishedOn   sql.NullString `db:"published_on"`
+	Attempts      int            `db:"attempts"`   //number of times publishing the event failed
+	LastError     sql.NullString `db:"last_error"` //error of the last failed attempt to publish the event
+}
+
+func newEvent(eventType string, aggregateType string, aggregateId string, payload interface{}, occurredOn string) Event {
+	payloadJson, _ := json.Marshal(payload) //payloads only have fields that can always be marshalled
+	return Event{
+		EventType:     eventType,
+		AggregateType: aggregateType,
+		AggregateId:   aggregateId,
+		Payload:       string(payloadJson),
+		OccurredOn:    occurredOn,
+	}
+}
+
+// NewAccountOpenedEvent creates the event for the opening of the given account, which should already have its ID.
+func NewAccountOpenedEvent(a Account) Event {
+	payload := dto.AccountOpenedPayload{
+		AccountId:   a.AccountId,
+		CustomerId:  a.CustomerId,
+		AccountType: a.AccountType,
+		Currency:    a.Currency,
+		Amount:      a.Amount,
+		OpeningDate: a.OpeningDate,
+	}
+	return newEvent(EventAccountOpened, AggregateAccount, a.AccountId, payload, a.OpeningDate)
+}
+
+// NewTransactionPostedEvent creates the event for the posting of the given bank transaction, which should already
+// have its ID and the new balance of its account.
+func NewTransactionPostedEvent(t Transaction) Event {
+	payload := dto.TransactionPostedPayload{
+		TransactionId:   t.TransactionId,
+		AccountId:       t.AccountId,
+		Amount:          t.Amount,
+		TransactionType: t.TransactionType,
+		TransactionDate: t.TransactionDate,
+		Balance:         t.Balance,
+		FxRate:          t.FxRate.Float64,
+		ConvertedAmount: t.ConvertedAmount.Float64,
+	}
+	return newEvent(EventTransactionPosted, AggregateAccount, t.AccountId, payload, t.TransactionDate)
+}
+
+// NewCustomerStatusChangedEvent creates the event for the change of the given customer's status from the given
+// previous status, both being database values.
+func NewCustomerStatusChangedEvent(customerId string, status string, previousStatus string, changedOn string) Event {
+	payload := dto.CustomerStatusChangedPayload{
+		CustomerId:     customerId,
+		Status:         Customer{Status: status}.AsStatusName(),
+		PreviousStatus: Customer{Status: previousStatus}.AsStatusName(),
+	}
+	return newEvent(EventCustomerStatusChanged, AggregateCustomer, customerId, payload, changedOn)
+}
+
+// ToMessageDTO converts the event into the message that is published.
+func (e Event) ToMessageDTO() dto.EventMessage {
+	return dto.EventMessage{
+		EventId:       e.EventId,
+		EventType:     e.EventType,
+		AggregateType: e.AggregateType,
+		AggregateId:   e.AggregateId,
+		OccurredOn:    e.OccurredOn,
+		Payload:       json.RawMessage(e.Payload),
+	}
+}
+
+// ToMessageJson encodes the message that is published for the event.
+func (e Event) ToMessageJson() (string, *errs.AppError) {
+	message, err := json.Marshal(e.ToMessageDTO())
+	if err != nil {
+		logger.Error("Error while encoding event " + e.EventId + ": " + err.Error())
+		return "", errs.NewUnexpectedError("Unexpected server error")
+	}
+	return string(message), nil
+}
+
+// OrderingKey identifies the object that changed. Events with the same key must be published in the order they
+// occurred.
+func (e Event) OrderingKey() string {
+	return e.AggregateType + "/" + e.AggregateId
+}
+
+//Server
+
+//go:generate mockgen -destination=../mocks/domain/mock_outboxRepository.go -package=domain github.com/aliciatay-zls/banking/backend/domain OutboxRepository
+type OutboxRepository interface { //repo (secondary port)
+	FindUnpublished(int) ([]Event, *errs.AppError)
+	MarkPublished(string, string) *errs.AppError
+	RecordFailure(string, string) *errs.AppError
+}
+
+//go:generate mockgen -destination=../mocks/domain/mock_eventPublisher.go -package=domain github.com/aliciatay-zls/banking/backend/domain EventPublisher
+type EventPublisher interface { //repo (secondary port)
+	Publish(Event) *errs.AppError
+}
+
+// EventPublishers publishes each event to every one of the publishers in turn, stopping at the first that fails. Since
+// the event is then published again later, the publishers before the failed one may receive it more than once.
+type EventPublishers []EventPublisher
+
+func (p EventPublishers) Publish(event Event) *errs.AppError {
+	for _, publisher := range p {
+		if err := publisher.Publish(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
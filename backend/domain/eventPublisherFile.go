@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"io"
+	"sync"
+)
+
+//Server
+
+// EventPublisherFile publishes events by writing each of them as a line of JSON to a file or to standard output,
+// from where they can be picked up by a log shipper or another service. It is safe for concurrent use.
+type EventPublisherFile struct { //file (adapter)
+	mu *sync.Mutex
+	w  io.Writer
+}
+
+func NewEventPublisherFile(w io.Writer) EventPublisherFile {
+	return EventPublisherFile{&sync.Mutex{}, w}
+}
+
+// Publish writes the given event as a dto.EventMessage followed by a newline.
+func (p EventPublisherFile) Publish(event Event) *errs.AppError { //file implements repo
+	line, appErr := event.ToMessageJson()
+	if appErr != nil {
+		return appErr
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, err := io.WriteString(p.w, line+"\n"); err != nil {
+		logger.Error("Error while writing event " + event.EventId + ": " + err.Error())
+		return errs.NewUnexpectedError("Unexpected server error")
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"strings"
+	"testing"
+)
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestEventPublisherFile_Publish_writes_one_jsonLine_per_event(t *testing.T) {
+	//Arrange
+	var buf bytes.Buffer
+	publisher := NewEventPublisherFile(&buf)
+	first := NewAccountOpenedEvent(getDefaultAccountAfterSave())
+	first.EventId = "1"
+	second := NewTransactionPostedEvent(getDefaultTransactionAfterTransact())
+	second.EventId = "2"
+
+	//Act
+	err1 := publisher.Publish(first)
+	err2 := publisher.Publish(second)
+
+	//Assert
+	if err1 != nil || err2 != nil {
+		t.Fatalf("Expected no error but got %v and %v", err1, err2)
+	}
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 lines but got %d: %s", len(lines), buf.String())
+	}
+	var message dto.EventMessage
+	if err := json.Unmarshal([]byte(lines[1]), &message); err != nil {
+		t.Fatal("Expected valid JSON but got error: " + err.Error())
+	}
+	if message.EventId != "2" || message.EventType != EventTransactionPosted || string(message.Payload) != second.Payload {
+		t.Errorf("Expected message for event %+v but got %+v", second, message)
+	}
+}
+
+func TestEventPublisherFile_Publish_returns_error_when_write_fails(t *testing.T) {
+	//Arrange
+	publisher := NewEventPublisherFile(failingWriter{})
+	logger.MuteLogger()
+
+	//Act
+	err := publisher.Publish(NewAccountOpenedEvent(getDefaultAccountAfterSave()))
+
+	//Assert
+	if err == nil {
+		t.Error("Expected error but got none while testing failed write")
+	}
+}
+
+func TestEventPublisherStub_Publish_keeps_messages_unless_told_to_fail(t *testing.T) {
+	//Arrange
+	publisher := NewEventPublisherStub(func(e Event) bool { return e.EventId == "2" })
+	logger.MuteLogger()
+
+	//Act
+	for _, id := range []string{"1", "2", "3"} {
+		event := NewAccountOpenedEvent(getDefaultAccountAfterSave())
+		event.EventId = id
+		_ = publisher.Publish(event)
+	}
+
+	//Assert
+	messages := publisher.Messages()
+	if len(messages) != 2 || messages[0].EventId != "1" || messages[1].EventId != "3" {
+		t.Errorf("Expected messages 1 and 3 but got %+v", messages)
+	}
+}
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"sync"
+)
+
+//Server
+
+// EventPublisherStub keeps published events in memory so that tests can check what was published. It is safe for
+// concurrent use.
+type EventPublisherStub struct { //stub (adapter)
+	mu       *sync.Mutex
+	messages *[]dto.EventMessage
+	fail     func(Event) bool
+}
+
+// NewEventPublisherStub creates a stub that fails to publish the events for which the given function returns true,
+// which is useful for simulating an outage of the message broker. The function may be nil, in which case all events
+// are published.
+func NewEventPublisherStub(fail func(Event) bool) EventPublisherStub {
+	return EventPublisherStub{&sync.Mutex{}, &[]dto.EventMessage{}, fail}
+}
+
+func (s EventPublisherStub) Publish(event Event) *errs.AppError { //stub implements repo
+	if s.fail != nil && s.fail(event) {
+		logger.Error("Error while publishing event " + event.EventId + " using stub for EventPublisher: failed on purpose")
+		return errs.NewUnexpectedError("Unexpected server error")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	*s.messages = append(*s.messages, event.ToMessageDTO())
+	return nil
+}
+
+// Messages returns the events published so far, in the order they were published.
+func (s EventPublisherStub) Messages() []dto.EventMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]dto.EventMessage{}, *s.messages...)
+}
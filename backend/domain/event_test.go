@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"database/sql"
+	"testing"
+)
+
+func TestNewTransactionPostedEvent_describes_transaction_with_newBalance(t *testing.T) {
+	//Arrange
+	transaction := getDefaultTransactionAfterTransact()
+	transaction.FxRate = sql.NullFloat64{Float64: 1.5, Valid: true}
+	transaction.ConvertedAmount = sql.NullFloat64{Float64: 9000, Valid: true}
+	expectedPayload := `{"transaction_id":"7791","account_id":"1977","amount":6000,"transaction_type":"deposit",` +
+		`"transaction_date":"2006-01-02 15:04:05","new_balance":12000,"fx_rate":1.5,"converted_amount":9000}`
+
+	//Act
+	event := NewTransactionPostedEvent(transaction)
+
+	//Assert
+	if event.EventType != EventTransactionPosted || event.OrderingKey() != "account/1977" || event.OccurredOn != dummyDate {
+		t.Errorf("Expected TransactionPosted event of account 1977 at %s but got %+v", dummyDate, event)
+	}
+	if event.Payload != expectedPayload {
+		t.Errorf("Expected payload %s but got %s", expectedPayload, event.Payload)
+	}
+}
+
+func TestNewCustomerStatusChangedEvent_uses_statusNames(t *testing.T) {
+	//Act
+	event := NewCustomerStatusChangedEvent("2000", "0", "1", dummyDate)
+
+	//Assert
+	expectedPayload := `{"customer_id":"2000","status":"inactive","previous_status":"active"}`
+	if event.Payload != expectedPayload {
+		t.Errorf("Expected payload %s but got %s", expectedPayload, event.Payload)
+	}
+	if event.OrderingKey() != "customer/2000" {
+		t.Errorf("Expected ordering key customer/2000 but got %s", event.OrderingKey())
+	}
+}
+
+func TestEvent_ToMessageDTO_embeds_payload(t *testing.T) {
+	//Arrange
+	event := NewAccountOpenedEvent(getDefaultAccountAfterSave())
+	event.EventId = "1"
+
+	//Act
+	message := event.ToMessageDTO()
+
+	//Assert
+	if message.EventId != "1" || message.EventType != EventAccountOpened || message.AggregateId != dummyAccountId {
+		t.Errorf("Expected AccountOpened message 1 for account %s but got %+v", dummyAccountId, message)
+	}
+	if string(message.Payload) != event.Payload {
+		t.Errorf("Expected payload %s but got %s", event.Payload, message.Payload)
+	}
+}
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+//Server
+
+//go:embed fixtures/demo.json
+var defaultFixtures []byte
+
+// Fixtures is the data that the in-memory repositories start out with in demo mode.
+type Fixtures struct {
+	Customers []Customer `json:"customers"`
+	Accounts  []Account  `json:"accounts"`
+	Users     []StubUser `json:"users"`
+}
+
+// StubUser is a user of AuthRepositoryStub, who is logged in by sending the given token as the bearer token.
+type StubUser struct {
+	Token      string `json:"token"`
+	Role       string `json:"role"`        //either "admin" or "user"
+	CustomerId string `json:"customer_id"` //customer the user is, for users with the "user" role
+}
+
+// LoadFixtures reads fixtures from the JSON file at the given path. The file should contain an object with the keys
+// "customers", "accounts" and "users", each holding a list of objects with the same keys as the database columns, e.g.
+// {"customers": [{"customer_id": "2000", "name": "Steve", ...}], "accounts": [...], "users": [{"token": "demo-admin",
+// "role": "admin"}]}. An empty path gives the default fixtures, which are the same as the seed data of the database.
+func LoadFixtures(path string) (Fixtures, error) {
+	data := defaultFixtures
+	if path != "" {
+		var err error
+		if data, err = os.ReadFile(path); err != nil {
+			return Fixtures{}, err
+		}
+	}
+
+	var fixtures Fixtures
+	if err := json.Unmarshal(data, &fixtures); err != nil {
+		return Fixtures{}, err
+	}
+
+	customerIds := make(map[string]bool)
+	for _, c := range fixtures.Customers {
+		customerIds[c.Id] = true
+	}
+	for _, a := range fixtures.Accounts {
+		if !customerIds[a.CustomerId] {
+			return Fixtures{}, fmt.Errorf("account %s belongs to customer %s, who is not in the fixtures", a.AccountId, a.CustomerId)
+		}
+	}
+	for _, u := range fixtures.Users {
+		if u.Role != RoleAdmin && !customerIds[u.CustomerId] {
+			return Fixtures{}, fmt.Errorf("user with token %s should be an admin or one of the customers in the fixtures", u.Token)
+		}
+	}
+	return fixtures, nil
+}
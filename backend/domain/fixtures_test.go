@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadFixtures_returns_defaultFixtures_when_path_empty(t *testing.T) {
+	//Act
+	fixtures, err := LoadFixtures("")
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Error())
+	}
+	if len(fixtures.Customers) != 6 || len(fixtures.Accounts) != 4 || len(fixtures.Users) != 3 {
+		t.Errorf("Expected 6 customers, 4 accounts and 3 users but got %d, %d and %d",
+			len(fixtures.Customers), len(fixtures.Accounts), len(fixtures.Users))
+	}
+	if fixtures.Accounts[1].OverdraftLimit != 500 || fixtures.Customers[0].DateOfBirth != "1978-12-15" {
+		t.Errorf("Expected fixtures to be read into all fields but got %+v and %+v", fixtures.Accounts[1], fixtures.Customers[0])
+	}
+}
+
+func TestLoadFixtures_returns_error_when_file_invalid(t *testing.T) {
+	//Arrange
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"not json", "customers"},
+		{"account of unknown customer", `{"customers": [{"customer_id": "1"}], "accounts": [{"account_id": "2", "customer_id": "3"}]}`},
+		{"user of unknown customer", `{"customers": [{"customer_id": "1"}], "users": [{"token": "t", "role": "user", "customer_id": "3"}]}`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "fixtures.json")
+			if err := os.WriteFile(path, []byte(tc.content), 0600); err != nil {
+				t.Fatal(err)
+			}
+
+			//Act
+			_, err := LoadFixtures(path)
+
+			//Assert
+			if err == nil {
+				t.Error("Expected error but got none")
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"database/sql"
+	"encoding/json"
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"strings"
+)
+
+//Business Domain
+
+// FraudCase is a transaction that the screening rules did not allow, waiting in the case queue for an admin to
+// review it. Transactions to be reviewed are made, while blocked transactions are not.
+type FraudCase struct { //business/domain object
+	CaseId          string         `db:"case_id"`
+	CustomerId      string         `db:"customer_id"`
+	AccountId       string         `db:"account_id"`
+	TransactionType string         `db:"transaction_type"`
+	Amount          float64        `db:"amount"`
+	TransactionId   sql.NullString `db:"transaction_id"` //NULL if the transaction was blocked
+	Decision        string         `db:"decision"`
+	Rules           string         `db:"rules"`   //comma-separated names of the rules that did not allow the transaction
+	Results         string         `db:"results"` //JSON array of the results of those rules
+	Status          string         `db:"status"`
+	Resolution      string         `db:"resolution"` //empty while the case is open
+	Note            string         `db:"note"`
+	CreationDate    string         `db:"creation_date"`
+	ResolutionDate  sql.NullString `db:"resolution_date"`
+}
+
+// NewFraudCase opens a case for the given transaction on the given account, which the given screening did not allow.
+func NewFraudCase(transaction Transaction, account Account, screening Screening, c clock.Clock) FraudCase {
+	rules := make([]string, 0)
+	for _, r := range screening.Results {
+		rules = append(rules, r.Rule)
+	}
+	results, err := json.Marshal(screening.Results)
+	if err != nil { //cannot happen, since results only hold strings
+		logger.Error("Error while marshalling screening results: " + err.Error())
+	}
+
+	return FraudCase{
+		CustomerId:      account.CustomerId,
+		AccountId:       transaction.AccountId,
+		TransactionType: transaction.TransactionType,
+		Amount:          transaction.Amount,
+		Decision:        screening.Decision,
+		Rules:           strings.Join(rules, ","),
+		Results:         string(results),
+		Status:          dto.FraudCaseStatusOpen,
+		CreationDate:    c.NowAsString(),
+	}
+}
+
+func (f FraudCase) IsOpen() bool {
+	return f.Status == dto.FraudCaseStatusOpen
+}
+
+// Resolve closes the case with the resolution in the given request. It returns a conflict error if the case is
+// already closed.
+func (f FraudCase) Resolve(request dto.ResolveFraudCaseRequest, c clock.Clock) (FraudCase, *errs.AppError) {
+	if !f.IsOpen() {
+		return f, errs.NewConflictError("Fraud case is already closed")
+	}
+	f.Status = dto.FraudCaseStatusClosed
+	f.Resolution = request.Resolution
+	f.Note = request.Note
+	f.ResolutionDate = sql.NullString{String: c.NowAsString(), Valid: true}
+	return f, nil
+}
+
+func (f FraudCase) ToDTO() dto.FraudCaseResponse {
+	results := make([]dto.ScreeningResultInfo, 0)
+	if err := json.Unmarshal([]byte(f.Results), &results); err != nil {
+		logger.Error("Error while unmarshalling screening results of fraud case: " + err.Error())
+	}
+
+	return dto.FraudCaseResponse{
+		CaseId:          f.CaseId,
+		CustomerId:      f.CustomerId,
+		AccountId:       f.AccountId,
+		TransactionType: f.TransactionType,
+		Amount:          f.Amount,
+		TransactionId:   f.TransactionId.String,
+		Decision:        f.Decision,
+		Results:         results,
+		Status:          f.Status,
+		Resolution:      f.Resolution,
+		Note:            f.Note,
+		CreationDate:    f.CreationDate,
+		ResolutionDate:  f.ResolutionDate.String,
+	}
+}
+
+// FraudCaseFilter selects the fraud cases to page through in the case queue.
+type FraudCaseFilter struct {
+	Status  string //only cases with this status, or all if empty
+	AfterId string //only cases after this one, for paging through the queue
+	Limit   int
+}
+
+//Server
+
+//go:generate mockgen -destination=../mocks/domain/mock_screeningRepository.go -package=domain github.com/aliciatay-zls/banking/backend/domain ScreeningRepository
+type ScreeningRepository interface { //repo (secondary port)
+	FindHistory(customerId string, since string) ([]Transaction, *errs.AppError)
+	SaveCase(FraudCase) (*FraudCase, *errs.AppError)
+	FindCaseById(string) (*FraudCase, *errs.AppError)
+	FindCases(FraudCaseFilter) ([]FraudCase, *errs.AppError)
+	ResolveCase(FraudCase) *errs.AppError
+}
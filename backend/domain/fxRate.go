@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"encoding/json"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"math"
+	"os"
+)
+
+//Business Domain
+
+// ConvertAmount converts the given amount at the given exchange rate, rounding the result to the nearest cent.
+func ConvertAmount(amount float64, rate float64) float64 {
+	return math.Round(amount*rate*100) / 100
+}
+
+//Server
+
+//go:generate mockgen -destination=../mocks/domain/mock_fxRateProvider.go -package=domain github.com/aliciatay-zls/banking/backend/domain FxRateProvider
+type FxRateProvider interface { //repo (secondary port)
+	GetRate(string, string) (float64, *errs.AppError)
+}
+
+type FxRateProviderFile struct { //file (adapter)
+	Base  string             `json:"base"`
+	Rates map[string]float64 `json:"rates"`
+}
+
+// NewFxRateProviderFile reads exchange rates from the JSON file at the given path. The file should contain an object
+// with the keys "base", the code of the base currency, and "rates", an object giving the number of units of each
+// currency that one unit of the base currency buys, e.g. {"base": "USD", "rates": {"INR": 83.12, "NOK": 10.65}}.
+// An empty path gives a provider with no rates, which only allows conversions between the same currency.
+func NewFxRateProviderFile(path string) (FxRateProviderFile, error) {
+	provider := FxRateProviderFile{Rates: map[string]float64{}}
+	if path == "" {
+		return provider, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return provider, err
+	}
+	if err = json.Unmarshal(data, &provider); err != nil {
+		return provider, err
+	}
+	return provider, nil
+}
+
+// GetRate returns the number of units of the currency to convert to that one unit of the currency to convert from
+// buys. Rates between two currencies other than the base currency are derived through the base currency. It returns
+// a validation error if there is no rate for either currency.
+func (p FxRateProviderFile) GetRate(from string, to string) (float64, *errs.AppError) {
+	if from == to {
+		return 1, nil
+	}
+
+	fromRate, ok := p.rateAgainstBase(from)
+	if !ok {
+		logger.Error("No exchange rate found for currency " + from)
+		return 0, errs.NewValidationError("Exchange rate from " + from + " to " + to + " is not available")
+	}
+	toRate, ok := p.rateAgainstBase(to)
+	if !ok {
+		logger.Error("No exchange rate found for currency " + to)
+		return 0, errs.NewValidationError("Exchange rate from " + from + " to " + to + " is not available")
+	}
+	return toRate / fromRate, nil
+}
+
+func (p FxRateProviderFile) rateAgainstBase(currency string) (float64, bool) {
+	if currency == p.Base {
+		return 1, true
+	}
+	rate, ok := p.Rates[currency]
+	if !ok || rate <= 0 {
+		return 0, false
+	}
+	return rate, true
+}
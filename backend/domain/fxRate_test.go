@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"math"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func getDummyFxRateProvider() FxRateProviderFile {
+	return FxRateProviderFile{Base: "USD", Rates: map[string]float64{"INR": 80, "NOK": 10}}
+}
+
+func TestFxRateProviderFile_GetRate_returns_rate_betweenCurrencies(t *testing.T) {
+	//Arrange
+	provider := getDummyFxRateProvider()
+	tests := []struct {
+		name         string
+		from         string
+		to           string
+		expectedRate float64
+	}{
+		{"same currency", "SGD", "SGD", 1},
+		{"from base", "USD", "INR", 80},
+		{"to base", "NOK", "USD", 0.1},
+		{"through base", "NOK", "INR", 8},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Act
+			actualRate, err := provider.GetRate(tc.from, tc.to)
+
+			//Assert
+			if err != nil {
+				t.Fatal("Expected no error but got error: " + err.Message)
+			}
+			if math.Abs(actualRate-tc.expectedRate) > 1e-9 {
+				t.Errorf("Expected rate %v but got %v", tc.expectedRate, actualRate)
+			}
+		})
+	}
+}
+
+func TestFxRateProviderFile_GetRate_returns_validationError_when_rate_missing(t *testing.T) {
+	//Arrange
+	provider := getDummyFxRateProvider()
+	expectedErrMessage := "Exchange rate from USD to GBP is not available"
+
+	//Act
+	_, err := provider.GetRate("USD", "GBP")
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing missing exchange rate")
+	}
+	if err.Code != http.StatusUnprocessableEntity || err.Message != expectedErrMessage {
+		t.Errorf("Expected %d \"%s\" but got %d \"%s\"", http.StatusUnprocessableEntity, expectedErrMessage, err.Code, err.Message)
+	}
+}
+
+func TestNewFxRateProviderFile_readsRates_fromFile(t *testing.T) {
+	//Arrange
+	path := filepath.Join(t.TempDir(), "rates.json")
+	if err := os.WriteFile(path, []byte(`{"base": "USD", "rates": {"INR": 83.12}}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	//Act
+	provider, err := NewFxRateProviderFile(path)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while reading rates file: " + err.Error())
+	}
+	if rate, _ := provider.GetRate("USD", "INR"); rate != 83.12 {
+		t.Errorf("Expected rate 83.12 but got %v", rate)
+	}
+}
+
+func TestConvertAmount_roundsToNearestCent(t *testing.T) {
+	//Arrange
+	var amount float64 = 33.33
+	var rate = 0.0931
+
+	//Act
+	actualAmount := ConvertAmount(amount, rate)
+
+	//Assert
+	if actualAmount != 3.1 {
+		t.Errorf("Expected converted amount 3.1 but got %v", actualAmount)
+	}
+}
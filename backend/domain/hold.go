@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"database/sql"
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"time"
+)
+
+//Business Domain
+
+const HoldStatusActive = "active"
+const HoldStatusCaptured = "captured"
+const HoldStatusReleased = "released"
+const HoldStatusExpired = "expired"
+
+type Hold struct { //business/domain object
+	HoldId         string         `db:"hold_id"`
+	AccountId      string         `db:"account_id"`
+	Amount         float64        `db:"amount"`
+	CapturedAmount float64        `db:"captured_amount"`
+	Description    string         `db:"description"`
+	Status         string         `db:"status"`
+	CreationDate   string         `db:"creation_date"`
+	ExpiryDate     string         `db:"expiry_date"`
+	TransactionId  sql.NullString `db:"transaction_id"` //transaction made when the hold was captured
+}
+
+// NewHold creates an active hold reserving the given amount on the given account until it expires after the given
+// number of hours, or after dto.HoldDefaultExpiryHours if none is given.
+func NewHold(request dto.NewHoldRequest, c clock.Clock) Hold {
+	expiresInHours := request.ExpiresInHours
+	if expiresInHours == 0 {
+		expiresInHours = dto.HoldDefaultExpiryHours
+	}
+
+	now := c.Now()
+	return Hold{
+		AccountId:    request.AccountId,
+		Amount:       request.Amount,
+		Description:  request.Description,
+		Status:       HoldStatusActive,
+		CreationDate: now.Format(clock.FormatDateTime),
+		ExpiryDate:   now.Add(time.Duration(expiresInHours) * time.Hour).Format(clock.FormatDateTime),
+	}
+}
+
+func (h Hold) IsActive() bool {
+	return h.Status == HoldStatusActive
+}
+
+// IsExpired checks whether the hold's expiry date is at or before the given time, in which case it can no longer be
+// captured or released even if the job expiring holds has not marked it as expired yet.
+func (h Hold) IsExpired(now string) bool {
+	return h.ExpiryDate <= now
+}
+
+// CaptureAmount returns the amount to be captured for the given requested amount, which is the full amount of the
+// hold if none is requested. It returns a validation error if more than the hold's amount is requested.
+func (h Hold) CaptureAmount(requested float64) (float64, *errs.AppError) {
+	if requested == 0 {
+		return h.Amount, nil
+	}
+	if requested > h.Amount {
+		return 0, errs.NewValidationError("Amount to capture cannot be more than the amount held")
+	}
+	return requested, nil
+}
+
+func (h Hold) ToDTO() *dto.HoldResponse {
+	return &dto.HoldResponse{
+		HoldId:         h.HoldId,
+		AccountId:      h.AccountId,
+		Amount:         h.Amount,
+		CapturedAmount: h.CapturedAmount,
+		Description:    h.Description,
+		Status:         h.Status,
+		CreationDate:   h.CreationDate,
+		ExpiryDate:     h.ExpiryDate,
+		TransactionId:  h.TransactionId.String,
+	}
+}
+
+//Server
+
+//go:generate mockgen -destination=../mocks/domain/mock_holdRepository.go -package=domain github.com/aliciatay-zls/banking/backend/domain HoldRepository
+type HoldRepository interface { //repo (secondary port)
+	Save(Hold) (*Hold, *errs.AppError)
+	FindById(string) (*Hold, *errs.AppError)
+	FindAll(string) ([]Hold, *errs.AppError)
+	FindExpired(string) ([]Hold, *errs.AppError)
+	Capture(Hold, Transaction) (*Transaction, *errs.AppError)
+	Release(Hold, string) *errs.AppError
+}
@@ -0,0 +1,189 @@
+package domain
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/jmoiron/sqlx"
+	"strconv"
+)
+
+//Server
+
+type HoldRepositoryDb struct { //DB (adapter)
+	client dbExecutor
+}
+
+func NewHoldRepositoryDb(dbClient *sqlx.DB) HoldRepositoryDb {
+	return HoldRepositoryDb{dbClient}
+}
+
+// Save starts a database transaction, reserves the given hold's amount on its account, creates a new entry in the
+// database for the hold and commits the database transaction. It sets the hold's ID using the database-generated ID
+// and returns the hold. The amount is only reserved if the account's available balance, plus its overdraft limit
+// for a checking account, still covers it at the time of the update, so that holds placed concurrently cannot
+// together reserve more than is available. Otherwise, Save returns a validation error without doing anything.
+func (d HoldRepositoryDb) Save(hold Hold) (*Hold, *errs.AppError) { //DB implements repo
+	appErr := inTransaction(d.client, "placing hold", func(tx dbExecutor) *errs.AppError {
+		updateAccountSql := "UPDATE accounts SET held_amount = held_amount + ? WHERE account_id = ? AND " +
+			"amount - held_amount + CASE WHEN account_type = ? THEN overdraft_limit ELSE 0 END >= ?"
+		result, err := tx.Exec(updateAccountSql, hold.Amount, hold.AccountId, dto.AccountTypeChecking, hold.Amount)
+		if err != nil {
+			logger.Error("Error while updating held amount of account: " + err.Error())
+			return errs.NewUnexpectedError("Unexpected database error")
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			logger.Error("Error while checking whether held amount of account was updated: " + err.Error())
+			return errs.NewUnexpectedError("Unexpected database error")
+		}
+		if rowsAffected == 0 {
+			logger.Error("Amount to hold exceeds available balance")
+			return errs.NewValidationError("Available balance insufficient to hold given amount")
+		}
+
+		insertSql := "INSERT INTO holds (account_id, amount, captured_amount, description, status, creation_date, expiry_date) " +
+			"VALUES (?, ?, ?, ?, ?, ?, ?)"
+		result, err = tx.Exec(insertSql, hold.AccountId, hold.Amount, hold.CapturedAmount, hold.Description, hold.Status,
+			hold.CreationDate, hold.ExpiryDate)
+		if err != nil {
+			logger.Error("Error while creating new hold: " + err.Error())
+			return errs.NewUnexpectedError("Unexpected database error")
+		}
+
+		id, err := result.LastInsertId()
+		if err != nil {
+			logger.Error("Error while getting id of newly inserted hold: " + err.Error())
+			return errs.NewUnexpectedError("Unexpected database error")
+		}
+		hold.HoldId = strconv.FormatInt(id, 10)
+		return nil
+	})
+	if appErr != nil {
+		return nil, appErr
+	}
+	return &hold, nil
+}
+
+// FindById retrieves the hold with the given id.
+func (d HoldRepositoryDb) FindById(holdId string) (*Hold, *errs.AppError) {
+	var hold Hold
+	if err := d.client.Get(&hold, "SELECT * FROM holds WHERE hold_id = ?", holdId); err != nil {
+		logger.Error("Error while retrieving hold: " + err.Error())
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.NewNotFoundError("Hold not found")
+		}
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return &hold, nil
+}
+
+// FindAll retrieves all holds placed on the account with the given id.
+func (d HoldRepositoryDb) FindAll(accountId string) ([]Hold, *errs.AppError) {
+	holds := make([]Hold, 0)
+	if err := d.client.Select(&holds, "SELECT * FROM holds WHERE account_id = ?", accountId); err != nil {
+		logger.Error("Error while retrieving holds of account: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return holds, nil
+}
+
+// FindExpired retrieves all active holds that expire at the given time or earlier.
+func (d HoldRepositoryDb) FindExpired(now string) ([]Hold, *errs.AppError) {
+	holds := make([]Hold, 0)
+	selectSql := "SELECT * FROM holds WHERE status = ? AND expiry_date <= ?"
+	if err := d.client.Select(&holds, selectSql, HoldStatusActive, now); err != nil {
+		logger.Error("Error while retrieving expired holds: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return holds, nil
+}
+
+// Capture starts a database transaction, makes the given bank transaction for the captured amount, marks the given
+// hold as captured, frees the whole amount it reserved, writes the event for the bank transaction to the outbox and
+// commits the database transaction. Since only an active hold that has not expired by the transaction date can be
+// marked as captured, it returns a conflict error without doing anything if the hold was already captured, released
+// or expired. Capture returns the completed bank transaction.
+func (d HoldRepositoryDb) Capture(hold Hold, transaction Transaction) (*Transaction, *errs.AppError) {
+	appErr := inTransaction(d.client, "capturing hold", func(tx dbExecutor) *errs.AppError {
+		addTransactionSql := "INSERT INTO transactions (account_id, amount, transaction_type, transaction_date, " +
+			"acting_customer_id) VALUES (?, ?, ?, ?, ?)"
+		result, err := tx.Exec(addTransactionSql, transaction.AccountId, transaction.Amount, transaction.TransactionType,
+			transaction.TransactionDate, transaction.ActingCustomer)
+		if err != nil {
+			logger.Error("Error while creating new bank account transaction for hold: " + err.Error())
+			return errs.NewUnexpectedError("Unexpected database error")
+		}
+		id, err := result.LastInsertId()
+		if err != nil {
+			logger.Error("Error while getting id of newly inserted transaction: " + err.Error())
+			return errs.NewUnexpectedError("Unexpected database error")
+		}
+		transaction.TransactionId = strconv.FormatInt(id, 10)
+
+		updateHoldSql := "UPDATE holds SET status = ?, captured_amount = ?, transaction_id = ? " +
+			"WHERE hold_id = ? AND status = ? AND expiry_date > ?"
+		result, err = tx.Exec(updateHoldSql, HoldStatusCaptured, transaction.Amount, transaction.TransactionId, hold.HoldId,
+			HoldStatusActive, transaction.TransactionDate)
+		if appErr := checkHoldUpdated(result, err); appErr != nil {
+			return appErr
+		}
+
+		updateAccountSql := "UPDATE accounts SET amount = amount - ?, held_amount = held_amount - ? WHERE account_id = ?"
+		if _, err = tx.Exec(updateAccountSql, transaction.Amount, hold.Amount, hold.AccountId); err != nil {
+			logger.Error("Error while updating account for captured hold: " + err.Error())
+			return errs.NewUnexpectedError("Unexpected database error")
+		}
+
+		var appErr *errs.AppError
+		if transaction.Balance, appErr = findBalance(tx, transaction.AccountId); appErr != nil {
+			return appErr
+		}
+		return saveEvent(tx, NewTransactionPostedEvent(transaction))
+	})
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return &transaction, nil
+}
+
+// Release starts a database transaction, sets the given hold's status to the given status (released or expired),
+// frees the amount it reserved and commits the database transaction. Like Capture, it returns a conflict error
+// without doing anything if the hold is no longer active.
+func (d HoldRepositoryDb) Release(hold Hold, status string) *errs.AppError {
+	return inTransaction(d.client, "releasing hold", func(tx dbExecutor) *errs.AppError {
+		updateHoldSql := "UPDATE holds SET status = ? WHERE hold_id = ? AND status = ?"
+		result, err := tx.Exec(updateHoldSql, status, hold.HoldId, HoldStatusActive)
+		if appErr := checkHoldUpdated(result, err); appErr != nil {
+			return appErr
+		}
+
+		updateAccountSql := "UPDATE accounts SET held_amount = held_amount - ? WHERE account_id = ?"
+		if _, err = tx.Exec(updateAccountSql, hold.Amount, hold.AccountId); err != nil {
+			logger.Error("Error while updating held amount of account: " + err.Error())
+			return errs.NewUnexpectedError("Unexpected database error")
+		}
+		return nil
+	})
+}
+
+// checkHoldUpdated checks the result of updating an active hold, returning a conflict error if no active hold was
+// updated because it had meanwhile been captured, released or expired.
+func checkHoldUpdated(result sql.Result, err error) *errs.AppError {
+	if err != nil {
+		logger.Error("Error while updating status of hold: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		logger.Error("Error while getting number of holds updated: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	if rowsAffected == 0 {
+		return errs.NewConflictError("Hold is no longer active")
+	}
+	return nil
+}
@@ -0,0 +1,325 @@
+package domain
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/jmoiron/sqlx"
+	"net/http"
+	"testing"
+)
+
+// Test common variables and inputs
+var holdRepoDb HoldRepositoryDb
+
+const updateHoldsStatusSql = "UPDATE holds SET status = ? WHERE hold_id = ? AND status = ?"
+const updateAccountsHeldAmountSql = "UPDATE accounts SET held_amount = held_amount - ? WHERE account_id = ?"
+const updateHoldsCapturedSql = "UPDATE holds SET status = ?, captured_amount = ?, transaction_id = ? " +
+	"WHERE hold_id = ? AND status = ? AND expiry_date > ?"
+const updateAccountsHeldAmountIfAvailableSql = "UPDATE accounts SET held_amount = held_amount + ? WHERE account_id = ? AND " +
+	"amount - held_amount + CASE WHEN account_type = ? THEN overdraft_limit ELSE 0 END >= ?"
+const updateAccountsCapturedSql = "UPDATE accounts SET amount = amount - ?, held_amount = held_amount - ? WHERE account_id = ?"
+
+func setupHoldRepoDbTest(t *testing.T) func() {
+	teardown := setupDB(t)
+	holdRepoDb = NewHoldRepositoryDb(sqlx.NewDb(db, driverName))
+	return teardown
+}
+
+func getDummyActiveHold() Hold {
+	return Hold{HoldId: "3", AccountId: dummyAccountId, Amount: 100, Status: HoldStatusActive}
+}
+
+func TestHoldRepositoryDb_Save_returns_validationError_when_availableBalance_insufficient(t *testing.T) {
+	//Arrange
+	teardown := setupHoldRepoDbTest(t)
+	defer teardown()
+
+	hold := getDummyActiveHold()
+	mockDB.ExpectBegin()
+	mockDB.ExpectExec(updateAccountsHeldAmountIfAvailableSql).
+		WithArgs(hold.Amount, hold.AccountId, dto.AccountTypeChecking, hold.Amount).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mockDB.ExpectRollback()
+
+	//Act
+	_, err := holdRepoDb.Save(hold)
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing hold beyond available balance")
+	}
+	if err.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status code %d but got %d", http.StatusUnprocessableEntity, err.Code)
+	}
+	if err := mockDB.ExpectationsWereMet(); err != nil {
+		t.Error(err.Error())
+	}
+}
+
+func TestHoldRepositoryDb_Release_returns_conflictError_when_hold_noLongerActive(t *testing.T) {
+	//Arrange
+	teardown := setupHoldRepoDbTest(t)
+	defer teardown()
+
+	hold := getDummyActiveHold()
+	mockDB.ExpectBegin()
+	mockDB.ExpectExec(updateHoldsStatusSql).WithArgs(HoldStatusExpired, hold.HoldId, HoldStatusActive).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mockDB.ExpectRollback()
+
+	//Act
+	err := holdRepoDb.Release(hold, HoldStatusExpired)
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing releasing inactive hold")
+	}
+	if err.Code != http.StatusConflict {
+		t.Errorf("Expected status code %d but got %d", http.StatusConflict, err.Code)
+	}
+	if err := mockDB.ExpectationsWereMet(); err != nil {
+		t.Error(err.Error())
+	}
+}
+
+func TestHoldRepositoryDb_Release_freesHeldAmount_when_hold_active(t *testing.T) {
+	//Arrange
+	teardown := setupHoldRepoDbTest(t)
+	defer teardown()
+
+	hold := getDummyActiveHold()
+	mockDB.ExpectBegin()
+	mockDB.ExpectExec(updateHoldsStatusSql).WithArgs(HoldStatusReleased, hold.HoldId, HoldStatusActive).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mockDB.ExpectExec(updateAccountsHeldAmountSql).WithArgs(hold.Amount, hold.AccountId).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mockDB.ExpectCommit()
+
+	//Act
+	err := holdRepoDb.Release(hold, HoldStatusReleased)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing successful release: " + err.Message)
+	}
+	if err := mockDB.ExpectationsWereMet(); err != nil {
+		t.Error(err.Error())
+	}
+}
+
+func TestHoldRepositoryDb_Capture_takesCapturedAmount_and_freesFullHold(t *testing.T) {
+	//Arrange
+	teardown := setupHoldRepoDbTest(t)
+	defer teardown()
+
+	hold := getDummyActiveHold()
+	transaction := Transaction{AccountId: dummyAccountId, Amount: 40, TransactionType: dto.TransactionTypeHoldCapture, TransactionDate: dummyDate}
+	transaction.ActedBy(dummyCustomerId)
+
+	mockDB.ExpectBegin()
+	mockDB.ExpectExec(insertActedTransactionsSql).
+		WithArgs(transaction.AccountId, transaction.Amount, transaction.TransactionType, transaction.TransactionDate,
+			transaction.ActingCustomer).
+		WillReturnResult(sqlmock.NewResult(dummyTransactionIdAsInt, 1))
+	mockDB.ExpectExec(updateHoldsCapturedSql).
+		WithArgs(HoldStatusCaptured, transaction.Amount, dummyTransactionId, hold.HoldId, HoldStatusActive, dummyDate).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mockDB.ExpectExec(updateAccountsCapturedSql).WithArgs(transaction.Amount, hold.Amount, hold.AccountId).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mockDB.ExpectQuery(selectBalanceSql).WithArgs(dummyAccountId).
+		WillReturnRows(sqlmock.NewRows([]string{"amount"}).AddRow(dummyAmount))
+	capturedTransaction := transaction
+	capturedTransaction.TransactionId = dummyTransactionId
+	capturedTransaction.Balance = dummyAmount
+	expectEventSaved(NewTransactionPostedEvent(capturedTransaction))
+	mockDB.ExpectCommit()
+
+	//Act
+	actualTransaction, err := holdRepoDb.Capture(hold, transaction)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing successful capture: " + err.Message)
+	}
+	if actualTransaction.TransactionId != dummyTransactionId || actualTransaction.Balance != dummyAmount {
+		t.Errorf("Expected transaction %s with balance %v but got %+v", dummyTransactionId, dummyAmount, *actualTransaction)
+	}
+}
+
+// The following tests run on a real SQLite database seeded with the demo data, since whether the available balance
+// covers a hold and whether a hold is still active are only checked when the SQL is executed, which go-sqlmock never
+// does.
+
+// setupHoldRepoSqliteTest opens a new SQLite database and places a hold of 200 on account 95472 in it.
+func setupHoldRepoSqliteTest(t *testing.T) (HoldRepositoryDb, AccountRepositoryDb, *Hold) {
+	logger.MuteLogger()
+	client := openSQLiteDb(t)
+	repo := NewHoldRepositoryDb(client)
+
+	hold, err := repo.Save(NewHold(dto.NewHoldRequest{AccountId: "95472", Amount: 200, Description: "hotel"},
+		clock.StaticClock{}))
+	if err != nil {
+		t.Fatal("Expected no error but got error while saving hold: " + err.Message)
+	}
+	return repo, NewAccountRepositoryDb(client), hold
+}
+
+func TestHoldRepositoryDb_Save_reservesAmount_on_account(t *testing.T) {
+	//Arrange
+	_, accRepo, _ := setupHoldRepoSqliteTest(t)
+
+	//Act
+	account, err := accRepo.FindById("95472")
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if account.HeldAmount != 200 || account.Amount != 7000 {
+		t.Errorf("Expected held amount 200 and balance 7000 but got %v and %v", account.HeldAmount, account.Amount)
+	}
+}
+
+func TestHoldRepositoryDb_FindAll_returns_holds_of_account(t *testing.T) {
+	//Arrange
+	repo, _, hold := setupHoldRepoSqliteTest(t)
+
+	//Act
+	holds, err := repo.FindAll("95472")
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if len(holds) != 1 || holds[0] != *hold {
+		t.Errorf("Expected hold %+v but got %+v", *hold, holds)
+	}
+}
+
+func TestHoldRepositoryDb_FindExpired_returns_hold_when_expiryDate_reached(t *testing.T) {
+	//Arrange
+	repo, _, hold := setupHoldRepoSqliteTest(t)
+
+	//Act
+	expired, err := repo.FindExpired(hold.ExpiryDate)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if len(expired) != 1 {
+		t.Errorf("Expected 1 expired hold but got %d", len(expired))
+	}
+}
+
+func TestHoldRepositoryDb_FindExpired_returns_noHolds_when_expiryDate_notReached(t *testing.T) {
+	//Arrange
+	repo, _, hold := setupHoldRepoSqliteTest(t)
+
+	//Act
+	expired, err := repo.FindExpired(hold.CreationDate)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if len(expired) != 0 {
+		t.Errorf("Expected no expired holds but got %d", len(expired))
+	}
+}
+
+func TestHoldRepositoryDb_Capture_marksHold_captured_and_freesHeldAmount(t *testing.T) {
+	//Arrange
+	repo, accRepo, hold := setupHoldRepoSqliteTest(t)
+	transaction := NewTransaction(hold.AccountId, 150, dto.TransactionTypeHoldCapture, clock.StaticClock{})
+
+	//Act
+	completed, err := repo.Capture(*hold, transaction)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if completed.TransactionId == "" || completed.Balance != 6850 {
+		t.Errorf("Expected transaction id and balance 6850 but got %+v", *completed)
+	}
+	captured, err := repo.FindById(hold.HoldId)
+	if err != nil {
+		t.Fatal("Expected no error but got error while finding hold: " + err.Message)
+	}
+	if captured.Status != HoldStatusCaptured || captured.TransactionId.String != completed.TransactionId {
+		t.Errorf("Expected hold to be captured by transaction %s but got %+v", completed.TransactionId, *captured)
+	}
+	account, err := accRepo.FindById(hold.AccountId)
+	if err != nil {
+		t.Fatal("Expected no error but got error while finding account: " + err.Message)
+	}
+	if account.HeldAmount != 0 {
+		t.Errorf("Expected held amount 0 but got %v", account.HeldAmount)
+	}
+}
+
+func TestHoldRepositoryDb_Capture_returns_conflictError_when_hold_alreadyCaptured(t *testing.T) {
+	//Arrange
+	repo, _, hold := setupHoldRepoSqliteTest(t)
+	transaction := NewTransaction(hold.AccountId, 150, dto.TransactionTypeHoldCapture, clock.StaticClock{})
+	if _, err := repo.Capture(*hold, transaction); err != nil {
+		t.Fatal("Expected no error but got error while capturing hold: " + err.Message)
+	}
+
+	//Act
+	_, err := repo.Capture(*hold, transaction)
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none")
+	}
+	if err.Code != http.StatusConflict {
+		t.Errorf("Expected status code %d but got %d", http.StatusConflict, err.Code)
+	}
+}
+
+func TestHoldRepositoryDb_Release_marksHold_released_and_freesHeldAmount(t *testing.T) {
+	//Arrange
+	repo, accRepo, hold := setupHoldRepoSqliteTest(t)
+
+	//Act
+	err := repo.Release(*hold, HoldStatusReleased)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	released, err := repo.FindById(hold.HoldId)
+	if err != nil {
+		t.Fatal("Expected no error but got error while finding hold: " + err.Message)
+	}
+	account, err := accRepo.FindById(hold.AccountId)
+	if err != nil {
+		t.Fatal("Expected no error but got error while finding account: " + err.Message)
+	}
+	if released.Status != HoldStatusReleased || account.HeldAmount != 0 {
+		t.Errorf("Expected released hold and held amount 0 but got %+v and %v", *released, account.HeldAmount)
+	}
+}
+
+func TestHoldRepositoryDb_Release_returns_conflictError_when_hold_alreadyReleased(t *testing.T) {
+	//Arrange
+	repo, _, hold := setupHoldRepoSqliteTest(t)
+	if err := repo.Release(*hold, HoldStatusReleased); err != nil {
+		t.Fatal("Expected no error but got error while releasing hold: " + err.Message)
+	}
+
+	//Act
+	err := repo.Release(*hold, HoldStatusExpired)
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none")
+	}
+	if err.Code != http.StatusConflict {
+		t.Errorf("Expected status code %d but got %d", http.StatusConflict, err.Code)
+	}
+}
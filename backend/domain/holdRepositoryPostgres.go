@@ -0,0 +1,160 @@
+package domain
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/jmoiron/sqlx"
+)
+
+//Server
+
+// selectHoldsPostgresSql selects the columns of holds, formatting dates the same way MySQL returns them.
+const selectHoldsPostgresSql = "SELECT hold_id, account_id, amount, captured_amount, description, status, " +
+	"to_char(creation_date, 'YYYY-MM-DD HH24:MI:SS') AS creation_date, " +
+	"to_char(expiry_date, 'YYYY-MM-DD HH24:MI:SS') AS expiry_date, transaction_id FROM holds"
+
+type HoldRepositoryPostgres struct { //DB (adapter)
+	client dbExecutor
+}
+
+func NewHoldRepositoryPostgres(dbClient *sqlx.DB) HoldRepositoryPostgres {
+	return HoldRepositoryPostgres{dbClient}
+}
+
+// Save starts a database transaction, reserves the given hold's amount on its account, creates a new entry in the
+// database for the hold, sets its ID using the ID returned by the database and commits the database transaction. Like
+// HoldRepositoryDb.Save, it only reserves the amount if the account's available balance still covers it at the time
+// of the update, and returns a validation error without doing anything otherwise.
+func (d HoldRepositoryPostgres) Save(hold Hold) (*Hold, *errs.AppError) { //DB implements repo
+	appErr := inTransaction(d.client, "placing hold", func(tx dbExecutor) *errs.AppError {
+		updateAccountSql := "UPDATE accounts SET held_amount = held_amount + $1 WHERE account_id = $2 AND " +
+			"amount - held_amount + CASE WHEN account_type = $3 THEN overdraft_limit ELSE 0 END >= $4"
+		result, err := tx.Exec(updateAccountSql, hold.Amount, hold.AccountId, dto.AccountTypeChecking, hold.Amount)
+		if err != nil {
+			logger.Error("Error while updating held amount of account: " + err.Error())
+			return errs.NewUnexpectedError("Unexpected database error")
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			logger.Error("Error while checking whether held amount of account was updated: " + err.Error())
+			return errs.NewUnexpectedError("Unexpected database error")
+		}
+		if rowsAffected == 0 {
+			logger.Error("Amount to hold exceeds available balance")
+			return errs.NewValidationError("Available balance insufficient to hold given amount")
+		}
+
+		insertSql := "INSERT INTO holds (account_id, amount, captured_amount, description, status, creation_date, " +
+			"expiry_date) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING hold_id"
+		err = tx.Get(&hold.HoldId, insertSql, hold.AccountId, hold.Amount, hold.CapturedAmount, hold.Description,
+			hold.Status, hold.CreationDate, hold.ExpiryDate)
+		if err != nil {
+			logger.Error("Error while creating new hold: " + err.Error())
+			return errs.NewUnexpectedError("Unexpected database error")
+		}
+		return nil
+	})
+	if appErr != nil {
+		return nil, appErr
+	}
+	return &hold, nil
+}
+
+// FindById retrieves the hold with the given id.
+func (d HoldRepositoryPostgres) FindById(holdId string) (*Hold, *errs.AppError) {
+	var hold Hold
+	if err := d.client.Get(&hold, selectHoldsPostgresSql+" WHERE hold_id = $1", holdId); err != nil {
+		logger.Error("Error while retrieving hold: " + err.Error())
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.NewNotFoundError("Hold not found")
+		}
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return &hold, nil
+}
+
+// FindAll retrieves all holds placed on the account with the given id.
+func (d HoldRepositoryPostgres) FindAll(accountId string) ([]Hold, *errs.AppError) {
+	holds := make([]Hold, 0)
+	if err := d.client.Select(&holds, selectHoldsPostgresSql+" WHERE account_id = $1 ORDER BY hold_id", accountId); err != nil {
+		logger.Error("Error while retrieving holds of account: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return holds, nil
+}
+
+// FindExpired retrieves all active holds that expire at the given time or earlier.
+func (d HoldRepositoryPostgres) FindExpired(now string) ([]Hold, *errs.AppError) {
+	holds := make([]Hold, 0)
+	selectSql := selectHoldsPostgresSql + " WHERE status = $1 AND expiry_date <= $2"
+	if err := d.client.Select(&holds, selectSql, HoldStatusActive, now); err != nil {
+		logger.Error("Error while retrieving expired holds: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return holds, nil
+}
+
+// Capture starts a database transaction, makes the given bank transaction for the captured amount, marks the given
+// hold as captured, frees the whole amount it reserved, writes the event for the bank transaction to the outbox and
+// commits the database transaction. Like HoldRepositoryDb.Capture, it returns a conflict error without doing anything
+// if the hold was already captured, released or expired. Capture returns the completed bank transaction.
+func (d HoldRepositoryPostgres) Capture(hold Hold, transaction Transaction) (*Transaction, *errs.AppError) {
+	appErr := inTransaction(d.client, "capturing hold", func(tx dbExecutor) *errs.AppError {
+		addTransactionSql := "INSERT INTO transactions (account_id, amount, transaction_type, transaction_date, " +
+			"acting_customer_id) VALUES ($1, $2, $3, $4, $5) RETURNING transaction_id"
+		err := tx.Get(&transaction.TransactionId, addTransactionSql, transaction.AccountId, transaction.Amount,
+			transaction.TransactionType, transaction.TransactionDate, transaction.ActingCustomer)
+		if err != nil {
+			logger.Error("Error while creating new bank account transaction for hold: " + err.Error())
+			return errs.NewUnexpectedError("Unexpected database error")
+		}
+
+		updateHoldSql := "UPDATE holds SET status = $1, captured_amount = $2, transaction_id = $3 " +
+			"WHERE hold_id = $4 AND status = $5 AND expiry_date > $6"
+		result, err := tx.Exec(updateHoldSql, HoldStatusCaptured, transaction.Amount, transaction.TransactionId,
+			hold.HoldId, HoldStatusActive, transaction.TransactionDate)
+		if appErr := checkHoldUpdated(result, err); appErr != nil {
+			return appErr
+		}
+
+		updateAccountSql := "UPDATE accounts SET amount = amount - $1, held_amount = held_amount - $2 WHERE account_id = $3"
+		if _, err = tx.Exec(updateAccountSql, transaction.Amount, hold.Amount, hold.AccountId); err != nil {
+			logger.Error("Error while updating account for captured hold: " + err.Error())
+			return errs.NewUnexpectedError("Unexpected database error")
+		}
+
+		var appErr *errs.AppError
+		if transaction.Balance, appErr = findBalance(tx, transaction.AccountId); appErr != nil {
+			return appErr
+		}
+		return saveEvent(tx, NewTransactionPostedEvent(transaction))
+	})
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return &transaction, nil
+}
+
+// Release starts a database transaction, sets the given hold's status to the given status (released or expired),
+// frees the amount it reserved and commits the database transaction. Like Capture, it returns a conflict error
+// without doing anything if the hold is no longer active.
+func (d HoldRepositoryPostgres) Release(hold Hold, status string) *errs.AppError {
+	return inTransaction(d.client, "releasing hold", func(tx dbExecutor) *errs.AppError {
+		updateHoldSql := "UPDATE holds SET status = $1 WHERE hold_id = $2 AND status = $3"
+		result, err := tx.Exec(updateHoldSql, status, hold.HoldId, HoldStatusActive)
+		if appErr := checkHoldUpdated(result, err); appErr != nil {
+			return appErr
+		}
+
+		updateAccountSql := "UPDATE accounts SET held_amount = held_amount - $1 WHERE account_id = $2"
+		if _, err = tx.Exec(updateAccountSql, hold.Amount, hold.AccountId); err != nil {
+			logger.Error("Error while updating held amount of account: " + err.Error())
+			return errs.NewUnexpectedError("Unexpected database error")
+		}
+		return nil
+	})
+}
@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/jmoiron/sqlx"
+	"testing"
+)
+
+// Test common variables and inputs
+var holdRepoPostgres HoldRepositoryPostgres
+
+const insertHoldsPostgresSql = "INSERT INTO holds (account_id, amount, captured_amount, description, status, " +
+	"creation_date, expiry_date) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING hold_id"
+const insertActedTransactionsPostgresSql = "INSERT INTO transactions (account_id, amount, transaction_type, " +
+	"transaction_date, acting_customer_id) VALUES ($1, $2, $3, $4, $5) RETURNING transaction_id"
+
+func setupHoldRepoPostgresTest(t *testing.T) func() {
+	teardown := setupDB(t)
+	holdRepoPostgres = NewHoldRepositoryPostgres(sqlx.NewDb(db, "postgres"))
+	return teardown
+}
+
+func TestHoldRepositoryPostgres_Save_returns_newHold_with_returnedId_when_availableBalance_sufficient(t *testing.T) {
+	//Arrange
+	teardown := setupHoldRepoPostgresTest(t)
+	defer teardown()
+
+	hold := getDummyActiveHold()
+	hold.HoldId = ""
+	mockDB.ExpectBegin()
+	mockDB.ExpectExec("UPDATE accounts SET held_amount = held_amount + $1 WHERE account_id = $2 AND "+
+		"amount - held_amount + CASE WHEN account_type = $3 THEN overdraft_limit ELSE 0 END >= $4").
+		WithArgs(hold.Amount, hold.AccountId, dto.AccountTypeChecking, hold.Amount).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mockDB.ExpectQuery(insertHoldsPostgresSql).
+		WithArgs(hold.AccountId, hold.Amount, hold.CapturedAmount, hold.Description, hold.Status, hold.CreationDate,
+			hold.ExpiryDate).
+		WillReturnRows(sqlmock.NewRows([]string{"hold_id"}).AddRow(3))
+	mockDB.ExpectCommit()
+
+	//Act
+	actualHold, err := holdRepoPostgres.Save(hold)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing successful hold: " + err.Message)
+	}
+	if actualHold.HoldId != "3" {
+		t.Errorf("Expected hold ID 3 but got %s", actualHold.HoldId)
+	}
+	if err := mockDB.ExpectationsWereMet(); err != nil {
+		t.Error(err.Error())
+	}
+}
+
+func TestHoldRepositoryPostgres_Capture_takesCapturedAmount_and_freesFullHold(t *testing.T) {
+	//Arrange
+	teardown := setupHoldRepoPostgresTest(t)
+	defer teardown()
+
+	hold := getDummyActiveHold()
+	transaction := Transaction{AccountId: dummyAccountId, Amount: 40, TransactionType: dto.TransactionTypeHoldCapture, TransactionDate: dummyDate}
+	transaction.ActedBy(dummyCustomerId)
+
+	mockDB.ExpectBegin()
+	mockDB.ExpectQuery(insertActedTransactionsPostgresSql).
+		WithArgs(transaction.AccountId, transaction.Amount, transaction.TransactionType, transaction.TransactionDate,
+			transaction.ActingCustomer).
+		WillReturnRows(sqlmock.NewRows([]string{"transaction_id"}).AddRow(dummyTransactionIdAsInt))
+	mockDB.ExpectExec("UPDATE holds SET status = $1, captured_amount = $2, transaction_id = $3 "+
+		"WHERE hold_id = $4 AND status = $5 AND expiry_date > $6").
+		WithArgs(HoldStatusCaptured, transaction.Amount, dummyTransactionId, hold.HoldId, HoldStatusActive, dummyDate).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mockDB.ExpectExec("UPDATE accounts SET amount = amount - $1, held_amount = held_amount - $2 WHERE account_id = $3").
+		WithArgs(transaction.Amount, hold.Amount, hold.AccountId).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mockDB.ExpectQuery("SELECT amount FROM accounts WHERE account_id = $1").WithArgs(dummyAccountId).
+		WillReturnRows(sqlmock.NewRows([]string{"amount"}).AddRow(dummyAmount))
+	capturedTransaction := transaction
+	capturedTransaction.TransactionId = dummyTransactionId
+	capturedTransaction.Balance = dummyAmount
+	expectEventSavedPostgres(NewTransactionPostedEvent(capturedTransaction))
+	mockDB.ExpectCommit()
+
+	//Act
+	actualTransaction, err := holdRepoPostgres.Capture(hold, transaction)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing successful capture: " + err.Message)
+	}
+	if actualTransaction.TransactionId != dummyTransactionId || actualTransaction.Balance != dummyAmount {
+		t.Errorf("Expected transaction %s with balance %v but got %+v", dummyTransactionId, dummyAmount, *actualTransaction)
+	}
+	if err := mockDB.ExpectationsWereMet(); err != nil {
+		t.Error(err.Error())
+	}
+}
@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"net/http"
+	"testing"
+)
+
+func TestNewHold_setsExpiryDate(t *testing.T) {
+	//Arrange
+	tests := []struct {
+		name           string
+		expiresInHours int
+		expected       string
+	}{
+		{"default expiry", 0, "2006-01-09 15:04:05"},
+		{"given expiry", 2, "2006-01-02 17:04:05"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			request := dto.NewHoldRequest{AccountId: dummyAccountId, CustomerId: dummyCustomerId, Amount: 100, ExpiresInHours: tc.expiresInHours}
+
+			//Act
+			hold := NewHold(request, clock.StaticClock{})
+
+			//Assert
+			if hold.ExpiryDate != tc.expected {
+				t.Errorf("Expected expiry date %s but got %s", tc.expected, hold.ExpiryDate)
+			}
+			if hold.Status != HoldStatusActive {
+				t.Errorf("Expected status %s but got %s", HoldStatusActive, hold.Status)
+			}
+		})
+	}
+}
+
+func TestHold_CaptureAmount_returns_amountToCapture(t *testing.T) {
+	//Arrange
+	hold := Hold{Amount: 100}
+	tests := []struct {
+		name      string
+		requested float64
+		expected  float64
+	}{
+		{"full capture", 0, 100},
+		{"partial capture", 40, 40},
+		{"exact capture", 100, 100},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Act
+			actual, err := hold.CaptureAmount(tc.requested)
+
+			//Assert
+			if err != nil {
+				t.Fatal("Expected no error but got error: " + err.Message)
+			}
+			if actual != tc.expected {
+				t.Errorf("Expected amount %v but got %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestHold_CaptureAmount_returns_error_when_requested_moreThanHeld(t *testing.T) {
+	_, err := Hold{Amount: 100}.CaptureAmount(100.01)
+
+	if err == nil {
+		t.Fatal("Expected error but got none while testing capturing more than held")
+	}
+	if err.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status code %d but got %d", http.StatusUnprocessableEntity, err.Code)
+	}
+}
@@ -0,0 +1,132 @@
+package domain
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/jmoiron/sqlx"
+	"strconv"
+)
+
+//Server
+
+type ImportRepositoryDb struct { //DB (adapter)
+	client dbExecutor
+}
+
+func NewImportRepositoryDb(dbClient *sqlx.DB) ImportRepositoryDb {
+	return ImportRepositoryDb{dbClient}
+}
+
+// SaveBatch creates a new entry in the database for the given batch, sets its ID using the database-generated ID and
+// returns the batch.
+func (d ImportRepositoryDb) SaveBatch(batch ImportBatch) (*ImportBatch, *errs.AppError) { //DB implements repo
+	insertSql := "INSERT INTO import_batches (mode, status, checkpoint, creation_date, update_date) VALUES (?, ?, ?, ?, ?)"
+	result, err := d.client.Exec(insertSql, batch.Mode, batch.Status, batch.Checkpoint, batch.CreationDate,
+		batch.UpdateDate)
+	if err != nil {
+		logger.Error("Error while creating new import batch: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		logger.Error("Error while getting id of newly inserted import batch: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	batch.BatchId = strconv.FormatInt(id, 10)
+
+	return &batch, nil
+}
+
+// FindBatchById retrieves the import batch with the given id.
+func (d ImportRepositoryDb) FindBatchById(id string) (*ImportBatch, *errs.AppError) { //DB implements repo
+	return findImportBatch(d.client, "SELECT * FROM import_batches", id)
+}
+
+// UpdateBatch sets the status, checkpoint and update date of the given batch.
+func (d ImportRepositoryDb) UpdateBatch(batch ImportBatch) *errs.AppError { //DB implements repo
+	return updateImportBatch(d.client, batch)
+}
+
+// SaveRowResult creates a new entry in the database for the given row result. Since a row can only have one result,
+// this fails if the row already has one.
+func (d ImportRepositoryDb) SaveRowResult(result ImportRowResult) *errs.AppError { //DB implements repo
+	return saveImportRowResult(d.client, result)
+}
+
+// FindRowResults retrieves the results of the rows of the given batch after the given row, in row order.
+func (d ImportRepositoryDb) FindRowResults(batchId string, afterRow int) ([]ImportRowResult, *errs.AppError) { //DB implements repo
+	return findImportRowResults(d.client, "SELECT * FROM import_rows", batchId, afterRow)
+}
+
+// CountRowResults counts the results of the rows of the given batch by status.
+func (d ImportRepositoryDb) CountRowResults(batchId string) (map[string]int, *errs.AppError) { //DB implements repo
+	return countImportRowResults(d.client, batchId)
+}
+
+// findImportBatch retrieves the import batch with the given id with the given select statement.
+func findImportBatch(client dbExecutor, selectSql string, id string) (*ImportBatch, *errs.AppError) {
+	var batch ImportBatch
+	if err := client.Get(&batch, client.Rebind(selectSql+" WHERE batch_id = ?"), id); err != nil {
+		logger.Error("Error while retrieving import batch: " + err.Error())
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.NewNotFoundError("Import batch not found")
+		}
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return &batch, nil
+}
+
+func updateImportBatch(client dbExecutor, batch ImportBatch) *errs.AppError {
+	updateSql := "UPDATE import_batches SET status = ?, checkpoint = ?, update_date = ? WHERE batch_id = ?"
+	if _, err := client.Exec(client.Rebind(updateSql), batch.Status, batch.Checkpoint, batch.UpdateDate,
+		batch.BatchId); err != nil {
+		logger.Error("Error while updating import batch: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	return nil
+}
+
+func saveImportRowResult(client dbExecutor, result ImportRowResult) *errs.AppError {
+	insertSql := "INSERT INTO import_rows (batch_id, row_no, account_id, amount, transaction_type, status, " +
+		"transaction_id, message, creation_date) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)"
+	_, err := client.Exec(client.Rebind(insertSql), result.BatchId, result.RowNumber, result.AccountId, result.Amount,
+		result.TransactionType, result.Status, result.TransactionId, result.Message, result.CreationDate)
+	if err != nil {
+		logger.Error("Error while saving result of import row: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	return nil
+}
+
+// findImportRowResults retrieves the results of the rows of the given batch after the given row with the given select
+// statement, in row order.
+func findImportRowResults(client dbExecutor, selectSql string, batchId string, afterRow int) ([]ImportRowResult, *errs.AppError) {
+	results := make([]ImportRowResult, 0)
+	selectSql += " WHERE batch_id = ? AND row_no > ? ORDER BY row_no"
+	if err := client.Select(&results, client.Rebind(selectSql), batchId, afterRow); err != nil {
+		logger.Error("Error while retrieving results of import rows: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return results, nil
+}
+
+func countImportRowResults(client dbExecutor, batchId string) (map[string]int, *errs.AppError) {
+	var counts []struct {
+		Status string `db:"status"`
+		Count  int    `db:"count"`
+	}
+	selectSql := "SELECT status, COUNT(*) AS count FROM import_rows WHERE batch_id = ? GROUP BY status"
+	if err := client.Select(&counts, client.Rebind(selectSql), batchId); err != nil {
+		logger.Error("Error while counting results of import rows: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	countsByStatus := make(map[string]int)
+	for _, c := range counts {
+		countsByStatus[c.Status] = c.Count
+	}
+	return countsByStatus, nil
+}
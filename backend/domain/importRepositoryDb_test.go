@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"net/http"
+	"testing"
+)
+
+// These tests run on a real SQLite database seeded with the demo data, since the uniqueness of row results and their
+// counts are only enforced and worked out when the SQL is executed, which go-sqlmock never does.
+
+var importRepoDb ImportRepositoryDb
+
+// setupImportRepoDbTest opens a new SQLite database and saves a batch in it with a posted row 2 and a rejected row 3.
+func setupImportRepoDbTest(t *testing.T) (*ImportBatch, dto.ImportRow) {
+	logger.MuteLogger()
+	importRepoDb = NewImportRepositoryDb(openSQLiteDb(t))
+	clk := clock.StaticClock{}
+
+	batch, err := importRepoDb.SaveBatch(NewImportBatch(ImportModeBestEffort, clk))
+	if err != nil {
+		t.Fatal("Expected no error but got error while saving import batch: " + err.Message)
+	}
+	row := dto.ImportRow{RowNumber: 2, Request: dto.TransactionRequest{AccountId: "95470", Amount: 100,
+		TransactionType: dto.TransactionTypeDeposit, CustomerId: "2000"}}
+	err = importRepoDb.SaveRowResult(NewImportRowResult(batch.BatchId, row, ImportRowStatusPosted, "1", "", clk))
+	if err != nil {
+		t.Fatal("Expected no error but got error while saving row result: " + err.Message)
+	}
+	row.RowNumber = 3
+	err = importRepoDb.SaveRowResult(NewImportRowResult(batch.BatchId, row, ImportRowStatusRejected, "",
+		"Account not found", clk))
+	if err != nil {
+		t.Fatal("Expected no error but got error while saving row result: " + err.Message)
+	}
+	return batch, row
+}
+
+func TestImportRepositoryDb_SaveRowResult_returns_error_when_row_alreadyHasResult(t *testing.T) {
+	//Arrange
+	batch, row := setupImportRepoDbTest(t)
+
+	//Act
+	err := importRepoDb.SaveRowResult(NewImportRowResult(batch.BatchId, row, ImportRowStatusPosted, "2", "",
+		clock.StaticClock{}))
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none")
+	}
+	if err.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status code %d but got %d", http.StatusInternalServerError, err.Code)
+	}
+}
+
+func TestImportRepositoryDb_FindRowResults_returns_results_after_givenRow(t *testing.T) {
+	//Arrange
+	batch, _ := setupImportRepoDbTest(t)
+
+	//Act
+	results, err := importRepoDb.FindRowResults(batch.BatchId, 2)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if len(results) != 1 || results[0].RowNumber != 3 || results[0].TransactionId.Valid {
+		t.Errorf("Expected rejected row 3 but got %+v", results)
+	}
+}
+
+func TestImportRepositoryDb_CountRowResults_counts_results_by_status(t *testing.T) {
+	//Arrange
+	batch, _ := setupImportRepoDbTest(t)
+
+	//Act
+	counts, err := importRepoDb.CountRowResults(batch.BatchId)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if counts[ImportRowStatusPosted] != 1 || counts[ImportRowStatusRejected] != 1 {
+		t.Errorf("Expected counts of 1 but got %v", counts)
+	}
+}
+
+func TestImportRepositoryDb_UpdateBatch_saves_status_and_checkpoint(t *testing.T) {
+	//Arrange
+	batch, _ := setupImportRepoDbTest(t)
+	batch.Status, batch.Checkpoint = ImportBatchStatusCompleted, 3
+
+	//Act
+	err := importRepoDb.UpdateBatch(*batch)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	found, err := importRepoDb.FindBatchById(batch.BatchId)
+	if err != nil {
+		t.Fatal("Expected no error but got error while finding batch: " + err.Message)
+	}
+	if *found != *batch {
+		t.Errorf("Expected batch %+v but got %+v", *batch, *found)
+	}
+}
@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/jmoiron/sqlx"
+)
+
+//Server
+
+// selectImportBatchesPostgresSql selects the columns of import batches, formatting dates the same way MySQL returns
+// them.
+const selectImportBatchesPostgresSql = "SELECT batch_id, mode, status, checkpoint, " +
+	"to_char(creation_date, 'YYYY-MM-DD HH24:MI:SS') AS creation_date, " +
+	"to_char(update_date, 'YYYY-MM-DD HH24:MI:SS') AS update_date FROM import_batches"
+
+// selectImportRowsPostgresSql selects the columns of import row results, formatting dates the same way MySQL returns
+// them.
+const selectImportRowsPostgresSql = "SELECT batch_id, row_no, account_id, amount, transaction_type, status, " +
+	"transaction_id, message, to_char(creation_date, 'YYYY-MM-DD HH24:MI:SS') AS creation_date FROM import_rows"
+
+type ImportRepositoryPostgres struct { //DB (adapter)
+	client dbExecutor
+}
+
+func NewImportRepositoryPostgres(dbClient *sqlx.DB) ImportRepositoryPostgres {
+	return ImportRepositoryPostgres{dbClient}
+}
+
+// SaveBatch creates a new entry in the database for the given batch, sets its ID using the ID returned by the
+// database and returns the batch.
+func (d ImportRepositoryPostgres) SaveBatch(batch ImportBatch) (*ImportBatch, *errs.AppError) { //DB implements repo
+	insertSql := "INSERT INTO import_batches (mode, status, checkpoint, creation_date, update_date) " +
+		"VALUES ($1, $2, $3, $4, $5) RETURNING batch_id"
+	err := d.client.Get(&batch.BatchId, insertSql, batch.Mode, batch.Status, batch.Checkpoint, batch.CreationDate,
+		batch.UpdateDate)
+	if err != nil {
+		logger.Error("Error while creating new import batch: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return &batch, nil
+}
+
+// FindBatchById retrieves the import batch with the given id.
+func (d ImportRepositoryPostgres) FindBatchById(id string) (*ImportBatch, *errs.AppError) { //DB implements repo
+	return findImportBatch(d.client, selectImportBatchesPostgresSql, id)
+}
+
+// UpdateBatch sets the status, checkpoint and update date of the given batch.
+func (d ImportRepositoryPostgres) UpdateBatch(batch ImportBatch) *errs.AppError { //DB implements repo
+	return updateImportBatch(d.client, batch)
+}
+
+// SaveRowResult creates a new entry in the database for the given row result. Since a row can only have one result,
+// this fails if the row already has one.
+func (d ImportRepositoryPostgres) SaveRowResult(result ImportRowResult) *errs.AppError { //DB implements repo
+	return saveImportRowResult(d.client, result)
+}
+
+// FindRowResults retrieves the results of the rows of the given batch after the given row, in row order.
+func (d ImportRepositoryPostgres) FindRowResults(batchId string, afterRow int) ([]ImportRowResult, *errs.AppError) { //DB implements repo
+	return findImportRowResults(d.client, selectImportRowsPostgresSql, batchId, afterRow)
+}
+
+// CountRowResults counts the results of the rows of the given batch by status.
+func (d ImportRepositoryPostgres) CountRowResults(batchId string) (map[string]int, *errs.AppError) { //DB implements repo
+	return countImportRowResults(d.client, batchId)
+}
@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/jmoiron/sqlx"
+	"testing"
+)
+
+// Test common variables and inputs
+var importRepoPostgres ImportRepositoryPostgres
+
+func setupImportRepoPostgresTest(t *testing.T) func() {
+	teardown := setupDB(t)
+	importRepoPostgres = NewImportRepositoryPostgres(sqlx.NewDb(db, "postgres"))
+	return teardown
+}
+
+func TestImportRepositoryPostgres_SaveBatch_returns_newBatch_with_returnedId(t *testing.T) {
+	//Arrange
+	teardown := setupImportRepoPostgresTest(t)
+	defer teardown()
+
+	batch := NewImportBatch(ImportModeBestEffort, clock.StaticClock{})
+	mockDB.ExpectQuery("INSERT INTO import_batches (mode, status, checkpoint, creation_date, update_date) "+
+		"VALUES ($1, $2, $3, $4, $5) RETURNING batch_id").
+		WithArgs(batch.Mode, batch.Status, batch.Checkpoint, batch.CreationDate, batch.UpdateDate).
+		WillReturnRows(sqlmock.NewRows([]string{"batch_id"}).AddRow(8))
+
+	//Act
+	saved, err := importRepoPostgres.SaveBatch(batch)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if saved.BatchId != "8" {
+		t.Errorf("Expected batch ID 8 but got %s", saved.BatchId)
+	}
+}
+
+func TestImportRepositoryPostgres_FindRowResults_returns_rows_afterGivenRow(t *testing.T) {
+	//Arrange
+	teardown := setupImportRepoPostgresTest(t)
+	defer teardown()
+
+	rows := sqlmock.NewRows([]string{"batch_id", "row_no", "status", "creation_date"}).
+		AddRow(8, 3, ImportRowStatusPosted, dummyDate)
+	mockDB.ExpectQuery(selectImportRowsPostgresSql+" WHERE batch_id = $1 AND row_no > $2 ORDER BY row_no").
+		WithArgs("8", 2).
+		WillReturnRows(rows)
+
+	//Act
+	results, err := importRepoPostgres.FindRowResults("8", 2)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if len(results) != 1 || results[0].RowNumber != 3 || results[0].CreationDate != dummyDate {
+		t.Errorf("Expected row 3 imported on %s but got %+v", dummyDate, results)
+	}
+}
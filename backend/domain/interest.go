@@ -0,0 +1,153 @@
+package domain
+
+import (
+	"encoding/json"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"math"
+	"os"
+	"sort"
+	"time"
+)
+
+//Business Domain
+
+const FormatDate = "2006-01-02"
+const FormatPeriod = "2006-01"
+const DaysInYear float64 = 365
+
+// InterestTier is the annual rate earned by accounts of the given type whose balance is at least MinBalance. The
+// minimum balance is compared with the balance in the account's own currency, so the same tiers apply to every
+// currency.
+type InterestTier struct { //business/domain object
+	AccountType string  `json:"account_type"`
+	MinBalance  float64 `json:"min_balance"`
+	AnnualRate  float64 `json:"annual_rate"`
+}
+
+type InterestRates []InterestTier
+
+// DefaultInterestRates returns the rates used when no rates file is configured. Only saving accounts earn interest.
+func DefaultInterestRates() InterestRates {
+	return InterestRates{
+		{AccountType: dto.AccountTypeSaving, MinBalance: 0, AnnualRate: 0.005},
+		{AccountType: dto.AccountTypeSaving, MinBalance: 10000, AnnualRate: 0.01},
+		{AccountType: dto.AccountTypeSaving, MinBalance: 50000, AnnualRate: 0.015},
+		{AccountType: dto.AccountTypeChecking, MinBalance: 0, AnnualRate: 0},
+	}
+}
+
+// LoadInterestRates reads the interest rate tiers from the JSON file at the given path. The file should contain
+// an array of objects with the keys "account_type", "min_balance" and "annual_rate".
+func LoadInterestRates(path string) (InterestRates, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var rates InterestRates
+	if err = json.Unmarshal(data, &rates); err != nil {
+		return nil, err
+	}
+	return rates, nil
+}
+
+// AnnualRateFor returns the annual rate of the highest tier for the given account type that the given balance
+// qualifies for. It returns 0 if there is no such tier or if the balance is not positive.
+func (r InterestRates) AnnualRateFor(accountType string, balance float64) float64 {
+	if balance <= 0 {
+		return 0
+	}
+
+	tiers := make([]InterestTier, 0)
+	for _, tier := range r {
+		if tier.AccountType == accountType {
+			tiers = append(tiers, tier)
+		}
+	}
+	sort.Slice(tiers, func(i, j int) bool { return tiers[i].MinBalance > tiers[j].MinBalance })
+
+	for _, tier := range tiers {
+		if balance >= tier.MinBalance {
+			return tier.AnnualRate
+		}
+	}
+	return 0
+}
+
+type OverdraftCharges struct { //business/domain object
+	AnnualRate float64
+	MonthlyFee float64
+}
+
+// DefaultOverdraftCharges returns the charges used when no overdraft charges are configured. The monthly fee is
+// charged in the currency of the account.
+func DefaultOverdraftCharges() OverdraftCharges {
+	return OverdraftCharges{AnnualRate: 0.18, MonthlyFee: 10}
+}
+
+type InterestAccrual struct { //business/domain object
+	AccountId   string  `db:"account_id"`
+	AccrualDate string  `db:"accrual_date"`
+	AccrualType string  `db:"accrual_type"`
+	Balance     float64 `db:"balance"`
+	AnnualRate  float64 `db:"annual_rate"`
+	Amount      float64 `db:"amount"`
+}
+
+// NewInterestAccrual calculates one day's worth of interest on the given end-of-day balance at the given annual rate.
+// The amount is not rounded so that rounding only happens once, when the month's accruals are posted.
+func NewInterestAccrual(accountId string, date time.Time, balance float64, annualRate float64) InterestAccrual {
+	return InterestAccrual{
+		AccountId:   accountId,
+		AccrualDate: date.Format(FormatDate),
+		AccrualType: dto.TransactionTypeInterest,
+		Balance:     balance,
+		AnnualRate:  annualRate,
+		Amount:      balance * annualRate / DaysInYear,
+	}
+}
+
+// NewOverdraftInterestAccrual calculates one day's worth of overdraft interest to be charged on the given negative
+// end-of-day balance at the given annual rate. The amount is positive since it is the amount to be charged.
+func NewOverdraftInterestAccrual(accountId string, date time.Time, balance float64, annualRate float64) InterestAccrual {
+	return InterestAccrual{
+		AccountId:   accountId,
+		AccrualDate: date.Format(FormatDate),
+		AccrualType: dto.TransactionTypeOverdraftInterest,
+		Balance:     balance,
+		AnnualRate:  annualRate,
+		Amount:      -balance * annualRate / DaysInYear,
+	}
+}
+
+type InterestPosting struct { //business/domain object
+	AccountId     string  `db:"account_id"`
+	Period        string  `db:"period"`
+	PostingType   string  `db:"posting_type"` //the type of transaction made to post the amount
+	Amount        float64 `db:"amount"`
+	TransactionId string  `db:"transaction_id"`
+	ClaimedOn     string  `db:"claimed_on"`
+}
+
+// RoundedAmount returns the amount to be posted, rounded to the nearest cent.
+func (p InterestPosting) RoundedAmount() float64 {
+	return math.Round(p.Amount*100) / 100
+}
+
+// IsLastDayOfMonth checks whether the given date is the last day of its month, which is when interest is posted.
+func IsLastDayOfMonth(date time.Time) bool {
+	return date.AddDate(0, 0, 1).Month() != date.Month()
+}
+
+//Server
+
+//go:generate mockgen -destination=../mocks/domain/mock_interestRepository.go -package=domain github.com/aliciatay-zls/banking/backend/domain InterestRepository
+type InterestRepository interface { //repo (secondary port)
+	FindEndOfDayBalances(string) ([]Account, *errs.AppError)
+	SaveAccrual(InterestAccrual) (bool, *errs.AppError)
+	FindLastAccrualDate() (string, *errs.AppError)
+	FindAccrualTotals(string) ([]InterestPosting, *errs.AppError)
+	ClaimPosting(InterestPosting) (bool, *errs.AppError)
+	CompletePosting(InterestPosting) *errs.AppError
+}
@@ -0,0 +1,157 @@
+package domain
+
+import (
+	"database/sql"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/jmoiron/sqlx"
+	"time"
+)
+
+//Server
+
+type InterestRepositoryDb struct { //DB (adapter)
+	client dbExecutor
+}
+
+func NewInterestRepositoryDb(dbClient *sqlx.DB) InterestRepositoryDb {
+	return InterestRepositoryDb{dbClient}
+}
+
+// FindEndOfDayBalances retrieves all active accounts opened on or before the given date, with each account's amount
+// set to its balance at the end of that date. This is done by taking the net amount of any transactions made after
+// that date off the current balance, so that the balances are correct even if the job runs late.
+func (d InterestRepositoryDb) FindEndOfDayBalances(date string) ([]Account, *errs.AppError) { //DB implements repo
+	return findEndOfDayBalances(d.client, "SELECT * FROM accounts", date)
+}
+
+// findEndOfDayBalances retrieves the active accounts opened by the end of the given date with the given select
+// statement, and sets the amount of each to its balance at the end of that date. The database sums up the transactions
+// made after that date per account, so that only one row per account is read however many transactions there are.
+func findEndOfDayBalances(client dbExecutor, selectSql string, date string) ([]Account, *errs.AppError) {
+	endOfDay := date + " 23:59:59"
+
+	accounts := make([]Account, 0)
+	selectSql += " WHERE status = 1 AND opening_date <= ?"
+	if err := client.Select(&accounts, client.Rebind(selectSql), endOfDay); err != nil {
+		logger.Error("Error while retrieving accounts for interest accrual: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	sumSql, args, err := sqlx.In("SELECT account_id, SUM(CASE WHEN transaction_type IN (?) THEN -amount ELSE amount END) "+
+		"AS amount FROM transactions WHERE transaction_date > ? GROUP BY account_id", debitTransactionTypes, endOfDay)
+	if err != nil {
+		logger.Error("Error while building query for transactions made after end of day: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected server error")
+	}
+	var netAmounts []struct {
+		AccountId string  `db:"account_id"`
+		Amount    float64 `db:"amount"`
+	}
+	if err = client.Select(&netAmounts, client.Rebind(sumSql), args...); err != nil {
+		logger.Error("Error while summing up transactions made after end of day: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	netAmountsByAccount := make(map[string]float64, len(netAmounts))
+	for _, n := range netAmounts {
+		netAmountsByAccount[n.AccountId] = n.Amount
+	}
+	for k := range accounts {
+		accounts[k].Amount -= netAmountsByAccount[accounts[k].AccountId]
+	}
+	return accounts, nil
+}
+
+// SaveAccrual creates a new entry in the database for the given accrual. It returns false without doing anything if
+// the same type of interest was already accrued for the same account and date.
+func (d InterestRepositoryDb) SaveAccrual(accrual InterestAccrual) (bool, *errs.AppError) {
+	var count int
+	countSql := "SELECT COUNT(*) FROM interest_accruals WHERE account_id = ? AND accrual_date = ? AND accrual_type = ?"
+	if err := d.client.Get(&count, countSql, accrual.AccountId, accrual.AccrualDate, accrual.AccrualType); err != nil {
+		logger.Error("Error while checking for existing interest accrual: " + err.Error())
+		return false, errs.NewUnexpectedError("Unexpected database error")
+	}
+	if count > 0 {
+		return false, nil
+	}
+
+	insertSql := "INSERT INTO interest_accruals (account_id, accrual_date, accrual_type, balance, annual_rate, amount) VALUES (?, ?, ?, ?, ?, ?)"
+	_, err := d.client.Exec(insertSql,
+		accrual.AccountId, accrual.AccrualDate, accrual.AccrualType, accrual.Balance, accrual.AnnualRate, accrual.Amount)
+	if err != nil {
+		logger.Error("Error while creating new interest accrual: " + err.Error())
+		return false, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return true, nil
+}
+
+// FindLastAccrualDate retrieves the latest date for which interest was accrued, or an empty string if no interest was
+// ever accrued.
+func (d InterestRepositoryDb) FindLastAccrualDate() (string, *errs.AppError) {
+	var date sql.NullString
+	if err := d.client.Get(&date, "SELECT MAX(accrual_date) FROM interest_accruals"); err != nil {
+		logger.Error("Error while retrieving last interest accrual date: " + err.Error())
+		return "", errs.NewUnexpectedError("Unexpected database error")
+	}
+	return date.String, nil
+}
+
+// FindAccrualTotals sums up, per account and type of interest, the interest accrued during the given period (a month
+// in the form "2006-01"). Totals that were already posted are included, since ClaimPosting guards against posting
+// them twice.
+func (d InterestRepositoryDb) FindAccrualTotals(period string) ([]InterestPosting, *errs.AppError) {
+	start, err := time.Parse(FormatPeriod, period)
+	if err != nil {
+		logger.Error("Error while parsing interest period: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected server error")
+	}
+	end := start.AddDate(0, 1, -1)
+
+	postings := make([]InterestPosting, 0)
+	selectSql := "SELECT account_id, accrual_type AS posting_type, SUM(amount) AS amount FROM interest_accruals " +
+		"WHERE accrual_date BETWEEN ? AND ? GROUP BY account_id, accrual_type"
+	if err = d.client.Select(&postings, selectSql, start.Format(FormatDate), end.Format(FormatDate)); err != nil {
+		logger.Error("Error while summing up interest accruals: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	for k := range postings {
+		postings[k].Period = period
+	}
+	return postings, nil
+}
+
+// ClaimPosting records that the given period's interest or charge is about to be posted to the given account, before
+// the transaction is made. It returns false without doing anything if the posting was already claimed, so that the
+// same period's interest or charge is never posted twice.
+func (d InterestRepositoryDb) ClaimPosting(posting InterestPosting) (bool, *errs.AppError) {
+	insertSql := "INSERT INTO interest_postings (account_id, period, posting_type, amount, claimed_on) VALUES (?, ?, ?, ?, ?)"
+	_, err := d.client.Exec(insertSql,
+		posting.AccountId, posting.Period, posting.PostingType, posting.RoundedAmount(), posting.ClaimedOn)
+	if err != nil {
+		if isDuplicateKey(err) {
+			return false, nil
+		}
+		logger.Error("Error while creating new interest posting: " + err.Error())
+		return false, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return true, nil
+}
+
+// CompletePosting links a claimed posting to the transaction that was made for it.
+func (d InterestRepositoryDb) CompletePosting(posting InterestPosting) *errs.AppError {
+	return completeInterestPosting(d.client, posting)
+}
+
+// completeInterestPosting links a claimed posting to the transaction that was made for it, using the given client.
+func completeInterestPosting(client dbExecutor, posting InterestPosting) *errs.AppError {
+	updateSql := "UPDATE interest_postings SET transaction_id = ? WHERE account_id = ? AND period = ? AND posting_type = ?"
+	_, err := client.Exec(client.Rebind(updateSql), posting.TransactionId, posting.AccountId, posting.Period, posting.PostingType)
+	if err != nil {
+		logger.Error("Error while completing interest posting: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	return nil
+}
@@ -0,0 +1,358 @@
+package domain
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	"testing"
+	"time"
+)
+
+// Test common variables and inputs
+var interestRepoDb InterestRepositoryDb
+
+const dummyAccrualDate = "2006-01-02"
+const dummyEndOfDay = "2006-01-02 23:59:59"
+const dummyPeriod = "2006-01"
+
+const selectAccountsOpenedBySql = "SELECT * FROM accounts WHERE status = 1 AND opening_date <= ?"
+const sumTransactionsAfterSql = "SELECT account_id, SUM(CASE WHEN transaction_type IN (?, ?, ?, ?, ?, ?) THEN -amount " +
+	"ELSE amount END) AS amount FROM transactions WHERE transaction_date > ? GROUP BY account_id"
+const countAccrualsSql = "SELECT COUNT(*) FROM interest_accruals WHERE account_id = ? AND accrual_date = ? AND accrual_type = ?"
+const insertAccrualsSql = "INSERT INTO interest_accruals (account_id, accrual_date, accrual_type, balance, annual_rate, amount) VALUES (?, ?, ?, ?, ?, ?)"
+const selectLastAccrualDateSql = "SELECT MAX(accrual_date) FROM interest_accruals"
+const insertPostingsSql = "INSERT INTO interest_postings (account_id, period, posting_type, amount, claimed_on) VALUES (?, ?, ?, ?, ?)"
+const completePostingSql = "UPDATE interest_postings SET transaction_id = ? WHERE account_id = ? AND period = ? AND posting_type = ?"
+
+const dummyClaimedOn = "2006-02-01 00:00:00"
+
+var dummyDuplicateKeyErr = &mysql.MySQLError{Number: 1062, Message: "Duplicate entry"}
+
+func setupInterestRepoDbTest(t *testing.T) func() {
+	teardown := setupDB(t)
+	interestRepoDb = NewInterestRepositoryDb(sqlx.NewDb(db, driverName))
+	return teardown
+}
+
+func TestInterestRepositoryDb_FindEndOfDayBalances_takesOff_netAmountOfTransactionsMadeAfterEndOfDay(t *testing.T) {
+	//Arrange
+	teardown := setupInterestRepoDbTest(t)
+	defer teardown()
+
+	dummyAccount := getDefaultAccountAfterSave()
+	accountRows := sqlmock.NewRows(accountsTableColumns).
+		AddRow(dummyAccount.AccountId, dummyAccount.CustomerId, dummyAccount.OpeningDate, dummyAccount.AccountType, dummyAccount.Currency, dummyAccount.Amount, dummyAccount.Status)
+	mockDB.ExpectQuery(selectAccountsOpenedBySql).WithArgs(dummyEndOfDay).WillReturnRows(accountRows)
+
+	netAmountRows := sqlmock.NewRows([]string{"account_id", "amount"}).
+		AddRow(dummyAccountId, 700).
+		AddRow("1980", 500)
+	mockDB.ExpectQuery(sumTransactionsAfterSql).
+		WithArgs(dto.TransactionTypeWithdrawal, dto.TransactionTypeTransferOut, dto.TransactionTypeHoldCapture,
+			dto.TransactionTypeReversalDebit, dto.TransactionTypeOverdraftInterest, dto.TransactionTypeFee, dummyEndOfDay).
+		WillReturnRows(netAmountRows)
+
+	expectedBalance := dummyAmount - 700
+
+	//Act
+	actualAccounts, err := interestRepoDb.FindEndOfDayBalances(dummyAccrualDate)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing successful select: " + err.Message)
+	}
+	if len(actualAccounts) != 1 {
+		t.Fatalf("Expected 1 account to be retrieved but got %d accounts", len(actualAccounts))
+	}
+	if actualAccounts[0].Amount != expectedBalance {
+		t.Errorf("Expected end-of-day balance %v but got %v", expectedBalance, actualAccounts[0].Amount)
+	}
+}
+
+func TestInterestRepositoryDb_SaveAccrual_returns_false_when_accrual_alreadyExists(t *testing.T) {
+	//Arrange
+	teardown := setupInterestRepoDbTest(t)
+	defer teardown()
+
+	dummyAccrual := InterestAccrual{AccountId: dummyAccountId, AccrualDate: dummyAccrualDate, AccrualType: dto.TransactionTypeInterest, Amount: 1}
+	mockDB.ExpectQuery(countAccrualsSql).
+		WithArgs(dummyAccrual.AccountId, dummyAccrual.AccrualDate, dummyAccrual.AccrualType).
+		WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(1))
+
+	//Act
+	isSaved, err := interestRepoDb.SaveAccrual(dummyAccrual)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing existing accrual: " + err.Message)
+	}
+	if isSaved {
+		t.Error("Expected existing accrual to not be saved again but it was")
+	}
+	if err := mockDB.ExpectationsWereMet(); err != nil {
+		t.Error(err.Error())
+	}
+}
+
+func TestInterestRepositoryDb_SaveAccrual_returns_true_when_insertAccruals_succeeds(t *testing.T) {
+	//Arrange
+	teardown := setupInterestRepoDbTest(t)
+	defer teardown()
+
+	dummyAccrual := InterestAccrual{AccountId: dummyAccountId, AccrualDate: dummyAccrualDate, AccrualType: dto.TransactionTypeInterest,
+		Balance: 36500, AnnualRate: 0.01, Amount: 1}
+	mockDB.ExpectQuery(countAccrualsSql).
+		WithArgs(dummyAccrual.AccountId, dummyAccrual.AccrualDate, dummyAccrual.AccrualType).
+		WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(0))
+	mockDB.ExpectExec(insertAccrualsSql).
+		WithArgs(dummyAccrual.AccountId, dummyAccrual.AccrualDate, dummyAccrual.AccrualType, dummyAccrual.Balance, dummyAccrual.AnnualRate, dummyAccrual.Amount).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	//Act
+	isSaved, err := interestRepoDb.SaveAccrual(dummyAccrual)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing new accrual: " + err.Message)
+	}
+	if !isSaved {
+		t.Error("Expected new accrual to be saved but it was not")
+	}
+}
+
+func TestInterestRepositoryDb_FindLastAccrualDate_returns_emptyString_when_noAccruals(t *testing.T) {
+	//Arrange
+	teardown := setupInterestRepoDbTest(t)
+	defer teardown()
+
+	mockDB.ExpectQuery(selectLastAccrualDateSql).WillReturnRows(sqlmock.NewRows([]string{"MAX(accrual_date)"}).AddRow(nil))
+
+	//Act
+	date, err := interestRepoDb.FindLastAccrualDate()
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if date != "" {
+		t.Errorf("Expected no date but got %s", date)
+	}
+}
+
+func TestInterestRepositoryDb_ClaimPosting_returns_false_when_posting_alreadyClaimed(t *testing.T) {
+	//Arrange
+	teardown := setupInterestRepoDbTest(t)
+	defer teardown()
+
+	dummyPosting := InterestPosting{AccountId: dummyAccountId, Period: dummyPeriod, PostingType: dto.TransactionTypeInterest,
+		Amount: 10, ClaimedOn: dummyClaimedOn}
+	mockDB.ExpectExec(insertPostingsSql).
+		WithArgs(dummyPosting.AccountId, dummyPosting.Period, dummyPosting.PostingType, 10.0, dummyClaimedOn).
+		WillReturnError(dummyDuplicateKeyErr)
+
+	//Act
+	isClaimed, err := interestRepoDb.ClaimPosting(dummyPosting)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing claimed posting: " + err.Message)
+	}
+	if isClaimed {
+		t.Error("Expected posting to not be claimed twice but it was")
+	}
+	if err := mockDB.ExpectationsWereMet(); err != nil {
+		t.Error(err.Error())
+	}
+}
+
+func TestInterestRepositoryDb_ClaimPosting_returns_true_when_insertPostings_succeeds(t *testing.T) {
+	//Arrange
+	teardown := setupInterestRepoDbTest(t)
+	defer teardown()
+
+	dummyPosting := InterestPosting{AccountId: dummyAccountId, Period: dummyPeriod, PostingType: dto.TransactionTypeInterest,
+		Amount: 10.004, ClaimedOn: dummyClaimedOn}
+	mockDB.ExpectExec(insertPostingsSql).
+		WithArgs(dummyPosting.AccountId, dummyPosting.Period, dummyPosting.PostingType, 10.0, dummyClaimedOn).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	//Act
+	isClaimed, err := interestRepoDb.ClaimPosting(dummyPosting)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing new posting: " + err.Message)
+	}
+	if !isClaimed {
+		t.Error("Expected new posting to be claimed but it was not")
+	}
+}
+
+// The following tests run on a real SQLite database seeded with the demo data, since end-of-day balances and claims on
+// postings are only worked out when the SQL is executed, which go-sqlmock never does.
+
+func setupInterestRepoSqliteTest(t *testing.T) (InterestRepositoryDb, *sqlx.DB) {
+	logger.MuteLogger()
+	client := openSQLiteDb(t)
+	return NewInterestRepositoryDb(client), client
+}
+
+func getDummySqlitePosting() InterestPosting {
+	return InterestPosting{AccountId: "95472", Period: "2020-08", PostingType: dto.TransactionTypeInterest, Amount: 1.4,
+		ClaimedOn: "2020-09-01 00:00:00"}
+}
+
+func TestInterestRepositoryDb_FindEndOfDayBalances_returns_balances_of_accountsOpenedBy_date(t *testing.T) {
+	//Arrange
+	repo, client := setupInterestRepoSqliteTest(t)
+	deposit := NewTransaction("95472", 500, dto.TransactionTypeDeposit, clock.RealClock{})
+	if _, err := NewAccountRepositoryDb(client).Transact(deposit); err != nil {
+		t.Fatal("Expected no error but got error while making transaction: " + err.Message)
+	}
+
+	//Act
+	accounts, err := repo.FindEndOfDayBalances("2020-08-15")
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if len(accounts) != 3 {
+		t.Fatalf("Expected the 3 accounts opened by then but got %d", len(accounts))
+	}
+	for _, account := range accounts {
+		if account.AccountId == "95472" && account.Amount != 7000 {
+			t.Errorf("Expected end of day balance 7000 but got %v", account.Amount)
+		}
+	}
+}
+
+func TestInterestRepositoryDb_SaveAccrual_saves_accrual_onlyOnce(t *testing.T) {
+	//Arrange
+	repo, _ := setupInterestRepoSqliteTest(t)
+	accrual := NewInterestAccrual("95472", time.Date(2020, 8, 31, 0, 0, 0, 0, time.UTC), 7000, 0.0365)
+	saved, err := repo.SaveAccrual(accrual)
+	if err != nil {
+		t.Fatal("Expected no error but got error while saving accrual: " + err.Message)
+	}
+	if !saved {
+		t.Fatal("Expected accrual to be saved the first time")
+	}
+
+	//Act
+	saved, err = repo.SaveAccrual(accrual)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if saved {
+		t.Error("Expected accrual to be saved only once")
+	}
+}
+
+func TestInterestRepositoryDb_FindAccrualTotals_sums_accruals_of_period(t *testing.T) {
+	//Arrange
+	repo, _ := setupInterestRepoSqliteTest(t)
+	date := time.Date(2020, 8, 31, 0, 0, 0, 0, time.UTC)
+	for _, accrualDate := range []time.Time{date, date.AddDate(0, 0, -1)} {
+		if _, err := repo.SaveAccrual(NewInterestAccrual("95472", accrualDate, 7000, 0.0365)); err != nil {
+			t.Fatal("Expected no error but got error while saving accrual: " + err.Message)
+		}
+	}
+	posting := getDummySqlitePosting()
+
+	//Act
+	postings, err := repo.FindAccrualTotals("2020-08")
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if len(postings) != 1 || postings[0].Period != "2020-08" || postings[0].RoundedAmount() != posting.Amount {
+		t.Errorf("Expected one posting of %v but got %+v", posting.Amount, postings)
+	}
+}
+
+func TestInterestRepositoryDb_ClaimPosting_claims_posting_again_after_unitOfWork_undone(t *testing.T) {
+	//Arrange
+	repo, client := setupInterestRepoSqliteTest(t)
+	posting := getDummySqlitePosting()
+	err := NewUnitOfWorkDb(client).Do(func(repos Repositories) *errs.AppError {
+		claimed, err := repos.Interest.ClaimPosting(posting)
+		if err != nil {
+			t.Fatal("Expected no error but got error while claiming posting: " + err.Message)
+		}
+		if !claimed {
+			t.Fatal("Expected posting to be claimed within unit of work")
+		}
+		return errs.NewUnexpectedError("some error message")
+	})
+	if err == nil {
+		t.Fatal("Expected the function's error but got none")
+	}
+
+	//Act
+	claimed, err := repo.ClaimPosting(posting)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if !claimed {
+		t.Error("Expected posting to be claimed again after unit of work was undone")
+	}
+}
+
+func TestInterestRepositoryDb_ClaimPosting_returns_false_when_posting_alreadyClaimed_inSameUnitOfWork(t *testing.T) {
+	//Arrange
+	_, client := setupInterestRepoSqliteTest(t)
+	posting := getDummySqlitePosting()
+	var claimed bool
+
+	//Act
+	err := NewUnitOfWorkDb(client).Do(func(repos Repositories) *errs.AppError {
+		if _, err := repos.Interest.ClaimPosting(posting); err != nil {
+			return err
+		}
+		var err *errs.AppError
+		claimed, err = repos.Interest.ClaimPosting(posting)
+		return err
+	})
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if claimed {
+		t.Error("Expected posting to be claimed only once")
+	}
+}
+
+func TestInterestRepositoryDb_ClaimPosting_returns_false_when_posting_completed(t *testing.T) {
+	//Arrange
+	repo, _ := setupInterestRepoSqliteTest(t)
+	posting := getDummySqlitePosting()
+	if _, err := repo.ClaimPosting(posting); err != nil {
+		t.Fatal("Expected no error but got error while claiming posting: " + err.Message)
+	}
+	posting.TransactionId = "1"
+	if err := repo.CompletePosting(posting); err != nil {
+		t.Fatal("Expected no error but got error while completing posting: " + err.Message)
+	}
+
+	//Act
+	claimed, err := repo.ClaimPosting(posting)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if claimed {
+		t.Error("Expected completed posting not to be claimed again")
+	}
+}
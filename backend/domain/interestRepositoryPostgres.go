@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"database/sql"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/jmoiron/sqlx"
+	"time"
+)
+
+//Server
+
+type InterestRepositoryPostgres struct { //DB (adapter)
+	client dbExecutor
+}
+
+func NewInterestRepositoryPostgres(dbClient *sqlx.DB) InterestRepositoryPostgres {
+	return InterestRepositoryPostgres{dbClient}
+}
+
+// FindEndOfDayBalances retrieves all active accounts opened on or before the given date, with each account's amount
+// set to its balance at the end of that date, the same way as InterestRepositoryDb.FindEndOfDayBalances.
+func (d InterestRepositoryPostgres) FindEndOfDayBalances(date string) ([]Account, *errs.AppError) { //DB implements repo
+	return findEndOfDayBalances(d.client, selectAccountsPostgresSql, date)
+}
+
+// SaveAccrual creates a new entry in the database for the given accrual. It returns false without doing anything if
+// the same type of interest was already accrued for the same account and date. The conflict is skipped by the INSERT
+// itself, since a failed INSERT would abort the whole database transaction on PostgreSQL.
+func (d InterestRepositoryPostgres) SaveAccrual(accrual InterestAccrual) (bool, *errs.AppError) {
+	insertSql := "INSERT INTO interest_accruals (account_id, accrual_date, accrual_type, balance, annual_rate, amount) " +
+		"VALUES ($1, $2, $3, $4, $5, $6) ON CONFLICT DO NOTHING"
+	result, err := d.client.Exec(insertSql,
+		accrual.AccountId, accrual.AccrualDate, accrual.AccrualType, accrual.Balance, accrual.AnnualRate, accrual.Amount)
+	if err != nil {
+		logger.Error("Error while creating new interest accrual: " + err.Error())
+		return false, errs.NewUnexpectedError("Unexpected database error")
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		logger.Error("Error while getting number of interest accruals created: " + err.Error())
+		return false, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return n > 0, nil
+}
+
+// FindLastAccrualDate retrieves the latest date for which interest was accrued, or an empty string if no interest was
+// ever accrued.
+func (d InterestRepositoryPostgres) FindLastAccrualDate() (string, *errs.AppError) {
+	var date sql.NullString
+	if err := d.client.Get(&date, "SELECT to_char(MAX(accrual_date), 'YYYY-MM-DD') FROM interest_accruals"); err != nil {
+		logger.Error("Error while retrieving last interest accrual date: " + err.Error())
+		return "", errs.NewUnexpectedError("Unexpected database error")
+	}
+	return date.String, nil
+}
+
+// FindAccrualTotals sums up, per account and type of interest, the interest accrued during the given period (a month
+// in the form "2006-01"), the same way as InterestRepositoryDb.FindAccrualTotals.
+func (d InterestRepositoryPostgres) FindAccrualTotals(period string) ([]InterestPosting, *errs.AppError) {
+	start, err := time.Parse(FormatPeriod, period)
+	if err != nil {
+		logger.Error("Error while parsing interest period: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected server error")
+	}
+	end := start.AddDate(0, 1, -1)
+
+	postings := make([]InterestPosting, 0)
+	selectSql := "SELECT account_id, accrual_type AS posting_type, SUM(amount) AS amount FROM interest_accruals " +
+		"WHERE accrual_date BETWEEN $1 AND $2 GROUP BY account_id, accrual_type"
+	if err = d.client.Select(&postings, selectSql, start.Format(FormatDate), end.Format(FormatDate)); err != nil {
+		logger.Error("Error while summing up interest accruals: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	for k := range postings {
+		postings[k].Period = period
+	}
+	return postings, nil
+}
+
+// ClaimPosting records that the given period's interest or charge is about to be posted to the given account, before
+// the transaction is made. Like InterestRepositoryDb.ClaimPosting, it returns false without doing anything if the
+// posting was already claimed. As in SaveAccrual, an existing claim is skipped by the INSERT itself.
+func (d InterestRepositoryPostgres) ClaimPosting(posting InterestPosting) (bool, *errs.AppError) {
+	insertSql := "INSERT INTO interest_postings (account_id, period, posting_type, amount, claimed_on) " +
+		"VALUES ($1, $2, $3, $4, $5) ON CONFLICT DO NOTHING"
+	result, err := d.client.Exec(insertSql,
+		posting.AccountId, posting.Period, posting.PostingType, posting.RoundedAmount(), posting.ClaimedOn)
+	if err != nil {
+		logger.Error("Error while creating new interest posting: " + err.Error())
+		return false, errs.NewUnexpectedError("Unexpected database error")
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		logger.Error("Error while getting number of interest postings created: " + err.Error())
+		return false, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return n > 0, nil
+}
+
+// CompletePosting links a claimed posting to the transaction that was made for it.
+func (d InterestRepositoryPostgres) CompletePosting(posting InterestPosting) *errs.AppError {
+	return completeInterestPosting(d.client, posting)
+}
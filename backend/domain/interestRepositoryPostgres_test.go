@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/jmoiron/sqlx"
+	"testing"
+)
+
+// Test common variables and inputs
+var interestRepoPostgres InterestRepositoryPostgres
+
+const insertAccrualsPostgresSql = "INSERT INTO interest_accruals (account_id, accrual_date, accrual_type, balance, " +
+	"annual_rate, amount) VALUES ($1, $2, $3, $4, $5, $6) ON CONFLICT DO NOTHING"
+const insertPostingsPostgresSql = "INSERT INTO interest_postings (account_id, period, posting_type, amount, claimed_on) " +
+	"VALUES ($1, $2, $3, $4, $5) ON CONFLICT DO NOTHING"
+
+func setupInterestRepoPostgresTest(t *testing.T) func() {
+	teardown := setupDB(t)
+	interestRepoPostgres = NewInterestRepositoryPostgres(sqlx.NewDb(db, "postgres"))
+	return teardown
+}
+
+func TestInterestRepositoryPostgres_FindEndOfDayBalances_numbers_placeholders_of_sum(t *testing.T) {
+	//Arrange
+	teardown := setupInterestRepoPostgresTest(t)
+	defer teardown()
+
+	mockDB.ExpectQuery(selectAccountsPostgresSql + " WHERE status = 1 AND opening_date <= $1").
+		WithArgs(dummyEndOfDay).
+		WillReturnRows(sqlmock.NewRows([]string{"account_id", "amount"}).AddRow(dummyAccountId, dummyAmount))
+	mockDB.ExpectQuery("SELECT account_id, SUM(CASE WHEN transaction_type IN ($1, $2, $3, $4, $5, $6) THEN -amount " +
+		"ELSE amount END) AS amount FROM transactions WHERE transaction_date > $7 GROUP BY account_id").
+		WillReturnRows(sqlmock.NewRows([]string{"account_id", "amount"}).AddRow(dummyAccountId, 700))
+
+	//Act
+	accounts, err := interestRepoPostgres.FindEndOfDayBalances(dummyAccrualDate)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if len(accounts) != 1 || accounts[0].Amount != dummyAmount-700 {
+		t.Errorf("Expected end-of-day balance %v but got %+v", dummyAmount-700, accounts)
+	}
+}
+
+func TestInterestRepositoryPostgres_SaveAccrual_returns_false_when_accrual_alreadyExists(t *testing.T) {
+	//Arrange
+	teardown := setupInterestRepoPostgresTest(t)
+	defer teardown()
+
+	dummyAccrual := InterestAccrual{AccountId: dummyAccountId, AccrualDate: dummyAccrualDate, AccrualType: dto.TransactionTypeInterest, Amount: 1}
+	mockDB.ExpectExec(insertAccrualsPostgresSql).
+		WithArgs(dummyAccrual.AccountId, dummyAccrual.AccrualDate, dummyAccrual.AccrualType, dummyAccrual.Balance,
+			dummyAccrual.AnnualRate, dummyAccrual.Amount).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	//Act
+	isSaved, err := interestRepoPostgres.SaveAccrual(dummyAccrual)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing existing accrual: " + err.Message)
+	}
+	if isSaved {
+		t.Error("Expected existing accrual to not be saved again but it was")
+	}
+	if err := mockDB.ExpectationsWereMet(); err != nil {
+		t.Error(err.Error())
+	}
+}
+
+func TestInterestRepositoryPostgres_ClaimPosting_returns_true_when_insertPostings_succeeds(t *testing.T) {
+	//Arrange
+	teardown := setupInterestRepoPostgresTest(t)
+	defer teardown()
+
+	dummyPosting := InterestPosting{AccountId: dummyAccountId, Period: dummyPeriod, PostingType: dto.TransactionTypeInterest,
+		Amount: 10, ClaimedOn: dummyClaimedOn}
+	mockDB.ExpectExec(insertPostingsPostgresSql).
+		WithArgs(dummyAccountId, dummyPeriod, dto.TransactionTypeInterest, 10.0, dummyClaimedOn).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	//Act
+	isClaimed, err := interestRepoPostgres.ClaimPosting(dummyPosting)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing new posting: " + err.Message)
+	}
+	if !isClaimed {
+		t.Error("Expected new posting to be claimed but it was not")
+	}
+	if err := mockDB.ExpectationsWereMet(); err != nil {
+		t.Error(err.Error())
+	}
+}
+
+func TestInterestRepositoryPostgres_ClaimPosting_returns_false_when_posting_alreadyClaimed(t *testing.T) {
+	//Arrange
+	teardown := setupInterestRepoPostgresTest(t)
+	defer teardown()
+
+	dummyPosting := InterestPosting{AccountId: dummyAccountId, Period: dummyPeriod, PostingType: dto.TransactionTypeInterest,
+		Amount: 10, ClaimedOn: dummyClaimedOn}
+	mockDB.ExpectExec(insertPostingsPostgresSql).
+		WithArgs(dummyAccountId, dummyPeriod, dto.TransactionTypeInterest, 10.0, dummyClaimedOn).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	//Act
+	isClaimed, err := interestRepoPostgres.ClaimPosting(dummyPosting)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing claimed posting: " + err.Message)
+	}
+	if isClaimed {
+		t.Error("Expected posting to not be claimed twice but it was")
+	}
+	if err := mockDB.ExpectationsWereMet(); err != nil {
+		t.Error(err.Error())
+	}
+}
@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"testing"
+	"time"
+)
+
+func TestInterestRates_AnnualRateFor_returns_rateOfHighestQualifyingTier(t *testing.T) {
+	//Arrange
+	rates := DefaultInterestRates()
+	tests := []struct {
+		name         string
+		accountType  string
+		balance      float64
+		expectedRate float64
+	}{
+		{"negative balance", dto.AccountTypeSaving, -100, 0},
+		{"zero balance", dto.AccountTypeSaving, 0, 0},
+		{"lowest tier", dto.AccountTypeSaving, 5000, 0.005},
+		{"middle tier lower boundary", dto.AccountTypeSaving, 10000, 0.01},
+		{"highest tier", dto.AccountTypeSaving, 80000, 0.015},
+		{"checking account", dto.AccountTypeChecking, 80000, 0},
+		{"unknown account type", "some account type", 80000, 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Act
+			actualRate := rates.AnnualRateFor(tc.accountType, tc.balance)
+
+			//Assert
+			if actualRate != tc.expectedRate {
+				t.Errorf("expected rate %v but got %v", tc.expectedRate, actualRate)
+			}
+		})
+	}
+}
+
+func TestNewInterestAccrual_calculates_oneDayOfInterest(t *testing.T) {
+	//Arrange
+	date := time.Date(2006, 1, 2, 0, 0, 0, 0, time.UTC)
+	var balance float64 = 36500
+	var annualRate = 0.01
+	var expectedAmount float64 = 1
+
+	//Act
+	accrual := NewInterestAccrual(dummyAccountId, date, balance, annualRate)
+
+	//Assert
+	if accrual.Amount != expectedAmount {
+		t.Errorf("expected accrued amount %v but got %v", expectedAmount, accrual.Amount)
+	}
+	if accrual.AccrualDate != "2006-01-02" {
+		t.Errorf("expected accrual date 2006-01-02 but got %s", accrual.AccrualDate)
+	}
+}
+
+func TestInterestPosting_RoundedAmount_rounds_toNearestCent(t *testing.T) {
+	//Arrange
+	posting := InterestPosting{Amount: 12.345678}
+	expectedAmount := 12.35
+
+	//Act
+	actualAmount := posting.RoundedAmount()
+
+	//Assert
+	if actualAmount != expectedAmount {
+		t.Errorf("expected %v but got %v", expectedAmount, actualAmount)
+	}
+}
+
+func TestIsLastDayOfMonth_returns_correctResult(t *testing.T) {
+	//Arrange
+	tests := []struct {
+		name           string
+		date           time.Time
+		expectedResult bool
+	}{
+		{"middle of month", time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), false},
+		{"end of January", time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), true},
+		{"end of February in leap year", time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), true},
+		{"28 February in leap year", time.Date(2024, 2, 28, 0, 0, 0, 0, time.UTC), false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Act
+			actualResult := IsLastDayOfMonth(tc.date)
+
+			//Assert
+			if actualResult != tc.expectedResult {
+				t.Errorf("expected %v but got %v", tc.expectedResult, actualResult)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/jmoiron/sqlx"
+)
+
+//Server
+
+// maxEventErrorLength is the length of the last_error column of the outbox.
+const maxEventErrorLength = 255
+
+type OutboxRepositoryDb struct { //DB (adapter)
+	client dbExecutor
+}
+
+func NewOutboxRepositoryDb(dbClient *sqlx.DB) OutboxRepositoryDb {
+	return OutboxRepositoryDb{dbClient}
+}
+
+// FindUnpublished retrieves up to the given number of events that have not been published yet, oldest first.
+func (d OutboxRepositoryDb) FindUnpublished(limit int) ([]Event, *errs.AppError) { //DB implements repo
+	return findUnpublishedEvents(d.client, "SELECT * FROM outbox", limit)
+}
+
+// MarkPublished records that the event with the given id was published at the given time.
+func (d OutboxRepositoryDb) MarkPublished(eventId string, publishedOn string) *errs.AppError {
+	return markEventPublished(d.client, eventId, publishedOn)
+}
+
+// RecordFailure counts a failed attempt to publish the event with the given id, keeping the given error message.
+func (d OutboxRepositoryDb) RecordFailure(eventId string, message string) *errs.AppError {
+	return recordEventFailure(d.client, eventId, message)
+}
+
+// saveEvent writes the given event to the outbox using the given executor. Repository adapters call it within the
+// database transaction that makes the change described by the event, so that the event is published if and only if
+// the change is committed.
+func saveEvent(tx dbExecutor, event Event) *errs.AppError {
+	insertSql := "INSERT INTO outbox (event_type, aggregate_type, aggregate_id, payload, occurred_on) VALUES (?, ?, ?, ?, ?)"
+	_, err := tx.Exec(tx.Rebind(insertSql),
+		event.EventType, event.AggregateType, event.AggregateId, event.Payload, event.OccurredOn)
+	if err != nil {
+		logger.Error("Error while writing event to outbox: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	return nil
+}
+
+// findUnpublishedEvents retrieves up to the given number of events that have not been published yet with the given
+// select statement, oldest first.
+func findUnpublishedEvents(client dbExecutor, selectSql string, limit int) ([]Event, *errs.AppError) {
+	events := make([]Event, 0)
+	selectSql += " WHERE published_on IS NULL ORDER BY event_id LIMIT ?"
+	if err := client.Select(&events, client.Rebind(selectSql), limit); err != nil {
+		logger.Error("Error while retrieving unpublished events: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return events, nil
+}
+
+func markEventPublished(client dbExecutor, eventId string, publishedOn string) *errs.AppError {
+	updateSql := "UPDATE outbox SET published_on = ? WHERE event_id = ?"
+	if _, err := client.Exec(client.Rebind(updateSql), publishedOn, eventId); err != nil {
+		logger.Error("Error while marking event as published: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	return nil
+}
+
+func recordEventFailure(client dbExecutor, eventId string, message string) *errs.AppError {
+	if len(message) > maxEventErrorLength {
+		message = message[:maxEventErrorLength]
+	}
+	updateSql := "UPDATE outbox SET attempts = attempts + 1, last_error = ? WHERE event_id = ?"
+	if _, err := client.Exec(client.Rebind(updateSql), message, eventId); err != nil {
+		logger.Error("Error while recording failure to publish event: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	return nil
+}
+
+// savePostedTransfer fills in the new balances of the accounts of the given debit and credit, which should already
+// have their IDs, and writes the events for both bank transactions to the outbox within the given database
+// transaction.
+func savePostedTransfer(tx dbExecutor, debit *Transaction, credit *Transaction) *errs.AppError {
+	for _, transaction := range []*Transaction{debit, credit} {
+		var appErr *errs.AppError
+		if transaction.Balance, appErr = findBalance(tx, transaction.AccountId); appErr != nil {
+			return appErr
+		}
+		if appErr = saveEvent(tx, NewTransactionPostedEvent(*transaction)); appErr != nil {
+			return appErr
+		}
+	}
+	return nil
+}
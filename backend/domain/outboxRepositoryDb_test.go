@@ -0,0 +1,224 @@
+package domain
+
+import (
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/jmoiron/sqlx"
+	"strings"
+	"testing"
+)
+
+// Test common variables and inputs
+var outboxRepoDb OutboxRepositoryDb
+var outboxTableColumns = []string{"event_id", "event_type", "aggregate_type", "aggregate_id", "payload", "occurred_on",
+	"published_on", "attempts", "last_error"}
+
+const insertOutboxSql = "INSERT INTO outbox (event_type, aggregate_type, aggregate_id, payload, occurred_on) VALUES (?, ?, ?, ?, ?)"
+const selectUnpublishedEventsSql = "SELECT * FROM outbox WHERE published_on IS NULL ORDER BY event_id LIMIT ?"
+const updateOutboxPublishedSql = "UPDATE outbox SET published_on = ? WHERE event_id = ?"
+const updateOutboxFailureSql = "UPDATE outbox SET attempts = attempts + 1, last_error = ? WHERE event_id = ?"
+
+func setupOutboxRepoDbTest(t *testing.T) func() {
+	teardown := setupDB(t)
+	outboxRepoDb = NewOutboxRepositoryDb(sqlx.NewDb(db, driverName))
+	return teardown
+}
+
+// expectEventSaved expects the given event to be written to the outbox.
+func expectEventSaved(event Event) {
+	mockDB.ExpectExec(insertOutboxSql).
+		WithArgs(event.EventType, event.AggregateType, event.AggregateId, event.Payload, event.OccurredOn).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+}
+
+func TestOutboxRepositoryDb_FindUnpublished_returns_events_when_select_succeeds(t *testing.T) {
+	//Arrange
+	teardown := setupOutboxRepoDbTest(t)
+	defer teardown()
+
+	event := NewAccountOpenedEvent(getDefaultAccountAfterSave())
+	mockDB.ExpectQuery(selectUnpublishedEventsSql).WithArgs(10).
+		WillReturnRows(sqlmock.NewRows(outboxTableColumns).
+			AddRow("1", event.EventType, event.AggregateType, event.AggregateId, event.Payload, event.OccurredOn, nil, 2, "timeout"))
+
+	//Act
+	events, err := outboxRepoDb.FindUnpublished(10)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if len(events) != 1 || events[0].EventId != "1" || events[0].Payload != event.Payload || events[0].Attempts != 2 {
+		t.Errorf("Expected the unpublished event but got %v", events)
+	}
+	if events[0].PublishedOn.Valid {
+		t.Errorf("Expected event to be unpublished but got published on %s", events[0].PublishedOn.String)
+	}
+}
+
+func TestOutboxRepositoryDb_FindUnpublished_returns_error_when_select_fails(t *testing.T) {
+	//Arrange
+	teardown := setupOutboxRepoDbTest(t)
+	defer teardown()
+
+	mockDB.ExpectQuery(selectUnpublishedEventsSql).WithArgs(10).WillReturnError(errors.New("some error message"))
+	logger.MuteLogger()
+
+	//Act
+	_, err := outboxRepoDb.FindUnpublished(10)
+
+	//Assert
+	if err == nil || err.Message != defaultExpectedErrMessage {
+		t.Errorf("Expected error \"%s\" but got %v", defaultExpectedErrMessage, err)
+	}
+}
+
+func TestOutboxRepositoryDb_MarkPublished_updates_event(t *testing.T) {
+	//Arrange
+	teardown := setupOutboxRepoDbTest(t)
+	defer teardown()
+
+	mockDB.ExpectExec(updateOutboxPublishedSql).WithArgs(dummyDate, "1").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	//Act
+	err := outboxRepoDb.MarkPublished("1", dummyDate)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if err := mockDB.ExpectationsWereMet(); err != nil {
+		t.Error(err.Error())
+	}
+}
+
+func TestOutboxRepositoryDb_RecordFailure_truncates_long_messages(t *testing.T) {
+	//Arrange
+	teardown := setupOutboxRepoDbTest(t)
+	defer teardown()
+
+	longMessage := strings.Repeat("x", maxEventErrorLength+10)
+	mockDB.ExpectExec(updateOutboxFailureSql).WithArgs(longMessage[:maxEventErrorLength], "1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	//Act
+	err := outboxRepoDb.RecordFailure("1", longMessage)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if err := mockDB.ExpectationsWereMet(); err != nil {
+		t.Error(err.Error())
+	}
+}
+
+// The following tests run on a real SQLite database seeded with the demo data, since go-sqlmock cannot show which
+// changes actually wrote events to the outbox.
+
+// setupOutboxRepoSqliteTest opens a new SQLite database, then opens an account, transfers from it to account 95472 and
+// activates customer 2003 in it.
+func setupOutboxRepoSqliteTest(t *testing.T) (OutboxRepositoryDb, UnitOfWorkDb) {
+	logger.MuteLogger()
+	client := openSQLiteDb(t)
+	accRepo := NewAccountRepositoryDb(client)
+	clk := clock.StaticClock{}
+
+	account, err := accRepo.Save(NewAccount("2000", dto.AccountTypeSaving, dto.DefaultCurrency, 6000, clk))
+	if err != nil {
+		t.Fatal("Expected no error but got error while saving account: " + err.Message)
+	}
+	debit := NewTransaction(account.AccountId, 500, dto.TransactionTypeTransferOut, clk)
+	credit := NewTransaction("95472", 500, dto.TransactionTypeTransferIn, clk)
+	if _, err = accRepo.Transfer(debit, credit); err != nil {
+		t.Fatal("Expected no error but got error while making transfer: " + err.Message)
+	}
+	if err = NewCustomerRepositoryDb(client).UpdateStatus("2003", "1", clk.NowAsString()); err != nil {
+		t.Fatal("Expected no error but got error while updating customer status: " + err.Message)
+	}
+	return NewOutboxRepositoryDb(client), NewUnitOfWorkDb(client)
+}
+
+func TestOutboxRepositoryDb_FindUnpublished_returns_events_of_changes_in_order(t *testing.T) {
+	//Arrange
+	repo, _ := setupOutboxRepoSqliteTest(t)
+
+	//Act
+	events, err := repo.FindUnpublished(10)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	expected := []string{EventAccountOpened, EventTransactionPosted, EventTransactionPosted, EventCustomerStatusChanged}
+	if len(events) != len(expected) {
+		t.Fatalf("Expected %d events but got %+v", len(expected), events)
+	}
+	for k, e := range events {
+		if e.EventType != expected[k] {
+			t.Errorf("Expected event %d to be %s but got %s", k, expected[k], e.EventType)
+		}
+	}
+	if events[2].AggregateId != "95472" || !strings.Contains(events[2].Payload, `"new_balance":7500`) {
+		t.Errorf("Expected credit on account 95472 with new balance 7500 but got %+v", events[2])
+	}
+	if events[3].Payload != `{"customer_id":"2003","status":"active","previous_status":"inactive"}` {
+		t.Errorf("Expected customer 2003 to be activated but got %s", events[3].Payload)
+	}
+}
+
+func TestOutboxRepositoryDb_FindUnpublished_returns_noEvents_of_failedUnitOfWork(t *testing.T) {
+	//Arrange
+	repo, uow := setupOutboxRepoSqliteTest(t)
+	err := uow.Do(func(repos Repositories) *errs.AppError {
+		deposit := NewTransaction("95472", 100, dto.TransactionTypeDeposit, clock.StaticClock{})
+		if _, err := repos.Accounts.Transact(deposit); err != nil {
+			return err
+		}
+		return errs.NewValidationError("some validation error")
+	})
+	if err == nil || err.Message != "some validation error" {
+		t.Fatalf("Expected the function's error but got %v", err)
+	}
+
+	//Act
+	events, err := repo.FindUnpublished(10)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if len(events) != 4 {
+		t.Errorf("Expected 4 events but got %d", len(events))
+	}
+}
+
+func TestOutboxRepositoryDb_FindUnpublished_returns_noPublishedEvents(t *testing.T) {
+	//Arrange
+	repo, _ := setupOutboxRepoSqliteTest(t)
+	events, err := repo.FindUnpublished(1)
+	if err != nil {
+		t.Fatal("Expected no error but got error while finding events: " + err.Message)
+	}
+	if err = repo.RecordFailure(events[0].EventId, "timeout"); err != nil {
+		t.Fatal("Expected no error but got error while recording failure: " + err.Message)
+	}
+	if err = repo.MarkPublished(events[0].EventId, clock.StaticClock{}.NowAsString()); err != nil {
+		t.Fatal("Expected no error but got error while marking event published: " + err.Message)
+	}
+
+	//Act
+	remaining, err := repo.FindUnpublished(10)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if len(remaining) != 3 || remaining[0].EventId == events[0].EventId {
+		t.Errorf("Expected 3 remaining events but got %+v", remaining)
+	}
+}
@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/jmoiron/sqlx"
+)
+
+//Server
+
+// selectOutboxPostgresSql selects the columns of the outbox, formatting dates the same way MySQL returns them.
+const selectOutboxPostgresSql = "SELECT event_id, event_type, aggregate_type, aggregate_id, payload, " +
+	"to_char(occurred_on, 'YYYY-MM-DD HH24:MI:SS') AS occurred_on, " +
+	"to_char(published_on, 'YYYY-MM-DD HH24:MI:SS') AS published_on, attempts, last_error FROM outbox"
+
+type OutboxRepositoryPostgres struct { //DB (adapter)
+	client dbExecutor
+}
+
+func NewOutboxRepositoryPostgres(dbClient *sqlx.DB) OutboxRepositoryPostgres {
+	return OutboxRepositoryPostgres{dbClient}
+}
+
+// FindUnpublished retrieves up to the given number of events that have not been published yet, oldest first.
+func (d OutboxRepositoryPostgres) FindUnpublished(limit int) ([]Event, *errs.AppError) {
+	return findUnpublishedEvents(d.client, selectOutboxPostgresSql, limit)
+}
+
+// MarkPublished records that the event with the given id was published at the given time.
+func (d OutboxRepositoryPostgres) MarkPublished(eventId string, publishedOn string) *errs.AppError {
+	return markEventPublished(d.client, eventId, publishedOn)
+}
+
+// RecordFailure counts a failed attempt to publish the event with the given id, keeping the given error message.
+func (d OutboxRepositoryPostgres) RecordFailure(eventId string, message string) *errs.AppError {
+	return recordEventFailure(d.client, eventId, message)
+}
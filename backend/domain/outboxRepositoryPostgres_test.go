@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"testing"
+)
+
+func TestOutboxRepositoryPostgres_FindUnpublished_returns_events_with_formattedDates(t *testing.T) {
+	//Arrange
+	teardown := setupDB(t)
+	defer teardown()
+	outboxRepoPostgres := NewOutboxRepositoryPostgres(sqlx.NewDb(db, "postgres"))
+
+	rows := sqlmock.NewRows([]string{"event_id", "event_type", "occurred_on", "published_on"}).
+		AddRow(1, EventTransactionPosted, dummyDate, nil)
+	mockDB.ExpectQuery(selectOutboxPostgresSql + " WHERE published_on IS NULL ORDER BY event_id LIMIT $1").
+		WithArgs(10).
+		WillReturnRows(rows)
+
+	//Act
+	events, err := outboxRepoPostgres.FindUnpublished(10)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if len(events) != 1 || events[0].EventId != "1" || events[0].OccurredOn != dummyDate {
+		t.Errorf("Expected event 1 occurring on %s but got %+v", dummyDate, events)
+	}
+}
@@ -0,0 +1,163 @@
+package domain
+
+import (
+	"database/sql"
+	"encoding/json"
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+)
+
+//Business Domain
+
+// SanctionsHit is a customer or an outbound transfer that potentially matches the watchlist, waiting for an admin to
+// review it. A customer with a pending or confirmed hit is on hold and cannot move money out of their accounts, while
+// a held transfer is only made once its hit is cleared.
+type SanctionsHit struct { //business/domain object
+	HitId                string         `db:"hit_id"`
+	CustomerId           string         `db:"customer_id"` //the customer screened, or the customer making the transfer
+	Kind                 string         `db:"kind"`
+	AccountId            sql.NullString `db:"account_id"`             //NULL for customers
+	DestinationAccountId sql.NullString `db:"destination_account_id"` //NULL for customers
+	Amount               float64        `db:"amount"`                 //0 for customers
+	TransactionId        sql.NullString `db:"transaction_id"`         //NULL until a held transfer is cleared and made
+	Matches              string         `db:"matches"`                //JSON array of the watchlist entries matched
+	Status               string         `db:"status"`
+	Note                 string         `db:"note"`
+	CreationDate         string         `db:"creation_date"`
+	ResolutionDate       sql.NullString `db:"resolution_date"`
+}
+
+// NewCustomerSanctionsHit puts the given customer on hold because of the given watchlist matches.
+func NewCustomerSanctionsHit(c Customer, matches []SanctionsMatch, clk clock.Clock) SanctionsHit {
+	return SanctionsHit{
+		CustomerId:   c.Id,
+		Kind:         dto.SanctionsHitKindCustomer,
+		Matches:      marshalSanctionsMatches(matches),
+		Status:       dto.SanctionsHitStatusPending,
+		CreationDate: clk.NowAsString(),
+	}
+}
+
+// NewTransferSanctionsHit holds the transfer in the given request from the given account, a party to which has the
+// given watchlist matches.
+func NewTransferSanctionsHit(account Account, request dto.TransferRequest, matches []SanctionsMatch,
+	clk clock.Clock) SanctionsHit {
+	return SanctionsHit{
+		CustomerId:           account.CustomerId,
+		Kind:                 dto.SanctionsHitKindTransfer,
+		AccountId:            sql.NullString{String: account.AccountId, Valid: true},
+		DestinationAccountId: sql.NullString{String: request.DestinationAccountId, Valid: true},
+		Amount:               request.Amount,
+		Matches:              marshalSanctionsMatches(matches),
+		Status:               dto.SanctionsHitStatusPending,
+		CreationDate:         clk.NowAsString(),
+	}
+}
+
+func marshalSanctionsMatches(matches []SanctionsMatch) string {
+	data, err := json.Marshal(matches)
+	if err != nil { //cannot happen, since matches only hold strings and numbers
+		logger.Error("Error while marshalling sanctions matches: " + err.Error())
+	}
+	return string(data)
+}
+
+func (h SanctionsHit) IsPending() bool {
+	return h.Status == dto.SanctionsHitStatusPending
+}
+
+func (h SanctionsHit) IsTransfer() bool {
+	return h.Kind == dto.SanctionsHitKindTransfer
+}
+
+// Resolve closes the hit with the resolution in the given request. It returns a conflict error if the hit is no
+// longer pending.
+func (h SanctionsHit) Resolve(request dto.ResolveSanctionsHitRequest, clk clock.Clock) (SanctionsHit, *errs.AppError) {
+	if !h.IsPending() {
+		return h, errs.NewConflictError("Sanctions hit is already resolved")
+	}
+	h.Status = request.Resolution
+	h.Note = request.Note
+	h.ResolutionDate = sql.NullString{String: clk.NowAsString(), Valid: true}
+	return h, nil
+}
+
+// ToTransferRequestDTO returns the request for the transfer held by the hit, to make it once the hit is cleared. The
+// beneficiary check was passed when the transfer was first requested, so it is not made again.
+func (h SanctionsHit) ToTransferRequestDTO() dto.TransferRequest {
+	return dto.TransferRequest{
+		AccountId:                h.AccountId.String,
+		CustomerId:               h.CustomerId,
+		DestinationAccountId:     h.DestinationAccountId.String,
+		Amount:                   h.Amount,
+		OverrideBeneficiaryCheck: true,
+		SkipSanctionsScreening:   true,
+	}
+}
+
+func (h SanctionsHit) ToDTO() dto.SanctionsHitResponse {
+	matches := make([]SanctionsMatch, 0)
+	if err := json.Unmarshal([]byte(h.Matches), &matches); err != nil {
+		logger.Error("Error while unmarshalling matches of sanctions hit: " + err.Error())
+	}
+
+	return dto.SanctionsHitResponse{
+		HitId:                h.HitId,
+		CustomerId:           h.CustomerId,
+		Kind:                 h.Kind,
+		AccountId:            h.AccountId.String,
+		DestinationAccountId: h.DestinationAccountId.String,
+		Amount:               h.Amount,
+		TransactionId:        h.TransactionId.String,
+		Matches:              SanctionsMatchesToDTO(matches),
+		Status:               h.Status,
+		Note:                 h.Note,
+		CreationDate:         h.CreationDate,
+		ResolutionDate:       h.ResolutionDate.String,
+	}
+}
+
+func SanctionsMatchesToDTO(matches []SanctionsMatch) []dto.SanctionsMatchInfo {
+	response := make([]dto.SanctionsMatchInfo, 0)
+	for _, m := range matches {
+		response = append(response, dto.SanctionsMatchInfo{
+			CustomerId:  m.CustomerId,
+			ListId:      m.ListId,
+			Name:        m.Name,
+			DateOfBirth: m.DateOfBirth,
+			Country:     m.Country,
+			Score:       m.Score,
+		})
+	}
+	return response
+}
+
+// CustomerScreening records which details of a customer were last screened against the watchlist.
+type CustomerScreening struct {
+	CustomerId    string `db:"customer_id"`
+	Fingerprint   string `db:"fingerprint"` //see Watchlist.Fingerprint
+	ScreeningDate string `db:"screening_date"`
+}
+
+// SanctionsHitFilter selects the sanctions hits to page through in the review queue.
+type SanctionsHitFilter struct {
+	Status  string //only hits with this status, or all if empty
+	AfterId string //only hits after this one, for paging through the queue
+	Limit   int
+}
+
+//Server
+
+//go:generate mockgen -destination=../mocks/domain/mock_sanctionsRepository.go -package=domain github.com/aliciatay-zls/banking/backend/domain SanctionsRepository
+type SanctionsRepository interface { //repo (secondary port)
+	FindScreenings() ([]CustomerScreening, *errs.AppError)
+	FindScreening(customerId string) (*CustomerScreening, *errs.AppError)
+	SaveScreening(CustomerScreening) *errs.AppError
+	SaveHit(SanctionsHit) (*SanctionsHit, *errs.AppError)
+	FindHitById(string) (*SanctionsHit, *errs.AppError)
+	FindHits(SanctionsHitFilter) ([]SanctionsHit, *errs.AppError)
+	ResolveHit(SanctionsHit) *errs.AppError
+	IsOnHold(customerId string) (bool, *errs.AppError)
+}
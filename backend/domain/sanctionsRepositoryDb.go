@@ -0,0 +1,157 @@
+package domain
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/jmoiron/sqlx"
+	"strconv"
+	"strings"
+)
+
+//Server
+
+type SanctionsRepositoryDb struct { //DB (adapter)
+	client dbExecutor
+}
+
+func NewSanctionsRepositoryDb(dbClient *sqlx.DB) SanctionsRepositoryDb {
+	return SanctionsRepositoryDb{dbClient}
+}
+
+// FindScreenings retrieves the last screening of every customer that has been screened.
+func (d SanctionsRepositoryDb) FindScreenings() ([]CustomerScreening, *errs.AppError) { //DB implements repo
+	screenings := make([]CustomerScreening, 0)
+	if err := d.client.Select(&screenings, "SELECT * FROM customer_screenings"); err != nil {
+		logger.Error("Error while retrieving customer screenings: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return screenings, nil
+}
+
+// FindScreening retrieves the last screening of the customer with the given id.
+func (d SanctionsRepositoryDb) FindScreening(customerId string) (*CustomerScreening, *errs.AppError) { //DB implements repo
+	var s CustomerScreening
+	if err := d.client.Get(&s, "SELECT * FROM customer_screenings WHERE customer_id = ?", customerId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.NewNotFoundError("Customer has not been screened")
+		}
+		logger.Error("Error while retrieving customer screening: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return &s, nil
+}
+
+// SaveScreening replaces the last screening of the customer with the given one.
+func (d SanctionsRepositoryDb) SaveScreening(s CustomerScreening) *errs.AppError { //DB implements repo
+	replaceSql := "REPLACE INTO customer_screenings (customer_id, fingerprint, screening_date) VALUES (?, ?, ?)"
+	if _, err := d.client.Exec(replaceSql, s.CustomerId, s.Fingerprint, s.ScreeningDate); err != nil {
+		logger.Error("Error while saving customer screening: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	return nil
+}
+
+// SaveHit creates a new entry in the database for the given sanctions hit, sets its ID using the database-generated
+// ID and returns the hit.
+func (d SanctionsRepositoryDb) SaveHit(h SanctionsHit) (*SanctionsHit, *errs.AppError) { //DB implements repo
+	insertSql := "INSERT INTO sanctions_hits (customer_id, kind, account_id, destination_account_id, amount, " +
+		"transaction_id, matches, status, note, creation_date, resolution_date) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+	result, err := d.client.Exec(insertSql, h.CustomerId, h.Kind, h.AccountId, h.DestinationAccountId, h.Amount,
+		h.TransactionId, h.Matches, h.Status, h.Note, h.CreationDate, h.ResolutionDate)
+	if err != nil {
+		logger.Error("Error while creating new sanctions hit: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		logger.Error("Error while getting id of newly inserted sanctions hit: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	h.HitId = strconv.FormatInt(id, 10)
+
+	return &h, nil
+}
+
+// FindHitById retrieves the sanctions hit with the given id.
+func (d SanctionsRepositoryDb) FindHitById(hitId string) (*SanctionsHit, *errs.AppError) { //DB implements repo
+	var h SanctionsHit
+	if err := d.client.Get(&h, "SELECT * FROM sanctions_hits WHERE hit_id = ?", hitId); err != nil {
+		logger.Error("Error while retrieving sanctions hit: " + err.Error())
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.NewNotFoundError("Sanctions hit not found")
+		}
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return &h, nil
+}
+
+// FindHits retrieves up to the filter's limit of the sanctions hits matching it, oldest first.
+func (d SanctionsRepositoryDb) FindHits(filter SanctionsHitFilter) ([]SanctionsHit, *errs.AppError) { //DB implements repo
+	return findSanctionsHits(d.client, "SELECT * FROM sanctions_hits", filter)
+}
+
+// findSanctionsHits retrieves up to the filter's limit of the sanctions hits matching it with the given select
+// statement, oldest first.
+func findSanctionsHits(client dbExecutor, selectSql string, filter SanctionsHitFilter) ([]SanctionsHit, *errs.AppError) {
+	conditions := make([]string, 0)
+	args := make([]interface{}, 0)
+	if filter.Status != "" {
+		conditions = append(conditions, "status = ?")
+		args = append(args, filter.Status)
+	}
+	if filter.AfterId != "" {
+		conditions = append(conditions, "hit_id > ?")
+		args = append(args, filter.AfterId)
+	}
+
+	if len(conditions) > 0 {
+		selectSql += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	selectSql += " ORDER BY hit_id LIMIT ?"
+	args = append(args, filter.Limit)
+
+	hits := make([]SanctionsHit, 0)
+	if err := client.Select(&hits, client.Rebind(selectSql), args...); err != nil {
+		logger.Error("Error while retrieving sanctions hits: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return hits, nil
+}
+
+// ResolveHit saves the resolution of the given sanctions hit and the transaction made for it, as long as the hit is
+// still pending. It returns a conflict error if the hit was meanwhile resolved by someone else.
+func (d SanctionsRepositoryDb) ResolveHit(h SanctionsHit) *errs.AppError { //DB implements repo
+	updateSql := "UPDATE sanctions_hits SET status = ?, note = ?, transaction_id = ?, resolution_date = ? " +
+		"WHERE hit_id = ? AND status = ?"
+	result, err := d.client.Exec(updateSql, h.Status, h.Note, h.TransactionId, h.ResolutionDate, h.HitId,
+		dto.SanctionsHitStatusPending)
+	if err != nil {
+		logger.Error("Error while resolving sanctions hit: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		logger.Error("Error while getting number of sanctions hits resolved: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	if rowsAffected == 0 {
+		return errs.NewConflictError("Sanctions hit is already resolved")
+	}
+	return nil
+}
+
+// IsOnHold checks whether the customer with the given id has a customer hit that is pending or was confirmed.
+func (d SanctionsRepositoryDb) IsOnHold(customerId string) (bool, *errs.AppError) { //DB implements repo
+	var count int
+	countSql := "SELECT COUNT(*) FROM sanctions_hits WHERE customer_id = ? AND kind = ? AND status IN (?, ?)"
+	if err := d.client.Get(&count, countSql, customerId, dto.SanctionsHitKindCustomer, dto.SanctionsHitStatusPending,
+		dto.SanctionsHitStatusConfirmed); err != nil {
+		logger.Error("Error while checking whether customer is on hold: " + err.Error())
+		return false, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,208 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"net/http"
+	"testing"
+)
+
+// These tests run on a real SQLite database seeded with the demo data, since which customers are on hold is only
+// decided when the SQL is executed, which go-sqlmock never does.
+
+var sanctionsRepoDb SanctionsRepositoryDb
+var sanctionsAccRepoDb AccountRepositoryDb
+
+func setupSanctionsRepoDbTest(t *testing.T) {
+	logger.MuteLogger()
+	client := openSQLiteDb(t)
+	sanctionsRepoDb = NewSanctionsRepositoryDb(client)
+	sanctionsAccRepoDb = NewAccountRepositoryDb(client)
+}
+
+// saveDummySanctionsHits saves a hit on customer 2002, followed by a hit on a transfer by customer 2000 to them.
+func saveDummySanctionsHits(t *testing.T) (*SanctionsHit, *SanctionsHit) {
+	clk := clock.StaticClock{}
+	matches := []SanctionsMatch{{CustomerId: "2002", ListId: "WL-1", Name: "Hadley", Score: 1}}
+	customerHit, err := sanctionsRepoDb.SaveHit(NewCustomerSanctionsHit(Customer{Id: "2002"}, matches, clk))
+	if err != nil {
+		t.Fatal("Expected no error but got error while saving sanctions hit: " + err.Message)
+	}
+
+	account, err := sanctionsAccRepoDb.FindById("95470")
+	if err != nil {
+		t.Fatal("Expected no error but got error while finding account: " + err.Message)
+	}
+	transfer := dto.TransferRequest{AccountId: "95470", DestinationAccountId: "95471", Amount: 100}
+	transferHit, err := sanctionsRepoDb.SaveHit(NewTransferSanctionsHit(*account, transfer, matches, clk))
+	if err != nil {
+		t.Fatal("Expected no error but got error while saving sanctions hit: " + err.Message)
+	}
+	return customerHit, transferHit
+}
+
+func TestSanctionsRepositoryDb_FindScreening_returns_notFoundError_when_customer_notScreened(t *testing.T) {
+	//Arrange
+	setupSanctionsRepoDbTest(t)
+
+	//Act
+	_, err := sanctionsRepoDb.FindScreening("2002")
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none")
+	}
+	if err.Code != http.StatusNotFound {
+		t.Errorf("Expected status code %d but got %d", http.StatusNotFound, err.Code)
+	}
+}
+
+func TestSanctionsRepositoryDb_SaveScreening_replaces_customerScreening(t *testing.T) {
+	//Arrange
+	setupSanctionsRepoDbTest(t)
+	date := clock.StaticClock{}.NowAsString()
+	err := sanctionsRepoDb.SaveScreening(CustomerScreening{CustomerId: "2002", Fingerprint: "abc", ScreeningDate: date})
+	if err != nil {
+		t.Fatal("Expected no error but got error while saving screening: " + err.Message)
+	}
+
+	//Act
+	err = sanctionsRepoDb.SaveScreening(CustomerScreening{CustomerId: "2002", Fingerprint: "def", ScreeningDate: date})
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	found, err := sanctionsRepoDb.FindScreening("2002")
+	if err != nil {
+		t.Fatal("Expected no error but got error while finding screening: " + err.Message)
+	}
+	all, err := sanctionsRepoDb.FindScreenings()
+	if err != nil {
+		t.Fatal("Expected no error but got error while finding screenings: " + err.Message)
+	}
+	if found.Fingerprint != "def" || len(all) != 1 {
+		t.Errorf("Expected only screening with fingerprint def but got %+v and %+v", *found, all)
+	}
+}
+
+func TestSanctionsRepositoryDb_FindHitById_returns_savedHit(t *testing.T) {
+	//Arrange
+	setupSanctionsRepoDbTest(t)
+	_, transferHit := saveDummySanctionsHits(t)
+
+	//Act
+	found, err := sanctionsRepoDb.FindHitById(transferHit.HitId)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if *found != *transferHit {
+		t.Errorf("Expected hit %+v but got %+v", *transferHit, *found)
+	}
+}
+
+func TestSanctionsRepositoryDb_FindHits_returns_hits_after_givenId(t *testing.T) {
+	//Arrange
+	setupSanctionsRepoDbTest(t)
+	customerHit, transferHit := saveDummySanctionsHits(t)
+
+	//Act
+	page, err := sanctionsRepoDb.FindHits(SanctionsHitFilter{Status: dto.SanctionsHitStatusPending,
+		AfterId: customerHit.HitId, Limit: 10})
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if len(page) != 1 || page[0].HitId != transferHit.HitId {
+		t.Errorf("Expected page of hit %s but got %+v", transferHit.HitId, page)
+	}
+}
+
+func TestSanctionsRepositoryDb_IsOnHold_returns_true_when_customerHit_pending(t *testing.T) {
+	//Arrange
+	setupSanctionsRepoDbTest(t)
+	saveDummySanctionsHits(t)
+
+	//Act
+	onHold, err := sanctionsRepoDb.IsOnHold("2002")
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if !onHold {
+		t.Error("Expected customer 2002 to be on hold")
+	}
+}
+
+func TestSanctionsRepositoryDb_IsOnHold_returns_false_when_customer_onlyHas_transferHit(t *testing.T) {
+	//Arrange
+	setupSanctionsRepoDbTest(t)
+	saveDummySanctionsHits(t)
+
+	//Act
+	onHold, err := sanctionsRepoDb.IsOnHold("2000")
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if onHold {
+		t.Error("Expected customer 2000 not to be on hold")
+	}
+}
+
+func TestSanctionsRepositoryDb_ResolveHit_takes_customer_offHold_when_hit_cleared(t *testing.T) {
+	//Arrange
+	setupSanctionsRepoDbTest(t)
+	customerHit, _ := saveDummySanctionsHits(t)
+	cleared, err := customerHit.Resolve(dto.ResolveSanctionsHitRequest{Resolution: dto.SanctionsHitStatusCleared},
+		clock.StaticClock{})
+	if err != nil {
+		t.Fatal("Expected no error but got error while resolving hit: " + err.Message)
+	}
+
+	//Act
+	err = sanctionsRepoDb.ResolveHit(cleared)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	onHold, err := sanctionsRepoDb.IsOnHold("2002")
+	if err != nil {
+		t.Fatal("Expected no error but got error while checking hold: " + err.Message)
+	}
+	if onHold {
+		t.Error("Expected customer 2002 not to be on hold once cleared")
+	}
+}
+
+func TestSanctionsRepositoryDb_ResolveHit_returns_conflictError_when_hit_alreadyResolved(t *testing.T) {
+	//Arrange
+	setupSanctionsRepoDbTest(t)
+	customerHit, _ := saveDummySanctionsHits(t)
+	cleared, err := customerHit.Resolve(dto.ResolveSanctionsHitRequest{Resolution: dto.SanctionsHitStatusCleared},
+		clock.StaticClock{})
+	if err != nil {
+		t.Fatal("Expected no error but got error while resolving hit: " + err.Message)
+	}
+	if err = sanctionsRepoDb.ResolveHit(cleared); err != nil {
+		t.Fatal("Expected no error but got error while resolving hit: " + err.Message)
+	}
+
+	//Act
+	err = sanctionsRepoDb.ResolveHit(cleared)
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none")
+	}
+	if err.Code != http.StatusConflict {
+		t.Errorf("Expected status code %d but got %d", http.StatusConflict, err.Code)
+	}
+}
@@ -0,0 +1,134 @@
+package domain
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/jmoiron/sqlx"
+)
+
+//Server
+
+// selectCustomerScreeningsPostgresSql selects the columns of customer screenings, formatting dates the same way MySQL
+// returns them.
+const selectCustomerScreeningsPostgresSql = "SELECT customer_id, fingerprint, " +
+	"to_char(screening_date, 'YYYY-MM-DD HH24:MI:SS') AS screening_date FROM customer_screenings"
+
+// selectSanctionsHitsPostgresSql selects the columns of sanctions hits, formatting dates the same way MySQL returns
+// them.
+const selectSanctionsHitsPostgresSql = "SELECT hit_id, customer_id, kind, account_id, destination_account_id, " +
+	"amount, transaction_id, matches, status, note, to_char(creation_date, 'YYYY-MM-DD HH24:MI:SS') AS creation_date, " +
+	"to_char(resolution_date, 'YYYY-MM-DD HH24:MI:SS') AS resolution_date FROM sanctions_hits"
+
+type SanctionsRepositoryPostgres struct { //DB (adapter)
+	client dbExecutor
+}
+
+func NewSanctionsRepositoryPostgres(dbClient *sqlx.DB) SanctionsRepositoryPostgres {
+	return SanctionsRepositoryPostgres{dbClient}
+}
+
+// FindScreenings retrieves the last screening of every customer that has been screened.
+func (d SanctionsRepositoryPostgres) FindScreenings() ([]CustomerScreening, *errs.AppError) { //DB implements repo
+	screenings := make([]CustomerScreening, 0)
+	if err := d.client.Select(&screenings, selectCustomerScreeningsPostgresSql); err != nil {
+		logger.Error("Error while retrieving customer screenings: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return screenings, nil
+}
+
+// FindScreening retrieves the last screening of the customer with the given id.
+func (d SanctionsRepositoryPostgres) FindScreening(customerId string) (*CustomerScreening, *errs.AppError) { //DB implements repo
+	var s CustomerScreening
+	if err := d.client.Get(&s, selectCustomerScreeningsPostgresSql+" WHERE customer_id = $1", customerId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.NewNotFoundError("Customer has not been screened")
+		}
+		logger.Error("Error while retrieving customer screening: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return &s, nil
+}
+
+// SaveScreening replaces the last screening of the customer with the given one.
+func (d SanctionsRepositoryPostgres) SaveScreening(s CustomerScreening) *errs.AppError { //DB implements repo
+	upsertSql := "INSERT INTO customer_screenings (customer_id, fingerprint, screening_date) VALUES ($1, $2, $3) " +
+		"ON CONFLICT (customer_id) DO UPDATE SET fingerprint = EXCLUDED.fingerprint, " +
+		"screening_date = EXCLUDED.screening_date"
+	if _, err := d.client.Exec(upsertSql, s.CustomerId, s.Fingerprint, s.ScreeningDate); err != nil {
+		logger.Error("Error while saving customer screening: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	return nil
+}
+
+// SaveHit creates a new entry in the database for the given sanctions hit, sets its ID using the ID returned by the
+// database and returns the hit.
+func (d SanctionsRepositoryPostgres) SaveHit(h SanctionsHit) (*SanctionsHit, *errs.AppError) { //DB implements repo
+	insertSql := "INSERT INTO sanctions_hits (customer_id, kind, account_id, destination_account_id, amount, " +
+		"transaction_id, matches, status, note, creation_date, resolution_date) " +
+		"VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) RETURNING hit_id"
+	err := d.client.Get(&h.HitId, insertSql, h.CustomerId, h.Kind, h.AccountId, h.DestinationAccountId, h.Amount,
+		h.TransactionId, h.Matches, h.Status, h.Note, h.CreationDate, h.ResolutionDate)
+	if err != nil {
+		logger.Error("Error while creating new sanctions hit: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return &h, nil
+}
+
+// FindHitById retrieves the sanctions hit with the given id.
+func (d SanctionsRepositoryPostgres) FindHitById(hitId string) (*SanctionsHit, *errs.AppError) { //DB implements repo
+	var h SanctionsHit
+	if err := d.client.Get(&h, selectSanctionsHitsPostgresSql+" WHERE hit_id = $1", hitId); err != nil {
+		logger.Error("Error while retrieving sanctions hit: " + err.Error())
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.NewNotFoundError("Sanctions hit not found")
+		}
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return &h, nil
+}
+
+// FindHits retrieves up to the filter's limit of the sanctions hits matching it, oldest first.
+func (d SanctionsRepositoryPostgres) FindHits(filter SanctionsHitFilter) ([]SanctionsHit, *errs.AppError) { //DB implements repo
+	return findSanctionsHits(d.client, selectSanctionsHitsPostgresSql, filter)
+}
+
+// ResolveHit saves the resolution of the given sanctions hit and the transaction made for it, as long as the hit is
+// still pending. It returns a conflict error if the hit was meanwhile resolved by someone else.
+func (d SanctionsRepositoryPostgres) ResolveHit(h SanctionsHit) *errs.AppError { //DB implements repo
+	updateSql := "UPDATE sanctions_hits SET status = $1, note = $2, transaction_id = $3, resolution_date = $4 " +
+		"WHERE hit_id = $5 AND status = $6"
+	result, err := d.client.Exec(updateSql, h.Status, h.Note, h.TransactionId, h.ResolutionDate, h.HitId,
+		dto.SanctionsHitStatusPending)
+	if err != nil {
+		logger.Error("Error while resolving sanctions hit: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		logger.Error("Error while getting number of sanctions hits resolved: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	if rowsAffected == 0 {
+		return errs.NewConflictError("Sanctions hit is already resolved")
+	}
+	return nil
+}
+
+// IsOnHold checks whether the customer with the given id has a customer hit that is pending or was confirmed.
+func (d SanctionsRepositoryPostgres) IsOnHold(customerId string) (bool, *errs.AppError) { //DB implements repo
+	var count int
+	countSql := "SELECT COUNT(*) FROM sanctions_hits WHERE customer_id = $1 AND kind = $2 AND status IN ($3, $4)"
+	if err := d.client.Get(&count, countSql, customerId, dto.SanctionsHitKindCustomer, dto.SanctionsHitStatusPending,
+		dto.SanctionsHitStatusConfirmed); err != nil {
+		logger.Error("Error while checking whether customer is on hold: " + err.Error())
+		return false, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/jmoiron/sqlx"
+	"testing"
+)
+
+// Test common variables and inputs
+var sanctionsRepoPostgres SanctionsRepositoryPostgres
+
+func setupSanctionsRepoPostgresTest(t *testing.T) func() {
+	teardown := setupDB(t)
+	sanctionsRepoPostgres = NewSanctionsRepositoryPostgres(sqlx.NewDb(db, "postgres"))
+	return teardown
+}
+
+func TestSanctionsRepositoryPostgres_SaveScreening_replaces_lastScreening(t *testing.T) {
+	//Arrange
+	teardown := setupSanctionsRepoPostgresTest(t)
+	defer teardown()
+
+	screening := CustomerScreening{CustomerId: dummyCustomerId, Fingerprint: "abc", ScreeningDate: dummyDate}
+	mockDB.ExpectExec("INSERT INTO customer_screenings (customer_id, fingerprint, screening_date) VALUES ($1, $2, $3) "+
+		"ON CONFLICT (customer_id) DO UPDATE SET fingerprint = EXCLUDED.fingerprint, "+
+		"screening_date = EXCLUDED.screening_date").
+		WithArgs(screening.CustomerId, screening.Fingerprint, screening.ScreeningDate).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	//Act
+	err := sanctionsRepoPostgres.SaveScreening(screening)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if err := mockDB.ExpectationsWereMet(); err != nil {
+		t.Error(err.Error())
+	}
+}
+
+func TestSanctionsRepositoryPostgres_FindHits_numbers_placeholders_of_filter(t *testing.T) {
+	//Arrange
+	teardown := setupSanctionsRepoPostgresTest(t)
+	defer teardown()
+
+	filter := SanctionsHitFilter{Status: dto.SanctionsHitStatusPending, AfterId: "4", Limit: 10}
+	mockDB.ExpectQuery(selectSanctionsHitsPostgresSql+" WHERE status = $1 AND hit_id > $2 ORDER BY hit_id LIMIT $3").
+		WithArgs(filter.Status, filter.AfterId, filter.Limit).
+		WillReturnRows(sqlmock.NewRows([]string{"hit_id", "status", "creation_date"}).AddRow(5, filter.Status, dummyDate))
+
+	//Act
+	hits, err := sanctionsRepoPostgres.FindHits(filter)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if len(hits) != 1 || hits[0].HitId != "5" || hits[0].CreationDate != dummyDate {
+		t.Errorf("Expected hit 5 created on %s but got %+v", dummyDate, hits)
+	}
+}
@@ -0,0 +1,167 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//Business Domain
+
+type Schedule interface { //business/domain object
+	Next(time.Time) time.Time
+}
+
+// MonthlySchedule runs at midnight on the same day of every month. Months that are too short for the day run on
+// their last day instead, e.g. a schedule for the 31st runs on the 30th of April.
+type MonthlySchedule struct {
+	DayOfMonth int
+}
+
+func NewMonthlySchedule(dayOfMonth int) (MonthlySchedule, error) {
+	if dayOfMonth < 1 || dayOfMonth > 31 {
+		return MonthlySchedule{}, errors.New("day of month should be between 1 and 31")
+	}
+	return MonthlySchedule{dayOfMonth}, nil
+}
+
+// Next returns the earliest run time that is strictly after the given time.
+func (s MonthlySchedule) Next(after time.Time) time.Time {
+	for monthOffset := 0; ; monthOffset++ {
+		firstOfMonth := time.Date(after.Year(), after.Month()+time.Month(monthOffset), 1, 0, 0, 0, 0, after.Location())
+		day := s.DayOfMonth
+		if lastDay := firstOfMonth.AddDate(0, 1, -1).Day(); day > lastDay {
+			day = lastDay
+		}
+		next := firstOfMonth.AddDate(0, 0, day-1)
+		if next.After(after) {
+			return next
+		}
+	}
+}
+
+// CronSchedule runs according to a standard 5-field cron expression: minute, hour, day of month, month and day of
+// week (0 is Sunday). Each field is "*", a value, a range such as "1-5", a step such as "*/15" or "1-10/2", or a
+// comma-separated list of these. As in cron, if both day fields are restricted, either of them has to match.
+type CronSchedule struct {
+	Expression string
+	minutes    []bool
+	hours      []bool
+	days       []bool
+	months     []bool
+	weekdays   []bool
+	anyDay     bool
+	anyWeekday bool
+}
+
+// maxCronSearchYears bounds the search for the next run time so that expressions that can never match, such as
+// "0 0 31 2 *", do not loop forever.
+const maxCronSearchYears = 5
+
+func ParseCronSchedule(expression string) (CronSchedule, error) {
+	fields := strings.Fields(expression)
+	if len(fields) != 5 {
+		return CronSchedule{}, fmt.Errorf("cron expression should have 5 fields but has %d", len(fields))
+	}
+
+	s := CronSchedule{Expression: expression}
+	var err error
+	if s.minutes, err = parseCronField(fields[0], 0, 59); err != nil {
+		return CronSchedule{}, fmt.Errorf("invalid minute field: %w", err)
+	}
+	if s.hours, err = parseCronField(fields[1], 0, 23); err != nil {
+		return CronSchedule{}, fmt.Errorf("invalid hour field: %w", err)
+	}
+	if s.days, err = parseCronField(fields[2], 1, 31); err != nil {
+		return CronSchedule{}, fmt.Errorf("invalid day of month field: %w", err)
+	}
+	if s.months, err = parseCronField(fields[3], 1, 12); err != nil {
+		return CronSchedule{}, fmt.Errorf("invalid month field: %w", err)
+	}
+	if s.weekdays, err = parseCronField(fields[4], 0, 6); err != nil {
+		return CronSchedule{}, fmt.Errorf("invalid day of week field: %w", err)
+	}
+	s.anyDay = fields[2] == "*"
+	s.anyWeekday = fields[4] == "*"
+
+	return s, nil
+}
+
+// parseCronField returns, for each value from 0 to max, whether the given field matches it.
+func parseCronField(field string, min int, max int) ([]bool, error) {
+	matches := make([]bool, max+1)
+	for _, part := range strings.Split(field, ",") {
+		valueRange, stepStr, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepStr); err != nil || step < 1 {
+				return nil, fmt.Errorf("invalid step %q", stepStr)
+			}
+		}
+
+		start, end := min, max
+		if valueRange != "*" {
+			startStr, endStr, isRange := strings.Cut(valueRange, "-")
+			var err error
+			if start, err = strconv.Atoi(startStr); err != nil {
+				return nil, fmt.Errorf("invalid value %q", startStr)
+			}
+			end = start
+			if isRange {
+				if end, err = strconv.Atoi(endStr); err != nil {
+					return nil, fmt.Errorf("invalid value %q", endStr)
+				}
+			} else if hasStep {
+				end = max
+			}
+		}
+		if start < min || end > max || start > end {
+			return nil, fmt.Errorf("%q is out of range %d-%d", part, min, max)
+		}
+
+		for v := start; v <= end; v += step {
+			matches[v] = true
+		}
+	}
+	return matches, nil
+}
+
+// Next returns the earliest run time that is strictly after the given time, or the zero time if there is none
+// within the next few years.
+func (s CronSchedule) Next(after time.Time) time.Time {
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := after.AddDate(maxCronSearchYears, 0, 0)
+
+	for t.Before(limit) {
+		if !s.months[t.Month()] {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.hours[t.Hour()] {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.minutes[t.Minute()] {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (s CronSchedule) matchesDay(t time.Time) bool {
+	dayMatches := s.days[t.Day()]
+	weekdayMatches := s.weekdays[t.Weekday()]
+	if s.anyDay || s.anyWeekday {
+		return dayMatches && weekdayMatches
+	}
+	return dayMatches || weekdayMatches
+}
@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMonthlySchedule_Next_returns_nextDayOfMonth_clampedToEndOfMonth(t *testing.T) {
+	//Arrange
+	tests := []struct {
+		name     string
+		day      int
+		after    time.Time
+		expected time.Time
+	}{
+		{"later this month", 15, time.Date(2023, 1, 10, 8, 0, 0, 0, time.UTC), time.Date(2023, 1, 15, 0, 0, 0, 0, time.UTC)},
+		{"already passed this month", 15, time.Date(2023, 1, 15, 0, 0, 0, 0, time.UTC), time.Date(2023, 2, 15, 0, 0, 0, 0, time.UTC)},
+		{"short month", 31, time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC), time.Date(2023, 2, 28, 0, 0, 0, 0, time.UTC)},
+		{"leap year", 30, time.Date(2024, 1, 30, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"end of year", 1, time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			schedule, _ := NewMonthlySchedule(tc.day)
+
+			//Act
+			actual := schedule.Next(tc.after)
+
+			//Assert
+			if !actual.Equal(tc.expected) {
+				t.Errorf("Expected next run %v but got %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestNewMonthlySchedule_returns_error_when_day_outOfRange(t *testing.T) {
+	for _, day := range []int{0, 32} {
+		if _, err := NewMonthlySchedule(day); err == nil {
+			t.Errorf("Expected error but got none for day %d", day)
+		}
+	}
+}
+
+func TestCronSchedule_Next_returns_nextMatchingMinute(t *testing.T) {
+	//Arrange
+	after := time.Date(2023, 1, 10, 8, 30, 15, 0, time.UTC) //a Tuesday
+	tests := []struct {
+		expression string
+		expected   time.Time
+	}{
+		{"* * * * *", time.Date(2023, 1, 10, 8, 31, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2023, 1, 10, 8, 45, 0, 0, time.UTC)},
+		{"0 9 * * *", time.Date(2023, 1, 10, 9, 0, 0, 0, time.UTC)},
+		{"0 8 * * *", time.Date(2023, 1, 11, 8, 0, 0, 0, time.UTC)},
+		{"0 9 1 * *", time.Date(2023, 2, 1, 9, 0, 0, 0, time.UTC)},
+		{"0 9 * * 1-5", time.Date(2023, 1, 10, 9, 0, 0, 0, time.UTC)},
+		{"0 0 * * 0", time.Date(2023, 1, 15, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1,20 * 5", time.Date(2023, 1, 13, 0, 0, 0, 0, time.UTC)},
+		{"30 12 29 2 *", time.Date(2024, 2, 29, 12, 30, 0, 0, time.UTC)},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.expression, func(t *testing.T) {
+			schedule, err := ParseCronSchedule(tc.expression)
+			if err != nil {
+				t.Fatal("Expected no error but got error while parsing: " + err.Error())
+			}
+
+			//Act
+			actual := schedule.Next(after)
+
+			//Assert
+			if !actual.Equal(tc.expected) {
+				t.Errorf("Expected next run %v but got %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestCronSchedule_Next_returns_zeroTime_when_expression_neverMatches(t *testing.T) {
+	schedule, _ := ParseCronSchedule("0 0 31 2 *")
+
+	if actual := schedule.Next(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)); !actual.IsZero() {
+		t.Errorf("Expected zero time but got %v", actual)
+	}
+}
+
+func TestParseCronSchedule_returns_error_when_expression_invalid(t *testing.T) {
+	tests := []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "* * * * 7",
+		"*/0 * * * *", "5-1 * * * *", "a * * * *"}
+
+	for _, expression := range tests {
+		t.Run(expression, func(t *testing.T) {
+			if _, err := ParseCronSchedule(expression); err == nil {
+				t.Error("Expected error but got none")
+			}
+		})
+	}
+}
@@ -0,0 +1,323 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"os"
+	"time"
+)
+
+//Business Domain
+
+const ScreeningDecisionAllow = "allow"
+const ScreeningDecisionReview = "review"
+const ScreeningDecisionBlock = "block"
+
+// screeningSeverity orders the decisions from least to most severe.
+var screeningSeverity = map[string]int{
+	ScreeningDecisionAllow:  0,
+	ScreeningDecisionReview: 1,
+	ScreeningDecisionBlock:  2,
+}
+
+// ScreeningInput is what the screening rules look at: the transaction about to be made, the account it is made on,
+// all accounts of the account's customer and the transactions made on them since the lookback of the pipeline.
+type ScreeningInput struct {
+	Transaction Transaction
+	Account     Account
+	Accounts    []Account
+	History     []Transaction
+	Now         time.Time
+}
+
+// ScreeningResult is the decision of a screening rule on a transaction, with the reason for it.
+type ScreeningResult struct {
+	Rule     string `json:"rule"`
+	Decision string `json:"decision"`
+	Reason   string `json:"reason"`
+}
+
+// ScreeningRule is a check that a transaction is put through before it is made.
+type ScreeningRule interface {
+	Name() string
+	Lookback() time.Duration //how far back the transaction history the rule looks at goes
+	Screen(ScreeningInput) ScreeningResult
+}
+
+// Screening is the outcome of putting a transaction through a screening pipeline: the most severe decision of its
+// rules, and the results of the rules that did not allow the transaction.
+type Screening struct {
+	Decision string
+	Results  []ScreeningResult
+}
+
+// ScreeningPipeline is the list of rules that transactions are put through before they are made.
+type ScreeningPipeline []ScreeningRule
+
+// DefaultScreeningPipeline returns the rules used when no screening rules file is configured.
+func DefaultScreeningPipeline() ScreeningPipeline {
+	return ScreeningPipeline{
+		VelocityRule{MaxWithdrawals: 5, WindowMinutes: 10, Decision: ScreeningDecisionBlock},
+		AmountSpikeRule{Factor: 5, MinHistory: 3, HistoryDays: 90, Decision: ScreeningDecisionReview},
+		NewAccountWithdrawalRule{AccountAgeHours: 48, Decision: ScreeningDecisionReview},
+		DepositThenWithdrawRule{WindowMinutes: 60, Ratio: 0.8, Decision: ScreeningDecisionReview},
+	}
+}
+
+// ScreeningRulesFile is the content of a screening rules file. Rules that are left out of the file are not used.
+type ScreeningRulesFile struct {
+	Velocity             *VelocityRule             `json:"velocity"`
+	AmountSpike          *AmountSpikeRule          `json:"amount_spike"`
+	NewAccountWithdrawal *NewAccountWithdrawalRule `json:"new_account_withdrawal"`
+	DepositThenWithdraw  *DepositThenWithdrawRule  `json:"deposit_then_withdraw"`
+}
+
+// LoadScreeningPipeline reads the screening rules from the JSON file at the given path. The file should contain an
+// object with a key per rule used (see build/package/fraud/rules.json), each giving the rule's thresholds and its
+// "decision", "review" or "block", when a transaction breaks it.
+func LoadScreeningPipeline(path string) (ScreeningPipeline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file ScreeningRulesFile
+	if err = json.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+
+	pipeline := make(ScreeningPipeline, 0)
+	if file.Velocity != nil {
+		if file.Velocity.MaxWithdrawals < 1 || file.Velocity.WindowMinutes < 1 {
+			return nil, errors.New("velocity rule needs a positive max_withdrawals and window_minutes")
+		}
+		if err = checkScreeningDecision(file.Velocity.Name(), file.Velocity.Decision); err != nil {
+			return nil, err
+		}
+		pipeline = append(pipeline, *file.Velocity)
+	}
+	if file.AmountSpike != nil {
+		if file.AmountSpike.Factor <= 1 || file.AmountSpike.MinHistory < 1 || file.AmountSpike.HistoryDays < 1 {
+			return nil, errors.New("amount spike rule needs a factor above 1 and a positive min_history and history_days")
+		}
+		if err = checkScreeningDecision(file.AmountSpike.Name(), file.AmountSpike.Decision); err != nil {
+			return nil, err
+		}
+		pipeline = append(pipeline, *file.AmountSpike)
+	}
+	if file.NewAccountWithdrawal != nil {
+		if file.NewAccountWithdrawal.AccountAgeHours < 1 {
+			return nil, errors.New("new account withdrawal rule needs a positive account_age_hours")
+		}
+		if err = checkScreeningDecision(file.NewAccountWithdrawal.Name(), file.NewAccountWithdrawal.Decision); err != nil {
+			return nil, err
+		}
+		pipeline = append(pipeline, *file.NewAccountWithdrawal)
+	}
+	if file.DepositThenWithdraw != nil {
+		if file.DepositThenWithdraw.WindowMinutes < 1 || file.DepositThenWithdraw.Ratio <= 0 {
+			return nil, errors.New("deposit then withdraw rule needs a positive window_minutes and ratio")
+		}
+		if err = checkScreeningDecision(file.DepositThenWithdraw.Name(), file.DepositThenWithdraw.Decision); err != nil {
+			return nil, err
+		}
+		pipeline = append(pipeline, *file.DepositThenWithdraw)
+	}
+
+	return pipeline, nil
+}
+
+// Lookback returns how far back the transaction history that the pipeline's rules look at goes.
+func (p ScreeningPipeline) Lookback() time.Duration {
+	var lookback time.Duration
+	for _, rule := range p {
+		if rule.Lookback() > lookback {
+			lookback = rule.Lookback()
+		}
+	}
+	return lookback
+}
+
+// Screen puts the given transaction through every rule of the pipeline. A rule with a decision other than review or
+// block is taken to allow the transaction.
+func (p ScreeningPipeline) Screen(input ScreeningInput) Screening {
+	screening := Screening{Decision: ScreeningDecisionAllow, Results: make([]ScreeningResult, 0)}
+	for _, rule := range p {
+		result := rule.Screen(input)
+		if result.Decision != ScreeningDecisionReview && result.Decision != ScreeningDecisionBlock {
+			continue
+		}
+		screening.Results = append(screening.Results, result)
+		if screeningSeverity[result.Decision] > screeningSeverity[screening.Decision] {
+			screening.Decision = result.Decision
+		}
+	}
+	return screening
+}
+
+// VelocityRule stops a customer from making more than the given number of withdrawals within the given number of
+// minutes.
+type VelocityRule struct {
+	MaxWithdrawals int    `json:"max_withdrawals"`
+	WindowMinutes  int    `json:"window_minutes"`
+	Decision       string `json:"decision"`
+}
+
+func (r VelocityRule) Name() string {
+	return "velocity"
+}
+
+func (r VelocityRule) Lookback() time.Duration {
+	return time.Duration(r.WindowMinutes) * time.Minute
+}
+
+func (r VelocityRule) Screen(input ScreeningInput) ScreeningResult {
+	if input.Transaction.TransactionType != dto.TransactionTypeWithdrawal {
+		return allow(r)
+	}
+
+	since := input.Now.Add(-r.Lookback())
+	count := 1 //the transaction being screened
+	for _, t := range input.History {
+		if t.TransactionType == dto.TransactionTypeWithdrawal && !transactionTime(t).Before(since) {
+			count++
+		}
+	}
+	if count <= r.MaxWithdrawals {
+		return allow(r)
+	}
+	return ScreeningResult{r.Name(), r.Decision,
+		fmt.Sprintf("%d withdrawals within %d minutes, more than the %d allowed", count, r.WindowMinutes, r.MaxWithdrawals)}
+}
+
+// AmountSpikeRule flags withdrawals of more than the given factor times the average of the customer's withdrawals in
+// the same currency over the given number of days. Customers with fewer withdrawals than the given minimum are not
+// checked, since their average says little.
+type AmountSpikeRule struct {
+	Factor      float64 `json:"factor"`
+	MinHistory  int     `json:"min_history"`
+	HistoryDays int     `json:"history_days"`
+	Decision    string  `json:"decision"`
+}
+
+func (r AmountSpikeRule) Name() string {
+	return "amount_spike"
+}
+
+func (r AmountSpikeRule) Lookback() time.Duration {
+	return time.Duration(r.HistoryDays) * 24 * time.Hour
+}
+
+func (r AmountSpikeRule) Screen(input ScreeningInput) ScreeningResult {
+	if input.Transaction.TransactionType != dto.TransactionTypeWithdrawal {
+		return allow(r)
+	}
+
+	currencies := make(map[string]string)
+	for _, a := range input.Accounts {
+		currencies[a.AccountId] = a.Currency
+	}
+
+	since := input.Now.Add(-r.Lookback())
+	count, total := 0, 0.0
+	for _, t := range input.History {
+		if t.TransactionType == dto.TransactionTypeWithdrawal && currencies[t.AccountId] == input.Account.Currency &&
+			!transactionTime(t).Before(since) {
+			count++
+			total += t.Amount
+		}
+	}
+	if count < r.MinHistory {
+		return allow(r)
+	}
+	average := total / float64(count)
+	if input.Transaction.Amount <= r.Factor*average {
+		return allow(r)
+	}
+	return ScreeningResult{r.Name(), r.Decision, fmt.Sprintf("Withdrawal of %.2f is more than %g times the average "+
+		"withdrawal of %.2f over the last %d days", input.Transaction.Amount, r.Factor, average, r.HistoryDays)}
+}
+
+// NewAccountWithdrawalRule flags withdrawals from accounts opened less than the given number of hours ago.
+type NewAccountWithdrawalRule struct {
+	AccountAgeHours int    `json:"account_age_hours"`
+	Decision        string `json:"decision"`
+}
+
+func (r NewAccountWithdrawalRule) Name() string {
+	return "new_account_withdrawal"
+}
+
+func (r NewAccountWithdrawalRule) Lookback() time.Duration {
+	return 0
+}
+
+func (r NewAccountWithdrawalRule) Screen(input ScreeningInput) ScreeningResult {
+	if input.Transaction.TransactionType != dto.TransactionTypeWithdrawal {
+		return allow(r)
+	}
+
+	opened, err := time.Parse(clock.FormatDateTime, input.Account.OpeningDate)
+	if err != nil || !input.Now.Before(opened.Add(time.Duration(r.AccountAgeHours)*time.Hour)) {
+		return allow(r)
+	}
+	return ScreeningResult{r.Name(), r.Decision,
+		fmt.Sprintf("Withdrawal from account opened on %s, less than %d hours ago", input.Account.OpeningDate, r.AccountAgeHours)}
+}
+
+// DepositThenWithdrawRule flags withdrawals that take at least the given ratio of the money paid into the account
+// within the given number of minutes, a sign of the account being used to pass money through.
+type DepositThenWithdrawRule struct {
+	WindowMinutes int     `json:"window_minutes"`
+	Ratio         float64 `json:"ratio"`
+	Decision      string  `json:"decision"`
+}
+
+func (r DepositThenWithdrawRule) Name() string {
+	return "deposit_then_withdraw"
+}
+
+func (r DepositThenWithdrawRule) Lookback() time.Duration {
+	return time.Duration(r.WindowMinutes) * time.Minute
+}
+
+func (r DepositThenWithdrawRule) Screen(input ScreeningInput) ScreeningResult {
+	if input.Transaction.TransactionType != dto.TransactionTypeWithdrawal {
+		return allow(r)
+	}
+
+	since := input.Now.Add(-r.Lookback())
+	deposited := 0.0
+	for _, t := range input.History {
+		if t.AccountId == input.Account.AccountId && !transactionTime(t).Before(since) &&
+			(t.TransactionType == dto.TransactionTypeDeposit || t.TransactionType == dto.TransactionTypeTransferIn) {
+			deposited += t.Amount
+		}
+	}
+	if deposited == 0 || input.Transaction.Amount < r.Ratio*deposited {
+		return allow(r)
+	}
+	return ScreeningResult{r.Name(), r.Decision, fmt.Sprintf("Withdrawal of %.2f within %d minutes of %.2f being "+
+		"paid into the account", input.Transaction.Amount, r.WindowMinutes, deposited)}
+}
+
+// checkScreeningDecision checks that the given decision of a rule in a screening rules file is review or block.
+func checkScreeningDecision(rule string, decision string) error {
+	if decision != ScreeningDecisionReview && decision != ScreeningDecisionBlock {
+		return fmt.Errorf("%s rule has decision %q instead of review or block", rule, decision)
+	}
+	return nil
+}
+
+func allow(r ScreeningRule) ScreeningResult {
+	return ScreeningResult{Rule: r.Name(), Decision: ScreeningDecisionAllow}
+}
+
+// transactionTime returns when the given transaction was made, or the zero time if its date cannot be read.
+func transactionTime(t Transaction) time.Time {
+	made, _ := time.Parse(clock.FormatDateTime, t.TransactionDate)
+	return made
+}
@@ -0,0 +1,144 @@
+package domain
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/jmoiron/sqlx"
+	"strconv"
+	"strings"
+)
+
+//Server
+
+// selectHistorySql selects the columns of transactions together with the account they were made on.
+const selectHistorySql = "SELECT t.transaction_id, t.account_id, t.amount, t.transaction_type, t.transaction_date " +
+	"FROM transactions t JOIN accounts a ON t.account_id = a.account_id"
+
+type ScreeningRepositoryDb struct { //DB (adapter)
+	client dbExecutor
+}
+
+func NewScreeningRepositoryDb(dbClient *sqlx.DB) ScreeningRepositoryDb {
+	return ScreeningRepositoryDb{dbClient}
+}
+
+// FindHistory retrieves the transactions made on all accounts of the customer with the given id since the given
+// date, oldest first.
+func (d ScreeningRepositoryDb) FindHistory(customerId string, since string) ([]Transaction, *errs.AppError) { //DB implements repo
+	return findHistory(d.client, selectHistorySql, customerId, since)
+}
+
+// SaveCase creates a new entry in the database for the given fraud case, sets its ID using the database-generated ID
+// and returns the case.
+func (d ScreeningRepositoryDb) SaveCase(f FraudCase) (*FraudCase, *errs.AppError) { //DB implements repo
+	insertSql := "INSERT INTO fraud_cases (customer_id, account_id, transaction_type, amount, transaction_id, " +
+		"decision, rules, results, status, resolution, note, creation_date, resolution_date) " +
+		"VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+	result, err := d.client.Exec(insertSql, f.CustomerId, f.AccountId, f.TransactionType, f.Amount, f.TransactionId,
+		f.Decision, f.Rules, f.Results, f.Status, f.Resolution, f.Note, f.CreationDate, f.ResolutionDate)
+	if err != nil {
+		logger.Error("Error while creating new fraud case: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		logger.Error("Error while getting id of newly inserted fraud case: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	f.CaseId = strconv.FormatInt(id, 10)
+
+	return &f, nil
+}
+
+// FindCaseById retrieves the fraud case with the given id.
+func (d ScreeningRepositoryDb) FindCaseById(caseId string) (*FraudCase, *errs.AppError) { //DB implements repo
+	return findFraudCase(d.client, "SELECT * FROM fraud_cases", caseId)
+}
+
+// FindCases retrieves up to the filter's limit of the fraud cases matching it, oldest first.
+func (d ScreeningRepositoryDb) FindCases(filter FraudCaseFilter) ([]FraudCase, *errs.AppError) { //DB implements repo
+	return findFraudCases(d.client, "SELECT * FROM fraud_cases", filter)
+}
+
+// findFraudCases retrieves up to the filter's limit of the fraud cases matching it with the given select statement,
+// oldest first.
+func findFraudCases(client dbExecutor, selectSql string, filter FraudCaseFilter) ([]FraudCase, *errs.AppError) {
+	conditions := make([]string, 0)
+	args := make([]interface{}, 0)
+	if filter.Status != "" {
+		conditions = append(conditions, "status = ?")
+		args = append(args, filter.Status)
+	}
+	if filter.AfterId != "" {
+		conditions = append(conditions, "case_id > ?")
+		args = append(args, filter.AfterId)
+	}
+
+	if len(conditions) > 0 {
+		selectSql += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	selectSql += " ORDER BY case_id LIMIT ?"
+	args = append(args, filter.Limit)
+
+	cases := make([]FraudCase, 0)
+	if err := client.Select(&cases, client.Rebind(selectSql), args...); err != nil {
+		logger.Error("Error while retrieving fraud cases: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return cases, nil
+}
+
+// ResolveCase saves the resolution of the given fraud case, as long as the case is still open. It returns a conflict
+// error if the case was meanwhile closed by someone else.
+func (d ScreeningRepositoryDb) ResolveCase(f FraudCase) *errs.AppError { //DB implements repo
+	return resolveFraudCase(d.client, f)
+}
+
+// findHistory retrieves the transactions made on all accounts of the customer with the given id since the given date
+// with the given select statement, oldest first.
+func findHistory(client dbExecutor, selectSql string, customerId string, since string) ([]Transaction, *errs.AppError) {
+	transactions := make([]Transaction, 0)
+	selectSql += " WHERE a.customer_id = ? AND t.transaction_date >= ? ORDER BY t.transaction_id"
+	if err := client.Select(&transactions, client.Rebind(selectSql), customerId, since); err != nil {
+		logger.Error("Error while retrieving transaction history of customer: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return transactions, nil
+}
+
+// findFraudCase retrieves the fraud case with the given id with the given select statement.
+func findFraudCase(client dbExecutor, selectSql string, caseId string) (*FraudCase, *errs.AppError) {
+	var f FraudCase
+	if err := client.Get(&f, client.Rebind(selectSql+" WHERE case_id = ?"), caseId); err != nil {
+		logger.Error("Error while retrieving fraud case: " + err.Error())
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.NewNotFoundError("Fraud case not found")
+		}
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return &f, nil
+}
+
+func resolveFraudCase(client dbExecutor, f FraudCase) *errs.AppError {
+	updateSql := "UPDATE fraud_cases SET status = ?, resolution = ?, note = ?, resolution_date = ? " +
+		"WHERE case_id = ? AND status = ?"
+	result, err := client.Exec(client.Rebind(updateSql), f.Status, f.Resolution, f.Note, f.ResolutionDate, f.CaseId,
+		dto.FraudCaseStatusOpen)
+	if err != nil {
+		logger.Error("Error while resolving fraud case: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		logger.Error("Error while getting number of fraud cases resolved: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	if rowsAffected == 0 {
+		return errs.NewConflictError("Fraud case is already closed")
+	}
+	return nil
+}
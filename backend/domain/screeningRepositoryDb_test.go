@@ -0,0 +1,191 @@
+package domain
+
+import (
+	"database/sql"
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"net/http"
+	"testing"
+)
+
+// These tests run on a real SQLite database seeded with the demo data, since the filters and history of fraud cases
+// are only meaningful when the SQL is executed, which go-sqlmock never does.
+
+var screeningRepoDb ScreeningRepositoryDb
+var screeningAccRepoDb AccountRepositoryDb
+
+func setupScreeningRepoDbTest(t *testing.T) {
+	logger.MuteLogger()
+	client := openSQLiteDb(t)
+	screeningRepoDb = NewScreeningRepositoryDb(client)
+	screeningAccRepoDb = NewAccountRepositoryDb(client)
+}
+
+// saveDummyFraudCases makes a withdrawal from account 95470 and saves a case for reviewing it, followed by a case for
+// a blocked withdrawal that was never made.
+func saveDummyFraudCases(t *testing.T) (*Transaction, *FraudCase, *FraudCase) {
+	clk := clock.StaticClock{}
+	transaction, err := screeningAccRepoDb.Transact(NewTransaction("95470", 100, dto.TransactionTypeWithdrawal, clk))
+	if err != nil {
+		t.Fatal("Expected no error but got error while making transaction: " + err.Message)
+	}
+	account, err := screeningAccRepoDb.FindById("95470")
+	if err != nil {
+		t.Fatal("Expected no error but got error while finding account: " + err.Message)
+	}
+
+	screening := Screening{Decision: ScreeningDecisionReview,
+		Results: []ScreeningResult{{"amount_spike", ScreeningDecisionReview, "Withdrawal is large"}}}
+	reviewed := NewFraudCase(*transaction, *account, screening, clk)
+	reviewed.TransactionId = sql.NullString{String: transaction.TransactionId, Valid: true}
+	savedReviewed, err := screeningRepoDb.SaveCase(reviewed)
+	if err != nil {
+		t.Fatal("Expected no error but got error while saving fraud case: " + err.Message)
+	}
+
+	screening.Decision = ScreeningDecisionBlock
+	blocked := NewFraudCase(NewTransaction("95470", 5000, dto.TransactionTypeWithdrawal, clk), *account, screening, clk)
+	savedBlocked, err := screeningRepoDb.SaveCase(blocked)
+	if err != nil {
+		t.Fatal("Expected no error but got error while saving fraud case: " + err.Message)
+	}
+	return transaction, savedReviewed, savedBlocked
+}
+
+func TestScreeningRepositoryDb_FindHistory_returns_customerTransactions_since_date(t *testing.T) {
+	//Arrange
+	setupScreeningRepoDbTest(t)
+	transaction, _, _ := saveDummyFraudCases(t)
+
+	//Act
+	history, err := screeningRepoDb.FindHistory("2000", transaction.TransactionDate)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if len(history) != 1 || history[0].TransactionId != transaction.TransactionId || history[0].Amount != 100 {
+		t.Errorf("Expected only transaction %s but got %+v", transaction.TransactionId, history)
+	}
+}
+
+func TestScreeningRepositoryDb_FindHistory_returns_noTransactions_when_none_since_date(t *testing.T) {
+	//Arrange
+	setupScreeningRepoDbTest(t)
+	saveDummyFraudCases(t)
+
+	//Act
+	history, err := screeningRepoDb.FindHistory("2000", "2006-01-02 15:04:06")
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if len(history) != 0 {
+		t.Errorf("Expected no transactions but got %+v", history)
+	}
+}
+
+func TestScreeningRepositoryDb_FindHistory_returns_noTransactions_of_otherCustomers(t *testing.T) {
+	//Arrange
+	setupScreeningRepoDbTest(t)
+	transaction, _, _ := saveDummyFraudCases(t)
+
+	//Act
+	history, err := screeningRepoDb.FindHistory("2001", transaction.TransactionDate)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if len(history) != 0 {
+		t.Errorf("Expected no transactions but got %+v", history)
+	}
+}
+
+func TestScreeningRepositoryDb_FindCaseById_returns_savedCase(t *testing.T) {
+	//Arrange
+	setupScreeningRepoDbTest(t)
+	_, reviewed, _ := saveDummyFraudCases(t)
+
+	//Act
+	found, err := screeningRepoDb.FindCaseById(reviewed.CaseId)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if *found != *reviewed {
+		t.Errorf("Expected case %+v but got %+v", *reviewed, *found)
+	}
+}
+
+func TestScreeningRepositoryDb_FindCases_returns_cases_after_givenId(t *testing.T) {
+	//Arrange
+	setupScreeningRepoDbTest(t)
+	_, reviewed, blocked := saveDummyFraudCases(t)
+
+	//Act
+	page, err := screeningRepoDb.FindCases(FraudCaseFilter{Status: dto.FraudCaseStatusOpen, AfterId: reviewed.CaseId,
+		Limit: 10})
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if len(page) != 1 || page[0].CaseId != blocked.CaseId {
+		t.Errorf("Expected page of case %s but got %+v", blocked.CaseId, page)
+	}
+}
+
+func TestScreeningRepositoryDb_ResolveCase_closes_case(t *testing.T) {
+	//Arrange
+	setupScreeningRepoDbTest(t)
+	_, reviewed, blocked := saveDummyFraudCases(t)
+	resolved, err := reviewed.Resolve(dto.ResolveFraudCaseRequest{Resolution: dto.FraudCaseResolutionFraud},
+		clock.StaticClock{})
+	if err != nil {
+		t.Fatal("Expected no error but got error while resolving case: " + err.Message)
+	}
+
+	//Act
+	err = screeningRepoDb.ResolveCase(resolved)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	open, err := screeningRepoDb.FindCases(FraudCaseFilter{Status: dto.FraudCaseStatusOpen, Limit: 10})
+	if err != nil {
+		t.Fatal("Expected no error but got error while finding cases: " + err.Message)
+	}
+	if len(open) != 1 || open[0].CaseId != blocked.CaseId {
+		t.Errorf("Expected only case %s to be open but got %+v", blocked.CaseId, open)
+	}
+}
+
+func TestScreeningRepositoryDb_ResolveCase_returns_conflictError_when_case_alreadyResolved(t *testing.T) {
+	//Arrange
+	setupScreeningRepoDbTest(t)
+	_, reviewed, _ := saveDummyFraudCases(t)
+	resolved, err := reviewed.Resolve(dto.ResolveFraudCaseRequest{Resolution: dto.FraudCaseResolutionFraud},
+		clock.StaticClock{})
+	if err != nil {
+		t.Fatal("Expected no error but got error while resolving case: " + err.Message)
+	}
+	if err = screeningRepoDb.ResolveCase(resolved); err != nil {
+		t.Fatal("Expected no error but got error while resolving case: " + err.Message)
+	}
+
+	//Act
+	err = screeningRepoDb.ResolveCase(resolved)
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none")
+	}
+	if err.Code != http.StatusConflict {
+		t.Errorf("Expected status code %d but got %d", http.StatusConflict, err.Code)
+	}
+}
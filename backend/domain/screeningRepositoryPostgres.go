@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/jmoiron/sqlx"
+)
+
+//Server
+
+// selectFraudCasesPostgresSql selects the columns of fraud cases, formatting dates the same way MySQL returns them.
+const selectFraudCasesPostgresSql = "SELECT case_id, customer_id, account_id, transaction_type, amount, " +
+	"transaction_id, decision, rules, results, status, resolution, note, " +
+	"to_char(creation_date, 'YYYY-MM-DD HH24:MI:SS') AS creation_date, " +
+	"to_char(resolution_date, 'YYYY-MM-DD HH24:MI:SS') AS resolution_date FROM fraud_cases"
+
+// selectHistoryPostgresSql is the PostgreSQL equivalent of selectHistorySql.
+const selectHistoryPostgresSql = "SELECT t.transaction_id, t.account_id, t.amount, t.transaction_type, " +
+	"to_char(t.transaction_date, 'YYYY-MM-DD HH24:MI:SS') AS transaction_date " +
+	"FROM transactions t JOIN accounts a ON t.account_id = a.account_id"
+
+type ScreeningRepositoryPostgres struct { //DB (adapter)
+	client dbExecutor
+}
+
+func NewScreeningRepositoryPostgres(dbClient *sqlx.DB) ScreeningRepositoryPostgres {
+	return ScreeningRepositoryPostgres{dbClient}
+}
+
+// FindHistory retrieves the transactions made on all accounts of the customer with the given id since the given
+// date, oldest first.
+func (d ScreeningRepositoryPostgres) FindHistory(customerId string, since string) ([]Transaction, *errs.AppError) { //DB implements repo
+	return findHistory(d.client, selectHistoryPostgresSql, customerId, since)
+}
+
+// SaveCase creates a new entry in the database for the given fraud case, sets its ID using the ID returned by the
+// database and returns the case.
+func (d ScreeningRepositoryPostgres) SaveCase(f FraudCase) (*FraudCase, *errs.AppError) { //DB implements repo
+	insertSql := "INSERT INTO fraud_cases (customer_id, account_id, transaction_type, amount, transaction_id, " +
+		"decision, rules, results, status, resolution, note, creation_date, resolution_date) " +
+		"VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13) RETURNING case_id"
+	err := d.client.Get(&f.CaseId, insertSql, f.CustomerId, f.AccountId, f.TransactionType, f.Amount,
+		f.TransactionId, f.Decision, f.Rules, f.Results, f.Status, f.Resolution, f.Note, f.CreationDate,
+		f.ResolutionDate)
+	if err != nil {
+		logger.Error("Error while creating new fraud case: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return &f, nil
+}
+
+// FindCaseById retrieves the fraud case with the given id.
+func (d ScreeningRepositoryPostgres) FindCaseById(caseId string) (*FraudCase, *errs.AppError) { //DB implements repo
+	return findFraudCase(d.client, selectFraudCasesPostgresSql, caseId)
+}
+
+// FindCases retrieves up to the filter's limit of the fraud cases matching it, oldest first.
+func (d ScreeningRepositoryPostgres) FindCases(filter FraudCaseFilter) ([]FraudCase, *errs.AppError) { //DB implements repo
+	return findFraudCases(d.client, selectFraudCasesPostgresSql, filter)
+}
+
+// ResolveCase saves the resolution of the given fraud case, as long as the case is still open. It returns a conflict
+// error if the case was meanwhile closed by someone else.
+func (d ScreeningRepositoryPostgres) ResolveCase(f FraudCase) *errs.AppError { //DB implements repo
+	return resolveFraudCase(d.client, f)
+}
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"database/sql"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/jmoiron/sqlx"
+	"testing"
+)
+
+// Test common variables and inputs
+var screeningRepoPostgres ScreeningRepositoryPostgres
+
+func setupScreeningRepoPostgresTest(t *testing.T) func() {
+	teardown := setupDB(t)
+	screeningRepoPostgres = NewScreeningRepositoryPostgres(sqlx.NewDb(db, "postgres"))
+	return teardown
+}
+
+func TestScreeningRepositoryPostgres_SaveCase_returns_newCase_with_returnedId(t *testing.T) {
+	//Arrange
+	teardown := setupScreeningRepoPostgresTest(t)
+	defer teardown()
+
+	f := FraudCase{CustomerId: dummyCustomerId, AccountId: dummyAccountId, TransactionType: dto.TransactionTypeWithdrawal,
+		Amount: 9000, Status: dto.FraudCaseStatusOpen, CreationDate: dummyDate}
+	mockDB.ExpectQuery("INSERT INTO fraud_cases (customer_id, account_id, transaction_type, amount, transaction_id, "+
+		"decision, rules, results, status, resolution, note, creation_date, resolution_date) "+
+		"VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13) RETURNING case_id").
+		WithArgs(f.CustomerId, f.AccountId, f.TransactionType, f.Amount, f.TransactionId, f.Decision, f.Rules,
+			f.Results, f.Status, f.Resolution, f.Note, f.CreationDate, f.ResolutionDate).
+		WillReturnRows(sqlmock.NewRows([]string{"case_id"}).AddRow(6))
+
+	//Act
+	saved, err := screeningRepoPostgres.SaveCase(f)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if saved.CaseId != "6" {
+		t.Errorf("Expected case ID 6 but got %s", saved.CaseId)
+	}
+}
+
+func TestScreeningRepositoryPostgres_ResolveCase_returns_conflictError_when_case_alreadyClosed(t *testing.T) {
+	//Arrange
+	teardown := setupScreeningRepoPostgresTest(t)
+	defer teardown()
+
+	f := FraudCase{CaseId: "6", Status: dto.FraudCaseStatusClosed,
+		ResolutionDate: sql.NullString{String: dummyDate, Valid: true}}
+	mockDB.ExpectExec("UPDATE fraud_cases SET status = $1, resolution = $2, note = $3, resolution_date = $4 "+
+		"WHERE case_id = $5 AND status = $6").
+		WithArgs(f.Status, f.Resolution, f.Note, f.ResolutionDate, f.CaseId, dto.FraudCaseStatusOpen).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	//Act
+	err := screeningRepoPostgres.ResolveCase(f)
+
+	//Assert
+	if err == nil || err.Message != "Fraud case is already closed" {
+		t.Errorf("Expected conflict error but got %v", err)
+	}
+}
@@ -0,0 +1,182 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// Test common variables and inputs
+var screeningNow = time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func getDummyScreeningInput(amount float64, history ...Transaction) ScreeningInput {
+	account := Account{AccountId: dummyAccountId, CustomerId: dummyCustomerId, Currency: dto.DefaultCurrency,
+		OpeningDate: "2020-01-01 00:00:00"}
+	return ScreeningInput{
+		Transaction: Transaction{AccountId: dummyAccountId, Amount: amount, TransactionType: dto.TransactionTypeWithdrawal,
+			TransactionDate: "2024-03-01 12:00:00"},
+		Account:  account,
+		Accounts: []Account{account, {AccountId: "1980", CustomerId: dummyCustomerId, Currency: "INR"}},
+		History:  history,
+		Now:      screeningNow,
+	}
+}
+
+// dummyHistoryTransaction returns a transaction of the given type and amount made on the given account the given
+// number of minutes before screeningNow.
+func dummyHistoryTransaction(accountId string, transactionType string, amount float64, minutesAgo int) Transaction {
+	return Transaction{AccountId: accountId, Amount: amount, TransactionType: transactionType,
+		TransactionDate: screeningNow.Add(-time.Duration(minutesAgo) * time.Minute).Format("2006-01-02 15:04:05")}
+}
+
+func TestScreeningRules_Screen(t *testing.T) {
+	velocity := VelocityRule{MaxWithdrawals: 3, WindowMinutes: 10, Decision: ScreeningDecisionBlock}
+	spike := AmountSpikeRule{Factor: 5, MinHistory: 3, HistoryDays: 90, Decision: ScreeningDecisionReview}
+	newAccount := NewAccountWithdrawalRule{AccountAgeHours: 48, Decision: ScreeningDecisionReview}
+	depositThenWithdraw := DepositThenWithdrawRule{WindowMinutes: 60, Ratio: 0.8, Decision: ScreeningDecisionReview}
+
+	withdrawal := func(accountId string, amount float64, minutesAgo int) Transaction {
+		return dummyHistoryTransaction(accountId, dto.TransactionTypeWithdrawal, amount, minutesAgo)
+	}
+	deposit := func(amount float64, minutesAgo int) Transaction {
+		return dummyHistoryTransaction(dummyAccountId, dto.TransactionTypeDeposit, amount, minutesAgo)
+	}
+	newlyOpened := getDummyScreeningInput(100)
+	newlyOpened.Account.OpeningDate = "2024-02-29 13:00:00"
+	deposited := getDummyScreeningInput(900, deposit(1000, 30))
+	deposited.Transaction.TransactionType = dto.TransactionTypeDeposit
+
+	tests := []struct {
+		name     string
+		rule     ScreeningRule
+		input    ScreeningInput
+		expected string
+	}{
+		{"velocity within limit", velocity, getDummyScreeningInput(10, withdrawal(dummyAccountId, 10, 1),
+			withdrawal("1980", 10, 5), withdrawal(dummyAccountId, 10, 11)), ScreeningDecisionAllow},
+		{"velocity over limit", velocity, getDummyScreeningInput(10, withdrawal(dummyAccountId, 10, 1),
+			withdrawal("1980", 10, 5), withdrawal(dummyAccountId, 10, 10)), ScreeningDecisionBlock},
+		{"spike with too little history", spike, getDummyScreeningInput(1000, withdrawal(dummyAccountId, 10, 60),
+			withdrawal(dummyAccountId, 10, 120)), ScreeningDecisionAllow},
+		{"spike within factor", spike, getDummyScreeningInput(100, withdrawal(dummyAccountId, 10, 60),
+			withdrawal(dummyAccountId, 20, 120), withdrawal(dummyAccountId, 30, 180)), ScreeningDecisionAllow},
+		{"spike over factor", spike, getDummyScreeningInput(101, withdrawal(dummyAccountId, 10, 60),
+			withdrawal(dummyAccountId, 20, 120), withdrawal(dummyAccountId, 30, 180)), ScreeningDecisionReview},
+		{"spike ignores other currencies", spike, getDummyScreeningInput(101, withdrawal(dummyAccountId, 10, 60),
+			withdrawal(dummyAccountId, 20, 120), withdrawal("1980", 9000, 180)), ScreeningDecisionAllow},
+		{"old account", newAccount, getDummyScreeningInput(100), ScreeningDecisionAllow},
+		{"newly opened account", newAccount, newlyOpened, ScreeningDecisionReview},
+		{"withdrawal of little of deposit", depositThenWithdraw, getDummyScreeningInput(500, deposit(1000, 30)),
+			ScreeningDecisionAllow},
+		{"withdrawal of most of deposit", depositThenWithdraw, getDummyScreeningInput(900, deposit(1000, 30)),
+			ScreeningDecisionReview},
+		{"withdrawal long after deposit", depositThenWithdraw, getDummyScreeningInput(900, deposit(1000, 61)),
+			ScreeningDecisionAllow},
+		{"deposit after deposit", depositThenWithdraw, deposited, ScreeningDecisionAllow},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Act
+			result := tc.rule.Screen(tc.input)
+
+			//Assert
+			if result.Decision != tc.expected {
+				t.Errorf("Expected decision %s but got %+v", tc.expected, result)
+			}
+			if result.Rule != tc.rule.Name() {
+				t.Errorf("Expected result of rule %s but got %s", tc.rule.Name(), result.Rule)
+			}
+		})
+	}
+}
+
+func TestScreeningPipeline_Screen_returns_mostSevereDecision(t *testing.T) {
+	//Arrange
+	pipeline := DefaultScreeningPipeline()
+	input := getDummyScreeningInput(900, dummyHistoryTransaction(dummyAccountId, dto.TransactionTypeDeposit, 1000, 5))
+	for i := 0; i < 5; i++ {
+		input.History = append(input.History, dummyHistoryTransaction("1980", dto.TransactionTypeWithdrawal, 1, i))
+	}
+
+	//Act
+	screening := pipeline.Screen(input)
+
+	//Assert
+	if screening.Decision != ScreeningDecisionBlock {
+		t.Errorf("Expected decision %s but got %s", ScreeningDecisionBlock, screening.Decision)
+	}
+	if len(screening.Results) != 2 || screening.Results[0].Rule != "velocity" ||
+		screening.Results[1].Rule != "deposit_then_withdraw" {
+		t.Errorf("Expected results of velocity and deposit_then_withdraw rules but got %+v", screening.Results)
+	}
+	if pipeline.Lookback() != 90*24*time.Hour {
+		t.Errorf("Expected lookback of 90 days but got %v", pipeline.Lookback())
+	}
+}
+
+func TestLoadScreeningPipeline(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       string
+		expectedRules int
+		expectErr     bool
+	}{
+		{"sample file", "", 4, false},
+		{"only some rules", `{"velocity": {"max_withdrawals": 3, "window_minutes": 5, "decision": "review"}}`, 1, false},
+		{"unknown decision", `{"velocity": {"max_withdrawals": 3, "window_minutes": 5, "decision": "allow"}}`, 0, true},
+		{"missing threshold", `{"amount_spike": {"factor": 5, "decision": "review"}}`, 0, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Arrange
+			path := filepath.Join("..", "build", "package", "fraud", "rules.json")
+			if tc.content != "" {
+				path = filepath.Join(t.TempDir(), "rules.json")
+				if err := os.WriteFile(path, []byte(tc.content), 0644); err != nil {
+					t.Fatal("Error during testing setup: " + err.Error())
+				}
+			}
+
+			//Act
+			pipeline, err := LoadScreeningPipeline(path)
+
+			//Assert
+			if tc.expectErr && err == nil {
+				t.Error("Expected error but got none")
+			}
+			if !tc.expectErr && (err != nil || len(pipeline) != tc.expectedRules) {
+				t.Errorf("Expected %d rules but got %v and error %v", tc.expectedRules, pipeline, err)
+			}
+		})
+	}
+}
+
+func TestFraudCase_Resolve_returns_conflictError_when_case_closed(t *testing.T) {
+	//Arrange
+	screening := Screening{Decision: ScreeningDecisionReview,
+		Results: []ScreeningResult{{"amount_spike", ScreeningDecisionReview, "Withdrawal is large"}}}
+	input := getDummyScreeningInput(100)
+	fraudCase := NewFraudCase(input.Transaction, input.Account, screening, clock.StaticClock{})
+	request := dto.ResolveFraudCaseRequest{Resolution: dto.FraudCaseResolutionLegitimate}
+
+	//Act
+	resolved, err := fraudCase.Resolve(request, clock.StaticClock{})
+	_, againErr := resolved.Resolve(request, clock.StaticClock{})
+
+	//Assert
+	if err != nil || resolved.Status != dto.FraudCaseStatusClosed || !resolved.ResolutionDate.Valid {
+		t.Errorf("Expected closed case but got %+v and error %v", resolved, err)
+	}
+	if againErr == nil {
+		t.Error("Expected error but got none while resolving closed case")
+	}
+	if response := resolved.ToDTO(); len(response.Results) != 1 || response.Results[0].Rule != "amount_spike" ||
+		fraudCase.Rules != "amount_spike" {
+		t.Errorf("Expected amount_spike result but got %+v", response.Results)
+	}
+}
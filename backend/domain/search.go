@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"strconv"
+)
+
+//Business Domain
+
+// SearchCursor marks where a page of search results ended: the value of the sort field and the ID of the last result.
+// The next page starts right after it, so results are neither skipped nor repeated when rows are added in between.
+type SearchCursor struct {
+	Sort  string `json:"s"` //sort the cursor was made for, including the "-" prefix for descending order
+	Value string `json:"v"`
+	Id    string `json:"id"`
+}
+
+// Encode turns the cursor into an opaque string for clients to send back.
+func (c SearchCursor) Encode() string {
+	cursor, _ := json.Marshal(c) //structs of strings can always be marshalled
+	return base64.RawURLEncoding.EncodeToString(cursor)
+}
+
+// DecodeSearchCursor reads a cursor returned by Encode, checking that it was made for the given sort.
+func DecodeSearchCursor(encoded string, sort string) (*SearchCursor, *errs.AppError) {
+	var cursor SearchCursor
+	decoded, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err == nil {
+		err = json.Unmarshal(decoded, &cursor)
+	}
+	if err != nil || cursor.Sort != sort || cursor.Id == "" {
+		logger.Error("Error while decoding search cursor: cursor is malformed or for another sort")
+		return nil, errs.NewValidationError("Cursor is invalid or was made for another sort order.")
+	}
+	return &cursor, nil
+}
+
+// CustomerSearch selects customers. Empty fields match every customer, and text fields match any part of the value,
+// ignoring case.
+type CustomerSearch struct {
+	Name    string
+	Email   string
+	Country string
+	Status  string //database value
+	Sort    string //field to sort by, optionally prefixed with "-" for descending order
+	After   *SearchCursor
+	Limit   int
+}
+
+// NextCursor returns the cursor that continues the given search after the given customer.
+func (s CustomerSearch) NextCursor(c Customer) SearchCursor {
+	values := map[string]string{"name": c.Name, "email": c.Email, "country": c.Country}
+	return SearchCursor{Sort: s.Sort, Value: values[trimDescending(s.Sort)], Id: c.Id}
+}
+
+// AccountSearch selects accounts. Empty fields match every account, and ranges are inclusive.
+type AccountSearch struct {
+	CustomerId  string
+	AccountType string
+	Currency    string
+	Status      string //database value
+	MinBalance  *float64
+	MaxBalance  *float64
+	OpenedFrom  string
+	OpenedTo    string
+	Sort        string //field to sort by, optionally prefixed with "-" for descending order
+	After       *SearchCursor
+	Limit       int
+}
+
+// NextCursor returns the cursor that continues the given search after the given account.
+func (s AccountSearch) NextCursor(a Account) SearchCursor {
+	values := map[string]string{
+		"amount":       strconv.FormatFloat(a.Amount, 'f', -1, 64),
+		"opening_date": a.OpeningDate,
+		"customer_id":  a.CustomerId,
+	}
+	return SearchCursor{Sort: s.Sort, Value: values[trimDescending(s.Sort)], Id: a.AccountId}
+}
+
+func trimDescending(sort string) string {
+	if len(sort) > 0 && sort[0] == '-' {
+		return sort[1:]
+	}
+	return sort
+}
+
+//Server
+
+//go:generate mockgen -destination=../mocks/domain/mock_searchRepository.go -package=domain github.com/aliciatay-zls/banking/backend/domain SearchRepository
+type SearchRepository interface { //repo (secondary port)
+	SearchCustomers(CustomerSearch) ([]Customer, *errs.AppError)
+	SearchAccounts(AccountSearch) ([]Account, *errs.AppError)
+}
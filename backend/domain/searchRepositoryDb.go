@@ -0,0 +1,173 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/jmoiron/sqlx"
+	"strconv"
+	"strings"
+)
+
+//Server
+
+// customerSortColumns and accountSortColumns are the columns that search results can be sorted by, keyed by the
+// names used in requests. Only these fixed strings are ever put into the SQL of a search.
+var customerSortColumns = map[string]string{
+	"customer_id": "customer_id",
+	"name":        "name",
+	"email":       "email",
+	"country":     "country",
+}
+
+var accountSortColumns = map[string]string{
+	"account_id":   "account_id",
+	"amount":       "amount",
+	"opening_date": "opening_date",
+	"customer_id":  "customer_id",
+}
+
+type SearchRepositoryDb struct { //DB (adapter)
+	client dbExecutor
+}
+
+func NewSearchRepositoryDb(dbClient *sqlx.DB) SearchRepositoryDb {
+	return SearchRepositoryDb{dbClient}
+}
+
+// SearchCustomers retrieves up to the search's limit of customers matching the given search, in its sort order.
+func (d SearchRepositoryDb) SearchCustomers(search CustomerSearch) ([]Customer, *errs.AppError) { //DB implements repo
+	return searchCustomers(d.client, "SELECT customer_id, name, date_of_birth, email, country, zipcode, status FROM customers",
+		search)
+}
+
+// SearchAccounts retrieves up to the search's limit of accounts matching the given search, in its sort order.
+func (d SearchRepositoryDb) SearchAccounts(search AccountSearch) ([]Account, *errs.AppError) {
+	return searchAccounts(d.client, "SELECT * FROM accounts", search)
+}
+
+// searchCustomers retrieves up to the search's limit of customers matching the given search with the given select
+// statement, in the search's sort order.
+func searchCustomers(client dbExecutor, selectSql string, search CustomerSearch) ([]Customer, *errs.AppError) {
+	var q searchQuery
+	q.contains("name", search.Name)
+	q.contains("email", search.Email)
+	q.contains("country", search.Country)
+	q.equals("status", search.Status)
+
+	var after interface{}
+	if search.After != nil {
+		after = search.After.Value
+	}
+	selectSql = q.build(selectSql, "customer_id", customerSortColumns, search.Sort, search.After, after, search.Limit)
+
+	customers := make([]Customer, 0)
+	if err := client.Select(&customers, client.Rebind(selectSql), q.args...); err != nil {
+		logger.Error("Error while searching customers: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return customers, nil
+}
+
+// searchAccounts retrieves up to the search's limit of accounts matching the given search with the given select
+// statement, in the search's sort order.
+func searchAccounts(client dbExecutor, selectSql string, search AccountSearch) ([]Account, *errs.AppError) {
+	var q searchQuery
+	q.equals("customer_id", search.CustomerId)
+	q.equals("account_type", search.AccountType)
+	q.equals("currency", search.Currency)
+	q.equals("status", search.Status)
+	if search.MinBalance != nil {
+		q.where("amount >= ?", *search.MinBalance)
+	}
+	if search.MaxBalance != nil {
+		q.where("amount <= ?", *search.MaxBalance)
+	}
+	if search.OpenedFrom != "" {
+		q.where("opening_date >= ?", search.OpenedFrom)
+	}
+	if search.OpenedTo != "" {
+		q.where("opening_date <= ?", search.OpenedTo)
+	}
+
+	var after interface{}
+	if search.After != nil {
+		after = search.After.Value
+		if trimDescending(search.Sort) == "amount" { //compared as a number, not as text
+			amount, err := strconv.ParseFloat(search.After.Value, 64)
+			if err != nil {
+				logger.Error("Error while reading amount of search cursor: " + err.Error())
+				return nil, errs.NewValidationError("Cursor is invalid or was made for another sort order.")
+			}
+			after = amount
+		}
+	}
+	selectSql = q.build(selectSql, "account_id", accountSortColumns, search.Sort, search.After, after, search.Limit)
+
+	accounts := make([]Account, 0)
+	if err := client.Select(&accounts, client.Rebind(selectSql), q.args...); err != nil {
+		logger.Error("Error while searching accounts: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return accounts, nil
+}
+
+// searchQuery collects the conditions of a search and their arguments. Conditions are only ever made of fixed column
+// names and placeholders, while the values searched for are passed as arguments.
+type searchQuery struct {
+	conditions []string
+	args       []interface{}
+}
+
+func (q *searchQuery) where(condition string, args ...interface{}) {
+	q.conditions = append(q.conditions, condition)
+	q.args = append(q.args, args...)
+}
+
+func (q *searchQuery) equals(column string, value string) {
+	if value != "" {
+		q.where(column+" = ?", value)
+	}
+}
+
+// contains matches rows whose column contains the given value, ignoring case. Wildcards in the value are escaped, so
+// that they match themselves.
+func (q *searchQuery) contains(column string, value string) {
+	if value != "" {
+		escaped := strings.NewReplacer("!", "!!", "%", "!%", "_", "!_").Replace(strings.ToLower(value))
+		q.where("LOWER("+column+") LIKE ? ESCAPE '!'", "%"+escaped+"%")
+	}
+}
+
+// build returns the SQL of the search from the given SELECT statement, sorted by the column for the given sort and
+// then by the ID column, which makes the order total. With a cursor, only rows after the cursor's row are selected,
+// using the given value of the sort column in place of the cursor's.
+func (q *searchQuery) build(selectSql string, idColumn string, sortColumns map[string]string, sort string,
+	cursor *SearchCursor, after interface{}, limit int) string {
+	column, ok := sortColumns[trimDescending(sort)]
+	if !ok {
+		column = idColumn
+	}
+	direction, comparison := "ASC", ">"
+	if strings.HasPrefix(sort, "-") {
+		direction, comparison = "DESC", "<"
+	}
+
+	if cursor != nil {
+		if column == idColumn {
+			q.where(idColumn+" "+comparison+" ?", cursor.Id)
+		} else {
+			q.where("("+column+" "+comparison+" ? OR ("+column+" = ? AND "+idColumn+" "+comparison+" ?))",
+				after, after, cursor.Id)
+		}
+	}
+
+	if len(q.conditions) > 0 {
+		selectSql += " WHERE " + strings.Join(q.conditions, " AND ")
+	}
+	selectSql += " ORDER BY " + column + " " + direction
+	if column != idColumn {
+		selectSql += ", " + idColumn + " " + direction
+	}
+	q.args = append(q.args, limit)
+	return selectSql + " LIMIT ?"
+}
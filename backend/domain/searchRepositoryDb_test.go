@@ -0,0 +1,158 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"testing"
+)
+
+// These tests run on a real SQLite database seeded with the demo data, since the filters, sorting and cursors of a
+// search are only applied when the SQL is executed, which go-sqlmock never does.
+
+var searchRepoDb SearchRepositoryDb
+
+// setupSearchRepoDbTest opens a new SQLite database and adds a customer whose name has wildcard characters to it.
+func setupSearchRepoDbTest(t *testing.T) {
+	logger.MuteLogger()
+	client := openSQLiteDb(t)
+	searchRepoDb = NewSearchRepositoryDb(client)
+	client.MustExec("INSERT INTO customers VALUES (2006,'Ann_100%','1990-01-01','ann@somemail.com','India','110001',1)")
+}
+
+// searchAccountsAfter returns the page of accounts found by the given search after the given account.
+func searchAccountsAfter(t *testing.T, search AccountSearch, last Account) []Account {
+	cursor := search.NextCursor(last)
+	search.After = &cursor
+	accounts, err := searchRepoDb.SearchAccounts(search)
+	if err != nil {
+		t.Fatal("Expected no error but got error while searching accounts: " + err.Message)
+	}
+	return accounts
+}
+
+func TestSearchRepositoryDb_SearchCustomers_filters_by_email_and_status(t *testing.T) {
+	//Arrange
+	setupSearchRepoDbTest(t)
+
+	//Act
+	customers, err := searchRepoDb.SearchCustomers(CustomerSearch{Email: "SOMEMAIL", Status: "0", Limit: 10})
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if len(customers) != 2 {
+		t.Errorf("Expected 2 inactive customers but got %+v", customers)
+	}
+}
+
+func TestSearchRepositoryDb_SearchCustomers_filters_by_country_and_sorts_by_name(t *testing.T) {
+	//Arrange
+	setupSearchRepoDbTest(t)
+
+	//Act
+	customers, err := searchRepoDb.SearchCustomers(CustomerSearch{Country: "united", Sort: "-name", Limit: 10})
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if len(customers) != 2 || customers[0].Name != "Ben" || customers[1].Name != "Arian" {
+		t.Errorf("Expected Ben then Arian but got %+v", customers)
+	}
+}
+
+func TestSearchRepositoryDb_SearchCustomers_matches_percentSign_literally(t *testing.T) {
+	//Arrange
+	setupSearchRepoDbTest(t)
+
+	//Act
+	customers, err := searchRepoDb.SearchCustomers(CustomerSearch{Name: "%", Limit: 10})
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if len(customers) != 1 || customers[0].Id != "2006" {
+		t.Errorf("Expected only customer 2006 but got %+v", customers)
+	}
+}
+
+func TestSearchRepositoryDb_SearchCustomers_matches_underscore_literally(t *testing.T) {
+	//Arrange
+	setupSearchRepoDbTest(t)
+
+	//Act
+	customers, err := searchRepoDb.SearchCustomers(CustomerSearch{Name: "n_1", Limit: 10})
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if len(customers) != 1 || customers[0].Id != "2006" {
+		t.Errorf("Expected only customer 2006 but got %+v", customers)
+	}
+}
+
+func TestSearchRepositoryDb_SearchAccounts_filters_by_ranges(t *testing.T) {
+	//Arrange
+	setupSearchRepoDbTest(t)
+	min, max := 5000.0, 7000.0
+
+	//Act
+	accounts, err := searchRepoDb.SearchAccounts(AccountSearch{MinBalance: &min, MaxBalance: &max,
+		OpenedFrom: "2020-08-09 00:00:00", OpenedTo: "2020-08-09 23:59:59", Sort: "-amount", Limit: 10})
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if len(accounts) != 2 || accounts[0].AccountId != "95472" || accounts[1].AccountId != "95473" {
+		t.Errorf("Expected accounts 95472 and 95473 but got %+v", accounts)
+	}
+}
+
+func TestSearchRepositoryDb_SearchAccounts_pages_with_cursor(t *testing.T) {
+	//Arrange
+	setupSearchRepoDbTest(t)
+	search := AccountSearch{CustomerId: "2001", Currency: "USD", Sort: "customer_id", Limit: 1}
+
+	//Act
+	first, err := searchRepoDb.SearchAccounts(search)
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if len(first) != 1 {
+		t.Fatalf("Expected first page of 1 account but got %+v", first)
+	}
+	second := searchAccountsAfter(t, search, first[0])
+	if len(second) != 1 {
+		t.Fatalf("Expected second page of 1 account but got %+v", second)
+	}
+	third := searchAccountsAfter(t, search, second[0])
+
+	//Assert
+	if first[0].AccountId != "95472" || second[0].AccountId != "95473" || len(third) != 0 {
+		t.Errorf("Expected pages of 95472, 95473 and nothing but got %+v, %+v and %+v", first, second, third)
+	}
+}
+
+func TestSearchRepositoryDb_SearchAccounts_pages_by_amount_descending(t *testing.T) {
+	//Arrange
+	setupSearchRepoDbTest(t)
+	search := AccountSearch{Sort: "-amount", Limit: 2}
+	first, err := searchRepoDb.SearchAccounts(search)
+	if err != nil {
+		t.Fatal("Expected no error but got error while searching accounts: " + err.Message)
+	}
+	if len(first) != 2 {
+		t.Fatalf("Expected first page of 2 accounts but got %+v", first)
+	}
+
+	//Act
+	second := searchAccountsAfter(t, search, first[1])
+
+	//Assert
+	if len(second) != 2 || second[0].AccountId != "95473" || second[1].AccountId != "95471" {
+		t.Errorf("Expected page of 95473 and 95471 but got %+v", second)
+	}
+}
@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/jmoiron/sqlx"
+)
+
+//Server
+
+type SearchRepositoryPostgres struct { //DB (adapter)
+	client dbExecutor
+}
+
+func NewSearchRepositoryPostgres(dbClient *sqlx.DB) SearchRepositoryPostgres {
+	return SearchRepositoryPostgres{dbClient}
+}
+
+// SearchCustomers retrieves up to the search's limit of customers matching the given search, in its sort order.
+func (d SearchRepositoryPostgres) SearchCustomers(search CustomerSearch) ([]Customer, *errs.AppError) { //DB implements repo
+	return searchCustomers(d.client, selectCustomersPostgresSql, search)
+}
+
+// SearchAccounts retrieves up to the search's limit of accounts matching the given search, in its sort order. Since
+// opening dates are formatted in the same order as they sort, sorting by the formatted column gives the same order.
+func (d SearchRepositoryPostgres) SearchAccounts(search AccountSearch) ([]Account, *errs.AppError) {
+	return searchAccounts(d.client, selectAccountsPostgresSql, search)
+}
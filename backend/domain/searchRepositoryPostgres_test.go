@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"testing"
+)
+
+// Test common variables and inputs
+var searchRepoPostgres SearchRepositoryPostgres
+
+func setupSearchRepoPostgresTest(t *testing.T) func() {
+	teardown := setupDB(t)
+	searchRepoPostgres = NewSearchRepositoryPostgres(sqlx.NewDb(db, "postgres"))
+	return teardown
+}
+
+func TestSearchRepositoryPostgres_SearchAccounts_numbers_placeholders_of_search(t *testing.T) {
+	//Arrange
+	teardown := setupSearchRepoPostgresTest(t)
+	defer teardown()
+
+	min := 5000.0
+	search := AccountSearch{CustomerId: "2001", MinBalance: &min, OpenedFrom: "2020-08-09 00:00:00", Sort: "-amount",
+		Limit: 10}
+	mockDB.ExpectQuery(selectAccountsPostgresSql+" WHERE customer_id = $1 AND amount >= $2 AND opening_date >= $3 "+
+		"ORDER BY amount DESC, account_id DESC LIMIT $4").
+		WithArgs(search.CustomerId, min, search.OpenedFrom, search.Limit).
+		WillReturnRows(sqlmock.NewRows([]string{"account_id", "opening_date"}).AddRow(95472, "2020-08-09 10:35:22"))
+
+	//Act
+	accounts, err := searchRepoPostgres.SearchAccounts(search)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if len(accounts) != 1 || accounts[0].AccountId != "95472" || accounts[0].OpeningDate != "2020-08-09 10:35:22" {
+		t.Errorf("Expected account 95472 opened on 2020-08-09 10:35:22 but got %+v", accounts)
+	}
+	if err := mockDB.ExpectationsWereMet(); err != nil {
+		t.Error(err.Error())
+	}
+}
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"net/http"
+	"testing"
+)
+
+func TestDecodeSearchCursor_returns_cursor_when_encoded_for_same_sort(t *testing.T) {
+	//Arrange
+	expectedCursor := AccountSearch{Sort: "-amount"}.NextCursor(Account{AccountId: "95470", Amount: 6823.23})
+
+	//Act
+	actualCursor, err := DecodeSearchCursor(expectedCursor.Encode(), "-amount")
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while decoding cursor: " + err.Message)
+	}
+	if *actualCursor != expectedCursor || actualCursor.Value != "6823.23" {
+		t.Errorf("Expected cursor %+v but got %+v", expectedCursor, *actualCursor)
+	}
+}
+
+func TestDecodeSearchCursor_returns_validationError_when_cursor_invalid(t *testing.T) {
+	logger.MuteLogger()
+	otherSortCursor := CustomerSearch{Sort: "name"}.NextCursor(Customer{Id: "2000", Name: "Steve"}).Encode()
+	tests := []struct {
+		name    string
+		encoded string
+	}{
+		{"not base64", "not a cursor!"},
+		{"not json", "bm90IGpzb24"},
+		{"other sort", otherSortCursor},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Act
+			_, err := DecodeSearchCursor(tc.encoded, "-name")
+
+			//Assert
+			if err == nil || err.Code != http.StatusUnprocessableEntity {
+				t.Errorf("Expected validation error but got %v", err)
+			}
+		})
+	}
+}
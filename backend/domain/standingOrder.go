@@ -0,0 +1,248 @@
+package domain
+
+import (
+	"database/sql"
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"time"
+)
+
+//Business Domain
+
+const StandingOrderStatusActive = "active"
+const StandingOrderStatusCompleted = "completed"
+const StandingOrderStatusCancelled = "cancelled"
+const StandingOrderStatusFailed = "failed"
+
+const ExecutionStatusSucceeded = "succeeded"
+const ExecutionStatusFailed = "failed"
+
+// StandingOrderMaxRetries is how many more times a transfer that failed due to insufficient funds is attempted
+// before its occurrence is skipped, and StandingOrderRetryInterval is how long to wait after a failed attempt.
+const StandingOrderMaxRetries = 3
+const StandingOrderRetryInterval = 24 * time.Hour
+
+type StandingOrder struct { //business/domain object
+	StandingOrderId      string         `db:"standing_order_id"`
+	CustomerId           string         `db:"customer_id"`
+	AccountId            string         `db:"account_id"`
+	DestinationAccountId string         `db:"destination_account_id"`
+	Amount               float64        `db:"amount"`
+	ScheduleType         string         `db:"schedule_type"`
+	DayOfMonth           int            `db:"day_of_month"`
+	CronExpression       string         `db:"cron_expression"`
+	EndDate              sql.NullString `db:"end_date"`
+	MaxOccurrences       int            `db:"max_occurrences"` //0 if there is no limit
+	Occurrences          int            `db:"occurrences"`
+	NextRunDate          string         `db:"next_run_date"` //when the pending occurrence is scheduled for
+	RetryCount           int            `db:"retry_count"`   //failed attempts for the pending occurrence
+	RetryDate            sql.NullString `db:"retry_date"`    //when the pending occurrence is attempted again, if it failed
+	Status               string         `db:"status"`
+	CreationDate         string         `db:"creation_date"`
+}
+
+// NewStandingOrder creates an active standing order from the given request, scheduled to first run on or after the
+// request's start date, or after the current time if there is none. It returns a validation error if the schedule
+// is invalid or would never run.
+func NewStandingOrder(request dto.NewStandingOrderRequest, c clock.Clock) (*StandingOrder, *errs.AppError) {
+	order := StandingOrder{
+		CustomerId:           request.CustomerId,
+		AccountId:            request.AccountId,
+		DestinationAccountId: request.DestinationAccountId,
+		Amount:               request.Amount,
+		ScheduleType:         request.ScheduleType,
+		DayOfMonth:           request.DayOfMonth,
+		CronExpression:       request.CronExpression,
+		MaxOccurrences:       request.MaxOccurrences,
+		Status:               StandingOrderStatusActive,
+		CreationDate:         c.NowAsString(),
+	}
+
+	schedule, err := order.Schedule()
+	if err != nil {
+		logger.Error("Error while parsing standing order schedule: " + err.Error())
+		return nil, errs.NewValidationError("Schedule is invalid: " + err.Error())
+	}
+
+	now := c.Now()
+	after := now
+	if request.StartDate != "" {
+		startDate, _ := time.Parse(FormatDate, request.StartDate)
+		if startDate.Before(truncateToDate(now)) {
+			return nil, errs.NewValidationError("Start date cannot be in the past.")
+		}
+		after = startDate.Add(-time.Minute)
+	}
+	if request.EndDate != "" {
+		order.EndDate = sql.NullString{String: request.EndDate, Valid: true}
+	}
+
+	if !order.scheduleNext(schedule.Next(after)) {
+		return nil, errs.NewValidationError("Schedule would never run before the end date.")
+	}
+	return &order, nil
+}
+
+// Schedule returns the schedule the standing order runs on.
+func (o StandingOrder) Schedule() (Schedule, error) {
+	if o.ScheduleType == dto.StandingOrderScheduleCron {
+		return ParseCronSchedule(o.CronExpression)
+	}
+	return NewMonthlySchedule(o.DayOfMonth)
+}
+
+// IsDue checks whether the pending occurrence of the standing order should be attempted at the given time, taking
+// into account the wait after a failed attempt.
+func (o StandingOrder) IsDue(now time.Time) bool {
+	if o.Status != StandingOrderStatusActive {
+		return false
+	}
+	dueDate := o.NextRunDate
+	if o.RetryDate.Valid {
+		dueDate = o.RetryDate.String
+	}
+	due, err := time.Parse(clock.FormatDateTime, dueDate)
+	if err != nil {
+		return false
+	}
+	return !now.Before(due)
+}
+
+// Succeed counts the pending occurrence as done and moves on to the next one.
+func (o *StandingOrder) Succeed() {
+	o.Occurrences++
+	o.advance()
+}
+
+// Retry counts a failed attempt of the pending occurrence made at the given time, and schedules the next attempt
+// StandingOrderRetryInterval after it. Once StandingOrderMaxRetries more attempts have failed, the occurrence is
+// skipped without counting it and Retry returns false.
+func (o *StandingOrder) Retry(attemptedAt time.Time) bool {
+	if o.RetryCount < StandingOrderMaxRetries {
+		o.RetryCount++
+		o.RetryDate = sql.NullString{String: attemptedAt.Add(StandingOrderRetryInterval).Format(clock.FormatDateTime),
+			Valid: true}
+		return true
+	}
+	o.advance()
+	return false
+}
+
+func (o *StandingOrder) advance() {
+	o.RetryCount = 0
+	o.RetryDate = sql.NullString{}
+
+	schedule, err := o.Schedule()
+	if err != nil {
+		logger.Error("Error while parsing standing order schedule: " + err.Error())
+		o.Status = StandingOrderStatusFailed
+		return
+	}
+	current, _ := time.Parse(clock.FormatDateTime, o.NextRunDate)
+	if !o.scheduleNext(schedule.Next(current)) {
+		o.Status = StandingOrderStatusCompleted
+	}
+}
+
+// scheduleNext sets the given time as the next run of the standing order. It returns false if the standing order
+// should not run then, because the time is past the end date or all occurrences have been made.
+func (o *StandingOrder) scheduleNext(next time.Time) bool {
+	if next.IsZero() || (o.MaxOccurrences > 0 && o.Occurrences >= o.MaxOccurrences) {
+		return false
+	}
+	if o.EndDate.Valid {
+		endDate, _ := time.Parse(FormatDate, o.EndDate.String)
+		if !next.Before(endDate.AddDate(0, 0, 1)) {
+			return false
+		}
+	}
+	o.NextRunDate = next.Format(clock.FormatDateTime)
+	return true
+}
+
+// ToTransferRequestDTO returns the request for the transfer to be made for each occurrence.
+func (o StandingOrder) ToTransferRequestDTO() dto.TransferRequest {
+	return dto.TransferRequest{
+		AccountId:            o.AccountId,
+		CustomerId:           o.CustomerId,
+		DestinationAccountId: o.DestinationAccountId,
+		Amount:               o.Amount,
+	}
+}
+
+func (o StandingOrder) ToDTO() *dto.StandingOrderResponse {
+	response := &dto.StandingOrderResponse{
+		StandingOrderId:      o.StandingOrderId,
+		AccountId:            o.AccountId,
+		DestinationAccountId: o.DestinationAccountId,
+		Amount:               o.Amount,
+		ScheduleType:         o.ScheduleType,
+		DayOfMonth:           o.DayOfMonth,
+		CronExpression:       o.CronExpression,
+		EndDate:              o.EndDate.String,
+		MaxOccurrences:       o.MaxOccurrences,
+		Occurrences:          o.Occurrences,
+		Status:               o.Status,
+	}
+	if o.Status == StandingOrderStatusActive {
+		response.NextRunDate = o.NextRunDate
+	}
+	return response
+}
+
+type StandingOrderExecution struct { //business/domain object
+	ExecutionId     string         `db:"execution_id"`
+	StandingOrderId string         `db:"standing_order_id"`
+	ScheduledDate   string         `db:"scheduled_date"`
+	ExecutionDate   string         `db:"execution_date"`
+	Status          string         `db:"status"`
+	TransactionId   sql.NullString `db:"transaction_id"`
+	Message         string         `db:"message"`
+}
+
+// NewStandingOrderExecution records an attempt of the given standing order's pending occurrence, which succeeded
+// if a transaction ID is given and failed with the given message otherwise.
+func NewStandingOrderExecution(order StandingOrder, transactionId string, message string, c clock.Clock) StandingOrderExecution {
+	execution := StandingOrderExecution{
+		StandingOrderId: order.StandingOrderId,
+		ScheduledDate:   order.NextRunDate,
+		ExecutionDate:   c.NowAsString(),
+		Status:          ExecutionStatusFailed,
+		Message:         message,
+	}
+	if transactionId != "" {
+		execution.Status = ExecutionStatusSucceeded
+		execution.TransactionId = sql.NullString{String: transactionId, Valid: true}
+	}
+	return execution
+}
+
+func (e StandingOrderExecution) ToDTO() *dto.StandingOrderExecutionResponse {
+	return &dto.StandingOrderExecutionResponse{
+		ExecutionId:   e.ExecutionId,
+		ScheduledDate: e.ScheduledDate,
+		ExecutionDate: e.ExecutionDate,
+		Status:        e.Status,
+		TransactionId: e.TransactionId.String,
+		Message:       e.Message,
+	}
+}
+
+func truncateToDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+//Server
+
+//go:generate mockgen -destination=../mocks/domain/mock_standingOrderRepository.go -package=domain github.com/aliciatay-zls/banking/backend/domain StandingOrderRepository
+type StandingOrderRepository interface { //repo (secondary port)
+	Save(StandingOrder) (*StandingOrder, *errs.AppError)
+	FindAll(string) ([]StandingOrder, *errs.AppError)
+	FindById(string) (*StandingOrder, *errs.AppError)
+	FindDue(string) ([]StandingOrder, *errs.AppError)
+	UpdateStatus(string, string) *errs.AppError
+	SaveExecution(StandingOrder, StandingOrderExecution) *errs.AppError
+	FindExecutions(string) ([]StandingOrderExecution, *errs.AppError)
+}
@@ -0,0 +1,155 @@
+package domain
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/jmoiron/sqlx"
+	"strconv"
+)
+
+//Server
+
+type StandingOrderRepositoryDb struct { //DB (adapter)
+	client dbExecutor
+}
+
+func NewStandingOrderRepositoryDb(dbClient *sqlx.DB) StandingOrderRepositoryDb {
+	return StandingOrderRepositoryDb{dbClient}
+}
+
+// Save creates a new entry in the database for the given standing order, sets its ID using the database-generated
+// ID and returns the standing order.
+func (d StandingOrderRepositoryDb) Save(order StandingOrder) (*StandingOrder, *errs.AppError) { //DB implements repo
+	insertSql := "INSERT INTO standing_orders (customer_id, account_id, destination_account_id, amount, schedule_type, " +
+		"day_of_month, cron_expression, end_date, max_occurrences, occurrences, next_run_date, retry_count, status, " +
+		"creation_date) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+	result, err := d.client.Exec(insertSql,
+		order.CustomerId, order.AccountId, order.DestinationAccountId, order.Amount, order.ScheduleType,
+		order.DayOfMonth, order.CronExpression, order.EndDate, order.MaxOccurrences, order.Occurrences,
+		order.NextRunDate, order.RetryCount, order.Status, order.CreationDate)
+	if err != nil {
+		logger.Error("Error while creating new standing order: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		logger.Error("Error while getting id of newly inserted standing order: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	order.StandingOrderId = strconv.FormatInt(id, 10)
+
+	return &order, nil
+}
+
+// FindAll retrieves all standing orders set up by the customer with the given id, oldest first.
+func (d StandingOrderRepositoryDb) FindAll(customerId string) ([]StandingOrder, *errs.AppError) {
+	return findStandingOrders(d.client, "SELECT * FROM standing_orders", customerId)
+}
+
+// FindById retrieves the standing order with the given id.
+func (d StandingOrderRepositoryDb) FindById(id string) (*StandingOrder, *errs.AppError) {
+	return findStandingOrder(d.client, "SELECT * FROM standing_orders", id)
+}
+
+// FindDue retrieves all active standing orders whose pending occurrence is scheduled for the given time or earlier.
+func (d StandingOrderRepositoryDb) FindDue(now string) ([]StandingOrder, *errs.AppError) {
+	return findDueStandingOrders(d.client, "SELECT * FROM standing_orders", now)
+}
+
+// UpdateStatus sets the status of the standing order with the given id.
+func (d StandingOrderRepositoryDb) UpdateStatus(id string, status string) *errs.AppError {
+	return updateStandingOrderStatus(d.client, id, status)
+}
+
+// SaveExecution starts a database transaction, creates a new entry in the database for the given execution, updates
+// the given standing order's progress and commits the database transaction, so that the execution history always
+// matches the standing order.
+func (d StandingOrderRepositoryDb) SaveExecution(order StandingOrder, execution StandingOrderExecution) *errs.AppError {
+	return saveStandingOrderExecution(d.client, order, execution)
+}
+
+// FindExecutions retrieves the execution history of the standing order with the given id, oldest first.
+func (d StandingOrderRepositoryDb) FindExecutions(id string) ([]StandingOrderExecution, *errs.AppError) {
+	return findStandingOrderExecutions(d.client, "SELECT * FROM standing_order_executions", id)
+}
+
+// findStandingOrders, findStandingOrder and findDueStandingOrders retrieve standing orders with the given select
+// statement.
+func findStandingOrders(client dbExecutor, selectSql string, customerId string) ([]StandingOrder, *errs.AppError) {
+	orders := make([]StandingOrder, 0)
+	selectSql += " WHERE customer_id = ? ORDER BY standing_order_id"
+	if err := client.Select(&orders, client.Rebind(selectSql), customerId); err != nil {
+		logger.Error("Error while retrieving all standing orders of this customer: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return orders, nil
+}
+
+func findStandingOrder(client dbExecutor, selectSql string, id string) (*StandingOrder, *errs.AppError) {
+	var order StandingOrder
+	if err := client.Get(&order, client.Rebind(selectSql+" WHERE standing_order_id = ?"), id); err != nil {
+		logger.Error("Error while retrieving standing order: " + err.Error())
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.NewNotFoundError("Standing order not found")
+		}
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return &order, nil
+}
+
+func findDueStandingOrders(client dbExecutor, selectSql string, now string) ([]StandingOrder, *errs.AppError) {
+	orders := make([]StandingOrder, 0)
+	selectSql += " WHERE status = ? AND next_run_date <= ?"
+	if err := client.Select(&orders, client.Rebind(selectSql), StandingOrderStatusActive, now); err != nil {
+		logger.Error("Error while retrieving due standing orders: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return orders, nil
+}
+
+func updateStandingOrderStatus(client dbExecutor, id string, status string) *errs.AppError {
+	updateSql := "UPDATE standing_orders SET status = ? WHERE standing_order_id = ?"
+	if _, err := client.Exec(client.Rebind(updateSql), status, id); err != nil {
+		logger.Error("Error while updating status of standing order: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	return nil
+}
+
+func saveStandingOrderExecution(client dbExecutor, order StandingOrder, execution StandingOrderExecution) *errs.AppError {
+	return inTransaction(client, "saving standing order execution", func(tx dbExecutor) *errs.AppError {
+		insertSql := "INSERT INTO standing_order_executions (standing_order_id, scheduled_date, execution_date, status, " +
+			"transaction_id, message) VALUES (?, ?, ?, ?, ?, ?)"
+		_, err := tx.Exec(tx.Rebind(insertSql), execution.StandingOrderId, execution.ScheduledDate,
+			execution.ExecutionDate, execution.Status, execution.TransactionId, execution.Message)
+		if err != nil {
+			logger.Error("Error while creating new standing order execution: " + err.Error())
+			return errs.NewUnexpectedError("Unexpected database error")
+		}
+
+		updateSql := "UPDATE standing_orders SET occurrences = ?, next_run_date = ?, retry_count = ?, retry_date = ?, " +
+			"status = ? WHERE standing_order_id = ?"
+		_, err = tx.Exec(tx.Rebind(updateSql), order.Occurrences, order.NextRunDate, order.RetryCount, order.RetryDate,
+			order.Status, order.StandingOrderId)
+		if err != nil {
+			logger.Error("Error while updating standing order progress: " + err.Error())
+			return errs.NewUnexpectedError("Unexpected database error")
+		}
+		return nil
+	})
+}
+
+// findStandingOrderExecutions retrieves the execution history of the standing order with the given id with the given
+// select statement, oldest first.
+func findStandingOrderExecutions(client dbExecutor, selectSql string, id string) ([]StandingOrderExecution, *errs.AppError) {
+	executions := make([]StandingOrderExecution, 0)
+	selectSql += " WHERE standing_order_id = ? ORDER BY execution_id"
+	if err := client.Select(&executions, client.Rebind(selectSql), id); err != nil {
+		logger.Error("Error while retrieving standing order executions: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return executions, nil
+}
@@ -0,0 +1,264 @@
+package domain
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/jmoiron/sqlx"
+	"net/http"
+	"testing"
+)
+
+// Test common variables and inputs
+var standingOrderRepoDb StandingOrderRepositoryDb
+
+const insertStandingOrderExecutionsSql = "INSERT INTO standing_order_executions (standing_order_id, scheduled_date, " +
+	"execution_date, status, transaction_id, message) VALUES (?, ?, ?, ?, ?, ?)"
+const updateStandingOrdersProgressSql = "UPDATE standing_orders SET occurrences = ?, next_run_date = ?, retry_count = ?, " +
+	"retry_date = ?, status = ? WHERE standing_order_id = ?"
+const selectDueStandingOrdersSql = "SELECT * FROM standing_orders WHERE status = ? AND next_run_date <= ?"
+
+func setupStandingOrderRepoDbTest(t *testing.T) func() {
+	teardown := setupDB(t)
+	standingOrderRepoDb = NewStandingOrderRepositoryDb(sqlx.NewDb(db, driverName))
+	return teardown
+}
+
+func getDummyStandingOrderAndExecution() (StandingOrder, StandingOrderExecution) {
+	order := StandingOrder{StandingOrderId: "5", Occurrences: 1, NextRunDate: "2006-02-15 00:00:00", Status: StandingOrderStatusActive}
+	execution := StandingOrderExecution{StandingOrderId: "5", ScheduledDate: "2006-01-15 00:00:00", ExecutionDate: dummyDate,
+		Status: ExecutionStatusSucceeded, TransactionId: sql.NullString{String: dummyTransactionId, Valid: true}}
+	return order, execution
+}
+
+func TestStandingOrderRepositoryDb_FindDue_returns_activeOrders_scheduledBeforeNow(t *testing.T) {
+	//Arrange
+	teardown := setupStandingOrderRepoDbTest(t)
+	defer teardown()
+
+	rows := sqlmock.NewRows([]string{"standing_order_id", "account_id", "end_date", "next_run_date", "status"}).
+		AddRow("5", dummyAccountId, nil, "2006-01-02 00:00:00", StandingOrderStatusActive)
+	mockDB.ExpectQuery(selectDueStandingOrdersSql).WithArgs(StandingOrderStatusActive, dummyDate).WillReturnRows(rows)
+
+	//Act
+	orders, err := standingOrderRepoDb.FindDue(dummyDate)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing successful select: " + err.Message)
+	}
+	if len(orders) != 1 || orders[0].StandingOrderId != "5" || orders[0].EndDate.Valid {
+		t.Errorf("Expected 1 standing order with no end date but got %+v", orders)
+	}
+}
+
+func TestStandingOrderRepositoryDb_SaveExecution_rollsBack_when_updateStandingOrders_fails(t *testing.T) {
+	//Arrange
+	teardown := setupStandingOrderRepoDbTest(t)
+	defer teardown()
+
+	order, execution := getDummyStandingOrderAndExecution()
+	mockDB.ExpectBegin()
+	mockDB.ExpectExec(insertStandingOrderExecutionsSql).
+		WithArgs(execution.StandingOrderId, execution.ScheduledDate, execution.ExecutionDate, execution.Status, execution.TransactionId, execution.Message).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mockDB.ExpectExec(updateStandingOrdersProgressSql).
+		WithArgs(order.Occurrences, order.NextRunDate, order.RetryCount, order.RetryDate, order.Status, order.StandingOrderId).
+		WillReturnError(errors.New("some error message"))
+	mockDB.ExpectRollback()
+
+	//Act
+	actualErr := standingOrderRepoDb.SaveExecution(order, execution)
+
+	//Assert
+	if actualErr == nil {
+		t.Fatal("Expected error but got none while testing failed update")
+	}
+	if actualErr.Message != defaultExpectedErrMessage {
+		t.Errorf("Expected error message to be \"%s\" but got \"%s\"", defaultExpectedErrMessage, actualErr.Message)
+	}
+	if err := mockDB.ExpectationsWereMet(); err != nil {
+		t.Error(err.Error())
+	}
+}
+
+func TestStandingOrderRepositoryDb_SaveExecution_returns_nil_when_insert_and_update_succeed(t *testing.T) {
+	//Arrange
+	teardown := setupStandingOrderRepoDbTest(t)
+	defer teardown()
+
+	order, execution := getDummyStandingOrderAndExecution()
+	mockDB.ExpectBegin()
+	mockDB.ExpectExec(insertStandingOrderExecutionsSql).
+		WithArgs(execution.StandingOrderId, execution.ScheduledDate, execution.ExecutionDate, execution.Status, execution.TransactionId, execution.Message).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mockDB.ExpectExec(updateStandingOrdersProgressSql).
+		WithArgs(order.Occurrences, order.NextRunDate, order.RetryCount, order.RetryDate, order.Status, order.StandingOrderId).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mockDB.ExpectCommit()
+
+	//Act
+	err := standingOrderRepoDb.SaveExecution(order, execution)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing successful save: " + err.Message)
+	}
+	if err := mockDB.ExpectationsWereMet(); err != nil {
+		t.Error(err.Error())
+	}
+}
+
+// The following tests run on a real SQLite database seeded with the demo data, since which standing orders are due is
+// only decided when the SQL is executed, which go-sqlmock never does.
+
+// setupStandingOrderRepoSqliteTest opens a new SQLite database and saves a monthly standing order of account 95472 in
+// it.
+func setupStandingOrderRepoSqliteTest(t *testing.T) (StandingOrderRepositoryDb, *StandingOrder) {
+	logger.MuteLogger()
+	repo := NewStandingOrderRepositoryDb(openSQLiteDb(t))
+
+	request := dto.NewStandingOrderRequest{AccountId: "95472", CustomerId: "2001", DestinationAccountId: "95473",
+		Amount: 100, ScheduleType: dto.StandingOrderScheduleMonthly, DayOfMonth: 1, MaxOccurrences: 2}
+	newOrder, err := NewStandingOrder(request, clock.StaticClock{})
+	if err != nil {
+		t.Fatal("Expected no error but got error while creating standing order: " + err.Message)
+	}
+	order, err := repo.Save(*newOrder)
+	if err != nil {
+		t.Fatal("Expected no error but got error while saving standing order: " + err.Message)
+	}
+	return repo, order
+}
+
+func TestStandingOrderRepositoryDb_FindById_returns_savedOrder(t *testing.T) {
+	//Arrange
+	repo, order := setupStandingOrderRepoSqliteTest(t)
+
+	//Act
+	found, err := repo.FindById(order.StandingOrderId)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if *found != *order {
+		t.Errorf("Expected %+v but got %+v", *order, *found)
+	}
+}
+
+func TestStandingOrderRepositoryDb_FindById_returns_notFoundError_when_order_nonexistent(t *testing.T) {
+	//Arrange
+	repo, _ := setupStandingOrderRepoSqliteTest(t)
+
+	//Act
+	_, err := repo.FindById("1000")
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none")
+	}
+	if err.Code != http.StatusNotFound {
+		t.Errorf("Expected status code %d but got %d", http.StatusNotFound, err.Code)
+	}
+}
+
+func TestStandingOrderRepositoryDb_FindAll_returns_orders_of_customer(t *testing.T) {
+	//Arrange
+	repo, order := setupStandingOrderRepoSqliteTest(t)
+
+	//Act
+	orders, err := repo.FindAll("2001")
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if len(orders) != 1 || orders[0] != *order {
+		t.Errorf("Expected only standing order %+v but got %+v", *order, orders)
+	}
+}
+
+func TestStandingOrderRepositoryDb_FindDue_returns_noOrders_before_nextRunDate(t *testing.T) {
+	//Arrange
+	repo, order := setupStandingOrderRepoSqliteTest(t)
+
+	//Act
+	due, err := repo.FindDue(order.CreationDate)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if len(due) != 0 {
+		t.Errorf("Expected no due standing orders but got %d", len(due))
+	}
+}
+
+func TestStandingOrderRepositoryDb_FindDue_returns_order_on_nextRunDate(t *testing.T) {
+	//Arrange
+	repo, order := setupStandingOrderRepoSqliteTest(t)
+
+	//Act
+	due, err := repo.FindDue(order.NextRunDate)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if len(due) != 1 {
+		t.Errorf("Expected 1 due standing order but got %d", len(due))
+	}
+}
+
+func TestStandingOrderRepositoryDb_SaveExecution_records_execution_and_progress(t *testing.T) {
+	//Arrange
+	repo, order := setupStandingOrderRepoSqliteTest(t)
+	execution := NewStandingOrderExecution(*order, "", "Insufficient balance", clock.StaticClock{})
+	order.RetryCount = 1
+
+	//Act
+	err := repo.SaveExecution(*order, execution)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	executions, err := repo.FindExecutions(order.StandingOrderId)
+	if err != nil {
+		t.Fatal("Expected no error but got error while finding executions: " + err.Message)
+	}
+	if len(executions) != 1 || executions[0].Status != ExecutionStatusFailed || executions[0].TransactionId.Valid {
+		t.Errorf("Expected 1 failed execution but got %+v", executions)
+	}
+	found, err := repo.FindById(order.StandingOrderId)
+	if err != nil {
+		t.Fatal("Expected no error but got error while finding standing order: " + err.Message)
+	}
+	if found.RetryCount != 1 {
+		t.Errorf("Expected retry count 1 but got %d", found.RetryCount)
+	}
+}
+
+func TestStandingOrderRepositoryDb_UpdateStatus_sets_status(t *testing.T) {
+	//Arrange
+	repo, order := setupStandingOrderRepoSqliteTest(t)
+
+	//Act
+	err := repo.UpdateStatus(order.StandingOrderId, StandingOrderStatusCancelled)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	found, err := repo.FindById(order.StandingOrderId)
+	if err != nil {
+		t.Fatal("Expected no error but got error while finding standing order: " + err.Message)
+	}
+	if found.Status != StandingOrderStatusCancelled {
+		t.Errorf("Expected status %s but got %s", StandingOrderStatusCancelled, found.Status)
+	}
+}
@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/jmoiron/sqlx"
+)
+
+//Server
+
+// selectStandingOrdersPostgresSql selects the columns of standing orders, formatting dates the same way MySQL returns
+// them.
+const selectStandingOrdersPostgresSql = "SELECT standing_order_id, customer_id, account_id, destination_account_id, " +
+	"amount, schedule_type, day_of_month, cron_expression, to_char(end_date, 'YYYY-MM-DD') AS end_date, " +
+	"max_occurrences, occurrences, to_char(next_run_date, 'YYYY-MM-DD HH24:MI:SS') AS next_run_date, retry_count, " +
+	"to_char(retry_date, 'YYYY-MM-DD HH24:MI:SS') AS retry_date, status, " +
+	"to_char(creation_date, 'YYYY-MM-DD HH24:MI:SS') AS creation_date FROM standing_orders"
+
+// selectStandingOrderExecutionsPostgresSql selects the columns of standing order executions, formatting dates the same
+// way MySQL returns them.
+const selectStandingOrderExecutionsPostgresSql = "SELECT execution_id, standing_order_id, " +
+	"to_char(scheduled_date, 'YYYY-MM-DD HH24:MI:SS') AS scheduled_date, " +
+	"to_char(execution_date, 'YYYY-MM-DD HH24:MI:SS') AS execution_date, status, transaction_id, message " +
+	"FROM standing_order_executions"
+
+type StandingOrderRepositoryPostgres struct { //DB (adapter)
+	client dbExecutor
+}
+
+func NewStandingOrderRepositoryPostgres(dbClient *sqlx.DB) StandingOrderRepositoryPostgres {
+	return StandingOrderRepositoryPostgres{dbClient}
+}
+
+// Save creates a new entry in the database for the given standing order, sets its ID using the ID returned by the
+// database and returns the standing order.
+func (d StandingOrderRepositoryPostgres) Save(order StandingOrder) (*StandingOrder, *errs.AppError) { //DB implements repo
+	insertSql := "INSERT INTO standing_orders (customer_id, account_id, destination_account_id, amount, schedule_type, " +
+		"day_of_month, cron_expression, end_date, max_occurrences, occurrences, next_run_date, retry_count, status, " +
+		"creation_date) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14) RETURNING standing_order_id"
+	err := d.client.Get(&order.StandingOrderId, insertSql,
+		order.CustomerId, order.AccountId, order.DestinationAccountId, order.Amount, order.ScheduleType,
+		order.DayOfMonth, order.CronExpression, order.EndDate, order.MaxOccurrences, order.Occurrences,
+		order.NextRunDate, order.RetryCount, order.Status, order.CreationDate)
+	if err != nil {
+		logger.Error("Error while creating new standing order: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return &order, nil
+}
+
+// FindAll retrieves all standing orders set up by the customer with the given id, oldest first.
+func (d StandingOrderRepositoryPostgres) FindAll(customerId string) ([]StandingOrder, *errs.AppError) {
+	return findStandingOrders(d.client, selectStandingOrdersPostgresSql, customerId)
+}
+
+// FindById retrieves the standing order with the given id.
+func (d StandingOrderRepositoryPostgres) FindById(id string) (*StandingOrder, *errs.AppError) {
+	return findStandingOrder(d.client, selectStandingOrdersPostgresSql, id)
+}
+
+// FindDue retrieves all active standing orders whose pending occurrence is scheduled for the given time or earlier.
+func (d StandingOrderRepositoryPostgres) FindDue(now string) ([]StandingOrder, *errs.AppError) {
+	return findDueStandingOrders(d.client, selectStandingOrdersPostgresSql, now)
+}
+
+// UpdateStatus sets the status of the standing order with the given id.
+func (d StandingOrderRepositoryPostgres) UpdateStatus(id string, status string) *errs.AppError {
+	return updateStandingOrderStatus(d.client, id, status)
+}
+
+// SaveExecution starts a database transaction, creates a new entry in the database for the given execution, updates
+// the given standing order's progress and commits the database transaction, so that the execution history always
+// matches the standing order.
+func (d StandingOrderRepositoryPostgres) SaveExecution(order StandingOrder, execution StandingOrderExecution) *errs.AppError {
+	return saveStandingOrderExecution(d.client, order, execution)
+}
+
+// FindExecutions retrieves the execution history of the standing order with the given id, oldest first.
+func (d StandingOrderRepositoryPostgres) FindExecutions(id string) ([]StandingOrderExecution, *errs.AppError) {
+	return findStandingOrderExecutions(d.client, selectStandingOrderExecutionsPostgresSql, id)
+}
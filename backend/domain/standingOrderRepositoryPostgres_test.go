@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/jmoiron/sqlx"
+	"testing"
+)
+
+// Test common variables and inputs
+var standingOrderRepoPostgres StandingOrderRepositoryPostgres
+
+func setupStandingOrderRepoPostgresTest(t *testing.T) func() {
+	teardown := setupDB(t)
+	standingOrderRepoPostgres = NewStandingOrderRepositoryPostgres(sqlx.NewDb(db, "postgres"))
+	return teardown
+}
+
+func TestStandingOrderRepositoryPostgres_Save_returns_newOrder_with_returnedId(t *testing.T) {
+	//Arrange
+	teardown := setupStandingOrderRepoPostgresTest(t)
+	defer teardown()
+
+	order := StandingOrder{CustomerId: dummyCustomerId, AccountId: dummyAccountId, DestinationAccountId: "1980",
+		Amount: 100, ScheduleType: dto.StandingOrderScheduleMonthly, DayOfMonth: 15, NextRunDate: "2006-01-15 00:00:00",
+		Status: StandingOrderStatusActive, CreationDate: dummyDate}
+	mockDB.ExpectQuery("INSERT INTO standing_orders (customer_id, account_id, destination_account_id, amount, "+
+		"schedule_type, day_of_month, cron_expression, end_date, max_occurrences, occurrences, next_run_date, "+
+		"retry_count, status, creation_date) "+
+		"VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14) RETURNING standing_order_id").
+		WithArgs(order.CustomerId, order.AccountId, order.DestinationAccountId, order.Amount, order.ScheduleType,
+			order.DayOfMonth, order.CronExpression, order.EndDate, order.MaxOccurrences, order.Occurrences,
+			order.NextRunDate, order.RetryCount, order.Status, order.CreationDate).
+		WillReturnRows(sqlmock.NewRows([]string{"standing_order_id"}).AddRow(5))
+
+	//Act
+	saved, err := standingOrderRepoPostgres.Save(order)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if saved.StandingOrderId != "5" {
+		t.Errorf("Expected standing order ID 5 but got %s", saved.StandingOrderId)
+	}
+}
+
+func TestStandingOrderRepositoryPostgres_SaveExecution_savesExecution_and_progress_inOneTransaction(t *testing.T) {
+	//Arrange
+	teardown := setupStandingOrderRepoPostgresTest(t)
+	defer teardown()
+
+	order, execution := getDummyStandingOrderAndExecution()
+	mockDB.ExpectBegin()
+	mockDB.ExpectExec("INSERT INTO standing_order_executions (standing_order_id, scheduled_date, execution_date, "+
+		"status, transaction_id, message) VALUES ($1, $2, $3, $4, $5, $6)").
+		WithArgs(execution.StandingOrderId, execution.ScheduledDate, execution.ExecutionDate, execution.Status,
+			execution.TransactionId, execution.Message).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mockDB.ExpectExec("UPDATE standing_orders SET occurrences = $1, next_run_date = $2, retry_count = $3, "+
+		"retry_date = $4, status = $5 WHERE standing_order_id = $6").
+		WithArgs(order.Occurrences, order.NextRunDate, order.RetryCount, order.RetryDate, order.Status,
+			order.StandingOrderId).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mockDB.ExpectCommit()
+
+	//Act
+	err := standingOrderRepoPostgres.SaveExecution(order, execution)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if err := mockDB.ExpectationsWereMet(); err != nil {
+		t.Error(err.Error())
+	}
+}
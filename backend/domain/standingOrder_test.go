@@ -0,0 +1,172 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// getDefaultNewStandingOrderRequest returns a dto.NewStandingOrderRequest for the customer with id 2 wanting to
+// transfer 100 from the account with id 1977 to the account with id 1980 on the 15th of every month
+func getDefaultNewStandingOrderRequest() dto.NewStandingOrderRequest {
+	return dto.NewStandingOrderRequest{
+		AccountId:            dummyAccountId,
+		CustomerId:           dummyCustomerId,
+		DestinationAccountId: "1980",
+		Amount:               100,
+		ScheduleType:         dto.StandingOrderScheduleMonthly,
+		DayOfMonth:           15,
+	}
+}
+
+func TestNewStandingOrder_schedules_firstRun(t *testing.T) {
+	//Arrange
+	tests := []struct {
+		name      string
+		startDate string
+		expected  string
+	}{
+		{"no start date", "", "2006-01-15 00:00:00"},
+		{"start date before day of month", "2006-02-10", "2006-02-15 00:00:00"},
+		{"start date on day of month", "2006-02-15", "2006-02-15 00:00:00"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			request := getDefaultNewStandingOrderRequest()
+			request.StartDate = tc.startDate
+
+			//Act
+			order, err := NewStandingOrder(request, clock.StaticClock{})
+
+			//Assert
+			if err != nil {
+				t.Fatal("Expected no error but got error: " + err.Message)
+			}
+			if order.NextRunDate != tc.expected {
+				t.Errorf("Expected next run date %s but got %s", tc.expected, order.NextRunDate)
+			}
+			if order.Status != StandingOrderStatusActive {
+				t.Errorf("Expected status %s but got %s", StandingOrderStatusActive, order.Status)
+			}
+		})
+	}
+}
+
+func TestNewStandingOrder_returns_validationError_when_schedule_invalid(t *testing.T) {
+	//Arrange
+	tests := []struct {
+		name    string
+		modify  func(r *dto.NewStandingOrderRequest)
+		message string
+	}{
+		{"invalid cron", func(r *dto.NewStandingOrderRequest) {
+			r.ScheduleType = dto.StandingOrderScheduleCron
+			r.CronExpression = "0 0 * *"
+		}, "Schedule is invalid: cron expression should have 5 fields but has 4"},
+		{"start date in past", func(r *dto.NewStandingOrderRequest) { r.StartDate = "2006-01-01" }, "Start date cannot be in the past."},
+		{"end date before first run", func(r *dto.NewStandingOrderRequest) { r.EndDate = "2006-01-14" }, "Schedule would never run before the end date."},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			request := getDefaultNewStandingOrderRequest()
+			tc.modify(&request)
+
+			//Act
+			_, err := NewStandingOrder(request, clock.StaticClock{})
+
+			//Assert
+			if err == nil {
+				t.Fatal("Expected error but got none")
+			}
+			if err.Code != http.StatusUnprocessableEntity {
+				t.Errorf("Expected status code %d but got %d", http.StatusUnprocessableEntity, err.Code)
+			}
+			if err.Message != tc.message {
+				t.Errorf("Expected message \"%s\" but got \"%s\"", tc.message, err.Message)
+			}
+		})
+	}
+}
+
+func TestStandingOrder_IsDue_waits_retryInterval_after_failedAttempt(t *testing.T) {
+	//Arrange
+	order, _ := NewStandingOrder(getDefaultNewStandingOrderRequest(), clock.StaticClock{})
+	nextRun := time.Date(2006, 1, 15, 0, 0, 0, 0, time.UTC)
+	attemptedAt := nextRun.Add(72 * time.Hour) //attempted late, e.g. after downtime
+
+	//Act & Assert
+	if order.IsDue(nextRun.Add(-time.Second)) {
+		t.Error("Expected standing order to not be due before its next run")
+	}
+	if !order.IsDue(nextRun) {
+		t.Error("Expected standing order to be due at its next run")
+	}
+	order.Retry(attemptedAt)
+	if order.IsDue(attemptedAt.Add(time.Hour)) {
+		t.Error("Expected standing order to not be due before the retry interval has passed since the failed attempt")
+	}
+	if !order.IsDue(attemptedAt.Add(StandingOrderRetryInterval)) {
+		t.Error("Expected standing order to be due once the retry interval has passed since the failed attempt")
+	}
+}
+
+func TestStandingOrder_Retry_skipsOccurrence_when_retries_exhausted(t *testing.T) {
+	//Arrange
+	order, _ := NewStandingOrder(getDefaultNewStandingOrderRequest(), clock.StaticClock{})
+
+	//Act
+	for i := 0; i < StandingOrderMaxRetries; i++ {
+		if !order.Retry(time.Date(2006, 1, 15+i, 0, 0, 0, 0, time.UTC)) {
+			t.Fatalf("Expected retry %d to be allowed", i+1)
+		}
+	}
+	isRetried := order.Retry(time.Date(2006, 1, 18, 0, 0, 0, 0, time.UTC))
+
+	//Assert
+	if isRetried {
+		t.Error("Expected occurrence to be skipped but it was retried")
+	}
+	if order.NextRunDate != "2006-02-15 00:00:00" || order.RetryCount != 0 || order.RetryDate.Valid ||
+		order.Occurrences != 0 {
+		t.Errorf("Expected next occurrence with no retries or occurrences but got %+v", order)
+	}
+}
+
+func TestStandingOrder_Succeed_completes_when_endReached(t *testing.T) {
+	//Arrange
+	tests := []struct {
+		name   string
+		modify func(r *dto.NewStandingOrderRequest)
+	}{
+		{"max occurrences", func(r *dto.NewStandingOrderRequest) { r.MaxOccurrences = 2 }},
+		{"end date", func(r *dto.NewStandingOrderRequest) { r.EndDate = "2006-02-15" }},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			request := getDefaultNewStandingOrderRequest()
+			tc.modify(&request)
+			order, _ := NewStandingOrder(request, clock.StaticClock{})
+
+			//Act
+			order.Succeed()
+			statusAfterFirst := order.Status
+			order.Succeed()
+
+			//Assert
+			if statusAfterFirst != StandingOrderStatusActive {
+				t.Errorf("Expected status %s after first occurrence but got %s", StandingOrderStatusActive, statusAfterFirst)
+			}
+			if order.Status != StandingOrderStatusCompleted {
+				t.Errorf("Expected status %s after second occurrence but got %s", StandingOrderStatusCompleted, order.Status)
+			}
+			if order.Occurrences != 2 {
+				t.Errorf("Expected 2 occurrences but got %d", order.Occurrences)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"math"
+	"strings"
+)
+
+//Business Domain
+
+// Statement is the account's transactions booked in a period of whole days, along with the account's balance before
+// and after them.
+type Statement struct { //business/domain object
+	Account        Account
+	From           string //first day of the period, as YYYY-MM-DD
+	To             string //last day of the period, as YYYY-MM-DD
+	OpeningBalance float64
+	ClosingBalance float64
+	Entries        []Transaction //oldest first
+	CreationDate   string
+}
+
+// NewStatement makes the statement of the given account for the period from and to the given days, using all of the
+// account's transactions, oldest first. Since balances are not kept per transaction, the closing balance is worked
+// out backwards from the account's current balance, undoing the transactions made after the period, and the opening
+// balance from the closing balance, undoing the transactions made in the period.
+func NewStatement(account Account, transactions []Transaction, from string, to string, creationDate string) Statement {
+	statement := Statement{Account: account, From: from, To: to, Entries: make([]Transaction, 0), CreationDate: creationDate}
+	closingBalance := account.Amount
+	for _, t := range transactions {
+		day := t.TransactionDate[:len(from)]
+		if day > to {
+			closingBalance -= t.SignedAmount()
+		} else if day >= from {
+			statement.Entries = append(statement.Entries, t)
+		}
+	}
+
+	openingBalance := closingBalance
+	for _, t := range statement.Entries {
+		openingBalance -= t.SignedAmount()
+	}
+	statement.OpeningBalance = roundAmount(openingBalance)
+	statement.ClosingBalance = roundAmount(closingBalance)
+	return statement
+}
+
+// Id returns the reference of the statement, which is the same every time the statement of the same account and
+// period is made.
+func (s Statement) Id() string {
+	return "STMT-" + s.Account.AccountId + "-" + strings.ReplaceAll(s.From, "-", "") + "-" +
+		strings.ReplaceAll(s.To, "-", "")
+}
+
+// Totals returns the number of credits and debits in the statement and the sum of each.
+func (s Statement) Totals() (int, float64, int, float64) {
+	credits, creditSum, debits, debitSum := 0, 0.0, 0, 0.0
+	for _, t := range s.Entries {
+		if t.IsDebit() {
+			debits++
+			debitSum += t.Amount
+		} else {
+			credits++
+			creditSum += t.Amount
+		}
+	}
+	return credits, roundAmount(creditSum), debits, roundAmount(debitSum)
+}
+
+func roundAmount(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
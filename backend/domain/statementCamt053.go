@@ -0,0 +1,190 @@
+package domain
+
+import (
+	"encoding/xml"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"math"
+	"strconv"
+	"strings"
+)
+
+//Business Domain
+
+const camt053Namespace = "urn:iso:std:iso:20022:tech:xsd:camt.053.001.02"
+
+// camtBankTransactionCodes are the ISO 20022 bank transaction codes (domain, family and sub-family) of the transaction
+// types that have one. Every entry also has the transaction type as its proprietary code.
+var camtBankTransactionCodes = map[string][3]string{
+	dto.TransactionTypeDeposit:           {"PMNT", "CNTR", "CDPT"}, //cash deposit
+	dto.TransactionTypeWithdrawal:        {"PMNT", "CNTR", "CWDL"}, //cash withdrawal
+	dto.TransactionTypeTransferOut:       {"PMNT", "ICDT", "BOOK"}, //internal book transfer, issued
+	dto.TransactionTypeTransferIn:        {"PMNT", "RCDT", "BOOK"}, //internal book transfer, received
+	dto.TransactionTypeInterest:          {"ACMT", "MDOP", "INTR"},
+	dto.TransactionTypeOverdraftInterest: {"ACMT", "MDOP", "INTR"},
+	dto.TransactionTypeFee:               {"ACMT", "MDOP", "CHRG"},
+	dto.TransactionTypeHoldCapture:       {"PMNT", "CCRD", "POSD"}, //card payment at point of sale
+}
+
+type camtDocument struct {
+	XMLName   xml.Name               `xml:"Document"`
+	Namespace string                 `xml:"xmlns,attr"`
+	Message   camtBankToCustomerStmt `xml:"BkToCstmrStmt"`
+}
+
+type camtBankToCustomerStmt struct {
+	GroupHeader camtGroupHeader `xml:"GrpHdr"`
+	Statement   camtStatement   `xml:"Stmt"`
+}
+
+type camtGroupHeader struct {
+	MessageId    string `xml:"MsgId"`
+	CreationTime string `xml:"CreDtTm"`
+}
+
+type camtStatement struct {
+	Id           string        `xml:"Id"`
+	CreationTime string        `xml:"CreDtTm"`
+	Period       camtPeriod    `xml:"FrToDt"`
+	Account      camtAccount   `xml:"Acct"`
+	Balances     []camtBalance `xml:"Bal"`
+	Summary      camtSummary   `xml:"TxsSummry"`
+	Entries      []camtEntry   `xml:"Ntry"`
+}
+
+type camtPeriod struct {
+	From string `xml:"FrDtTm"`
+	To   string `xml:"ToDtTm"`
+}
+
+type camtAccount struct {
+	Id       string `xml:"Id>Othr>Id"`
+	Currency string `xml:"Ccy"`
+}
+
+type camtBalance struct {
+	Code      string     `xml:"Tp>CdOrPrtry>Cd"`
+	Amount    camtAmount `xml:"Amt"`
+	Indicator string     `xml:"CdtDbtInd"`
+	Date      string     `xml:"Dt>Dt"`
+}
+
+type camtAmount struct {
+	Currency string `xml:"Ccy,attr"`
+	Value    string `xml:",chardata"`
+}
+
+type camtSummary struct {
+	Credits camtNumberAndSum `xml:"TtlCdtNtries"`
+	Debits  camtNumberAndSum `xml:"TtlDbtNtries"`
+}
+
+type camtNumberAndSum struct {
+	Count int    `xml:"NbOfNtries"`
+	Sum   string `xml:"Sum"`
+}
+
+type camtEntry struct {
+	Reference       string                  `xml:"NtryRef"`
+	Amount          camtAmount              `xml:"Amt"`
+	Indicator       string                  `xml:"CdtDbtInd"`
+	Reversal        bool                    `xml:"RvslInd,omitempty"`
+	Status          string                  `xml:"Sts"`
+	BookingTime     string                  `xml:"BookgDt>DtTm"`
+	ValueDate       string                  `xml:"ValDt>Dt"`
+	ServicerRef     string                  `xml:"AcctSvcrRef"`
+	TransactionCode camtBankTransactionCode `xml:"BkTxCd"`
+	Information     string                  `xml:"AddtlNtryInf,omitempty"`
+}
+
+type camtBankTransactionCode struct {
+	Domain      *camtDomainCode `xml:"Domn,omitempty"`
+	Proprietary string          `xml:"Prtry>Cd"`
+}
+
+type camtDomainCode struct {
+	Code          string `xml:"Cd"`
+	FamilyCode    string `xml:"Fmly>Cd"`
+	SubFamilyCode string `xml:"Fmly>SubFmlyCd"`
+}
+
+// ToCamt053 renders the statement as an ISO 20022 bank-to-customer statement (camt.053.001.02), with the opening and
+// closing booked balances (OPBD and CLBD) of the period and a booked entry per transaction.
+func (s Statement) ToCamt053() ([]byte, error) {
+	currency := s.Account.Currency
+	credits, creditSum, debits, debitSum := s.Totals()
+	statement := camtStatement{
+		Id:           s.Id(),
+		CreationTime: isoDateTime(s.CreationDate),
+		Period:       camtPeriod{From: s.From + "T00:00:00", To: s.To + "T23:59:59"},
+		Account:      camtAccount{Id: s.Account.AccountId, Currency: currency},
+		Balances: []camtBalance{
+			{"OPBD", camtAmountOf(s.OpeningBalance, currency), creditDebitIndicator(s.OpeningBalance), s.From},
+			{"CLBD", camtAmountOf(s.ClosingBalance, currency), creditDebitIndicator(s.ClosingBalance), s.To},
+		},
+		Summary: camtSummary{
+			Credits: camtNumberAndSum{credits, formatCamtAmount(creditSum)},
+			Debits:  camtNumberAndSum{debits, formatCamtAmount(debitSum)},
+		},
+		Entries: make([]camtEntry, 0),
+	}
+	for _, t := range s.Entries {
+		statement.Entries = append(statement.Entries, camtEntryOf(t, currency))
+	}
+
+	document := camtDocument{
+		Namespace: camt053Namespace,
+		Message: camtBankToCustomerStmt{
+			GroupHeader: camtGroupHeader{MessageId: s.Id(), CreationTime: isoDateTime(s.CreationDate)},
+			Statement:   statement,
+		},
+	}
+	content, err := xml.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), content...), nil
+}
+
+func camtEntryOf(t Transaction, currency string) camtEntry {
+	entry := camtEntry{
+		Reference:       t.TransactionId,
+		Amount:          camtAmountOf(t.Amount, currency),
+		Indicator:       "CRDT",
+		Reversal:        t.ReversalOf.Valid,
+		Status:          "BOOK",
+		BookingTime:     isoDateTime(t.TransactionDate),
+		ValueDate:       t.TransactionDate[:len(FormatDate)],
+		ServicerRef:     t.TransactionId,
+		TransactionCode: camtBankTransactionCode{Proprietary: t.TransactionType},
+		Information:     t.Description(),
+	}
+	if t.IsDebit() {
+		entry.Indicator = "DBIT"
+	}
+	if code, ok := camtBankTransactionCodes[t.TransactionType]; ok {
+		entry.TransactionCode.Domain = &camtDomainCode{code[0], code[1], code[2]}
+	}
+	return entry
+}
+
+func camtAmountOf(amount float64, currency string) camtAmount {
+	return camtAmount{Currency: currency, Value: formatCamtAmount(math.Abs(amount))}
+}
+
+func formatCamtAmount(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', 2, 64)
+}
+
+// creditDebitIndicator returns the ISO 20022 code saying whether the given signed amount is a credit or a debit.
+// Balances of zero are credits.
+func creditDebitIndicator(amount float64) string {
+	if amount < 0 {
+		return "DBIT"
+	}
+	return "CRDT"
+}
+
+// isoDateTime converts a date and time in the format used by the app to an ISO 8601 date and time.
+func isoDateTime(dateTime string) string {
+	return strings.Replace(dateTime, " ", "T", 1)
+}
@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"fmt"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+//Business Domain
+
+// mt940TransactionCodes are the SWIFT transaction type identification codes of the transaction types that have one.
+// Other transactions are marked as miscellaneous (MSC).
+var mt940TransactionCodes = map[string]string{
+	dto.TransactionTypeTransferOut:       "TRF",
+	dto.TransactionTypeTransferIn:        "TRF",
+	dto.TransactionTypeInterest:          "INT",
+	dto.TransactionTypeOverdraftInterest: "INT",
+	dto.TransactionTypeFee:               "CHG",
+}
+
+// mt940InvalidChars matches the characters that are not in the SWIFT X character set, which is all that MT940 text
+// may contain.
+var mt940InvalidChars = regexp.MustCompile(`[^a-zA-Z0-9/\-?:().,'+ ]`)
+
+// mt940MaxReferenceLength and mt940MaxInfoLength are the lengths of the longest reference and information to owner
+// line allowed in MT940.
+const mt940MaxReferenceLength = 16
+const mt940MaxInfoLength = 65
+
+// ToMt940 renders the statement as a SWIFT MT940 customer statement message, with the opening and closing balances
+// of the period (tags 60F and 62F) and a statement line (tag 61) followed by information to the account owner (tag
+// 86) per transaction. Lines end in CRLF, and the message ends with a line holding a single "-".
+func (s Statement) ToMt940() []byte {
+	var b strings.Builder
+	line := func(format string, args ...interface{}) {
+		b.WriteString(fmt.Sprintf(format, args...) + "\r\n")
+	}
+
+	line(":20:%s", truncate(s.Account.AccountId+"-"+mt940Date(s.To), mt940MaxReferenceLength))
+	line(":25:%s", s.Account.AccountId)
+	line(":28C:%s", mt940Date(s.To)[:2]+dayOfYear(s.To)) //statement number, made unique per day by the period's end
+	line(":60F:%s%s%s%s", mt940Mark(s.OpeningBalance), mt940Date(s.From), s.Account.Currency, mt940Amount(s.OpeningBalance))
+	for _, t := range s.Entries {
+		code, ok := mt940TransactionCodes[t.TransactionType]
+		if !ok {
+			code = "MSC"
+		}
+		date := t.TransactionDate[:len(FormatDate)]
+		line(":61:%s%s%s%sN%sNONREF//%s", mt940Date(date), mt940Date(date)[2:], mt940EntryMark(t),
+			mt940Amount(t.Amount), code, truncate(t.TransactionId, mt940MaxReferenceLength))
+		line(":86:%s", truncate(mt940InvalidChars.ReplaceAllString(t.Description(), " "), mt940MaxInfoLength))
+	}
+	line(":62F:%s%s%s%s", mt940Mark(s.ClosingBalance), mt940Date(s.To), s.Account.Currency, mt940Amount(s.ClosingBalance))
+	line("-")
+	return []byte(b.String())
+}
+
+// mt940Mark returns the mark of a balance, which is D if the given balance is negative and C otherwise.
+func mt940Mark(balance float64) string {
+	if balance < 0 {
+		return "D"
+	}
+	return "C"
+}
+
+// mt940EntryMark returns the debit/credit mark of a transaction. Reversals are marked RC if they reverse a credit
+// (and so are debits) and RD if they reverse a debit.
+func mt940EntryMark(t Transaction) string {
+	mark := "C"
+	if t.IsDebit() {
+		mark = "D"
+	}
+	if t.ReversalOf.Valid {
+		if mark == "D" {
+			return "RC"
+		}
+		return "RD"
+	}
+	return mark
+}
+
+// mt940Amount formats the size of the given amount the way MT940 does, with a decimal comma.
+func mt940Amount(amount float64) string {
+	return strings.Replace(strconv.FormatFloat(math.Abs(amount), 'f', 2, 64), ".", ",", 1)
+}
+
+// mt940Date converts a date as YYYY-MM-DD to YYMMDD.
+func mt940Date(date string) string {
+	return strings.ReplaceAll(date, "-", "")[2:]
+}
+
+// dayOfYear returns the day of the year of the given date as YYYY-MM-DD, padded to 3 digits.
+func dayOfYear(date string) string {
+	year, _ := strconv.Atoi(date[:4])
+	month, _ := strconv.Atoi(date[5:7])
+	day, _ := strconv.Atoi(date[8:10])
+	daysBefore := []int{0, 31, 59, 90, 120, 151, 181, 212, 243, 273, 304, 334}[month-1]
+	if month > 2 && year%4 == 0 && (year%100 != 0 || year%400 == 0) {
+		daysBefore++
+	}
+	return fmt.Sprintf("%03d", daysBefore+day)
+}
+
+func truncate(s string, length int) string {
+	if len(s) > length {
+		return s[:length]
+	}
+	return s
+}
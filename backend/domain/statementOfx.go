@@ -0,0 +1,160 @@
+package domain
+
+import (
+	"encoding/xml"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"strconv"
+	"strings"
+)
+
+//Business Domain
+
+// ofxHeader is the processing instruction that starts every OFX 2.1.1 document.
+const ofxHeader = `<?OFX OFXHEADER="200" VERSION="211" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>` + "\n"
+
+// ofxBankId identifies the bank in OFX documents, which personal finance tools use along with the account ID to tell
+// accounts apart. The bank has no routing number, so a fixed name is used instead.
+const ofxBankId = "BANKING"
+
+// ofxMaxNameLength is the length of the longest payee name allowed in OFX.
+const ofxMaxNameLength = 32
+
+// ofxTransactionTypes are the OFX transaction types of the transaction types that have one. Other transactions are
+// of type CREDIT or DEBIT.
+var ofxTransactionTypes = map[string]string{
+	dto.TransactionTypeDeposit:           "DEP",
+	dto.TransactionTypeWithdrawal:        "CASH",
+	dto.TransactionTypeTransferOut:       "XFER",
+	dto.TransactionTypeTransferIn:        "XFER",
+	dto.TransactionTypeInterest:          "INT",
+	dto.TransactionTypeOverdraftInterest: "INT",
+	dto.TransactionTypeFee:               "FEE",
+	dto.TransactionTypeHoldCapture:       "POS",
+}
+
+var ofxAccountTypes = map[string]string{
+	dto.AccountTypeSaving:   "SAVINGS",
+	dto.AccountTypeChecking: "CHECKING",
+}
+
+type ofxDocument struct {
+	XMLName   xml.Name        `xml:"OFX"`
+	SignOn    ofxSignOn       `xml:"SIGNONMSGSRSV1>SONRS"`
+	Statement ofxStatementTrn `xml:"BANKMSGSRSV1>STMTTRNRS"`
+}
+
+type ofxStatus struct {
+	Code     int    `xml:"CODE"`
+	Severity string `xml:"SEVERITY"`
+}
+
+type ofxSignOn struct {
+	Status     ofxStatus `xml:"STATUS"`
+	ServerTime string    `xml:"DTSERVER"`
+	Language   string    `xml:"LANGUAGE"`
+}
+
+type ofxStatementTrn struct {
+	TransactionUid string       `xml:"TRNUID"`
+	Status         ofxStatus    `xml:"STATUS"`
+	Response       ofxStatement `xml:"STMTRS"`
+}
+
+type ofxStatement struct {
+	Currency        string       `xml:"CURDEF"`
+	Account         ofxAccount   `xml:"BANKACCTFROM"`
+	TransactionList ofxTransList `xml:"BANKTRANLIST"`
+	LedgerBalance   ofxLedgerBal `xml:"LEDGERBAL"`
+}
+
+type ofxAccount struct {
+	BankId      string `xml:"BANKID"`
+	AccountId   string `xml:"ACCTID"`
+	AccountType string `xml:"ACCTTYPE"`
+}
+
+type ofxTransList struct {
+	Start        string           `xml:"DTSTART"`
+	End          string           `xml:"DTEND"`
+	Transactions []ofxTransaction `xml:"STMTTRN"`
+}
+
+type ofxTransaction struct {
+	Type       string `xml:"TRNTYPE"`
+	PostedTime string `xml:"DTPOSTED"`
+	Amount     string `xml:"TRNAMT"`
+	FitId      string `xml:"FITID"`
+	Name       string `xml:"NAME"`
+	Memo       string `xml:"MEMO"`
+}
+
+type ofxLedgerBal struct {
+	Amount string `xml:"BALAMT"`
+	AsOf   string `xml:"DTASOF"`
+}
+
+// ToOfx renders the statement as an OFX 2.1.1 bank statement response for personal finance tools. Each transaction's
+// ID is its FITID, so that tools importing overlapping periods can tell which transactions they already have. The
+// ledger balance is the account's current balance, as of when the statement was made.
+func (s Statement) ToOfx() ([]byte, error) {
+	accountType, ok := ofxAccountTypes[s.Account.AccountType]
+	if !ok {
+		accountType = "CHECKING"
+	}
+	statement := ofxStatement{
+		Currency: s.Account.Currency,
+		Account:  ofxAccount{BankId: ofxBankId, AccountId: s.Account.AccountId, AccountType: accountType},
+		TransactionList: ofxTransList{
+			Start:        ofxDateTime(s.From + " 00:00:00"),
+			End:          ofxDateTime(s.To + " 23:59:59"),
+			Transactions: make([]ofxTransaction, 0),
+		},
+		LedgerBalance: ofxLedgerBal{Amount: formatSignedAmount(s.Account.Amount), AsOf: ofxDateTime(s.CreationDate)},
+	}
+	for _, t := range s.Entries {
+		statement.TransactionList.Transactions = append(statement.TransactionList.Transactions, ofxTransactionOf(t))
+	}
+
+	okStatus := ofxStatus{Code: 0, Severity: "INFO"}
+	document := ofxDocument{
+		SignOn:    ofxSignOn{Status: okStatus, ServerTime: ofxDateTime(s.CreationDate), Language: "ENG"},
+		Statement: ofxStatementTrn{TransactionUid: s.Id(), Status: okStatus, Response: statement},
+	}
+	content, err := xml.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header+ofxHeader), content...), nil
+}
+
+func ofxTransactionOf(t Transaction) ofxTransaction {
+	transactionType, ok := ofxTransactionTypes[t.TransactionType]
+	if !ok {
+		transactionType = "CREDIT"
+		if t.IsDebit() {
+			transactionType = "DEBIT"
+		}
+	}
+	description := t.Description()
+	return ofxTransaction{
+		Type:       transactionType,
+		PostedTime: ofxDateTime(t.TransactionDate),
+		Amount:     formatSignedAmount(t.SignedAmount()),
+		FitId:      t.TransactionId,
+		Name:       truncate(description, ofxMaxNameLength),
+		Memo:       description,
+	}
+}
+
+// ofxDateTime converts a date and time in the format used by the app to the format used by OFX, YYYYMMDDHHMMSS.
+func ofxDateTime(dateTime string) string {
+	return strings.NewReplacer("-", "", " ", "", ":", "").Replace(dateTime)
+}
+
+// formatSignedAmount formats the given amount with two decimals and, if it is negative, a minus sign.
+func formatSignedAmount(amount float64) string {
+	if amount == 0 {
+		amount = 0 //not -0, as the debits of nothing are
+	}
+	return strconv.FormatFloat(amount, 'f', 2, 64)
+}
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+//Business Domain
+
+// ToQif renders the statement as a QIF file for personal finance tools: an account record holding the account's
+// current balance, as of when the statement was made, followed by a record per transaction. Each transaction's ID is
+// its number (N), so that tools importing overlapping periods can tell which transactions they already have. Dates are
+// written as MM/DD/YYYY, as most tools expect.
+func (s Statement) ToQif() []byte {
+	var b strings.Builder
+	line := func(format string, args ...interface{}) {
+		b.WriteString(fmt.Sprintf(format, args...) + "\n")
+	}
+
+	line("!Account")
+	line("N%s", s.Account.AccountId)
+	line("TBank")
+	line("/%s", qifDate(s.CreationDate))
+	line("$%s", formatSignedAmount(s.Account.Amount))
+	line("^")
+	line("!Type:Bank")
+	for _, t := range s.Entries {
+		line("D%s", qifDate(t.TransactionDate))
+		line("T%s", formatSignedAmount(t.SignedAmount()))
+		line("N%s", t.TransactionId)
+		line("P%s", t.Description())
+		line("^")
+	}
+	return []byte(b.String())
+}
+
+// qifDate converts a date and time in the format used by the app to a date as MM/DD/YYYY.
+func qifDate(dateTime string) string {
+	return dateTime[5:7] + "/" + dateTime[8:10] + "/" + dateTime[:4]
+}
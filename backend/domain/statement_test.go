@@ -0,0 +1,212 @@
+package domain
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/xml"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// newDummyStatement makes the statement of August 2020 of an account now holding 1000, with a deposit before the
+// period, a withdrawal, a transfer and a reversed fee in it, and a deposit after it.
+func newDummyStatement() Statement {
+	account := Account{AccountId: "95470", CustomerId: "2000", Currency: "INR", Amount: 1000}
+	transactions := []Transaction{
+		{TransactionId: "1", AccountId: "95470", Amount: 500, TransactionType: dto.TransactionTypeDeposit,
+			TransactionDate: "2020-07-31 23:59:59"},
+		{TransactionId: "2", AccountId: "95470", Amount: 120.5, TransactionType: dto.TransactionTypeWithdrawal,
+			TransactionDate: "2020-08-01 00:00:00"},
+		{TransactionId: "3", AccountId: "95470", Amount: 30.25, TransactionType: dto.TransactionTypeTransferIn,
+			TransactionDate: "2020-08-12 10:00:00"},
+		{TransactionId: "4", AccountId: "95470", Amount: 10, TransactionType: dto.TransactionTypeFee,
+			TransactionDate: "2020-08-20 10:00:00", ReversedBy: sql.NullString{String: "5", Valid: true}},
+		{TransactionId: "5", AccountId: "95470", Amount: 10, TransactionType: dto.TransactionTypeReversalCredit,
+			TransactionDate: "2020-08-31 23:59:59", ReversalOf: sql.NullString{String: "4", Valid: true},
+			ReversalReason: sql.NullString{String: "Charged in error", Valid: true}},
+		{TransactionId: "6", AccountId: "95470", Amount: 200, TransactionType: dto.TransactionTypeDeposit,
+			TransactionDate: "2020-09-01 00:00:00"},
+	}
+	return NewStatement(account, transactions, "2020-08-01", "2020-08-31", "2020-09-02 08:00:00")
+}
+
+func TestNewStatement_works_out_balances_from_current_balance(t *testing.T) {
+	//Act
+	statement := newDummyStatement()
+	credits, creditSum, debits, debitSum := statement.Totals()
+
+	//Assert
+	if statement.ClosingBalance != 800 || statement.OpeningBalance != 890.25 {
+		t.Errorf("expected balances 890.25 and 800 but got %v and %v", statement.OpeningBalance, statement.ClosingBalance)
+	}
+	if len(statement.Entries) != 4 || statement.Entries[0].TransactionId != "2" || statement.Entries[3].TransactionId != "5" {
+		t.Errorf("expected transactions 2 to 5 in statement but got %+v", statement.Entries)
+	}
+	if credits != 2 || creditSum != 40.25 || debits != 2 || debitSum != 130.5 {
+		t.Errorf("expected 2 credits of 40.25 and 2 debits of 130.5 but got %d of %v and %d of %v",
+			credits, creditSum, debits, debitSum)
+	}
+}
+
+func TestStatement_ToCamt053_renders_document_valid_against_schema(t *testing.T) {
+	//Arrange
+	xmllint, err := exec.LookPath("xmllint")
+	if err != nil {
+		t.Skip("xmllint is needed to validate camt.053 documents")
+	}
+	statement := newDummyStatement()
+
+	//Act
+	content, err := statement.ToCamt053()
+
+	//Assert
+	if err != nil {
+		t.Fatalf("expected no error but got %s", err)
+	}
+	file := filepath.Join(t.TempDir(), "statement.xml")
+	if err = os.WriteFile(file, content, 0600); err != nil {
+		t.Fatal(err)
+	}
+	output, err := exec.Command(xmllint, "--noout", "--schema", "testdata/camt.053.001.02.xsd", file).CombinedOutput()
+	if err != nil {
+		t.Errorf("expected document valid against schema but got %s\n%s", output, content)
+	}
+}
+
+func TestStatement_ToCamt053_renders_balances_and_entries(t *testing.T) {
+	//Arrange
+	statement := newDummyStatement()
+	var document struct {
+		Balances []struct {
+			Code      string `xml:"Tp>CdOrPrtry>Cd"`
+			Amount    string `xml:"Amt"`
+			Indicator string `xml:"CdtDbtInd"`
+			Date      string `xml:"Dt>Dt"`
+		} `xml:"BkToCstmrStmt>Stmt>Bal"`
+		Entries []struct {
+			Amount    string `xml:"Amt"`
+			Indicator string `xml:"CdtDbtInd"`
+			Reversal  bool   `xml:"RvslInd"`
+			Domain    string `xml:"BkTxCd>Domn>Cd"`
+		} `xml:"BkToCstmrStmt>Stmt>Ntry"`
+	}
+
+	//Act
+	content, _ := statement.ToCamt053()
+
+	//Assert
+	if err := xml.Unmarshal(content, &document); err != nil {
+		t.Fatalf("expected XML document but got %s", err)
+	}
+	if len(document.Balances) != 2 || document.Balances[0].Code != "OPBD" || document.Balances[0].Amount != "890.25" ||
+		document.Balances[0].Date != "2020-08-01" || document.Balances[1].Code != "CLBD" ||
+		document.Balances[1].Amount != "800.00" || document.Balances[1].Date != "2020-08-31" {
+		t.Errorf("expected opening and closing balances but got %+v", document.Balances)
+	}
+	if len(document.Entries) != 4 || document.Entries[0].Indicator != "DBIT" || document.Entries[0].Amount != "120.50" ||
+		document.Entries[0].Domain != "PMNT" || document.Entries[1].Indicator != "CRDT" ||
+		!document.Entries[3].Reversal || document.Entries[3].Domain != "" {
+		t.Errorf("expected an entry per transaction but got %+v", document.Entries)
+	}
+}
+
+func TestStatement_ToMt940_renders_message(t *testing.T) {
+	//Arrange
+	statement := newDummyStatement()
+	expectedLines := []*regexp.Regexp{
+		regexp.MustCompile(`^:20:95470-200831$`),
+		regexp.MustCompile(`^:25:95470$`),
+		regexp.MustCompile(`^:28C:20244$`),
+		regexp.MustCompile(`^:60F:C200801INR890,25$`),
+		regexp.MustCompile(`^:61:2008010801D120,50NMSCNONREF//2$`),
+		regexp.MustCompile(`^:86:Withdrawal$`),
+		regexp.MustCompile(`^:61:2008120812C30,25NTRFNONREF//3$`),
+		regexp.MustCompile(`^:86:Transfer in$`),
+		regexp.MustCompile(`^:61:2008200820D10,00NCHGNONREF//4$`),
+		regexp.MustCompile(`^:86:Fee, reversed by transaction 5$`),
+		regexp.MustCompile(`^:61:2008310831RD10,00NMSCNONREF//5$`),
+		regexp.MustCompile(`^:86:Reversal credit of transaction 4, reason Charged in error$`),
+		regexp.MustCompile(`^:62F:C200831INR800,00$`),
+		regexp.MustCompile(`^-$`),
+	}
+
+	//Act
+	content := statement.ToMt940()
+
+	//Assert
+	if !bytes.HasSuffix(content, []byte("-\r\n")) {
+		t.Errorf("expected message ending in a line with - but got %q", content)
+	}
+	lines := strings.Split(strings.TrimSuffix(string(content), "\r\n"), "\r\n")
+	if len(lines) != len(expectedLines) {
+		t.Fatalf("expected %d lines but got %q", len(expectedLines), lines)
+	}
+	for k, line := range lines {
+		if !expectedLines[k].MatchString(line) {
+			t.Errorf("expected line %d to match %s but got %q", k+1, expectedLines[k], line)
+		}
+	}
+}
+
+func TestStatement_ToOfx_renders_signed_transactions_and_current_balance(t *testing.T) {
+	//Arrange
+	statement := newDummyStatement()
+	statement.Account.AccountType = dto.AccountTypeSaving
+	var document struct {
+		AccountType  string `xml:"BANKMSGSRSV1>STMTTRNRS>STMTRS>BANKACCTFROM>ACCTTYPE"`
+		Transactions []struct {
+			Type   string `xml:"TRNTYPE"`
+			Posted string `xml:"DTPOSTED"`
+			Amount string `xml:"TRNAMT"`
+			FitId  string `xml:"FITID"`
+		} `xml:"BANKMSGSRSV1>STMTTRNRS>STMTRS>BANKTRANLIST>STMTTRN"`
+		Balance string `xml:"BANKMSGSRSV1>STMTTRNRS>STMTRS>LEDGERBAL>BALAMT"`
+		AsOf    string `xml:"BANKMSGSRSV1>STMTTRNRS>STMTRS>LEDGERBAL>DTASOF"`
+	}
+
+	//Act
+	content, err := statement.ToOfx()
+
+	//Assert
+	if err != nil {
+		t.Fatalf("expected no error but got %s", err)
+	}
+	if !bytes.Contains(content, []byte(`<?OFX OFXHEADER="200" VERSION="211"`)) {
+		t.Errorf("expected OFX header but got %s", content)
+	}
+	if err = xml.Unmarshal(content, &document); err != nil {
+		t.Fatalf("expected XML document but got %s", err)
+	}
+	if document.AccountType != "SAVINGS" || document.Balance != "1000.00" || document.AsOf != "20200902080000" {
+		t.Errorf("expected savings account with current balance but got %+v", document)
+	}
+	if len(document.Transactions) != 4 || document.Transactions[0].Type != "CASH" ||
+		document.Transactions[0].Amount != "-120.50" || document.Transactions[0].FitId != "2" ||
+		document.Transactions[0].Posted != "20200801000000" || document.Transactions[2].Amount != "-10.00" ||
+		document.Transactions[3].Type != "CREDIT" || document.Transactions[3].Amount != "10.00" {
+		t.Errorf("expected a signed transaction per entry but got %+v", document.Transactions)
+	}
+}
+
+func TestStatement_ToQif_renders_signed_transactions_and_current_balance(t *testing.T) {
+	//Arrange
+	statement := newDummyStatement()
+	expected := "!Account\nN95470\nTBank\n/09/02/2020\n$1000.00\n^\n!Type:Bank\n" +
+		"D08/01/2020\nT-120.50\nN2\nPWithdrawal\n^\n" +
+		"D08/12/2020\nT30.25\nN3\nPTransfer in\n^\n" +
+		"D08/20/2020\nT-10.00\nN4\nPFee, reversed by transaction 5\n^\n" +
+		"D08/31/2020\nT10.00\nN5\nPReversal credit of transaction 4, reason Charged in error\n^\n"
+
+	//Act
+	content := statement.ToQif()
+
+	//Assert
+	if string(content) != expected {
+		t.Errorf("expected %q but got %q", expected, content)
+	}
+}
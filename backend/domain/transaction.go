@@ -1,8 +1,12 @@
 package domain
 
 import (
+	"database/sql"
+	"fmt"
 	"github.com/aliciatay-zls/banking-lib/clock"
 	"github.com/aliciatay-zls/banking/backend/dto"
+	"strconv"
+	"strings"
 )
 
 //Business Domain
@@ -12,8 +16,14 @@ type Transaction struct { //business/domain object
 	AccountId       string  `db:"account_id"`
 	Amount          float64 `db:"amount"`
 	Balance         float64
-	TransactionType string `db:"transaction_type"`
-	TransactionDate string `db:"transaction_date"`
+	TransactionType string          `db:"transaction_type"`
+	TransactionDate string          `db:"transaction_date"`
+	ReversalOf      sql.NullString  `db:"reversal_of"`        //id of the transaction this reverses, if it is a reversal
+	ReversedBy      sql.NullString  `db:"reversed_by"`        //id of the reversal of this transaction, if it was reversed
+	ReversalReason  sql.NullString  `db:"reversal_reason"`    //reason code of the reversal, set on both transactions
+	FxRate          sql.NullFloat64 `db:"fx_rate"`            //exchange rate applied, if it is part of a cross-currency transfer
+	ConvertedAmount sql.NullFloat64 `db:"converted_amount"`   //amount credited after conversion, if it is part of a cross-currency transfer
+	ActingCustomer  sql.NullString  `db:"acting_customer_id"` //holder who made it, if it was made by a customer
 }
 
 func NewTransaction(accountId string, amount float64, transactionType string, c clock.Clock) Transaction {
@@ -30,9 +40,139 @@ func (t Transaction) ToTransactionResponseDTO() *dto.TransactionResponse {
 		TransactionId:   t.TransactionId,
 		Balance:         t.Balance,
 		TransactionDate: t.TransactionDate,
+		ReversalOf:      t.ReversalOf.String,
+		FxRate:          t.FxRate.Float64,
+		ConvertedAmount: t.ConvertedAmount.Float64,
 	}
 }
 
+func (t Transaction) ToTransactionDetailResponseDTO() *dto.TransactionDetailResponse {
+	return &dto.TransactionDetailResponse{
+		TransactionId:    t.TransactionId,
+		AccountId:        t.AccountId,
+		Amount:           t.Amount,
+		TransactionType:  t.TransactionType,
+		TransactionDate:  t.TransactionDate,
+		ReversalOf:       t.ReversalOf.String,
+		ReversedBy:       t.ReversedBy.String,
+		ReversalReason:   t.ReversalReason.String,
+		FxRate:           t.FxRate.Float64,
+		ConvertedAmount:  t.ConvertedAmount.Float64,
+		ActingCustomerId: t.ActingCustomer.String,
+	}
+}
+
+// ActedBy records the customer with the given id as the account holder or delegate who made the transaction.
+func (t *Transaction) ActedBy(customerId string) {
+	t.ActingCustomer = sql.NullString{String: customerId, Valid: customerId != ""}
+}
+
+// NewTransfer creates the debit on the source account and the credit on the destination account that make up a
+// transfer of the given amount. If the accounts are in different currencies, the amount credited is the given amount
+// converted at the given rate, and both transactions record the rate and the converted amount.
+func NewTransfer(source Account, destination Account, amount float64, rate float64, c clock.Clock) (Transaction, Transaction) {
+	debit := NewTransaction(source.AccountId, amount, dto.TransactionTypeTransferOut, c)
+	credit := NewTransaction(destination.AccountId, amount, dto.TransactionTypeTransferIn, c)
+	if source.Currency == destination.Currency {
+		return debit, credit
+	}
+
+	credit.Amount = ConvertAmount(amount, rate)
+	for _, t := range []*Transaction{&debit, &credit} {
+		t.FxRate = sql.NullFloat64{Float64: rate, Valid: true}
+		t.ConvertedAmount = sql.NullFloat64{Float64: credit.Amount, Valid: true}
+	}
+	return debit, credit
+}
+
 func (t Transaction) IsWithdrawal() bool {
 	return t.TransactionType == dto.TransactionTypeWithdrawal
 }
+
+// debitTransactionTypes are the types of transactions that take money out of the account: withdrawals, outgoing
+// transfers, captured holds and reversals of credits as well as any charges made by the bank.
+var debitTransactionTypes = []string{dto.TransactionTypeWithdrawal, dto.TransactionTypeTransferOut,
+	dto.TransactionTypeHoldCapture, dto.TransactionTypeReversalDebit, dto.TransactionTypeOverdraftInterest,
+	dto.TransactionTypeFee}
+
+// IsDebit checks whether the transaction takes money out of the account, which is the case for the types in
+// debitTransactionTypes.
+func (t Transaction) IsDebit() bool {
+	for _, transactionType := range debitTransactionTypes {
+		if t.TransactionType == transactionType {
+			return true
+		}
+	}
+	return false
+}
+
+// SignedAmount returns the amount of the transaction as it changed the account's balance, which is negative for
+// debits.
+func (t Transaction) SignedAmount() float64 {
+	if t.IsDebit() {
+		return -t.Amount
+	}
+	return t.Amount
+}
+
+// Description describes the transaction for the account holder, e.g. in statements: its type, and what it reversed
+// or how it was converted, if that applies.
+func (t Transaction) Description() string {
+	description := strings.ToUpper(t.TransactionType[:1]) + strings.ReplaceAll(t.TransactionType[1:], "_", " ")
+	if t.ReversalOf.Valid {
+		description += " of transaction " + t.ReversalOf.String + ", reason " + t.ReversalReason.String
+	}
+	if t.ReversedBy.Valid {
+		description += ", reversed by transaction " + t.ReversedBy.String
+	}
+	if t.FxRate.Valid {
+		description += fmt.Sprintf(", converted at rate %s to %s", strconv.FormatFloat(t.FxRate.Float64, 'f', -1, 64),
+			strconv.FormatFloat(t.ConvertedAmount.Float64, 'f', 2, 64))
+	}
+	return description
+}
+
+// IsReversible checks whether the transaction can be reversed by an admin. Reversals cannot themselves be reversed,
+// and neither can either side of a transfer since that would only correct one of the two accounts.
+func (t Transaction) IsReversible() bool {
+	switch t.TransactionType {
+	case dto.TransactionTypeTransferOut, dto.TransactionTypeTransferIn,
+		dto.TransactionTypeReversalDebit, dto.TransactionTypeReversalCredit:
+		return false
+	}
+	return !t.ReversalOf.Valid
+}
+
+// IsReversed checks whether the transaction has already been reversed.
+func (t Transaction) IsReversed() bool {
+	return t.ReversedBy.Valid
+}
+
+type Reversal struct { //business/domain object
+	OriginalTransactionId string `db:"original_transaction_id"`
+	ReversalTransactionId string `db:"reversal_transaction_id"`
+	ReasonCode            string `db:"reason_code"`
+	Note                  string `db:"note"`
+	ReversalDate          string `db:"reversal_date"`
+}
+
+// NewReversal creates the compensating transaction for the given transaction, which moves the same amount in the
+// opposite direction on the same account, along with the record linking the two.
+func NewReversal(original Transaction, reasonCode string, note string, c clock.Clock) (Transaction, Reversal) {
+	reversalType := dto.TransactionTypeReversalDebit
+	if original.IsDebit() {
+		reversalType = dto.TransactionTypeReversalCredit
+	}
+
+	transaction := NewTransaction(original.AccountId, original.Amount, reversalType, c)
+	transaction.ReversalOf = sql.NullString{String: original.TransactionId, Valid: true}
+	transaction.ReversalReason = sql.NullString{String: reasonCode, Valid: true}
+
+	reversal := Reversal{
+		OriginalTransactionId: original.TransactionId,
+		ReasonCode:            reasonCode,
+		Note:                  note,
+		ReversalDate:          transaction.TransactionDate,
+	}
+	return transaction, reversal
+}
@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"database/sql"
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/dto"
+)
+
+//Business Domain
+
+const ImportModeBestEffort = "best_effort"      //rows are posted one by one, and rejected rows are skipped
+const ImportModeAllOrNothing = "all_or_nothing" //rows are only posted if none are rejected
+
+const ImportBatchStatusRunning = "running"
+const ImportBatchStatusIncomplete = "incomplete" //some rows could not be imported because of unexpected errors
+const ImportBatchStatusCompleted = "completed"
+const ImportBatchStatusFailed = "failed" //nothing was posted
+
+const ImportRowStatusPosted = "posted"
+const ImportRowStatusRejected = "rejected"
+const ImportRowStatusNotPosted = "not_posted" //valid, but not posted since another row of an all-or-nothing batch was rejected
+const ImportRowStatusError = "error"          //unexpected error, the row is tried again when the batch is resumed
+
+// maxImportMessageLength is the length of the message column of import rows.
+const maxImportMessageLength = 255
+
+// ImportBatch is a file of transactions being imported. Its checkpoint is the number of the last row such that it
+// and every row before it have a saved result, which is where a resumed batch carries on from.
+type ImportBatch struct { //business/domain object
+	BatchId      string `db:"batch_id"`
+	Mode         string `db:"mode"`
+	Status       string `db:"status"`
+	Checkpoint   int    `db:"checkpoint"`
+	CreationDate string `db:"creation_date"`
+	UpdateDate   string `db:"update_date"`
+}
+
+func NewImportBatch(mode string, c clock.Clock) ImportBatch {
+	now := c.NowAsString()
+	return ImportBatch{Mode: mode, Status: ImportBatchStatusRunning, CreationDate: now, UpdateDate: now}
+}
+
+// CanResume checks whether rows can still be imported into the batch.
+func (b ImportBatch) CanResume() bool {
+	return b.Status == ImportBatchStatusRunning || b.Status == ImportBatchStatusIncomplete ||
+		b.Status == ImportBatchStatusFailed //a failed all-or-nothing batch posted nothing, so it can be run again
+}
+
+func (b ImportBatch) ToDTO() dto.ImportBatchResponse {
+	return dto.ImportBatchResponse{
+		BatchId:      b.BatchId,
+		Mode:         b.Mode,
+		Status:       b.Status,
+		Checkpoint:   b.Checkpoint,
+		CreationDate: b.CreationDate,
+		UpdateDate:   b.UpdateDate,
+	}
+}
+
+// ImportRowResult is the outcome of importing a row of a batch.
+type ImportRowResult struct { //business/domain object
+	BatchId         string         `db:"batch_id"`
+	RowNumber       int            `db:"row_no"`
+	AccountId       string         `db:"account_id"`
+	Amount          float64        `db:"amount"`
+	TransactionType string         `db:"transaction_type"`
+	Status          string         `db:"status"`
+	TransactionId   sql.NullString `db:"transaction_id"`
+	Message         string         `db:"message"`
+	CreationDate    string         `db:"creation_date"`
+}
+
+// NewImportRowResult records that the given row of the given batch was posted as the transaction with the given ID,
+// or, if no transaction ID is given, that it was not posted for the reason in the given message.
+func NewImportRowResult(batchId string, row dto.ImportRow, status string, transactionId string, message string,
+	c clock.Clock) ImportRowResult {
+	if len(message) > maxImportMessageLength {
+		message = message[:maxImportMessageLength]
+	}
+	return ImportRowResult{
+		BatchId:         batchId,
+		RowNumber:       row.RowNumber,
+		AccountId:       row.Request.AccountId,
+		Amount:          row.Request.Amount,
+		TransactionType: row.Request.TransactionType,
+		Status:          status,
+		TransactionId:   sql.NullString{String: transactionId, Valid: transactionId != ""},
+		Message:         message,
+		CreationDate:    c.NowAsString(),
+	}
+}
+
+func (r ImportRowResult) ToDTO() dto.ImportRowResponse {
+	return dto.ImportRowResponse{
+		RowNumber:       r.RowNumber,
+		AccountId:       r.AccountId,
+		Amount:          r.Amount,
+		TransactionType: r.TransactionType,
+		Status:          r.Status,
+		TransactionId:   r.TransactionId.String,
+		Message:         r.Message,
+	}
+}
+
+//Server
+
+//go:generate mockgen -destination=../mocks/domain/mock_importRepository.go -package=domain github.com/aliciatay-zls/banking/backend/domain ImportRepository
+type ImportRepository interface { //repo (secondary port)
+	SaveBatch(ImportBatch) (*ImportBatch, *errs.AppError)
+	FindBatchById(string) (*ImportBatch, *errs.AppError)
+	UpdateBatch(ImportBatch) *errs.AppError
+	SaveRowResult(ImportRowResult) *errs.AppError
+	FindRowResults(batchId string, afterRow int) ([]ImportRowResult, *errs.AppError)
+	CountRowResults(batchId string) (map[string]int, *errs.AppError)
+}
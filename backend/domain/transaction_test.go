@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"database/sql"
+	"github.com/aliciatay-zls/banking-lib/clock"
 	"github.com/aliciatay-zls/banking/backend/dto"
 	"testing"
 )
@@ -28,3 +30,131 @@ func TestTransaction_IsWithdrawal_returns_correctResult(t *testing.T) {
 		})
 	}
 }
+
+func TestTransaction_IsDebit_returns_correctResult(t *testing.T) {
+	//Arrange
+	tests := []struct {
+		name           string
+		transaction    Transaction
+		expectedResult bool
+	}{
+		{"withdrawal", Transaction{TransactionType: dto.TransactionTypeWithdrawal}, true},
+		{"overdraft interest", Transaction{TransactionType: dto.TransactionTypeOverdraftInterest}, true},
+		{"fee", Transaction{TransactionType: dto.TransactionTypeFee}, true},
+		{"deposit", Transaction{TransactionType: dto.TransactionTypeDeposit}, false},
+		{"interest", Transaction{TransactionType: dto.TransactionTypeInterest}, false},
+		{"outgoing transfer", Transaction{TransactionType: dto.TransactionTypeTransferOut}, true},
+		{"incoming transfer", Transaction{TransactionType: dto.TransactionTypeTransferIn}, false},
+		{"reversal of credit", Transaction{TransactionType: dto.TransactionTypeReversalDebit}, true},
+		{"reversal of debit", Transaction{TransactionType: dto.TransactionTypeReversalCredit}, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Act
+			actualResult := tc.transaction.IsDebit()
+
+			//Assert
+			if actualResult != tc.expectedResult {
+				t.Errorf("expected \"%v\" but got \"%v\"", tc.expectedResult, actualResult)
+			}
+		})
+	}
+}
+
+func TestTransaction_IsReversible_returns_correctResult(t *testing.T) {
+	//Arrange
+	tests := []struct {
+		name           string
+		transaction    Transaction
+		expectedResult bool
+	}{
+		{"deposit", Transaction{TransactionType: dto.TransactionTypeDeposit}, true},
+		{"fee", Transaction{TransactionType: dto.TransactionTypeFee}, true},
+		{"transfer", Transaction{TransactionType: dto.TransactionTypeTransferOut}, false},
+		{"reversal", Transaction{TransactionType: dto.TransactionTypeReversalCredit,
+			ReversalOf: sql.NullString{String: "7790", Valid: true}}, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Act
+			actualResult := tc.transaction.IsReversible()
+
+			//Assert
+			if actualResult != tc.expectedResult {
+				t.Errorf("expected \"%v\" but got \"%v\"", tc.expectedResult, actualResult)
+			}
+		})
+	}
+}
+
+func TestNewReversal_movesAmount_inOppositeDirection(t *testing.T) {
+	//Arrange
+	tests := []struct {
+		name         string
+		originalType string
+		expectedType string
+	}{
+		{"deposit", dto.TransactionTypeDeposit, dto.TransactionTypeReversalDebit},
+		{"withdrawal", dto.TransactionTypeWithdrawal, dto.TransactionTypeReversalCredit},
+		{"fee", dto.TransactionTypeFee, dto.TransactionTypeReversalCredit},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			original := Transaction{TransactionId: dummyTransactionId, AccountId: dummyAccountId, Amount: dummyAmount, TransactionType: tc.originalType}
+
+			//Act
+			transaction, reversal := NewReversal(original, dto.ReversalReasonDuplicate, "", clock.StaticClock{})
+
+			//Assert
+			if transaction.TransactionType != tc.expectedType {
+				t.Errorf("Expected transaction type %s but got %s", tc.expectedType, transaction.TransactionType)
+			}
+			if transaction.AccountId != dummyAccountId || transaction.Amount != dummyAmount {
+				t.Errorf("Expected reversal of %v on account %s but got %+v", dummyAmount, dummyAccountId, transaction)
+			}
+			if transaction.ReversalOf.String != dummyTransactionId || reversal.OriginalTransactionId != dummyTransactionId {
+				t.Errorf("Expected reversal to be linked to transaction %s but got %+v and %+v", dummyTransactionId, transaction, reversal)
+			}
+			if reversal.ReasonCode != dto.ReversalReasonDuplicate {
+				t.Errorf("Expected reason code %s but got %s", dto.ReversalReasonDuplicate, reversal.ReasonCode)
+			}
+		})
+	}
+}
+
+func TestNewTransfer_recordsFxRate_onlyWhen_currenciesDiffer(t *testing.T) {
+	//Arrange
+	source := Account{AccountId: dummyAccountId, Currency: "USD"}
+	tests := []struct {
+		name                 string
+		destination          Account
+		expectedCreditAmount float64
+		expectedFx           bool
+	}{
+		{"same currency", Account{AccountId: "1980", Currency: "USD"}, 100, false},
+		{"other currency", Account{AccountId: "1980", Currency: "INR"}, 8312.5, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Act
+			debit, credit := NewTransfer(source, tc.destination, 100, 83.125, clock.StaticClock{})
+
+			//Assert
+			if debit.Amount != 100 || credit.Amount != tc.expectedCreditAmount {
+				t.Errorf("Expected debit of 100 and credit of %v but got %v and %v", tc.expectedCreditAmount, debit.Amount, credit.Amount)
+			}
+			for _, leg := range []Transaction{debit, credit} {
+				if leg.FxRate.Valid != tc.expectedFx || leg.ConvertedAmount.Valid != tc.expectedFx {
+					t.Errorf("Expected fx fields to be set: %v but got %+v", tc.expectedFx, leg)
+				}
+				if tc.expectedFx && leg.ConvertedAmount.Float64 != tc.expectedCreditAmount {
+					t.Errorf("Expected converted amount %v but got %v", tc.expectedCreditAmount, leg.ConvertedAmount.Float64)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package domain
+
+import "github.com/aliciatay-zls/banking-lib/errs"
+
+// Repositories are the repositories that take part in a unit of work, so that all changes made through them are
+// made together or not at all. Repositories that are not available with the configured database, such as the hold
+// repository in demo mode, are nil.
+type Repositories struct {
+	Customers      CustomerRepository
+	Accounts       AccountRepository
+	Holds          HoldRepository
+	StandingOrders StandingOrderRepository
+	Interest       InterestRepository
+	Imports        ImportRepository
+	Beneficiaries  BeneficiaryRepository
+	Screening      ScreeningRepository
+	Sanctions      SanctionsRepository
+	Delegations    DelegationRepository
+	UnitOfWork     UnitOfWork //runs nested units of work as part of this one
+}
+
+//go:generate mockgen -destination=../mocks/domain/mock_unitOfWork.go -package=domain github.com/aliciatay-zls/banking/backend/domain UnitOfWork
+type UnitOfWork interface { //repo (secondary port)
+	// Do runs the given function with repositories that make all of their changes in one database transaction. The
+	// changes are committed if the function returns no error and undone otherwise. If the database transaction is
+	// aborted because of a deadlock, the function is run again, so it should have no side effects besides the changes
+	// made through the repositories.
+	Do(func(Repositories) *errs.AppError) *errs.AppError
+}
@@ -0,0 +1,277 @@
+package domain
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/mattn/go-sqlite3"
+	"time"
+)
+
+//Server
+
+// maxTxAttempts is the number of times a database transaction is tried before giving up when it keeps being aborted
+// because of deadlocks. The delay before each retry doubles, starting from txRetryDelay.
+const maxTxAttempts = 3
+
+var txRetryDelay = 50 * time.Millisecond
+
+// dbExecutor runs the SQL of repository adapters, either directly on the database (*sqlx.DB) or within a database
+// transaction that has already been started (dbTx), so that the same adapter code works on its own and as part of a
+// unit of work.
+type dbExecutor interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Get(dest interface{}, query string, args ...interface{}) error
+	Select(dest interface{}, query string, args ...interface{}) error
+	Rebind(query string) string
+	DriverName() string
+}
+
+// txState is the state shared by all levels of a database transaction.
+type txState struct {
+	tx         *sqlx.Tx
+	savepoints int
+	retryable  bool //whether the database aborted the transaction, e.g. because of a deadlock
+}
+
+// dbTx is a level of a database transaction: the transaction itself, or a savepoint within it that is set when a
+// repository method or unit of work is run as part of another one. It notes any error that aborted the transaction,
+// so that the transaction can be retried even if the error is handled further up.
+type dbTx struct {
+	*txState
+	savepoint string //empty for the transaction itself
+}
+
+func (t dbTx) Exec(query string, args ...interface{}) (sql.Result, error) {
+	result, err := t.tx.Exec(query, args...)
+	t.check(err)
+	return result, err
+}
+
+func (t dbTx) Get(dest interface{}, query string, args ...interface{}) error {
+	err := t.tx.Get(dest, query, args...)
+	t.check(err)
+	return err
+}
+
+func (t dbTx) Select(dest interface{}, query string, args ...interface{}) error {
+	err := t.tx.Select(dest, query, args...)
+	t.check(err)
+	return err
+}
+
+func (t dbTx) Rebind(query string) string {
+	return t.tx.Rebind(query)
+}
+
+func (t dbTx) DriverName() string {
+	return t.tx.DriverName()
+}
+
+func (t dbTx) check(err error) {
+	if isRetryable(err) {
+		t.retryable = true
+	}
+}
+
+// isRetryable checks whether the given error means that the database aborted the transaction because it conflicted
+// with another one, in which case it may succeed if tried again.
+func isRetryable(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlErr.Number == 1213 || mysqlErr.Number == 1205 //deadlock, lock wait timeout
+	}
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code == "40P01" || pqErr.Code == "40001" //deadlock, serialization failure
+	}
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.Code == sqlite3.ErrBusy || sqliteErr.Code == sqlite3.ErrLocked
+	}
+	return false
+}
+
+// isDuplicateKey checks whether the given error means that a row could not be inserted because a row with the same
+// primary or unique key already exists.
+func isDuplicateKey(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlErr.Number == 1062
+	}
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code == "23505"
+	}
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.ExtendedCode == sqlite3.ErrConstraintPrimaryKey || sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique
+	}
+	return false
+}
+
+// inTransaction runs the given function within a database transaction started on the given executor, committing it
+// if the function returns no error and rolling it back otherwise. The purpose of the database transaction is used in
+// the log message if it cannot be started. If the executor is already within a database transaction, a savepoint is
+// set instead, so that nested calls join the outer transaction and only undo their own changes on error. Otherwise,
+// the whole database transaction is retried if the database aborts it because of a deadlock.
+func inTransaction(executor dbExecutor, purpose string, fn func(dbExecutor) *errs.AppError) *errs.AppError {
+	if outer, ok := executor.(dbTx); ok {
+		return inSavepoint(outer, purpose, fn)
+	}
+	client, ok := executor.(*sqlx.DB)
+	if !ok {
+		logger.Error(fmt.Sprintf("Error while starting db transaction for %s: unknown executor %T", purpose, executor))
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	delay := txRetryDelay
+	for attempt := 1; ; attempt++ {
+		tx, err := client.Beginx()
+		if err != nil {
+			logger.Error("Error while starting db transaction for " + purpose + ": " + err.Error())
+			return errs.NewUnexpectedError("Unexpected database error")
+		}
+
+		t := dbTx{txState: &txState{tx: tx}}
+		appErr := runInTx(t, fn)
+		if appErr == nil && !t.retryable {
+			err = tx.Commit()
+			if err == nil {
+				return nil
+			}
+			t.check(err)
+			if !t.retryable || attempt == maxTxAttempts {
+				logger.Error("Error while committing db transaction: " + err.Error())
+				return errs.NewUnexpectedError("Unexpected database error")
+			}
+		} else {
+			rollback(tx.Tx)
+		}
+
+		if !t.retryable {
+			return appErr
+		}
+		if attempt == maxTxAttempts {
+			logger.Error(fmt.Sprintf("Error while running db transaction for %s: aborted %d times", purpose, attempt))
+			return errs.NewUnexpectedError("Unexpected database error")
+		}
+		logger.Info(fmt.Sprintf("Retrying db transaction for %s in %v after it was aborted", purpose, delay))
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+// runInTx runs the given function within the given database transaction, rolling it back if the function panics.
+func runInTx(t dbTx, fn func(dbExecutor) *errs.AppError) *errs.AppError {
+	defer func() {
+		if r := recover(); r != nil {
+			rollback(t.tx.Tx)
+			panic(r)
+		}
+	}()
+	return fn(t)
+}
+
+// inSavepoint runs the given function within a savepoint set in the given database transaction, rolling back to the
+// savepoint if the function returns an error. If the database aborted the whole transaction, the error is returned
+// without touching the savepoint, and the outermost call retries the transaction.
+func inSavepoint(outer dbTx, purpose string, fn func(dbExecutor) *errs.AppError) *errs.AppError {
+	outer.savepoints++
+	t := dbTx{outer.txState, fmt.Sprintf("sp%d", outer.savepoints)}
+	if _, err := t.Exec("SAVEPOINT " + t.savepoint); err != nil {
+		logger.Error("Error while setting savepoint for " + purpose + ": " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	appErr := fn(t)
+	if t.retryable {
+		if appErr == nil {
+			appErr = errs.NewUnexpectedError("Unexpected database error")
+		}
+		return appErr
+	}
+	if appErr != nil {
+		if _, err := t.Exec("ROLLBACK TO SAVEPOINT " + t.savepoint); err != nil {
+			logger.Error("Error while rolling back to savepoint: " + err.Error())
+		}
+		return appErr
+	}
+	if _, err := t.Exec("RELEASE SAVEPOINT " + t.savepoint); err != nil {
+		logger.Error("Error while releasing savepoint: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	return nil
+}
+
+// rollback rolls back the given database transaction, logging any error since there is nothing more to be done.
+func rollback(tx *sql.Tx) {
+	if err := tx.Rollback(); err != nil {
+		logger.Error("Error while rolling back db transaction: " + err.Error())
+	}
+}
+
+// findBalance retrieves the current balance of the account with the given id, which includes the changes made so far
+// in the database transaction of the given executor.
+func findBalance(executor dbExecutor, accountId string) (float64, *errs.AppError) {
+	var balance float64
+	if err := executor.Get(&balance, executor.Rebind("SELECT amount FROM accounts WHERE account_id = ?"), accountId); err != nil {
+		logger.Error("Error while retrieving new account balance: " + err.Error())
+		return 0, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return balance, nil
+}
+
+type UnitOfWorkDb struct { //DB (adapter)
+	client dbExecutor
+}
+
+func NewUnitOfWorkDb(dbClient *sqlx.DB) UnitOfWorkDb {
+	return UnitOfWorkDb{dbClient}
+}
+
+// Do starts a database transaction and runs the given function with repositories that make their changes within it.
+// When called on the unit of work given to the function, it sets a savepoint within the same database transaction
+// instead, so that only the nested unit of work's changes are undone if it fails.
+func (u UnitOfWorkDb) Do(fn func(Repositories) *errs.AppError) *errs.AppError { //DB implements repo
+	return inTransaction(u.client, "unit of work", func(tx dbExecutor) *errs.AppError {
+		return fn(u.repositories(tx))
+	})
+}
+
+// repositories returns the repository adapters for the configured database, bound to the given database transaction.
+func (u UnitOfWorkDb) repositories(tx dbExecutor) Repositories {
+	if tx.DriverName() == "postgres" {
+		return Repositories{
+			Customers:      CustomerRepositoryPostgres{tx},
+			Accounts:       AccountRepositoryPostgres{tx},
+			Holds:          HoldRepositoryPostgres{tx},
+			StandingOrders: StandingOrderRepositoryPostgres{tx},
+			Interest:       InterestRepositoryPostgres{tx},
+			Imports:        ImportRepositoryPostgres{tx},
+			Beneficiaries:  BeneficiaryRepositoryPostgres{tx},
+			Screening:      ScreeningRepositoryPostgres{tx},
+			Sanctions:      SanctionsRepositoryPostgres{tx},
+			Delegations:    DelegationRepositoryPostgres{tx},
+			UnitOfWork:     UnitOfWorkDb{tx},
+		}
+	}
+	return Repositories{
+		Customers:      CustomerRepositoryDb{tx},
+		Accounts:       AccountRepositoryDb{tx},
+		Holds:          HoldRepositoryDb{tx},
+		StandingOrders: StandingOrderRepositoryDb{tx},
+		Interest:       InterestRepositoryDb{tx},
+		Imports:        ImportRepositoryDb{tx},
+		Beneficiaries:  BeneficiaryRepositoryDb{tx},
+		Screening:      ScreeningRepositoryDb{tx},
+		Sanctions:      SanctionsRepositoryDb{tx},
+		Delegations:    DelegationRepositoryDb{tx},
+		UnitOfWork:     UnitOfWorkDb{tx},
+	}
+}
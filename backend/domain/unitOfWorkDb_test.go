@@ -0,0 +1,344 @@
+package domain
+
+import (
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/mattn/go-sqlite3"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// Test common variables and inputs
+var unitOfWorkDb UnitOfWorkDb
+
+var dummyDeadlockErr = &mysql.MySQLError{Number: 1213, Message: "Deadlock found when trying to get lock"}
+
+func setupUnitOfWorkDbTest(t *testing.T) func() {
+	teardown := setupDB(t)
+	unitOfWorkDb = NewUnitOfWorkDb(sqlx.NewDb(db, driverName))
+	txRetryDelay = 0
+	return func() {
+		txRetryDelay = 50 * time.Millisecond
+		teardown()
+	}
+}
+
+func TestUnitOfWorkDb_Do_commits_when_function_succeeds(t *testing.T) {
+	//Arrange
+	teardown := setupUnitOfWorkDbTest(t)
+	defer teardown()
+
+	mockDB.ExpectBegin()
+	mockDB.ExpectExec(updateAccountsOverdraftLimitSql).WithArgs(500.0, dummyAccountId).WillReturnResult(sqlmock.NewResult(0, 1))
+	mockDB.ExpectExec(updateAccountsOverdraftLimitSql).WithArgs(500.0, "1980").WillReturnResult(sqlmock.NewResult(0, 1))
+	mockDB.ExpectCommit()
+
+	//Act
+	err := unitOfWorkDb.Do(func(repos Repositories) *errs.AppError {
+		if err := repos.Accounts.UpdateOverdraftLimit(dummyAccountId, 500); err != nil {
+			return err
+		}
+		return repos.Accounts.UpdateOverdraftLimit("1980", 500)
+	})
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if err := mockDB.ExpectationsWereMet(); err != nil {
+		t.Error(err.Error())
+	}
+}
+
+func TestUnitOfWorkDb_Do_rollsBack_and_logs_when_rollback_fails(t *testing.T) {
+	//Arrange
+	teardown := setupUnitOfWorkDbTest(t)
+	defer teardown()
+
+	dummyRollbackErr := errors.New("connection lost")
+	mockDB.ExpectBegin()
+	mockDB.ExpectRollback().WillReturnError(dummyRollbackErr)
+
+	logs := logger.ReplaceWithTestLogger()
+	expectedLogMessage := "Error while rolling back db transaction: " + dummyRollbackErr.Error()
+
+	//Act
+	err := unitOfWorkDb.Do(func(repos Repositories) *errs.AppError {
+		return errs.NewValidationError("some validation error")
+	})
+
+	//Assert
+	if err == nil || err.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("Expected the function's error but got %v", err)
+	}
+	if logs.Len() != 1 || logs.All()[0].Message != expectedLogMessage {
+		t.Errorf("Expected log message \"%s\" but got %v", expectedLogMessage, logs.All())
+	}
+	if err := mockDB.ExpectationsWereMet(); err != nil {
+		t.Error(err.Error())
+	}
+}
+
+func TestUnitOfWorkDb_Do_rollsBack_and_repanics_when_function_panics(t *testing.T) {
+	//Arrange
+	teardown := setupUnitOfWorkDbTest(t)
+	defer teardown()
+
+	mockDB.ExpectBegin()
+	mockDB.ExpectRollback()
+
+	//Act
+	defer func() {
+		//Assert
+		if r := recover(); r != "some panic" {
+			t.Errorf("Expected panic to be passed on but got %v", r)
+		}
+		if err := mockDB.ExpectationsWereMet(); err != nil {
+			t.Error(err.Error())
+		}
+	}()
+	_ = unitOfWorkDb.Do(func(repos Repositories) *errs.AppError {
+		panic("some panic")
+	})
+}
+
+func TestUnitOfWorkDb_Do_joinsTransaction_with_savepoint_when_repositoryMethod_startsTransaction(t *testing.T) {
+	//Arrange
+	teardown := setupUnitOfWorkDbTest(t)
+	defer teardown()
+
+	debit := Transaction{AccountId: dummyAccountId, Amount: dummyAmount, TransactionType: dummyTransactionType, TransactionDate: dummyDate}
+	reversal := Reversal{OriginalTransactionId: "7790", ReversalDate: dummyDate}
+
+	mockDB.ExpectBegin()
+	mockDB.ExpectExec("SAVEPOINT sp1").WillReturnResult(sqlmock.NewResult(0, 0))
+	mockDB.ExpectExec(updateAccountsDepositSql).WithArgs(debit.Amount, debit.AccountId).WillReturnResult(sqlmock.NewResult(0, 1))
+	mockDB.ExpectExec(insertActedTransactionsSql).
+		WithArgs(debit.AccountId, debit.Amount, debit.TransactionType, debit.TransactionDate, debit.ActingCustomer).
+		WillReturnResult(sqlmock.NewResult(dummyTransactionIdAsInt, 1))
+	mockDB.ExpectQuery(selectBalanceSql).WithArgs(dummyAccountId).
+		WillReturnRows(sqlmock.NewRows([]string{"amount"}).AddRow(dummyBalance))
+	expectEventSaved(NewTransactionPostedEvent(Transaction{AccountId: debit.AccountId, Amount: debit.Amount,
+		TransactionType: debit.TransactionType, TransactionDate: debit.TransactionDate,
+		TransactionId: dummyTransactionId, Balance: dummyBalance}))
+	mockDB.ExpectExec("RELEASE SAVEPOINT sp1").WillReturnResult(sqlmock.NewResult(0, 0))
+	mockDB.ExpectExec("SAVEPOINT sp2").WillReturnResult(sqlmock.NewResult(0, 0))
+	mockDB.ExpectQuery(countReversalsSql).WithArgs(reversal.OriginalTransactionId).
+		WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(1))
+	mockDB.ExpectExec("ROLLBACK TO SAVEPOINT sp2").WillReturnResult(sqlmock.NewResult(0, 0))
+	mockDB.ExpectCommit()
+
+	//Act
+	var reversalErr *errs.AppError
+	err := unitOfWorkDb.Do(func(repos Repositories) *errs.AppError {
+		if _, err := repos.Accounts.Transact(debit); err != nil {
+			return err
+		}
+		_, reversalErr = repos.Accounts.Reverse(debit, reversal)
+		return nil
+	})
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if reversalErr == nil || reversalErr.Code != http.StatusConflict {
+		t.Errorf("Expected conflict error from nested call but got %v", reversalErr)
+	}
+	if err := mockDB.ExpectationsWereMet(); err != nil {
+		t.Error(err.Error())
+	}
+}
+
+func TestUnitOfWorkDb_Do_retries_when_transaction_deadlocks(t *testing.T) {
+	//Arrange
+	teardown := setupUnitOfWorkDbTest(t)
+	defer teardown()
+
+	mockDB.ExpectBegin()
+	mockDB.ExpectExec(updateAccountsOverdraftLimitSql).WithArgs(500.0, dummyAccountId).WillReturnError(dummyDeadlockErr)
+	mockDB.ExpectRollback()
+	mockDB.ExpectBegin()
+	mockDB.ExpectExec(updateAccountsOverdraftLimitSql).WithArgs(500.0, dummyAccountId).WillReturnResult(sqlmock.NewResult(0, 1))
+	mockDB.ExpectCommit()
+
+	logger.MuteLogger()
+	attempts := 0
+
+	//Act
+	err := unitOfWorkDb.Do(func(repos Repositories) *errs.AppError {
+		attempts++
+		_ = repos.Accounts.UpdateOverdraftLimit(dummyAccountId, 500) //error is ignored, yet the deadlock is noted
+		return nil
+	})
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if attempts != 2 {
+		t.Errorf("Expected 2 attempts but got %d", attempts)
+	}
+	if err := mockDB.ExpectationsWereMet(); err != nil {
+		t.Error(err.Error())
+	}
+}
+
+func TestUnitOfWorkDb_Do_returns_error_when_transaction_keeps_deadlocking(t *testing.T) {
+	//Arrange
+	teardown := setupUnitOfWorkDbTest(t)
+	defer teardown()
+
+	for k := 0; k < maxTxAttempts; k++ {
+		mockDB.ExpectBegin()
+		mockDB.ExpectExec(updateAccountsOverdraftLimitSql).WithArgs(500.0, dummyAccountId).WillReturnError(dummyDeadlockErr)
+		mockDB.ExpectRollback()
+	}
+
+	logger.MuteLogger()
+
+	//Act
+	err := unitOfWorkDb.Do(func(repos Repositories) *errs.AppError {
+		return repos.Accounts.UpdateOverdraftLimit(dummyAccountId, 500)
+	})
+
+	//Assert
+	if err == nil || err.Code != http.StatusInternalServerError {
+		t.Fatalf("Expected unexpected error but got %v", err)
+	}
+	if err := mockDB.ExpectationsWereMet(); err != nil {
+		t.Error(err.Error())
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"MySQL deadlock", dummyDeadlockErr, true},
+		{"MySQL lock wait timeout", &mysql.MySQLError{Number: 1205}, true},
+		{"MySQL duplicate entry", &mysql.MySQLError{Number: 1062}, false},
+		{"PostgreSQL deadlock", &pq.Error{Code: "40P01"}, true},
+		{"PostgreSQL serialization failure", &pq.Error{Code: "40001"}, true},
+		{"PostgreSQL unique violation", &pq.Error{Code: "23505"}, false},
+		{"SQLite busy", sqlite3.Error{Code: sqlite3.ErrBusy}, true},
+		{"SQLite constraint", sqlite3.Error{Code: sqlite3.ErrConstraint}, false},
+		{"other error", errors.New("some error message"), false},
+		{"no error", nil, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := isRetryable(tc.err); actual != tc.expected {
+				t.Errorf("Expected %v but got %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestIsDuplicateKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"MySQL duplicate entry", &mysql.MySQLError{Number: 1062}, true},
+		{"MySQL deadlock", dummyDeadlockErr, false},
+		{"PostgreSQL unique violation", &pq.Error{Code: "23505"}, true},
+		{"SQLite primary key constraint", sqlite3.Error{Code: sqlite3.ErrConstraint, ExtendedCode: sqlite3.ErrConstraintPrimaryKey}, true},
+		{"SQLite foreign key constraint", sqlite3.Error{Code: sqlite3.ErrConstraint, ExtendedCode: sqlite3.ErrConstraintForeignKey}, false},
+		{"other error", errors.New("some error message"), false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := isDuplicateKey(tc.err); actual != tc.expected {
+				t.Errorf("Expected %v but got %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+// The following tests run on a real SQLite database seeded with the demo data, since go-sqlmock cannot show whether
+// changes were actually undone.
+
+func setupUnitOfWorkSqliteTest(t *testing.T) (UnitOfWorkDb, AccountRepositoryDb) {
+	logger.MuteLogger()
+	client := openSQLiteDb(t)
+	return NewUnitOfWorkDb(client), NewAccountRepositoryDb(client)
+}
+
+func TestUnitOfWorkDb_Do_undoes_changes_of_allRepositories_when_function_fails(t *testing.T) {
+	//Arrange
+	uow, accRepo := setupUnitOfWorkSqliteTest(t)
+	clk := clock.StaticClock{}
+
+	//Act
+	err := uow.Do(func(repos Repositories) *errs.AppError {
+		if _, err := repos.Accounts.Transact(NewTransaction("95472", 500, dto.TransactionTypeDeposit, clk)); err != nil {
+			return err
+		}
+		if _, err := repos.Holds.Save(NewHold(dto.NewHoldRequest{AccountId: "95472", Amount: 100}, clk)); err != nil {
+			return err
+		}
+		return errs.NewValidationError("some validation error")
+	})
+
+	//Assert
+	if err == nil || err.Message != "some validation error" {
+		t.Fatalf("Expected the function's error but got %v", err)
+	}
+	account, err := accRepo.FindById("95472")
+	if err != nil {
+		t.Fatal("Expected no error but got error while finding account: " + err.Message)
+	}
+	if account.Amount != 7000 || account.HeldAmount != 0 {
+		t.Errorf("Expected balance 7000 and held amount 0 but got %v and %v", account.Amount, account.HeldAmount)
+	}
+}
+
+func TestUnitOfWorkDb_Do_only_undoes_changes_of_nestedDo_when_nestedFunction_fails(t *testing.T) {
+	//Arrange
+	uow, accRepo := setupUnitOfWorkSqliteTest(t)
+	clk := clock.StaticClock{}
+	var nestedErr *errs.AppError
+
+	//Act
+	err := uow.Do(func(repos Repositories) *errs.AppError {
+		if _, err := repos.Accounts.Transact(NewTransaction("95472", 500, dto.TransactionTypeDeposit, clk)); err != nil {
+			return err
+		}
+		nestedErr = repos.UnitOfWork.Do(func(nested Repositories) *errs.AppError {
+			if _, err := nested.Accounts.Transact(NewTransaction("95472", 200, dto.TransactionTypeWithdrawal, clk)); err != nil {
+				return err
+			}
+			return errs.NewValidationError("some validation error")
+		})
+		return nil
+	})
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if nestedErr == nil || nestedErr.Message != "some validation error" {
+		t.Fatalf("Expected the nested function's error but got %v", nestedErr)
+	}
+	account, err := accRepo.FindById("95472")
+	if err != nil {
+		t.Fatal("Expected no error but got error while finding account: " + err.Message)
+	}
+	if account.Amount != 7500 {
+		t.Errorf("Expected balance 7500 but got %v", account.Amount)
+	}
+}
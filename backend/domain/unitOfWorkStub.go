@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"sync"
+)
+
+//Server
+
+// UnitOfWorkStub runs units of work on in-memory repositories one at a time. Unlike a database transaction, it cannot
+// undo the changes of a unit of work that fails part of the way through, so it is only meant for demo mode.
+type UnitOfWorkStub struct { //stub (adapter)
+	mu     *sync.Mutex
+	repos  Repositories
+	nested bool
+}
+
+func NewUnitOfWorkStub(repos Repositories) UnitOfWorkStub {
+	return UnitOfWorkStub{mu: &sync.Mutex{}, repos: repos}
+}
+
+// Do runs the given function with the stub's repositories once no other unit of work is running. When called on the
+// unit of work given to the function, it runs the nested function right away.
+func (s UnitOfWorkStub) Do(fn func(Repositories) *errs.AppError) *errs.AppError { //stub implements repo
+	if !s.nested {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+	}
+
+	repos := s.repos
+	repos.UnitOfWork = UnitOfWorkStub{s.mu, s.repos, true}
+	return fn(repos)
+}
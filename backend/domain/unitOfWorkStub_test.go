@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"testing"
+)
+
+func TestUnitOfWorkStub_Do_runs_nestedUnitOfWork_without_waiting(t *testing.T) {
+	//Arrange
+	accRepo := NewAccountRepositoryStub([]Account{{AccountId: "95470", CustomerId: "2000", Amount: 100}})
+	uow := NewUnitOfWorkStub(Repositories{Accounts: accRepo})
+	ran := false
+
+	//Act
+	err := uow.Do(func(repos Repositories) *errs.AppError {
+		return repos.UnitOfWork.Do(func(nested Repositories) *errs.AppError {
+			ran = true
+			_, err := nested.Accounts.FindById("95470")
+			return err
+		})
+	})
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if !ran {
+		t.Error("Expected nested unit of work to run")
+	}
+}
@@ -0,0 +1,311 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+//Business Domain
+
+// Default thresholds of sanctions screening. Names are compared after normalizing them, with a Jaro-Winkler score
+// between 0 (nothing in common) and 1 (the same name).
+const DefaultSanctionsNameThreshold = 0.88
+const DefaultSanctionsStrongNameThreshold = 0.95
+
+// SanctionsThresholds are how similar a name must be to a watchlist entry's name or one of its aliases for a customer
+// to potentially be the person on the watchlist.
+type SanctionsThresholds struct {
+	Name       float64 //names scoring at least this are a hit, unless the date of birth or country says otherwise
+	StrongName float64 //names scoring at least this are a hit even if the country is different
+}
+
+func DefaultSanctionsThresholds() SanctionsThresholds {
+	return SanctionsThresholds{Name: DefaultSanctionsNameThreshold, StrongName: DefaultSanctionsStrongNameThreshold}
+}
+
+// Validate checks that both thresholds are between 0 and 1 and that the strong threshold is not below the other one.
+func (t SanctionsThresholds) Validate() error {
+	if t.Name <= 0 || t.Name > 1 || t.StrongName <= 0 || t.StrongName > 1 {
+		return errors.New("sanctions name thresholds should be above 0 and at most 1")
+	}
+	if t.StrongName < t.Name {
+		return errors.New("strong sanctions name threshold should not be below the name threshold")
+	}
+	return nil
+}
+
+// WatchlistEntry is a person on a sanctions list or watchlist. The date of birth is either a full date (YYYY-MM-DD)
+// or only the year (YYYY), and is empty if unknown, like the country.
+type WatchlistEntry struct {
+	ListId      string   `xml:"id,attr"`
+	Name        string   `xml:"name"`
+	Aliases     []string `xml:"alias"`
+	DateOfBirth string   `xml:"date_of_birth"`
+	Country     string   `xml:"country"`
+}
+
+// Watchlist is the list that customers are screened against. Screening is off if it has no entries.
+type Watchlist struct {
+	Entries    []WatchlistEntry
+	Thresholds SanctionsThresholds
+	Digest     string //hash of the file the entries were loaded from, so that customers are screened again when it changes
+}
+
+// SanctionsMatch is a watchlist entry that a customer potentially is.
+type SanctionsMatch struct {
+	CustomerId  string  `json:"customer_id"`
+	ListId      string  `json:"list_id"`
+	Name        string  `json:"name"`
+	DateOfBirth string  `json:"date_of_birth"`
+	Country     string  `json:"country"`
+	Score       float64 `json:"score"`
+}
+
+// watchlistXml is the layout of XML watchlist files.
+type watchlistXml struct {
+	Entries []WatchlistEntry `xml:"entry"`
+}
+
+// LoadWatchlist reads the watchlist from the CSV or XML file at the given path, depending on its extension. CSV files
+// have a header row naming the columns "name", "date_of_birth", "country" and optionally "id" and "aliases" (separated
+// by semicolons), in any order. XML files hold a <watchlist> element with an <entry> element per person, which has an
+// optional id attribute and <name>, <alias>, <date_of_birth> and <country> elements.
+func LoadWatchlist(path string, thresholds SanctionsThresholds) (Watchlist, error) {
+	if err := thresholds.Validate(); err != nil {
+		return Watchlist{}, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Watchlist{}, err
+	}
+
+	var entries []WatchlistEntry
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		entries, err = parseWatchlistCsv(strings.NewReader(string(data)))
+	case ".xml":
+		var file watchlistXml
+		err = xml.Unmarshal(data, &file)
+		entries = file.Entries
+	default:
+		return Watchlist{}, errors.New("watchlist file should be a .csv or .xml file")
+	}
+	if err != nil {
+		return Watchlist{}, err
+	}
+
+	for i, e := range entries {
+		if normalizeName(e.Name) == "" {
+			return Watchlist{}, fmt.Errorf("watchlist entry %d has no name", i+1)
+		}
+		if e.DateOfBirth != "" && !isWatchlistDate(e.DateOfBirth) {
+			return Watchlist{}, fmt.Errorf("watchlist entry %d has a date of birth that is not YYYY-MM-DD or YYYY", i+1)
+		}
+	}
+
+	sum := sha256.Sum256(data)
+	return Watchlist{Entries: entries, Thresholds: thresholds, Digest: hex.EncodeToString(sum[:])}, nil
+}
+
+func parseWatchlistCsv(r io.Reader) ([]WatchlistEntry, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, errors.New("watchlist file has no header row")
+	}
+
+	columns := make(map[string]int)
+	for i, name := range rows[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["name"]; !ok {
+		return nil, errors.New("watchlist file has no name column")
+	}
+	column := func(row []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(row) {
+			return strings.TrimSpace(row[i])
+		}
+		return ""
+	}
+
+	entries := make([]WatchlistEntry, 0)
+	for _, row := range rows[1:] {
+		entry := WatchlistEntry{
+			ListId:      column(row, "id"),
+			Name:        column(row, "name"),
+			DateOfBirth: column(row, "date_of_birth"),
+			Country:     column(row, "country"),
+		}
+		for _, alias := range strings.Split(column(row, "aliases"), ";") {
+			if alias = strings.TrimSpace(alias); alias != "" {
+				entry.Aliases = append(entry.Aliases, alias)
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func isWatchlistDate(date string) bool {
+	if _, err := time.Parse("2006-01-02", date); err == nil {
+		return true
+	}
+	_, err := time.Parse("2006", date)
+	return err == nil
+}
+
+func (w Watchlist) IsEmpty() bool {
+	return len(w.Entries) == 0
+}
+
+// Screen returns the watchlist entries that the given customer potentially is. A customer is a hit for an entry if
+// their name is similar enough to the entry's name or one of its aliases, and their date of birth does not contradict
+// the entry's. A different country only rules out a hit if the names are not strongly similar, since people move.
+func (w Watchlist) Screen(c Customer) []SanctionsMatch {
+	matches := make([]SanctionsMatch, 0)
+	for _, e := range w.Entries {
+		score := nameSimilarity(c.Name, e.Name)
+		for _, alias := range e.Aliases {
+			if s := nameSimilarity(c.Name, alias); s > score {
+				score = s
+			}
+		}
+		if score < w.Thresholds.Name {
+			continue
+		}
+		if e.DateOfBirth != "" && c.DateOfBirth != "" && !strings.HasPrefix(c.DateOfBirth, e.DateOfBirth) {
+			continue
+		}
+		if e.Country != "" && c.Country != "" && normalizeName(e.Country) != normalizeName(c.Country) &&
+			score < w.Thresholds.StrongName {
+			continue
+		}
+
+		matches = append(matches, SanctionsMatch{
+			CustomerId:  c.Id,
+			ListId:      e.ListId,
+			Name:        e.Name,
+			DateOfBirth: e.DateOfBirth,
+			Country:     e.Country,
+			Score:       float64(int(score*1000)) / 1000,
+		})
+	}
+	return matches
+}
+
+// Fingerprint identifies the details of the given customer that are screened, together with the watchlist and
+// thresholds they are screened with, so that a customer is only screened again once any of them has changed.
+func (w Watchlist) Fingerprint(c Customer) string {
+	details := strings.Join([]string{w.Digest, fmt.Sprintf("%g/%g", w.Thresholds.Name, w.Thresholds.StrongName),
+		normalizeName(c.Name), c.DateOfBirth, normalizeName(c.Country)}, "|")
+	sum := sha256.Sum256([]byte(details))
+	return hex.EncodeToString(sum[:])
+}
+
+// foldedLetters are the accented letters in names that are compared as their unaccented form.
+var foldedLetters = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ä", "a", "ã", "a", "å", "a", "ā", "a", "æ", "ae",
+	"ç", "c", "č", "c", "ć", "c",
+	"é", "e", "è", "e", "ê", "e", "ë", "e", "ē", "e", "ě", "e",
+	"í", "i", "ì", "i", "î", "i", "ï", "i", "ī", "i", "ı", "i",
+	"ñ", "n", "ń", "n", "ň", "n",
+	"ó", "o", "ò", "o", "ô", "o", "ö", "o", "õ", "o", "ø", "o", "ō", "o", "œ", "oe",
+	"ř", "r", "š", "s", "ś", "s", "ş", "s", "ß", "ss",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u", "ū", "u", "ů", "u",
+	"ý", "y", "ÿ", "y", "ž", "z", "ź", "z", "ż", "z", "ł", "l", "đ", "d",
+)
+
+// normalizeName lowercases the given name, drops accents and punctuation and sorts its words, so that names written
+// in a different order or style compare as the same.
+func normalizeName(name string) string {
+	name = foldedLetters.Replace(strings.ToLower(name))
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	sort.Strings(words)
+	return strings.Join(words, " ")
+}
+
+// nameSimilarity returns the Jaro-Winkler similarity of the given names after normalizing them.
+func nameSimilarity(a string, b string) float64 {
+	return jaroWinkler(normalizeName(a), normalizeName(b))
+}
+
+// jaroWinkler returns the Jaro-Winkler similarity of the given strings, which favours strings with a common prefix.
+func jaroWinkler(a string, b string) float64 {
+	s1, s2 := []rune(a), []rune(b)
+	if len(s1) == 0 || len(s2) == 0 {
+		return 0
+	}
+
+	window := len(s1)
+	if len(s2) > window {
+		window = len(s2)
+	}
+	window = window/2 - 1
+	if window < 0 {
+		window = 0
+	}
+
+	matched1 := make([]bool, len(s1))
+	matched2 := make([]bool, len(s2))
+	matches := 0
+	for i := range s1 {
+		start, end := i-window, i+window+1
+		if start < 0 {
+			start = 0
+		}
+		if end > len(s2) {
+			end = len(s2)
+		}
+		for j := start; j < end; j++ {
+			if !matched2[j] && s1[i] == s2[j] {
+				matched1[i], matched2[j] = true, true
+				matches++
+				break
+			}
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+
+	transpositions := 0
+	j := 0
+	for i := range s1 {
+		if !matched1[i] {
+			continue
+		}
+		for !matched2[j] {
+			j++
+		}
+		if s1[i] != s2[j] {
+			transpositions++
+		}
+		j++
+	}
+
+	m := float64(matches)
+	jaro := (m/float64(len(s1)) + m/float64(len(s2)) + (m-float64(transpositions)/2)/m) / 3
+
+	prefix := 0
+	for prefix < len(s1) && prefix < len(s2) && prefix < 4 && s1[prefix] == s2[prefix] {
+		prefix++
+	}
+	return jaro + float64(prefix)*0.1*(1-jaro)
+}
@@ -0,0 +1,170 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"math"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestJaroWinkler(t *testing.T) {
+	tests := []struct {
+		a        string
+		b        string
+		expected float64
+	}{
+		{"martha", "marhta", 0.961},
+		{"dwayne", "duane", 0.84},
+		{"abc", "abc", 1},
+		{"abc", "xyz", 0},
+		{"", "abc", 0},
+	}
+
+	for _, tc := range tests {
+		//Act
+		actual := jaroWinkler(tc.a, tc.b)
+
+		//Assert
+		if math.Abs(actual-tc.expected) > 0.001 {
+			t.Errorf("Expected similarity of %s and %s to be %.3f but got %.3f", tc.a, tc.b, tc.expected, actual)
+		}
+	}
+}
+
+func TestNormalizeName(t *testing.T) {
+	//Act
+	actual := normalizeName("  Dufresne, Marguerite-Solange  Hélène ")
+
+	//Assert
+	if actual != "dufresne helene marguerite solange" {
+		t.Errorf("Expected normalized name but got %q", actual)
+	}
+}
+
+func TestWatchlist_Screen(t *testing.T) {
+	watchlist := Watchlist{
+		Entries: []WatchlistEntry{
+			{ListId: "WL-1", Name: "Viktor Ardenko", Aliases: []string{"Vitya Ardenko"}, DateOfBirth: "1971-03-09", Country: "Freedonia"},
+			{ListId: "WL-2", Name: "Marguerite Dufresne", DateOfBirth: "1965", Country: "Latveria"},
+		},
+		Thresholds: DefaultSanctionsThresholds(),
+	}
+	tests := []struct {
+		name            string
+		customer        Customer
+		expectedListIds []string
+	}{
+		{"exact match", Customer{Name: "Viktor Ardenko", DateOfBirth: "1971-03-09", Country: "Freedonia"}, []string{"WL-1"}},
+		{"misspelt name in other order", Customer{Name: "Ardenco, Victor", DateOfBirth: "1971-03-09"}, []string{"WL-1"}},
+		{"alias", Customer{Name: "Vitya Ardenko", DateOfBirth: "1971-03-09"}, []string{"WL-1"}},
+		{"accents and year of birth", Customer{Name: "Marguérite Dufresne", DateOfBirth: "1965-07-01"}, []string{"WL-2"}},
+		{"different date of birth", Customer{Name: "Viktor Ardenko", DateOfBirth: "1971-03-10"}, []string{}},
+		{"exact name in other country", Customer{Name: "Viktor Ardenko", DateOfBirth: "1971-03-09", Country: "Norway"}, []string{"WL-1"}},
+		{"similar name in other country", Customer{Name: "Victor Ardenco", DateOfBirth: "1971-03-09", Country: "Norway"}, []string{}},
+		{"different name", Customer{Name: "Steve", DateOfBirth: "1971-03-09", Country: "Freedonia"}, []string{}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Act
+			matches := watchlist.Screen(tc.customer)
+
+			//Assert
+			if len(matches) != len(tc.expectedListIds) {
+				t.Fatalf("Expected matches %v but got %+v", tc.expectedListIds, matches)
+			}
+			for i, m := range matches {
+				if m.ListId != tc.expectedListIds[i] {
+					t.Errorf("Expected matches %v but got %+v", tc.expectedListIds, matches)
+				}
+			}
+		})
+	}
+}
+
+func TestWatchlist_Fingerprint_changes_with_screenedDetails_only(t *testing.T) {
+	//Arrange
+	watchlist := Watchlist{Thresholds: DefaultSanctionsThresholds(), Digest: "abc"}
+	c := Customer{Id: "2000", Name: "Steve", DateOfBirth: "1978-12-15", Country: "India", Email: "a@b.com"}
+	sameDetails := c
+	sameDetails.Name, sameDetails.Email = "STEVE", "c@d.com"
+	moved := c
+	moved.Country = "Norway"
+	newList := watchlist
+	newList.Digest = "def"
+
+	//Act
+	fingerprint := watchlist.Fingerprint(c)
+
+	//Assert
+	if fingerprint != watchlist.Fingerprint(sameDetails) {
+		t.Error("Expected same fingerprint when only the email and case of the name changed")
+	}
+	if fingerprint == watchlist.Fingerprint(moved) || fingerprint == newList.Fingerprint(c) {
+		t.Error("Expected different fingerprint when the country or the watchlist changed")
+	}
+}
+
+func TestLoadWatchlist(t *testing.T) {
+	tests := []struct {
+		name            string
+		file            string
+		content         string
+		thresholds      SanctionsThresholds
+		expectedEntries int
+		expectErr       bool
+	}{
+		{"sample CSV file", "watchlist.csv", "", DefaultSanctionsThresholds(), 4, false},
+		{"sample XML file", "watchlist.xml", "", DefaultSanctionsThresholds(), 4, false},
+		{"columns in any order", "list.csv", "country,name\nLatveria,Marguerite Dufresne\n", DefaultSanctionsThresholds(), 1, false},
+		{"no name column", "list.csv", "id,country\nWL-1,Latveria\n", DefaultSanctionsThresholds(), 0, true},
+		{"invalid date of birth", "list.csv", "name,date_of_birth\nViktor Ardenko,09/03/1971\n", DefaultSanctionsThresholds(), 0, true},
+		{"unknown extension", "list.json", "[]", DefaultSanctionsThresholds(), 0, true},
+		{"strong threshold below threshold", "watchlist.csv", "", SanctionsThresholds{Name: 0.9, StrongName: 0.8}, 0, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Arrange
+			path := filepath.Join("..", "build", "package", "sanctions", tc.file)
+			if tc.content != "" {
+				path = filepath.Join(t.TempDir(), tc.file)
+				if err := os.WriteFile(path, []byte(tc.content), 0644); err != nil {
+					t.Fatal("Error during testing setup: " + err.Error())
+				}
+			}
+
+			//Act
+			watchlist, err := LoadWatchlist(path, tc.thresholds)
+
+			//Assert
+			if tc.expectErr && err == nil {
+				t.Error("Expected error but got none")
+			}
+			if !tc.expectErr && (err != nil || len(watchlist.Entries) != tc.expectedEntries || watchlist.Digest == "") {
+				t.Errorf("Expected %d entries but got %+v and error %v", tc.expectedEntries, watchlist, err)
+			}
+		})
+	}
+}
+
+func TestSanctionsHit_Resolve_returns_conflictError_when_hit_resolved(t *testing.T) {
+	//Arrange
+	hit := NewCustomerSanctionsHit(Customer{Id: "2000"}, []SanctionsMatch{{ListId: "WL-1"}}, clock.StaticClock{})
+	request := dto.ResolveSanctionsHitRequest{Resolution: dto.SanctionsHitStatusCleared}
+	cleared, _ := hit.Resolve(request, clock.StaticClock{})
+
+	//Act
+	_, err := cleared.Resolve(request, clock.StaticClock{})
+
+	//Assert
+	if cleared.Status != dto.SanctionsHitStatusCleared || !cleared.ResolutionDate.Valid {
+		t.Errorf("Expected hit to be cleared but got %+v", cleared)
+	}
+	if err == nil || err.Code != http.StatusConflict {
+		t.Errorf("Expected conflict error but got %v", err)
+	}
+}
@@ -0,0 +1,204 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"strings"
+	"time"
+)
+
+//Business Domain
+
+const WebhookStatusActive = "active"
+const WebhookStatusDisabled = "disabled"
+
+const DeliveryStatusPending = "pending"
+const DeliveryStatusDelivered = "delivered"
+const DeliveryStatusDead = "dead" //given up on after WebhookMaxAttempts failed attempts
+
+// WebhookMaxAttempts is how many times a delivery is attempted before it is given up on, and WebhookRetryDelay is how
+// long to wait after the first failed attempt. The delay doubles after each further failed attempt.
+const WebhookMaxAttempts = 8
+const WebhookRetryDelay = time.Minute
+
+// Headers of webhook requests. The signature is the hex-encoded HMAC-SHA256 of the timestamp, a dot and the body,
+// keyed with the subscription's secret and prefixed with "sha256=", so that subscribers can check that the request
+// came from the bank and reject old requests being replayed to them.
+const WebhookHeaderDeliveryId = "X-Webhook-Delivery-Id"
+const WebhookHeaderEvent = "X-Webhook-Event"
+const WebhookHeaderTimestamp = "X-Webhook-Timestamp"
+const WebhookHeaderSignature = "X-Webhook-Signature"
+
+type WebhookSubscription struct { //business/domain object
+	SubscriptionId string `db:"subscription_id"`
+	CustomerId     string `db:"customer_id"`
+	Url            string `db:"url"`
+	EventTypes     string `db:"event_types"` //comma-separated, or empty for all event types
+	Secret         string `db:"secret"`
+	Status         string `db:"status"`
+	CreationDate   string `db:"creation_date"`
+}
+
+// NewWebhookSubscription creates an active subscription from the given request.
+func NewWebhookSubscription(request dto.NewWebhookRequest, c clock.Clock) WebhookSubscription {
+	return WebhookSubscription{
+		CustomerId:   request.CustomerId,
+		Url:          request.Url,
+		EventTypes:   strings.Join(request.EventTypes, ","),
+		Secret:       request.Secret,
+		Status:       WebhookStatusActive,
+		CreationDate: c.NowAsString(),
+	}
+}
+
+// Accepts checks whether events of the given type should be delivered to the subscription.
+func (s WebhookSubscription) Accepts(eventType string) bool {
+	if s.Status != WebhookStatusActive {
+		return false
+	}
+	if s.EventTypes == "" {
+		return true
+	}
+	for _, t := range strings.Split(s.EventTypes, ",") {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+func (s WebhookSubscription) ToDTO() *dto.WebhookResponse {
+	eventTypes := make([]string, 0)
+	if s.EventTypes != "" {
+		eventTypes = strings.Split(s.EventTypes, ",")
+	}
+	return &dto.WebhookResponse{
+		WebhookId:    s.SubscriptionId,
+		Url:          s.Url,
+		EventTypes:   eventTypes,
+		Status:       s.Status,
+		CreationDate: s.CreationDate,
+	}
+}
+
+// SignWebhook computes the signature of a webhook request with the given body, sent at the given timestamp to a
+// subscription with the given secret.
+func SignWebhook(secret string, timestamp string, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "." + body))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+type WebhookDelivery struct { //business/domain object
+	DeliveryId      string         `db:"delivery_id"`
+	SubscriptionId  string         `db:"subscription_id"`
+	EventId         string         `db:"event_id"`
+	EventType       string         `db:"event_type"`
+	Payload         string         `db:"payload"` //body of the request, the event as published
+	Status          string         `db:"status"`
+	Attempts        int            `db:"attempts"` //failed attempts so far
+	NextAttemptDate string         `db:"next_attempt_date"`
+	LastAttemptDate sql.NullString `db:"last_attempt_date"`
+	ResponseCode    sql.NullInt64  `db:"response_code"` //status code of the last response, if any
+	LastError       sql.NullString `db:"last_error"`
+	CreationDate    string         `db:"creation_date"`
+}
+
+// NewWebhookDelivery creates a pending delivery of the given event, which should already have its ID, to the given
+// subscription, to be attempted right away.
+func NewWebhookDelivery(subscription WebhookSubscription, event Event, c clock.Clock) (*WebhookDelivery, *errs.AppError) {
+	payload, err := event.ToMessageJson()
+	if err != nil {
+		return nil, err
+	}
+	now := c.NowAsString()
+	return &WebhookDelivery{
+		SubscriptionId:  subscription.SubscriptionId,
+		EventId:         event.EventId,
+		EventType:       event.EventType,
+		Payload:         payload,
+		Status:          DeliveryStatusPending,
+		NextAttemptDate: now,
+		CreationDate:    now,
+	}, nil
+}
+
+// RecordAttempt updates the delivery with the outcome of an attempt made at the current time, which succeeded if the
+// subscriber responded with a 2xx status code. After a failed attempt, the next one is scheduled with exponential
+// backoff, unless WebhookMaxAttempts have failed, in which case the delivery is given up on. The response code is 0
+// if the subscriber could not be reached.
+func (d *WebhookDelivery) RecordAttempt(responseCode int, errMessage string, c clock.Clock) {
+	now := c.Now()
+	d.LastAttemptDate = sql.NullString{String: now.Format(clock.FormatDateTime), Valid: true}
+	d.ResponseCode = sql.NullInt64{Int64: int64(responseCode), Valid: responseCode != 0}
+	d.LastError = sql.NullString{String: errMessage, Valid: errMessage != ""}
+
+	if responseCode >= 200 && responseCode < 300 {
+		d.Status = DeliveryStatusDelivered
+		return
+	}
+
+	d.Attempts++
+	if d.Attempts >= WebhookMaxAttempts {
+		d.Status = DeliveryStatusDead
+		return
+	}
+	d.NextAttemptDate = now.Add(WebhookRetryDelay << (d.Attempts - 1)).Format(clock.FormatDateTime)
+}
+
+// Replay schedules the delivery to be attempted again right away, with a fresh set of attempts. It returns a conflict
+// error if the delivery is still pending, since it will be attempted anyway.
+func (d *WebhookDelivery) Replay(c clock.Clock) *errs.AppError {
+	if d.Status == DeliveryStatusPending {
+		return errs.NewConflictError("Delivery is still pending")
+	}
+	d.Status = DeliveryStatusPending
+	d.Attempts = 0
+	d.NextAttemptDate = c.NowAsString()
+	return nil
+}
+
+func (d WebhookDelivery) ToDTO() *dto.WebhookDeliveryResponse {
+	response := dto.WebhookDeliveryResponse{
+		DeliveryId:      d.DeliveryId,
+		EventId:         d.EventId,
+		EventType:       d.EventType,
+		Status:          d.Status,
+		Attempts:        d.Attempts,
+		LastAttemptDate: d.LastAttemptDate.String,
+		ResponseCode:    int(d.ResponseCode.Int64),
+		LastError:       d.LastError.String,
+		CreationDate:    d.CreationDate,
+	}
+	if d.Status == DeliveryStatusPending {
+		response.NextAttemptDate = d.NextAttemptDate
+	}
+	return &response
+}
+
+//Server
+
+//go:generate mockgen -destination=../mocks/domain/mock_webhookRepository.go -package=domain github.com/aliciatay-zls/banking/backend/domain WebhookRepository
+type WebhookRepository interface { //repo (secondary port)
+	SaveSubscription(WebhookSubscription) (*WebhookSubscription, *errs.AppError)
+	FindSubscriptions(string) ([]WebhookSubscription, *errs.AppError)
+	FindSubscriptionById(string) (*WebhookSubscription, *errs.AppError)
+	UpdateSubscriptionStatus(string, string) *errs.AppError
+	SaveDeliveries([]WebhookDelivery) *errs.AppError
+	FindDeliveries(string) ([]WebhookDelivery, *errs.AppError)
+	FindDeliveryById(string) (*WebhookDelivery, *errs.AppError)
+	FindDueDeliveries(string, int) ([]WebhookDelivery, *errs.AppError)
+	UpdateDelivery(WebhookDelivery) *errs.AppError
+}
+
+//go:generate mockgen -destination=../mocks/domain/mock_webhookSender.go -package=domain github.com/aliciatay-zls/banking/backend/domain WebhookSender
+type WebhookSender interface { //repo (secondary port)
+	// Send posts the given body with the given headers to the given URL and returns the status code of the response.
+	// It returns an error if no response was received.
+	Send(string, string, map[string]string) (int, *errs.AppError)
+}
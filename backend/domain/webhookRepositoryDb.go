@@ -0,0 +1,191 @@
+package domain
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/jmoiron/sqlx"
+	"strconv"
+)
+
+//Server
+
+// maxDeliveryErrorLength is the length of the last_error column of webhook deliveries.
+const maxDeliveryErrorLength = 255
+
+type WebhookRepositoryDb struct { //DB (adapter)
+	client dbExecutor
+}
+
+func NewWebhookRepositoryDb(dbClient *sqlx.DB) WebhookRepositoryDb {
+	return WebhookRepositoryDb{dbClient}
+}
+
+// SaveSubscription creates a new entry in the database for the given subscription, sets its ID using the
+// database-generated ID and returns the subscription.
+func (d WebhookRepositoryDb) SaveSubscription(subscription WebhookSubscription) (*WebhookSubscription, *errs.AppError) { //DB implements repo
+	insertSql := "INSERT INTO webhook_subscriptions (customer_id, url, event_types, secret, status, creation_date) " +
+		"VALUES (?, ?, ?, ?, ?, ?)"
+	result, err := d.client.Exec(insertSql, subscription.CustomerId, subscription.Url, subscription.EventTypes,
+		subscription.Secret, subscription.Status, subscription.CreationDate)
+	if err != nil {
+		logger.Error("Error while creating new webhook subscription: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		logger.Error("Error while getting id of newly inserted webhook subscription: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	subscription.SubscriptionId = strconv.FormatInt(id, 10)
+
+	return &subscription, nil
+}
+
+// FindSubscriptions retrieves all webhook subscriptions of the customer with the given id.
+func (d WebhookRepositoryDb) FindSubscriptions(customerId string) ([]WebhookSubscription, *errs.AppError) {
+	return findWebhookSubscriptions(d.client, "SELECT * FROM webhook_subscriptions", customerId)
+}
+
+// FindSubscriptionById retrieves the webhook subscription with the given id.
+func (d WebhookRepositoryDb) FindSubscriptionById(id string) (*WebhookSubscription, *errs.AppError) {
+	return findWebhookSubscription(d.client, "SELECT * FROM webhook_subscriptions", id)
+}
+
+// UpdateSubscriptionStatus sets the status of the webhook subscription with the given id.
+func (d WebhookRepositoryDb) UpdateSubscriptionStatus(id string, status string) *errs.AppError {
+	return updateWebhookSubscriptionStatus(d.client, id, status)
+}
+
+// SaveDeliveries starts a database transaction, creates a new entry in the database for each of the given deliveries
+// and commits the database transaction. Deliveries of an event to a subscription that already has a delivery of the
+// same event are skipped, since the outbox relay may publish an event more than once.
+func (d WebhookRepositoryDb) SaveDeliveries(deliveries []WebhookDelivery) *errs.AppError {
+	return inTransaction(d.client, "saving webhook deliveries", func(tx dbExecutor) *errs.AppError {
+		for _, delivery := range deliveries {
+			var count int
+			countSql := "SELECT COUNT(*) FROM webhook_deliveries WHERE subscription_id = ? AND event_id = ?"
+			if err := tx.Get(&count, countSql, delivery.SubscriptionId, delivery.EventId); err != nil {
+				logger.Error("Error while checking for existing webhook delivery: " + err.Error())
+				return errs.NewUnexpectedError("Unexpected database error")
+			}
+			if count > 0 {
+				continue
+			}
+
+			insertSql := "INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload, status, attempts, " +
+				"next_attempt_date, creation_date) VALUES (?, ?, ?, ?, ?, ?, ?, ?)"
+			_, err := tx.Exec(insertSql, delivery.SubscriptionId, delivery.EventId, delivery.EventType, delivery.Payload,
+				delivery.Status, delivery.Attempts, delivery.NextAttemptDate, delivery.CreationDate)
+			if err != nil {
+				logger.Error("Error while creating new webhook delivery: " + err.Error())
+				return errs.NewUnexpectedError("Unexpected database error")
+			}
+		}
+		return nil
+	})
+}
+
+// FindDeliveries retrieves the deliveries to the webhook subscription with the given id, oldest first.
+func (d WebhookRepositoryDb) FindDeliveries(subscriptionId string) ([]WebhookDelivery, *errs.AppError) {
+	return findWebhookDeliveries(d.client, "SELECT * FROM webhook_deliveries", subscriptionId)
+}
+
+// FindDeliveryById retrieves the webhook delivery with the given id.
+func (d WebhookRepositoryDb) FindDeliveryById(id string) (*WebhookDelivery, *errs.AppError) {
+	return findWebhookDelivery(d.client, "SELECT * FROM webhook_deliveries", id)
+}
+
+// FindDueDeliveries retrieves up to the given number of pending deliveries whose next attempt is scheduled for the
+// given time or earlier, oldest first.
+func (d WebhookRepositoryDb) FindDueDeliveries(now string, limit int) ([]WebhookDelivery, *errs.AppError) {
+	return findDueWebhookDeliveries(d.client, "SELECT * FROM webhook_deliveries", now, limit)
+}
+
+// UpdateDelivery saves the status and the outcome of the last attempt of the given webhook delivery.
+func (d WebhookRepositoryDb) UpdateDelivery(delivery WebhookDelivery) *errs.AppError {
+	return updateWebhookDelivery(d.client, delivery)
+}
+
+// findWebhookSubscriptions and findWebhookSubscription retrieve webhook subscriptions with the given select statement.
+func findWebhookSubscriptions(client dbExecutor, selectSql string, customerId string) ([]WebhookSubscription, *errs.AppError) {
+	subscriptions := make([]WebhookSubscription, 0)
+	selectSql += " WHERE customer_id = ? ORDER BY subscription_id"
+	if err := client.Select(&subscriptions, client.Rebind(selectSql), customerId); err != nil {
+		logger.Error("Error while retrieving webhook subscriptions of customer: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return subscriptions, nil
+}
+
+func findWebhookSubscription(client dbExecutor, selectSql string, id string) (*WebhookSubscription, *errs.AppError) {
+	var subscription WebhookSubscription
+	if err := client.Get(&subscription, client.Rebind(selectSql+" WHERE subscription_id = ?"), id); err != nil {
+		logger.Error("Error while retrieving webhook subscription: " + err.Error())
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.NewNotFoundError("Webhook not found")
+		}
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return &subscription, nil
+}
+
+func updateWebhookSubscriptionStatus(client dbExecutor, id string, status string) *errs.AppError {
+	updateSql := "UPDATE webhook_subscriptions SET status = ? WHERE subscription_id = ?"
+	if _, err := client.Exec(client.Rebind(updateSql), status, id); err != nil {
+		logger.Error("Error while updating status of webhook subscription: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	return nil
+}
+
+// findWebhookDeliveries, findWebhookDelivery and findDueWebhookDeliveries retrieve webhook deliveries with the given
+// select statement.
+func findWebhookDeliveries(client dbExecutor, selectSql string, subscriptionId string) ([]WebhookDelivery, *errs.AppError) {
+	deliveries := make([]WebhookDelivery, 0)
+	selectSql += " WHERE subscription_id = ? ORDER BY delivery_id"
+	if err := client.Select(&deliveries, client.Rebind(selectSql), subscriptionId); err != nil {
+		logger.Error("Error while retrieving webhook deliveries: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return deliveries, nil
+}
+
+func findWebhookDelivery(client dbExecutor, selectSql string, id string) (*WebhookDelivery, *errs.AppError) {
+	var delivery WebhookDelivery
+	if err := client.Get(&delivery, client.Rebind(selectSql+" WHERE delivery_id = ?"), id); err != nil {
+		logger.Error("Error while retrieving webhook delivery: " + err.Error())
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.NewNotFoundError("Delivery not found")
+		}
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return &delivery, nil
+}
+
+func findDueWebhookDeliveries(client dbExecutor, selectSql string, now string, limit int) ([]WebhookDelivery, *errs.AppError) {
+	deliveries := make([]WebhookDelivery, 0)
+	selectSql += " WHERE status = ? AND next_attempt_date <= ? ORDER BY delivery_id LIMIT ?"
+	if err := client.Select(&deliveries, client.Rebind(selectSql), DeliveryStatusPending, now, limit); err != nil {
+		logger.Error("Error while retrieving due webhook deliveries: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return deliveries, nil
+}
+
+func updateWebhookDelivery(client dbExecutor, delivery WebhookDelivery) *errs.AppError {
+	if len(delivery.LastError.String) > maxDeliveryErrorLength {
+		delivery.LastError.String = delivery.LastError.String[:maxDeliveryErrorLength]
+	}
+	updateSql := "UPDATE webhook_deliveries SET status = ?, attempts = ?, next_attempt_date = ?, last_attempt_date = ?, " +
+		"response_code = ?, last_error = ? WHERE delivery_id = ?"
+	_, err := client.Exec(client.Rebind(updateSql), delivery.Status, delivery.Attempts, delivery.NextAttemptDate,
+		delivery.LastAttemptDate, delivery.ResponseCode, delivery.LastError, delivery.DeliveryId)
+	if err != nil {
+		logger.Error("Error while updating webhook delivery: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	return nil
+}
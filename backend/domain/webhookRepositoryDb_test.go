@@ -0,0 +1,196 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// These tests run on a real SQLite database seeded with the demo data, since duplicate deliveries and the retry
+// schedule are only handled when the SQL is executed, which go-sqlmock never does.
+
+var webhookRepoDb WebhookRepositoryDb
+
+// setupWebhookRepoDbTest opens a new SQLite database and saves a subscription of customer 2001 to posted transactions
+// in it.
+func setupWebhookRepoDbTest(t *testing.T) *WebhookSubscription {
+	logger.MuteLogger()
+	webhookRepoDb = NewWebhookRepositoryDb(openSQLiteDb(t))
+
+	request := dto.NewWebhookRequest{CustomerId: "2001", Url: "https://example.com/hooks",
+		EventTypes: []string{EventTransactionPosted}, Secret: "0123456789abcdef"}
+	subscription, err := webhookRepoDb.SaveSubscription(NewWebhookSubscription(request, clock.StaticClock{}))
+	if err != nil {
+		t.Fatal("Expected no error but got error while saving webhook subscription: " + err.Message)
+	}
+	return subscription
+}
+
+// getDummyWebhookDelivery returns a delivery of a posted transaction to the given subscription.
+func getDummyWebhookDelivery(t *testing.T, subscription WebhookSubscription) WebhookDelivery {
+	event := NewTransactionPostedEvent(Transaction{TransactionId: "1", AccountId: "95472", TransactionDate: dummyDate})
+	event.EventId = "1"
+	delivery, err := NewWebhookDelivery(subscription, event, clock.StaticClock{})
+	if err != nil {
+		t.Fatal("Expected no error but got error while creating webhook delivery: " + err.Message)
+	}
+	return *delivery
+}
+
+func TestWebhookRepositoryDb_FindSubscriptionById_returns_savedSubscription(t *testing.T) {
+	//Arrange
+	subscription := setupWebhookRepoDbTest(t)
+
+	//Act
+	found, err := webhookRepoDb.FindSubscriptionById(subscription.SubscriptionId)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if *found != *subscription {
+		t.Errorf("Expected %+v but got %+v", *subscription, *found)
+	}
+}
+
+func TestWebhookRepositoryDb_FindSubscriptionById_returns_notFoundError_when_subscription_nonexistent(t *testing.T) {
+	//Arrange
+	setupWebhookRepoDbTest(t)
+
+	//Act
+	_, err := webhookRepoDb.FindSubscriptionById("1000")
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none")
+	}
+	if err.Code != http.StatusNotFound {
+		t.Errorf("Expected status code %d but got %d", http.StatusNotFound, err.Code)
+	}
+}
+
+func TestWebhookRepositoryDb_FindSubscriptions_returns_subscriptions_of_customer(t *testing.T) {
+	//Arrange
+	setupWebhookRepoDbTest(t)
+
+	//Act
+	subscriptions, err := webhookRepoDb.FindSubscriptions("2001")
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if len(subscriptions) != 1 {
+		t.Errorf("Expected 1 subscription but got %d", len(subscriptions))
+	}
+}
+
+func TestWebhookRepositoryDb_SaveDeliveries_skips_events_alreadyDelivered(t *testing.T) {
+	//Arrange
+	subscription := setupWebhookRepoDbTest(t)
+	delivery := getDummyWebhookDelivery(t, *subscription)
+	if err := webhookRepoDb.SaveDeliveries([]WebhookDelivery{delivery}); err != nil {
+		t.Fatal("Expected no error but got error while saving deliveries: " + err.Message)
+	}
+
+	//Act
+	err := webhookRepoDb.SaveDeliveries([]WebhookDelivery{delivery})
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	deliveries, err := webhookRepoDb.FindDeliveries(subscription.SubscriptionId)
+	if err != nil {
+		t.Fatal("Expected no error but got error while finding deliveries: " + err.Message)
+	}
+	if len(deliveries) != 1 || deliveries[0].Payload != delivery.Payload {
+		t.Errorf("Expected 1 delivery of the event but got %+v", deliveries)
+	}
+}
+
+func TestWebhookRepositoryDb_FindDueDeliveries_returns_newDelivery(t *testing.T) {
+	//Arrange
+	subscription := setupWebhookRepoDbTest(t)
+	if err := webhookRepoDb.SaveDeliveries([]WebhookDelivery{getDummyWebhookDelivery(t, *subscription)}); err != nil {
+		t.Fatal("Expected no error but got error while saving deliveries: " + err.Message)
+	}
+
+	//Act
+	due, err := webhookRepoDb.FindDueDeliveries(clock.StaticClock{}.NowAsString(), 10)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if len(due) != 1 {
+		t.Errorf("Expected 1 due delivery but got %d", len(due))
+	}
+}
+
+func TestWebhookRepositoryDb_UpdateDelivery_schedules_retry_of_failedDelivery(t *testing.T) {
+	//Arrange
+	subscription := setupWebhookRepoDbTest(t)
+	clk := clock.StaticClock{}
+	if err := webhookRepoDb.SaveDeliveries([]WebhookDelivery{getDummyWebhookDelivery(t, *subscription)}); err != nil {
+		t.Fatal("Expected no error but got error while saving deliveries: " + err.Message)
+	}
+	due, err := webhookRepoDb.FindDueDeliveries(clk.NowAsString(), 10)
+	if err != nil {
+		t.Fatal("Expected no error but got error while finding due deliveries: " + err.Message)
+	}
+	failed := due[0]
+	failed.RecordAttempt(0, strings.Repeat("x", 300), clk)
+
+	//Act
+	err = webhookRepoDb.UpdateDelivery(failed)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	dueNow, err := webhookRepoDb.FindDueDeliveries(clk.NowAsString(), 10)
+	if err != nil {
+		t.Fatal("Expected no error but got error while finding due deliveries: " + err.Message)
+	}
+	if len(dueNow) != 0 {
+		t.Errorf("Expected no due deliveries before retry but got %d", len(dueNow))
+	}
+	dueLater, err := webhookRepoDb.FindDueDeliveries(clk.Now().Add(WebhookRetryDelay).Format(clock.FormatDateTime), 10)
+	if err != nil {
+		t.Fatal("Expected no error but got error while finding due deliveries: " + err.Message)
+	}
+	if len(dueLater) != 1 || dueLater[0].Attempts != 1 || len(dueLater[0].LastError.String) != maxDeliveryErrorLength {
+		t.Errorf("Expected delivery with 1 attempt and truncated error but got %+v", dueLater)
+	}
+	found, err := webhookRepoDb.FindDeliveryById(failed.DeliveryId)
+	if err != nil {
+		t.Fatal("Expected no error but got error while finding delivery: " + err.Message)
+	}
+	if found.LastAttemptDate.String != clk.NowAsString() || found.ResponseCode.Valid {
+		t.Errorf("Expected last attempt at %s and no response code but got %+v", clk.NowAsString(), *found)
+	}
+}
+
+func TestWebhookRepositoryDb_UpdateSubscriptionStatus_disables_subscription(t *testing.T) {
+	//Arrange
+	subscription := setupWebhookRepoDbTest(t)
+
+	//Act
+	err := webhookRepoDb.UpdateSubscriptionStatus(subscription.SubscriptionId, WebhookStatusDisabled)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	found, err := webhookRepoDb.FindSubscriptionById(subscription.SubscriptionId)
+	if err != nil {
+		t.Fatal("Expected no error but got error while finding subscription: " + err.Message)
+	}
+	if found.Status != WebhookStatusDisabled {
+		t.Errorf("Expected status %s but got %s", WebhookStatusDisabled, found.Status)
+	}
+}
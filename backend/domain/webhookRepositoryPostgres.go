@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/jmoiron/sqlx"
+)
+
+//Server
+
+// selectWebhookSubscriptionsPostgresSql selects the columns of webhook subscriptions, formatting dates the same way
+// MySQL returns them.
+const selectWebhookSubscriptionsPostgresSql = "SELECT subscription_id, customer_id, url, event_types, secret, status, " +
+	"to_char(creation_date, 'YYYY-MM-DD HH24:MI:SS') AS creation_date FROM webhook_subscriptions"
+
+// selectWebhookDeliveriesPostgresSql selects the columns of webhook deliveries, formatting dates the same way MySQL
+// returns them.
+const selectWebhookDeliveriesPostgresSql = "SELECT delivery_id, subscription_id, event_id, event_type, payload, " +
+	"status, attempts, to_char(next_attempt_date, 'YYYY-MM-DD HH24:MI:SS') AS next_attempt_date, " +
+	"to_char(last_attempt_date, 'YYYY-MM-DD HH24:MI:SS') AS last_attempt_date, response_code, last_error, " +
+	"to_char(creation_date, 'YYYY-MM-DD HH24:MI:SS') AS creation_date FROM webhook_deliveries"
+
+type WebhookRepositoryPostgres struct { //DB (adapter)
+	client dbExecutor
+}
+
+func NewWebhookRepositoryPostgres(dbClient *sqlx.DB) WebhookRepositoryPostgres {
+	return WebhookRepositoryPostgres{dbClient}
+}
+
+// SaveSubscription creates a new entry in the database for the given subscription, sets its ID using the ID returned
+// by the database and returns the subscription.
+func (d WebhookRepositoryPostgres) SaveSubscription(subscription WebhookSubscription) (*WebhookSubscription, *errs.AppError) { //DB implements repo
+	insertSql := "INSERT INTO webhook_subscriptions (customer_id, url, event_types, secret, status, creation_date) " +
+		"VALUES ($1, $2, $3, $4, $5, $6) RETURNING subscription_id"
+	err := d.client.Get(&subscription.SubscriptionId, insertSql, subscription.CustomerId, subscription.Url,
+		subscription.EventTypes, subscription.Secret, subscription.Status, subscription.CreationDate)
+	if err != nil {
+		logger.Error("Error while creating new webhook subscription: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return &subscription, nil
+}
+
+// FindSubscriptions retrieves all webhook subscriptions of the customer with the given id.
+func (d WebhookRepositoryPostgres) FindSubscriptions(customerId string) ([]WebhookSubscription, *errs.AppError) {
+	return findWebhookSubscriptions(d.client, selectWebhookSubscriptionsPostgresSql, customerId)
+}
+
+// FindSubscriptionById retrieves the webhook subscription with the given id.
+func (d WebhookRepositoryPostgres) FindSubscriptionById(id string) (*WebhookSubscription, *errs.AppError) {
+	return findWebhookSubscription(d.client, selectWebhookSubscriptionsPostgresSql, id)
+}
+
+// UpdateSubscriptionStatus sets the status of the webhook subscription with the given id.
+func (d WebhookRepositoryPostgres) UpdateSubscriptionStatus(id string, status string) *errs.AppError {
+	return updateWebhookSubscriptionStatus(d.client, id, status)
+}
+
+// SaveDeliveries starts a database transaction, creates a new entry in the database for each of the given deliveries
+// and commits the database transaction. Like WebhookRepositoryDb.SaveDeliveries, it skips deliveries of an event to a
+// subscription that already has a delivery of the same event, which the INSERT itself does here.
+func (d WebhookRepositoryPostgres) SaveDeliveries(deliveries []WebhookDelivery) *errs.AppError {
+	return inTransaction(d.client, "saving webhook deliveries", func(tx dbExecutor) *errs.AppError {
+		for _, delivery := range deliveries {
+			insertSql := "INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload, status, attempts, " +
+				"next_attempt_date, creation_date) VALUES ($1, $2, $3, $4, $5, $6, $7, $8) ON CONFLICT DO NOTHING"
+			_, err := tx.Exec(insertSql, delivery.SubscriptionId, delivery.EventId, delivery.EventType, delivery.Payload,
+				delivery.Status, delivery.Attempts, delivery.NextAttemptDate, delivery.CreationDate)
+			if err != nil {
+				logger.Error("Error while creating new webhook delivery: " + err.Error())
+				return errs.NewUnexpectedError("Unexpected database error")
+			}
+		}
+		return nil
+	})
+}
+
+// FindDeliveries retrieves the deliveries to the webhook subscription with the given id, oldest first.
+func (d WebhookRepositoryPostgres) FindDeliveries(subscriptionId string) ([]WebhookDelivery, *errs.AppError) {
+	return findWebhookDeliveries(d.client, selectWebhookDeliveriesPostgresSql, subscriptionId)
+}
+
+// FindDeliveryById retrieves the webhook delivery with the given id.
+func (d WebhookRepositoryPostgres) FindDeliveryById(id string) (*WebhookDelivery, *errs.AppError) {
+	return findWebhookDelivery(d.client, selectWebhookDeliveriesPostgresSql, id)
+}
+
+// FindDueDeliveries retrieves up to the given number of pending deliveries whose next attempt is scheduled for the
+// given time or earlier, oldest first.
+func (d WebhookRepositoryPostgres) FindDueDeliveries(now string, limit int) ([]WebhookDelivery, *errs.AppError) {
+	return findDueWebhookDeliveries(d.client, selectWebhookDeliveriesPostgresSql, now, limit)
+}
+
+// UpdateDelivery saves the status and the outcome of the last attempt of the given webhook delivery.
+func (d WebhookRepositoryPostgres) UpdateDelivery(delivery WebhookDelivery) *errs.AppError {
+	return updateWebhookDelivery(d.client, delivery)
+}
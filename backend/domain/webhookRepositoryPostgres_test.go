@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/jmoiron/sqlx"
+	"testing"
+)
+
+// Test common variables and inputs
+var webhookRepoPostgres WebhookRepositoryPostgres
+
+func setupWebhookRepoPostgresTest(t *testing.T) func() {
+	teardown := setupDB(t)
+	webhookRepoPostgres = NewWebhookRepositoryPostgres(sqlx.NewDb(db, "postgres"))
+	return teardown
+}
+
+func getDummyWebhookSubscription() WebhookSubscription {
+	request := dto.NewWebhookRequest{CustomerId: dummyCustomerId, Url: "https://example.com/hooks",
+		EventTypes: []string{EventTransactionPosted}, Secret: "0123456789abcdef"}
+	return NewWebhookSubscription(request, clock.StaticClock{})
+}
+
+func TestWebhookRepositoryPostgres_SaveSubscription_returns_newSubscription_with_returnedId(t *testing.T) {
+	//Arrange
+	teardown := setupWebhookRepoPostgresTest(t)
+	defer teardown()
+
+	subscription := getDummyWebhookSubscription()
+	mockDB.ExpectQuery("INSERT INTO webhook_subscriptions (customer_id, url, event_types, secret, status, "+
+		"creation_date) VALUES ($1, $2, $3, $4, $5, $6) RETURNING subscription_id").
+		WithArgs(subscription.CustomerId, subscription.Url, subscription.EventTypes, subscription.Secret,
+			subscription.Status, subscription.CreationDate).
+		WillReturnRows(sqlmock.NewRows([]string{"subscription_id"}).AddRow(2))
+
+	//Act
+	saved, err := webhookRepoPostgres.SaveSubscription(subscription)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if saved.SubscriptionId != "2" {
+		t.Errorf("Expected subscription ID 2 but got %s", saved.SubscriptionId)
+	}
+}
+
+func TestWebhookRepositoryPostgres_SaveDeliveries_skips_duplicateDeliveries_inInsert(t *testing.T) {
+	//Arrange
+	teardown := setupWebhookRepoPostgresTest(t)
+	defer teardown()
+
+	subscription := getDummyWebhookSubscription()
+	subscription.SubscriptionId = "2"
+	delivery := getDummyWebhookDelivery(t, subscription)
+	mockDB.ExpectBegin()
+	mockDB.ExpectExec("INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload, status, "+
+		"attempts, next_attempt_date, creation_date) VALUES ($1, $2, $3, $4, $5, $6, $7, $8) ON CONFLICT DO NOTHING").
+		WithArgs(delivery.SubscriptionId, delivery.EventId, delivery.EventType, delivery.Payload, delivery.Status,
+			delivery.Attempts, delivery.NextAttemptDate, delivery.CreationDate).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mockDB.ExpectCommit()
+
+	//Act
+	err := webhookRepoPostgres.SaveDeliveries([]WebhookDelivery{delivery})
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if err := mockDB.ExpectationsWereMet(); err != nil {
+		t.Error(err.Error())
+	}
+}
@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"fmt"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+)
+
+//Server
+
+// webhookTimeout is how long a subscriber has to respond to a webhook request before the attempt counts as failed.
+const webhookTimeout = 10 * time.Second
+
+// WebhookSenderHttp sends webhook requests to subscribers over HTTP.
+type WebhookSenderHttp struct { //HTTP (adapter)
+	client *http.Client
+}
+
+// NewWebhookSenderHttp creates a sender that refuses to connect to internal addresses (see dto.IsInternalIP). The
+// address is checked after the subscriber's host name is resolved, so that a host name registered for a webhook
+// cannot be pointed at the auth server or at cloud metadata addresses later on. Proxies are not used, since the
+// address of the proxy is all that could be checked then.
+func NewWebhookSenderHttp() WebhookSenderHttp {
+	dialer := &net.Dialer{Timeout: webhookTimeout, Control: refuseInternalAddress}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return WebhookSenderHttp{&http.Client{Timeout: webhookTimeout, Transport: transport}}
+}
+
+// refuseInternalAddress stops the connection to the given resolved address if it is an internal one.
+func refuseInternalAddress(_ string, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || dto.IsInternalIP(ip) {
+		return fmt.Errorf("refusing to connect to internal address %s", host)
+	}
+	return nil
+}
+
+// Send posts the given JSON body with the given headers to the given URL and returns the status code of the response.
+// Redirects are not followed, so that deliveries only go to the URL the subscriber registered.
+func (s WebhookSenderHttp) Send(url string, body string, headers map[string]string) (int, *errs.AppError) { //HTTP implements repo
+	request, err := http.NewRequest(http.MethodPost, url, strings.NewReader(body))
+	if err != nil {
+		logger.Error("Error while creating webhook request: " + err.Error())
+		return 0, errs.NewUnexpectedError("Invalid webhook URL")
+	}
+	request.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		request.Header.Set(key, value)
+	}
+
+	client := *s.client
+	client.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+	response, err := client.Do(request)
+	if err != nil {
+		logger.Error("Error while sending webhook request: " + err.Error())
+		return 0, errs.NewUnexpectedError("Could not reach webhook URL")
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, 64*1024)) //lets the connection be reused
+
+	return response.StatusCode, nil
+}
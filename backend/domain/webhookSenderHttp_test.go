@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newTestWebhookSender creates a sender that, unlike NewWebhookSenderHttp, may connect to the local test servers.
+func newTestWebhookSender() WebhookSenderHttp {
+	return WebhookSenderHttp{&http.Client{Timeout: webhookTimeout}}
+}
+
+func TestWebhookSenderHttp_Send_posts_body_with_headers(t *testing.T) {
+	//Arrange
+	var receivedBody, receivedSignature, receivedContentType string
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		receivedBody = string(body)
+		receivedSignature = r.Header.Get(WebhookHeaderSignature)
+		receivedContentType = r.Header.Get("Content-Type")
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer receiver.Close()
+
+	//Act
+	code, err := newTestWebhookSender().Send(receiver.URL, `{"event_id":"1"}`, map[string]string{WebhookHeaderSignature: "sha256=abc"})
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if code != http.StatusAccepted {
+		t.Errorf("Expected status code %d but got %d", http.StatusAccepted, code)
+	}
+	if receivedBody != `{"event_id":"1"}` || receivedSignature != "sha256=abc" || receivedContentType != "application/json" {
+		t.Errorf("Expected body, signature and content type to be sent but got %s, %s and %s",
+			receivedBody, receivedSignature, receivedContentType)
+	}
+}
+
+func TestWebhookSenderHttp_Send_does_not_follow_redirects(t *testing.T) {
+	//Arrange
+	redirected := false
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { redirected = true }))
+	defer target.Close()
+	receiver := httptest.NewServer(http.RedirectHandler(target.URL, http.StatusTemporaryRedirect))
+	defer receiver.Close()
+
+	//Act
+	code, err := newTestWebhookSender().Send(receiver.URL, "{}", nil)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if code != http.StatusTemporaryRedirect || redirected {
+		t.Errorf("Expected redirect response without following it but got %d (followed: %v)", code, redirected)
+	}
+}
+
+func TestWebhookSenderHttp_Send_returns_error_when_receiver_unreachable(t *testing.T) {
+	//Arrange
+	logger.MuteLogger()
+	receiver := httptest.NewServer(http.NotFoundHandler())
+	receiver.Close()
+
+	//Act
+	_, err := newTestWebhookSender().Send(receiver.URL, "{}", nil)
+
+	//Assert
+	if err == nil || err.Code != http.StatusInternalServerError {
+		t.Errorf("Expected unexpected error but got %v", err)
+	}
+}
+
+func TestWebhookSenderHttp_Send_refuses_internal_address(t *testing.T) {
+	//Arrange
+	logger.MuteLogger()
+	received := false
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { received = true }))
+	defer receiver.Close()
+
+	//Act
+	_, err := NewWebhookSenderHttp().Send(receiver.URL, "{}", nil)
+
+	//Assert
+	if err == nil || err.Code != http.StatusInternalServerError {
+		t.Errorf("Expected unexpected error but got %v", err)
+	}
+	if received {
+		t.Error("Expected no request to reach the loopback address")
+	}
+}
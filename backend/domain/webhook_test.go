@@ -0,0 +1,137 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestWebhookSubscription_Accepts(t *testing.T) {
+	tests := []struct {
+		name         string
+		subscription WebhookSubscription
+		expected     bool
+	}{
+		{"listed event type", WebhookSubscription{EventTypes: "AccountOpened,TransactionPosted", Status: WebhookStatusActive}, true},
+		{"unlisted event type", WebhookSubscription{EventTypes: "AccountOpened", Status: WebhookStatusActive}, false},
+		{"all event types", WebhookSubscription{Status: WebhookStatusActive}, true},
+		{"disabled subscription", WebhookSubscription{Status: WebhookStatusDisabled}, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := tc.subscription.Accepts(EventTransactionPosted); actual != tc.expected {
+				t.Errorf("Expected %v but got %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestSignWebhook_returns_hmacSha256_of_timestamp_and_body(t *testing.T) {
+	//Arrange
+	expected := "sha256=136c597fc75ec1c32c5fd435b9b25e839301b0a4f004b4329f4ff6f67a890898"
+
+	//Act
+	actual := SignWebhook("0123456789abcdef", "1136214245", `{"event_id":"1"}`)
+
+	//Assert
+	if actual != expected {
+		t.Errorf("Expected %s but got %s", expected, actual)
+	}
+}
+
+func TestWebhookDelivery_RecordAttempt_marks_delivered_when_response_2xx(t *testing.T) {
+	//Arrange
+	delivery := WebhookDelivery{Status: DeliveryStatusPending, Attempts: 2}
+
+	//Act
+	delivery.RecordAttempt(http.StatusNoContent, "", clock.StaticClock{})
+
+	//Assert
+	if delivery.Status != DeliveryStatusDelivered || delivery.ResponseCode.Int64 != http.StatusNoContent {
+		t.Errorf("Expected delivered with response code 204 but got %+v", delivery)
+	}
+	if delivery.Attempts != 2 || delivery.LastError.Valid {
+		t.Errorf("Expected attempts unchanged and no error but got %+v", delivery)
+	}
+}
+
+func TestWebhookDelivery_RecordAttempt_backsOff_exponentially_then_gives_up(t *testing.T) {
+	//Arrange
+	clk := clock.StaticClock{}
+	delivery := WebhookDelivery{Status: DeliveryStatusPending}
+
+	for k := 1; k < WebhookMaxAttempts; k++ {
+		//Act
+		delivery.RecordAttempt(http.StatusInternalServerError, "Webhook responded with status 500", clk)
+
+		//Assert
+		expectedNext := clk.Now().Add(WebhookRetryDelay * time.Duration(1<<(k-1))).Format(clock.FormatDateTime)
+		if delivery.Status != DeliveryStatusPending || delivery.Attempts != k || delivery.NextAttemptDate != expectedNext {
+			t.Fatalf("Expected pending with %d attempts and next attempt at %s but got %+v", k, expectedNext, delivery)
+		}
+	}
+
+	delivery.RecordAttempt(0, "Could not reach webhook URL", clk)
+	if delivery.Status != DeliveryStatusDead || delivery.ResponseCode.Valid {
+		t.Errorf("Expected dead with no response code after %d attempts but got %+v", WebhookMaxAttempts, delivery)
+	}
+}
+
+func TestWebhookDelivery_Replay(t *testing.T) {
+	tests := []struct {
+		name            string
+		status          string
+		expectedErrCode int
+	}{
+		{"dead delivery", DeliveryStatusDead, 0},
+		{"delivered delivery", DeliveryStatusDelivered, 0},
+		{"pending delivery", DeliveryStatusPending, http.StatusConflict},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Arrange
+			delivery := WebhookDelivery{Status: tc.status, Attempts: WebhookMaxAttempts, NextAttemptDate: "2000-01-01 00:00:00"}
+
+			//Act
+			err := delivery.Replay(clock.StaticClock{})
+
+			//Assert
+			if tc.expectedErrCode != 0 {
+				if err == nil || err.Code != tc.expectedErrCode {
+					t.Errorf("Expected error code %d but got %v", tc.expectedErrCode, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal("Expected no error but got error: " + err.Message)
+			}
+			if delivery.Status != DeliveryStatusPending || delivery.Attempts != 0 || delivery.NextAttemptDate != dummyDate {
+				t.Errorf("Expected pending delivery due now with no attempts but got %+v", delivery)
+			}
+		})
+	}
+}
+
+func TestEventPublishers_Publish_stops_at_first_failure(t *testing.T) {
+	//Arrange
+	dummyAppErr := errs.NewUnexpectedError("Unexpected server error")
+	first := NewEventPublisherStub(nil)
+	failing := NewEventPublisherStub(func(Event) bool { return true })
+	last := NewEventPublisherStub(nil)
+	publishers := EventPublishers{first, failing, last}
+
+	//Act
+	err := publishers.Publish(Event{EventId: "1"})
+
+	//Assert
+	if err == nil || err.Code != dummyAppErr.Code {
+		t.Fatalf("Expected error but got %v", err)
+	}
+	if len(first.Messages()) != 1 || len(last.Messages()) != 0 {
+		t.Errorf("Expected event to reach only the first publisher but got %d and %d", len(first.Messages()), len(last.Messages()))
+	}
+}
@@ -0,0 +1,40 @@
+package dto
+
+import (
+	"fmt"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/formValidator"
+	"github.com/aliciatay-zls/banking-lib/logger"
+)
+
+const AccountHolderRolePrimary = "primary"    //customer the account was opened for, who manages its holders
+const AccountHolderRoleJoint = "joint"        //may do everything with the account except manage its holders
+const AccountHolderRoleViewOnly = "view_only" //may only look at the account
+
+// AccountHolderRequest adds a customer as a holder of an account. It is made by the account's primary holder, given
+// by CustomerId, while HolderCustomerId is the customer to add.
+type AccountHolderRequest struct {
+	AccountId        string `json:"-" validate:"required,max=11,number"`
+	CustomerId       string `json:"-" validate:"required,max=11,number"`
+	HolderCustomerId string `json:"customer_id" validate:"required,max=11,number"`
+	Role             string `json:"role" validate:"required,oneof=joint view_only"`
+}
+
+func (r AccountHolderRequest) Validate() *errs.AppError {
+	errMsg := map[string]string{
+		"AccountId":        "Account ID must be present and a number.",
+		"CustomerId":       "Customer ID must be present and a number.",
+		"HolderCustomerId": "Customer ID of the holder must be present and a number.",
+		"Role":             fmt.Sprintf("Role should be %s or %s.", AccountHolderRoleJoint, AccountHolderRoleViewOnly),
+	}
+	if errsArr := formValidator.Struct(r); errsArr != nil {
+		logger.Error(fmt.Sprintf("Account holder request is invalid (%s) (%s)",
+			errsArr[0].Error(), errsArr[0].ActualTag()))
+		return errs.NewValidationError(errMsg[errsArr[0].Field()])
+	}
+	if r.HolderCustomerId == r.CustomerId {
+		return errs.NewValidationError("You already hold this account.")
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package dto
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestAccountHolderRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		request   AccountHolderRequest
+		expectErr bool
+	}{
+		{"joint holder", AccountHolderRequest{AccountId: "1977", CustomerId: dummyCustomerId, HolderCustomerId: "3",
+			Role: AccountHolderRoleJoint}, false},
+		{"view-only holder", AccountHolderRequest{AccountId: "1977", CustomerId: dummyCustomerId, HolderCustomerId: "3",
+			Role: AccountHolderRoleViewOnly}, false},
+		{"second primary holder", AccountHolderRequest{AccountId: "1977", CustomerId: dummyCustomerId, HolderCustomerId: "3",
+			Role: AccountHolderRolePrimary}, true},
+		{"missing holder", AccountHolderRequest{AccountId: "1977", CustomerId: dummyCustomerId,
+			Role: AccountHolderRoleJoint}, true},
+		{"requester as holder", AccountHolderRequest{AccountId: "1977", CustomerId: dummyCustomerId,
+			HolderCustomerId: dummyCustomerId, Role: AccountHolderRoleJoint}, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Act
+			err := tc.request.Validate()
+
+			//Assert
+			if !tc.expectErr && err != nil {
+				t.Error("Expected no error but got error: " + err.Message)
+			}
+			if tc.expectErr && (err == nil || err.Code != http.StatusUnprocessableEntity) {
+				t.Errorf("Expected validation error but got %v", err)
+			}
+		})
+	}
+}
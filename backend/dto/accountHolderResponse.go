@@ -0,0 +1,8 @@
+package dto
+
+type AccountHolderResponse struct {
+	AccountId    string `json:"account_id"`
+	CustomerId   string `json:"customer_id"`
+	Role         string `json:"role"`
+	CreationDate string `json:"creation_date"`
+}
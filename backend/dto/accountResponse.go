@@ -1,8 +1,14 @@
 package dto
 
 type AccountResponse struct {
-	AccountId   string  `json:"account_id"`
-	OpeningDate string  `json:"opening_date"`
-	AccountType string  `json:"account_type"`
-	Amount      float64 `json:"amount"`
+	AccountId        string  `json:"account_id"`
+	OpeningDate      string  `json:"opening_date"`
+	AccountType      string  `json:"account_type"`
+	Currency         string  `json:"currency"`
+	Amount           float64 `json:"amount"`
+	LedgerBalance    float64 `json:"ledger_balance"`
+	AvailableBalance float64 `json:"available_balance"`
+	OverdraftLimit   float64 `json:"overdraft_limit"`
+	OverdraftUsed    float64 `json:"overdraft_used"`
+	Role             string  `json:"role,omitempty"` //of the customer the account was listed for
 }
@@ -0,0 +1,55 @@
+package dto
+
+import (
+	"fmt"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/formValidator"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"strconv"
+)
+
+const AuditLogDefaultLimit = 100
+const AuditLogMaxLimit = 500
+
+// AuditLogRequest holds the query parameters of a request for the audit log. Dates are inclusive.
+type AuditLogRequest struct {
+	CustomerId string `validate:"omitempty,max=11,number"`
+	RouteName  string `validate:"omitempty,max=50,alphanum"`
+	From       string `validate:"omitempty,datetime=2006-01-02"`
+	To         string `validate:"omitempty,datetime=2006-01-02"`
+	AfterId    string `validate:"omitempty,max=11,number"`
+	Limit      string `validate:"omitempty,max=3,number"`
+}
+
+func (r AuditLogRequest) Validate() *errs.AppError {
+	errMsg := map[string]string{
+		"CustomerId": "Customer ID must be a number.",
+		"RouteName":  "Route name must be a name such as NewTransaction.",
+		"From":       "From must be a date in the format YYYY-MM-DD.",
+		"To":         "To must be a date in the format YYYY-MM-DD.",
+		"AfterId":    "After ID must be a number.",
+		"Limit":      fmt.Sprintf("Limit must be a number between 1 and %d.", AuditLogMaxLimit),
+	}
+	if errsArr := formValidator.Struct(r); errsArr != nil {
+		logger.Error(fmt.Sprintf("Audit log request is invalid (%s) (%s)",
+			errsArr[0].Error(), errsArr[0].ActualTag()))
+		return errs.NewValidationError(errMsg[errsArr[0].Field()])
+	}
+	if limit := r.LimitOrDefault(); limit < 1 || limit > AuditLogMaxLimit {
+		return errs.NewValidationError(errMsg["Limit"])
+	}
+	if r.From != "" && r.To != "" && r.From > r.To {
+		return errs.NewValidationError("From should not be after to.")
+	}
+
+	return nil
+}
+
+// LimitOrDefault returns the maximum number of entries requested, or the default if none was given.
+func (r AuditLogRequest) LimitOrDefault() int {
+	if r.Limit == "" {
+		return AuditLogDefaultLimit
+	}
+	limit, _ := strconv.Atoi(r.Limit) //checked to be a number by Validate
+	return limit
+}
@@ -0,0 +1,53 @@
+package dto
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestAuditLogRequest_Validate_returns_nil_when_request_valid(t *testing.T) {
+	//Arrange
+	request := AuditLogRequest{CustomerId: dummyCustomerId, RouteName: "NewTransaction", From: "2024-01-01",
+		To: "2024-01-31", AfterId: "10", Limit: "500"}
+
+	//Act
+	err := request.Validate()
+
+	//Assert
+	if err != nil {
+		t.Errorf("expected no error but got error: %s", err.Message)
+	}
+	if (AuditLogRequest{}).LimitOrDefault() != AuditLogDefaultLimit {
+		t.Errorf("expected default limit %d", AuditLogDefaultLimit)
+	}
+}
+
+func TestAuditLogRequest_Validate_returns_error_when_field_invalid(t *testing.T) {
+	tests := []struct {
+		name               string
+		request            AuditLogRequest
+		expectedErrMessage string
+	}{
+		{"route name with symbols", AuditLogRequest{RouteName: "New' OR 1=1"}, "Route name must be a name such as NewTransaction."},
+		{"date in other format", AuditLogRequest{From: "01/02/2024"}, "From must be a date in the format YYYY-MM-DD."},
+		{"from after to", AuditLogRequest{From: "2024-02-01", To: "2024-01-01"}, "From should not be after to."},
+		{"limit too high", AuditLogRequest{Limit: "501"}, "Limit must be a number between 1 and 500."},
+		{"limit zero", AuditLogRequest{Limit: "0"}, "Limit must be a number between 1 and 500."},
+		{"limit not a number", AuditLogRequest{Limit: "ten"}, "Limit must be a number between 1 and 500."},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Act
+			err := tc.request.Validate()
+
+			//Assert
+			if err == nil {
+				t.Fatal("expected error but got none")
+			}
+			if err.Code != http.StatusUnprocessableEntity || err.Message != tc.expectedErrMessage {
+				t.Errorf("Expected %d \"%s\" but got %d \"%s\"", http.StatusUnprocessableEntity, tc.expectedErrMessage, err.Code, err.Message)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package dto
+
+type AuditEntryResponse struct {
+	EntryId         string            `json:"entry_id"`
+	ActorRole       string            `json:"actor_role"`
+	ActorCustomerId string            `json:"actor_customer_id,omitempty"`
+	RouteName       string            `json:"route_name"`
+	RouteVars       map[string]string `json:"route_vars"`
+	RequestHash     string            `json:"request_hash"`
+	StatusCode      int               `json:"status_code"`
+	Outcome         string            `json:"outcome"`
+	IpAddress       string            `json:"ip_address"`
+	CreatedOn       string            `json:"created_on"`
+	EntryHash       string            `json:"entry_hash"`
+}
+
+type AuditLogResponse struct {
+	Entries     []AuditEntryResponse `json:"entries"`
+	NextAfterId string               `json:"next_after_id,omitempty"` //after_id of the next page, if there may be one
+}
+
+type AuditVerificationResponse struct {
+	Valid          bool   `json:"valid"`
+	EntriesChecked int    `json:"entries_checked"`
+	LastHash       string `json:"last_hash"`                  //hash of the last valid entry, to compare with a copy kept elsewhere
+	InvalidEntryId string `json:"invalid_entry_id,omitempty"` //first entry that breaks the chain
+	Reason         string `json:"reason,omitempty"`
+}
@@ -0,0 +1,39 @@
+package dto
+
+import (
+	"fmt"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/formValidator"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"strconv"
+)
+
+// BeneficiaryRequest adds a beneficiary for a customer or, if it has a beneficiary ID, replaces one. A beneficiary
+// without a bank code has an account of this bank, given by its account ID.
+type BeneficiaryRequest struct {
+	BeneficiaryId string `json:"-" validate:"omitempty,max=11,number"`
+	CustomerId    string `json:"-" validate:"required,max=11,number"`
+	Nickname      string `json:"nickname" validate:"required,max=50"`
+	AccountId     string `json:"account_id" validate:"required,max=34,alphanum"`
+	BankCode      string `json:"bank_code" validate:"omitempty,max=11,alphanum,uppercase"`
+}
+
+func (r BeneficiaryRequest) Validate() *errs.AppError {
+	errMsg := map[string]string{
+		"BeneficiaryId": "Beneficiary ID must be a number.",
+		"CustomerId":    "Customer ID must be present and a number.",
+		"Nickname":      "Nickname must be present and at most 50 characters long.",
+		"AccountId":     "Account ID must be present and at most 34 letters and digits.",
+		"BankCode":      "Bank code should be at most 11 uppercase letters and digits.",
+	}
+	if errsArr := formValidator.Struct(r); errsArr != nil {
+		logger.Error(fmt.Sprintf("Beneficiary request is invalid (%s) (%s)",
+			errsArr[0].Error(), errsArr[0].ActualTag()))
+		return errs.NewValidationError(errMsg[errsArr[0].Field()])
+	}
+	if _, err := strconv.ParseUint(r.AccountId, 10, 64); r.BankCode == "" && (err != nil || len(r.AccountId) > 11) {
+		return errs.NewValidationError("Account ID must be a number for accounts of this bank.")
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package dto
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestBeneficiaryRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		request   BeneficiaryRequest
+		expectErr bool
+	}{
+		{"account of this bank", BeneficiaryRequest{CustomerId: dummyCustomerId, Nickname: "landlord", AccountId: "1980"}, false},
+		{"account of other bank", BeneficiaryRequest{CustomerId: dummyCustomerId, Nickname: "sister",
+			AccountId: "DE89370400440532013000", BankCode: "DEUTDEFF"}, false},
+		{"non-numeric account of this bank", BeneficiaryRequest{CustomerId: dummyCustomerId, Nickname: "sister",
+			AccountId: "DE89370400440532013000"}, true},
+		{"lowercase bank code", BeneficiaryRequest{CustomerId: dummyCustomerId, Nickname: "sister",
+			AccountId: "DE89370400440532013000", BankCode: "deutdeff"}, true},
+		{"missing nickname", BeneficiaryRequest{CustomerId: dummyCustomerId, AccountId: "1980"}, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Act
+			err := tc.request.Validate()
+
+			//Assert
+			if !tc.expectErr && err != nil {
+				t.Error("Expected no error but got error: " + err.Message)
+			}
+			if tc.expectErr && (err == nil || err.Code != http.StatusUnprocessableEntity) {
+				t.Errorf("Expected validation error but got %v", err)
+			}
+		})
+	}
+}
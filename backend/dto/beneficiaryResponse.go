@@ -0,0 +1,12 @@
+package dto
+
+type BeneficiaryResponse struct {
+	BeneficiaryId     string  `json:"beneficiary_id"`
+	Nickname          string  `json:"nickname"`
+	AccountId         string  `json:"account_id"`
+	BankCode          string  `json:"bank_code,omitempty"`
+	CreationDate      string  `json:"creation_date"`
+	CoolingOffEndDate string  `json:"cooling_off_end_date"`
+	FirstTransferDate string  `json:"first_transfer_date,omitempty"`
+	TransferLimit     float64 `json:"transfer_limit"` //of the next transfer, which is lower for the first one
+}
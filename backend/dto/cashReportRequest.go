@@ -0,0 +1,40 @@
+package dto
+
+import (
+	"fmt"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/formValidator"
+	"github.com/aliciatay-zls/banking-lib/logger"
+)
+
+const CashReportKindSingle = "single"       //one cash transaction above the threshold
+const CashReportKindAggregate = "aggregate" //cash transactions of a customer on one day, together above the threshold
+
+const CashReportFormatCsv = "csv"
+const CashReportFormatJson = "json"
+
+// CashReportRequest asks for the report of the large cash transactions made on the days from From to To, both
+// included.
+type CashReportRequest struct {
+	From   string `validate:"required,datetime=2006-01-02"`
+	To     string `validate:"required,datetime=2006-01-02"`
+	Format string `validate:"required,oneof=csv json"`
+}
+
+func (r CashReportRequest) Validate() *errs.AppError {
+	errMsg := map[string]string{
+		"From":   "From must be a date in the format YYYY-MM-DD.",
+		"To":     "To must be a date in the format YYYY-MM-DD.",
+		"Format": fmt.Sprintf("Format should be %s or %s.", CashReportFormatCsv, CashReportFormatJson),
+	}
+	if errsArr := formValidator.Struct(r); errsArr != nil {
+		logger.Error(fmt.Sprintf("Cash report request is invalid (%s) (%s)",
+			errsArr[0].Error(), errsArr[0].ActualTag()))
+		return errs.NewValidationError(errMsg[errsArr[0].Field()])
+	}
+	if r.From > r.To {
+		return errs.NewValidationError("From should not be after to.")
+	}
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package dto
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestCashReportRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		request   CashReportRequest
+		expectErr bool
+	}{
+		{"one day", CashReportRequest{From: "2006-01-02", To: "2006-01-02", Format: CashReportFormatCsv}, false},
+		{"several days", CashReportRequest{From: "2006-01-02", To: "2006-02-01", Format: CashReportFormatJson}, false},
+		{"from after to", CashReportRequest{From: "2006-01-03", To: "2006-01-02", Format: CashReportFormatCsv}, true},
+		{"invalid date", CashReportRequest{From: "02/01/2006", To: "2006-01-02", Format: CashReportFormatCsv}, true},
+		{"unknown format", CashReportRequest{From: "2006-01-02", To: "2006-01-02", Format: "xml"}, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Act
+			err := tc.request.Validate()
+
+			//Assert
+			if !tc.expectErr && err != nil {
+				t.Error("Expected no error but got error: " + err.Message)
+			}
+			if tc.expectErr && (err == nil || err.Code != http.StatusUnprocessableEntity) {
+				t.Errorf("Expected validation error but got %v", err)
+			}
+		})
+	}
+}
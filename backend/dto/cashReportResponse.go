@@ -0,0 +1,43 @@
+package dto
+
+import (
+	"strconv"
+	"strings"
+)
+
+type CashReportResponse struct {
+	From           string                    `json:"from"`
+	To             string                    `json:"to"`
+	Threshold      float64                   `json:"threshold"`
+	GenerationDate string                    `json:"generation_date"`
+	Entries        []CashReportEntryResponse `json:"entries"`
+}
+
+type CashReportEntryResponse struct {
+	Kind            string   `json:"kind"`
+	Date            string   `json:"date"`
+	CustomerId      string   `json:"customer_id"`
+	Name            string   `json:"full_name"`
+	DateOfBirth     string   `json:"date_of_birth"`
+	Email           string   `json:"email"`
+	Country         string   `json:"country"`
+	Zipcode         string   `json:"zipcode"`
+	TransactionType string   `json:"transaction_type"`
+	Currency        string   `json:"currency"`
+	Total           float64  `json:"total"`
+	AccountIds      []string `json:"account_ids"`
+	TransactionIds  []string `json:"transaction_ids"`
+}
+
+// CsvRecords returns the entries of the report as CSV records, starting with a header. The IDs of the accounts and
+// transactions of an entry are separated by semicolons.
+func (r CashReportResponse) CsvRecords() [][]string {
+	records := [][]string{{"kind", "date", "customer_id", "full_name", "date_of_birth", "email", "country", "zipcode",
+		"transaction_type", "currency", "total", "transaction_count", "account_ids", "transaction_ids"}}
+	for _, e := range r.Entries {
+		records = append(records, []string{e.Kind, e.Date, e.CustomerId, csvText(e.Name), e.DateOfBirth,
+			csvText(e.Email), csvText(e.Country), csvText(e.Zipcode), e.TransactionType, e.Currency, csvAmount(e.Total),
+			strconv.Itoa(len(e.TransactionIds)), strings.Join(e.AccountIds, ";"), strings.Join(e.TransactionIds, ";")})
+	}
+	return records
+}
@@ -0,0 +1,40 @@
+package dto
+
+import "sort"
+
+// DefaultCurrency is the currency of accounts opened without one, which is also the currency that all existing
+// accounts were opened in before accounts had currencies.
+const DefaultCurrency = "USD"
+
+type NewAccountAmountRule struct {
+	Min float64
+	Max float64
+}
+
+// NewAccountAmountRules holds, for each supported currency, the range that the initial amount of a new account
+// opened in that currency must fall within. The minimums are roughly equivalent to 5000 USD.
+var NewAccountAmountRules = map[string]NewAccountAmountRule{
+	"USD": {Min: 5000, Max: 99999999.99},
+	"CAD": {Min: 6500, Max: 99999999.99},
+	"EUR": {Min: 4500, Max: 99999999.99},
+	"GBP": {Min: 4000, Max: 99999999.99},
+	"INR": {Min: 400000, Max: 99999999.99},
+	"NOK": {Min: 50000, Max: 99999999.99},
+	"SGD": {Min: 6500, Max: 99999999.99},
+}
+
+// IsSupportedCurrency checks whether accounts can be opened in the given currency.
+func IsSupportedCurrency(currency string) bool {
+	_, ok := NewAccountAmountRules[currency]
+	return ok
+}
+
+// SupportedCurrencies returns the codes of all supported currencies in alphabetical order.
+func SupportedCurrencies() []string {
+	currencies := make([]string, 0, len(NewAccountAmountRules))
+	for c := range NewAccountAmountRules {
+		currencies = append(currencies, c)
+	}
+	sort.Strings(currencies)
+	return currencies
+}
@@ -0,0 +1,30 @@
+package dto
+
+import (
+	"fmt"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/formValidator"
+	"github.com/aliciatay-zls/banking-lib/logger"
+)
+
+const CustomerStatusActive = "active"
+const CustomerStatusInactive = "inactive"
+
+type CustomerStatusRequest struct {
+	CustomerId string `json:"customer_id" validate:"required,max=11,number"`
+	Status     string `json:"status" validate:"required,oneof=active inactive"`
+}
+
+func (r CustomerStatusRequest) Validate() *errs.AppError {
+	errMsg := map[string]string{
+		"CustomerId": "Customer ID must be present and a number.",
+		"Status":     fmt.Sprintf("Status should be %s or %s.", CustomerStatusActive, CustomerStatusInactive),
+	}
+	if errsArr := formValidator.Struct(r); errsArr != nil {
+		logger.Error(fmt.Sprintf("Customer status request is invalid (%s) (%s)",
+			errsArr[0].Error(), errsArr[0].ActualTag()))
+		return errs.NewValidationError(errMsg[errsArr[0].Field()])
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package dto
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestCustomerStatusRequest_Validate_returns_nil_when_status_valid(t *testing.T) {
+	for _, status := range []string{CustomerStatusActive, CustomerStatusInactive} {
+		t.Run(status, func(t *testing.T) {
+			//Arrange
+			request := CustomerStatusRequest{CustomerId: dummyCustomerId, Status: status}
+
+			//Act
+			err := request.Validate()
+
+			//Assert
+			if err != nil {
+				t.Errorf("expected no error but got error while testing valid status %s: %s", status, err.Message)
+			}
+		})
+	}
+}
+
+func TestCustomerStatusRequest_Validate_returns_error_when_status_invalid(t *testing.T) {
+	//Arrange
+	request := CustomerStatusRequest{CustomerId: dummyCustomerId, Status: "suspended"}
+	expectedErrMessage := "Status should be active or inactive."
+
+	//Act
+	err := request.Validate()
+
+	//Assert
+	if err == nil {
+		t.Fatal("expected error but got none while testing invalid status")
+	}
+	if err.Code != http.StatusUnprocessableEntity || err.Message != expectedErrMessage {
+		t.Errorf("Expected %d \"%s\" but got %d \"%s\"", http.StatusUnprocessableEntity, expectedErrMessage, err.Code, err.Message)
+	}
+}
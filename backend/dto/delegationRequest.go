@@ -0,0 +1,53 @@
+package dto
+
+import (
+	"fmt"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/formValidator"
+	"github.com/aliciatay-zls/banking-lib/logger"
+)
+
+const DelegationScopeViewOnly = "view_only"       //may only look at the account
+const DelegationScopeDepositOnly = "deposit_only" //may also deposit into the account
+const DelegationScopeWithdraw = "withdraw"        //may also deposit, withdraw and transfer, up to a daily limit
+
+// DelegationRequest grants a customer who does not hold an account access to it within a scope, from the start date
+// (today if not given) to the end date, both included. It is made by the account's primary holder, given by
+// CustomerId, while DelegateCustomerId is the customer to grant access to.
+type DelegationRequest struct {
+	AccountId          string  `json:"-" validate:"required,max=11,number"`
+	CustomerId         string  `json:"-" validate:"required,max=11,number"`
+	DelegateCustomerId string  `json:"customer_id" validate:"required,max=11,number,nefield=CustomerId"`
+	Scope              string  `json:"scope" validate:"required,oneof=view_only deposit_only withdraw"`
+	DailyLimit         float64 `json:"daily_limit" validate:"required_if=Scope withdraw,gte=0,lte=10000"`
+	StartDate          string  `json:"start_date" validate:"omitempty,datetime=2006-01-02"`
+	EndDate            string  `json:"end_date" validate:"required,datetime=2006-01-02"`
+}
+
+func (r DelegationRequest) Validate() *errs.AppError {
+	errMsg := map[string]string{
+		"AccountId":          "Account ID must be present and a number.",
+		"CustomerId":         "Customer ID must be present and a number.",
+		"DelegateCustomerId": "Customer ID of the delegate must be present, a number and not your own.",
+		"Scope": fmt.Sprintf("Scope should be %s, %s or %s.",
+			DelegationScopeViewOnly, DelegationScopeDepositOnly, DelegationScopeWithdraw),
+		"DailyLimit": fmt.Sprintf("Daily limit should be more than %.2f and at most %.2f for the %s scope.",
+			TransactionMinAmountAllowed, TransactionMaxAmountAllowed, DelegationScopeWithdraw),
+		"StartDate": "Start date should be in the format YYYY-MM-DD.",
+		"EndDate":   "End date must be present and in the format YYYY-MM-DD.",
+	}
+	if errsArr := formValidator.Struct(r); errsArr != nil {
+		logger.Error(fmt.Sprintf("Delegation request is invalid (%s) (%s)",
+			errsArr[0].Error(), errsArr[0].ActualTag()))
+		return errs.NewValidationError(errMsg[errsArr[0].Field()])
+	}
+	if r.Scope != DelegationScopeWithdraw && r.DailyLimit != 0 {
+		return errs.NewValidationError(fmt.Sprintf("Daily limit can only be given for the %s scope.",
+			DelegationScopeWithdraw))
+	}
+	if r.StartDate != "" && r.StartDate > r.EndDate {
+		return errs.NewValidationError("Start date should not be after end date.")
+	}
+
+	return nil
+}
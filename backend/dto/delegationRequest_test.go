@@ -0,0 +1,47 @@
+package dto
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestDelegationRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		request   DelegationRequest
+		expectErr bool
+	}{
+		{"view-only delegation", DelegationRequest{AccountId: "1977", CustomerId: dummyCustomerId, DelegateCustomerId: "3",
+			Scope: DelegationScopeViewOnly, EndDate: "2023-03-31"}, false},
+		{"withdraw delegation with daily limit", DelegationRequest{AccountId: "1977", CustomerId: dummyCustomerId,
+			DelegateCustomerId: "3", Scope: DelegationScopeWithdraw, DailyLimit: 500, StartDate: "2023-03-01",
+			EndDate: "2023-03-31"}, false},
+		{"withdraw delegation without daily limit", DelegationRequest{AccountId: "1977", CustomerId: dummyCustomerId,
+			DelegateCustomerId: "3", Scope: DelegationScopeWithdraw, EndDate: "2023-03-31"}, true},
+		{"daily limit for deposit-only delegation", DelegationRequest{AccountId: "1977", CustomerId: dummyCustomerId,
+			DelegateCustomerId: "3", Scope: DelegationScopeDepositOnly, DailyLimit: 500, EndDate: "2023-03-31"}, true},
+		{"unknown scope", DelegationRequest{AccountId: "1977", CustomerId: dummyCustomerId, DelegateCustomerId: "3",
+			Scope: "full", EndDate: "2023-03-31"}, true},
+		{"requester as delegate", DelegationRequest{AccountId: "1977", CustomerId: dummyCustomerId,
+			DelegateCustomerId: dummyCustomerId, Scope: DelegationScopeViewOnly, EndDate: "2023-03-31"}, true},
+		{"missing end date", DelegationRequest{AccountId: "1977", CustomerId: dummyCustomerId, DelegateCustomerId: "3",
+			Scope: DelegationScopeViewOnly}, true},
+		{"start date after end date", DelegationRequest{AccountId: "1977", CustomerId: dummyCustomerId,
+			DelegateCustomerId: "3", Scope: DelegationScopeViewOnly, StartDate: "2023-04-01", EndDate: "2023-03-31"}, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Act
+			err := tc.request.Validate()
+
+			//Assert
+			if !tc.expectErr && err != nil {
+				t.Error("Expected no error but got error: " + err.Message)
+			}
+			if tc.expectErr && (err == nil || err.Code != http.StatusUnprocessableEntity) {
+				t.Errorf("Expected validation error but got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package dto
+
+type DelegationResponse struct {
+	DelegationId       string  `json:"delegation_id"`
+	AccountId          string  `json:"account_id"`
+	GrantorCustomerId  string  `json:"grantor_customer_id"`
+	DelegateCustomerId string  `json:"delegate_customer_id"`
+	Scope              string  `json:"scope"`
+	DailyLimit         float64 `json:"daily_limit,omitempty"` //of the withdraw scope
+	StartDate          string  `json:"start_date"`
+	EndDate            string  `json:"end_date"`
+	CreationDate       string  `json:"creation_date"`
+	RevocationDate     string  `json:"revocation_date,omitempty"`
+	Active             bool    `json:"active"`
+}
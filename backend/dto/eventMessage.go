@@ -0,0 +1,40 @@
+package dto
+
+import "encoding/json"
+
+// EventMessage is a domain event as it is published. Since events are published at least once, consumers should use
+// the event ID to ignore events they have already handled.
+type EventMessage struct {
+	EventId       string          `json:"event_id"`
+	EventType     string          `json:"event_type"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateId   string          `json:"aggregate_id"`
+	OccurredOn    string          `json:"occurred_on"`
+	Payload       json.RawMessage `json:"payload"`
+}
+
+type AccountOpenedPayload struct {
+	AccountId   string  `json:"account_id"`
+	CustomerId  string  `json:"customer_id"`
+	AccountType string  `json:"account_type"`
+	Currency    string  `json:"currency"`
+	Amount      float64 `json:"amount"`
+	OpeningDate string  `json:"opening_date"`
+}
+
+type TransactionPostedPayload struct {
+	TransactionId   string  `json:"transaction_id"`
+	AccountId       string  `json:"account_id"`
+	Amount          float64 `json:"amount"`
+	TransactionType string  `json:"transaction_type"`
+	TransactionDate string  `json:"transaction_date"`
+	Balance         float64 `json:"new_balance"`
+	FxRate          float64 `json:"fx_rate,omitempty"`
+	ConvertedAmount float64 `json:"converted_amount,omitempty"`
+}
+
+type CustomerStatusChangedPayload struct {
+	CustomerId     string `json:"customer_id"`
+	Status         string `json:"status"`
+	PreviousStatus string `json:"previous_status"`
+}
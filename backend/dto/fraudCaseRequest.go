@@ -0,0 +1,74 @@
+package dto
+
+import (
+	"fmt"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/formValidator"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"strconv"
+)
+
+const FraudCaseStatusOpen = "open"
+const FraudCaseStatusClosed = "closed"
+
+const FraudCaseResolutionFraud = "fraud"           //the transaction was fraudulent
+const FraudCaseResolutionLegitimate = "legitimate" //the transaction was made by the customer in good faith
+
+const FraudCasesDefaultLimit = 100
+const FraudCasesMaxLimit = 500
+
+// FraudCasesRequest holds the query parameters of a request for the fraud case queue.
+type FraudCasesRequest struct {
+	Status  string `validate:"omitempty,oneof=open closed"`
+	AfterId string `validate:"omitempty,max=11,number"`
+	Limit   string `validate:"omitempty,max=3,number"`
+}
+
+func (r FraudCasesRequest) Validate() *errs.AppError {
+	errMsg := map[string]string{
+		"Status":  fmt.Sprintf("Status should be %s or %s.", FraudCaseStatusOpen, FraudCaseStatusClosed),
+		"AfterId": "After ID must be a number.",
+		"Limit":   fmt.Sprintf("Limit must be a number between 1 and %d.", FraudCasesMaxLimit),
+	}
+	if errsArr := formValidator.Struct(r); errsArr != nil {
+		logger.Error(fmt.Sprintf("Fraud cases request is invalid (%s) (%s)",
+			errsArr[0].Error(), errsArr[0].ActualTag()))
+		return errs.NewValidationError(errMsg[errsArr[0].Field()])
+	}
+	if limit := r.LimitOrDefault(); limit < 1 || limit > FraudCasesMaxLimit {
+		return errs.NewValidationError(errMsg["Limit"])
+	}
+
+	return nil
+}
+
+// LimitOrDefault returns the maximum number of cases requested, or the default if none was given.
+func (r FraudCasesRequest) LimitOrDefault() int {
+	if r.Limit == "" {
+		return FraudCasesDefaultLimit
+	}
+	limit, _ := strconv.Atoi(r.Limit) //checked to be a number by Validate
+	return limit
+}
+
+// ResolveFraudCaseRequest closes a fraud case with the outcome of the admin's review of it.
+type ResolveFraudCaseRequest struct {
+	CaseId     string `json:"-" validate:"required,max=11,number"`
+	Resolution string `json:"resolution" validate:"required,oneof=fraud legitimate"`
+	Note       string `json:"note" validate:"max=255"`
+}
+
+func (r ResolveFraudCaseRequest) Validate() *errs.AppError {
+	errMsg := map[string]string{
+		"CaseId":     "Case ID must be present and a number.",
+		"Resolution": fmt.Sprintf("Resolution should be %s or %s.", FraudCaseResolutionFraud, FraudCaseResolutionLegitimate),
+		"Note":       "Note must be at most 255 characters.",
+	}
+	if errsArr := formValidator.Struct(r); errsArr != nil {
+		logger.Error(fmt.Sprintf("Resolve fraud case request is invalid (%s) (%s)",
+			errsArr[0].Error(), errsArr[0].ActualTag()))
+		return errs.NewValidationError(errMsg[errsArr[0].Field()])
+	}
+
+	return nil
+}
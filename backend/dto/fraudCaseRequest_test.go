@@ -0,0 +1,48 @@
+package dto
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestFraudCasesRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		request   FraudCasesRequest
+		expectErr bool
+	}{
+		{"no parameters", FraudCasesRequest{}, false},
+		{"all parameters", FraudCasesRequest{Status: FraudCaseStatusClosed, AfterId: "10", Limit: "500"}, false},
+		{"unknown status", FraudCasesRequest{Status: "pending"}, true},
+		{"limit too high", FraudCasesRequest{Limit: "501"}, true},
+		{"zero limit", FraudCasesRequest{Limit: "0"}, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Act
+			err := tc.request.Validate()
+
+			//Assert
+			if !tc.expectErr && err != nil {
+				t.Error("Expected no error but got error: " + err.Message)
+			}
+			if tc.expectErr && (err == nil || err.Code != http.StatusUnprocessableEntity) {
+				t.Errorf("Expected validation error but got %v", err)
+			}
+		})
+	}
+}
+
+func TestResolveFraudCaseRequest_Validate_returns_error_when_resolution_missing(t *testing.T) {
+	//Arrange
+	request := ResolveFraudCaseRequest{CaseId: "5", Note: "Customer confirmed by phone"}
+
+	//Act
+	err := request.Validate()
+
+	//Assert
+	if err == nil || err.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected validation error but got %v", err)
+	}
+}
@@ -0,0 +1,29 @@
+package dto
+
+type FraudCaseResponse struct {
+	CaseId          string                `json:"case_id"`
+	CustomerId      string                `json:"customer_id"`
+	AccountId       string                `json:"account_id"`
+	TransactionType string                `json:"transaction_type"`
+	Amount          float64               `json:"amount"`
+	TransactionId   string                `json:"transaction_id,omitempty"` //empty if the transaction was blocked
+	Decision        string                `json:"decision"`
+	Results         []ScreeningResultInfo `json:"results"`
+	Status          string                `json:"status"`
+	Resolution      string                `json:"resolution,omitempty"`
+	Note            string                `json:"note,omitempty"`
+	CreationDate    string                `json:"creation_date"`
+	ResolutionDate  string                `json:"resolution_date,omitempty"`
+}
+
+// ScreeningResultInfo is the decision of one of the screening rules that did not allow a transaction.
+type ScreeningResultInfo struct {
+	Rule     string `json:"rule"`
+	Decision string `json:"decision"`
+	Reason   string `json:"reason"`
+}
+
+type FraudCasesResponse struct {
+	Cases       []FraudCaseResponse `json:"cases"`
+	NextAfterId string              `json:"next_after_id,omitempty"` //after_id of the next page, if there may be one
+}
@@ -0,0 +1,60 @@
+package dto
+
+import (
+	"fmt"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/formValidator"
+	"github.com/aliciatay-zls/banking-lib/logger"
+)
+
+const HoldDefaultExpiryHours = 168 //7 days
+const HoldMaxExpiryHours = 720     //30 days
+
+type NewHoldRequest struct {
+	AccountId      string  `json:"-" validate:"required,max=11,number"`
+	CustomerId     string  `json:"-" validate:"required,max=11,number"`
+	Amount         float64 `json:"amount" validate:"number,gt=0,lte=10000"`
+	Description    string  `json:"description" validate:"max=255"`
+	ExpiresInHours int     `json:"expires_in_hours" validate:"gte=0,lte=720"`
+}
+
+func (r NewHoldRequest) Validate() *errs.AppError {
+	errMsg := map[string]string{
+		"AccountId":  "Account ID must be present and a number.",
+		"CustomerId": "Customer ID must be present and a number.",
+		"Amount": fmt.Sprintf("Amount to hold should be more than %.2f and at most %.2f.",
+			TransactionMinAmountAllowed, TransactionMaxAmountAllowed),
+		"Description":    "Description should be at most 255 characters.",
+		"ExpiresInHours": fmt.Sprintf("Hold should expire in at most %d hours.", HoldMaxExpiryHours),
+	}
+	if errsArr := formValidator.Struct(r); errsArr != nil {
+		logger.Error(fmt.Sprintf("New hold request is invalid (%s) (%s)",
+			errsArr[0].Error(), errsArr[0].ActualTag()))
+		return errs.NewValidationError(errMsg[errsArr[0].Field()])
+	}
+
+	return nil
+}
+
+type CaptureHoldRequest struct {
+	AccountId  string  `json:"-" validate:"required,max=11,number"`
+	CustomerId string  `json:"-" validate:"required,max=11,number"`
+	HoldId     string  `json:"-" validate:"required,max=11,number"`
+	Amount     float64 `json:"amount" validate:"number,gte=0,lte=10000"` //0 to capture the full amount held
+}
+
+func (r CaptureHoldRequest) Validate() *errs.AppError {
+	errMsg := map[string]string{
+		"AccountId":  "Account ID must be present and a number.",
+		"CustomerId": "Customer ID must be present and a number.",
+		"HoldId":     "Hold ID must be present and a number.",
+		"Amount":     "Please check that the amount to capture is valid.",
+	}
+	if errsArr := formValidator.Struct(r); errsArr != nil {
+		logger.Error(fmt.Sprintf("Capture hold request is invalid (%s) (%s)",
+			errsArr[0].Error(), errsArr[0].ActualTag()))
+		return errs.NewValidationError(errMsg[errsArr[0].Field()])
+	}
+
+	return nil
+}
@@ -0,0 +1,13 @@
+package dto
+
+type HoldResponse struct {
+	HoldId         string  `json:"hold_id"`
+	AccountId      string  `json:"account_id"`
+	Amount         float64 `json:"amount"`
+	CapturedAmount float64 `json:"captured_amount"`
+	Description    string  `json:"description,omitempty"`
+	Status         string  `json:"status"`
+	CreationDate   string  `json:"creation_date"`
+	ExpiryDate     string  `json:"expiry_date"`
+	TransactionId  string  `json:"transaction_id,omitempty"`
+}
@@ -5,17 +5,25 @@ import (
 	"github.com/aliciatay-zls/banking-lib/errs"
 	"github.com/aliciatay-zls/banking-lib/formValidator"
 	"github.com/aliciatay-zls/banking-lib/logger"
+	"strings"
 )
 
 const AccountTypeSaving = "saving"
 const AccountTypeChecking = "checking"
-const NewAccountMinAmountAllowed float64 = 5000
-const NewAccountMaxAmountAllowed float64 = 99999999.99
 
 type NewAccountRequest struct {
 	CustomerId  string  `json:"customer_id" validate:"required,max=11,number"`
 	AccountType string  `json:"account_type" validate:"required,alpha,oneof=saving checking"`
-	Amount      float64 `json:"amount" validate:"required,number,gte=5000,lte=99999999.99"`
+	Currency    string  `json:"currency" validate:"omitempty,len=3,alpha"`
+	Amount      float64 `json:"amount" validate:"required,number,gt=0"`
+}
+
+// CurrencyCode returns the currency the account is to be opened in, which is DefaultCurrency if none was given.
+func (r NewAccountRequest) CurrencyCode() string {
+	if r.Currency == "" {
+		return DefaultCurrency
+	}
+	return r.Currency
 }
 
 func (r NewAccountRequest) Validate() *errs.AppError {
@@ -23,6 +31,7 @@ func (r NewAccountRequest) Validate() *errs.AppError {
 	errMsg := map[string]string{
 		"CustomerId":  "Customer ID must be present and a number.",
 		"AccountType": fmt.Sprintf("Account type should be %s or %s.", AccountTypeSaving, AccountTypeChecking),
+		"Currency":    "Currency should be one of " + strings.Join(SupportedCurrencies(), ", ") + ".",
 		"Amount":      "Please check that the initial amount is valid.",
 	}
 	if errsArr := formValidator.Struct(r); errsArr != nil {
@@ -31,5 +40,16 @@ func (r NewAccountRequest) Validate() *errs.AppError {
 		return errs.NewValidationError(errMsg[errsArr[0].Field()])
 	}
 
+	rule, ok := NewAccountAmountRules[r.CurrencyCode()]
+	if !ok {
+		logger.Error("New account request is invalid (unsupported currency " + r.Currency + ")")
+		return errs.NewValidationError(errMsg["Currency"])
+	}
+	if r.Amount < rule.Min || r.Amount > rule.Max {
+		logger.Error(fmt.Sprintf("New account request is invalid (amount %.2f %s not within %.2f and %.2f)",
+			r.Amount, r.CurrencyCode(), rule.Min, rule.Max))
+		return errs.NewValidationError(errMsg["Amount"])
+	}
+
 	return nil
 }
@@ -19,7 +19,7 @@ func getDefaultValidNewAccountRequest() NewAccountRequest {
 	return NewAccountRequest{
 		CustomerId:  dummyCustomerId,
 		AccountType: AccountTypeSaving,
-		Amount:      NewAccountMinAmountAllowed,
+		Amount:      NewAccountAmountRules[DefaultCurrency].Min,
 	}
 }
 
@@ -30,7 +30,7 @@ func TestNewAccountRequest_Validate_returns_nil_when_amount_valid(t *testing.T)
 		amount float64
 	}{
 		{"in range", 6000.50},
-		{"lower boundary", NewAccountMinAmountAllowed},
+		{"lower boundary", NewAccountAmountRules[DefaultCurrency].Min},
 		{"upper boundary", 99999999},
 	}
 
@@ -118,8 +118,8 @@ func TestNewAccountRequest_Validate_returns_error_when_field_empty(t *testing.T)
 		expectedErrMessage string
 	}{
 		{"amount empty", NewAccountRequest{CustomerId: "2000", AccountType: AccountTypeSaving}, "Please check that the initial amount is valid."},
-		{"type empty", NewAccountRequest{CustomerId: dummyCustomerId, Amount: NewAccountMinAmountAllowed}, "Account type should be saving or checking."},
-		{"customer id empty", NewAccountRequest{Amount: NewAccountMinAmountAllowed, AccountType: AccountTypeSaving}, "Customer ID must be present and a number."},
+		{"type empty", NewAccountRequest{CustomerId: dummyCustomerId, Amount: NewAccountAmountRules[DefaultCurrency].Min}, "Account type should be saving or checking."},
+		{"customer id empty", NewAccountRequest{Amount: NewAccountAmountRules[DefaultCurrency].Min, AccountType: AccountTypeSaving}, "Customer ID must be present and a number."},
 	}
 
 	expectedCode := http.StatusUnprocessableEntity
@@ -180,3 +180,53 @@ func TestNewAccountRequest_Validate_returns_first_error_when_field_multiple_erro
 		}
 	}
 }
+
+func TestNewAccountRequest_Validate_appliesAmountRule_ofCurrency(t *testing.T) {
+	//Arrange
+	tests := []struct {
+		name          string
+		currency      string
+		amount        float64
+		expectedValid bool
+	}{
+		{"default currency lower boundary", "", 5000, true},
+		{"INR below lower boundary", "INR", 399999.99, false},
+		{"INR lower boundary", "INR", 400000, true},
+		{"GBP below USD lower boundary", "GBP", 4000, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			request := getDefaultValidNewAccountRequest()
+			request.Currency = tc.currency
+			request.Amount = tc.amount
+
+			//Act
+			err := request.Validate()
+
+			//Assert
+			if (err == nil) != tc.expectedValid {
+				t.Errorf("expected valid: %v but got error: %v", tc.expectedValid, err)
+			}
+		})
+	}
+}
+
+func TestNewAccountRequest_Validate_returns_error_when_currency_unsupported(t *testing.T) {
+	//Arrange
+	request := getDefaultValidNewAccountRequest()
+	request.Currency = "XYZ"
+
+	expectedErrMessage := "Currency should be one of CAD, EUR, GBP, INR, NOK, SGD, USD."
+
+	//Act
+	actualErr := request.Validate()
+
+	//Assert
+	if actualErr == nil {
+		t.Fatal("expected error but got none while testing unsupported currency")
+	}
+	if actualErr.Message != expectedErrMessage {
+		t.Errorf("expected message: \"%s\", actual message: \"%s\"", expectedErrMessage, actualErr.Message)
+	}
+}
@@ -0,0 +1,48 @@
+package dto
+
+import (
+	"fmt"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/formValidator"
+	"github.com/aliciatay-zls/banking-lib/logger"
+)
+
+const StandingOrderScheduleMonthly = "monthly"
+const StandingOrderScheduleCron = "cron"
+
+type NewStandingOrderRequest struct {
+	AccountId            string  `json:"-" validate:"required,max=11,number"`
+	CustomerId           string  `json:"-" validate:"required,max=11,number"`
+	DestinationAccountId string  `json:"destination_account_id" validate:"required,max=11,number,nefield=AccountId"`
+	Amount               float64 `json:"amount" validate:"number,gt=0,lte=10000"`
+	ScheduleType         string  `json:"schedule_type" validate:"required,oneof=monthly cron"`
+	DayOfMonth           int     `json:"day_of_month" validate:"required_if=ScheduleType monthly,gte=0,lte=31"`
+	CronExpression       string  `json:"cron_expression" validate:"required_if=ScheduleType cron,max=100"`
+	StartDate            string  `json:"start_date" validate:"omitempty,datetime=2006-01-02"`
+	EndDate              string  `json:"end_date" validate:"omitempty,datetime=2006-01-02"`
+	MaxOccurrences       int     `json:"max_occurrences" validate:"gte=0"`
+}
+
+func (r NewStandingOrderRequest) Validate() *errs.AppError {
+	errMsg := map[string]string{
+		"AccountId":            "Account ID must be present and a number.",
+		"CustomerId":           "Customer ID must be present and a number.",
+		"DestinationAccountId": "Destination account ID must be present, a number and different from the account ID.",
+		"Amount": fmt.Sprintf("Transfer amount should be more than %.2f and at most %.2f.",
+			TransactionMinAmountAllowed, TransactionMaxAmountAllowed),
+		"ScheduleType": fmt.Sprintf("Schedule type should be %s or %s.",
+			StandingOrderScheduleMonthly, StandingOrderScheduleCron),
+		"DayOfMonth":     "Day of month should be between 1 and 31 for a monthly schedule.",
+		"CronExpression": "Cron expression must be present for a cron schedule.",
+		"StartDate":      "Start date should be in the format YYYY-MM-DD.",
+		"EndDate":        "End date should be in the format YYYY-MM-DD.",
+		"MaxOccurrences": "Number of occurrences cannot be negative.",
+	}
+	if errsArr := formValidator.Struct(r); errsArr != nil {
+		logger.Error(fmt.Sprintf("New standing order request is invalid (%s) (%s)",
+			errsArr[0].Error(), errsArr[0].ActualTag()))
+		return errs.NewValidationError(errMsg[errsArr[0].Field()])
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package dto
+
+import (
+	"net/http"
+	"testing"
+)
+
+// getDefaultValidNewStandingOrderRequest returns a NewStandingOrderRequest for the customer with id 2 wanting to
+// transfer 1000.00 from the account numbered 1977 to the account numbered 1980 on the 15th of every month
+func getDefaultValidNewStandingOrderRequest() NewStandingOrderRequest {
+	return NewStandingOrderRequest{
+		AccountId:            dummyAccountId,
+		CustomerId:           dummyCustomerId,
+		DestinationAccountId: "1980",
+		Amount:               dummyAmount,
+		ScheduleType:         StandingOrderScheduleMonthly,
+		DayOfMonth:           15,
+	}
+}
+
+func TestNewStandingOrderRequest_Validate_returns_nil_when_request_valid(t *testing.T) {
+	//Arrange
+	tests := []struct {
+		name   string
+		modify func(r *NewStandingOrderRequest)
+	}{
+		{"monthly", func(r *NewStandingOrderRequest) {}},
+		{"cron", func(r *NewStandingOrderRequest) {
+			r.ScheduleType, r.DayOfMonth, r.CronExpression = StandingOrderScheduleCron, 0, "0 9 * * 1"
+		}},
+		{"with end date and occurrences", func(r *NewStandingOrderRequest) {
+			r.StartDate, r.EndDate, r.MaxOccurrences = "2030-01-01", "2030-12-31", 12
+		}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			request := getDefaultValidNewStandingOrderRequest()
+			tc.modify(&request)
+
+			//Act
+			err := request.Validate()
+
+			//Assert
+			if err != nil {
+				t.Errorf("expected no error but got error while testing valid request: %s", err.Message)
+			}
+		})
+	}
+}
+
+func TestNewStandingOrderRequest_Validate_returns_error_when_request_invalid(t *testing.T) {
+	//Arrange
+	tests := []struct {
+		name    string
+		modify  func(r *NewStandingOrderRequest)
+		message string
+	}{
+		{"same destination", func(r *NewStandingOrderRequest) { r.DestinationAccountId = dummyAccountId },
+			"Destination account ID must be present, a number and different from the account ID."},
+		{"zero amount", func(r *NewStandingOrderRequest) { r.Amount = 0 },
+			"Transfer amount should be more than 0.00 and at most 10000.00."},
+		{"unknown schedule", func(r *NewStandingOrderRequest) { r.ScheduleType = "weekly" },
+			"Schedule type should be monthly or cron."},
+		{"monthly without day", func(r *NewStandingOrderRequest) { r.DayOfMonth = 0 },
+			"Day of month should be between 1 and 31 for a monthly schedule."},
+		{"cron without expression", func(r *NewStandingOrderRequest) { r.ScheduleType = StandingOrderScheduleCron },
+			"Cron expression must be present for a cron schedule."},
+		{"bad end date", func(r *NewStandingOrderRequest) { r.EndDate = "31/12/2030" },
+			"End date should be in the format YYYY-MM-DD."},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			request := getDefaultValidNewStandingOrderRequest()
+			tc.modify(&request)
+
+			//Act
+			actualErr := request.Validate()
+
+			//Assert
+			if actualErr == nil {
+				t.Fatal("expected error but got none while testing invalid request")
+			}
+			if actualErr.Message != tc.message {
+				t.Errorf("expected message: \"%s\", actual message: \"%s\"", tc.message, actualErr.Message)
+			}
+			if actualErr.Code != http.StatusUnprocessableEntity {
+				t.Errorf("expected status code: \"%d\", actual status code: \"%d\"", http.StatusUnprocessableEntity, actualErr.Code)
+			}
+		})
+	}
+}
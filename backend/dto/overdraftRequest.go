@@ -0,0 +1,35 @@
+package dto
+
+import (
+	"fmt"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/formValidator"
+	"github.com/aliciatay-zls/banking-lib/logger"
+)
+
+// OverdraftMinLimitAllowed and OverdraftMaxLimitAllowed bound the overdraft limit of a checking account. Like the
+// limit itself, they are in the currency of the account and are not converted between currencies.
+const OverdraftMinLimitAllowed float64 = 0
+const OverdraftMaxLimitAllowed float64 = 10000
+
+type OverdraftRequest struct {
+	AccountId      string  `json:"-" validate:"required,max=11,number"`
+	CustomerId     string  `json:"-" validate:"required,max=11,number"`
+	OverdraftLimit float64 `json:"overdraft_limit" validate:"number,gte=0,lte=10000"`
+}
+
+func (r OverdraftRequest) Validate() *errs.AppError {
+	errMsg := map[string]string{
+		"AccountId":  "Account ID must be present and a number.",
+		"CustomerId": "Customer ID must be present and a number.",
+		"OverdraftLimit": fmt.Sprintf("Overdraft limit should be between %.2f and %.2f.",
+			OverdraftMinLimitAllowed, OverdraftMaxLimitAllowed),
+	}
+	if errsArr := formValidator.Struct(r); errsArr != nil {
+		logger.Error(fmt.Sprintf("Overdraft request is invalid (%s) (%s)",
+			errsArr[0].Error(), errsArr[0].ActualTag()))
+		return errs.NewValidationError(errMsg[errsArr[0].Field()])
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package dto
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestOverdraftRequest_Validate_returns_nil_when_limit_valid(t *testing.T) {
+	//Arrange
+	tests := []struct {
+		name  string
+		limit float64
+	}{
+		{"zero to remove overdraft", 0},
+		{"in range", 500},
+		{"upper boundary", OverdraftMaxLimitAllowed},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			request := OverdraftRequest{AccountId: dummyAccountId, CustomerId: dummyCustomerId, OverdraftLimit: tc.limit}
+
+			//Act
+			err := request.Validate()
+
+			//Assert
+			if err != nil {
+				t.Errorf("expected no error but got error while testing valid overdraft limit %v: %s",
+					request.OverdraftLimit, err.Message)
+			}
+		})
+	}
+}
+
+func TestOverdraftRequest_Validate_returns_error_when_limit_invalid(t *testing.T) {
+	//Arrange
+	tests := []struct {
+		name  string
+		limit float64
+	}{
+		{"below lower boundary", -0.01},
+		{"above upper boundary", OverdraftMaxLimitAllowed + 0.01},
+	}
+
+	expectedErrMessage := "Overdraft limit should be between 0.00 and 10000.00."
+	expectedCode := http.StatusUnprocessableEntity
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			request := OverdraftRequest{AccountId: dummyAccountId, CustomerId: dummyCustomerId, OverdraftLimit: tc.limit}
+
+			//Act
+			actualErr := request.Validate()
+
+			//Assert
+			if actualErr == nil {
+				t.Fatal("expected error but got none while testing invalid overdraft limit")
+			}
+			if actualErr.Message != expectedErrMessage {
+				t.Errorf("expected message: \"%s\", actual message: \"%s\"", expectedErrMessage, actualErr.Message)
+			}
+			if actualErr.Code != expectedCode {
+				t.Errorf("expected status code: \"%d\", actual status code: \"%d\"", expectedCode, actualErr.Code)
+			}
+		})
+	}
+}
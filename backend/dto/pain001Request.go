@@ -0,0 +1,121 @@
+package dto
+
+import (
+	"encoding/xml"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// pain001NamespacePrefix starts the namespace of every version of pain.001, the versions differing only in elements
+// that are not read here.
+const pain001NamespacePrefix = "urn:iso:std:iso:20022:tech:xsd:pain.001.001."
+
+// Pain001MaxTransactions is the largest number of credit transfers that can be initiated with one message.
+const Pain001MaxTransactions = 1000
+
+// PaymentInitiationRequest asks to make the credit transfers in a pain.001 message on behalf of the given customer.
+type PaymentInitiationRequest struct {
+	CustomerId string `validate:"required,max=11,number"`
+}
+
+// Pain001Document is an ISO 20022 customer credit transfer initiation (pain.001). Only the elements needed to make
+// transfers between accounts of the bank are read.
+type Pain001Document struct {
+	XMLName      xml.Name             `xml:"Document"`
+	GroupHeader  Pain001GroupHeader   `xml:"CstmrCdtTrfInitn>GrpHdr"`
+	PaymentInfos []Pain001PaymentInfo `xml:"CstmrCdtTrfInitn>PmtInf"`
+}
+
+type Pain001GroupHeader struct {
+	MessageId            string `xml:"MsgId"`
+	NumberOfTransactions string `xml:"NbOfTxs"`
+	ControlSum           string `xml:"CtrlSum"`
+}
+
+// Pain001PaymentInfo is a set of credit transfers from the same debtor account.
+type Pain001PaymentInfo struct {
+	PaymentInfoId        string                  `xml:"PmtInfId"`
+	NumberOfTransactions string                  `xml:"NbOfTxs"`
+	ControlSum           string                  `xml:"CtrlSum"`
+	DebtorAccount        Pain001Account          `xml:"DbtrAcct"`
+	Transactions         []Pain001CreditTransfer `xml:"CdtTrfTxInf"`
+}
+
+type Pain001CreditTransfer struct {
+	InstructionId   string         `xml:"PmtId>InstrId"`
+	EndToEndId      string         `xml:"PmtId>EndToEndId"`
+	Amount          Pain001Amount  `xml:"Amt>InstdAmt"`
+	CreditorAccount Pain001Account `xml:"CdtrAcct"`
+	RemittanceInfo  string         `xml:"RmtInf>Ustrd"`
+}
+
+type Pain001Amount struct {
+	Currency string `xml:"Ccy,attr"`
+	Value    string `xml:",chardata"`
+}
+
+// Pain001Account identifies an account, which for accounts of the bank is by its ID as a generic identification.
+// Accounts identified by IBAN are read, but cannot be transferred from or to.
+type Pain001Account struct {
+	Iban string `xml:"Id>IBAN"`
+	Id   string `xml:"Id>Othr>Id"`
+}
+
+// ParsePain001 reads a pain.001 message, checking that it is one and that it has a message ID.
+func ParsePain001(body io.Reader) (*Pain001Document, *errs.AppError) {
+	var document Pain001Document
+	if err := xml.NewDecoder(body).Decode(&document); err != nil {
+		logger.Error("Error while decoding pain.001 message: " + err.Error())
+		return nil, errs.NewAppError(http.StatusBadRequest, "Please check that the body is a valid pain.001 XML document.")
+	}
+	if !strings.HasPrefix(document.XMLName.Space, pain001NamespacePrefix) {
+		logger.Error("Unknown namespace of payment initiation message: " + document.XMLName.Space)
+		return nil, errs.NewValidationError("Document should be a pain.001 customer credit transfer initiation.")
+	}
+	if document.GroupHeader.MessageId == "" {
+		return nil, errs.NewValidationError("Group header should have a message ID.")
+	}
+	return &document, nil
+}
+
+// MessageNameId returns the name of the message with its version, e.g. pain.001.001.03.
+func (d Pain001Document) MessageNameId() string {
+	return d.XMLName.Space[strings.LastIndex(d.XMLName.Space, ":")+1:]
+}
+
+// CountAndSum returns the number of credit transfers in the message and the sum of their amounts, or false if an
+// amount is not a number.
+func (d Pain001Document) CountAndSum() (int, float64, bool) {
+	count, sum := 0, 0.0
+	for _, p := range d.PaymentInfos {
+		n, s, ok := p.CountAndSum()
+		if !ok {
+			return 0, 0, false
+		}
+		count += n
+		sum += s
+	}
+	return count, sum, true
+}
+
+// CountAndSum returns the number of credit transfers in the payment info and the sum of their amounts, or false if an
+// amount is not a number.
+func (p Pain001PaymentInfo) CountAndSum() (int, float64, bool) {
+	sum := 0.0
+	for _, t := range p.Transactions {
+		amount, err := t.Amount.Float()
+		if err != nil {
+			return 0, 0, false
+		}
+		sum += amount
+	}
+	return len(p.Transactions), sum, true
+}
+
+func (a Pain001Amount) Float() (float64, error) {
+	return strconv.ParseFloat(strings.TrimSpace(a.Value), 64)
+}
@@ -0,0 +1,90 @@
+package dto
+
+import (
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParsePain001_reads_credit_transfers(t *testing.T) {
+	//Arrange
+	body := `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:pain.001.001.09"><CstmrCdtTrfInitn>
+<GrpHdr><MsgId>MSG-1</MsgId><NbOfTxs>2</NbOfTxs><CtrlSum>150.5</CtrlSum></GrpHdr>
+<PmtInf><PmtInfId>PMT-1</PmtInfId><DbtrAcct><Id><Othr><Id>1977</Id></Othr></Id></DbtrAcct>
+<CdtTrfTxInf><PmtId><InstrId>I-1</InstrId><EndToEndId>E-1</EndToEndId></PmtId><Amt><InstdAmt Ccy="USD">100</InstdAmt></Amt>
+<CdtrAcct><Id><Othr><Id>2001</Id></Othr></Id></CdtrAcct></CdtTrfTxInf>
+<CdtTrfTxInf><PmtId><EndToEndId>E-2</EndToEndId></PmtId><Amt><InstdAmt Ccy="USD"> 50.50 </InstdAmt></Amt>
+<CdtrAcct><Id><IBAN>NO9386011117947</IBAN></Id></CdtrAcct></CdtTrfTxInf>
+</PmtInf></CstmrCdtTrfInitn></Document>`
+
+	//Act
+	document, err := ParsePain001(strings.NewReader(body))
+
+	//Assert
+	if err != nil {
+		t.Fatalf("expected no error but got error: %s", err.Message)
+	}
+	count, sum, ok := document.CountAndSum()
+	if document.MessageNameId() != "pain.001.001.09" || count != 2 || sum != 150.5 || !ok {
+		t.Errorf("expected pain.001.001.09 message of 2 transfers of 150.5 but got %s of %d of %v",
+			document.MessageNameId(), count, sum)
+	}
+	transfers := document.PaymentInfos[0].Transactions
+	if document.PaymentInfos[0].DebtorAccount.Id != "1977" || transfers[0].InstructionId != "I-1" ||
+		transfers[0].CreditorAccount.Id != "2001" || transfers[1].CreditorAccount.Iban != "NO9386011117947" ||
+		transfers[1].CreditorAccount.Id != "" {
+		t.Errorf("expected credit transfers from account 1977 but got %+v", document.PaymentInfos)
+	}
+}
+
+func TestParsePain001_returns_error_when_body_not_xml(t *testing.T) {
+	//Act
+	_, err := ParsePain001(strings.NewReader(`{"account_id": "1977"}`))
+
+	//Assert
+	if err == nil || err.Code != http.StatusBadRequest {
+		t.Errorf("expected bad request error but got %v", err)
+	}
+}
+
+func TestPain002Document_ToXml_renders_document_valid_against_schema(t *testing.T) {
+	//Arrange
+	xmllint, err := exec.LookPath("xmllint")
+	if err != nil {
+		t.Skip("xmllint is needed to validate pain.002 documents")
+	}
+	document := NewPain002Document(Pain002Report{
+		GroupHeader: Pain002GroupHeader{MessageId: "PSR-20230102120000-2", CreationTime: "2023-01-02T12:00:00"},
+		OriginalGroup: Pain002OriginalGroup{MessageId: "MSG-1", MessageNameId: "pain.001.001.03",
+			NumberOfTransactions: "2", ControlSum: "150.50", Status: GroupStatus(1, 0, 2)},
+		PaymentInfos: []Pain002PaymentInfoStatus{{
+			PaymentInfoId: "PMT-1",
+			Status:        GroupStatus(1, 0, 2),
+			Transactions: []Pain002TransactionStatus{
+				{StatusId: "51", EndToEndId: "E-1", Status: PaymentStatusAccepted},
+				{InstructionId: "I-2", EndToEndId: "E-2", Status: PaymentStatusRejected, Reasons: []Pain002StatusReason{
+					NewPain002StatusReason(PaymentReasonInsufficientFunds, strings.Repeat("Insufficient ", 10))}},
+			},
+		}},
+	})
+
+	//Act
+	content, err := document.ToXml()
+
+	//Assert
+	if err != nil {
+		t.Fatalf("expected no error but got %s", err)
+	}
+	file := filepath.Join(t.TempDir(), "report.xml")
+	if err = os.WriteFile(file, content, 0600); err != nil {
+		t.Fatal(err)
+	}
+	output, err := exec.Command(xmllint, "--noout", "--schema", "testdata/pain.002.001.03.xsd", file).CombinedOutput()
+	if err != nil {
+		t.Errorf("expected document valid against schema but got %s\n%s", output, content)
+	}
+}
@@ -0,0 +1,112 @@
+package dto
+
+import (
+	"encoding/xml"
+)
+
+const pain002Namespace = "urn:iso:std:iso:20022:tech:xsd:pain.002.001.03"
+
+// ISO 20022 payment statuses used in pain.002 status reports
+const PaymentStatusAccepted = "ACSC" //accepted, settlement completed
+const PaymentStatusRejected = "RJCT"
+const PaymentStatusPartiallyAccepted = "PART"
+const PaymentStatusPending = "PDNG" //pending, e.g. held for review
+
+// ISO 20022 status reason codes used in pain.002 status reports
+const PaymentReasonIncorrectAccount = "AC01"
+const PaymentReasonInvalidCreditorAccount = "AC03"
+const PaymentReasonNotAllowedAmount = "AM02"
+const PaymentReasonNotAllowedCurrency = "AM03"
+const PaymentReasonInsufficientFunds = "AM04"
+const PaymentReasonInvalidNumberOfTransactions = "AM18"
+const PaymentReasonInvalidControlSum = "AM10"
+const PaymentReasonTransactionForbidden = "AG01"
+const PaymentReasonNarrative = "NARR"
+
+// pain002MaxInfoLength is the length of the longest additional information allowed in a status reason.
+const pain002MaxInfoLength = 105
+
+// Pain002Document is an ISO 20022 customer payment status report (pain.002.001.03), reporting the status of a
+// pain.001 message, each of its payment infos and each of their credit transfers.
+type Pain002Document struct {
+	XMLName   xml.Name      `xml:"Document"`
+	Namespace string        `xml:"xmlns,attr"`
+	Report    Pain002Report `xml:"CstmrPmtStsRpt"`
+}
+
+type Pain002Report struct {
+	GroupHeader   Pain002GroupHeader         `xml:"GrpHdr"`
+	OriginalGroup Pain002OriginalGroup       `xml:"OrgnlGrpInfAndSts"`
+	PaymentInfos  []Pain002PaymentInfoStatus `xml:"OrgnlPmtInfAndSts"`
+}
+
+type Pain002GroupHeader struct {
+	MessageId    string `xml:"MsgId"`
+	CreationTime string `xml:"CreDtTm"`
+}
+
+type Pain002OriginalGroup struct {
+	MessageId            string                `xml:"OrgnlMsgId"`
+	MessageNameId        string                `xml:"OrgnlMsgNmId"`
+	NumberOfTransactions string                `xml:"OrgnlNbOfTxs,omitempty"`
+	ControlSum           string                `xml:"OrgnlCtrlSum,omitempty"`
+	Status               string                `xml:"GrpSts"`
+	Reasons              []Pain002StatusReason `xml:"StsRsnInf,omitempty"`
+}
+
+type Pain002PaymentInfoStatus struct {
+	PaymentInfoId string                     `xml:"OrgnlPmtInfId"`
+	Status        string                     `xml:"PmtInfSts"`
+	Reasons       []Pain002StatusReason      `xml:"StsRsnInf,omitempty"`
+	Transactions  []Pain002TransactionStatus `xml:"TxInfAndSts"`
+}
+
+type Pain002TransactionStatus struct {
+	StatusId      string                `xml:"StsId,omitempty"` //the ID of the transfer's debit transaction, if accepted
+	InstructionId string                `xml:"OrgnlInstrId,omitempty"`
+	EndToEndId    string                `xml:"OrgnlEndToEndId,omitempty"`
+	Status        string                `xml:"TxSts"`
+	Reasons       []Pain002StatusReason `xml:"StsRsnInf,omitempty"`
+}
+
+type Pain002StatusReason struct {
+	Code           string `xml:"Rsn>Cd"`
+	AdditionalInfo string `xml:"AddtlInf,omitempty"`
+}
+
+// NewPain002StatusReason makes a status reason with the given code and information, cut to the length allowed.
+func NewPain002StatusReason(code string, info string) Pain002StatusReason {
+	if len(info) > pain002MaxInfoLength {
+		info = info[:pain002MaxInfoLength]
+	}
+	return Pain002StatusReason{Code: code, AdditionalInfo: info}
+}
+
+func NewPain002Document(report Pain002Report) Pain002Document {
+	return Pain002Document{Namespace: pain002Namespace, Report: report}
+}
+
+// ToXml renders the status report as an XML document.
+func (d Pain002Document) ToXml() ([]byte, error) {
+	content, err := xml.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), content...), nil
+}
+
+// GroupStatus works out the status of a set of transfers from the numbers accepted and pending out of the total:
+// accepted if all were accepted, partially accepted if only some were, pending if none were but some are pending, and
+// rejected otherwise.
+func GroupStatus(accepted int, pending int, total int) string {
+	if accepted == total {
+		return PaymentStatusAccepted
+	}
+	if accepted > 0 {
+		return PaymentStatusPartiallyAccepted
+	}
+	if pending > 0 {
+		return PaymentStatusPending
+	}
+	return PaymentStatusRejected
+}
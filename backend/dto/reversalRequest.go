@@ -0,0 +1,42 @@
+package dto
+
+import (
+	"fmt"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/formValidator"
+	"github.com/aliciatay-zls/banking-lib/logger"
+)
+
+const ReversalReasonDuplicate = "duplicate"
+const ReversalReasonIncorrectAmount = "incorrect_amount"
+const ReversalReasonWrongAccount = "wrong_account"
+const ReversalReasonFraud = "fraud"
+const ReversalReasonRefund = "refund"
+const ReversalReasonOther = "other"
+
+type ReversalRequest struct {
+	AccountId     string `json:"-" validate:"required,max=11,number"`
+	CustomerId    string `json:"-" validate:"required,max=11,number"`
+	TransactionId string `json:"-" validate:"required,max=11,number"`
+	ReasonCode    string `json:"reason_code" validate:"required,oneof=duplicate incorrect_amount wrong_account fraud refund other"`
+	Note          string `json:"note" validate:"required_if=ReasonCode other,max=255"`
+}
+
+func (r ReversalRequest) Validate() *errs.AppError {
+	errMsg := map[string]string{
+		"AccountId":     "Account ID must be present and a number.",
+		"CustomerId":    "Customer ID must be present and a number.",
+		"TransactionId": "Transaction ID must be present and a number.",
+		"ReasonCode": fmt.Sprintf("Reason code should be one of %s, %s, %s, %s, %s or %s.",
+			ReversalReasonDuplicate, ReversalReasonIncorrectAmount, ReversalReasonWrongAccount,
+			ReversalReasonFraud, ReversalReasonRefund, ReversalReasonOther),
+		"Note": fmt.Sprintf("Note must be present for reason code %s and at most 255 characters.", ReversalReasonOther),
+	}
+	if errsArr := formValidator.Struct(r); errsArr != nil {
+		logger.Error(fmt.Sprintf("Reversal request is invalid (%s) (%s)",
+			errsArr[0].Error(), errsArr[0].ActualTag()))
+		return errs.NewValidationError(errMsg[errsArr[0].Field()])
+	}
+
+	return nil
+}
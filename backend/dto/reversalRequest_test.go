@@ -0,0 +1,47 @@
+package dto
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestReversalRequest_Validate_returns_error_when_request_invalid(t *testing.T) {
+	//Arrange
+	tests := []struct {
+		name    string
+		request ReversalRequest
+		message string
+	}{
+		{"unknown reason", ReversalRequest{AccountId: dummyAccountId, CustomerId: dummyCustomerId, TransactionId: "7791", ReasonCode: "mistake"},
+			"Reason code should be one of duplicate, incorrect_amount, wrong_account, fraud, refund or other."},
+		{"other without note", ReversalRequest{AccountId: dummyAccountId, CustomerId: dummyCustomerId, TransactionId: "7791", ReasonCode: ReversalReasonOther},
+			"Note must be present for reason code other and at most 255 characters."},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Act
+			actualErr := tc.request.Validate()
+
+			//Assert
+			if actualErr == nil {
+				t.Fatal("expected error but got none while testing invalid reversal request")
+			}
+			if actualErr.Message != tc.message {
+				t.Errorf("expected message: \"%s\", actual message: \"%s\"", tc.message, actualErr.Message)
+			}
+			if actualErr.Code != http.StatusUnprocessableEntity {
+				t.Errorf("expected status code: \"%d\", actual status code: \"%d\"", http.StatusUnprocessableEntity, actualErr.Code)
+			}
+		})
+	}
+}
+
+func TestReversalRequest_Validate_returns_nil_when_request_valid(t *testing.T) {
+	request := ReversalRequest{AccountId: dummyAccountId, CustomerId: dummyCustomerId, TransactionId: "7791",
+		ReasonCode: ReversalReasonOther, Note: "Deposit keyed in by teller on wrong day"}
+
+	if err := request.Validate(); err != nil {
+		t.Errorf("expected no error but got error while testing valid reversal request: %s", err.Message)
+	}
+}
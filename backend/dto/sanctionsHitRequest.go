@@ -0,0 +1,76 @@
+package dto
+
+import (
+	"fmt"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/formValidator"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"strconv"
+)
+
+const SanctionsHitKindCustomer = "customer" //a customer's details potentially match the watchlist
+const SanctionsHitKindTransfer = "transfer" //a party to an outbound transfer potentially matches the watchlist
+
+const SanctionsHitStatusPending = "pending"
+const SanctionsHitStatusCleared = "cleared"     //the hit was a false positive
+const SanctionsHitStatusConfirmed = "confirmed" //the customer is the person on the watchlist
+
+const SanctionsHitsDefaultLimit = 100
+const SanctionsHitsMaxLimit = 500
+
+// SanctionsHitsRequest holds the query parameters of a request for the sanctions review queue.
+type SanctionsHitsRequest struct {
+	Status  string `validate:"omitempty,oneof=pending cleared confirmed"`
+	AfterId string `validate:"omitempty,max=11,number"`
+	Limit   string `validate:"omitempty,max=3,number"`
+}
+
+func (r SanctionsHitsRequest) Validate() *errs.AppError {
+	errMsg := map[string]string{
+		"Status": fmt.Sprintf("Status should be %s, %s or %s.",
+			SanctionsHitStatusPending, SanctionsHitStatusCleared, SanctionsHitStatusConfirmed),
+		"AfterId": "After ID must be a number.",
+		"Limit":   fmt.Sprintf("Limit must be a number between 1 and %d.", SanctionsHitsMaxLimit),
+	}
+	if errsArr := formValidator.Struct(r); errsArr != nil {
+		logger.Error(fmt.Sprintf("Sanctions hits request is invalid (%s) (%s)",
+			errsArr[0].Error(), errsArr[0].ActualTag()))
+		return errs.NewValidationError(errMsg[errsArr[0].Field()])
+	}
+	if limit := r.LimitOrDefault(); limit < 1 || limit > SanctionsHitsMaxLimit {
+		return errs.NewValidationError(errMsg["Limit"])
+	}
+
+	return nil
+}
+
+// LimitOrDefault returns the maximum number of hits requested, or the default if none was given.
+func (r SanctionsHitsRequest) LimitOrDefault() int {
+	if r.Limit == "" {
+		return SanctionsHitsDefaultLimit
+	}
+	limit, _ := strconv.Atoi(r.Limit) //checked to be a number by Validate
+	return limit
+}
+
+// ResolveSanctionsHitRequest closes a pending sanctions hit with the outcome of the admin's review of it.
+type ResolveSanctionsHitRequest struct {
+	HitId      string `json:"-" validate:"required,max=11,number"`
+	Resolution string `json:"resolution" validate:"required,oneof=cleared confirmed"`
+	Note       string `json:"note" validate:"max=255"`
+}
+
+func (r ResolveSanctionsHitRequest) Validate() *errs.AppError {
+	errMsg := map[string]string{
+		"HitId":      "Hit ID must be present and a number.",
+		"Resolution": fmt.Sprintf("Resolution should be %s or %s.", SanctionsHitStatusCleared, SanctionsHitStatusConfirmed),
+		"Note":       "Note must be at most 255 characters.",
+	}
+	if errsArr := formValidator.Struct(r); errsArr != nil {
+		logger.Error(fmt.Sprintf("Resolve sanctions hit request is invalid (%s) (%s)",
+			errsArr[0].Error(), errsArr[0].ActualTag()))
+		return errs.NewValidationError(errMsg[errsArr[0].Field()])
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package dto
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestSanctionsHitsRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		request   SanctionsHitsRequest
+		expectErr bool
+	}{
+		{"no parameters", SanctionsHitsRequest{}, false},
+		{"all parameters", SanctionsHitsRequest{Status: SanctionsHitStatusConfirmed, AfterId: "10", Limit: "500"}, false},
+		{"unknown status", SanctionsHitsRequest{Status: "open"}, true},
+		{"limit too high", SanctionsHitsRequest{Limit: "501"}, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Act
+			err := tc.request.Validate()
+
+			//Assert
+			if !tc.expectErr && err != nil {
+				t.Error("Expected no error but got error: " + err.Message)
+			}
+			if tc.expectErr && (err == nil || err.Code != http.StatusUnprocessableEntity) {
+				t.Errorf("Expected validation error but got %v", err)
+			}
+		})
+	}
+}
+
+func TestResolveSanctionsHitRequest_Validate_returns_error_when_resolution_pending(t *testing.T) {
+	//Arrange
+	request := ResolveSanctionsHitRequest{HitId: "4", Resolution: SanctionsHitStatusPending}
+
+	//Act
+	err := request.Validate()
+
+	//Assert
+	if err == nil || err.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected validation error but got %v", err)
+	}
+}
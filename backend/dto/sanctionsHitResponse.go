@@ -0,0 +1,38 @@
+package dto
+
+type SanctionsHitResponse struct {
+	HitId                string               `json:"hit_id"`
+	CustomerId           string               `json:"customer_id"`
+	Kind                 string               `json:"kind"`
+	AccountId            string               `json:"account_id,omitempty"`             //only for transfers
+	DestinationAccountId string               `json:"destination_account_id,omitempty"` //only for transfers
+	Amount               float64              `json:"amount,omitempty"`                 //only for transfers
+	TransactionId        string               `json:"transaction_id,omitempty"`         //set once a held transfer is cleared and made
+	Matches              []SanctionsMatchInfo `json:"matches"`
+	Status               string               `json:"status"`
+	Note                 string               `json:"note,omitempty"`
+	CreationDate         string               `json:"creation_date"`
+	ResolutionDate       string               `json:"resolution_date,omitempty"`
+}
+
+// SanctionsMatchInfo is a watchlist entry that a customer potentially is.
+type SanctionsMatchInfo struct {
+	CustomerId  string  `json:"customer_id"`
+	ListId      string  `json:"list_id,omitempty"`
+	Name        string  `json:"name"`
+	DateOfBirth string  `json:"date_of_birth,omitempty"`
+	Country     string  `json:"country,omitempty"`
+	Score       float64 `json:"score"`
+}
+
+type SanctionsHitsResponse struct {
+	Hits        []SanctionsHitResponse `json:"hits"`
+	NextAfterId string                 `json:"next_after_id,omitempty"` //after_id of the next page, if there may be one
+}
+
+// CustomerScreeningResponse is the outcome of screening a customer against the watchlist.
+type CustomerScreeningResponse struct {
+	CustomerId string               `json:"customer_id"`
+	Matches    []SanctionsMatchInfo `json:"matches"`
+	OnHold     bool                 `json:"on_hold"`
+}
@@ -0,0 +1,133 @@
+package dto
+
+import (
+	"fmt"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/formValidator"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"strconv"
+)
+
+const SearchDefaultLimit = 50
+const SearchMaxLimit = 500
+
+const SearchFormatJson = "json"
+const SearchFormatCsv = "csv"
+
+// CustomerSearchRequest holds the query parameters of an admin's search for customers.
+type CustomerSearchRequest struct {
+	Name    string `validate:"max=100"`
+	Email   string `validate:"max=100"`
+	Country string `validate:"max=100"`
+	Status  string `validate:"omitempty,oneof=active inactive"`
+	Sort    string `validate:"omitempty,oneof=customer_id -customer_id name -name email -email country -country"`
+	Cursor  string `validate:"max=500"`
+	Limit   string `validate:"omitempty,max=3,number"`
+	Format  string `validate:"omitempty,oneof=json csv"`
+}
+
+func (r CustomerSearchRequest) Validate() *errs.AppError {
+	errMsg := map[string]string{
+		"Name":    "Name should be at most 100 characters long.",
+		"Email":   "Email should be at most 100 characters long.",
+		"Country": "Country should be at most 100 characters long.",
+		"Status":  fmt.Sprintf("Status should be %s or %s.", CustomerStatusActive, CustomerStatusInactive),
+		"Sort":    "Sort should be customer_id, name, email or country, prefixed with - for descending order.",
+		"Cursor":  "Cursor is invalid or was made for another sort order.",
+		"Limit":   fmt.Sprintf("Limit must be a number between 1 and %d.", SearchMaxLimit),
+		"Format":  "Format should be json or csv.",
+	}
+	if errsArr := formValidator.Struct(r); errsArr != nil {
+		logger.Error(fmt.Sprintf("Customer search request is invalid (%s) (%s)",
+			errsArr[0].Error(), errsArr[0].ActualTag()))
+		return errs.NewValidationError(errMsg[errsArr[0].Field()])
+	}
+	if limit := searchLimit(r.Limit); limit < 1 || limit > SearchMaxLimit {
+		return errs.NewValidationError(errMsg["Limit"])
+	}
+
+	return nil
+}
+
+// LimitOrDefault returns the maximum number of customers requested, or the default if none was given.
+func (r CustomerSearchRequest) LimitOrDefault() int {
+	return searchLimit(r.Limit)
+}
+
+// AccountSearchRequest holds the query parameters of an admin's search for accounts. Ranges are inclusive.
+type AccountSearchRequest struct {
+	CustomerId  string `validate:"omitempty,max=11,number"`
+	AccountType string `validate:"omitempty,oneof=saving checking"`
+	Currency    string `validate:"omitempty,len=3,alpha,uppercase"`
+	Status      string `validate:"omitempty,oneof=active inactive"`
+	MinBalance  string `validate:"omitempty,max=20,numeric"`
+	MaxBalance  string `validate:"omitempty,max=20,numeric"`
+	OpenedFrom  string `validate:"omitempty,datetime=2006-01-02"`
+	OpenedTo    string `validate:"omitempty,datetime=2006-01-02"`
+	Sort        string `validate:"omitempty,oneof=account_id -account_id amount -amount opening_date -opening_date customer_id -customer_id"`
+	Cursor      string `validate:"max=500"`
+	Limit       string `validate:"omitempty,max=3,number"`
+	Format      string `validate:"omitempty,oneof=json csv"`
+}
+
+func (r AccountSearchRequest) Validate() *errs.AppError {
+	errMsg := map[string]string{
+		"CustomerId":  "Customer ID must be a number.",
+		"AccountType": fmt.Sprintf("Account type should be %s or %s.", AccountTypeSaving, AccountTypeChecking),
+		"Currency":    "Currency should be a 3-letter ISO 4217 code such as USD.",
+		"Status":      "Status should be active or inactive.",
+		"MinBalance":  "Minimum balance must be a number.",
+		"MaxBalance":  "Maximum balance must be a number.",
+		"OpenedFrom":  "Opened from must be a date in the format YYYY-MM-DD.",
+		"OpenedTo":    "Opened to must be a date in the format YYYY-MM-DD.",
+		"Sort":        "Sort should be account_id, amount, opening_date or customer_id, prefixed with - for descending order.",
+		"Cursor":      "Cursor is invalid or was made for another sort order.",
+		"Limit":       fmt.Sprintf("Limit must be a number between 1 and %d.", SearchMaxLimit),
+		"Format":      "Format should be json or csv.",
+	}
+	if errsArr := formValidator.Struct(r); errsArr != nil {
+		logger.Error(fmt.Sprintf("Account search request is invalid (%s) (%s)",
+			errsArr[0].Error(), errsArr[0].ActualTag()))
+		return errs.NewValidationError(errMsg[errsArr[0].Field()])
+	}
+	if limit := searchLimit(r.Limit); limit < 1 || limit > SearchMaxLimit {
+		return errs.NewValidationError(errMsg["Limit"])
+	}
+	if min, max := r.MinMaxBalance(); min != nil && max != nil && *min > *max {
+		return errs.NewValidationError("Minimum balance should not be more than maximum balance.")
+	}
+	if r.OpenedFrom != "" && r.OpenedTo != "" && r.OpenedFrom > r.OpenedTo {
+		return errs.NewValidationError("Opened from should not be after opened to.")
+	}
+
+	return nil
+}
+
+// LimitOrDefault returns the maximum number of accounts requested, or the default if none was given.
+func (r AccountSearchRequest) LimitOrDefault() int {
+	return searchLimit(r.Limit)
+}
+
+// MinMaxBalance returns the balance range requested, nil meaning no bound.
+func (r AccountSearchRequest) MinMaxBalance() (*float64, *float64) {
+	return parseBound(r.MinBalance), parseBound(r.MaxBalance)
+}
+
+func searchLimit(limit string) int {
+	if limit == "" {
+		return SearchDefaultLimit
+	}
+	n, _ := strconv.Atoi(limit) //checked to be a number by Validate
+	return n
+}
+
+func parseBound(bound string) *float64 {
+	if bound == "" {
+		return nil
+	}
+	n, err := strconv.ParseFloat(bound, 64)
+	if err != nil {
+		return nil
+	}
+	return &n
+}
@@ -0,0 +1,90 @@
+package dto
+
+import (
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"net/http"
+	"testing"
+)
+
+func TestCustomerSearchRequest_Validate_returns_nil_when_request_valid(t *testing.T) {
+	//Arrange
+	request := CustomerSearchRequest{Name: "ste", Email: "@somemail.com", Country: "India", Status: CustomerStatusActive,
+		Sort: "-name", Cursor: "eyJzIjoiLW5hbWUifQ", Limit: "500", Format: SearchFormatCsv}
+
+	//Act
+	err := request.Validate()
+
+	//Assert
+	if err != nil {
+		t.Errorf("expected no error but got error: %s", err.Message)
+	}
+	if (CustomerSearchRequest{}).LimitOrDefault() != SearchDefaultLimit {
+		t.Errorf("expected default limit %d", SearchDefaultLimit)
+	}
+}
+
+func TestAccountSearchRequest_Validate_returns_nil_when_request_valid(t *testing.T) {
+	//Arrange
+	request := AccountSearchRequest{CustomerId: dummyCustomerId, AccountType: AccountTypeChecking, Currency: "USD",
+		Status: CustomerStatusInactive, MinBalance: "-500", MaxBalance: "1000.50", OpenedFrom: "2020-08-01",
+		OpenedTo: "2020-08-31", Sort: "opening_date", Limit: "1", Format: SearchFormatJson}
+
+	//Act
+	err := request.Validate()
+	min, max := request.MinMaxBalance()
+
+	//Assert
+	if err != nil {
+		t.Errorf("expected no error but got error: %s", err.Message)
+	}
+	if min == nil || max == nil || *min != -500 || *max != 1000.50 {
+		t.Errorf("expected balance range -500 to 1000.50 but got %v to %v", min, max)
+	}
+}
+
+func TestSearchRequest_Validate_returns_error_when_field_invalid(t *testing.T) {
+	tests := []struct {
+		name               string
+		request            interface{ Validate() *errs.AppError }
+		expectedErrMessage string
+	}{
+		{"customer status unknown", CustomerSearchRequest{Status: "closed"}, "Status should be active or inactive."},
+		{"customer sort unknown", CustomerSearchRequest{Sort: "name; DROP TABLE customers"}, "Sort should be customer_id, name, email or country, prefixed with - for descending order."},
+		{"customer limit too high", CustomerSearchRequest{Limit: "501"}, "Limit must be a number between 1 and 500."},
+		{"customer format unknown", CustomerSearchRequest{Format: "xml"}, "Format should be json or csv."},
+		{"account currency lowercase", AccountSearchRequest{Currency: "usd"}, "Currency should be a 3-letter ISO 4217 code such as USD."},
+		{"account balance not a number", AccountSearchRequest{MinBalance: "1e3"}, "Minimum balance must be a number."},
+		{"account balance range reversed", AccountSearchRequest{MinBalance: "100", MaxBalance: "10"}, "Minimum balance should not be more than maximum balance."},
+		{"account date in other format", AccountSearchRequest{OpenedTo: "31/08/2020"}, "Opened to must be a date in the format YYYY-MM-DD."},
+		{"account date range reversed", AccountSearchRequest{OpenedFrom: "2020-09-01", OpenedTo: "2020-08-01"}, "Opened from should not be after opened to."},
+		{"account limit zero", AccountSearchRequest{Limit: "0"}, "Limit must be a number between 1 and 500."},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Act
+			err := tc.request.Validate()
+
+			//Assert
+			if err == nil {
+				t.Fatal("expected error but got none")
+			}
+			if err.Code != http.StatusUnprocessableEntity || err.Message != tc.expectedErrMessage {
+				t.Errorf("Expected %d \"%s\" but got %d \"%s\"", http.StatusUnprocessableEntity, tc.expectedErrMessage, err.Code, err.Message)
+			}
+		})
+	}
+}
+
+func TestCustomerSearchResponse_CsvRecords_escapes_formulas(t *testing.T) {
+	//Arrange
+	response := CustomerSearchResponse{Customers: []CustomerResponse{{Id: "2000", Name: "=HYPERLINK(\"x\")", Email: "-a@b.com", Country: "India"}}}
+
+	//Act
+	records := response.CsvRecords()
+
+	//Assert
+	if len(records) != 2 || records[1][1] != "'=HYPERLINK(\"x\")" || records[1][3] != "'-a@b.com" || records[1][4] != "India" {
+		t.Errorf("Expected header and escaped customer but got %v", records)
+	}
+}
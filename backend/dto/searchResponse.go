@@ -0,0 +1,63 @@
+package dto
+
+import (
+	"strconv"
+	"strings"
+)
+
+type CustomerSearchResponse struct {
+	Customers  []CustomerResponse `json:"customers"`
+	NextCursor string             `json:"next_cursor,omitempty"` //cursor of the next page, if there is one
+}
+
+// AccountSearchResult is an account as shown to admins, together with its owner and status.
+type AccountSearchResult struct {
+	AccountId        string  `json:"account_id"`
+	CustomerId       string  `json:"customer_id"`
+	OpeningDate      string  `json:"opening_date"`
+	AccountType      string  `json:"account_type"`
+	Currency         string  `json:"currency"`
+	Amount           float64 `json:"amount"`
+	AvailableBalance float64 `json:"available_balance"`
+	OverdraftLimit   float64 `json:"overdraft_limit"`
+	Status           string  `json:"status"`
+}
+
+type AccountSearchResponse struct {
+	Accounts   []AccountSearchResult `json:"accounts"`
+	NextCursor string                `json:"next_cursor,omitempty"` //cursor of the next page, if there is one
+}
+
+// CsvRecords returns the customers as CSV records, starting with a header.
+func (r CustomerSearchResponse) CsvRecords() [][]string {
+	records := [][]string{{"customer_id", "full_name", "date_of_birth", "email", "country", "zipcode", "status"}}
+	for _, c := range r.Customers {
+		records = append(records, []string{c.Id, csvText(c.Name), c.DateOfBirth, csvText(c.Email), csvText(c.Country),
+			csvText(c.Zipcode), c.Status})
+	}
+	return records
+}
+
+// CsvRecords returns the accounts as CSV records, starting with a header.
+func (r AccountSearchResponse) CsvRecords() [][]string {
+	records := [][]string{{"account_id", "customer_id", "opening_date", "account_type", "currency", "amount",
+		"available_balance", "overdraft_limit", "status"}}
+	for _, a := range r.Accounts {
+		records = append(records, []string{a.AccountId, a.CustomerId, a.OpeningDate, a.AccountType, a.Currency,
+			csvAmount(a.Amount), csvAmount(a.AvailableBalance), csvAmount(a.OverdraftLimit), a.Status})
+	}
+	return records
+}
+
+// csvText stops text that customers entered from being run as a formula when the CSV file is opened in a
+// spreadsheet, by prefixing it with an apostrophe if it starts like a formula.
+func csvText(s string) string {
+	if s != "" && strings.ContainsAny(s[:1], "=+-@\t\r") {
+		return "'" + s
+	}
+	return s
+}
+
+func csvAmount(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', 2, 64)
+}
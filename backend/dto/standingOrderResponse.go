@@ -0,0 +1,25 @@
+package dto
+
+type StandingOrderResponse struct {
+	StandingOrderId      string  `json:"standing_order_id"`
+	AccountId            string  `json:"account_id"`
+	DestinationAccountId string  `json:"destination_account_id"`
+	Amount               float64 `json:"amount"`
+	ScheduleType         string  `json:"schedule_type"`
+	DayOfMonth           int     `json:"day_of_month,omitempty"`
+	CronExpression       string  `json:"cron_expression,omitempty"`
+	EndDate              string  `json:"end_date,omitempty"`
+	MaxOccurrences       int     `json:"max_occurrences,omitempty"`
+	Occurrences          int     `json:"occurrences"`
+	NextRunDate          string  `json:"next_run_date,omitempty"`
+	Status               string  `json:"status"`
+}
+
+type StandingOrderExecutionResponse struct {
+	ExecutionId   string `json:"execution_id"`
+	ScheduledDate string `json:"scheduled_date"`
+	ExecutionDate string `json:"execution_date"`
+	Status        string `json:"status"`
+	TransactionId string `json:"transaction_id,omitempty"`
+	Message       string `json:"message,omitempty"`
+}
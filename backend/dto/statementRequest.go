@@ -0,0 +1,102 @@
+package dto
+
+import (
+	"fmt"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/formValidator"
+	"github.com/aliciatay-zls/banking-lib/logger"
+)
+
+const StatementFormatCamt053 = "camt053"
+const StatementFormatMt940 = "mt940"
+
+const ExportFormatOfx = "ofx"
+const ExportFormatQif = "qif"
+
+// StatementRequest asks for the statement of an account for the days from From to To, both included. If no period is
+// given, the statement is of the current month up to today, and if only To is given, of To's month up to To.
+type StatementRequest struct {
+	CustomerId string `validate:"required,max=11,number"`
+	AccountId  string `validate:"required,max=11,number"`
+	Format     string `validate:"required,oneof=camt053 mt940"`
+	From       string `validate:"omitempty,datetime=2006-01-02"`
+	To         string `validate:"omitempty,datetime=2006-01-02"`
+}
+
+func (r StatementRequest) Validate() *errs.AppError {
+	errMsg := map[string]string{
+		"CustomerId": "Customer ID must be a number.",
+		"AccountId":  "Account ID must be a number.",
+		"Format":     fmt.Sprintf("Format should be %s or %s.", StatementFormatCamt053, StatementFormatMt940),
+		"From":       "From must be a date in the format YYYY-MM-DD.",
+		"To":         "To must be a date in the format YYYY-MM-DD.",
+	}
+	if errsArr := formValidator.Struct(r); errsArr != nil {
+		logger.Error(fmt.Sprintf("Statement request is invalid (%s) (%s)",
+			errsArr[0].Error(), errsArr[0].ActualTag()))
+		return errs.NewValidationError(errMsg[errsArr[0].Field()])
+	}
+	if r.From != "" && r.To != "" && r.From > r.To {
+		return errs.NewValidationError("From should not be after to.")
+	}
+
+	return nil
+}
+
+// Period returns the first and last days of the statement's period, filling in the ones not given using the given
+// date of today as YYYY-MM-DD.
+func (r StatementRequest) Period(today string) (string, string) {
+	return statementPeriod(r.From, r.To, today)
+}
+
+// TransactionExportRequest asks for the transactions of an account made on the days from From to To, both included,
+// as a file for personal finance tools. Missing days are filled in the same way as for statements.
+type TransactionExportRequest struct {
+	CustomerId string `validate:"required,max=11,number"`
+	AccountId  string `validate:"required,max=11,number"`
+	Format     string `validate:"required,oneof=ofx qif"`
+	From       string `validate:"omitempty,datetime=2006-01-02"`
+	To         string `validate:"omitempty,datetime=2006-01-02"`
+}
+
+func (r TransactionExportRequest) Validate() *errs.AppError {
+	errMsg := map[string]string{
+		"CustomerId": "Customer ID must be a number.",
+		"AccountId":  "Account ID must be a number.",
+		"Format":     fmt.Sprintf("Format should be %s or %s.", ExportFormatOfx, ExportFormatQif),
+		"From":       "From must be a date in the format YYYY-MM-DD.",
+		"To":         "To must be a date in the format YYYY-MM-DD.",
+	}
+	if errsArr := formValidator.Struct(r); errsArr != nil {
+		logger.Error(fmt.Sprintf("Transaction export request is invalid (%s) (%s)",
+			errsArr[0].Error(), errsArr[0].ActualTag()))
+		return errs.NewValidationError(errMsg[errsArr[0].Field()])
+	}
+	if r.From != "" && r.To != "" && r.From > r.To {
+		return errs.NewValidationError("From should not be after to.")
+	}
+
+	return nil
+}
+
+// Period returns the first and last days of the export's period, filling in the ones not given using the given date
+// of today as YYYY-MM-DD.
+func (r TransactionExportRequest) Period(today string) (string, string) {
+	return statementPeriod(r.From, r.To, today)
+}
+
+// statementPeriod fills in the days of a period that were not given. The period ends today if no last day is given
+// (or on the first day, if that is after today), and starts on the first of the last day's month if no first day is
+// given.
+func statementPeriod(from string, to string, today string) (string, string) {
+	if to == "" {
+		to = today
+		if from > to {
+			to = from
+		}
+	}
+	if from == "" {
+		from = to[:len("2006-01-")] + "01"
+	}
+	return from, to
+}
@@ -0,0 +1,69 @@
+package dto
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestStatementRequest_Validate_returns_error_when_request_invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		request StatementRequest
+	}{
+		{"unknown format", StatementRequest{CustomerId: "2", AccountId: "1977", Format: "ofx"}},
+		{"invalid date", StatementRequest{CustomerId: "2", AccountId: "1977", Format: StatementFormatMt940, From: "2023-02-30"}},
+		{"from after to", StatementRequest{CustomerId: "2", AccountId: "1977", Format: StatementFormatCamt053,
+			From: "2023-03-02", To: "2023-03-01"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Act
+			err := tc.request.Validate()
+
+			//Assert
+			if err == nil || err.Code != http.StatusUnprocessableEntity {
+				t.Errorf("expected validation error but got %v", err)
+			}
+		})
+	}
+}
+
+func TestStatementRequest_Period_fills_in_missing_days(t *testing.T) {
+	tests := []struct {
+		name         string
+		request      StatementRequest
+		expectedFrom string
+		expectedTo   string
+	}{
+		{"none given", StatementRequest{}, "2023-03-01", "2023-03-15"},
+		{"only to given", StatementRequest{To: "2023-02-10"}, "2023-02-01", "2023-02-10"},
+		{"only from given", StatementRequest{From: "2023-01-20"}, "2023-01-20", "2023-03-15"},
+		{"both given", StatementRequest{From: "2022-12-01", To: "2022-12-31"}, "2022-12-01", "2022-12-31"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Act
+			from, to := tc.request.Period("2023-03-15")
+
+			//Assert
+			if from != tc.expectedFrom || to != tc.expectedTo {
+				t.Errorf("expected period %s to %s but got %s to %s", tc.expectedFrom, tc.expectedTo, from, to)
+			}
+		})
+	}
+}
+
+func TestTransactionExportRequest_Validate_returns_error_when_format_not_supported(t *testing.T) {
+	//Arrange
+	request := TransactionExportRequest{CustomerId: "2", AccountId: "1977", Format: StatementFormatMt940}
+
+	//Act
+	err := request.Validate()
+
+	//Assert
+	if err == nil || err.Message != "Format should be ofx or qif." {
+		t.Errorf("expected validation error but got %v", err)
+	}
+}
@@ -0,0 +1,8 @@
+package dto
+
+// StatementResponse is a statement rendered as a file to download.
+type StatementResponse struct {
+	ContentType string
+	Filename    string
+	Content     []byte
+}
@@ -0,0 +1,175 @@
+package dto
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/formValidator"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const ImportFormatCsv = "csv"
+const ImportFormatJsonl = "jsonl"
+
+const ImportDefaultConcurrency = 4
+const ImportMaxConcurrency = 16
+
+// importCsvColumns are the columns that a CSV file of transactions must have. Any other columns are ignored.
+var importCsvColumns = []string{"account_id", "amount", "transaction_type", "customer_id"}
+
+// importMaxLineLength is the length of the longest line of a JSON-lines file that can be read.
+const importMaxLineLength = 64 * 1024
+
+// TransactionImportRequest holds the options of an import of a file of transactions. A batch ID is given to resume an
+// earlier import of the same file.
+type TransactionImportRequest struct {
+	BatchId     string `validate:"omitempty,max=11,number"`
+	Mode        string `validate:"required,oneof=best_effort all_or_nothing"`
+	Format      string `validate:"required,oneof=csv jsonl"`
+	Concurrency string `validate:"omitempty,max=2,number"`
+}
+
+func (r TransactionImportRequest) Validate() *errs.AppError {
+	errMsg := map[string]string{
+		"BatchId":     "Batch ID must be a number.",
+		"Mode":        "Mode should be best_effort or all_or_nothing.",
+		"Format":      fmt.Sprintf("Format should be %s or %s.", ImportFormatCsv, ImportFormatJsonl),
+		"Concurrency": fmt.Sprintf("Concurrency must be a number between 1 and %d.", ImportMaxConcurrency),
+	}
+	if errsArr := formValidator.Struct(r); errsArr != nil {
+		logger.Error(fmt.Sprintf("Transaction import request is invalid (%s) (%s)",
+			errsArr[0].Error(), errsArr[0].ActualTag()))
+		return errs.NewValidationError(errMsg[errsArr[0].Field()])
+	}
+	if n := r.ConcurrencyOrDefault(); n < 1 || n > ImportMaxConcurrency {
+		return errs.NewValidationError(errMsg["Concurrency"])
+	}
+
+	return nil
+}
+
+// ConcurrencyOrDefault returns the number of rows to post at the same time, or the default if none was given.
+func (r TransactionImportRequest) ConcurrencyOrDefault() int {
+	if r.Concurrency == "" {
+		return ImportDefaultConcurrency
+	}
+	n, _ := strconv.Atoi(r.Concurrency) //checked to be a number by Validate
+	return n
+}
+
+// ImportRow is a row of a file of transactions, numbered by the line it starts on. A row that could not be read has
+// a message saying why.
+type ImportRow struct {
+	RowNumber    int
+	Request      TransactionRequest
+	ReadErrorMsg string
+}
+
+// ImportRowReader reads the rows of a file of transactions one by one, returning io.EOF after the last row. A row
+// that cannot be read is returned with a message rather than as an error, so that the rest of the file can still be
+// read. Errors are only returned if the file itself cannot be read any further.
+type ImportRowReader interface {
+	Next() (ImportRow, error)
+}
+
+// NewImportRowReader returns a reader of the rows of the given file in the given format. A CSV file must start with
+// a header naming its columns, which are account_id, amount, transaction_type and customer_id in any order. Each line
+// of a JSON-lines file is a transaction request, and blank lines are skipped.
+func NewImportRowReader(format string, file io.Reader) (ImportRowReader, *errs.AppError) {
+	if format == ImportFormatJsonl {
+		scanner := bufio.NewScanner(file)
+		scanner.Buffer(make([]byte, 0, 4096), importMaxLineLength)
+		return &jsonlRowReader{scanner: scanner}, nil
+	}
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1 //rows with missing columns are rejected one by one instead
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		logger.Error("Error while reading header of CSV import file: " + err.Error())
+		return nil, errs.NewValidationError("CSV file must start with a header.")
+	}
+	columns := make(map[string]int)
+	for k, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = k
+	}
+	for _, name := range importCsvColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, errs.NewValidationError(fmt.Sprintf("CSV header is missing the %s column.", name))
+		}
+	}
+	return &csvRowReader{reader: reader, columns: columns}, nil
+}
+
+type csvRowReader struct {
+	reader  *csv.Reader
+	columns map[string]int
+}
+
+func (c *csvRowReader) Next() (ImportRow, error) {
+	record, err := c.reader.Read()
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return ImportRow{RowNumber: parseErr.StartLine, ReadErrorMsg: "Row is not valid CSV."}, nil
+	}
+	if err != nil {
+		return ImportRow{}, err
+	}
+
+	line, _ := c.reader.FieldPos(0)
+	row := ImportRow{RowNumber: line}
+	if len(record) <= c.maxColumn() {
+		row.ReadErrorMsg = "Row is missing columns."
+		return row, nil
+	}
+	row.Request = TransactionRequest{
+		AccountId:       strings.TrimSpace(record[c.columns["account_id"]]),
+		TransactionType: strings.TrimSpace(record[c.columns["transaction_type"]]),
+		CustomerId:      strings.TrimSpace(record[c.columns["customer_id"]]),
+	}
+	if row.Request.Amount, err = strconv.ParseFloat(strings.TrimSpace(record[c.columns["amount"]]), 64); err != nil {
+		row.ReadErrorMsg = "Please check that the transaction amount is valid."
+	}
+	return row, nil
+}
+
+func (c *csvRowReader) maxColumn() int {
+	max := 0
+	for _, name := range importCsvColumns {
+		if c.columns[name] > max {
+			max = c.columns[name]
+		}
+	}
+	return max
+}
+
+type jsonlRowReader struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+func (j *jsonlRowReader) Next() (ImportRow, error) {
+	for j.scanner.Scan() {
+		j.line++
+		if len(bytes.TrimSpace(j.scanner.Bytes())) == 0 {
+			continue
+		}
+		row := ImportRow{RowNumber: j.line}
+		if err := json.Unmarshal(j.scanner.Bytes(), &row.Request); err != nil {
+			row.ReadErrorMsg = "Row is not a valid transaction request."
+		}
+		return row, nil
+	}
+	if err := j.scanner.Err(); err != nil {
+		return ImportRow{}, err
+	}
+	return ImportRow{}, io.EOF
+}
@@ -0,0 +1,103 @@
+package dto
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// readAllImportRows reads every row of the given file in the given format.
+func readAllImportRows(t *testing.T, format string, file string) []ImportRow {
+	reader, appErr := NewImportRowReader(format, strings.NewReader(file))
+	if appErr != nil {
+		t.Fatal("Expected no error but got error: " + appErr.Message)
+	}
+	rows := make([]ImportRow, 0)
+	for {
+		row, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return rows
+		}
+		if err != nil {
+			t.Fatal("Expected no error but got error: " + err.Error())
+		}
+		rows = append(rows, row)
+	}
+}
+
+func TestTransactionImportRequest_Validate_returns_error_when_field_invalid(t *testing.T) {
+	tests := []struct {
+		name               string
+		request            TransactionImportRequest
+		expectedErrMessage string
+	}{
+		{"mode missing", TransactionImportRequest{Format: ImportFormatCsv}, "Mode should be best_effort or all_or_nothing."},
+		{"format unknown", TransactionImportRequest{Mode: "best_effort", Format: "xlsx"}, "Format should be csv or jsonl."},
+		{"batch id not a number", TransactionImportRequest{Mode: "best_effort", Format: ImportFormatCsv, BatchId: "abc"}, "Batch ID must be a number."},
+		{"concurrency too high", TransactionImportRequest{Mode: "best_effort", Format: ImportFormatCsv, Concurrency: "17"}, "Concurrency must be a number between 1 and 16."},
+		{"concurrency zero", TransactionImportRequest{Mode: "best_effort", Format: ImportFormatCsv, Concurrency: "0"}, "Concurrency must be a number between 1 and 16."},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Act
+			err := tc.request.Validate()
+
+			//Assert
+			if err == nil {
+				t.Fatal("expected error but got none")
+			}
+			if err.Code != http.StatusUnprocessableEntity || err.Message != tc.expectedErrMessage {
+				t.Errorf("Expected %d \"%s\" but got %d \"%s\"", http.StatusUnprocessableEntity, tc.expectedErrMessage, err.Code, err.Message)
+			}
+		})
+	}
+}
+
+func TestNewImportRowReader_reads_csv_rows_by_header(t *testing.T) {
+	//Arrange
+	file := "\ufeffcustomer_id,Amount,note,transaction_type,account_id\n" +
+		"2, 100.50,payroll,deposit,1977\n" +
+		"2,ten,,deposit,1977\n" +
+		"2,100\n" +
+		"2,\"100,deposit,1977\n"
+
+	//Act
+	rows := readAllImportRows(t, ImportFormatCsv, file)
+
+	//Assert
+	expectedRequest := TransactionRequest{AccountId: "1977", Amount: 100.50, TransactionType: "deposit", CustomerId: "2"}
+	if len(rows) != 4 || rows[0].Request != expectedRequest || rows[0].RowNumber != 2 || rows[0].ReadErrorMsg != "" {
+		t.Fatalf("Expected first of 4 rows to be %+v on line 2 but got %+v", expectedRequest, rows)
+	}
+	if rows[1].ReadErrorMsg == "" || rows[2].ReadErrorMsg != "Row is missing columns." || rows[3].ReadErrorMsg != "Row is not valid CSV." {
+		t.Errorf("Expected last 3 rows to be unreadable but got %+v", rows[1:])
+	}
+}
+
+func TestNewImportRowReader_returns_error_when_csv_header_missing_column(t *testing.T) {
+	//Act
+	_, err := NewImportRowReader(ImportFormatCsv, strings.NewReader("account_id,amount,customer_id\n1977,100,2\n"))
+
+	//Assert
+	if err == nil || err.Message != "CSV header is missing the transaction_type column." {
+		t.Errorf("Expected missing column error but got %v", err)
+	}
+}
+
+func TestNewImportRowReader_reads_jsonl_rows_by_line(t *testing.T) {
+	//Arrange
+	file := `{"account_id": "1977", "amount": 100, "transaction_type": "withdrawal", "customer_id": "2"}` + "\n\n" +
+		`{"account_id": 1977}` + "\n"
+
+	//Act
+	rows := readAllImportRows(t, ImportFormatJsonl, file)
+
+	//Assert
+	if len(rows) != 2 || rows[0].Request.TransactionType != "withdrawal" || rows[1].RowNumber != 3 ||
+		rows[1].ReadErrorMsg != "Row is not a valid transaction request." {
+		t.Errorf("Expected valid row on line 1 and invalid row on line 3 but got %+v", rows)
+	}
+}
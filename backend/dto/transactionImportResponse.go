@@ -0,0 +1,37 @@
+package dto
+
+type ImportBatchResponse struct {
+	BatchId      string `json:"batch_id"`
+	Mode         string `json:"mode"`
+	Status       string `json:"status"`
+	Checkpoint   int    `json:"checkpoint"` //rows up to and including this row have been imported
+	CreationDate string `json:"creation_date"`
+	UpdateDate   string `json:"update_date"`
+}
+
+type ImportRowResponse struct {
+	RowNumber       int     `json:"row"`
+	AccountId       string  `json:"account_id"`
+	Amount          float64 `json:"amount"`
+	TransactionType string  `json:"transaction_type"`
+	Status          string  `json:"status"`
+	TransactionId   string  `json:"transaction_id,omitempty"`
+	Message         string  `json:"message,omitempty"`
+}
+
+type ImportSummaryResponse struct {
+	ImportBatchResponse
+	Posted   int `json:"posted"`   //rows of the batch posted so far, including by earlier runs
+	Rejected int `json:"rejected"` //rows of the batch rejected so far, including by earlier runs
+	Errors   int `json:"errors"`   //rows of this run that failed unexpectedly, which are tried again on resuming
+}
+
+// ImportReportLine is a line of the report streamed while a file of transactions is imported. The report starts with
+// the batch, followed by the result of each row as it is known, and ends with a summary, or an error if the import
+// stopped part way.
+type ImportReportLine struct {
+	Batch   *ImportBatchResponse   `json:"batch,omitempty"`
+	Row     *ImportRowResponse     `json:"row,omitempty"`
+	Summary *ImportSummaryResponse `json:"summary,omitempty"`
+	Error   string                 `json:"error,omitempty"`
+}
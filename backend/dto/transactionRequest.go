@@ -9,6 +9,18 @@ import (
 
 const TransactionTypeWithdrawal = "withdrawal"
 const TransactionTypeDeposit = "deposit"
+const TransactionTypeInterest = "interest"
+const TransactionTypeOverdraftInterest = "overdraft_interest"
+const TransactionTypeFee = "fee"
+const TransactionTypeTransferOut = "transfer_out"
+const TransactionTypeTransferIn = "transfer_in"
+const TransactionTypeHoldCapture = "hold_capture"
+const TransactionTypeReversalDebit = "reversal_debit"   //reverses a deposit or other credit
+const TransactionTypeReversalCredit = "reversal_credit" //reverses a withdrawal or other debit
+
+// TransactionMinAmountAllowed and TransactionMaxAmountAllowed bound the amount of a transaction, transfer, hold or
+// standing order. The amount is in the currency of the account it is taken from or added to, and the bounds are not
+// converted, so they are the same number for accounts in every currency.
 const TransactionMinAmountAllowed float64 = 0
 const TransactionMaxAmountAllowed float64 = 10000
 
@@ -17,6 +29,14 @@ type TransactionRequest struct {
 	Amount          float64 `json:"amount" validate:"number,gte=0,lte=10000"`
 	TransactionType string  `json:"transaction_type" validate:"required,alpha,oneof=withdrawal deposit"`
 	CustomerId      string  `json:"customer_id" validate:"required,max=11,number"`
+
+	// OverrideScreening lets an admin make a transaction without putting it through the fraud screening rules, such as
+	// one that was blocked and found to be legitimate.
+	OverrideScreening bool `json:"override_screening"`
+
+	// ActingAdmin is set when an admin makes the transaction, who may do so on accounts they neither hold nor were
+	// delegated. It cannot be set by clients.
+	ActingAdmin bool `json:"-"`
 }
 
 func (r TransactionRequest) Validate() *errs.AppError {
@@ -4,4 +4,21 @@ type TransactionResponse struct {
 	TransactionId   string  `json:"transaction_id"`
 	Balance         float64 `json:"new_balance"`
 	TransactionDate string  `json:"transaction_date"`
+	ReversalOf      string  `json:"reversal_of,omitempty"`
+	FxRate          float64 `json:"fx_rate,omitempty"`
+	ConvertedAmount float64 `json:"converted_amount,omitempty"`
+}
+
+type TransactionDetailResponse struct {
+	TransactionId    string  `json:"transaction_id"`
+	AccountId        string  `json:"account_id"`
+	Amount           float64 `json:"amount"`
+	TransactionType  string  `json:"transaction_type"`
+	TransactionDate  string  `json:"transaction_date"`
+	ReversalOf       string  `json:"reversal_of,omitempty"`
+	ReversedBy       string  `json:"reversed_by,omitempty"`
+	ReversalReason   string  `json:"reversal_reason,omitempty"`
+	FxRate           float64 `json:"fx_rate,omitempty"`
+	ConvertedAmount  float64 `json:"converted_amount,omitempty"`
+	ActingCustomerId string  `json:"acting_customer_id,omitempty"` //holder who made it, if made by a customer
 }
@@ -0,0 +1,44 @@
+package dto
+
+import (
+	"fmt"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/formValidator"
+	"github.com/aliciatay-zls/banking-lib/logger"
+)
+
+type TransferRequest struct {
+	AccountId            string  `json:"-" validate:"required,max=11,number"`
+	CustomerId           string  `json:"-" validate:"required,max=11,number"`
+	DestinationAccountId string  `json:"destination_account_id" validate:"required,max=11,number,nefield=AccountId"`
+	Amount               float64 `json:"amount" validate:"number,gt=0,lte=10000"`
+
+	// OverrideBeneficiaryCheck lets an admin transfer to an account that is not one of the customer's beneficiaries,
+	// or one that is still in its cooling-off period or over its first-transfer limit.
+	OverrideBeneficiaryCheck bool `json:"override_beneficiary_check"`
+
+	// SkipSanctionsScreening is set when an admin clears a transfer that sanctions screening held, so that it is made
+	// without being screened again. It cannot be set by clients.
+	SkipSanctionsScreening bool `json:"-"`
+
+	// ActingAdmin is set when an admin makes the transfer, who may do so from accounts they neither hold nor were
+	// delegated. It cannot be set by clients.
+	ActingAdmin bool `json:"-"`
+}
+
+func (r TransferRequest) Validate() *errs.AppError {
+	errMsg := map[string]string{
+		"AccountId":            "Account ID must be present and a number.",
+		"CustomerId":           "Customer ID must be present and a number.",
+		"DestinationAccountId": "Destination account ID must be present, a number and different from the account ID.",
+		"Amount": fmt.Sprintf("Transfer amount should be more than %.2f and at most %.2f.",
+			TransactionMinAmountAllowed, TransactionMaxAmountAllowed),
+	}
+	if errsArr := formValidator.Struct(r); errsArr != nil {
+		logger.Error(fmt.Sprintf("Transfer request is invalid (%s) (%s)",
+			errsArr[0].Error(), errsArr[0].ActualTag()))
+		return errs.NewValidationError(errMsg[errsArr[0].Field()])
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package dto
+
+import (
+	"fmt"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/formValidator"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"net"
+	"net/url"
+	"strings"
+)
+
+const WebhookSecretMinLength = 16
+
+type NewWebhookRequest struct {
+	CustomerId string   `json:"-" validate:"required,max=11,number"`
+	Url        string   `json:"url" validate:"required,max=255,url,startswith=http"`
+	EventTypes []string `json:"event_types" validate:"max=3,unique,dive,oneof=AccountOpened TransactionPosted CustomerStatusChanged"`
+	Secret     string   `json:"secret" validate:"required,min=16,max=64"`
+}
+
+func (r NewWebhookRequest) Validate() *errs.AppError {
+	errMsg := map[string]string{
+		"CustomerId": "Customer ID must be present and a number.",
+		"Url":        "URL must be present, start with http:// or https:// and be at most 255 characters long.",
+		"EventTypes": "Event types should be a list of AccountOpened, TransactionPosted and CustomerStatusChanged, " +
+			"or empty for all events.",
+		"Secret": fmt.Sprintf("Secret must be between %d and 64 characters long.", WebhookSecretMinLength),
+	}
+	if errsArr := formValidator.Struct(r); errsArr != nil {
+		logger.Error(fmt.Sprintf("New webhook request is invalid (%s) (%s)",
+			errsArr[0].Error(), errsArr[0].ActualTag()))
+		field, _, _ := strings.Cut(errsArr[0].Field(), "[") //errors in list elements are reported as EventTypes[i]
+		return errs.NewValidationError(errMsg[field])
+	}
+
+	if isInternalHost(r.Url) {
+		logger.Error("New webhook request is invalid (URL points to an internal host)")
+		return errs.NewValidationError("URL must not point to a local or private network address.")
+	}
+
+	return nil
+}
+
+// isInternalHost checks whether the host of the given URL is localhost or an IP address that IsInternalIP rejects.
+// Host names are only resolved when a delivery is sent, where WebhookSenderHttp checks the resolved address again.
+func isInternalHost(rawUrl string) bool {
+	u, err := url.Parse(rawUrl)
+	if err != nil {
+		return true
+	}
+	host := strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && IsInternalIP(ip)
+}
+
+// IsInternalIP checks whether webhooks must not be sent to the given IP address: loopback, link-local (which includes
+// cloud metadata addresses), private and unspecified addresses are only reachable from inside the bank's network.
+func IsInternalIP(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsPrivate() ||
+		ip.IsUnspecified()
+}
@@ -0,0 +1,79 @@
+package dto
+
+import (
+	"net/http"
+	"testing"
+)
+
+func getDefaultWebhookRequest() NewWebhookRequest {
+	return NewWebhookRequest{
+		CustomerId: dummyCustomerId,
+		Url:        "https://example.com/hooks",
+		EventTypes: []string{"TransactionPosted"},
+		Secret:     "0123456789abcdef",
+	}
+}
+
+func TestNewWebhookRequest_Validate_returns_nil_when_request_valid(t *testing.T) {
+	//Arrange
+	request := getDefaultWebhookRequest()
+	allEvents := getDefaultWebhookRequest()
+	allEvents.EventTypes = nil
+
+	//Act
+	err := request.Validate()
+	allEventsErr := allEvents.Validate()
+
+	//Assert
+	if err != nil || allEventsErr != nil {
+		t.Errorf("expected no error but got %v and %v", err, allEventsErr)
+	}
+}
+
+func TestNewWebhookRequest_Validate_returns_error_when_field_invalid(t *testing.T) {
+	tests := []struct {
+		name               string
+		modify             func(*NewWebhookRequest)
+		expectedErrMessage string
+	}{
+		{"url without scheme", func(r *NewWebhookRequest) { r.Url = "example.com/hooks" },
+			"URL must be present, start with http:// or https:// and be at most 255 characters long."},
+		{"ftp url", func(r *NewWebhookRequest) { r.Url = "ftp://example.com/hooks" },
+			"URL must be present, start with http:// or https:// and be at most 255 characters long."},
+		{"localhost url", func(r *NewWebhookRequest) { r.Url = "http://localhost:8181/auth/verify" },
+			"URL must not point to a local or private network address."},
+		{"loopback url", func(r *NewWebhookRequest) { r.Url = "http://127.0.0.1/hooks" },
+			"URL must not point to a local or private network address."},
+		{"metadata url", func(r *NewWebhookRequest) { r.Url = "http://169.254.169.254/latest/meta-data" },
+			"URL must not point to a local or private network address."},
+		{"private url", func(r *NewWebhookRequest) { r.Url = "https://10.0.0.5/hooks" },
+			"URL must not point to a local or private network address."},
+		{"ipv6 loopback url", func(r *NewWebhookRequest) { r.Url = "http://[::1]:8080/hooks" },
+			"URL must not point to a local or private network address."},
+		{"unknown event type", func(r *NewWebhookRequest) { r.EventTypes = []string{"TransactionPosted", "AccountClosed"} },
+			"Event types should be a list of AccountOpened, TransactionPosted and CustomerStatusChanged, or empty for all events."},
+		{"duplicate event types", func(r *NewWebhookRequest) { r.EventTypes = []string{"TransactionPosted", "TransactionPosted"} },
+			"Event types should be a list of AccountOpened, TransactionPosted and CustomerStatusChanged, or empty for all events."},
+		{"short secret", func(r *NewWebhookRequest) { r.Secret = "secret" },
+			"Secret must be between 16 and 64 characters long."},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Arrange
+			request := getDefaultWebhookRequest()
+			tc.modify(&request)
+
+			//Act
+			err := request.Validate()
+
+			//Assert
+			if err == nil {
+				t.Fatal("expected error but got none")
+			}
+			if err.Code != http.StatusUnprocessableEntity || err.Message != tc.expectedErrMessage {
+				t.Errorf("Expected %d \"%s\" but got %d \"%s\"", http.StatusUnprocessableEntity, tc.expectedErrMessage, err.Code, err.Message)
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package dto
+
+// WebhookResponse describes a webhook subscription. The secret is never sent back, since it is only known to the
+// subscriber and the bank.
+type WebhookResponse struct {
+	WebhookId    string   `json:"webhook_id"`
+	Url          string   `json:"url"`
+	EventTypes   []string `json:"event_types"`
+	Status       string   `json:"status"`
+	CreationDate string   `json:"creation_date"`
+}
+
+type WebhookDeliveryResponse struct {
+	DeliveryId      string `json:"delivery_id"`
+	EventId         string `json:"event_id"`
+	EventType       string `json:"event_type"`
+	Status          string `json:"status"`
+	Attempts        int    `json:"attempts"`
+	NextAttemptDate string `json:"next_attempt_date,omitempty"`
+	LastAttemptDate string `json:"last_attempt_date,omitempty"`
+	ResponseCode    int    `json:"response_code,omitempty"`
+	LastError       string `json:"last_error,omitempty"`
+	CreationDate    string `json:"creation_date"`
+}
@@ -1,13 +1,33 @@
 package main
 
 import (
+	"flag"
 	"github.com/aliciatay-zls/banking-lib/formValidator"
 	"github.com/aliciatay-zls/banking-lib/logger"
 	"github.com/aliciatay-zls/banking/backend/app"
+	"os"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		app.Migrate(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "import" {
+		formValidator.Create()
+		app.ImportTransactions(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "cash-report" {
+		formValidator.Create()
+		app.GenerateCashReport(os.Args[2:])
+		return
+	}
+
+	demo := flag.Bool("demo", false, "run with in-memory demo data, without a database or auth server")
+	flag.Parse()
+
 	logger.Info("Starting the app...")
 	formValidator.Create()
-	app.Start()
+	app.Start(*demo)
 }
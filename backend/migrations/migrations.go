@@ -0,0 +1,122 @@
+package migrations
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// files holds the migration scripts of each database dialect, in a directory named after the dialect. Every dialect
+// should have the same migrations so that the schema version means the same thing on each database. Each migration
+// is a pair of scripts named <version>_<name>.up.sql and <version>_<name>.down.sql, where version is a number that is
+// one more than the version of the migration before it.
+//
+//go:embed mysql/*.sql postgres/*.sql sqlite3/*.sql
+var files embed.FS
+
+const DialectMySQL = "mysql"
+const DialectPostgres = "postgres"
+const DialectSQLite = "sqlite3"
+
+var fileNamePattern = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+type Migration struct {
+	Version int
+	Name    string
+	Up      string //script that applies the migration
+	Down    string //script that reverts the migration
+}
+
+// Load reads the embedded migrations of the given dialect, ordered by version. It returns an error if a migration is
+// missing either of its scripts or if the versions are not numbered 1, 2, 3 and so on.
+func Load(dialect string) ([]Migration, error) {
+	return load(files, dialect)
+}
+
+func load(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("no migrations found for %s: %w", dir, err)
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		matches := fileNamePattern.FindStringSubmatch(entry.Name())
+		if matches == nil {
+			return nil, fmt.Errorf("migration file name %s is not of the form <version>_<name>.<up|down>.sql", entry.Name())
+		}
+		version, _ := strconv.Atoi(matches[1])
+
+		data, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: matches[2]}
+			byVersion[version] = m
+		}
+		if m.Name != matches[2] {
+			return nil, fmt.Errorf("migration %d has scripts with different names (%s and %s)", version, m.Name, matches[2])
+		}
+		if matches[3] == "up" {
+			m.Up = string(data)
+		} else {
+			m.Down = string(data)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %d (%s) must have both an up and a down script", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	for i, m := range migrations {
+		if m.Version != i+1 {
+			return nil, fmt.Errorf("migration versions must be numbered from 1 without gaps but found %d after %d", m.Version, i)
+		}
+	}
+	return migrations, nil
+}
+
+// LatestVersion returns the version of the last of the given migrations, which is the schema version the code expects.
+func LatestVersion(migrations []Migration) int {
+	if len(migrations) == 0 {
+		return 0
+	}
+	return migrations[len(migrations)-1].Version
+}
+
+// splitStatements splits the given script into the statements it contains, since the database driver only executes
+// one statement at a time. Statements must end with a semicolon at the end of a line. Lines starting with "--" are
+// treated as comments and dropped.
+func splitStatements(script string) []string {
+	statements := make([]string, 0)
+	var current strings.Builder
+	for _, line := range strings.Split(script, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "--") {
+			continue
+		}
+		current.WriteString(line)
+		current.WriteString("\n")
+		if strings.HasSuffix(trimmed, ";") {
+			statements = append(statements, strings.TrimSpace(current.String()))
+			current.Reset()
+		}
+	}
+	if rest := strings.TrimSpace(current.String()); rest != "" {
+		statements = append(statements, rest)
+	}
+	return statements
+}
@@ -0,0 +1,109 @@
+package migrations
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoad_returns_embeddedMigrations_inOrder(t *testing.T) {
+	for _, dialect := range []string{DialectMySQL, DialectPostgres, DialectSQLite} {
+		t.Run(dialect, func(t *testing.T) {
+			//Act
+			migrations, err := Load(dialect)
+
+			//Assert
+			if err != nil {
+				t.Fatal("Expected no error but got error while loading embedded migrations: " + err.Error())
+			}
+			if len(migrations) == 0 {
+				t.Fatal("Expected embedded migrations but got none")
+			}
+			for i, m := range migrations {
+				if m.Version != i+1 {
+					t.Errorf("Expected migration %d to have version %d but got %d", i, i+1, m.Version)
+				}
+				if len(splitStatements(m.Up)) == 0 || len(splitStatements(m.Down)) == 0 {
+					t.Errorf("Expected migration %d (%s) to have up and down statements", m.Version, m.Name)
+				}
+			}
+		})
+	}
+}
+
+func TestLoad_returns_sameMigrations_forEveryDialect(t *testing.T) {
+	//Arrange
+	mysql, _ := Load(DialectMySQL)
+
+	for _, dialect := range []string{DialectPostgres, DialectSQLite} {
+		t.Run(dialect, func(t *testing.T) {
+			//Act
+			other, err := Load(dialect)
+
+			//Assert
+			if err != nil {
+				t.Fatal("Expected no error but got error while loading embedded migrations: " + err.Error())
+			}
+			if len(mysql) != len(other) {
+				t.Fatalf("Expected %d migrations for each dialect but got %d and %d", len(mysql), len(mysql), len(other))
+			}
+			for i := range mysql {
+				if mysql[i].Name != other[i].Name {
+					t.Errorf("Expected migration %d to be %s for every dialect but got %s", i+1, mysql[i].Name, other[i].Name)
+				}
+			}
+		})
+	}
+}
+
+func TestLoad_returns_error_when_migrations_invalid(t *testing.T) {
+	//Arrange
+	tests := []struct {
+		name  string
+		files fstest.MapFS
+	}{
+		{"missing down script", fstest.MapFS{
+			"d/0001_a.up.sql": {Data: []byte("SELECT 1;")},
+		}},
+		{"gap in versions", fstest.MapFS{
+			"d/0001_a.up.sql":   {Data: []byte("SELECT 1;")},
+			"d/0001_a.down.sql": {Data: []byte("SELECT 1;")},
+			"d/0003_c.up.sql":   {Data: []byte("SELECT 1;")},
+			"d/0003_c.down.sql": {Data: []byte("SELECT 1;")},
+		}},
+		{"badly named file", fstest.MapFS{
+			"d/first.sql": {Data: []byte("SELECT 1;")},
+		}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Act
+			_, err := load(tc.files, "d")
+
+			//Assert
+			if err == nil {
+				t.Error("Expected error but got none")
+			}
+		})
+	}
+}
+
+func TestSplitStatements_splits_onSemicolonsAtLineEnd_and_dropsComments(t *testing.T) {
+	//Arrange
+	script := "-- a comment\nCREATE TABLE a (\n  b int\n);\n\nINSERT INTO a VALUES (1), (2);\nSELECT ';' FROM a"
+
+	//Act
+	statements := splitStatements(script)
+
+	//Assert
+	if len(statements) != 3 {
+		t.Fatalf("Expected 3 statements but got %d: %q", len(statements), statements)
+	}
+	if !strings.HasPrefix(statements[0], "CREATE TABLE a (") || strings.Contains(statements[0], "comment") {
+		t.Errorf("Unexpected first statement %q", statements[0])
+	}
+	if statements[2] != "SELECT ';' FROM a" {
+		t.Errorf("Unexpected last statement %q", statements[2])
+	}
+}
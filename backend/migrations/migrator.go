@@ -0,0 +1,183 @@
+package migrations
+
+import (
+	"fmt"
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/jmoiron/sqlx"
+	"io"
+)
+
+const createSchemaMigrationsSql = "CREATE TABLE IF NOT EXISTS schema_migrations (" +
+	"version integer NOT NULL, name varchar(100) NOT NULL, applied_on timestamp NOT NULL, PRIMARY KEY (version))"
+
+type AppliedMigration struct {
+	Version   int    `db:"version"`
+	Name      string `db:"name"`
+	AppliedOn string `db:"applied_on"`
+}
+
+// Migrator applies and reverts migrations on a database, keeping track of the applied migrations in the
+// schema_migrations table. In a dry run, it only writes out the statements that it would have executed.
+type Migrator struct {
+	client     *sqlx.DB
+	migrations []Migration
+	clk        clock.Clock
+	out        io.Writer
+	dryRun     bool
+}
+
+func NewMigrator(client *sqlx.DB, migrations []Migration, clk clock.Clock, out io.Writer, dryRun bool) Migrator {
+	return Migrator{client, migrations, clk, out, dryRun}
+}
+
+// CurrentVersion returns the version of the last migration applied to the database, or 0 if none has been applied.
+func (m Migrator) CurrentVersion() (int, error) {
+	var version int
+	if err := m.client.Get(&version, "SELECT COALESCE(MAX(version), 0) FROM schema_migrations"); err != nil {
+		return 0, fmt.Errorf("error while reading schema version: %w", err)
+	}
+	return version, nil
+}
+
+// CheckVersion returns an error if the database schema is older than the latest migration, or if its version cannot
+// be read because no migrations were ever applied. A newer schema is allowed so that the previous version of the
+// code can keep running while a new version is rolled out.
+func (m Migrator) CheckVersion() error {
+	current, err := m.CurrentVersion()
+	if err != nil {
+		return fmt.Errorf("%w (has \"migrate up\" been run?)", err)
+	}
+	if expected := LatestVersion(m.migrations); current < expected {
+		return fmt.Errorf("database schema is at version %d but version %d is required, run \"migrate up\"",
+			current, expected)
+	}
+	return nil
+}
+
+// Up applies all migrations newer than the current schema version, oldest first. Each migration is applied and
+// recorded in its own database transaction, so that a failure stops at the migration that failed. Note that MySQL
+// commits schema changes implicitly, so a migration that fails halfway may need to be cleaned up by hand.
+func (m Migrator) Up() error {
+	if err := m.ensureTable(); err != nil {
+		return err
+	}
+	current, err := m.CurrentVersion()
+	if err != nil {
+		return err
+	}
+
+	pending := 0
+	for _, migration := range m.migrations {
+		if migration.Version <= current {
+			continue
+		}
+		pending++
+		fmt.Fprintf(m.out, "Applying %04d_%s\n", migration.Version, migration.Name)
+		insertSql := "INSERT INTO schema_migrations (version, name, applied_on) VALUES (?, ?, ?)"
+		if err = m.run(migration.Up, insertSql, migration.Version, migration.Name, m.clk.NowAsString()); err != nil {
+			return fmt.Errorf("error while applying migration %d (%s): %w", migration.Version, migration.Name, err)
+		}
+	}
+
+	if pending == 0 {
+		fmt.Fprintf(m.out, "Schema is up to date at version %d\n", current)
+	}
+	return nil
+}
+
+// Down reverts the given number of most recently applied migrations, newest first.
+func (m Migrator) Down(steps int) error {
+	if err := m.ensureTable(); err != nil {
+		return err
+	}
+	applied, err := m.applied()
+	if err != nil {
+		return err
+	}
+
+	for i := len(applied) - 1; i >= 0 && i >= len(applied)-steps; i-- {
+		version := applied[i].Version
+		if version > len(m.migrations) {
+			return fmt.Errorf("cannot revert migration %d since it is newer than the migrations known to this build", version)
+		}
+		migration := m.migrations[version-1]
+
+		fmt.Fprintf(m.out, "Reverting %04d_%s\n", migration.Version, migration.Name)
+		deleteSql := "DELETE FROM schema_migrations WHERE version = ?"
+		if err = m.run(migration.Down, deleteSql, migration.Version); err != nil {
+			return fmt.Errorf("error while reverting migration %d (%s): %w", migration.Version, migration.Name, err)
+		}
+	}
+	return nil
+}
+
+// Status writes out every known migration along with when it was applied, or that it is still pending.
+func (m Migrator) Status() error {
+	if err := m.ensureTable(); err != nil {
+		return err
+	}
+	applied, err := m.applied()
+	if err != nil {
+		return err
+	}
+
+	appliedOn := make(map[int]string)
+	for _, a := range applied {
+		appliedOn[a.Version] = a.AppliedOn
+	}
+	for _, migration := range m.migrations {
+		status, ok := appliedOn[migration.Version]
+		if !ok {
+			status = "pending"
+		}
+		fmt.Fprintf(m.out, "%04d_%-40s %s\n", migration.Version, migration.Name, status)
+	}
+	return nil
+}
+
+// ensureTable creates the schema_migrations table if it does not exist yet. This is done even in a dry run since
+// the applied migrations cannot be read otherwise.
+func (m Migrator) ensureTable() error {
+	if _, err := m.client.Exec(createSchemaMigrationsSql); err != nil {
+		return fmt.Errorf("error while creating schema_migrations table: %w", err)
+	}
+	return nil
+}
+
+func (m Migrator) applied() ([]AppliedMigration, error) {
+	applied := make([]AppliedMigration, 0)
+	selectSql := "SELECT version, name, applied_on FROM schema_migrations ORDER BY version"
+	if err := m.client.Select(&applied, selectSql); err != nil {
+		return nil, fmt.Errorf("error while reading applied migrations: %w", err)
+	}
+	return applied, nil
+}
+
+// run executes the statements of the given script followed by the given bookkeeping statement in one database
+// transaction. In a dry run, it writes out the statements instead. The bookkeeping statement is written with ?
+// placeholders, which are converted to the placeholders of the database driver in use.
+func (m Migrator) run(script string, bookkeepingSql string, args ...interface{}) error {
+	statements := splitStatements(script)
+	if m.dryRun {
+		for _, statement := range statements {
+			fmt.Fprintln(m.out, statement)
+		}
+		return nil
+	}
+
+	tx, err := m.client.Begin()
+	if err != nil {
+		return err
+	}
+	for _, statement := range statements {
+		if _, err = tx.Exec(statement); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+	if _, err = tx.Exec(m.client.Rebind(bookkeepingSql), args...); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
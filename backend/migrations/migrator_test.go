@@ -0,0 +1,144 @@
+package migrations
+
+import (
+	"bytes"
+	"database/sql"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/jmoiron/sqlx"
+	"strings"
+	"testing"
+)
+
+// Test common variables and inputs
+var db *sql.DB
+var mockDB sqlmock.Sqlmock
+var out *bytes.Buffer
+
+const selectCurrentVersionSql = "SELECT COALESCE(MAX(version), 0) FROM schema_migrations"
+const insertSchemaMigrationsSql = "INSERT INTO schema_migrations (version, name, applied_on) VALUES (?, ?, ?)"
+const deleteSchemaMigrationsSql = "DELETE FROM schema_migrations WHERE version = ?"
+const selectAppliedSql = "SELECT version, name, applied_on FROM schema_migrations ORDER BY version"
+const dummyDate = "2006-01-02 15:04:05"
+
+var dummyMigrations = []Migration{
+	{Version: 1, Name: "create_a", Up: "CREATE TABLE a (id int);", Down: "DROP TABLE a;"},
+	{Version: 2, Name: "create_b", Up: "CREATE TABLE b (id int);\nINSERT INTO b VALUES (1);", Down: "DROP TABLE b;"},
+}
+
+func setupMigratorTest(t *testing.T, dryRun bool) (Migrator, func()) {
+	var err error
+	db, mockDB, err = sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatal("error while setting up test")
+	}
+	out = &bytes.Buffer{}
+	migrator := NewMigrator(sqlx.NewDb(db, "sqlmock"), dummyMigrations, clock.StaticClock{}, out, dryRun)
+
+	return migrator, func() {
+		defer db.Close()
+	}
+}
+
+func TestMigrator_Up_appliesPendingMigrations_only(t *testing.T) {
+	//Arrange
+	migrator, teardown := setupMigratorTest(t, false)
+	defer teardown()
+
+	mockDB.ExpectExec(createSchemaMigrationsSql).WillReturnResult(sqlmock.NewResult(0, 0))
+	mockDB.ExpectQuery(selectCurrentVersionSql).WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(1))
+	mockDB.ExpectBegin()
+	mockDB.ExpectExec("CREATE TABLE b (id int);").WillReturnResult(sqlmock.NewResult(0, 0))
+	mockDB.ExpectExec("INSERT INTO b VALUES (1);").WillReturnResult(sqlmock.NewResult(1, 1))
+	mockDB.ExpectExec(insertSchemaMigrationsSql).WithArgs(2, "create_b", dummyDate).WillReturnResult(sqlmock.NewResult(2, 1))
+	mockDB.ExpectCommit()
+
+	//Act
+	err := migrator.Up()
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing migrating up: " + err.Error())
+	}
+	if err = mockDB.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMigrator_Up_executesNothing_when_dryRun(t *testing.T) {
+	//Arrange
+	migrator, teardown := setupMigratorTest(t, true)
+	defer teardown()
+
+	mockDB.ExpectExec(createSchemaMigrationsSql).WillReturnResult(sqlmock.NewResult(0, 0))
+	mockDB.ExpectQuery(selectCurrentVersionSql).WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(0))
+
+	//Act
+	err := migrator.Up()
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing dry run: " + err.Error())
+	}
+	if err = mockDB.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+	if !strings.Contains(out.String(), "CREATE TABLE a (id int);") || !strings.Contains(out.String(), "INSERT INTO b VALUES (1);") {
+		t.Errorf("Expected statements to be printed but got %q", out.String())
+	}
+}
+
+func TestMigrator_Down_revertsLatestMigration(t *testing.T) {
+	//Arrange
+	migrator, teardown := setupMigratorTest(t, false)
+	defer teardown()
+
+	mockDB.ExpectExec(createSchemaMigrationsSql).WillReturnResult(sqlmock.NewResult(0, 0))
+	mockDB.ExpectQuery(selectAppliedSql).WillReturnRows(sqlmock.NewRows([]string{"version", "name", "applied_on"}).
+		AddRow(1, "create_a", dummyDate).
+		AddRow(2, "create_b", dummyDate))
+	mockDB.ExpectBegin()
+	mockDB.ExpectExec("DROP TABLE b;").WillReturnResult(sqlmock.NewResult(0, 0))
+	mockDB.ExpectExec(deleteSchemaMigrationsSql).WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 1))
+	mockDB.ExpectCommit()
+
+	//Act
+	err := migrator.Down(1)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing migrating down: " + err.Error())
+	}
+	if err = mockDB.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMigrator_CheckVersion_returns_error_when_schema_older(t *testing.T) {
+	//Arrange
+	tests := []struct {
+		name          string
+		version       int
+		expectedError bool
+	}{
+		{"older", 1, true},
+		{"same", 2, false},
+		{"newer", 3, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			migrator, teardown := setupMigratorTest(t, false)
+			defer teardown()
+			mockDB.ExpectQuery(selectCurrentVersionSql).WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(tc.version))
+
+			//Act
+			err := migrator.CheckVersion()
+
+			//Assert
+			if (err != nil) != tc.expectedError {
+				t.Errorf("Expected error: %v but got %v", tc.expectedError, err)
+			}
+		})
+	}
+}
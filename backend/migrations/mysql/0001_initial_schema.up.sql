@@ -0,0 +1,159 @@
+-- Initial schema, taken from the schema dump that was used before migrations were introduced. Tables are only
+-- created if they do not exist yet so that databases created from that dump can be brought under migration by
+-- running this migration without losing any data.
+
+CREATE TABLE IF NOT EXISTS `customers` (
+  `customer_id` int(11) NOT NULL AUTO_INCREMENT,
+  `name` varchar(100) NOT NULL,
+  `date_of_birth` date NOT NULL,
+  `email` varchar(100) NOT NULL,
+  `country` varchar(100) NOT NULL,
+  `zipcode` varchar(10) NOT NULL,
+  `status` tinyint(1) NOT NULL DEFAULT '1',
+  PRIMARY KEY (`customer_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=latin1;
+
+CREATE TABLE IF NOT EXISTS `accounts` (
+  `account_id` int(11) NOT NULL AUTO_INCREMENT,
+  `customer_id` int(11) NOT NULL,
+  `opening_date` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `account_type` varchar(10) NOT NULL,
+  `currency` char(3) NOT NULL DEFAULT 'USD',
+  `amount` decimal(10,2) NOT NULL,
+  `status` tinyint(1) NOT NULL DEFAULT '1',
+  `overdraft_limit` decimal(10,2) NOT NULL DEFAULT '0',
+  `held_amount` decimal(10,2) NOT NULL DEFAULT '0',
+  PRIMARY KEY (`account_id`),
+  KEY `accounts_FK` (`customer_id`),
+  CONSTRAINT `accounts_FK` FOREIGN KEY (`customer_id`) REFERENCES `customers` (`customer_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=latin1;
+
+CREATE TABLE IF NOT EXISTS `transactions` (
+  `transaction_id` int(11) NOT NULL AUTO_INCREMENT,
+  `account_id` int(11) NOT NULL,
+  `amount` decimal(10,2) NOT NULL,
+  `transaction_type` varchar(20) NOT NULL,
+  `transaction_date` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `fx_rate` decimal(18,8) DEFAULT NULL,
+  `converted_amount` decimal(10,2) DEFAULT NULL,
+  PRIMARY KEY (`transaction_id`),
+  KEY `transactions_FK` (`account_id`),
+  CONSTRAINT `transactions_FK` FOREIGN KEY (`account_id`) REFERENCES `accounts` (`account_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=latin1;
+
+CREATE TABLE IF NOT EXISTS `transaction_reversals` (
+  `original_transaction_id` int(11) NOT NULL,
+  `reversal_transaction_id` int(11) NOT NULL,
+  `reason_code` varchar(20) NOT NULL,
+  `note` varchar(255) NOT NULL DEFAULT '',
+  `reversal_date` datetime NOT NULL,
+  PRIMARY KEY (`original_transaction_id`),
+  UNIQUE KEY `transaction_reversals_reversal` (`reversal_transaction_id`),
+  CONSTRAINT `transaction_reversals_FK` FOREIGN KEY (`original_transaction_id`) REFERENCES `transactions` (`transaction_id`),
+  CONSTRAINT `transaction_reversals_FK_1` FOREIGN KEY (`reversal_transaction_id`) REFERENCES `transactions` (`transaction_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=latin1;
+
+CREATE TABLE IF NOT EXISTS `holds` (
+  `hold_id` int(11) NOT NULL AUTO_INCREMENT,
+  `account_id` int(11) NOT NULL,
+  `amount` decimal(10,2) NOT NULL,
+  `captured_amount` decimal(10,2) NOT NULL DEFAULT '0',
+  `description` varchar(255) NOT NULL DEFAULT '',
+  `status` varchar(10) NOT NULL,
+  `creation_date` datetime NOT NULL,
+  `expiry_date` datetime NOT NULL,
+  `transaction_id` int(11) DEFAULT NULL,
+  PRIMARY KEY (`hold_id`),
+  KEY `holds_FK` (`account_id`),
+  KEY `holds_expiry` (`status`, `expiry_date`),
+  CONSTRAINT `holds_FK` FOREIGN KEY (`account_id`) REFERENCES `accounts` (`account_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=latin1;
+
+CREATE TABLE IF NOT EXISTS `interest_accruals` (
+  `account_id` int(11) NOT NULL,
+  `accrual_date` date NOT NULL,
+  `accrual_type` varchar(20) NOT NULL,
+  `balance` decimal(10,2) NOT NULL,
+  `annual_rate` decimal(6,5) NOT NULL,
+  `amount` decimal(16,6) NOT NULL,
+  PRIMARY KEY (`account_id`, `accrual_date`, `accrual_type`),
+  CONSTRAINT `interest_accruals_FK` FOREIGN KEY (`account_id`) REFERENCES `accounts` (`account_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=latin1;
+
+CREATE TABLE IF NOT EXISTS `interest_postings` (
+  `account_id` int(11) NOT NULL,
+  `period` char(7) NOT NULL,
+  `posting_type` varchar(20) NOT NULL,
+  `amount` decimal(10,2) NOT NULL,
+  `transaction_id` int(11) DEFAULT NULL,
+  PRIMARY KEY (`account_id`, `period`, `posting_type`),
+  CONSTRAINT `interest_postings_FK` FOREIGN KEY (`account_id`) REFERENCES `accounts` (`account_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=latin1;
+
+CREATE TABLE IF NOT EXISTS `standing_orders` (
+  `standing_order_id` int(11) NOT NULL AUTO_INCREMENT,
+  `customer_id` int(11) NOT NULL,
+  `account_id` int(11) NOT NULL,
+  `destination_account_id` int(11) NOT NULL,
+  `amount` decimal(10,2) NOT NULL,
+  `schedule_type` varchar(10) NOT NULL,
+  `day_of_month` tinyint(2) NOT NULL DEFAULT '0',
+  `cron_expression` varchar(100) NOT NULL DEFAULT '',
+  `end_date` date DEFAULT NULL,
+  `max_occurrences` int(11) NOT NULL DEFAULT '0',
+  `occurrences` int(11) NOT NULL DEFAULT '0',
+  `next_run_date` datetime NOT NULL,
+  `retry_count` int(11) NOT NULL DEFAULT '0',
+  `status` varchar(10) NOT NULL,
+  `creation_date` datetime NOT NULL,
+  PRIMARY KEY (`standing_order_id`),
+  KEY `standing_orders_FK` (`account_id`),
+  KEY `standing_orders_due` (`status`, `next_run_date`),
+  CONSTRAINT `standing_orders_FK` FOREIGN KEY (`account_id`) REFERENCES `accounts` (`account_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=latin1;
+
+CREATE TABLE IF NOT EXISTS `standing_order_executions` (
+  `execution_id` int(11) NOT NULL AUTO_INCREMENT,
+  `standing_order_id` int(11) NOT NULL,
+  `scheduled_date` datetime NOT NULL,
+  `execution_date` datetime NOT NULL,
+  `status` varchar(10) NOT NULL,
+  `transaction_id` int(11) DEFAULT NULL,
+  `message` varchar(255) NOT NULL DEFAULT '',
+  PRIMARY KEY (`execution_id`),
+  KEY `standing_order_executions_FK` (`standing_order_id`),
+  CONSTRAINT `standing_order_executions_FK` FOREIGN KEY (`standing_order_id`) REFERENCES `standing_orders` (`standing_order_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=latin1;
+
+CREATE TABLE IF NOT EXISTS `users` (
+  `username` varchar(20) NOT NULL,
+  `password` varchar(64) NOT NULL,
+  `role` varchar(20) NOT NULL,
+  `customer_id` int(11) DEFAULT NULL,
+  `created_on` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`username`)
+) ENGINE=InnoDB DEFAULT CHARSET=latin1;
+
+CREATE TABLE IF NOT EXISTS `registrations` (
+  `email` varchar(100) NOT NULL,
+  `customer_id` int(11) DEFAULT NULL,
+  `name` varchar(100) NOT NULL,
+  `date_of_birth` date NOT NULL,
+  `country` varchar(100) NOT NULL,
+  `zipcode` varchar(10) NOT NULL,
+  `status` tinyint(1) NOT NULL DEFAULT '0',
+  `username` varchar(20) NOT NULL,
+  `password` varchar(64) NOT NULL,
+  `role` varchar(20) NOT NULL,
+  `email_attempts` tinyint(1) NOT NULL DEFAULT '0',
+  `created_on` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `last_emailed_on` datetime DEFAULT NULL,
+  `confirmed_on` datetime DEFAULT NULL,
+  PRIMARY KEY (`email`)
+) ENGINE=InnoDB DEFAULT CHARSET=latin1;
+
+CREATE TABLE IF NOT EXISTS `refresh_token_store` (
+  `refresh_token` char(64) NOT NULL,
+  created_on timestamp DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`refresh_token`)
+) ENGINE=InnoDB DEFAULT CHARSET=latin1;
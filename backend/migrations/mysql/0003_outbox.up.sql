@@ -0,0 +1,16 @@
+-- Domain events waiting to be published by the outbox relay, written in the same transaction as the change they
+-- describe. Events of the same aggregate are published in the order of their IDs.
+
+CREATE TABLE IF NOT EXISTS `outbox` (
+  `event_id` int(11) NOT NULL AUTO_INCREMENT,
+  `event_type` varchar(50) NOT NULL,
+  `aggregate_type` varchar(20) NOT NULL,
+  `aggregate_id` varchar(20) NOT NULL,
+  `payload` text NOT NULL,
+  `occurred_on` datetime NOT NULL,
+  `published_on` datetime DEFAULT NULL,
+  `attempts` int(11) NOT NULL DEFAULT '0',
+  `last_error` varchar(255) DEFAULT NULL,
+  PRIMARY KEY (`event_id`),
+  KEY `outbox_unpublished` (`published_on`, `event_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=latin1;
@@ -0,0 +1,34 @@
+-- Webhook subscriptions of customers, and the deliveries of events to them. Each event is delivered at most once per
+-- subscription, retried with exponential backoff until it succeeds or is given up on.
+
+CREATE TABLE IF NOT EXISTS `webhook_subscriptions` (
+  `subscription_id` int(11) NOT NULL AUTO_INCREMENT,
+  `customer_id` int(11) NOT NULL,
+  `url` varchar(255) NOT NULL,
+  `event_types` varchar(255) NOT NULL DEFAULT '',
+  `secret` varchar(64) NOT NULL,
+  `status` varchar(10) NOT NULL,
+  `creation_date` datetime NOT NULL,
+  PRIMARY KEY (`subscription_id`),
+  KEY `webhook_subscriptions_customer` (`customer_id`),
+  CONSTRAINT `webhook_subscriptions_FK` FOREIGN KEY (`customer_id`) REFERENCES `customers` (`customer_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=latin1;
+
+CREATE TABLE IF NOT EXISTS `webhook_deliveries` (
+  `delivery_id` int(11) NOT NULL AUTO_INCREMENT,
+  `subscription_id` int(11) NOT NULL,
+  `event_id` int(11) NOT NULL,
+  `event_type` varchar(50) NOT NULL,
+  `payload` text NOT NULL,
+  `status` varchar(10) NOT NULL,
+  `attempts` int(11) NOT NULL DEFAULT '0',
+  `next_attempt_date` datetime NOT NULL,
+  `last_attempt_date` datetime DEFAULT NULL,
+  `response_code` int(11) DEFAULT NULL,
+  `last_error` varchar(255) DEFAULT NULL,
+  `creation_date` datetime NOT NULL,
+  PRIMARY KEY (`delivery_id`),
+  UNIQUE KEY `webhook_deliveries_event` (`subscription_id`, `event_id`),
+  KEY `webhook_deliveries_due` (`status`, `next_attempt_date`),
+  CONSTRAINT `webhook_deliveries_FK` FOREIGN KEY (`subscription_id`) REFERENCES `webhook_subscriptions` (`subscription_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=latin1;
@@ -0,0 +1,21 @@
+-- Append-only log of state-changing requests. Each entry holds the hash of the entry before it, and the unique key on
+-- previous_hash stops two entries from being chained to the same one.
+
+CREATE TABLE IF NOT EXISTS `audit_log` (
+  `entry_id` int(11) NOT NULL AUTO_INCREMENT,
+  `actor_role` varchar(10) NOT NULL DEFAULT '',
+  `actor_customer_id` varchar(11) NOT NULL DEFAULT '',
+  `route_name` varchar(50) NOT NULL,
+  `route_vars` varchar(255) NOT NULL,
+  `request_hash` char(64) NOT NULL,
+  `status_code` int(11) NOT NULL,
+  `outcome` varchar(10) NOT NULL,
+  `ip_address` varchar(45) NOT NULL,
+  `created_on` datetime NOT NULL,
+  `previous_hash` char(64) NOT NULL,
+  `entry_hash` char(64) NOT NULL,
+  PRIMARY KEY (`entry_id`),
+  UNIQUE KEY `audit_log_chain` (`previous_hash`),
+  KEY `audit_log_actor` (`actor_customer_id`),
+  KEY `audit_log_created` (`created_on`)
+) ENGINE=InnoDB DEFAULT CHARSET=latin1;
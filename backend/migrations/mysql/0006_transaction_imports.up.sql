@@ -0,0 +1,26 @@
+-- Batches of transactions imported from files, and the result of each row of a batch. A row has at most one result,
+-- which is saved together with its transaction, so that a resumed batch never posts a row twice.
+
+CREATE TABLE IF NOT EXISTS `import_batches` (
+  `batch_id` int(11) NOT NULL AUTO_INCREMENT,
+  `mode` varchar(20) NOT NULL,
+  `status` varchar(10) NOT NULL,
+  `checkpoint` int(11) NOT NULL DEFAULT '0',
+  `creation_date` datetime NOT NULL,
+  `update_date` datetime NOT NULL,
+  PRIMARY KEY (`batch_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=latin1;
+
+CREATE TABLE IF NOT EXISTS `import_rows` (
+  `batch_id` int(11) NOT NULL,
+  `row_no` int(11) NOT NULL,
+  `account_id` varchar(11) NOT NULL DEFAULT '',
+  `amount` decimal(10,2) NOT NULL DEFAULT '0',
+  `transaction_type` varchar(10) NOT NULL DEFAULT '',
+  `status` varchar(10) NOT NULL,
+  `transaction_id` int(11) DEFAULT NULL,
+  `message` varchar(255) NOT NULL DEFAULT '',
+  `creation_date` datetime NOT NULL,
+  PRIMARY KEY (`batch_id`, `row_no`),
+  CONSTRAINT `import_rows_FK` FOREIGN KEY (`batch_id`) REFERENCES `import_batches` (`batch_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=latin1;
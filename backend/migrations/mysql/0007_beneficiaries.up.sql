@@ -0,0 +1,16 @@
+-- Beneficiaries (payees) that customers may transfer to. A beneficiary can only be transferred to once its cooling-off
+-- period, counted from cooling_off_start, is over, and its first transfer has a lower limit than the rest.
+
+CREATE TABLE IF NOT EXISTS `beneficiaries` (
+  `beneficiary_id` int(11) NOT NULL AUTO_INCREMENT,
+  `customer_id` int(11) NOT NULL,
+  `nickname` varchar(50) NOT NULL,
+  `account_id` varchar(34) NOT NULL,
+  `bank_code` varchar(11) DEFAULT NULL,
+  `creation_date` datetime NOT NULL,
+  `cooling_off_start` datetime NOT NULL,
+  `first_transfer_date` datetime DEFAULT NULL,
+  PRIMARY KEY (`beneficiary_id`),
+  KEY `beneficiaries_customer_idx` (`customer_id`, `account_id`),
+  CONSTRAINT `beneficiaries_FK` FOREIGN KEY (`customer_id`) REFERENCES `customers` (`customer_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=latin1;
@@ -0,0 +1,23 @@
+-- Cases opened by the fraud screening rules for transactions they did not allow. Transactions to be reviewed were made
+-- and are linked by transaction_id, while blocked transactions were not made. Admins close cases with a resolution.
+
+CREATE TABLE IF NOT EXISTS `fraud_cases` (
+  `case_id` int(11) NOT NULL AUTO_INCREMENT,
+  `customer_id` int(11) NOT NULL,
+  `account_id` int(11) NOT NULL,
+  `transaction_type` varchar(10) NOT NULL,
+  `amount` decimal(10,2) NOT NULL,
+  `transaction_id` int(11) DEFAULT NULL,
+  `decision` varchar(10) NOT NULL,
+  `rules` varchar(255) NOT NULL,
+  `results` text NOT NULL,
+  `status` varchar(10) NOT NULL,
+  `resolution` varchar(20) NOT NULL DEFAULT '',
+  `note` varchar(255) NOT NULL DEFAULT '',
+  `creation_date` datetime NOT NULL,
+  `resolution_date` datetime DEFAULT NULL,
+  PRIMARY KEY (`case_id`),
+  KEY `fraud_cases_status_idx` (`status`, `case_id`),
+  CONSTRAINT `fraud_cases_FK` FOREIGN KEY (`customer_id`) REFERENCES `customers` (`customer_id`),
+  CONSTRAINT `fraud_cases_FK_1` FOREIGN KEY (`account_id`) REFERENCES `accounts` (`account_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=latin1;
@@ -0,0 +1,31 @@
+-- Sanctions screening: the details each customer was last screened with, and the customers and outbound transfers that
+-- potentially match the watchlist. Customers with pending or confirmed hits are on hold until an admin clears them.
+
+CREATE TABLE IF NOT EXISTS `customer_screenings` (
+  `customer_id` int(11) NOT NULL,
+  `fingerprint` char(64) NOT NULL,
+  `screening_date` datetime NOT NULL,
+  PRIMARY KEY (`customer_id`),
+  CONSTRAINT `customer_screenings_FK` FOREIGN KEY (`customer_id`) REFERENCES `customers` (`customer_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=latin1;
+
+CREATE TABLE IF NOT EXISTS `sanctions_hits` (
+  `hit_id` int(11) NOT NULL AUTO_INCREMENT,
+  `customer_id` int(11) NOT NULL,
+  `kind` varchar(10) NOT NULL,
+  `account_id` int(11) DEFAULT NULL,
+  `destination_account_id` int(11) DEFAULT NULL,
+  `amount` decimal(10,2) NOT NULL DEFAULT 0,
+  `transaction_id` int(11) DEFAULT NULL,
+  `matches` text NOT NULL,
+  `status` varchar(10) NOT NULL,
+  `note` varchar(255) NOT NULL DEFAULT '',
+  `creation_date` datetime NOT NULL,
+  `resolution_date` datetime DEFAULT NULL,
+  PRIMARY KEY (`hit_id`),
+  KEY `sanctions_hits_status_idx` (`status`, `hit_id`),
+  KEY `sanctions_hits_customer_idx` (`customer_id`, `kind`, `status`),
+  CONSTRAINT `sanctions_hits_FK` FOREIGN KEY (`customer_id`) REFERENCES `customers` (`customer_id`),
+  CONSTRAINT `sanctions_hits_FK_1` FOREIGN KEY (`account_id`) REFERENCES `accounts` (`account_id`),
+  CONSTRAINT `sanctions_hits_FK_2` FOREIGN KEY (`destination_account_id`) REFERENCES `accounts` (`account_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=latin1;
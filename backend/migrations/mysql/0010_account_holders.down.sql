@@ -0,0 +1,2 @@
+ALTER TABLE `transactions` DROP FOREIGN KEY `transactions_FK_1`, DROP COLUMN `acting_customer_id`;
+DROP TABLE IF EXISTS `account_holders`;
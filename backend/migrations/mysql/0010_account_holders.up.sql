@@ -0,0 +1,19 @@
+-- Account holders: the customers who hold each account with their role (primary, joint or view_only), and the customer
+-- who made each transaction. The customer an account is opened for stays in accounts.customer_id as its primary holder.
+
+CREATE TABLE IF NOT EXISTS `account_holders` (
+  `account_id` int(11) NOT NULL,
+  `customer_id` int(11) NOT NULL,
+  `role` varchar(10) NOT NULL,
+  `creation_date` datetime NOT NULL,
+  PRIMARY KEY (`account_id`, `customer_id`),
+  KEY `account_holders_customer_idx` (`customer_id`),
+  CONSTRAINT `account_holders_FK` FOREIGN KEY (`account_id`) REFERENCES `accounts` (`account_id`),
+  CONSTRAINT `account_holders_FK_1` FOREIGN KEY (`customer_id`) REFERENCES `customers` (`customer_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=latin1;
+
+INSERT INTO `account_holders` (`account_id`, `customer_id`, `role`, `creation_date`)
+SELECT `account_id`, `customer_id`, 'primary', `opening_date` FROM `accounts`;
+
+ALTER TABLE `transactions` ADD COLUMN `acting_customer_id` int(11) DEFAULT NULL,
+  ADD CONSTRAINT `transactions_FK_1` FOREIGN KEY (`acting_customer_id`) REFERENCES `customers` (`customer_id`);
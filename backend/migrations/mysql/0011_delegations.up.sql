@@ -0,0 +1,20 @@
+-- Delegations: customers who may operate an account they do not hold, within a scope (view_only, deposit_only or
+-- withdraw, up to daily_limit a day) granted by its primary holder from start_date to end_date, unless revoked.
+
+CREATE TABLE IF NOT EXISTS `delegations` (
+  `delegation_id` int(11) NOT NULL AUTO_INCREMENT,
+  `account_id` int(11) NOT NULL,
+  `grantor_customer_id` int(11) NOT NULL,
+  `delegate_customer_id` int(11) NOT NULL,
+  `scope` varchar(12) NOT NULL,
+  `daily_limit` decimal(10,2) DEFAULT NULL,
+  `start_date` datetime NOT NULL,
+  `end_date` datetime NOT NULL,
+  `creation_date` datetime NOT NULL,
+  `revocation_date` datetime DEFAULT NULL,
+  PRIMARY KEY (`delegation_id`),
+  KEY `delegations_delegate_idx` (`delegate_customer_id`, `account_id`),
+  CONSTRAINT `delegations_FK` FOREIGN KEY (`account_id`) REFERENCES `accounts` (`account_id`),
+  CONSTRAINT `delegations_FK_1` FOREIGN KEY (`grantor_customer_id`) REFERENCES `customers` (`customer_id`),
+  CONSTRAINT `delegations_FK_2` FOREIGN KEY (`delegate_customer_id`) REFERENCES `customers` (`customer_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=latin1;
@@ -0,0 +1,134 @@
+-- Initial schema, the PostgreSQL equivalent of mysql/0001_initial_schema.up.sql.
+
+CREATE TABLE IF NOT EXISTS customers (
+  customer_id serial PRIMARY KEY,
+  name varchar(100) NOT NULL,
+  date_of_birth date NOT NULL,
+  email varchar(100) NOT NULL,
+  country varchar(100) NOT NULL,
+  zipcode varchar(10) NOT NULL,
+  status smallint NOT NULL DEFAULT 1
+);
+
+CREATE TABLE IF NOT EXISTS accounts (
+  account_id serial PRIMARY KEY,
+  customer_id integer NOT NULL REFERENCES customers (customer_id),
+  opening_date timestamp(0) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  account_type varchar(10) NOT NULL,
+  currency char(3) NOT NULL DEFAULT 'USD',
+  amount numeric(10,2) NOT NULL,
+  status smallint NOT NULL DEFAULT 1,
+  overdraft_limit numeric(10,2) NOT NULL DEFAULT 0,
+  held_amount numeric(10,2) NOT NULL DEFAULT 0
+);
+CREATE INDEX IF NOT EXISTS accounts_customer ON accounts (customer_id);
+
+CREATE TABLE IF NOT EXISTS transactions (
+  transaction_id serial PRIMARY KEY,
+  account_id integer NOT NULL REFERENCES accounts (account_id),
+  amount numeric(10,2) NOT NULL,
+  transaction_type varchar(20) NOT NULL,
+  transaction_date timestamp(0) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  fx_rate numeric(18,8) DEFAULT NULL,
+  converted_amount numeric(10,2) DEFAULT NULL
+);
+CREATE INDEX IF NOT EXISTS transactions_account ON transactions (account_id);
+
+CREATE TABLE IF NOT EXISTS transaction_reversals (
+  original_transaction_id integer PRIMARY KEY REFERENCES transactions (transaction_id),
+  reversal_transaction_id integer NOT NULL UNIQUE REFERENCES transactions (transaction_id),
+  reason_code varchar(20) NOT NULL,
+  note varchar(255) NOT NULL DEFAULT '',
+  reversal_date timestamp(0) NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS holds (
+  hold_id serial PRIMARY KEY,
+  account_id integer NOT NULL REFERENCES accounts (account_id),
+  amount numeric(10,2) NOT NULL,
+  captured_amount numeric(10,2) NOT NULL DEFAULT 0,
+  description varchar(255) NOT NULL DEFAULT '',
+  status varchar(10) NOT NULL,
+  creation_date timestamp(0) NOT NULL,
+  expiry_date timestamp(0) NOT NULL,
+  transaction_id integer DEFAULT NULL
+);
+CREATE INDEX IF NOT EXISTS holds_expiry ON holds (status, expiry_date);
+
+CREATE TABLE IF NOT EXISTS interest_accruals (
+  account_id integer NOT NULL REFERENCES accounts (account_id),
+  accrual_date date NOT NULL,
+  accrual_type varchar(20) NOT NULL,
+  balance numeric(10,2) NOT NULL,
+  annual_rate numeric(6,5) NOT NULL,
+  amount numeric(16,6) NOT NULL,
+  PRIMARY KEY (account_id, accrual_date, accrual_type)
+);
+
+CREATE TABLE IF NOT EXISTS interest_postings (
+  account_id integer NOT NULL REFERENCES accounts (account_id),
+  period char(7) NOT NULL,
+  posting_type varchar(20) NOT NULL,
+  amount numeric(10,2) NOT NULL,
+  transaction_id integer DEFAULT NULL,
+  PRIMARY KEY (account_id, period, posting_type)
+);
+
+CREATE TABLE IF NOT EXISTS standing_orders (
+  standing_order_id serial PRIMARY KEY,
+  customer_id integer NOT NULL,
+  account_id integer NOT NULL REFERENCES accounts (account_id),
+  destination_account_id integer NOT NULL,
+  amount numeric(10,2) NOT NULL,
+  schedule_type varchar(10) NOT NULL,
+  day_of_month smallint NOT NULL DEFAULT 0,
+  cron_expression varchar(100) NOT NULL DEFAULT '',
+  end_date date DEFAULT NULL,
+  max_occurrences integer NOT NULL DEFAULT 0,
+  occurrences integer NOT NULL DEFAULT 0,
+  next_run_date timestamp(0) NOT NULL,
+  retry_count integer NOT NULL DEFAULT 0,
+  status varchar(10) NOT NULL,
+  creation_date timestamp(0) NOT NULL
+);
+CREATE INDEX IF NOT EXISTS standing_orders_due ON standing_orders (status, next_run_date);
+
+CREATE TABLE IF NOT EXISTS standing_order_executions (
+  execution_id serial PRIMARY KEY,
+  standing_order_id integer NOT NULL REFERENCES standing_orders (standing_order_id),
+  scheduled_date timestamp(0) NOT NULL,
+  execution_date timestamp(0) NOT NULL,
+  status varchar(10) NOT NULL,
+  transaction_id integer DEFAULT NULL,
+  message varchar(255) NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS users (
+  username varchar(20) PRIMARY KEY,
+  password varchar(64) NOT NULL,
+  role varchar(20) NOT NULL,
+  customer_id integer DEFAULT NULL,
+  created_on timestamp(0) NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS registrations (
+  email varchar(100) PRIMARY KEY,
+  customer_id integer DEFAULT NULL,
+  name varchar(100) NOT NULL,
+  date_of_birth date NOT NULL,
+  country varchar(100) NOT NULL,
+  zipcode varchar(10) NOT NULL,
+  status smallint NOT NULL DEFAULT 0,
+  username varchar(20) NOT NULL,
+  password varchar(64) NOT NULL,
+  role varchar(20) NOT NULL,
+  email_attempts smallint NOT NULL DEFAULT 0,
+  created_on timestamp(0) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  last_emailed_on timestamp(0) DEFAULT NULL,
+  confirmed_on timestamp(0) DEFAULT NULL
+);
+
+CREATE TABLE IF NOT EXISTS refresh_token_store (
+  refresh_token char(64) PRIMARY KEY,
+  created_on timestamp DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,3 @@
+DELETE FROM users WHERE username IN ('admin','2000','2001');
+DELETE FROM accounts WHERE account_id IN (95470,95471,95472,95473);
+DELETE FROM customers WHERE customer_id IN (2000,2001,2002,2003,2004,2005);
@@ -0,0 +1,15 @@
+-- Domain events waiting to be published by the outbox relay, written in the same transaction as the change they
+-- describe. Events of the same aggregate are published in the order of their IDs.
+
+CREATE TABLE IF NOT EXISTS outbox (
+  event_id serial PRIMARY KEY,
+  event_type varchar(50) NOT NULL,
+  aggregate_type varchar(20) NOT NULL,
+  aggregate_id varchar(20) NOT NULL,
+  payload text NOT NULL,
+  occurred_on timestamp(0) NOT NULL,
+  published_on timestamp(0) DEFAULT NULL,
+  attempts integer NOT NULL DEFAULT 0,
+  last_error varchar(255) DEFAULT NULL
+);
+CREATE INDEX IF NOT EXISTS outbox_unpublished ON outbox (published_on, event_id);
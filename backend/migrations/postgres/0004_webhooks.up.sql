@@ -0,0 +1,30 @@
+-- Webhook subscriptions of customers, and the deliveries of events to them. Each event is delivered at most once per
+-- subscription, retried with exponential backoff until it succeeds or is given up on.
+
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+  subscription_id serial PRIMARY KEY,
+  customer_id integer NOT NULL REFERENCES customers (customer_id),
+  url varchar(255) NOT NULL,
+  event_types varchar(255) NOT NULL DEFAULT '',
+  secret varchar(64) NOT NULL,
+  status varchar(10) NOT NULL,
+  creation_date timestamp(0) NOT NULL
+);
+CREATE INDEX IF NOT EXISTS webhook_subscriptions_customer ON webhook_subscriptions (customer_id);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+  delivery_id serial PRIMARY KEY,
+  subscription_id integer NOT NULL REFERENCES webhook_subscriptions (subscription_id),
+  event_id integer NOT NULL,
+  event_type varchar(50) NOT NULL,
+  payload text NOT NULL,
+  status varchar(10) NOT NULL,
+  attempts integer NOT NULL DEFAULT 0,
+  next_attempt_date timestamp(0) NOT NULL,
+  last_attempt_date timestamp(0) DEFAULT NULL,
+  response_code integer DEFAULT NULL,
+  last_error varchar(255) DEFAULT NULL,
+  creation_date timestamp(0) NOT NULL,
+  UNIQUE (subscription_id, event_id)
+);
+CREATE INDEX IF NOT EXISTS webhook_deliveries_due ON webhook_deliveries (status, next_attempt_date);
@@ -0,0 +1,19 @@
+-- Append-only log of state-changing requests. Each entry holds the hash of the entry before it, and the unique
+-- constraint on previous_hash stops two entries from being chained to the same one.
+
+CREATE TABLE IF NOT EXISTS audit_log (
+  entry_id serial PRIMARY KEY,
+  actor_role varchar(10) NOT NULL DEFAULT '',
+  actor_customer_id varchar(11) NOT NULL DEFAULT '',
+  route_name varchar(50) NOT NULL,
+  route_vars varchar(255) NOT NULL,
+  request_hash char(64) NOT NULL,
+  status_code integer NOT NULL,
+  outcome varchar(10) NOT NULL,
+  ip_address varchar(45) NOT NULL,
+  created_on timestamp(0) NOT NULL,
+  previous_hash char(64) NOT NULL UNIQUE,
+  entry_hash char(64) NOT NULL
+);
+CREATE INDEX IF NOT EXISTS audit_log_actor ON audit_log (actor_customer_id);
+CREATE INDEX IF NOT EXISTS audit_log_created ON audit_log (created_on);
@@ -0,0 +1,24 @@
+-- Batches of transactions imported from files, and the result of each row of a batch. A row has at most one result,
+-- which is saved together with its transaction, so that a resumed batch never posts a row twice.
+
+CREATE TABLE IF NOT EXISTS import_batches (
+  batch_id serial PRIMARY KEY,
+  mode varchar(20) NOT NULL,
+  status varchar(10) NOT NULL,
+  checkpoint integer NOT NULL DEFAULT 0,
+  creation_date timestamp(0) NOT NULL,
+  update_date timestamp(0) NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS import_rows (
+  batch_id integer NOT NULL REFERENCES import_batches (batch_id),
+  row_no integer NOT NULL,
+  account_id varchar(11) NOT NULL DEFAULT '',
+  amount numeric(10,2) NOT NULL DEFAULT 0,
+  transaction_type varchar(10) NOT NULL DEFAULT '',
+  status varchar(10) NOT NULL,
+  transaction_id integer DEFAULT NULL,
+  message varchar(255) NOT NULL DEFAULT '',
+  creation_date timestamp(0) NOT NULL,
+  PRIMARY KEY (batch_id, row_no)
+);
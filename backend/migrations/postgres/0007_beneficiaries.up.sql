@@ -0,0 +1,15 @@
+-- Beneficiaries (payees) that customers may transfer to. A beneficiary can only be transferred to once its cooling-off
+-- period, counted from cooling_off_start, is over, and its first transfer has a lower limit than the rest.
+
+CREATE TABLE IF NOT EXISTS beneficiaries (
+  beneficiary_id serial PRIMARY KEY,
+  customer_id integer NOT NULL REFERENCES customers (customer_id),
+  nickname varchar(50) NOT NULL,
+  account_id varchar(34) NOT NULL,
+  bank_code varchar(11) DEFAULT NULL,
+  creation_date timestamp(0) NOT NULL,
+  cooling_off_start timestamp(0) NOT NULL,
+  first_transfer_date timestamp(0) DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS beneficiaries_customer_idx ON beneficiaries (customer_id, account_id);
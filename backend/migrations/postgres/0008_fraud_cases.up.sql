@@ -0,0 +1,21 @@
+-- Cases opened by the fraud screening rules for transactions they did not allow. Transactions to be reviewed were made
+-- and are linked by transaction_id, while blocked transactions were not made. Admins close cases with a resolution.
+
+CREATE TABLE IF NOT EXISTS fraud_cases (
+  case_id serial PRIMARY KEY,
+  customer_id integer NOT NULL REFERENCES customers (customer_id),
+  account_id integer NOT NULL REFERENCES accounts (account_id),
+  transaction_type varchar(10) NOT NULL,
+  amount numeric(10,2) NOT NULL,
+  transaction_id integer DEFAULT NULL REFERENCES transactions (transaction_id),
+  decision varchar(10) NOT NULL,
+  rules varchar(255) NOT NULL,
+  results text NOT NULL,
+  status varchar(10) NOT NULL,
+  resolution varchar(20) NOT NULL DEFAULT '',
+  note varchar(255) NOT NULL DEFAULT '',
+  creation_date timestamp(0) NOT NULL,
+  resolution_date timestamp(0) DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS fraud_cases_status_idx ON fraud_cases (status, case_id);
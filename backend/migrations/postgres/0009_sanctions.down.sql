@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS sanctions_hits;
+DROP TABLE IF EXISTS customer_screenings;
@@ -0,0 +1,26 @@
+-- Sanctions screening: the details each customer was last screened with, and the customers and outbound transfers that
+-- potentially match the watchlist. Customers with pending or confirmed hits are on hold until an admin clears them.
+
+CREATE TABLE IF NOT EXISTS customer_screenings (
+  customer_id integer PRIMARY KEY REFERENCES customers (customer_id),
+  fingerprint char(64) NOT NULL,
+  screening_date timestamp(0) NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS sanctions_hits (
+  hit_id serial PRIMARY KEY,
+  customer_id integer NOT NULL REFERENCES customers (customer_id),
+  kind varchar(10) NOT NULL,
+  account_id integer DEFAULT NULL REFERENCES accounts (account_id),
+  destination_account_id integer DEFAULT NULL REFERENCES accounts (account_id),
+  amount numeric(10,2) NOT NULL DEFAULT 0,
+  transaction_id integer DEFAULT NULL REFERENCES transactions (transaction_id),
+  matches text NOT NULL,
+  status varchar(10) NOT NULL,
+  note varchar(255) NOT NULL DEFAULT '',
+  creation_date timestamp(0) NOT NULL,
+  resolution_date timestamp(0) DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS sanctions_hits_status_idx ON sanctions_hits (status, hit_id);
+CREATE INDEX IF NOT EXISTS sanctions_hits_customer_idx ON sanctions_hits (customer_id, kind, status);
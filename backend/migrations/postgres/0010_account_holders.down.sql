@@ -0,0 +1,2 @@
+ALTER TABLE transactions DROP COLUMN acting_customer_id;
+DROP TABLE IF EXISTS account_holders;
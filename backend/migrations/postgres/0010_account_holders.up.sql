@@ -0,0 +1,17 @@
+-- Account holders: the customers who hold each account with their role (primary, joint or view_only), and the customer
+-- who made each transaction. The customer an account is opened for stays in accounts.customer_id as its primary holder.
+
+CREATE TABLE IF NOT EXISTS account_holders (
+  account_id integer NOT NULL REFERENCES accounts (account_id),
+  customer_id integer NOT NULL REFERENCES customers (customer_id),
+  role varchar(10) NOT NULL,
+  creation_date timestamp(0) NOT NULL,
+  PRIMARY KEY (account_id, customer_id)
+);
+
+CREATE INDEX IF NOT EXISTS account_holders_customer_idx ON account_holders (customer_id);
+
+INSERT INTO account_holders (account_id, customer_id, role, creation_date)
+SELECT account_id, customer_id, 'primary', opening_date FROM accounts;
+
+ALTER TABLE transactions ADD COLUMN acting_customer_id integer DEFAULT NULL REFERENCES customers (customer_id);
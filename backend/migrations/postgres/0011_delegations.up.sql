@@ -0,0 +1,17 @@
+-- Delegations: customers who may operate an account they do not hold, within a scope (view_only, deposit_only or
+-- withdraw, up to daily_limit a day) granted by its primary holder from start_date to end_date, unless revoked.
+
+CREATE TABLE IF NOT EXISTS delegations (
+  delegation_id serial PRIMARY KEY,
+  account_id integer NOT NULL REFERENCES accounts (account_id),
+  grantor_customer_id integer NOT NULL REFERENCES customers (customer_id),
+  delegate_customer_id integer NOT NULL REFERENCES customers (customer_id),
+  scope varchar(12) NOT NULL,
+  daily_limit numeric(10,2) DEFAULT NULL,
+  start_date timestamp(0) NOT NULL,
+  end_date timestamp(0) NOT NULL,
+  creation_date timestamp(0) NOT NULL,
+  revocation_date timestamp(0) DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS delegations_delegate_idx ON delegations (delegate_customer_id, account_id);
@@ -0,0 +1,12 @@
+DROP TABLE IF EXISTS refresh_token_store;
+DROP TABLE IF EXISTS registrations;
+DROP TABLE IF EXISTS users;
+DROP TABLE IF EXISTS standing_order_executions;
+DROP TABLE IF EXISTS standing_orders;
+DROP TABLE IF EXISTS interest_postings;
+DROP TABLE IF EXISTS interest_accruals;
+DROP TABLE IF EXISTS holds;
+DROP TABLE IF EXISTS transaction_reversals;
+DROP TABLE IF EXISTS transactions;
+DROP TABLE IF EXISTS accounts;
+DROP TABLE IF EXISTS customers;
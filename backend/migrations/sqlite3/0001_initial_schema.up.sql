@@ -0,0 +1,138 @@
+-- Initial schema, the SQLite equivalent of the MySQL schema. Dates are stored as text in the same format that MySQL
+-- returns them in ("2006-01-02 15:04:05"), so that they sort and compare the same way, and amounts are stored as reals.
+
+CREATE TABLE IF NOT EXISTS customers (
+  customer_id integer PRIMARY KEY AUTOINCREMENT,
+  name text NOT NULL,
+  date_of_birth text NOT NULL,
+  email text NOT NULL,
+  country text NOT NULL,
+  zipcode text NOT NULL,
+  status integer NOT NULL DEFAULT 1
+);
+
+CREATE TABLE IF NOT EXISTS accounts (
+  account_id integer PRIMARY KEY AUTOINCREMENT,
+  customer_id integer NOT NULL REFERENCES customers (customer_id),
+  opening_date text NOT NULL DEFAULT (datetime('now')),
+  account_type text NOT NULL,
+  currency text NOT NULL DEFAULT 'USD',
+  amount real NOT NULL,
+  status integer NOT NULL DEFAULT 1,
+  overdraft_limit real NOT NULL DEFAULT 0,
+  held_amount real NOT NULL DEFAULT 0
+);
+CREATE INDEX IF NOT EXISTS accounts_FK ON accounts (customer_id);
+
+CREATE TABLE IF NOT EXISTS transactions (
+  transaction_id integer PRIMARY KEY AUTOINCREMENT,
+  account_id integer NOT NULL REFERENCES accounts (account_id),
+  amount real NOT NULL,
+  transaction_type text NOT NULL,
+  transaction_date text NOT NULL DEFAULT (datetime('now')),
+  fx_rate real DEFAULT NULL,
+  converted_amount real DEFAULT NULL
+);
+CREATE INDEX IF NOT EXISTS transactions_FK ON transactions (account_id);
+
+CREATE TABLE IF NOT EXISTS transaction_reversals (
+  original_transaction_id integer NOT NULL PRIMARY KEY REFERENCES transactions (transaction_id),
+  reversal_transaction_id integer NOT NULL UNIQUE REFERENCES transactions (transaction_id),
+  reason_code text NOT NULL,
+  note text NOT NULL DEFAULT '',
+  reversal_date text NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS holds (
+  hold_id integer PRIMARY KEY AUTOINCREMENT,
+  account_id integer NOT NULL REFERENCES accounts (account_id),
+  amount real NOT NULL,
+  captured_amount real NOT NULL DEFAULT 0,
+  description text NOT NULL DEFAULT '',
+  status text NOT NULL,
+  creation_date text NOT NULL,
+  expiry_date text NOT NULL,
+  transaction_id integer DEFAULT NULL
+);
+CREATE INDEX IF NOT EXISTS holds_FK ON holds (account_id);
+CREATE INDEX IF NOT EXISTS holds_expiry ON holds (status, expiry_date);
+
+CREATE TABLE IF NOT EXISTS interest_accruals (
+  account_id integer NOT NULL REFERENCES accounts (account_id),
+  accrual_date text NOT NULL,
+  accrual_type text NOT NULL,
+  balance real NOT NULL,
+  annual_rate real NOT NULL,
+  amount real NOT NULL,
+  PRIMARY KEY (account_id, accrual_date, accrual_type)
+);
+
+CREATE TABLE IF NOT EXISTS interest_postings (
+  account_id integer NOT NULL REFERENCES accounts (account_id),
+  period text NOT NULL,
+  posting_type text NOT NULL,
+  amount real NOT NULL,
+  transaction_id integer DEFAULT NULL,
+  PRIMARY KEY (account_id, period, posting_type)
+);
+
+CREATE TABLE IF NOT EXISTS standing_orders (
+  standing_order_id integer PRIMARY KEY AUTOINCREMENT,
+  customer_id integer NOT NULL,
+  account_id integer NOT NULL REFERENCES accounts (account_id),
+  destination_account_id integer NOT NULL,
+  amount real NOT NULL,
+  schedule_type text NOT NULL,
+  day_of_month integer NOT NULL DEFAULT 0,
+  cron_expression text NOT NULL DEFAULT '',
+  end_date text DEFAULT NULL,
+  max_occurrences integer NOT NULL DEFAULT 0,
+  occurrences integer NOT NULL DEFAULT 0,
+  next_run_date text NOT NULL,
+  retry_count integer NOT NULL DEFAULT 0,
+  status text NOT NULL,
+  creation_date text NOT NULL
+);
+CREATE INDEX IF NOT EXISTS standing_orders_FK ON standing_orders (account_id);
+CREATE INDEX IF NOT EXISTS standing_orders_due ON standing_orders (status, next_run_date);
+
+CREATE TABLE IF NOT EXISTS standing_order_executions (
+  execution_id integer PRIMARY KEY AUTOINCREMENT,
+  standing_order_id integer NOT NULL REFERENCES standing_orders (standing_order_id),
+  scheduled_date text NOT NULL,
+  execution_date text NOT NULL,
+  status text NOT NULL,
+  transaction_id integer DEFAULT NULL,
+  message text NOT NULL DEFAULT ''
+);
+CREATE INDEX IF NOT EXISTS standing_order_executions_FK ON standing_order_executions (standing_order_id);
+
+CREATE TABLE IF NOT EXISTS users (
+  username text NOT NULL PRIMARY KEY,
+  password text NOT NULL,
+  role text NOT NULL,
+  customer_id integer DEFAULT NULL,
+  created_on text NOT NULL DEFAULT (datetime('now'))
+);
+
+CREATE TABLE IF NOT EXISTS registrations (
+  email text NOT NULL PRIMARY KEY,
+  customer_id integer DEFAULT NULL,
+  name text NOT NULL,
+  date_of_birth text NOT NULL,
+  country text NOT NULL,
+  zipcode text NOT NULL,
+  status integer NOT NULL DEFAULT 0,
+  username text NOT NULL,
+  password text NOT NULL,
+  role text NOT NULL,
+  email_attempts integer NOT NULL DEFAULT 0,
+  created_on text NOT NULL DEFAULT (datetime('now')),
+  last_emailed_on text DEFAULT NULL,
+  confirmed_on text DEFAULT NULL
+);
+
+CREATE TABLE IF NOT EXISTS refresh_token_store (
+  refresh_token text NOT NULL PRIMARY KEY,
+  created_on text DEFAULT (datetime('now'))
+);
@@ -0,0 +1,21 @@
+-- Demo customers, accounts and users. Rows that already exist are left as they are.
+
+INSERT OR IGNORE INTO customers VALUES
+  (2000,'Steve','1978-12-15','steve.jobs@somemail.com','India','110075',1),
+  (2001,'Arian','1988-05-21','arian@somemail.com','United States','12550',1),
+  (2002,'Hadley','1988-04-30','sir_hadley@somemail.com','Norway','07631',1),
+  (2003,'Ben','1988-01-04','ben_cumberbatch@somemail.com','United Kingdom','03102',0),
+  (2004,'Nina','1988-05-14','ninadobrev@somemail.com','Canada','48348',1),
+  (2005,'Osman','1988-11-08','osman@somemail.com','Iran','20782',0);
+
+INSERT OR IGNORE INTO accounts VALUES
+  (95470,2000,'2020-08-22 10:20:06','saving','USD',6823.23,1,0,0),
+  (95471,2002,'2020-08-09 10:27:22','checking','USD',3342.96,1,500,0),
+  (95472,2001,'2020-08-09 10:35:22','saving','USD',7000,1,0,0),
+  (95473,2001,'2020-08-09 10:38:22','saving','USD',5861.86,1,0,0);
+
+-- all passwords are currently "abc123" (hashed)
+INSERT OR IGNORE INTO users VALUES
+  ('admin','$2a$10$OAN0NrrYwvvWfPwgCS6Dd.ftzc1QxV84pW8GL2QCa6K.P63aK4og6','admin',NULL,'2020-08-09 10:27:22'),
+  ('2001','$2a$10$f88KJdHGTPr8B4CNpcjtTuAH41XyosuZtRowuqzGutrHOOIJz.vti','user',2001,'2020-08-09 10:27:22'),
+  ('2000','$2a$10$L165AfBDM93hKbXYxF9dg.jO/2.jgGViOF0ZbP7pIED6CvRtEdjk2','user',2000,'2020-08-09 10:27:22');
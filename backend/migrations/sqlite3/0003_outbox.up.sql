@@ -0,0 +1,15 @@
+-- Domain events waiting to be published by the outbox relay, written in the same transaction as the change they
+-- describe. Events of the same aggregate are published in the order of their IDs.
+
+CREATE TABLE IF NOT EXISTS outbox (
+  event_id integer PRIMARY KEY AUTOINCREMENT,
+  event_type text NOT NULL,
+  aggregate_type text NOT NULL,
+  aggregate_id text NOT NULL,
+  payload text NOT NULL,
+  occurred_on text NOT NULL,
+  published_on text DEFAULT NULL,
+  attempts integer NOT NULL DEFAULT 0,
+  last_error text DEFAULT NULL
+);
+CREATE INDEX IF NOT EXISTS outbox_unpublished ON outbox (published_on, event_id);
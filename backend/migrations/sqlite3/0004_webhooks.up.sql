@@ -0,0 +1,30 @@
+-- Webhook subscriptions of customers, and the deliveries of events to them. Each event is delivered at most once per
+-- subscription, retried with exponential backoff until it succeeds or is given up on.
+
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+  subscription_id integer PRIMARY KEY AUTOINCREMENT,
+  customer_id integer NOT NULL REFERENCES customers (customer_id),
+  url text NOT NULL,
+  event_types text NOT NULL DEFAULT '',
+  secret text NOT NULL,
+  status text NOT NULL,
+  creation_date text NOT NULL
+);
+CREATE INDEX IF NOT EXISTS webhook_subscriptions_customer ON webhook_subscriptions (customer_id);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+  delivery_id integer PRIMARY KEY AUTOINCREMENT,
+  subscription_id integer NOT NULL REFERENCES webhook_subscriptions (subscription_id),
+  event_id integer NOT NULL,
+  event_type text NOT NULL,
+  payload text NOT NULL,
+  status text NOT NULL,
+  attempts integer NOT NULL DEFAULT 0,
+  next_attempt_date text NOT NULL,
+  last_attempt_date text DEFAULT NULL,
+  response_code integer DEFAULT NULL,
+  last_error text DEFAULT NULL,
+  creation_date text NOT NULL,
+  UNIQUE (subscription_id, event_id)
+);
+CREATE INDEX IF NOT EXISTS webhook_deliveries_due ON webhook_deliveries (status, next_attempt_date);
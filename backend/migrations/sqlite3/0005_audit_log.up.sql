@@ -0,0 +1,19 @@
+-- Append-only log of state-changing requests. Each entry holds the hash of the entry before it, and the unique
+-- constraint on previous_hash stops two entries from being chained to the same one.
+
+CREATE TABLE IF NOT EXISTS audit_log (
+  entry_id integer PRIMARY KEY AUTOINCREMENT,
+  actor_role text NOT NULL DEFAULT '',
+  actor_customer_id text NOT NULL DEFAULT '',
+  route_name text NOT NULL,
+  route_vars text NOT NULL,
+  request_hash text NOT NULL,
+  status_code integer NOT NULL,
+  outcome text NOT NULL,
+  ip_address text NOT NULL,
+  created_on text NOT NULL,
+  previous_hash text NOT NULL UNIQUE,
+  entry_hash text NOT NULL
+);
+CREATE INDEX IF NOT EXISTS audit_log_actor ON audit_log (actor_customer_id);
+CREATE INDEX IF NOT EXISTS audit_log_created ON audit_log (created_on);
@@ -0,0 +1,24 @@
+-- Batches of transactions imported from files, and the result of each row of a batch. A row has at most one result,
+-- which is saved together with its transaction, so that a resumed batch never posts a row twice.
+
+CREATE TABLE IF NOT EXISTS import_batches (
+  batch_id integer PRIMARY KEY AUTOINCREMENT,
+  mode text NOT NULL,
+  status text NOT NULL,
+  checkpoint integer NOT NULL DEFAULT 0,
+  creation_date text NOT NULL,
+  update_date text NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS import_rows (
+  batch_id integer NOT NULL REFERENCES import_batches (batch_id),
+  row_no integer NOT NULL,
+  account_id text NOT NULL DEFAULT '',
+  amount real NOT NULL DEFAULT 0,
+  transaction_type text NOT NULL DEFAULT '',
+  status text NOT NULL,
+  transaction_id integer DEFAULT NULL,
+  message text NOT NULL DEFAULT '',
+  creation_date text NOT NULL,
+  PRIMARY KEY (batch_id, row_no)
+);
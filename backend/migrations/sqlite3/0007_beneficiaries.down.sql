@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS beneficiaries_customer_idx;
+DROP TABLE IF EXISTS beneficiaries;
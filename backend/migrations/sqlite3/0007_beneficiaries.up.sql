@@ -0,0 +1,15 @@
+-- Beneficiaries (payees) that customers may transfer to. A beneficiary can only be transferred to once its cooling-off
+-- period, counted from cooling_off_start, is over, and its first transfer has a lower limit than the rest.
+
+CREATE TABLE IF NOT EXISTS beneficiaries (
+  beneficiary_id integer PRIMARY KEY AUTOINCREMENT,
+  customer_id integer NOT NULL REFERENCES customers (customer_id),
+  nickname text NOT NULL,
+  account_id text NOT NULL,
+  bank_code text DEFAULT NULL,
+  creation_date text NOT NULL,
+  cooling_off_start text NOT NULL,
+  first_transfer_date text DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS beneficiaries_customer_idx ON beneficiaries (customer_id, account_id);
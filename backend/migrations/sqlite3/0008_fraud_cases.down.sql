@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS fraud_cases_status_idx;
+DROP TABLE IF EXISTS fraud_cases;
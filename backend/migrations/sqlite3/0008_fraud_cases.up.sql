@@ -0,0 +1,21 @@
+-- Cases opened by the fraud screening rules for transactions they did not allow. Transactions to be reviewed were made
+-- and are linked by transaction_id, while blocked transactions were not made. Admins close cases with a resolution.
+
+CREATE TABLE IF NOT EXISTS fraud_cases (
+  case_id integer PRIMARY KEY AUTOINCREMENT,
+  customer_id integer NOT NULL REFERENCES customers (customer_id),
+  account_id integer NOT NULL REFERENCES accounts (account_id),
+  transaction_type text NOT NULL,
+  amount real NOT NULL,
+  transaction_id integer DEFAULT NULL REFERENCES transactions (transaction_id),
+  decision text NOT NULL,
+  rules text NOT NULL,
+  results text NOT NULL,
+  status text NOT NULL,
+  resolution text NOT NULL DEFAULT '',
+  note text NOT NULL DEFAULT '',
+  creation_date text NOT NULL,
+  resolution_date text DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS fraud_cases_status_idx ON fraud_cases (status, case_id);
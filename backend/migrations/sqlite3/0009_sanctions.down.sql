@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS sanctions_hits_customer_idx;
+DROP INDEX IF EXISTS sanctions_hits_status_idx;
+DROP TABLE IF EXISTS sanctions_hits;
+DROP TABLE IF EXISTS customer_screenings;
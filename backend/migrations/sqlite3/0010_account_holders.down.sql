@@ -0,0 +1,3 @@
+ALTER TABLE transactions DROP COLUMN acting_customer_id;
+DROP INDEX IF EXISTS account_holders_customer_idx;
+DROP TABLE IF EXISTS account_holders;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS delegations_delegate_idx;
+DROP TABLE IF EXISTS delegations;
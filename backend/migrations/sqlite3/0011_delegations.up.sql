@@ -0,0 +1,17 @@
+-- Delegations: customers who may operate an account they do not hold, within a scope (view_only, deposit_only or
+-- withdraw, up to daily_limit a day) granted by its primary holder from start_date to end_date, unless revoked.
+
+CREATE TABLE IF NOT EXISTS delegations (
+  delegation_id integer PRIMARY KEY AUTOINCREMENT,
+  account_id integer NOT NULL REFERENCES accounts (account_id),
+  grantor_customer_id integer NOT NULL REFERENCES customers (customer_id),
+  delegate_customer_id integer NOT NULL REFERENCES customers (customer_id),
+  scope text NOT NULL,
+  daily_limit real DEFAULT NULL,
+  start_date text NOT NULL,
+  end_date text NOT NULL,
+  creation_date text NOT NULL,
+  revocation_date text DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS delegations_delegate_idx ON delegations (delegate_customer_id, account_id);
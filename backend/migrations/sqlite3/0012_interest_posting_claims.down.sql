@@ -0,0 +1 @@
+ALTER TABLE interest_postings DROP COLUMN claimed_on;
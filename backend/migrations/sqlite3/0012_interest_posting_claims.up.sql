@@ -0,0 +1,4 @@
+-- Interest postings: when each was claimed. A claim is made in the same database transaction as the transaction that
+-- posts it, so a claim that exists was always completed.
+
+ALTER TABLE interest_postings ADD COLUMN claimed_on text DEFAULT NULL;
@@ -0,0 +1,3 @@
+-- Standing orders: when the pending occurrence is attempted again after an attempt failed, a day after that attempt.
+
+ALTER TABLE standing_orders ADD COLUMN retry_date text DEFAULT NULL;
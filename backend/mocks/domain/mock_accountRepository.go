@@ -65,6 +65,66 @@ func (mr *MockAccountRepositoryMockRecorder) FindById(arg0 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockAccountRepository)(nil).FindById), arg0)
 }
 
+// FindHolder mocks base method.
+func (m *MockAccountRepository) FindHolder(arg0, arg1 string) (*domain.AccountHolder, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindHolder", arg0, arg1)
+	ret0, _ := ret[0].(*domain.AccountHolder)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// FindHolder indicates an expected call of FindHolder.
+func (mr *MockAccountRepositoryMockRecorder) FindHolder(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindHolder", reflect.TypeOf((*MockAccountRepository)(nil).FindHolder), arg0, arg1)
+}
+
+// FindTransactionById mocks base method.
+func (m *MockAccountRepository) FindTransactionById(arg0 string) (*domain.Transaction, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTransactionById", arg0)
+	ret0, _ := ret[0].(*domain.Transaction)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// FindTransactionById indicates an expected call of FindTransactionById.
+func (mr *MockAccountRepositoryMockRecorder) FindTransactionById(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTransactionById", reflect.TypeOf((*MockAccountRepository)(nil).FindTransactionById), arg0)
+}
+
+// FindTransactions mocks base method.
+func (m *MockAccountRepository) FindTransactions(arg0 string) ([]domain.Transaction, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTransactions", arg0)
+	ret0, _ := ret[0].([]domain.Transaction)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// FindTransactions indicates an expected call of FindTransactions.
+func (mr *MockAccountRepositoryMockRecorder) FindTransactions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTransactions", reflect.TypeOf((*MockAccountRepository)(nil).FindTransactions), arg0)
+}
+
+// Reverse mocks base method.
+func (m *MockAccountRepository) Reverse(arg0 domain.Transaction, arg1 domain.Reversal) (*domain.Transaction, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reverse", arg0, arg1)
+	ret0, _ := ret[0].(*domain.Transaction)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// Reverse indicates an expected call of Reverse.
+func (mr *MockAccountRepositoryMockRecorder) Reverse(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reverse", reflect.TypeOf((*MockAccountRepository)(nil).Reverse), arg0, arg1)
+}
+
 // Save mocks base method.
 func (m *MockAccountRepository) Save(arg0 domain.Account) (*domain.Account, *errs.AppError) {
 	m.ctrl.T.Helper()
@@ -94,3 +154,32 @@ func (mr *MockAccountRepositoryMockRecorder) Transact(arg0 interface{}) *gomock.
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transact", reflect.TypeOf((*MockAccountRepository)(nil).Transact), arg0)
 }
+
+// Transfer mocks base method.
+func (m *MockAccountRepository) Transfer(arg0, arg1 domain.Transaction) (*domain.Transaction, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transfer", arg0, arg1)
+	ret0, _ := ret[0].(*domain.Transaction)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// Transfer indicates an expected call of Transfer.
+func (mr *MockAccountRepositoryMockRecorder) Transfer(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transfer", reflect.TypeOf((*MockAccountRepository)(nil).Transfer), arg0, arg1)
+}
+
+// UpdateOverdraftLimit mocks base method.
+func (m *MockAccountRepository) UpdateOverdraftLimit(arg0 string, arg1 float64) *errs.AppError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOverdraftLimit", arg0, arg1)
+	ret0, _ := ret[0].(*errs.AppError)
+	return ret0
+}
+
+// UpdateOverdraftLimit indicates an expected call of UpdateOverdraftLimit.
+func (mr *MockAccountRepositoryMockRecorder) UpdateOverdraftLimit(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOverdraftLimit", reflect.TypeOf((*MockAccountRepository)(nil).UpdateOverdraftLimit), arg0, arg1)
+}
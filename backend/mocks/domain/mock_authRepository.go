@@ -8,6 +8,7 @@ import (
 	reflect "reflect"
 
 	errs "github.com/aliciatay-zls/banking-lib/errs"
+	domain "github.com/aliciatay-zls/banking/backend/domain"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -35,11 +36,12 @@ func (m *MockAuthRepository) EXPECT() *MockAuthRepositoryMockRecorder {
 }
 
 // IsAuthorized mocks base method.
-func (m *MockAuthRepository) IsAuthorized(arg0, arg1 string, arg2 map[string]string) *errs.AppError {
+func (m *MockAuthRepository) IsAuthorized(arg0, arg1 string, arg2 map[string]string) (*domain.Actor, *errs.AppError) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "IsAuthorized", arg0, arg1, arg2)
-	ret0, _ := ret[0].(*errs.AppError)
-	return ret0
+	ret0, _ := ret[0].(*domain.Actor)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
 }
 
 // IsAuthorized indicates an expected call of IsAuthorized.
@@ -65,6 +65,21 @@ func (mr *MockAccountServiceMockRecorder) GetAllAccounts(arg0 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllAccounts", reflect.TypeOf((*MockAccountService)(nil).GetAllAccounts), arg0)
 }
 
+// GetTransactions mocks base method.
+func (m *MockAccountService) GetTransactions(arg0, arg1 string) ([]dto.TransactionDetailResponse, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransactions", arg0, arg1)
+	ret0, _ := ret[0].([]dto.TransactionDetailResponse)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// GetTransactions indicates an expected call of GetTransactions.
+func (mr *MockAccountServiceMockRecorder) GetTransactions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactions", reflect.TypeOf((*MockAccountService)(nil).GetTransactions), arg0, arg1)
+}
+
 // MakeTransaction mocks base method.
 func (m *MockAccountService) MakeTransaction(arg0 dto.TransactionRequest) (*dto.TransactionResponse, *errs.AppError) {
 	m.ctrl.T.Helper()
@@ -79,3 +94,48 @@ func (mr *MockAccountServiceMockRecorder) MakeTransaction(arg0 interface{}) *gom
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MakeTransaction", reflect.TypeOf((*MockAccountService)(nil).MakeTransaction), arg0)
 }
+
+// MakeTransfer mocks base method.
+func (m *MockAccountService) MakeTransfer(arg0 dto.TransferRequest) (*dto.TransactionResponse, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MakeTransfer", arg0)
+	ret0, _ := ret[0].(*dto.TransactionResponse)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// MakeTransfer indicates an expected call of MakeTransfer.
+func (mr *MockAccountServiceMockRecorder) MakeTransfer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MakeTransfer", reflect.TypeOf((*MockAccountService)(nil).MakeTransfer), arg0)
+}
+
+// ReverseTransaction mocks base method.
+func (m *MockAccountService) ReverseTransaction(arg0 dto.ReversalRequest) (*dto.TransactionResponse, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReverseTransaction", arg0)
+	ret0, _ := ret[0].(*dto.TransactionResponse)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// ReverseTransaction indicates an expected call of ReverseTransaction.
+func (mr *MockAccountServiceMockRecorder) ReverseTransaction(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReverseTransaction", reflect.TypeOf((*MockAccountService)(nil).ReverseTransaction), arg0)
+}
+
+// SetOverdraftLimit mocks base method.
+func (m *MockAccountService) SetOverdraftLimit(arg0 dto.OverdraftRequest) (*dto.AccountResponse, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetOverdraftLimit", arg0)
+	ret0, _ := ret[0].(*dto.AccountResponse)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// SetOverdraftLimit indicates an expected call of SetOverdraftLimit.
+func (mr *MockAccountServiceMockRecorder) SetOverdraftLimit(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOverdraftLimit", reflect.TypeOf((*MockAccountService)(nil).SetOverdraftLimit), arg0)
+}
@@ -64,3 +64,18 @@ func (mr *MockCustomerServiceMockRecorder) GetCustomer(arg0 interface{}) *gomock
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomer", reflect.TypeOf((*MockCustomerService)(nil).GetCustomer), arg0)
 }
+
+// SetCustomerStatus mocks base method.
+func (m *MockCustomerService) SetCustomerStatus(arg0 dto.CustomerStatusRequest) (*dto.CustomerResponse, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCustomerStatus", arg0)
+	ret0, _ := ret[0].(*dto.CustomerResponse)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// SetCustomerStatus indicates an expected call of SetCustomerStatus.
+func (mr *MockCustomerServiceMockRecorder) SetCustomerStatus(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCustomerStatus", reflect.TypeOf((*MockCustomerService)(nil).SetCustomerStatus), arg0)
+}
@@ -0,0 +1,18 @@
+#!/bin/bash
+# Set environment variables for the session, using an in-process SQLite database instead of MySQL
+export APP_ENV="development"
+
+export SERVER_ADDRESS="localhost"
+export SERVER_PORT="8080"
+export SERVER_DOMAIN="localhost:8080"
+export AUTH_SERVER_ADDRESS="localhost"
+export AUTH_SERVER_PORT="8181"
+export AUTH_SERVER_DOMAIN="localhost:8181"
+export FRONTEND_SERVER_ADDRESS="localhost"
+export FRONTEND_SERVER_PORT="3000"
+export FRONTEND_SERVER_DOMAIN="localhost:3000"
+export DB_DRIVER="sqlite3"
+export DB_NAME="banking.db"
+
+# Run app, which creates and seeds the database file on first run
+go run main.go
@@ -17,5 +17,6 @@ export DB_HOST="localhost"
 export DB_PORT="3306"
 export DB_NAME="banking"
 
-# Run app
+# Bring the database schema up to date, then run app
+go run main.go migrate up
 go run main.go
\ No newline at end of file
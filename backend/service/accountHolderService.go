@@ -0,0 +1,115 @@
+package service
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+)
+
+//go:generate mockgen -destination=../mocks/service/mock_accountHolderService.go -package=service github.com/aliciatay-zls/banking/backend/service AccountHolderService
+type AccountHolderService interface { //service (primary port)
+	GetAccountHolders(customerId string, accountId string) ([]dto.AccountHolderResponse, *errs.AppError)
+	AddAccountHolder(dto.AccountHolderRequest) (*dto.AccountHolderResponse, *errs.AppError)
+	RemoveAccountHolder(customerId string, accountId string, holderCustomerId string) *errs.AppError
+}
+
+type DefaultAccountHolderService struct { //business/domain object
+	repo         domain.AccountHolderRepository
+	accountRepo  domain.AccountRepository
+	customerRepo domain.CustomerRepository
+	clk          clock.Clock
+}
+
+func NewAccountHolderService(repo domain.AccountHolderRepository, accountRepo domain.AccountRepository,
+	customerRepo domain.CustomerRepository, clk clock.Clock) DefaultAccountHolderService {
+	return DefaultAccountHolderService{repo, accountRepo, customerRepo, clk}
+}
+
+// GetAccountHolders returns all holders of the given account, as long as the given customer is one of them.
+func (s DefaultAccountHolderService) GetAccountHolders(customerId string, accountId string) ([]dto.AccountHolderResponse, *errs.AppError) {
+	if _, err := s.accountRepo.FindHolder(accountId, customerId); err != nil {
+		return nil, err
+	}
+
+	holders, err := s.repo.FindHolders(accountId)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.AccountHolderResponse, 0)
+	for _, h := range holders {
+		response = append(response, h.ToDTO())
+	}
+	return response, nil
+}
+
+// AddAccountHolder adds the customer in the given request as a joint or view-only holder of the account, as long as
+// the request is made by the account's primary holder and the customer to add exists.
+func (s DefaultAccountHolderService) AddAccountHolder(request dto.AccountHolderRequest) (*dto.AccountHolderResponse, *errs.AppError) {
+	if err := s.checkPrimaryHolder(request.AccountId, request.CustomerId); err != nil {
+		return nil, err
+	}
+	if _, err := s.customerRepo.FindById(request.HolderCustomerId); err != nil {
+		return nil, err
+	}
+
+	holder := domain.NewAccountHolder(request, s.clk)
+	if err := s.repo.SaveHolder(holder); err != nil {
+		return nil, err
+	}
+	response := holder.ToDTO()
+	return &response, nil
+}
+
+// RemoveAccountHolder removes the given holder from the given account. The primary holder may remove any other
+// holder, while other holders may only remove themselves. The primary holder cannot be removed.
+func (s DefaultAccountHolderService) RemoveAccountHolder(customerId string, accountId string, holderCustomerId string) *errs.AppError {
+	if holderCustomerId != customerId {
+		if err := s.checkPrimaryHolder(accountId, customerId); err != nil {
+			return err
+		}
+	} else {
+		holder, err := s.accountRepo.FindHolder(accountId, customerId)
+		if err != nil {
+			return err
+		}
+		if holder.IsPrimary() {
+			return errs.NewValidationError("The primary holder of an account cannot be removed.")
+		}
+	}
+
+	return s.repo.RemoveHolder(accountId, holderCustomerId)
+}
+
+// checkPrimaryHolder checks that the customer with the given customer id is the primary holder of the account with
+// the given account id, who alone may manage its holders.
+func (s DefaultAccountHolderService) checkPrimaryHolder(accountId string, customerId string) *errs.AppError {
+	holder, err := s.accountRepo.FindHolder(accountId, customerId)
+	if err != nil {
+		return err
+	}
+	if !holder.IsPrimary() {
+		logger.Error("Customer " + customerId + " is not the primary holder of account " + accountId)
+		return errs.NewAuthorizationError("Only the primary holder of the account can manage its holders")
+	}
+	return nil
+}
+
+// checkHolder checks that the customer with the given customer id holds the account with the given account id and,
+// if the request makes changes to the account, is not a view-only holder. Services check this themselves on the
+// routes of an account, since HolderAuthRepository does not ask the auth server about the account for joint and
+// view-only holders.
+func checkHolder(accountRepo domain.AccountRepository, accountId string, customerId string,
+	changesAccount bool) *errs.AppError {
+	holder, err := accountRepo.FindHolder(accountId, customerId)
+	if err != nil {
+		return err
+	}
+	if changesAccount && !holder.CanTransact() {
+		logger.Error("View-only holder " + customerId + " cannot make changes to account " + accountId)
+		return errs.NewAuthorizationError("View-only holders cannot make changes to the account")
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	mocksDomain "github.com/aliciatay-zls/banking/backend/mocks/domain"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"testing"
+)
+
+// Test common variables and inputs
+var mockAccountHolderRepo *mocksDomain.MockAccountHolderRepository
+var accountHolderSvc DefaultAccountHolderService
+
+func setupAccountHolderServiceTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	mockAccountHolderRepo = mocksDomain.NewMockAccountHolderRepository(ctrl)
+	mockAccountRepo = mocksDomain.NewMockAccountRepository(ctrl)
+	mockCustomerRepo = mocksDomain.NewMockCustomerRepository(ctrl)
+	accountHolderSvc = NewAccountHolderService(mockAccountHolderRepo, mockAccountRepo, mockCustomerRepo,
+		clock.StaticClock{})
+
+	return func() {
+		mockAccountHolderRepo = nil
+		mockAccountRepo = nil
+		mockCustomerRepo = nil
+		defer ctrl.Finish()
+	}
+}
+
+// expectHolder expects the customer with the given id to be looked up as a holder of the account with id 1977 and
+// found to have the given role.
+func expectHolder(customerId string, role string) {
+	mockAccountRepo.EXPECT().FindHolder(dummyAccountId, customerId).Return(&domain.AccountHolder{
+		AccountId: dummyAccountId, CustomerId: customerId, Role: role}, nil)
+}
+
+func TestDefaultAccountHolderService_AddAccountHolder_returns_error_when_requester_notPrimaryHolder(t *testing.T) {
+	//Arrange
+	teardown := setupAccountHolderServiceTest(t)
+	defer teardown()
+
+	expectHolder(dummyCustomerId, dto.AccountHolderRoleJoint)
+	mockAccountHolderRepo.EXPECT().SaveHolder(gomock.Any()).Times(0)
+
+	request := dto.AccountHolderRequest{AccountId: dummyAccountId, CustomerId: dummyCustomerId, HolderCustomerId: "3",
+		Role: dto.AccountHolderRoleJoint}
+
+	//Act
+	_, err := accountHolderSvc.AddAccountHolder(request)
+
+	//Assert
+	if err == nil || err.Code != http.StatusForbidden {
+		t.Errorf("Expected authorization error but got %v", err)
+	}
+}
+
+func TestDefaultAccountHolderService_AddAccountHolder_saves_holder_when_requester_primaryHolder(t *testing.T) {
+	//Arrange
+	teardown := setupAccountHolderServiceTest(t)
+	defer teardown()
+
+	request := dto.AccountHolderRequest{AccountId: dummyAccountId, CustomerId: dummyCustomerId, HolderCustomerId: "3",
+		Role: dto.AccountHolderRoleViewOnly}
+	expectedHolder := domain.NewAccountHolder(request, clock.StaticClock{})
+
+	expectHolder(dummyCustomerId, dto.AccountHolderRolePrimary)
+	mockCustomerRepo.EXPECT().FindById("3").Return(&domain.Customer{Id: "3"}, nil)
+	mockAccountHolderRepo.EXPECT().SaveHolder(expectedHolder).Return(nil)
+
+	//Act
+	response, err := accountHolderSvc.AddAccountHolder(request)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if *response != expectedHolder.ToDTO() {
+		t.Errorf("Expected holder %+v but got %+v", expectedHolder.ToDTO(), *response)
+	}
+}
+
+func TestDefaultAccountHolderService_RemoveAccountHolder(t *testing.T) {
+	tests := []struct {
+		name             string
+		holderCustomerId string
+		requesterRole    string
+		expectRemoval    bool
+		expectedCode     int
+	}{
+		{"primary holder removes joint holder", "3", dto.AccountHolderRolePrimary, true, 0},
+		{"joint holder removes other holder", "3", dto.AccountHolderRoleJoint, false, http.StatusForbidden},
+		{"view-only holder removes themselves", dummyCustomerId, dto.AccountHolderRoleViewOnly, true, 0},
+		{"primary holder removes themselves", dummyCustomerId, dto.AccountHolderRolePrimary, false,
+			http.StatusUnprocessableEntity},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Arrange
+			teardown := setupAccountHolderServiceTest(t)
+			defer teardown()
+
+			expectHolder(dummyCustomerId, tc.requesterRole)
+			if tc.expectRemoval {
+				mockAccountHolderRepo.EXPECT().RemoveHolder(dummyAccountId, tc.holderCustomerId).Return(nil)
+			}
+
+			//Act
+			err := accountHolderSvc.RemoveAccountHolder(dummyCustomerId, dummyAccountId, tc.holderCustomerId)
+
+			//Assert
+			if tc.expectRemoval && err != nil {
+				t.Error("Expected no error but got error: " + err.Message)
+			}
+			if !tc.expectRemoval && (err == nil || err.Code != tc.expectedCode) {
+				t.Errorf("Expected error with status code %d but got %v", tc.expectedCode, err)
+			}
+		})
+	}
+}
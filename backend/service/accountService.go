@@ -1,11 +1,14 @@
 package service
 
 import (
+	"database/sql"
+	"fmt"
 	"github.com/aliciatay-zls/banking-lib/clock"
 	"github.com/aliciatay-zls/banking-lib/errs"
 	"github.com/aliciatay-zls/banking-lib/logger"
 	"github.com/aliciatay-zls/banking/backend/domain"
 	"github.com/aliciatay-zls/banking/backend/dto"
+	"net/http"
 )
 
 //go:generate mockgen -destination=../mocks/service/mock_accountService.go -package=service github.com/aliciatay-zls/banking/backend/service AccountService
@@ -13,15 +16,60 @@ type AccountService interface { //service (primary port)
 	GetAllAccounts(string) ([]dto.AccountResponse, *errs.AppError)
 	CreateNewAccount(dto.NewAccountRequest) (*dto.NewAccountResponse, *errs.AppError)
 	MakeTransaction(dto.TransactionRequest) (*dto.TransactionResponse, *errs.AppError)
+	MakeTransfer(dto.TransferRequest) (*dto.TransactionResponse, *errs.AppError)
+	SetOverdraftLimit(dto.OverdraftRequest) (*dto.AccountResponse, *errs.AppError)
+	GetTransactions(string, string) ([]dto.TransactionDetailResponse, *errs.AppError)
+	ReverseTransaction(dto.ReversalRequest) (*dto.TransactionResponse, *errs.AppError)
 }
 
+// errInsufficientBalanceForTransfer is returned when a transfer is more than the account balance allows for. It and
+// the errors below are always returned as they are, so that callers such as the payment initiation service can tell
+// them apart by comparing them with these values rather than by their messages.
+var errInsufficientBalanceForTransfer = errs.NewValidationError("Account balance insufficient to transfer given amount")
+
+// errNotBeneficiary is returned when a transfer is to an account that is not one of the customer's beneficiaries.
+var errNotBeneficiary = errs.NewValidationError("Transfers can only be made to your own accounts or to your beneficiaries")
+
+// errTransactionBlocked is returned when the fraud screening rules block a transaction.
+var errTransactionBlocked = errs.NewAuthorizationError("Transaction was blocked by fraud screening. Please contact the bank")
+
+// errCustomerOnHold is returned when a customer on hold after sanctions screening tries to move money out of their
+// accounts.
+var errCustomerOnHold = errs.NewAuthorizationError("Customer is on hold pending sanctions review. Please contact the bank")
+
+// errTransferHeld is returned when sanctions screening holds a transfer.
+var errTransferHeld = errs.NewAuthorizationError("Transfer is on hold pending sanctions review. Please contact the bank")
+
 type DefaultAccountService struct { //business/domain object
-	repo domain.AccountRepository //Business Domain has dependency on repo (repo is a field)
-	clk  clock.Clock
+	repo          domain.AccountRepository //Business Domain has dependency on repo (repo is a field)
+	uow           domain.UnitOfWork
+	fxRates       domain.FxRateProvider
+	beneficiaries domain.BeneficiaryPolicy
+	screening     domain.ScreeningPipeline
+	watchlist     domain.Watchlist
+	clk           clock.Clock
+}
+
+func NewAccountService(repo domain.AccountRepository, uow domain.UnitOfWork, fxRates domain.FxRateProvider,
+	beneficiaries domain.BeneficiaryPolicy, screening domain.ScreeningPipeline, watchlist domain.Watchlist,
+	clk clock.Clock) DefaultAccountService {
+	return DefaultAccountService{repo, uow, fxRates, beneficiaries, screening, watchlist, clk}
+}
+
+// within returns a copy of the service that uses the repositories of the unit of work they were given by, so that the
+// changes it makes become part of that unit of work.
+func (s DefaultAccountService) within(repos domain.Repositories) DefaultAccountService {
+	return DefaultAccountService{repos.Accounts, repos.UnitOfWork, s.fxRates, s.beneficiaries, s.screening, s.watchlist,
+		s.clk}
 }
 
-func NewAccountService(repo domain.AccountRepository, clk clock.Clock) DefaultAccountService {
-	return DefaultAccountService{repo, clk}
+// accountServiceWithin returns the given account service bound to the unit of work of the given repositories if it is
+// a DefaultAccountService. Other implementations, such as mocks, are returned as they are.
+func accountServiceWithin(s AccountService, repos domain.Repositories) AccountService {
+	if d, ok := s.(DefaultAccountService); ok {
+		return d.within(repos)
+	}
+	return s
 }
 
 func (s DefaultAccountService) GetAllAccounts(customerId string) ([]dto.AccountResponse, *errs.AppError) {
@@ -38,7 +86,7 @@ func (s DefaultAccountService) GetAllAccounts(customerId string) ([]dto.AccountR
 }
 
 func (s DefaultAccountService) CreateNewAccount(request dto.NewAccountRequest) (*dto.NewAccountResponse, *errs.AppError) { //Business Domain implements service
-	account := domain.NewAccount(request.CustomerId, request.AccountType, request.Amount, s.clk)
+	account := domain.NewAccount(request.CustomerId, request.AccountType, request.CurrencyCode(), request.Amount, s.clk)
 
 	newAccount, err := s.repo.Save(account)
 	if err != nil {
@@ -50,23 +98,373 @@ func (s DefaultAccountService) CreateNewAccount(request dto.NewAccountRequest) (
 
 // MakeTransaction checks whether the values in the given request's body are valid, whether the given account exists,
 // and whether the current account balance allows for the request to be fulfilled. If so, it passes the request down
-// to the server side as an Account object and passes the returned Account DTO back up to the REST handler.
+// to the server side as an Account object and passes the returned Account DTO back up to the REST handler. Where
+// fraud screening is available, the transaction is first put through the screening rules: a transaction to be
+// reviewed is made and opens a fraud case, while a blocked one only opens a fraud case, unless an admin overrides
+// this. Where sanctions screening is available, customers on hold cannot withdraw. Where delegations are available,
+// a delegate can only make the transactions their delegation allows, up to its daily limit. The checks and the
+// transaction are made in one unit of work.
 func (s DefaultAccountService) MakeTransaction(request dto.TransactionRequest) (*dto.TransactionResponse, *errs.AppError) { //Business Domain implements service
-	account, err := s.repo.FindById(request.AccountId)
+	var completedTransaction *domain.Transaction
+	var blocked bool
+	err := s.uow.Do(func(repos domain.Repositories) *errs.AppError {
+		blocked = false
+		account, err := repos.Accounts.FindById(request.AccountId)
+		if err != nil {
+			return err
+		}
+		if request.CustomerId != account.CustomerId {
+			if err = s.checkDelegation(repos, request.AccountId, request.CustomerId, request.TransactionType,
+				request.Amount, request.ActingAdmin); err != nil {
+				return err
+			}
+		}
+
+		if request.TransactionType == dto.TransactionTypeWithdrawal {
+			if !account.CanWithdraw(request.Amount) {
+				logger.Error("Amount to withdraw exceeds account balance")
+				return errs.NewValidationError("Account balance insufficient to withdraw given amount")
+			}
+			if repos.Sanctions != nil {
+				if err = checkNotOnHold(repos.Sanctions, account.CustomerId); err != nil {
+					return err
+				}
+			}
+		}
+
+		transaction := domain.NewTransaction(request.AccountId, request.Amount, request.TransactionType, s.clk)
+		transaction.ActedBy(request.CustomerId)
+
+		screening := domain.Screening{Decision: domain.ScreeningDecisionAllow}
+		if repos.Screening != nil && len(s.screening) > 0 && !request.OverrideScreening {
+			if screening, err = s.screen(repos, *account, transaction); err != nil {
+				return err
+			}
+		}
+		if screening.Decision == domain.ScreeningDecisionBlock {
+			logger.Error("Transaction on account " + account.AccountId + " was blocked by fraud screening")
+			blocked = true
+			_, err = repos.Screening.SaveCase(domain.NewFraudCase(transaction, *account, screening, s.clk))
+			return err //the case is kept although the transaction is not made
+		}
+
+		if completedTransaction, err = repos.Accounts.Transact(transaction); err != nil {
+			return err
+		}
+		if screening.Decision == domain.ScreeningDecisionReview {
+			fraudCase := domain.NewFraudCase(transaction, *account, screening, s.clk)
+			fraudCase.TransactionId = sql.NullString{String: completedTransaction.TransactionId, Valid: true}
+			_, err = repos.Screening.SaveCase(fraudCase)
+		}
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
+	if blocked {
+		return nil, errTransactionBlocked
+	}
+
+	return completedTransaction.ToTransactionResponseDTO(), nil
+}
+
+// MakeTransfer checks whether both the given source and destination accounts exist and whether the source account
+// balance allows for the given amount to be transferred. If so, it moves the amount from the source account to the
+// destination account and returns the outgoing transaction. The amount is in the source account's currency, and is
+// converted at the current exchange rate if the destination account is in a different currency. Where beneficiaries
+// are available, the destination account must be another of the customer's accounts or one of their beneficiaries
+// that is past its cooling-off period and, for a first transfer, within the first-transfer limit, unless an admin
+// overrides this. Where sanctions screening is available, customers on hold cannot transfer, and a transfer to
+// another customer who potentially matches the watchlist is held until an admin clears it, instead of being made.
+// Like MakeTransaction, a delegate's transfer counts towards their daily limit, and the checks and the transfer are
+// made in one unit of work.
+func (s DefaultAccountService) MakeTransfer(request dto.TransferRequest) (*dto.TransactionResponse, *errs.AppError) {
+	var completedTransaction *domain.Transaction
+	var held bool
+	err := s.uow.Do(func(repos domain.Repositories) *errs.AppError {
+		held = false
+		account, err := repos.Accounts.FindById(request.AccountId)
+		if err != nil {
+			return err
+		}
+		if request.CustomerId != account.CustomerId {
+			if err = s.checkDelegation(repos, request.AccountId, request.CustomerId, dto.TransactionTypeTransferOut,
+				request.Amount, request.ActingAdmin); err != nil {
+				return err
+			}
+		}
+		destination, err := repos.Accounts.FindById(request.DestinationAccountId)
+		if err != nil {
+			return err
+		}
 
-	if request.TransactionType == dto.TransactionTypeWithdrawal {
 		if !account.CanWithdraw(request.Amount) {
-			logger.Error("Amount to withdraw exceeds account balance")
-			return nil, errs.NewValidationError("Account balance insufficient to withdraw given amount")
+			logger.Error("Amount to transfer exceeds account balance")
+			return errInsufficientBalanceForTransfer
+		}
+
+		var beneficiary *domain.Beneficiary
+		if repos.Beneficiaries != nil && destination.CustomerId != account.CustomerId && !request.OverrideBeneficiaryCheck {
+			if beneficiary, err = s.findBeneficiary(repos.Beneficiaries, account.CustomerId, destination.AccountId,
+				request.Amount); err != nil {
+				return err
+			}
+		}
+
+		if repos.Sanctions != nil && !request.SkipSanctionsScreening {
+			var hit *domain.SanctionsHit
+			if hit, err = s.screenTransfer(repos, *account, *destination, request); err != nil {
+				return err
+			}
+			if hit != nil {
+				logger.Error("Transfer from account " + account.AccountId + " was held by sanctions screening")
+				held = true
+				_, err = repos.Sanctions.SaveHit(*hit)
+				return err //the hit is kept although the transfer is not made
+			}
+		}
+
+		var rate float64 = 1
+		if account.Currency != destination.Currency {
+			if rate, err = s.fxRates.GetRate(account.Currency, destination.Currency); err != nil {
+				return err
+			}
+		}
+
+		debit, credit := domain.NewTransfer(*account, *destination, request.Amount, rate, s.clk)
+		debit.ActedBy(request.CustomerId)
+
+		if completedTransaction, err = repos.Accounts.Transfer(debit, credit); err != nil {
+			return err
 		}
+		if beneficiary != nil && !beneficiary.FirstTransferDate.Valid {
+			return repos.Beneficiaries.SetFirstTransferDate(beneficiary.BeneficiaryId, completedTransaction.TransactionDate)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if held {
+		return nil, errTransferHeld
 	}
 
-	transaction := domain.NewTransaction(request.AccountId, request.Amount, request.TransactionType, s.clk)
+	return completedTransaction.ToTransactionResponseDTO(), nil
+}
+
+// screen puts the given transaction on the given account through the screening rules, along with the customer's
+// accounts and their recent transactions.
+func (s DefaultAccountService) screen(repos domain.Repositories, account domain.Account,
+	transaction domain.Transaction) (domain.Screening, *errs.AppError) {
+	now := s.clk.Now()
+	accounts, err := repos.Accounts.FindAll(account.CustomerId)
+	if err != nil {
+		return domain.Screening{}, err
+	}
+	since := now.Add(-s.screening.Lookback()).Format(clock.FormatDateTime)
+	history, err := repos.Screening.FindHistory(account.CustomerId, since)
+	if err != nil {
+		return domain.Screening{}, err
+	}
+
+	input := domain.ScreeningInput{Transaction: transaction, Account: account, Accounts: accounts, History: history, Now: now}
+	return s.screening.Screen(input), nil
+}
+
+// screenTransfer checks that the customer making the given transfer is not on hold and, for a transfer to another
+// customer, screens that customer. It returns a hit holding the transfer if they potentially match the watchlist.
+func (s DefaultAccountService) screenTransfer(repos domain.Repositories, account domain.Account,
+	destination domain.Account, request dto.TransferRequest) (*domain.SanctionsHit, *errs.AppError) {
+	if err := checkNotOnHold(repos.Sanctions, account.CustomerId); err != nil {
+		return nil, err
+	}
+	if destination.CustomerId == account.CustomerId {
+		return nil, nil
+	}
+
+	recipient, err := repos.Customers.FindById(destination.CustomerId)
+	if err != nil {
+		return nil, err
+	}
+	matches, onHold, err := screenCustomer(repos.Sanctions, s.watchlist, *recipient, s.clk)
+	if err != nil || !onHold {
+		return nil, err
+	}
+	hit := domain.NewTransferSanctionsHit(account, request, matches, s.clk)
+	return &hit, nil
+}
+
+// checkDelegation checks that the given customer, who does not own the given account, may make a transaction of the
+// given type and amount on it. Joint holders may and view-only holders may not, while delegates may if their
+// delegation allows the transaction, where delegations are available. It returns an authorization error if the
+// customer neither holds the account nor was delegated it, unless the request was made by an admin.
+func (s DefaultAccountService) checkDelegation(repos domain.Repositories, accountId string, customerId string,
+	transactionType string, amount float64, actingAdmin bool) *errs.AppError {
+	err := checkHolder(repos.Accounts, accountId, customerId, true)
+	if err == nil || err.Code != http.StatusNotFound {
+		return err
+	}
+	now := s.clk.Now()
+	var delegation *domain.Delegation
+	if repos.Delegations != nil {
+		delegation, err = repos.Delegations.FindActive(accountId, customerId, now.Format(clock.FormatDateTime))
+		if err != nil && err.Code != http.StatusNotFound {
+			return err
+		}
+	}
+	if delegation == nil {
+		if actingAdmin {
+			return nil
+		}
+		logger.Error("Customer " + customerId + " neither holds nor was delegated account " + accountId)
+		return errs.NewAuthorizationError("Customer neither holds nor was delegated this account")
+	}
+
+	var withdrawnToday float64
+	if transactionType != dto.TransactionTypeDeposit {
+		since := now.Format(domain.FormatDate) + " 00:00:00"
+		if withdrawnToday, err = repos.Delegations.SumWithdrawn(accountId, customerId, since); err != nil {
+			return err
+		}
+	}
+	if err = delegation.CheckTransaction(transactionType, amount, withdrawnToday); err != nil {
+		logger.Error("Customer " + customerId + " made a transaction on account " + accountId +
+			" outside of delegation " + delegation.DelegationId)
+		return err
+	}
+	return nil
+}
+
+// checkNotOnHold returns an authorization error if the customer with the given ID is on hold.
+func checkNotOnHold(repo domain.SanctionsRepository, customerId string) *errs.AppError {
+	onHold, err := repo.IsOnHold(customerId)
+	if err != nil {
+		return err
+	}
+	if onHold {
+		logger.Error("Customer " + customerId + " is on hold pending sanctions review")
+		return errCustomerOnHold
+	}
+	return nil
+}
+
+// findBeneficiary finds the given customer's beneficiary with the given account of this bank and checks whether the
+// given amount can be transferred to it now.
+func (s DefaultAccountService) findBeneficiary(repo domain.BeneficiaryRepository, customerId string, accountId string,
+	amount float64) (*domain.Beneficiary, *errs.AppError) {
+	beneficiary, err := repo.FindByAccount(customerId, accountId)
+	if err != nil {
+		if err.Code == http.StatusNotFound {
+			logger.Error("Account " + accountId + " is not a beneficiary of customer " + customerId)
+			return nil, errNotBeneficiary
+		}
+		return nil, err
+	}
+	if err = beneficiary.CheckTransfer(amount, s.beneficiaries, s.clk.Now()); err != nil {
+		return nil, err
+	}
+	return beneficiary, nil
+}
+
+// SetOverdraftLimit checks whether the given account exists, belongs to the given customer and is a checking account,
+// and whether the new limit still covers what the account is overdrawn by. If so, it updates the account's overdraft
+// limit and returns the updated account.
+func (s DefaultAccountService) SetOverdraftLimit(request dto.OverdraftRequest) (*dto.AccountResponse, *errs.AppError) {
+	account, err := s.repo.FindById(request.AccountId)
+	if err != nil {
+		return nil, err
+	}
+
+	if account.CustomerId != request.CustomerId {
+		logger.Error("Account " + account.AccountId + " does not belong to customer " + request.CustomerId)
+		return nil, errs.NewAuthorizationError("Account does not belong to the given customer")
+	}
+	if account.AccountType != dto.AccountTypeChecking {
+		logger.Error("Cannot set overdraft limit on account of type " + account.AccountType)
+		return nil, errs.NewValidationError("Overdraft is only available for checking accounts")
+	}
+	if request.OverdraftLimit < account.OverdraftUsed() {
+		logger.Error("Overdraft limit is below the overdraft used by account " + account.AccountId)
+		return nil, errs.NewValidationError(fmt.Sprintf("Overdraft limit cannot be less than the %.2f the account "+
+			"is overdrawn by", account.OverdraftUsed()))
+	}
+
+	if err = s.repo.UpdateOverdraftLimit(request.AccountId, request.OverdraftLimit); err != nil {
+		return nil, err
+	}
+
+	account.OverdraftLimit = request.OverdraftLimit
+	return account.ToDTO(), nil
+}
+
+// GetTransactions returns the transaction history of the given account, including the links between reversed
+// transactions and their reversals, as long as the given customer holds the account or, where delegations are
+// available, was delegated it.
+func (s DefaultAccountService) GetTransactions(customerId string, accountId string) ([]dto.TransactionDetailResponse, *errs.AppError) {
+	var transactions []domain.Transaction
+	err := s.uow.Do(func(repos domain.Repositories) *errs.AppError {
+		err := checkHolderOrDelegate(repos.Accounts, repos.Delegations, accountId, customerId, s.clk.NowAsString())
+		if err != nil {
+			return err
+		}
+		transactions, err = repos.Accounts.FindTransactions(accountId)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.TransactionDetailResponse, 0)
+	for _, t := range transactions {
+		response = append(response, *t.ToTransactionDetailResponseDTO())
+	}
+	return response, nil
+}
+
+// ReverseTransaction checks whether the given customer holds the given account, whether the given transaction was made
+// on the account, can be reversed and has not been reversed yet, and whether the account balance allows for it to be
+// reversed. If so, it makes a compensating
+// transaction for the same amount in the opposite direction, linked to the original with the given reason. The
+// balance check and the reversal are made in one unit of work.
+func (s DefaultAccountService) ReverseTransaction(request dto.ReversalRequest) (*dto.TransactionResponse, *errs.AppError) {
+	if err := checkHolder(s.repo, request.AccountId, request.CustomerId, true); err != nil {
+		return nil, err
+	}
+
+	original, err := s.repo.FindTransactionById(request.TransactionId)
+	if err != nil {
+		return nil, err
+	}
+	if original.AccountId != request.AccountId {
+		logger.Error("Transaction " + request.TransactionId + " was not made on account " + request.AccountId)
+		return nil, errs.NewNotFoundError("Transaction not found")
+	}
+	if original.IsReversed() {
+		return nil, errs.NewConflictError("Transaction has already been reversed")
+	}
+	if !original.IsReversible() {
+		return nil, errs.NewValidationError("Transactions of type " + original.TransactionType + " cannot be reversed")
+	}
+
+	transaction, reversal := domain.NewReversal(*original, request.ReasonCode, request.Note, s.clk)
+
+	var completedTransaction *domain.Transaction
+	err = s.uow.Do(func(repos domain.Repositories) *errs.AppError {
+		if transaction.IsDebit() {
+			account, err := repos.Accounts.FindById(request.AccountId)
+			if err != nil {
+				return err
+			}
+			if !account.CanWithdraw(transaction.Amount) {
+				logger.Error("Amount to reverse exceeds account balance")
+				return errs.NewValidationError("Account balance insufficient to reverse transaction")
+			}
+		}
 
-	completedTransaction, err := s.repo.Transact(transaction)
+		var err *errs.AppError
+		completedTransaction, err = repos.Accounts.Reverse(transaction, reversal)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
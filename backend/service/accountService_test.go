@@ -1,6 +1,7 @@
 package service
 
 import (
+	"database/sql"
 	"github.com/aliciatay-zls/banking-lib/clock"
 	"github.com/aliciatay-zls/banking-lib/errs"
 	"github.com/aliciatay-zls/banking-lib/formValidator"
@@ -9,7 +10,9 @@ import (
 	"github.com/aliciatay-zls/banking/backend/dto"
 	mocksDomain "github.com/aliciatay-zls/banking/backend/mocks/domain"
 	"go.uber.org/mock/gomock"
+	"net/http"
 	"testing"
+	"time"
 )
 
 // Package common variables and inputs
@@ -17,6 +20,7 @@ const dummyCustomerId = "2"
 
 // Test common variables and inputs
 var mockAccountRepo *mocksDomain.MockAccountRepository
+var mockFxRateProvider *mocksDomain.MockFxRateProvider
 var mockClock clock.Clock
 var accSvc DefaultAccountService
 
@@ -36,17 +40,21 @@ func init() {
 func setupAccountServiceTest(t *testing.T) func() {
 	ctrl := gomock.NewController(t)
 	mockAccountRepo = mocksDomain.NewMockAccountRepository(ctrl)
+	mockFxRateProvider = mocksDomain.NewMockFxRateProvider(ctrl)
 	mockClock = clock.StaticClock{}
-	accSvc = NewAccountService(mockAccountRepo, mockClock) //prevents flaky tests due to minor time differences
+	unitOfWork := domain.NewUnitOfWorkStub(domain.Repositories{Accounts: mockAccountRepo})
+	accSvc = NewAccountService(mockAccountRepo, unitOfWork, mockFxRateProvider, domain.DefaultBeneficiaryPolicy(), nil,
+		domain.Watchlist{}, mockClock) //prevents flaky tests due to minor time differences
 
 	return func() {
 		mockAccountRepo = nil
+		mockFxRateProvider = nil
 		defer ctrl.Finish()
 	}
 }
 
 // getDefaultDummyNewAccountRequest returns a dto.NewAccountRequest for the customer with id 2 wanting to open
-// a saving account of amount 6000
+// a saving account of amount 6000 in the default currency
 func getDefaultDummyNewAccountRequest() dto.NewAccountRequest {
 	return dto.NewAccountRequest{
 		CustomerId:  dummyCustomerId,
@@ -69,13 +77,15 @@ func getDefaultDummyTransactionRequest() dto.TransactionRequest {
 // getDefaultDummyAccount returns a domain.Account of saving type and amount 6000 belonging to the customer with id 2,
 // opened on 2 Jan 2006, before it was saved to the db.
 func getDefaultDummyAccount() domain.Account {
-	return domain.NewAccount(dummyCustomerId, dummyAccountType, dummyAmount, mockClock)
+	return domain.NewAccount(dummyCustomerId, dummyAccountType, dto.DefaultCurrency, dummyAmount, mockClock)
 }
 
 // getDefaultDummyTransaction returns a domain.Transaction of withdrawal type and amount 6000 made on the account
 // with number 1977, on 2 Jan 2006, before it was saved to the db.
 func getDefaultDummyTransaction() domain.Transaction {
-	return domain.NewTransaction(dummyAccountId, dummyAmount, dummyTransactionType, mockClock)
+	transaction := domain.NewTransaction(dummyAccountId, dummyAmount, dummyTransactionType, mockClock)
+	transaction.ActedBy(dummyCustomerId)
+	return transaction
 }
 
 func TestDefaultAccountService_CreateNewAccount_returns_error_when_repo_fails(t *testing.T) {
@@ -239,3 +249,716 @@ func TestDefaultAccountService_MakeTransaction_returns_newTransactionDetails_whe
 			dummyNewTransaction.Balance, newTransactionResponse.Balance)
 	}
 }
+
+func TestDefaultAccountService_SetOverdraftLimit_returns_error_when_account_notChecking(t *testing.T) {
+	//Arrange
+	teardown := setupAccountServiceTest(t)
+	defer teardown()
+
+	dummyOverdraftRequest := dto.OverdraftRequest{AccountId: dummyAccountId, CustomerId: dummyCustomerId, OverdraftLimit: 500}
+	dummyExistentAccount := getDefaultDummyAccount() //saving account
+	dummyExistentAccount.AccountId = dummyAccountId
+	mockAccountRepo.EXPECT().FindById(dummyAccountId).Return(&dummyExistentAccount, nil)
+	mockAccountRepo.EXPECT().UpdateOverdraftLimit(gomock.Any(), gomock.Any()).Times(0)
+
+	expectedErrMessage := "Overdraft is only available for checking accounts"
+
+	//Act
+	_, actualErr := accSvc.SetOverdraftLimit(dummyOverdraftRequest)
+
+	//Assert
+	if actualErr == nil {
+		t.Fatal("Expected error but got none while testing overdraft on saving account")
+	}
+	if actualErr.Message != expectedErrMessage {
+		t.Errorf("Expected error message to be \"%s\" but got \"%s\"", expectedErrMessage, actualErr.Message)
+	}
+}
+
+func TestDefaultAccountService_SetOverdraftLimit_returns_error_when_account_notCustomers(t *testing.T) {
+	//Arrange
+	teardown := setupAccountServiceTest(t)
+	defer teardown()
+
+	dummyOverdraftRequest := dto.OverdraftRequest{AccountId: dummyAccountId, CustomerId: "3", OverdraftLimit: 500}
+	dummyExistentAccount := domain.NewAccount(dummyCustomerId, dto.AccountTypeChecking, dto.DefaultCurrency, 100, mockClock)
+	dummyExistentAccount.AccountId = dummyAccountId
+	mockAccountRepo.EXPECT().FindById(dummyAccountId).Return(&dummyExistentAccount, nil)
+	mockAccountRepo.EXPECT().UpdateOverdraftLimit(gomock.Any(), gomock.Any()).Times(0)
+
+	//Act
+	_, err := accSvc.SetOverdraftLimit(dummyOverdraftRequest)
+
+	//Assert
+	if err == nil || err.Code != http.StatusForbidden {
+		t.Errorf("Expected authorization error but got %v", err)
+	}
+}
+
+func TestDefaultAccountService_SetOverdraftLimit_returns_error_when_limit_belowOverdraftUsed(t *testing.T) {
+	//Arrange
+	teardown := setupAccountServiceTest(t)
+	defer teardown()
+
+	dummyOverdraftRequest := dto.OverdraftRequest{AccountId: dummyAccountId, CustomerId: dummyCustomerId, OverdraftLimit: 50}
+	dummyExistentAccount := domain.NewAccount(dummyCustomerId, dto.AccountTypeChecking, dto.DefaultCurrency, -100, mockClock)
+	dummyExistentAccount.AccountId = dummyAccountId
+	mockAccountRepo.EXPECT().FindById(dummyAccountId).Return(&dummyExistentAccount, nil)
+	mockAccountRepo.EXPECT().UpdateOverdraftLimit(gomock.Any(), gomock.Any()).Times(0)
+
+	//Act
+	_, err := accSvc.SetOverdraftLimit(dummyOverdraftRequest)
+
+	//Assert
+	if err == nil || err.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected validation error but got %v", err)
+	}
+}
+
+func TestDefaultAccountService_SetOverdraftLimit_returns_updatedAccount_when_repo_succeeds(t *testing.T) {
+	//Arrange
+	teardown := setupAccountServiceTest(t)
+	defer teardown()
+
+	dummyOverdraftRequest := dto.OverdraftRequest{AccountId: dummyAccountId, CustomerId: dummyCustomerId, OverdraftLimit: 500}
+	dummyExistentAccount := domain.NewAccount(dummyCustomerId, dto.AccountTypeChecking, dto.DefaultCurrency, -100, mockClock)
+	dummyExistentAccount.AccountId = dummyAccountId
+	mockAccountRepo.EXPECT().FindById(dummyAccountId).Return(&dummyExistentAccount, nil)
+	mockAccountRepo.EXPECT().UpdateOverdraftLimit(dummyAccountId, dummyOverdraftRequest.OverdraftLimit).Return(nil)
+
+	//Act
+	response, err := accSvc.SetOverdraftLimit(dummyOverdraftRequest)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing successful update of overdraft limit: " + err.Message)
+	}
+	if response.OverdraftLimit != dummyOverdraftRequest.OverdraftLimit {
+		t.Errorf("Expected overdraft limit %v but got %v", dummyOverdraftRequest.OverdraftLimit, response.OverdraftLimit)
+	}
+	if response.OverdraftUsed != 100 {
+		t.Errorf("Expected overdraft used to be 100 but got %v", response.OverdraftUsed)
+	}
+}
+
+// dummyScreeningRule is a screening rule that always comes to the same decision.
+type dummyScreeningRule struct {
+	decision string
+}
+
+func (r dummyScreeningRule) Name() string {
+	return "dummy"
+}
+
+func (r dummyScreeningRule) Lookback() time.Duration {
+	return time.Hour
+}
+
+func (r dummyScreeningRule) Screen(domain.ScreeningInput) domain.ScreeningResult {
+	return domain.ScreeningResult{Rule: r.Name(), Decision: r.decision, Reason: "dummy reason"}
+}
+
+func TestDefaultAccountService_MakeTransaction_screensTransaction(t *testing.T) {
+	tests := []struct {
+		name               string
+		decision           string
+		override           bool
+		expectTransaction  bool
+		expectCase         bool
+		expectedStatusCode int
+	}{
+		{"allowed", domain.ScreeningDecisionAllow, false, true, false, 0},
+		{"to be reviewed", domain.ScreeningDecisionReview, false, true, true, 0},
+		{"blocked", domain.ScreeningDecisionBlock, false, false, true, http.StatusForbidden},
+		{"blocked but overridden by admin", domain.ScreeningDecisionBlock, true, true, false, 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Arrange
+			ctrl := gomock.NewController(t)
+			accountRepo := mocksDomain.NewMockAccountRepository(ctrl)
+			screeningRepo := mocksDomain.NewMockScreeningRepository(ctrl)
+			unitOfWork := domain.NewUnitOfWorkStub(domain.Repositories{Accounts: accountRepo, Screening: screeningRepo})
+			pipeline := domain.ScreeningPipeline{dummyScreeningRule{tc.decision}}
+			svc := NewAccountService(accountRepo, unitOfWork, nil, domain.DefaultBeneficiaryPolicy(), pipeline,
+				domain.Watchlist{}, mockClock)
+
+			account := getDefaultDummyAccount()
+			account.AccountId = dummyAccountId
+			transaction := domain.NewTransaction(dummyAccountId, 100, dto.TransactionTypeWithdrawal, mockClock)
+			transaction.ActedBy(dummyCustomerId)
+			accountRepo.EXPECT().FindById(dummyAccountId).Return(&account, nil)
+			if !tc.override {
+				accountRepo.EXPECT().FindAll(dummyCustomerId).Return([]domain.Account{account}, nil)
+				screeningRepo.EXPECT().FindHistory(dummyCustomerId, "2006-01-02 14:04:05").Return(nil, nil)
+			}
+			if tc.expectTransaction {
+				completed := transaction
+				completed.TransactionId = dummyTransactionId
+				accountRepo.EXPECT().Transact(transaction).Return(&completed, nil)
+			} else {
+				accountRepo.EXPECT().Transact(gomock.Any()).Times(0)
+			}
+			if tc.expectCase {
+				screeningRepo.EXPECT().SaveCase(gomock.Any()).DoAndReturn(func(f domain.FraudCase) (*domain.FraudCase, *errs.AppError) {
+					if f.Decision != tc.decision || f.TransactionId.Valid != tc.expectTransaction || f.Rules != "dummy" {
+						t.Errorf("Expected %s case for the transaction but got %+v", tc.decision, f)
+					}
+					return &f, nil
+				})
+			} else {
+				screeningRepo.EXPECT().SaveCase(gomock.Any()).Times(0)
+			}
+
+			request := dto.TransactionRequest{AccountId: dummyAccountId, CustomerId: dummyCustomerId, Amount: 100,
+				TransactionType: dto.TransactionTypeWithdrawal, OverrideScreening: tc.override}
+
+			//Act
+			_, err := svc.MakeTransaction(request)
+
+			//Assert
+			if tc.expectedStatusCode == 0 && err != nil {
+				t.Error("Expected no error but got error: " + err.Message)
+			}
+			if tc.expectedStatusCode != 0 && (err == nil || err.Code != tc.expectedStatusCode) {
+				t.Errorf("Expected status code %d but got %v", tc.expectedStatusCode, err)
+			}
+		})
+	}
+}
+
+func TestDefaultAccountService_MakeTransfer_returns_error_when_cannotWithdraw(t *testing.T) {
+	//Arrange
+	teardown := setupAccountServiceTest(t)
+	defer teardown()
+
+	dummyTransferRequest := dto.TransferRequest{AccountId: dummyAccountId, CustomerId: dummyCustomerId, DestinationAccountId: "1980", Amount: dummyAmount}
+	dummyExistentAccount := getDefaultDummyAccount()
+	dummyExistentAccount.AccountId = dummyAccountId
+	dummyExistentAccount.Amount = 10
+	mockAccountRepo.EXPECT().FindById(dummyAccountId).Return(&dummyExistentAccount, nil)
+	mockAccountRepo.EXPECT().FindById("1980").Return(&domain.Account{AccountId: "1980", Currency: dto.DefaultCurrency}, nil)
+	mockAccountRepo.EXPECT().Transfer(gomock.Any(), gomock.Any()).Times(0)
+
+	expectedErrMessage := "Account balance insufficient to transfer given amount"
+
+	//Act
+	_, actualErr := accSvc.MakeTransfer(dummyTransferRequest)
+
+	//Assert
+	if actualErr == nil {
+		t.Fatal("Expected error but got none while testing unable to transfer")
+	}
+	if actualErr.Message != expectedErrMessage {
+		t.Errorf("Expected error message to be \"%s\" but got \"%s\"", expectedErrMessage, actualErr.Message)
+	}
+}
+
+func TestDefaultAccountService_MakeTransfer_returns_outgoingTransaction_when_repo_succeeds(t *testing.T) {
+	//Arrange
+	teardown := setupAccountServiceTest(t)
+	defer teardown()
+
+	dummyTransferRequest := dto.TransferRequest{AccountId: dummyAccountId, CustomerId: dummyCustomerId, DestinationAccountId: "1980", Amount: dummyAmount}
+	dummyExistentAccount := getDefaultDummyAccount()
+	dummyExistentAccount.AccountId = dummyAccountId
+	mockAccountRepo.EXPECT().FindById(dummyAccountId).Return(&dummyExistentAccount, nil)
+	mockAccountRepo.EXPECT().FindById("1980").Return(&domain.Account{AccountId: "1980", Currency: dto.DefaultCurrency}, nil)
+
+	debit := domain.NewTransaction(dummyAccountId, dummyAmount, dto.TransactionTypeTransferOut, mockClock)
+	debit.ActedBy(dummyCustomerId)
+	credit := domain.NewTransaction("1980", dummyAmount, dto.TransactionTypeTransferIn, mockClock)
+	completedDebit := debit
+	completedDebit.TransactionId = dummyTransactionId
+	completedDebit.Balance = dummyBalance
+	mockAccountRepo.EXPECT().Transfer(debit, credit).Return(&completedDebit, nil)
+
+	//Act
+	response, err := accSvc.MakeTransfer(dummyTransferRequest)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing successful transfer: " + err.Message)
+	}
+	if response.TransactionId != dummyTransactionId || response.Balance != dummyBalance {
+		t.Errorf("Expected transaction %s with new balance %v but got %+v", dummyTransactionId, float64(dummyBalance), *response)
+	}
+}
+
+func TestDefaultAccountService_MakeTransfer_convertsAmount_when_destination_inOtherCurrency(t *testing.T) {
+	//Arrange
+	teardown := setupAccountServiceTest(t)
+	defer teardown()
+
+	dummyTransferRequest := dto.TransferRequest{AccountId: dummyAccountId, CustomerId: dummyCustomerId, DestinationAccountId: "1980", Amount: 100}
+	dummyExistentAccount := getDefaultDummyAccount()
+	dummyExistentAccount.AccountId = dummyAccountId
+	mockAccountRepo.EXPECT().FindById(dummyAccountId).Return(&dummyExistentAccount, nil)
+	mockAccountRepo.EXPECT().FindById("1980").Return(&domain.Account{AccountId: "1980", Currency: "INR"}, nil)
+	mockFxRateProvider.EXPECT().GetRate(dto.DefaultCurrency, "INR").Return(83.123, nil)
+
+	debit := domain.NewTransaction(dummyAccountId, 100, dto.TransactionTypeTransferOut, mockClock)
+	debit.ActedBy(dummyCustomerId)
+	debit.FxRate = sql.NullFloat64{Float64: 83.123, Valid: true}
+	debit.ConvertedAmount = sql.NullFloat64{Float64: 8312.3, Valid: true}
+	credit := domain.NewTransaction("1980", 8312.3, dto.TransactionTypeTransferIn, mockClock)
+	credit.FxRate = debit.FxRate
+	credit.ConvertedAmount = debit.ConvertedAmount
+	completedDebit := debit
+	completedDebit.TransactionId = dummyTransactionId
+	mockAccountRepo.EXPECT().Transfer(debit, credit).Return(&completedDebit, nil)
+
+	//Act
+	response, err := accSvc.MakeTransfer(dummyTransferRequest)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing cross-currency transfer: " + err.Message)
+	}
+	if response.FxRate != 83.123 || response.ConvertedAmount != 8312.3 {
+		t.Errorf("Expected rate 83.123 and converted amount 8312.3 but got %+v", *response)
+	}
+}
+
+func TestDefaultAccountService_MakeTransfer_returns_error_when_fxRate_unavailable(t *testing.T) {
+	//Arrange
+	teardown := setupAccountServiceTest(t)
+	defer teardown()
+
+	dummyTransferRequest := dto.TransferRequest{AccountId: dummyAccountId, CustomerId: dummyCustomerId, DestinationAccountId: "1980", Amount: 100}
+	dummyExistentAccount := getDefaultDummyAccount()
+	dummyExistentAccount.AccountId = dummyAccountId
+	mockAccountRepo.EXPECT().FindById(dummyAccountId).Return(&dummyExistentAccount, nil)
+	mockAccountRepo.EXPECT().FindById("1980").Return(&domain.Account{AccountId: "1980", Currency: "NOK"}, nil)
+	dummyAppErr := errs.NewValidationError("Exchange rate from USD to NOK is not available")
+	mockFxRateProvider.EXPECT().GetRate(dto.DefaultCurrency, "NOK").Return(float64(0), dummyAppErr)
+	mockAccountRepo.EXPECT().Transfer(gomock.Any(), gomock.Any()).Times(0)
+
+	//Act
+	_, err := accSvc.MakeTransfer(dummyTransferRequest)
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing transfer without exchange rate")
+	}
+	if err.Message != dummyAppErr.Message {
+		t.Errorf("Expected error message to be \"%s\" but got \"%s\"", dummyAppErr.Message, err.Message)
+	}
+}
+
+func TestDefaultAccountService_ReverseTransaction_returns_error_when_transaction_cannotBeReversed(t *testing.T) {
+	//Arrange
+	tests := []struct {
+		name         string
+		original     domain.Transaction
+		expectedCode int
+	}{
+		{"other account", domain.Transaction{TransactionId: "7790", AccountId: "1980", TransactionType: dto.TransactionTypeDeposit}, http.StatusNotFound},
+		{"already reversed", domain.Transaction{TransactionId: "7790", AccountId: dummyAccountId, TransactionType: dto.TransactionTypeDeposit,
+			ReversedBy: sql.NullString{String: dummyTransactionId, Valid: true}}, http.StatusConflict},
+		{"transfer", domain.Transaction{TransactionId: "7790", AccountId: dummyAccountId, TransactionType: dto.TransactionTypeTransferIn}, http.StatusUnprocessableEntity},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			teardown := setupAccountServiceTest(t)
+			defer teardown()
+
+			dummyReversalRequest := dto.ReversalRequest{AccountId: dummyAccountId, CustomerId: dummyCustomerId, TransactionId: "7790", ReasonCode: dto.ReversalReasonDuplicate}
+			original := tc.original
+			expectHolder(dummyCustomerId, dto.AccountHolderRolePrimary)
+			mockAccountRepo.EXPECT().FindTransactionById("7790").Return(&original, nil)
+			mockAccountRepo.EXPECT().Reverse(gomock.Any(), gomock.Any()).Times(0)
+
+			//Act
+			_, err := accSvc.ReverseTransaction(dummyReversalRequest)
+
+			//Assert
+			if err == nil {
+				t.Fatal("Expected error but got none while testing transaction that cannot be reversed")
+			}
+			if err.Code != tc.expectedCode {
+				t.Errorf("Expected status code %d but got %d", tc.expectedCode, err.Code)
+			}
+		})
+	}
+}
+
+func TestDefaultAccountService_ReverseTransaction_returns_reversal_when_repo_succeeds(t *testing.T) {
+	//Arrange
+	teardown := setupAccountServiceTest(t)
+	defer teardown()
+
+	dummyReversalRequest := dto.ReversalRequest{AccountId: dummyAccountId, CustomerId: dummyCustomerId, TransactionId: "7790", ReasonCode: dto.ReversalReasonDuplicate}
+	original := domain.Transaction{TransactionId: "7790", AccountId: dummyAccountId, Amount: dummyAmount, TransactionType: dto.TransactionTypeWithdrawal}
+	expectHolder(dummyCustomerId, dto.AccountHolderRolePrimary)
+	mockAccountRepo.EXPECT().FindTransactionById("7790").Return(&original, nil)
+
+	expectedTransaction, expectedReversal := domain.NewReversal(original, dto.ReversalReasonDuplicate, "", mockClock)
+	completedTransaction := expectedTransaction
+	completedTransaction.TransactionId = dummyTransactionId
+	completedTransaction.Balance = dummyAmount
+	mockAccountRepo.EXPECT().Reverse(expectedTransaction, expectedReversal).Return(&completedTransaction, nil)
+
+	//Act
+	response, err := accSvc.ReverseTransaction(dummyReversalRequest)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing successful reversal: " + err.Message)
+	}
+	if response.TransactionId != dummyTransactionId || response.ReversalOf != "7790" {
+		t.Errorf("Expected reversal %s of transaction 7790 but got %+v", dummyTransactionId, *response)
+	}
+}
+
+func TestDefaultAccountService_MakeTransfer_beneficiaryCheck(t *testing.T) {
+	tests := []struct {
+		name               string
+		override           bool
+		found              *domain.Beneficiary
+		expectedStatusCode int
+	}{
+		{"not a beneficiary", false, nil, http.StatusUnprocessableEntity},
+		{"above first-transfer limit", false, &domain.Beneficiary{BeneficiaryId: "3", CoolingOffStart: "2006-01-01 00:00:00"}, http.StatusUnprocessableEntity},
+		{"overridden by admin", true, nil, 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Arrange
+			ctrl := gomock.NewController(t)
+			accountRepo := mocksDomain.NewMockAccountRepository(ctrl)
+			beneficiaryRepo := mocksDomain.NewMockBeneficiaryRepository(ctrl)
+			unitOfWork := domain.NewUnitOfWorkStub(domain.Repositories{Accounts: accountRepo, Beneficiaries: beneficiaryRepo})
+			svc := NewAccountService(accountRepo, unitOfWork, nil, domain.DefaultBeneficiaryPolicy(), nil,
+				domain.Watchlist{}, clock.StaticClock{})
+
+			source := domain.Account{AccountId: dummyAccountId, CustomerId: dummyCustomerId, Amount: 5000, Currency: dto.DefaultCurrency}
+			destination := domain.Account{AccountId: "1980", CustomerId: "3", Currency: dto.DefaultCurrency}
+			accountRepo.EXPECT().FindById(dummyAccountId).Return(&source, nil)
+			accountRepo.EXPECT().FindById("1980").Return(&destination, nil)
+			if !tc.override {
+				var err *errs.AppError
+				if tc.found == nil {
+					err = errs.NewNotFoundError("Beneficiary not found")
+				}
+				beneficiaryRepo.EXPECT().FindByAccount(dummyCustomerId, "1980").Return(tc.found, err)
+				accountRepo.EXPECT().Transfer(gomock.Any(), gomock.Any()).Times(0)
+			} else {
+				beneficiaryRepo.EXPECT().FindByAccount(gomock.Any(), gomock.Any()).Times(0)
+				accountRepo.EXPECT().Transfer(gomock.Any(), gomock.Any()).Return(&domain.Transaction{TransactionId: dummyTransactionId}, nil)
+			}
+
+			request := dto.TransferRequest{AccountId: dummyAccountId, CustomerId: dummyCustomerId, DestinationAccountId: "1980",
+				Amount: 2000, OverrideBeneficiaryCheck: tc.override}
+
+			//Act
+			_, err := svc.MakeTransfer(request)
+
+			//Assert
+			if tc.expectedStatusCode == 0 && err != nil {
+				t.Error("Expected no error but got error: " + err.Message)
+			}
+			if tc.expectedStatusCode != 0 && (err == nil || err.Code != tc.expectedStatusCode) {
+				t.Errorf("Expected status code %d but got %v", tc.expectedStatusCode, err)
+			}
+		})
+	}
+}
+
+func TestDefaultAccountService_MakeTransfer_setsFirstTransferDate_of_beneficiary(t *testing.T) {
+	//Arrange
+	ctrl := gomock.NewController(t)
+	accountRepo := mocksDomain.NewMockAccountRepository(ctrl)
+	beneficiaryRepo := mocksDomain.NewMockBeneficiaryRepository(ctrl)
+	unitOfWork := domain.NewUnitOfWorkStub(domain.Repositories{Accounts: accountRepo, Beneficiaries: beneficiaryRepo})
+	svc := NewAccountService(accountRepo, unitOfWork, nil, domain.DefaultBeneficiaryPolicy(), nil,
+		domain.Watchlist{}, clock.StaticClock{})
+
+	source := domain.Account{AccountId: dummyAccountId, CustomerId: dummyCustomerId, Amount: 5000, Currency: dto.DefaultCurrency}
+	destination := domain.Account{AccountId: "1980", CustomerId: "3", Currency: dto.DefaultCurrency}
+	beneficiary := domain.Beneficiary{BeneficiaryId: "3", CoolingOffStart: "2006-01-01 00:00:00"}
+	accountRepo.EXPECT().FindById(dummyAccountId).Return(&source, nil)
+	accountRepo.EXPECT().FindById("1980").Return(&destination, nil)
+	beneficiaryRepo.EXPECT().FindByAccount(dummyCustomerId, "1980").Return(&beneficiary, nil)
+	completed := domain.Transaction{TransactionId: dummyTransactionId, TransactionDate: "2006-01-02 15:04:05"}
+	accountRepo.EXPECT().Transfer(gomock.Any(), gomock.Any()).Return(&completed, nil)
+	beneficiaryRepo.EXPECT().SetFirstTransferDate("3", completed.TransactionDate).Return(nil)
+
+	request := dto.TransferRequest{AccountId: dummyAccountId, CustomerId: dummyCustomerId, DestinationAccountId: "1980", Amount: 500}
+
+	//Act
+	_, err := svc.MakeTransfer(request)
+
+	//Assert
+	if err != nil {
+		t.Error("Expected no error but got error: " + err.Message)
+	}
+}
+
+func TestDefaultAccountService_MakeTransfer_sanctionsScreening(t *testing.T) {
+	tests := []struct {
+		name         string
+		senderOnHold bool
+		recipient    domain.Customer
+		expectedErr  *errs.AppError
+	}{
+		{"sender on hold", true, domain.Customer{}, errCustomerOnHold},
+		{"recipient matches watchlist", false, domain.Customer{Id: "3", Name: "Viktor Ardenko", DateOfBirth: "1971-03-09"},
+			errTransferHeld},
+		{"recipient does not match watchlist", false, domain.Customer{Id: "3", Name: "Steve", DateOfBirth: "1978-12-15"}, nil},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Arrange
+			ctrl := gomock.NewController(t)
+			accountRepo := mocksDomain.NewMockAccountRepository(ctrl)
+			customerRepo := mocksDomain.NewMockCustomerRepository(ctrl)
+			sanctionsRepo := mocksDomain.NewMockSanctionsRepository(ctrl)
+			unitOfWork := domain.NewUnitOfWorkStub(domain.Repositories{Customers: customerRepo, Accounts: accountRepo,
+				Sanctions: sanctionsRepo})
+			watchlist := domain.Watchlist{Entries: []domain.WatchlistEntry{{Name: "Viktor Ardenko", DateOfBirth: "1971-03-09"}},
+				Thresholds: domain.DefaultSanctionsThresholds()}
+			svc := NewAccountService(accountRepo, unitOfWork, nil, domain.DefaultBeneficiaryPolicy(), nil, watchlist,
+				clock.StaticClock{})
+
+			source := domain.Account{AccountId: dummyAccountId, CustomerId: dummyCustomerId, Amount: 5000, Currency: dto.DefaultCurrency}
+			destination := domain.Account{AccountId: "1980", CustomerId: "3", Currency: dto.DefaultCurrency}
+			accountRepo.EXPECT().FindById(dummyAccountId).Return(&source, nil)
+			accountRepo.EXPECT().FindById("1980").Return(&destination, nil)
+			sanctionsRepo.EXPECT().IsOnHold(dummyCustomerId).Return(tc.senderOnHold, nil)
+			if !tc.senderOnHold {
+				customerRepo.EXPECT().FindById("3").Return(&tc.recipient, nil)
+				sanctionsRepo.EXPECT().IsOnHold("3").Return(false, nil)
+				sanctionsRepo.EXPECT().FindScreening("3").Return(nil, errs.NewNotFoundError("Customer has not been screened"))
+				sanctionsRepo.EXPECT().SaveScreening(gomock.Any()).Return(nil)
+			}
+			if tc.expectedErr == errTransferHeld {
+				sanctionsRepo.EXPECT().SaveHit(gomock.Any()).Times(2).DoAndReturn(
+					func(h domain.SanctionsHit) (*domain.SanctionsHit, *errs.AppError) { return &h, nil })
+			}
+			if tc.expectedErr == nil {
+				accountRepo.EXPECT().Transfer(gomock.Any(), gomock.Any()).Return(&domain.Transaction{TransactionId: dummyTransactionId}, nil)
+			} else {
+				accountRepo.EXPECT().Transfer(gomock.Any(), gomock.Any()).Times(0)
+			}
+
+			request := dto.TransferRequest{AccountId: dummyAccountId, CustomerId: dummyCustomerId, DestinationAccountId: "1980",
+				Amount: 500, OverrideBeneficiaryCheck: true}
+
+			//Act
+			_, err := svc.MakeTransfer(request)
+
+			//Assert
+			if err != tc.expectedErr {
+				t.Errorf("Expected error %v but got %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestDefaultAccountService_MakeTransaction_delegation(t *testing.T) {
+	tests := []struct {
+		name               string
+		scope              string
+		transactionType    string
+		withdrawnToday     float64
+		expectedStatusCode int
+	}{
+		{"deposit by deposit-only delegate", dto.DelegationScopeDepositOnly, dto.TransactionTypeDeposit, 0, 0},
+		{"withdrawal by deposit-only delegate", dto.DelegationScopeDepositOnly, dto.TransactionTypeWithdrawal, 0, http.StatusForbidden},
+		{"withdrawal within daily limit", dto.DelegationScopeWithdraw, dto.TransactionTypeWithdrawal, 300, 0},
+		{"withdrawal above daily limit", dto.DelegationScopeWithdraw, dto.TransactionTypeWithdrawal, 400, http.StatusUnprocessableEntity},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Arrange
+			ctrl := gomock.NewController(t)
+			accountRepo := mocksDomain.NewMockAccountRepository(ctrl)
+			delegationRepo := mocksDomain.NewMockDelegationRepository(ctrl)
+			unitOfWork := domain.NewUnitOfWorkStub(domain.Repositories{Accounts: accountRepo, Delegations: delegationRepo})
+			svc := NewAccountService(accountRepo, unitOfWork, nil, domain.DefaultBeneficiaryPolicy(), nil,
+				domain.Watchlist{}, clock.StaticClock{})
+
+			account := domain.Account{AccountId: dummyAccountId, CustomerId: dummyCustomerId, Amount: 5000}
+			delegation := domain.Delegation{DelegationId: "4", AccountId: dummyAccountId, DelegateCustomerId: "3",
+				Scope: tc.scope, DailyLimit: sql.NullFloat64{Float64: 500, Valid: tc.scope == dto.DelegationScopeWithdraw}}
+			accountRepo.EXPECT().FindById(dummyAccountId).Return(&account, nil)
+			accountRepo.EXPECT().FindHolder(dummyAccountId, "3").Return(nil, errs.NewNotFoundError("Account holder not found"))
+			delegationRepo.EXPECT().FindActive(dummyAccountId, "3", "2006-01-02 15:04:05").Return(&delegation, nil)
+			if tc.transactionType != dto.TransactionTypeDeposit {
+				delegationRepo.EXPECT().SumWithdrawn(dummyAccountId, "3", "2006-01-02 00:00:00").Return(tc.withdrawnToday, nil)
+			}
+			if tc.expectedStatusCode == 0 {
+				transaction := domain.NewTransaction(dummyAccountId, 200, tc.transactionType, clock.StaticClock{})
+				transaction.ActedBy("3")
+				accountRepo.EXPECT().Transact(transaction).Return(&transaction, nil)
+			} else {
+				accountRepo.EXPECT().Transact(gomock.Any()).Times(0)
+			}
+
+			request := dto.TransactionRequest{AccountId: dummyAccountId, CustomerId: "3", Amount: 200,
+				TransactionType: tc.transactionType}
+
+			//Act
+			_, err := svc.MakeTransaction(request)
+
+			//Assert
+			if tc.expectedStatusCode == 0 && err != nil {
+				t.Error("Expected no error but got error: " + err.Message)
+			}
+			if tc.expectedStatusCode != 0 && (err == nil || err.Code != tc.expectedStatusCode) {
+				t.Errorf("Expected status code %d but got %v", tc.expectedStatusCode, err)
+			}
+		})
+	}
+}
+
+func TestDefaultAccountService_MakeTransaction_returns_authorizationError_when_customer_viewOnlyHolder(t *testing.T) {
+	//Arrange
+	teardown := setupAccountServiceTest(t)
+	defer teardown()
+
+	account := domain.Account{AccountId: dummyAccountId, CustomerId: dummyCustomerId, Amount: 5000}
+	mockAccountRepo.EXPECT().FindById(dummyAccountId).Return(&account, nil)
+	expectHolder("3", dto.AccountHolderRoleViewOnly)
+	mockAccountRepo.EXPECT().Transact(gomock.Any()).Times(0)
+
+	request := dto.TransactionRequest{AccountId: dummyAccountId, CustomerId: "3", Amount: 200,
+		TransactionType: dto.TransactionTypeDeposit}
+
+	//Act
+	_, err := accSvc.MakeTransaction(request)
+
+	//Assert
+	if err == nil || err.Code != http.StatusForbidden {
+		t.Errorf("Expected authorization error but got %v", err)
+	}
+}
+
+func TestDefaultAccountService_MakeTransaction_without_holding_or_delegation_allowed_only_for_admin(t *testing.T) {
+	tests := []struct {
+		name               string
+		actingAdmin        bool
+		expectedStatusCode int
+	}{
+		{"user", false, http.StatusForbidden},
+		{"admin", true, 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Arrange
+			ctrl := gomock.NewController(t)
+			accountRepo := mocksDomain.NewMockAccountRepository(ctrl)
+			delegationRepo := mocksDomain.NewMockDelegationRepository(ctrl)
+			unitOfWork := domain.NewUnitOfWorkStub(domain.Repositories{Accounts: accountRepo, Delegations: delegationRepo})
+			svc := NewAccountService(accountRepo, unitOfWork, nil, domain.DefaultBeneficiaryPolicy(), nil,
+				domain.Watchlist{}, clock.StaticClock{})
+
+			account := domain.Account{AccountId: dummyAccountId, CustomerId: dummyCustomerId, Amount: 5000}
+			accountRepo.EXPECT().FindById(dummyAccountId).Return(&account, nil)
+			accountRepo.EXPECT().FindHolder(dummyAccountId, "3").Return(nil, errs.NewNotFoundError("Account holder not found"))
+			delegationRepo.EXPECT().FindActive(dummyAccountId, "3", "2006-01-02 15:04:05").
+				Return(nil, errs.NewNotFoundError("Delegation not found"))
+			if tc.expectedStatusCode == 0 {
+				transaction := domain.NewTransaction(dummyAccountId, 200, dto.TransactionTypeDeposit, clock.StaticClock{})
+				transaction.ActedBy("3")
+				accountRepo.EXPECT().Transact(transaction).Return(&transaction, nil)
+			} else {
+				accountRepo.EXPECT().Transact(gomock.Any()).Times(0)
+			}
+
+			request := dto.TransactionRequest{AccountId: dummyAccountId, CustomerId: "3", Amount: 200,
+				TransactionType: dto.TransactionTypeDeposit, ActingAdmin: tc.actingAdmin}
+
+			//Act
+			_, err := svc.MakeTransaction(request)
+
+			//Assert
+			if tc.expectedStatusCode == 0 && err != nil {
+				t.Error("Expected no error but got error: " + err.Message)
+			}
+			if tc.expectedStatusCode != 0 && (err == nil || err.Code != tc.expectedStatusCode) {
+				t.Errorf("Expected status code %d but got %v", tc.expectedStatusCode, err)
+			}
+		})
+	}
+}
+
+func TestDefaultAccountService_GetTransactions_allowed_only_for_holders_and_delegates(t *testing.T) {
+	tests := []struct {
+		name               string
+		delegationErr      *errs.AppError
+		expectedStatusCode int
+	}{
+		{"delegate", nil, 0},
+		{"neither holder nor delegate", errs.NewNotFoundError("Delegation not found"), http.StatusNotFound},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Arrange
+			ctrl := gomock.NewController(t)
+			accountRepo := mocksDomain.NewMockAccountRepository(ctrl)
+			delegationRepo := mocksDomain.NewMockDelegationRepository(ctrl)
+			unitOfWork := domain.NewUnitOfWorkStub(domain.Repositories{Accounts: accountRepo, Delegations: delegationRepo})
+			svc := NewAccountService(accountRepo, unitOfWork, nil, domain.DefaultBeneficiaryPolicy(), nil,
+				domain.Watchlist{}, clock.StaticClock{})
+
+			accountRepo.EXPECT().FindHolder(dummyAccountId, "3").Return(nil, errs.NewNotFoundError("Account not found"))
+			if tc.delegationErr == nil {
+				delegationRepo.EXPECT().FindActive(dummyAccountId, "3", "2006-01-02 15:04:05").
+					Return(&domain.Delegation{DelegationId: "4", Scope: dto.DelegationScopeViewOnly}, nil)
+				accountRepo.EXPECT().FindTransactions(dummyAccountId).Return([]domain.Transaction{}, nil)
+			} else {
+				delegationRepo.EXPECT().FindActive(dummyAccountId, "3", "2006-01-02 15:04:05").Return(nil, tc.delegationErr)
+				accountRepo.EXPECT().FindTransactions(gomock.Any()).Times(0)
+			}
+
+			//Act
+			_, err := svc.GetTransactions("3", dummyAccountId)
+
+			//Assert
+			if tc.expectedStatusCode == 0 && err != nil {
+				t.Error("Expected no error but got error: " + err.Message)
+			}
+			if tc.expectedStatusCode != 0 && (err == nil || err.Code != tc.expectedStatusCode) {
+				t.Errorf("Expected status code %d but got %v", tc.expectedStatusCode, err)
+			}
+		})
+	}
+}
+
+func TestDefaultAccountService_MakeTransfer_returns_error_when_delegate_exceedsDailyLimit(t *testing.T) {
+	//Arrange
+	ctrl := gomock.NewController(t)
+	accountRepo := mocksDomain.NewMockAccountRepository(ctrl)
+	delegationRepo := mocksDomain.NewMockDelegationRepository(ctrl)
+	unitOfWork := domain.NewUnitOfWorkStub(domain.Repositories{Accounts: accountRepo, Delegations: delegationRepo})
+	svc := NewAccountService(accountRepo, unitOfWork, nil, domain.DefaultBeneficiaryPolicy(), nil,
+		domain.Watchlist{}, clock.StaticClock{})
+
+	account := domain.Account{AccountId: dummyAccountId, CustomerId: dummyCustomerId, Amount: 5000}
+	delegation := domain.Delegation{DelegationId: "4", AccountId: dummyAccountId, DelegateCustomerId: "3",
+		Scope: dto.DelegationScopeWithdraw, DailyLimit: sql.NullFloat64{Float64: 500, Valid: true}}
+	accountRepo.EXPECT().FindById(dummyAccountId).Return(&account, nil)
+	accountRepo.EXPECT().FindHolder(dummyAccountId, "3").Return(nil, errs.NewNotFoundError("Account holder not found"))
+	delegationRepo.EXPECT().FindActive(dummyAccountId, "3", "2006-01-02 15:04:05").Return(&delegation, nil)
+	delegationRepo.EXPECT().SumWithdrawn(dummyAccountId, "3", "2006-01-02 00:00:00").Return(float64(400), nil)
+	accountRepo.EXPECT().Transfer(gomock.Any(), gomock.Any()).Times(0)
+
+	request := dto.TransferRequest{AccountId: dummyAccountId, CustomerId: "3", DestinationAccountId: "1980", Amount: 200}
+
+	//Act
+	_, err := svc.MakeTransfer(request)
+
+	//Assert
+	if err == nil || err.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected validation error but got %v", err)
+	}
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+)
+
+// auditVerifyBatchSize is the number of audit entries read at a time while verifying the audit log.
+const auditVerifyBatchSize = 500
+
+//go:generate mockgen -destination=../mocks/service/mock_auditService.go -package=service github.com/aliciatay-zls/banking/backend/service AuditService
+type AuditService interface { //service (primary port)
+	Record(domain.AuditEntry) *errs.AppError
+	GetAuditLog(dto.AuditLogRequest) (*dto.AuditLogResponse, *errs.AppError)
+	VerifyAuditLog() (*dto.AuditVerificationResponse, *errs.AppError)
+}
+
+type DefaultAuditService struct { //business/domain object
+	repo domain.AuditRepository
+	clk  clock.Clock
+}
+
+func NewAuditService(repo domain.AuditRepository, clk clock.Clock) DefaultAuditService {
+	return DefaultAuditService{repo, clk}
+}
+
+// Record appends the given entry to the audit log, timestamped with the current time.
+func (s DefaultAuditService) Record(entry domain.AuditEntry) *errs.AppError {
+	entry.CreatedOn = s.clk.NowAsString()
+	_, err := s.repo.Append(entry)
+	return err
+}
+
+// GetAuditLog returns a page of the audit log entries matching the given request, oldest first. If the page is full,
+// the response says which after_id to request the next page with.
+func (s DefaultAuditService) GetAuditLog(request dto.AuditLogRequest) (*dto.AuditLogResponse, *errs.AppError) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	filter := domain.AuditFilter{
+		ActorCustomerId: request.CustomerId,
+		RouteName:       request.RouteName,
+		AfterId:         request.AfterId,
+		Limit:           request.LimitOrDefault(),
+	}
+	if request.From != "" {
+		filter.From = request.From + " 00:00:00"
+	}
+	if request.To != "" {
+		filter.To = request.To + " 23:59:59"
+	}
+
+	entries, err := s.repo.FindEntries(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	response := dto.AuditLogResponse{Entries: make([]dto.AuditEntryResponse, 0)}
+	for _, e := range entries {
+		response.Entries = append(response.Entries, e.ToDTO())
+	}
+	if len(entries) == filter.Limit {
+		response.NextAfterId = entries[len(entries)-1].EntryId
+	}
+	return &response, nil
+}
+
+// VerifyAuditLog walks the whole audit log from the first entry, checking that each entry follows the one before it
+// and still matches its hash. It reports the first entry that does not, if any. Entries removed from the end of the
+// log cannot be detected this way, so the hash of the last entry is returned to be compared with a copy kept
+// elsewhere.
+func (s DefaultAuditService) VerifyAuditLog() (*dto.AuditVerificationResponse, *errs.AppError) {
+	response := dto.AuditVerificationResponse{Valid: true, LastHash: domain.AuditGenesisHash}
+	filter := domain.AuditFilter{Limit: auditVerifyBatchSize}
+	for {
+		entries, err := s.repo.FindEntries(filter)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, e := range entries {
+			if reason := e.Verify(response.LastHash); reason != "" {
+				response.Valid = false
+				response.InvalidEntryId = e.EntryId
+				response.Reason = reason
+				return &response, nil
+			}
+			response.LastHash = e.EntryHash
+			response.EntriesChecked++
+		}
+
+		if len(entries) < filter.Limit {
+			return &response, nil
+		}
+		filter.AfterId = entries[len(entries)-1].EntryId
+	}
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"fmt"
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	mocksDomain "github.com/aliciatay-zls/banking/backend/mocks/domain"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"testing"
+)
+
+// Test common variables and inputs
+var mockAuditRepo *mocksDomain.MockAuditRepository
+var auditSvc DefaultAuditService
+
+func setupAuditServiceTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	mockAuditRepo = mocksDomain.NewMockAuditRepository(ctrl)
+	auditSvc = NewAuditService(mockAuditRepo, clock.StaticClock{})
+
+	return func() {
+		mockAuditRepo = nil
+		defer ctrl.Finish()
+	}
+}
+
+// getDummyAuditChain returns the given number of correctly chained audit entries with ids from 1.
+func getDummyAuditChain(n int) []domain.AuditEntry {
+	entries := make([]domain.AuditEntry, 0)
+	previousHash := domain.AuditGenesisHash
+	for k := 1; k <= n; k++ {
+		entry := domain.NewAuditEntry(domain.Actor{Role: domain.RoleAdmin}, "NewAccount", nil, "abc", http.StatusCreated, "127.0.0.1")
+		entry.EntryId = fmt.Sprint(k)
+		entry.CreatedOn = dummyNow
+		entry.Chain(previousHash)
+		previousHash = entry.EntryHash
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestDefaultAuditService_Record_timestamps_and_appends_entry(t *testing.T) {
+	//Arrange
+	teardown := setupAuditServiceTest(t)
+	defer teardown()
+
+	entry := domain.NewAuditEntry(domain.Actor{}, "NewAccount", nil, "abc", http.StatusCreated, "127.0.0.1")
+	expected := entry
+	expected.CreatedOn = dummyNow
+	mockAuditRepo.EXPECT().Append(expected).Return(&expected, nil)
+
+	//Act
+	err := auditSvc.Record(entry)
+
+	//Assert
+	if err != nil {
+		t.Error("Expected no error but got error: " + err.Message)
+	}
+}
+
+func TestDefaultAuditService_GetAuditLog_returns_page_with_nextAfterId_when_page_full(t *testing.T) {
+	//Arrange
+	teardown := setupAuditServiceTest(t)
+	defer teardown()
+
+	request := dto.AuditLogRequest{CustomerId: dummyCustomerId, From: "2006-01-01", To: "2006-01-02", Limit: "2"}
+	expectedFilter := domain.AuditFilter{ActorCustomerId: dummyCustomerId, From: "2006-01-01 00:00:00",
+		To: "2006-01-02 23:59:59", Limit: 2}
+	mockAuditRepo.EXPECT().FindEntries(expectedFilter).Return(getDummyAuditChain(2), nil)
+
+	//Act
+	response, err := auditSvc.GetAuditLog(request)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if len(response.Entries) != 2 || response.NextAfterId != "2" {
+		t.Errorf("Expected 2 entries and next after id 2 but got %+v", *response)
+	}
+}
+
+func TestDefaultAuditService_VerifyAuditLog_reports_valid_chain_across_batches(t *testing.T) {
+	//Arrange
+	teardown := setupAuditServiceTest(t)
+	defer teardown()
+
+	chain := getDummyAuditChain(auditVerifyBatchSize + 1)
+	gomock.InOrder(
+		mockAuditRepo.EXPECT().FindEntries(domain.AuditFilter{Limit: auditVerifyBatchSize}).Return(chain[:auditVerifyBatchSize], nil),
+		mockAuditRepo.EXPECT().FindEntries(domain.AuditFilter{AfterId: fmt.Sprint(auditVerifyBatchSize), Limit: auditVerifyBatchSize}).
+			Return(chain[auditVerifyBatchSize:], nil),
+	)
+
+	//Act
+	response, err := auditSvc.VerifyAuditLog()
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if !response.Valid || response.EntriesChecked != len(chain) || response.LastHash != chain[len(chain)-1].EntryHash {
+		t.Errorf("Expected valid chain of %d entries but got %+v", len(chain), *response)
+	}
+}
+
+func TestDefaultAuditService_VerifyAuditLog_reports_first_invalid_entry(t *testing.T) {
+	//Arrange
+	teardown := setupAuditServiceTest(t)
+	defer teardown()
+
+	chain := getDummyAuditChain(4)
+	tampered := append(chain[:1:1], chain[2:]...) //second entry removed
+	mockAuditRepo.EXPECT().FindEntries(domain.AuditFilter{Limit: auditVerifyBatchSize}).Return(tampered, nil)
+
+	//Act
+	response, err := auditSvc.VerifyAuditLog()
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if response.Valid || response.InvalidEntryId != "3" || response.EntriesChecked != 1 || response.LastHash != chain[0].EntryHash {
+		t.Errorf("Expected entry 3 to be reported after 1 valid entry but got %+v", *response)
+	}
+}
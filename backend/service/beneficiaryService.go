@@ -0,0 +1,135 @@
+package service
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+)
+
+//go:generate mockgen -destination=../mocks/service/mock_beneficiaryService.go -package=service github.com/aliciatay-zls/banking/backend/service BeneficiaryService
+type BeneficiaryService interface { //service (primary port)
+	AddBeneficiary(dto.BeneficiaryRequest) (*dto.BeneficiaryResponse, *errs.AppError)
+	GetBeneficiaries(string) ([]dto.BeneficiaryResponse, *errs.AppError)
+	UpdateBeneficiary(dto.BeneficiaryRequest) (*dto.BeneficiaryResponse, *errs.AppError)
+	DeleteBeneficiary(customerId string, beneficiaryId string) *errs.AppError
+}
+
+type DefaultBeneficiaryService struct { //business/domain object
+	repo   domain.BeneficiaryRepository
+	uow    domain.UnitOfWork
+	policy domain.BeneficiaryPolicy
+	clk    clock.Clock
+}
+
+func NewBeneficiaryService(repo domain.BeneficiaryRepository, uow domain.UnitOfWork, policy domain.BeneficiaryPolicy,
+	clk clock.Clock) DefaultBeneficiaryService {
+	return DefaultBeneficiaryService{repo, uow, policy, clk}
+}
+
+// AddBeneficiary checks that the customer does not already have a beneficiary with the given account and, for an
+// account of this bank, that the account exists. If so, it adds the beneficiary, starting its cooling-off period. The
+// checks and the change are made in one unit of work, as for updating and deleting a beneficiary.
+func (s DefaultBeneficiaryService) AddBeneficiary(request dto.BeneficiaryRequest) (*dto.BeneficiaryResponse, *errs.AppError) {
+	var beneficiary *domain.Beneficiary
+	err := s.uow.Do(func(repos domain.Repositories) *errs.AppError {
+		if err := checkBeneficiaryAccount(repos, request, ""); err != nil {
+			return err
+		}
+
+		var err *errs.AppError
+		beneficiary, err = repos.Beneficiaries.Save(domain.NewBeneficiary(request, s.clk))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return beneficiary.ToDTO(s.policy), nil
+}
+
+// GetBeneficiaries returns all beneficiaries of the given customer.
+func (s DefaultBeneficiaryService) GetBeneficiaries(customerId string) ([]dto.BeneficiaryResponse, *errs.AppError) {
+	beneficiaries, err := s.repo.FindAll(customerId)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.BeneficiaryResponse, 0)
+	for _, b := range beneficiaries {
+		response = append(response, *b.ToDTO(s.policy))
+	}
+	return response, nil
+}
+
+// UpdateBeneficiary replaces the customer's beneficiary with the given ID by the given one. Changing the account it
+// pays to is checked the same way as adding a beneficiary, and starts its cooling-off period again.
+func (s DefaultBeneficiaryService) UpdateBeneficiary(request dto.BeneficiaryRequest) (*dto.BeneficiaryResponse, *errs.AppError) {
+	var updated domain.Beneficiary
+	err := s.uow.Do(func(repos domain.Repositories) *errs.AppError {
+		beneficiary, err := findCustomerBeneficiary(repos.Beneficiaries, request.CustomerId, request.BeneficiaryId)
+		if err != nil {
+			return err
+		}
+
+		if !beneficiary.IsSameAccount(request.AccountId, request.BankCode) {
+			if err = checkBeneficiaryAccount(repos, request, beneficiary.BeneficiaryId); err != nil {
+				return err
+			}
+		}
+
+		updated = beneficiary.Update(request, s.clk)
+		return repos.Beneficiaries.Update(updated)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated.ToDTO(s.policy), nil
+}
+
+// DeleteBeneficiary removes the customer's beneficiary with the given ID, after which the customer can no longer
+// transfer to it.
+func (s DefaultBeneficiaryService) DeleteBeneficiary(customerId string, beneficiaryId string) *errs.AppError {
+	return s.uow.Do(func(repos domain.Repositories) *errs.AppError {
+		if _, err := findCustomerBeneficiary(repos.Beneficiaries, customerId, beneficiaryId); err != nil {
+			return err
+		}
+		return repos.Beneficiaries.Delete(beneficiaryId)
+	})
+}
+
+// findCustomerBeneficiary retrieves the beneficiary with the given ID, as long as it is one of the given customer's.
+func findCustomerBeneficiary(repo domain.BeneficiaryRepository, customerId string,
+	beneficiaryId string) (*domain.Beneficiary, *errs.AppError) {
+	beneficiary, err := repo.FindById(beneficiaryId)
+	if err != nil {
+		return nil, err
+	}
+	if beneficiary.CustomerId != customerId {
+		logger.Error("Beneficiary " + beneficiaryId + " does not belong to customer " + customerId)
+		return nil, errs.NewNotFoundError("Beneficiary not found")
+	}
+	return beneficiary, nil
+}
+
+// checkBeneficiaryAccount checks that none of the customer's beneficiaries other than the one with the given ID has
+// the requested account and, for an account of this bank, that the account exists.
+func checkBeneficiaryAccount(repos domain.Repositories, request dto.BeneficiaryRequest,
+	beneficiaryId string) *errs.AppError {
+	beneficiaries, err := repos.Beneficiaries.FindAll(request.CustomerId)
+	if err != nil {
+		return err
+	}
+	for _, b := range beneficiaries {
+		if b.BeneficiaryId != beneficiaryId && b.IsSameAccount(request.AccountId, request.BankCode) {
+			return errs.NewConflictError("Beneficiary " + b.Nickname + " already has this account")
+		}
+	}
+
+	if request.BankCode == "" {
+		if _, err = repos.Accounts.FindById(request.AccountId); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	mocksDomain "github.com/aliciatay-zls/banking/backend/mocks/domain"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"testing"
+)
+
+// Test common variables and inputs
+var mockBeneficiaryRepo *mocksDomain.MockBeneficiaryRepository
+var beneficiarySvc DefaultBeneficiaryService
+
+const dummyBeneficiaryId = "3"
+
+func setupBeneficiaryServiceTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	mockBeneficiaryRepo = mocksDomain.NewMockBeneficiaryRepository(ctrl)
+	mockAccountRepo = mocksDomain.NewMockAccountRepository(ctrl)
+	uow := domain.NewUnitOfWorkStub(domain.Repositories{Accounts: mockAccountRepo, Beneficiaries: mockBeneficiaryRepo})
+	beneficiarySvc = NewBeneficiaryService(mockBeneficiaryRepo, uow, domain.DefaultBeneficiaryPolicy(), clock.StaticClock{})
+
+	return func() {
+		mockBeneficiaryRepo = nil
+		mockAccountRepo = nil
+		defer ctrl.Finish()
+	}
+}
+
+func getDummyBeneficiary() domain.Beneficiary {
+	return domain.Beneficiary{BeneficiaryId: dummyBeneficiaryId, CustomerId: dummyCustomerId, Nickname: "landlord",
+		AccountId: "1980", CreationDate: "2006-01-01 15:04:05", CoolingOffStart: "2006-01-01 15:04:05"}
+}
+
+func TestDefaultBeneficiaryService_AddBeneficiary_returns_error_when_account_alreadyBeneficiary(t *testing.T) {
+	//Arrange
+	teardown := setupBeneficiaryServiceTest(t)
+	defer teardown()
+
+	mockBeneficiaryRepo.EXPECT().FindAll(dummyCustomerId).Return([]domain.Beneficiary{getDummyBeneficiary()}, nil)
+	mockBeneficiaryRepo.EXPECT().Save(gomock.Any()).Times(0)
+
+	request := dto.BeneficiaryRequest{CustomerId: dummyCustomerId, Nickname: "rent", AccountId: "1980"}
+
+	//Act
+	_, err := beneficiarySvc.AddBeneficiary(request)
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing duplicate beneficiary")
+	}
+	if err.Code != http.StatusConflict {
+		t.Errorf("Expected status code %d but got %d", http.StatusConflict, err.Code)
+	}
+}
+
+func TestDefaultBeneficiaryService_AddBeneficiary_returns_error_when_account_nonExistent(t *testing.T) {
+	//Arrange
+	teardown := setupBeneficiaryServiceTest(t)
+	defer teardown()
+
+	mockBeneficiaryRepo.EXPECT().FindAll(dummyCustomerId).Return(nil, nil)
+	mockAccountRepo.EXPECT().FindById("1980").Return(nil, errs.NewNotFoundError("Account not found"))
+	mockBeneficiaryRepo.EXPECT().Save(gomock.Any()).Times(0)
+
+	request := dto.BeneficiaryRequest{CustomerId: dummyCustomerId, Nickname: "landlord", AccountId: "1980"}
+
+	//Act
+	_, err := beneficiarySvc.AddBeneficiary(request)
+
+	//Assert
+	if err == nil || err.Code != http.StatusNotFound {
+		t.Errorf("Expected not found error but got %v", err)
+	}
+}
+
+func TestDefaultBeneficiaryService_AddBeneficiary_skipsAccountCheck_when_account_ofOtherBank(t *testing.T) {
+	//Arrange
+	teardown := setupBeneficiaryServiceTest(t)
+	defer teardown()
+
+	request := dto.BeneficiaryRequest{CustomerId: dummyCustomerId, Nickname: "sister", AccountId: "DE89370400440532013000",
+		BankCode: "DEUTDEFF"}
+	saved := domain.NewBeneficiary(request, clock.StaticClock{})
+	saved.BeneficiaryId = dummyBeneficiaryId
+	mockBeneficiaryRepo.EXPECT().FindAll(dummyCustomerId).Return([]domain.Beneficiary{getDummyBeneficiary()}, nil)
+	mockBeneficiaryRepo.EXPECT().Save(domain.NewBeneficiary(request, clock.StaticClock{})).Return(&saved, nil)
+
+	//Act
+	response, err := beneficiarySvc.AddBeneficiary(request)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if response.BeneficiaryId != dummyBeneficiaryId || response.CoolingOffEndDate != "2006-01-03 15:04:05" ||
+		response.TransferLimit != 1000 {
+		t.Errorf("Expected new beneficiary in cooling-off period but got %+v", *response)
+	}
+}
+
+func TestDefaultBeneficiaryService_DeleteBeneficiary_returns_error_when_beneficiary_ofOtherCustomer(t *testing.T) {
+	//Arrange
+	teardown := setupBeneficiaryServiceTest(t)
+	defer teardown()
+
+	beneficiary := getDummyBeneficiary()
+	beneficiary.CustomerId = "3"
+	mockBeneficiaryRepo.EXPECT().FindById(dummyBeneficiaryId).Return(&beneficiary, nil)
+	mockBeneficiaryRepo.EXPECT().Delete(gomock.Any()).Times(0)
+
+	//Act
+	err := beneficiarySvc.DeleteBeneficiary(dummyCustomerId, dummyBeneficiaryId)
+
+	//Assert
+	if err == nil || err.Code != http.StatusNotFound {
+		t.Errorf("Expected not found error but got %v", err)
+	}
+}
+
+func TestDefaultBeneficiaryService_UpdateBeneficiary_keepsCoolingOff_when_account_unchanged(t *testing.T) {
+	//Arrange
+	teardown := setupBeneficiaryServiceTest(t)
+	defer teardown()
+
+	beneficiary := getDummyBeneficiary()
+	mockBeneficiaryRepo.EXPECT().FindById(dummyBeneficiaryId).Return(&beneficiary, nil)
+	updated := beneficiary
+	updated.Nickname = "old landlord"
+	mockBeneficiaryRepo.EXPECT().Update(updated).Return(nil)
+
+	request := dto.BeneficiaryRequest{BeneficiaryId: dummyBeneficiaryId, CustomerId: dummyCustomerId,
+		Nickname: "old landlord", AccountId: "1980"}
+
+	//Act
+	response, err := beneficiarySvc.UpdateBeneficiary(request)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if response.CoolingOffEndDate != "2006-01-02 15:04:05" {
+		t.Errorf("Expected cooling-off period to end at 2006-01-02 15:04:05 but got %s", response.CoolingOffEndDate)
+	}
+}
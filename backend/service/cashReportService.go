@@ -0,0 +1,90 @@
+package service
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"time"
+)
+
+//go:generate mockgen -destination=../mocks/service/mock_cashReportService.go -package=service github.com/aliciatay-zls/banking/backend/service CashReportService
+type CashReportService interface { //service (primary port)
+	RunDailyJob() *errs.AppError
+	GenerateReport(dto.CashReportRequest) (*dto.CashReportResponse, *errs.AppError)
+}
+
+type DefaultCashReportService struct { //business/domain object
+	repo         domain.CashReportRepository
+	customerRepo domain.CustomerRepository
+	writer       domain.CashReportWriter
+	threshold    float64
+	clk          clock.Clock
+}
+
+func NewCashReportService(repo domain.CashReportRepository, customerRepo domain.CustomerRepository,
+	writer domain.CashReportWriter, threshold float64, clk clock.Clock) DefaultCashReportService {
+	return DefaultCashReportService{repo, customerRepo, writer, threshold, clk}
+}
+
+// RunDailyJob writes the cash report of the day that has just ended according to the clock, unless it has already
+// been written, so the job can safely be run more than once a day.
+func (s DefaultCashReportService) RunDailyJob() *errs.AppError {
+	now := s.clk.Now()
+	yesterday := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()).AddDate(0, 0, -1)
+	date := yesterday.Format(domain.FormatDate)
+	name := "cash-report-" + date
+
+	written, err := s.writer.HasReport(name)
+	if err != nil || written {
+		return err
+	}
+
+	report, err := s.GenerateReport(dto.CashReportRequest{From: date, To: date, Format: dto.CashReportFormatJson})
+	if err != nil {
+		return err
+	}
+	if err = s.writer.WriteReport(name, *report); err != nil {
+		return err
+	}
+	logger.Info("Cash report " + name + " written")
+	return nil
+}
+
+// GenerateReport returns the report of the cash transactions made on the days in the given request that are above
+// the threshold, either on their own or together with the other cash transactions of the same customer, type and
+// currency on the same day, along with the details of the customers who made them.
+func (s DefaultCashReportService) GenerateReport(request dto.CashReportRequest) (*dto.CashReportResponse, *errs.AppError) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+	to, _ := time.Parse(domain.FormatDate, request.To) //already validated
+
+	transactions, err := s.repo.FindCashTransactions(request.From, to.AddDate(0, 0, 1).Format(domain.FormatDate))
+	if err != nil {
+		return nil, err
+	}
+
+	response := dto.CashReportResponse{
+		From:           request.From,
+		To:             request.To,
+		Threshold:      s.threshold,
+		GenerationDate: s.clk.NowAsString(),
+		Entries:        make([]dto.CashReportEntryResponse, 0),
+	}
+	customers := make(map[string]domain.Customer)
+	for _, e := range domain.BuildCashReport(transactions, s.threshold) {
+		c, ok := customers[e.CustomerId]
+		if !ok {
+			customer, err := s.customerRepo.FindById(e.CustomerId)
+			if err != nil {
+				return nil, err
+			}
+			c = *customer
+			customers[e.CustomerId] = c
+		}
+		response.Entries = append(response.Entries, e.ToDTO(c))
+	}
+	return &response, nil
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	mocksDomain "github.com/aliciatay-zls/banking/backend/mocks/domain"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+// Test common variables and inputs
+var mockCashReportRepo *mocksDomain.MockCashReportRepository
+var mockCashReportCustomerRepo *mocksDomain.MockCustomerRepository
+var mockCashReportWriter *mocksDomain.MockCashReportWriter
+var cashReportSvc DefaultCashReportService
+
+func setupCashReportServiceTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	mockCashReportRepo = mocksDomain.NewMockCashReportRepository(ctrl)
+	mockCashReportCustomerRepo = mocksDomain.NewMockCustomerRepository(ctrl)
+	mockCashReportWriter = mocksDomain.NewMockCashReportWriter(ctrl)
+	cashReportSvc = NewCashReportService(mockCashReportRepo, mockCashReportCustomerRepo, mockCashReportWriter,
+		domain.DefaultCashReportThreshold, clock.StaticClock{})
+
+	return func() {
+		mockCashReportRepo = nil
+		mockCashReportCustomerRepo = nil
+		mockCashReportWriter = nil
+		defer ctrl.Finish()
+	}
+}
+
+func TestDefaultCashReportService_GenerateReport_looksUp_eachCustomer_once(t *testing.T) {
+	//Arrange
+	teardown := setupCashReportServiceTest(t)
+	defer teardown()
+
+	deposit := func(id string, amount float64) domain.CashTransaction {
+		return domain.CashTransaction{TransactionId: id, AccountId: "95470", CustomerId: "2000", Currency: "INR",
+			Amount: amount, TransactionType: dto.TransactionTypeDeposit, TransactionDate: "2006-01-02 10:00:00"}
+	}
+	mockCashReportRepo.EXPECT().FindCashTransactions("2006-01-01", "2006-01-03").
+		Return([]domain.CashTransaction{deposit("1", 12000), deposit("2", 6000), deposit("3", 6000)}, nil)
+	mockCashReportCustomerRepo.EXPECT().FindById("2000").
+		Return(&domain.Customer{Id: "2000", Name: "Steve", Country: "India"}, nil).Times(1)
+
+	request := dto.CashReportRequest{From: "2006-01-01", To: "2006-01-02", Format: dto.CashReportFormatCsv}
+
+	//Act
+	response, err := cashReportSvc.GenerateReport(request)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if len(response.Entries) != 2 || response.Entries[0].Kind != dto.CashReportKindSingle ||
+		response.Entries[1].Kind != dto.CashReportKindAggregate || response.Entries[1].Name != "Steve" {
+		t.Errorf("Expected single and aggregate entries of Steve but got %+v", response.Entries)
+	}
+}
+
+func TestDefaultCashReportService_RunDailyJob_writes_yesterdaysReport(t *testing.T) {
+	//Arrange
+	teardown := setupCashReportServiceTest(t)
+	defer teardown()
+
+	mockCashReportWriter.EXPECT().HasReport("cash-report-2006-01-01").Return(false, nil)
+	mockCashReportRepo.EXPECT().FindCashTransactions("2006-01-01", "2006-01-02").Return([]domain.CashTransaction{}, nil)
+	mockCashReportWriter.EXPECT().WriteReport("cash-report-2006-01-01", dto.CashReportResponse{From: "2006-01-01",
+		To: "2006-01-01", Threshold: domain.DefaultCashReportThreshold, GenerationDate: "2006-01-02 15:04:05",
+		Entries: []dto.CashReportEntryResponse{}}).Return(nil)
+
+	//Act
+	err := cashReportSvc.RunDailyJob()
+
+	//Assert
+	if err != nil {
+		t.Error("Expected no error but got error: " + err.Message)
+	}
+}
+
+func TestDefaultCashReportService_RunDailyJob_skips_writtenReport(t *testing.T) {
+	//Arrange
+	teardown := setupCashReportServiceTest(t)
+	defer teardown()
+
+	mockCashReportWriter.EXPECT().HasReport("cash-report-2006-01-01").Return(true, nil)
+	mockCashReportRepo.EXPECT().FindCashTransactions(gomock.Any(), gomock.Any()).Times(0)
+	mockCashReportWriter.EXPECT().WriteReport(gomock.Any(), gomock.Any()).Times(0)
+
+	//Act
+	err := cashReportSvc.RunDailyJob()
+
+	//Assert
+	if err != nil {
+		t.Error("Expected no error but got error: " + err.Message)
+	}
+}
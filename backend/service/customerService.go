@@ -2,6 +2,7 @@ package service
 
 import (
 	"fmt"
+	"github.com/aliciatay-zls/banking-lib/clock"
 	"github.com/aliciatay-zls/banking-lib/errs"
 	"github.com/aliciatay-zls/banking-lib/logger"
 	"github.com/aliciatay-zls/banking/backend/domain"
@@ -12,14 +13,16 @@ import (
 type CustomerService interface { //service (primary port)
 	GetAllCustomers(string) ([]dto.CustomerResponse, *errs.AppError)
 	GetCustomer(string) (*dto.CustomerResponse, *errs.AppError)
+	SetCustomerStatus(dto.CustomerStatusRequest) (*dto.CustomerResponse, *errs.AppError)
 }
 
 type DefaultCustomerService struct { //business/domain object
 	repo domain.CustomerRepository //Business Domain has dependency on repo (repo is a field)
+	clk  clock.Clock
 }
 
-func NewCustomerService(repository domain.CustomerRepository) DefaultCustomerService { //helper function to create and initialize a business object
-	return DefaultCustomerService{repository}
+func NewCustomerService(repository domain.CustomerRepository, clk clock.Clock) DefaultCustomerService { //helper function to create and initialize a business object
+	return DefaultCustomerService{repository, clk}
 }
 
 func (s DefaultCustomerService) GetAllCustomers(status string) ([]dto.CustomerResponse, *errs.AppError) { //Business Domain implements service
@@ -55,6 +58,15 @@ func (s DefaultCustomerService) GetCustomer(id string) (*dto.CustomerResponse, *
 	return c.ToDTO(), nil
 }
 
+// SetCustomerStatus activates or deactivates the given customer and returns the customer's updated profile. Other
+// services are told about the change through the CustomerStatusChanged event.
+func (s DefaultCustomerService) SetCustomerStatus(request dto.CustomerStatusRequest) (*dto.CustomerResponse, *errs.AppError) {
+	if err := s.repo.UpdateStatus(request.CustomerId, domain.AsStatusValue(request.Status), s.clk.NowAsString()); err != nil {
+		return nil, err
+	}
+	return s.GetCustomer(request.CustomerId)
+}
+
 // (*)
 //calls repo's method, which is either the stub implementation or the DB implementation, depending on whether repo is of
 //type domain.CustomerRepositoryStub or domain.CustomerRepositoryDb respectively
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"github.com/aliciatay-zls/banking-lib/clock"
 	"github.com/aliciatay-zls/banking-lib/errs"
 	"github.com/aliciatay-zls/banking-lib/logger"
 	"github.com/aliciatay-zls/banking/backend/domain"
@@ -20,7 +21,7 @@ const dummyConvertedStatus = ""
 func setupCustomerServiceTest(t *testing.T) func() {
 	ctrl := gomock.NewController(t)
 	mockCustomerRepo = mocksDomain.NewMockCustomerRepository(ctrl)
-	cusSvc = NewCustomerService(mockCustomerRepo)
+	cusSvc = NewCustomerService(mockCustomerRepo, clock.StaticClock{})
 
 	return func() {
 		mockCustomerRepo = nil
@@ -37,7 +38,7 @@ func getDefaultDummyCustomers() []domain.Customer {
 
 func TestDefaultCustomerService_GetAllCustomers_returns_error_when_invalid_status(t *testing.T) {
 	//Arrange
-	cusSvc = NewCustomerService(nil)
+	cusSvc = NewCustomerService(nil, clock.StaticClock{})
 
 	invalidStatus := "some status"
 	expectedErrMessage := "Invalid status"
@@ -157,3 +158,42 @@ func TestDefaultCustomerService_GetCustomer_returns_a_customer_when_repo_succeed
 		t.Errorf("Expected customer %v but got customer %v", expectedCustomerResponse, actualCustomerResponse)
 	}
 }
+
+func TestDefaultCustomerService_SetCustomerStatus_updates_status_and_returns_customer(t *testing.T) {
+	//Arrange
+	teardown := setupCustomerServiceTest(t)
+	defer teardown()
+
+	customer := getDefaultDummyCustomers()[1]
+	customer.Status = "1"
+	mockCustomerRepo.EXPECT().UpdateStatus(customer.Id, "1", clock.StaticClock{}.NowAsString()).Return(nil)
+	mockCustomerRepo.EXPECT().FindById(customer.Id).Return(&customer, nil)
+
+	//Act
+	response, err := cusSvc.SetCustomerStatus(dto.CustomerStatusRequest{CustomerId: customer.Id, Status: dto.CustomerStatusActive})
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if response.Status != dto.CustomerStatusActive {
+		t.Errorf("Expected status %s but got %s", dto.CustomerStatusActive, response.Status)
+	}
+}
+
+func TestDefaultCustomerService_SetCustomerStatus_returns_error_when_repo_fails(t *testing.T) {
+	//Arrange
+	teardown := setupCustomerServiceTest(t)
+	defer teardown()
+
+	dummyAppErr := errs.NewNotFoundError("Customer not found")
+	mockCustomerRepo.EXPECT().UpdateStatus("1", "0", gomock.Any()).Return(dummyAppErr)
+
+	//Act
+	_, err := cusSvc.SetCustomerStatus(dto.CustomerStatusRequest{CustomerId: "1", Status: dto.CustomerStatusInactive})
+
+	//Assert
+	if err != dummyAppErr {
+		t.Errorf("Expected repo error but got %v", err)
+	}
+}
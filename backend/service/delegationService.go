@@ -0,0 +1,160 @@
+package service
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"net/http"
+)
+
+//go:generate mockgen -destination=../mocks/service/mock_delegationService.go -package=service github.com/aliciatay-zls/banking/backend/service DelegationService
+type DelegationService interface { //service (primary port)
+	GetDelegations(customerId string, accountId string) ([]dto.DelegationResponse, *errs.AppError)
+	GetReceivedDelegations(customerId string) ([]dto.DelegationResponse, *errs.AppError)
+	GrantDelegation(dto.DelegationRequest) (*dto.DelegationResponse, *errs.AppError)
+	RevokeDelegation(customerId string, accountId string, delegationId string) (*dto.DelegationResponse, *errs.AppError)
+}
+
+type DefaultDelegationService struct { //business/domain object
+	repo         domain.DelegationRepository
+	accountRepo  domain.AccountRepository
+	customerRepo domain.CustomerRepository
+	clk          clock.Clock
+}
+
+func NewDelegationService(repo domain.DelegationRepository, accountRepo domain.AccountRepository,
+	customerRepo domain.CustomerRepository, clk clock.Clock) DefaultDelegationService {
+	return DefaultDelegationService{repo, accountRepo, customerRepo, clk}
+}
+
+// GetDelegations returns all delegations granted on the given account, including revoked and ended ones, as long as
+// the given customer holds the account.
+func (s DefaultDelegationService) GetDelegations(customerId string, accountId string) ([]dto.DelegationResponse, *errs.AppError) {
+	if _, err := s.accountRepo.FindHolder(accountId, customerId); err != nil {
+		return nil, err
+	}
+
+	delegations, err := s.repo.FindAll(accountId)
+	if err != nil {
+		return nil, err
+	}
+	return s.toDTOs(delegations), nil
+}
+
+// GetReceivedDelegations returns all delegations granted to the given customer, so that they know which accounts
+// they may operate.
+func (s DefaultDelegationService) GetReceivedDelegations(customerId string) ([]dto.DelegationResponse, *errs.AppError) {
+	delegations, err := s.repo.FindReceived(customerId)
+	if err != nil {
+		return nil, err
+	}
+	return s.toDTOs(delegations), nil
+}
+
+// GrantDelegation grants the delegation in the given request, as long as the request is made by the account's primary
+// holder, the delegate exists and does not hold the account, and the delegate has no other delegation for the account
+// that has yet to end.
+func (s DefaultDelegationService) GrantDelegation(request dto.DelegationRequest) (*dto.DelegationResponse, *errs.AppError) {
+	if err := s.checkPrimaryHolder(request.AccountId, request.CustomerId); err != nil {
+		return nil, err
+	}
+	if _, err := s.customerRepo.FindById(request.DelegateCustomerId); err != nil {
+		return nil, err
+	}
+	if _, err := s.accountRepo.FindHolder(request.AccountId, request.DelegateCustomerId); err == nil {
+		return nil, errs.NewValidationError("Customer already holds this account.")
+	} else if err.Code != http.StatusNotFound {
+		return nil, err
+	}
+
+	existing, err := s.repo.FindAll(request.AccountId)
+	if err != nil {
+		return nil, err
+	}
+	now := s.clk.NowAsString()
+	for _, d := range existing {
+		if d.DelegateCustomerId == request.DelegateCustomerId && !d.IsOver(now) {
+			return nil, errs.NewConflictError("Customer already has a delegation for this account. Please revoke it first")
+		}
+	}
+
+	delegation, err := domain.NewDelegation(request, s.clk)
+	if err != nil {
+		return nil, err
+	}
+	if delegation, err = s.repo.Save(*delegation); err != nil {
+		return nil, err
+	}
+	response := delegation.ToDTO(now)
+	return &response, nil
+}
+
+// RevokeDelegation revokes the given delegation of the given account straight away, as long as the request is made by
+// the account's primary holder.
+func (s DefaultDelegationService) RevokeDelegation(customerId string, accountId string,
+	delegationId string) (*dto.DelegationResponse, *errs.AppError) {
+	if err := s.checkPrimaryHolder(accountId, customerId); err != nil {
+		return nil, err
+	}
+	delegation, err := s.repo.FindById(delegationId)
+	if err != nil {
+		return nil, err
+	}
+	if delegation.AccountId != accountId {
+		logger.Error("Delegation " + delegationId + " is not of account " + accountId)
+		return nil, errs.NewNotFoundError("Delegation not found")
+	}
+
+	now := s.clk.NowAsString()
+	if err = s.repo.Revoke(delegationId, now); err != nil {
+		return nil, err
+	}
+	delegation.RevocationDate.String, delegation.RevocationDate.Valid = now, true
+	response := delegation.ToDTO(now)
+	return &response, nil
+}
+
+// checkPrimaryHolder checks that the customer with the given customer id is the primary holder of the account with
+// the given account id, who alone may grant and revoke delegations of it.
+func (s DefaultDelegationService) checkPrimaryHolder(accountId string, customerId string) *errs.AppError {
+	holder, err := s.accountRepo.FindHolder(accountId, customerId)
+	if err != nil {
+		return err
+	}
+	if !holder.IsPrimary() {
+		logger.Error("Customer " + customerId + " is not the primary holder of account " + accountId)
+		return errs.NewAuthorizationError("Only the primary holder of the account can manage its delegations")
+	}
+	return nil
+}
+
+// checkHolderOrDelegate checks that the customer with the given customer id holds the account with the given account
+// id, whatever their role, or, where delegations are available, was delegated it at the given time, whatever the
+// scope. Services check this themselves on the routes that only look at an account, since DelegationAuthRepository
+// does not ask the auth server about the account for delegates.
+func checkHolderOrDelegate(accountRepo domain.AccountRepository, delegations domain.DelegationRepository,
+	accountId string, customerId string, now string) *errs.AppError {
+	err := checkHolder(accountRepo, accountId, customerId, false)
+	if err == nil || err.Code != http.StatusNotFound || delegations == nil {
+		return err
+	}
+	if _, delegationErr := delegations.FindActive(accountId, customerId, now); delegationErr != nil {
+		if delegationErr.Code != http.StatusNotFound {
+			return delegationErr
+		}
+		logger.Error("Customer " + customerId + " neither holds nor was delegated account " + accountId)
+		return err
+	}
+	return nil
+}
+
+func (s DefaultDelegationService) toDTOs(delegations []domain.Delegation) []dto.DelegationResponse {
+	now := s.clk.NowAsString()
+	response := make([]dto.DelegationResponse, 0)
+	for _, d := range delegations {
+		response = append(response, d.ToDTO(now))
+	}
+	return response
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	mocksDomain "github.com/aliciatay-zls/banking/backend/mocks/domain"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"testing"
+)
+
+// Test common variables and inputs
+var mockDelegationRepo *mocksDomain.MockDelegationRepository
+var delegationSvc DefaultDelegationService
+
+func setupDelegationServiceTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	mockDelegationRepo = mocksDomain.NewMockDelegationRepository(ctrl)
+	mockAccountRepo = mocksDomain.NewMockAccountRepository(ctrl)
+	mockCustomerRepo = mocksDomain.NewMockCustomerRepository(ctrl)
+	delegationSvc = NewDelegationService(mockDelegationRepo, mockAccountRepo, mockCustomerRepo, clock.StaticClock{})
+
+	return func() {
+		mockDelegationRepo = nil
+		mockAccountRepo = nil
+		mockCustomerRepo = nil
+		defer ctrl.Finish()
+	}
+}
+
+func getDummyDelegationRequest() dto.DelegationRequest {
+	return dto.DelegationRequest{AccountId: dummyAccountId, CustomerId: dummyCustomerId, DelegateCustomerId: "3",
+		Scope: dto.DelegationScopeWithdraw, DailyLimit: 500, EndDate: "2006-01-31"}
+}
+
+func TestDefaultDelegationService_GrantDelegation_returns_error_when_requester_notPrimaryHolder(t *testing.T) {
+	//Arrange
+	teardown := setupDelegationServiceTest(t)
+	defer teardown()
+
+	expectHolder(dummyCustomerId, dto.AccountHolderRoleJoint)
+	mockDelegationRepo.EXPECT().Save(gomock.Any()).Times(0)
+
+	//Act
+	_, err := delegationSvc.GrantDelegation(getDummyDelegationRequest())
+
+	//Assert
+	if err == nil || err.Code != http.StatusForbidden {
+		t.Errorf("Expected authorization error but got %v", err)
+	}
+}
+
+func TestDefaultDelegationService_GrantDelegation_returns_error_when_delegate_holdsAccount(t *testing.T) {
+	//Arrange
+	teardown := setupDelegationServiceTest(t)
+	defer teardown()
+
+	expectHolder(dummyCustomerId, dto.AccountHolderRolePrimary)
+	mockCustomerRepo.EXPECT().FindById("3").Return(&domain.Customer{Id: "3"}, nil)
+	expectHolder("3", dto.AccountHolderRoleViewOnly)
+	mockDelegationRepo.EXPECT().Save(gomock.Any()).Times(0)
+
+	//Act
+	_, err := delegationSvc.GrantDelegation(getDummyDelegationRequest())
+
+	//Assert
+	if err == nil || err.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected validation error but got %v", err)
+	}
+}
+
+func TestDefaultDelegationService_GrantDelegation_returns_error_when_delegate_hasOngoingDelegation(t *testing.T) {
+	//Arrange
+	teardown := setupDelegationServiceTest(t)
+	defer teardown()
+
+	expectHolder(dummyCustomerId, dto.AccountHolderRolePrimary)
+	mockCustomerRepo.EXPECT().FindById("3").Return(&domain.Customer{Id: "3"}, nil)
+	mockAccountRepo.EXPECT().FindHolder(dummyAccountId, "3").Return(nil, errs.NewNotFoundError("Account holder not found"))
+	existing := []domain.Delegation{{DelegationId: "4", DelegateCustomerId: "3", EndDate: "2006-01-10 23:59:59"}}
+	mockDelegationRepo.EXPECT().FindAll(dummyAccountId).Return(existing, nil)
+	mockDelegationRepo.EXPECT().Save(gomock.Any()).Times(0)
+
+	//Act
+	_, err := delegationSvc.GrantDelegation(getDummyDelegationRequest())
+
+	//Assert
+	if err == nil || err.Code != http.StatusConflict {
+		t.Errorf("Expected conflict error but got %v", err)
+	}
+}
+
+func TestDefaultDelegationService_GrantDelegation_saves_delegation_when_requester_primaryHolder(t *testing.T) {
+	//Arrange
+	teardown := setupDelegationServiceTest(t)
+	defer teardown()
+
+	request := getDummyDelegationRequest()
+	expectedDelegation, _ := domain.NewDelegation(request, clock.StaticClock{})
+	savedDelegation := *expectedDelegation
+	savedDelegation.DelegationId = "5"
+	ended := domain.Delegation{DelegationId: "4", DelegateCustomerId: "3", EndDate: "2006-01-01 23:59:59"}
+
+	expectHolder(dummyCustomerId, dto.AccountHolderRolePrimary)
+	mockCustomerRepo.EXPECT().FindById("3").Return(&domain.Customer{Id: "3"}, nil)
+	mockAccountRepo.EXPECT().FindHolder(dummyAccountId, "3").Return(nil, errs.NewNotFoundError("Account holder not found"))
+	mockDelegationRepo.EXPECT().FindAll(dummyAccountId).Return([]domain.Delegation{ended}, nil)
+	mockDelegationRepo.EXPECT().Save(*expectedDelegation).Return(&savedDelegation, nil)
+
+	//Act
+	response, err := delegationSvc.GrantDelegation(request)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if *response != savedDelegation.ToDTO("2006-01-02 15:04:05") || !response.Active {
+		t.Errorf("Expected active delegation %+v but got %+v", savedDelegation, *response)
+	}
+}
+
+func TestDefaultDelegationService_RevokeDelegation_returns_error_when_delegation_ofOtherAccount(t *testing.T) {
+	//Arrange
+	teardown := setupDelegationServiceTest(t)
+	defer teardown()
+
+	expectHolder(dummyCustomerId, dto.AccountHolderRolePrimary)
+	mockDelegationRepo.EXPECT().FindById("4").Return(&domain.Delegation{DelegationId: "4", AccountId: "1980"}, nil)
+	mockDelegationRepo.EXPECT().Revoke(gomock.Any(), gomock.Any()).Times(0)
+
+	//Act
+	_, err := delegationSvc.RevokeDelegation(dummyCustomerId, dummyAccountId, "4")
+
+	//Assert
+	if err == nil || err.Code != http.StatusNotFound {
+		t.Errorf("Expected not found error but got %v", err)
+	}
+}
+
+func TestDefaultDelegationService_RevokeDelegation_revokes_delegation_now(t *testing.T) {
+	//Arrange
+	teardown := setupDelegationServiceTest(t)
+	defer teardown()
+
+	delegation := domain.Delegation{DelegationId: "4", AccountId: dummyAccountId, DelegateCustomerId: "3",
+		StartDate: "2006-01-01 00:00:00", EndDate: "2006-01-31 23:59:59"}
+	expectHolder(dummyCustomerId, dto.AccountHolderRolePrimary)
+	mockDelegationRepo.EXPECT().FindById("4").Return(&delegation, nil)
+	mockDelegationRepo.EXPECT().Revoke("4", "2006-01-02 15:04:05").Return(nil)
+
+	//Act
+	response, err := delegationSvc.RevokeDelegation(dummyCustomerId, dummyAccountId, "4")
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if response.RevocationDate != "2006-01-02 15:04:05" || response.Active {
+		t.Errorf("Expected delegation revoked at 2006-01-02 15:04:05 but got %+v", *response)
+	}
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/domain"
+)
+
+// eventBatchSize is the maximum number of events that the outbox relay publishes each time it runs.
+const eventBatchSize = 100
+
+//go:generate mockgen -destination=../mocks/service/mock_eventRelayService.go -package=service github.com/aliciatay-zls/banking/backend/service EventRelayService
+type EventRelayService interface { //service (primary port)
+	PublishPending() *errs.AppError
+}
+
+type DefaultEventRelayService struct { //business/domain object
+	repo      domain.OutboxRepository
+	publisher domain.EventPublisher
+	clk       clock.Clock
+}
+
+func NewEventRelayService(repo domain.OutboxRepository, publisher domain.EventPublisher, clk clock.Clock) DefaultEventRelayService {
+	return DefaultEventRelayService{repo, publisher, clk}
+}
+
+// PublishPending publishes the events in the outbox that have not been published yet, oldest first, and marks each
+// of them as published. Events are delivered at least once: an event that was published but could not be marked is
+// published again the next time. Events of the same account or customer are published in the order they occurred, so
+// once one of them fails, the later ones are left for the next time as well. The last error is returned after trying
+// every event.
+func (s DefaultEventRelayService) PublishPending() *errs.AppError {
+	events, err := s.repo.FindUnpublished(eventBatchSize)
+	if err != nil {
+		return err
+	}
+
+	var lastErr *errs.AppError
+	blocked := make(map[string]bool) //ordering keys of events that could not be published
+	for _, e := range events {
+		if blocked[e.OrderingKey()] {
+			continue
+		}
+
+		if err = s.publisher.Publish(e); err != nil {
+			blocked[e.OrderingKey()] = true
+			lastErr = err
+			if err = s.repo.RecordFailure(e.EventId, err.Message); err != nil {
+				lastErr = err
+			}
+			continue
+		}
+
+		if err = s.repo.MarkPublished(e.EventId, s.clk.NowAsString()); err != nil {
+			blocked[e.OrderingKey()] = true
+			lastErr = err
+		}
+	}
+	return lastErr
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	mocksDomain "github.com/aliciatay-zls/banking/backend/mocks/domain"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+// Test common variables and inputs
+var mockOutboxRepo *mocksDomain.MockOutboxRepository
+var mockEventPublisher *mocksDomain.MockEventPublisher
+var relaySvc DefaultEventRelayService
+
+const dummyNow = "2006-01-02 15:04:05"
+
+func setupEventRelayServiceTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	mockOutboxRepo = mocksDomain.NewMockOutboxRepository(ctrl)
+	mockEventPublisher = mocksDomain.NewMockEventPublisher(ctrl)
+	relaySvc = NewEventRelayService(mockOutboxRepo, mockEventPublisher, clock.StaticClock{})
+
+	return func() {
+		mockOutboxRepo = nil
+		mockEventPublisher = nil
+		defer ctrl.Finish()
+	}
+}
+
+func getDummyEvent(eventId string, accountId string) domain.Event {
+	event := domain.NewTransactionPostedEvent(domain.Transaction{TransactionId: eventId, AccountId: accountId})
+	event.EventId = eventId
+	return event
+}
+
+func TestDefaultEventRelayService_PublishPending_publishes_and_marks_events_inOrder(t *testing.T) {
+	//Arrange
+	teardown := setupEventRelayServiceTest(t)
+	defer teardown()
+
+	first, second := getDummyEvent("1", "1977"), getDummyEvent("2", "1977")
+	mockOutboxRepo.EXPECT().FindUnpublished(eventBatchSize).Return([]domain.Event{first, second}, nil)
+	gomock.InOrder(
+		mockEventPublisher.EXPECT().Publish(first).Return(nil),
+		mockOutboxRepo.EXPECT().MarkPublished("1", dummyNow).Return(nil),
+		mockEventPublisher.EXPECT().Publish(second).Return(nil),
+		mockOutboxRepo.EXPECT().MarkPublished("2", dummyNow).Return(nil),
+	)
+
+	//Act
+	err := relaySvc.PublishPending()
+
+	//Assert
+	if err != nil {
+		t.Error("Expected no error but got error: " + err.Message)
+	}
+}
+
+func TestDefaultEventRelayService_PublishPending_holds_back_laterEvents_of_sameAccount_when_publish_fails(t *testing.T) {
+	//Arrange
+	teardown := setupEventRelayServiceTest(t)
+	defer teardown()
+
+	failed, later, other := getDummyEvent("1", "1977"), getDummyEvent("2", "1977"), getDummyEvent("3", "1980")
+	dummyAppErr := errs.NewUnexpectedError("Unexpected server error")
+	mockOutboxRepo.EXPECT().FindUnpublished(eventBatchSize).Return([]domain.Event{failed, later, other}, nil)
+	mockEventPublisher.EXPECT().Publish(failed).Return(dummyAppErr)
+	mockOutboxRepo.EXPECT().RecordFailure("1", dummyAppErr.Message).Return(nil)
+	mockEventPublisher.EXPECT().Publish(other).Return(nil)
+	mockOutboxRepo.EXPECT().MarkPublished("3", dummyNow).Return(nil)
+
+	//Act
+	err := relaySvc.PublishPending()
+
+	//Assert
+	if err != dummyAppErr {
+		t.Errorf("Expected publishing error but got %v", err)
+	}
+}
+
+func TestDefaultEventRelayService_PublishPending_holds_back_laterEvents_of_sameAccount_when_marking_fails(t *testing.T) {
+	//Arrange
+	teardown := setupEventRelayServiceTest(t)
+	defer teardown()
+
+	first, later := getDummyEvent("1", "1977"), getDummyEvent("2", "1977")
+	dummyAppErr := errs.NewUnexpectedError("Unexpected database error")
+	mockOutboxRepo.EXPECT().FindUnpublished(eventBatchSize).Return([]domain.Event{first, later}, nil)
+	mockEventPublisher.EXPECT().Publish(first).Return(nil)
+	mockOutboxRepo.EXPECT().MarkPublished("1", dummyNow).Return(dummyAppErr)
+	logger.MuteLogger()
+
+	//Act
+	err := relaySvc.PublishPending()
+
+	//Assert
+	if err != dummyAppErr {
+		t.Errorf("Expected marking error but got %v", err)
+	}
+}
+
+func TestDefaultEventRelayService_PublishPending_redelivers_event_when_stubPublisher_recovers(t *testing.T) {
+	//Arrange
+	teardown := setupEventRelayServiceTest(t)
+	defer teardown()
+
+	down := true
+	publisher := domain.NewEventPublisherStub(func(domain.Event) bool { return down })
+	relaySvc = NewEventRelayService(mockOutboxRepo, publisher, clock.StaticClock{})
+	event := getDummyEvent("1", "1977")
+	mockOutboxRepo.EXPECT().FindUnpublished(eventBatchSize).Return([]domain.Event{event}, nil).Times(2)
+	mockOutboxRepo.EXPECT().RecordFailure("1", gomock.Any()).Return(nil)
+	mockOutboxRepo.EXPECT().MarkPublished("1", dummyNow).Return(nil)
+	logger.MuteLogger()
+
+	//Act
+	firstErr := relaySvc.PublishPending()
+	down = false
+	secondErr := relaySvc.PublishPending()
+
+	//Assert
+	if firstErr == nil || secondErr != nil {
+		t.Errorf("Expected only the first run to fail but got %v and %v", firstErr, secondErr)
+	}
+	if messages := publisher.Messages(); len(messages) != 1 || messages[0].EventId != "1" {
+		t.Errorf("Expected event 1 to be published once but got %+v", messages)
+	}
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+)
+
+//go:generate mockgen -destination=../mocks/service/mock_fraudCaseService.go -package=service github.com/aliciatay-zls/banking/backend/service FraudCaseService
+type FraudCaseService interface { //service (primary port)
+	GetFraudCases(dto.FraudCasesRequest) (*dto.FraudCasesResponse, *errs.AppError)
+	GetFraudCase(string) (*dto.FraudCaseResponse, *errs.AppError)
+	ResolveFraudCase(dto.ResolveFraudCaseRequest) (*dto.FraudCaseResponse, *errs.AppError)
+}
+
+type DefaultFraudCaseService struct { //business/domain object
+	repo domain.ScreeningRepository
+	clk  clock.Clock
+}
+
+func NewFraudCaseService(repo domain.ScreeningRepository, clk clock.Clock) DefaultFraudCaseService {
+	return DefaultFraudCaseService{repo, clk}
+}
+
+// GetFraudCases returns a page of the fraud cases matching the given request, oldest first, so that the case queue
+// is worked through in the order the cases were opened. If the page is full, the response says which after_id to
+// request the next page with.
+func (s DefaultFraudCaseService) GetFraudCases(request dto.FraudCasesRequest) (*dto.FraudCasesResponse, *errs.AppError) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	filter := domain.FraudCaseFilter{Status: request.Status, AfterId: request.AfterId, Limit: request.LimitOrDefault()}
+	cases, err := s.repo.FindCases(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	response := dto.FraudCasesResponse{Cases: make([]dto.FraudCaseResponse, 0)}
+	for _, c := range cases {
+		response.Cases = append(response.Cases, c.ToDTO())
+	}
+	if len(cases) == filter.Limit {
+		response.NextAfterId = cases[len(cases)-1].CaseId
+	}
+	return &response, nil
+}
+
+// GetFraudCase returns the fraud case with the given ID.
+func (s DefaultFraudCaseService) GetFraudCase(caseId string) (*dto.FraudCaseResponse, *errs.AppError) {
+	fraudCase, err := s.repo.FindCaseById(caseId)
+	if err != nil {
+		return nil, err
+	}
+
+	response := fraudCase.ToDTO()
+	return &response, nil
+}
+
+// ResolveFraudCase closes the open fraud case with the given ID with the resolution in the given request. Resolving a
+// case does not undo or make its transaction: a fraudulent transaction that was made can be reversed, while a
+// legitimate transaction that was blocked can be made again by an admin.
+func (s DefaultFraudCaseService) ResolveFraudCase(request dto.ResolveFraudCaseRequest) (*dto.FraudCaseResponse, *errs.AppError) {
+	fraudCase, err := s.repo.FindCaseById(request.CaseId)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved, err := fraudCase.Resolve(request, s.clk)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.repo.ResolveCase(resolved); err != nil {
+		return nil, err
+	}
+
+	response := resolved.ToDTO()
+	return &response, nil
+}
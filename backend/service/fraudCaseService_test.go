@@ -0,0 +1,103 @@
+package service
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	mocksDomain "github.com/aliciatay-zls/banking/backend/mocks/domain"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"testing"
+)
+
+// Test common variables and inputs
+var mockScreeningRepo *mocksDomain.MockScreeningRepository
+var fraudCaseSvc DefaultFraudCaseService
+
+const dummyCaseId = "5"
+
+func setupFraudCaseServiceTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	mockScreeningRepo = mocksDomain.NewMockScreeningRepository(ctrl)
+	fraudCaseSvc = NewFraudCaseService(mockScreeningRepo, clock.StaticClock{})
+
+	return func() {
+		mockScreeningRepo = nil
+		defer ctrl.Finish()
+	}
+}
+
+func getDummyOpenFraudCase() domain.FraudCase {
+	return domain.FraudCase{CaseId: dummyCaseId, CustomerId: dummyCustomerId, AccountId: dummyAccountId,
+		TransactionType: dto.TransactionTypeWithdrawal, Amount: 5000, Decision: domain.ScreeningDecisionBlock,
+		Rules: "velocity", Results: `[{"rule":"velocity","decision":"block","reason":"6 withdrawals"}]`,
+		Status: dto.FraudCaseStatusOpen, CreationDate: "2006-01-02 15:04:05"}
+}
+
+func TestDefaultFraudCaseService_GetFraudCases_returns_nextAfterId_when_page_full(t *testing.T) {
+	//Arrange
+	teardown := setupFraudCaseServiceTest(t)
+	defer teardown()
+
+	filter := domain.FraudCaseFilter{Status: dto.FraudCaseStatusOpen, AfterId: "4", Limit: 1}
+	mockScreeningRepo.EXPECT().FindCases(filter).Return([]domain.FraudCase{getDummyOpenFraudCase()}, nil)
+
+	request := dto.FraudCasesRequest{Status: dto.FraudCaseStatusOpen, AfterId: "4", Limit: "1"}
+
+	//Act
+	response, err := fraudCaseSvc.GetFraudCases(request)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if len(response.Cases) != 1 || response.NextAfterId != dummyCaseId || len(response.Cases[0].Results) != 1 {
+		t.Errorf("Expected 1 case with next after id %s but got %+v", dummyCaseId, *response)
+	}
+}
+
+func TestDefaultFraudCaseService_ResolveFraudCase_returns_error_when_case_closed(t *testing.T) {
+	//Arrange
+	teardown := setupFraudCaseServiceTest(t)
+	defer teardown()
+
+	fraudCase := getDummyOpenFraudCase()
+	fraudCase.Status = dto.FraudCaseStatusClosed
+	mockScreeningRepo.EXPECT().FindCaseById(dummyCaseId).Return(&fraudCase, nil)
+	mockScreeningRepo.EXPECT().ResolveCase(gomock.Any()).Times(0)
+
+	request := dto.ResolveFraudCaseRequest{CaseId: dummyCaseId, Resolution: dto.FraudCaseResolutionFraud}
+
+	//Act
+	_, err := fraudCaseSvc.ResolveFraudCase(request)
+
+	//Assert
+	if err == nil || err.Code != http.StatusConflict {
+		t.Errorf("Expected conflict error but got %v", err)
+	}
+}
+
+func TestDefaultFraudCaseService_ResolveFraudCase_closesCase(t *testing.T) {
+	//Arrange
+	teardown := setupFraudCaseServiceTest(t)
+	defer teardown()
+
+	fraudCase := getDummyOpenFraudCase()
+	mockScreeningRepo.EXPECT().FindCaseById(dummyCaseId).Return(&fraudCase, nil)
+	request := dto.ResolveFraudCaseRequest{CaseId: dummyCaseId, Resolution: dto.FraudCaseResolutionLegitimate,
+		Note: "Customer confirmed by phone"}
+	resolved, _ := fraudCase.Resolve(request, clock.StaticClock{})
+	mockScreeningRepo.EXPECT().ResolveCase(resolved).Return(nil)
+
+	//Act
+	response, err := fraudCaseSvc.ResolveFraudCase(request)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if response.Status != dto.FraudCaseStatusClosed || response.Resolution != dto.FraudCaseResolutionLegitimate ||
+		response.ResolutionDate != "2006-01-02 15:04:05" {
+		t.Errorf("Expected case resolved as legitimate but got %+v", *response)
+	}
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"net/http"
+)
+
+//go:generate mockgen -destination=../mocks/service/mock_holdService.go -package=service github.com/aliciatay-zls/banking/backend/service HoldService
+type HoldService interface { //service (primary port)
+	PlaceHold(dto.NewHoldRequest) (*dto.HoldResponse, *errs.AppError)
+	GetHolds(string, string) ([]dto.HoldResponse, *errs.AppError)
+	CaptureHold(dto.CaptureHoldRequest) (*dto.TransactionResponse, *errs.AppError)
+	ReleaseHold(string, string, string) (*dto.HoldResponse, *errs.AppError)
+	ExpireHolds() *errs.AppError
+}
+
+type DefaultHoldService struct { //business/domain object
+	repo        domain.HoldRepository
+	accountRepo domain.AccountRepository
+	delegations domain.DelegationRepository //nil where delegations are not available
+	uow         domain.UnitOfWork
+	clk         clock.Clock
+}
+
+func NewHoldService(repo domain.HoldRepository, accountRepo domain.AccountRepository,
+	delegations domain.DelegationRepository, uow domain.UnitOfWork, clk clock.Clock) DefaultHoldService {
+	return DefaultHoldService{repo, accountRepo, delegations, uow, clk}
+}
+
+// PlaceHold checks whether the given customer holds the given account and whether its available balance allows for
+// the given amount to be reserved. If so, it places a hold for the amount, which lowers the available balance but not the ledger
+// balance until the hold is captured. The repository checks the available balance again when reserving the amount,
+// in case it was lowered in the meantime. The check and the hold are made in one unit of work.
+func (s DefaultHoldService) PlaceHold(request dto.NewHoldRequest) (*dto.HoldResponse, *errs.AppError) {
+	if err := checkHolder(s.accountRepo, request.AccountId, request.CustomerId, true); err != nil {
+		return nil, err
+	}
+
+	var hold *domain.Hold
+	err := s.uow.Do(func(repos domain.Repositories) *errs.AppError {
+		account, err := repos.Accounts.FindById(request.AccountId)
+		if err != nil {
+			return err
+		}
+		if !account.CanWithdraw(request.Amount) {
+			logger.Error("Amount to hold exceeds available balance")
+			return errs.NewValidationError("Available balance insufficient to hold given amount")
+		}
+
+		hold, err = repos.Holds.Save(domain.NewHold(request, s.clk))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return hold.ToDTO(), nil
+}
+
+// GetHolds returns the holds placed on the given account, as long as the given customer holds the account or was
+// delegated it.
+func (s DefaultHoldService) GetHolds(customerId string, accountId string) ([]dto.HoldResponse, *errs.AppError) {
+	err := checkHolderOrDelegate(s.accountRepo, s.delegations, accountId, customerId, s.clk.NowAsString())
+	if err != nil {
+		return nil, err
+	}
+
+	holds, err := s.repo.FindAll(accountId)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.HoldResponse, 0)
+	for _, h := range holds {
+		response = append(response, *h.ToDTO())
+	}
+	return response, nil
+}
+
+// CaptureHold takes the given amount, or the full amount held if none is given, out of the account as a transaction
+// and frees the rest of the hold. A hold can only be captured once. Where sanctions screening is available, holds on
+// the accounts of customers on hold cannot be captured, like withdrawals.
+func (s DefaultHoldService) CaptureHold(request dto.CaptureHoldRequest) (*dto.TransactionResponse, *errs.AppError) {
+	if err := checkHolder(s.accountRepo, request.AccountId, request.CustomerId, true); err != nil {
+		return nil, err
+	}
+
+	var completedTransaction *domain.Transaction
+	err := s.withActiveHold(request.AccountId, request.HoldId, func(repos domain.Repositories,
+		hold domain.Hold) *errs.AppError {
+		amount, err := hold.CaptureAmount(request.Amount)
+		if err != nil {
+			return err
+		}
+		if repos.Sanctions != nil {
+			account, err := repos.Accounts.FindById(hold.AccountId)
+			if err != nil {
+				return err
+			}
+			if err = checkNotOnHold(repos.Sanctions, account.CustomerId); err != nil {
+				return err
+			}
+		}
+
+		transaction := domain.NewTransaction(hold.AccountId, amount, dto.TransactionTypeHoldCapture, s.clk)
+		transaction.ActedBy(request.CustomerId)
+		completedTransaction, err = repos.Holds.Capture(hold, transaction)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return completedTransaction.ToTransactionResponseDTO(), nil
+}
+
+// ReleaseHold frees the full amount of the given hold without taking anything out of the account, as long as the given
+// customer holds the account.
+func (s DefaultHoldService) ReleaseHold(customerId string, accountId string, holdId string) (*dto.HoldResponse, *errs.AppError) {
+	if err := checkHolder(s.accountRepo, accountId, customerId, true); err != nil {
+		return nil, err
+	}
+
+	var released domain.Hold
+	err := s.withActiveHold(accountId, holdId, func(repos domain.Repositories, hold domain.Hold) *errs.AppError {
+		released = hold
+		released.Status = domain.HoldStatusReleased
+		return repos.Holds.Release(hold, domain.HoldStatusReleased)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return released.ToDTO(), nil
+}
+
+// withActiveHold retrieves the hold with the given id and runs the given function with it in one unit of work. The
+// hold is treated as not found if it was placed on another account, and a conflict error is returned if it is no
+// longer active. A hold past its expiry date is expired on the spot instead, so that it cannot be captured or
+// released while waiting for ExpireHolds to run.
+func (s DefaultHoldService) withActiveHold(accountId string, holdId string,
+	fn func(domain.Repositories, domain.Hold) *errs.AppError) *errs.AppError {
+	var expired bool
+	err := s.uow.Do(func(repos domain.Repositories) *errs.AppError {
+		expired = false
+		hold, err := repos.Holds.FindById(holdId)
+		if err != nil {
+			return err
+		}
+		if hold.AccountId != accountId {
+			logger.Error("Hold " + holdId + " was not placed on account " + accountId)
+			return errs.NewNotFoundError("Hold not found")
+		}
+		if !hold.IsActive() {
+			return errs.NewConflictError("Hold is already " + hold.Status)
+		}
+		if hold.IsExpired(s.clk.NowAsString()) {
+			logger.Error("Hold " + holdId + " expired on " + hold.ExpiryDate)
+			expired = true
+			return expireHold(repos.Holds, *hold) //the hold is kept expired although the function is not run
+		}
+		return fn(repos, *hold)
+	})
+	if err != nil {
+		return err
+	}
+	if expired {
+		return errs.NewConflictError("Hold is already " + domain.HoldStatusExpired)
+	}
+	return nil
+}
+
+// ExpireHolds frees every active hold whose expiry date has passed according to the clock. Holds that were captured
+// or released in the meantime are skipped.
+func (s DefaultHoldService) ExpireHolds() *errs.AppError {
+	holds, err := s.repo.FindExpired(s.clk.NowAsString())
+	if err != nil {
+		return err
+	}
+
+	for _, h := range holds {
+		if err = expireHold(s.repo, h); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// expireHold marks the given hold as expired and frees the amount it reserved, unless it is no longer active.
+func expireHold(repo domain.HoldRepository, hold domain.Hold) *errs.AppError {
+	if err := repo.Release(hold, domain.HoldStatusExpired); err != nil && err.Code != http.StatusConflict {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,230 @@
+package service
+
+import (
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	mocksDomain "github.com/aliciatay-zls/banking/backend/mocks/domain"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// Test common variables and inputs
+var mockHoldRepo *mocksDomain.MockHoldRepository
+var holdClock *dummyClock
+var holdSvc DefaultHoldService
+
+const dummyHoldId = "3"
+
+func setupHoldServiceTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	mockHoldRepo = mocksDomain.NewMockHoldRepository(ctrl)
+	mockAccountRepo = mocksDomain.NewMockAccountRepository(ctrl)
+	holdClock = &dummyClock{time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC)}
+	uow := domain.NewUnitOfWorkStub(domain.Repositories{Accounts: mockAccountRepo, Holds: mockHoldRepo})
+	holdSvc = NewHoldService(mockHoldRepo, mockAccountRepo, nil, uow, holdClock)
+
+	return func() {
+		mockHoldRepo = nil
+		mockAccountRepo = nil
+		defer ctrl.Finish()
+	}
+}
+
+func getDummyActiveHold() domain.Hold {
+	return domain.Hold{HoldId: dummyHoldId, AccountId: dummyAccountId, Amount: 100, Status: domain.HoldStatusActive,
+		ExpiryDate: "2023-01-09 12:00:00"}
+}
+
+func TestDefaultHoldService_PlaceHold_returns_error_when_availableBalance_insufficient(t *testing.T) {
+	//Arrange
+	teardown := setupHoldServiceTest(t)
+	defer teardown()
+	expectHolder(dummyCustomerId, dto.AccountHolderRolePrimary)
+
+	dummyAccount := domain.Account{AccountId: dummyAccountId, AccountType: dto.AccountTypeSaving, Amount: 1000, HeldAmount: 950}
+	mockAccountRepo.EXPECT().FindById(dummyAccountId).Return(&dummyAccount, nil)
+	mockHoldRepo.EXPECT().Save(gomock.Any()).Times(0)
+
+	request := dto.NewHoldRequest{AccountId: dummyAccountId, CustomerId: dummyCustomerId, Amount: 100}
+
+	//Act
+	_, err := holdSvc.PlaceHold(request)
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing hold beyond available balance")
+	}
+	if err.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status code %d but got %d", http.StatusUnprocessableEntity, err.Code)
+	}
+}
+
+func TestDefaultHoldService_CaptureHold_capturesPartialAmount(t *testing.T) {
+	//Arrange
+	teardown := setupHoldServiceTest(t)
+	defer teardown()
+	expectHolder(dummyCustomerId, dto.AccountHolderRolePrimary)
+
+	hold := getDummyActiveHold()
+	mockHoldRepo.EXPECT().FindById(dummyHoldId).Return(&hold, nil)
+
+	expectedTransaction := domain.NewTransaction(dummyAccountId, 40, dto.TransactionTypeHoldCapture, holdClock)
+	expectedTransaction.ActedBy(dummyCustomerId)
+	completedTransaction := expectedTransaction
+	completedTransaction.TransactionId = dummyTransactionId
+	mockHoldRepo.EXPECT().Capture(hold, expectedTransaction).Return(&completedTransaction, nil)
+
+	request := dto.CaptureHoldRequest{AccountId: dummyAccountId, CustomerId: dummyCustomerId, HoldId: dummyHoldId, Amount: 40}
+
+	//Act
+	response, err := holdSvc.CaptureHold(request)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing partial capture: " + err.Message)
+	}
+	if response.TransactionId != dummyTransactionId {
+		t.Errorf("Expected transaction %s but got %s", dummyTransactionId, response.TransactionId)
+	}
+}
+
+func TestDefaultHoldService_CaptureHold_returns_conflictError_when_hold_notActive(t *testing.T) {
+	//Arrange
+	teardown := setupHoldServiceTest(t)
+	defer teardown()
+	expectHolder(dummyCustomerId, dto.AccountHolderRolePrimary)
+
+	hold := getDummyActiveHold()
+	hold.Status = domain.HoldStatusExpired
+	mockHoldRepo.EXPECT().FindById(dummyHoldId).Return(&hold, nil)
+	mockHoldRepo.EXPECT().Capture(gomock.Any(), gomock.Any()).Times(0)
+
+	request := dto.CaptureHoldRequest{AccountId: dummyAccountId, CustomerId: dummyCustomerId, HoldId: dummyHoldId}
+
+	//Act
+	_, err := holdSvc.CaptureHold(request)
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing capturing expired hold")
+	}
+	if err.Code != http.StatusConflict {
+		t.Errorf("Expected status code %d but got %d", http.StatusConflict, err.Code)
+	}
+}
+
+func TestDefaultHoldService_CaptureHold_expiresHold_when_hold_pastExpiryDate(t *testing.T) {
+	//Arrange
+	teardown := setupHoldServiceTest(t)
+	defer teardown()
+	expectHolder(dummyCustomerId, dto.AccountHolderRolePrimary)
+
+	hold := getDummyActiveHold()
+	hold.ExpiryDate = "2023-01-02 11:59:59"
+	mockHoldRepo.EXPECT().FindById(dummyHoldId).Return(&hold, nil)
+	mockHoldRepo.EXPECT().Release(hold, domain.HoldStatusExpired).Return(nil)
+	mockHoldRepo.EXPECT().Capture(gomock.Any(), gomock.Any()).Times(0)
+
+	request := dto.CaptureHoldRequest{AccountId: dummyAccountId, CustomerId: dummyCustomerId, HoldId: dummyHoldId}
+
+	//Act
+	_, err := holdSvc.CaptureHold(request)
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing capturing hold past its expiry date")
+	}
+	if err.Code != http.StatusConflict {
+		t.Errorf("Expected status code %d but got %d", http.StatusConflict, err.Code)
+	}
+}
+
+func TestDefaultHoldService_CaptureHold_returns_authorizationError_when_customer_onHold(t *testing.T) {
+	//Arrange
+	teardown := setupHoldServiceTest(t)
+	defer teardown()
+
+	mockSanctionsRepo := mocksDomain.NewMockSanctionsRepository(gomock.NewController(t))
+	uow := domain.NewUnitOfWorkStub(domain.Repositories{Accounts: mockAccountRepo, Holds: mockHoldRepo,
+		Sanctions: mockSanctionsRepo})
+	holdSvc = NewHoldService(mockHoldRepo, mockAccountRepo, nil, uow, holdClock)
+
+	expectHolder(dummyCustomerId, dto.AccountHolderRolePrimary)
+	hold := getDummyActiveHold()
+	mockHoldRepo.EXPECT().FindById(dummyHoldId).Return(&hold, nil)
+	mockAccountRepo.EXPECT().FindById(dummyAccountId).Return(&domain.Account{AccountId: dummyAccountId,
+		CustomerId: dummyCustomerId}, nil)
+	mockSanctionsRepo.EXPECT().IsOnHold(dummyCustomerId).Return(true, nil)
+	mockHoldRepo.EXPECT().Capture(gomock.Any(), gomock.Any()).Times(0)
+
+	request := dto.CaptureHoldRequest{AccountId: dummyAccountId, CustomerId: dummyCustomerId, HoldId: dummyHoldId}
+
+	//Act
+	_, err := holdSvc.CaptureHold(request)
+
+	//Assert
+	if err != errCustomerOnHold {
+		t.Errorf("Expected error %v but got %v", errCustomerOnHold, err)
+	}
+}
+
+func TestDefaultHoldService_PlaceHold_returns_authorizationError_when_customer_viewOnlyHolder(t *testing.T) {
+	//Arrange
+	teardown := setupHoldServiceTest(t)
+	defer teardown()
+
+	expectHolder("3", dto.AccountHolderRoleViewOnly)
+	mockAccountRepo.EXPECT().FindById(gomock.Any()).Times(0)
+	mockHoldRepo.EXPECT().Save(gomock.Any()).Times(0)
+
+	request := dto.NewHoldRequest{AccountId: dummyAccountId, CustomerId: "3", Amount: 100}
+
+	//Act
+	_, err := holdSvc.PlaceHold(request)
+
+	//Assert
+	if err == nil || err.Code != http.StatusForbidden {
+		t.Errorf("Expected authorization error but got %v", err)
+	}
+}
+
+func TestDefaultHoldService_GetHolds_returns_error_when_customer_notHolder(t *testing.T) {
+	//Arrange
+	teardown := setupHoldServiceTest(t)
+	defer teardown()
+
+	mockAccountRepo.EXPECT().FindHolder(dummyAccountId, "3").Return(nil, errs.NewNotFoundError("Account not found"))
+	mockHoldRepo.EXPECT().FindAll(gomock.Any()).Times(0)
+
+	//Act
+	_, err := holdSvc.GetHolds("3", dummyAccountId)
+
+	//Assert
+	if err == nil || err.Code != http.StatusNotFound {
+		t.Errorf("Expected not found error but got %v", err)
+	}
+}
+
+func TestDefaultHoldService_ExpireHolds_expiresHolds_and_skips_holdsNoLongerActive(t *testing.T) {
+	//Arrange
+	teardown := setupHoldServiceTest(t)
+	defer teardown()
+
+	first := getDummyActiveHold()
+	second := getDummyActiveHold()
+	second.HoldId = "4"
+	mockHoldRepo.EXPECT().FindExpired("2023-01-02 12:00:00").Return([]domain.Hold{first, second}, nil)
+	mockHoldRepo.EXPECT().Release(first, domain.HoldStatusExpired).Return(errs.NewConflictError("Hold is no longer active"))
+	mockHoldRepo.EXPECT().Release(second, domain.HoldStatusExpired).Return(nil)
+
+	//Act
+	err := holdSvc.ExpireHolds()
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing expiring holds: " + err.Message)
+	}
+}
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"fmt"
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"time"
+)
+
+//go:generate mockgen -destination=../mocks/service/mock_interestService.go -package=service github.com/aliciatay-zls/banking/backend/service InterestService
+type InterestService interface { //service (primary port)
+	RunDailyJob() *errs.AppError
+	AccrueInterest(time.Time) *errs.AppError
+	PostInterest(string) *errs.AppError
+}
+
+type DefaultInterestService struct { //business/domain object
+	repo    domain.InterestRepository
+	uow     domain.UnitOfWork
+	rates   domain.InterestRates
+	charges domain.OverdraftCharges
+	clk     clock.Clock
+}
+
+func NewInterestService(repo domain.InterestRepository, uow domain.UnitOfWork, rates domain.InterestRates,
+	charges domain.OverdraftCharges, clk clock.Clock) DefaultInterestService {
+	return DefaultInterestService{repo, uow, rates, charges, clk}
+}
+
+// RunDailyJob accrues interest and overdraft interest for each day from the last day interest was accrued for up to
+// the day that has just ended according to the clock, so that days on which the job did not run are caught up on. For
+// each of those days that was the last day of a month, it also posts the month's interest and overdraft charges. The
+// last day accrued is run again in case the run that accrued it stopped before posting. Since both steps are
+// idempotent per date, the job can safely be run more than once a day.
+func (s DefaultInterestService) RunDailyJob() *errs.AppError {
+	now := s.clk.Now()
+	yesterday := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()).AddDate(0, 0, -1)
+
+	from := yesterday
+	lastAccrualDate, err := s.repo.FindLastAccrualDate()
+	if err != nil {
+		return err
+	}
+	if lastAccrualDate != "" {
+		last, parseErr := time.ParseInLocation(domain.FormatDate, lastAccrualDate, now.Location())
+		if parseErr != nil {
+			logger.Error("Error while parsing last interest accrual date: " + parseErr.Error())
+			return errs.NewUnexpectedError("Unexpected server error")
+		}
+		if last.Before(from) {
+			from = last
+		}
+	}
+
+	for date := from; !date.After(yesterday); date = date.AddDate(0, 0, 1) {
+		if err = s.AccrueInterest(date); err != nil {
+			return err
+		}
+		if domain.IsLastDayOfMonth(date) {
+			if err = s.PostInterest(date.Format(domain.FormatPeriod)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// AccrueInterest calculates interest on the end-of-day balance of every account for the given date and saves it.
+// Overdrawn checking accounts accrue overdraft interest instead. Accounts that have already accrued interest for the
+// date are skipped. The accruals of the date are saved in one unit of work.
+func (s DefaultInterestService) AccrueInterest(date time.Time) *errs.AppError {
+	accounts, err := s.repo.FindEndOfDayBalances(date.Format(domain.FormatDate))
+	if err != nil {
+		return err
+	}
+
+	return s.uow.Do(func(repos domain.Repositories) *errs.AppError {
+		for _, a := range accounts {
+			var accrual domain.InterestAccrual
+			if a.OverdraftUsed() > 0 {
+				if a.AccountType != dto.AccountTypeChecking || s.charges.AnnualRate == 0 {
+					continue
+				}
+				accrual = domain.NewOverdraftInterestAccrual(a.AccountId, date, a.Amount, s.charges.AnnualRate)
+			} else {
+				rate := s.rates.AnnualRateFor(a.AccountType, a.Amount)
+				if rate == 0 {
+					continue
+				}
+				accrual = domain.NewInterestAccrual(a.AccountId, date, a.Amount, rate)
+			}
+
+			if _, err := repos.Interest.SaveAccrual(accrual); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// PostInterest credits each account with the interest it accrued during the given period (a month in the form
+// "2006-01") and charges each overdrawn account the overdraft interest it accrued, as well as the monthly overdraft
+// fee, by making one transaction for each of these.
+func (s DefaultInterestService) PostInterest(period string) *errs.AppError {
+	totals, err := s.repo.FindAccrualTotals(period)
+	if err != nil {
+		return err
+	}
+
+	postings := make([]domain.InterestPosting, 0)
+	for _, p := range totals {
+		postings = append(postings, p)
+		if p.PostingType == dto.TransactionTypeOverdraftInterest && s.charges.MonthlyFee > 0 {
+			fee := domain.InterestPosting{
+				AccountId:   p.AccountId,
+				Period:      period,
+				PostingType: dto.TransactionTypeFee,
+				Amount:      s.charges.MonthlyFee,
+			}
+			postings = append(postings, fee)
+		}
+	}
+
+	for _, p := range postings {
+		if err = s.post(p); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// post makes a transaction for the given posting. The posting is claimed, the transaction is made and the posting is
+// completed in one unit of work, so that re-running this never posts the same amount for the same period twice, and a
+// posting whose transaction fails is not claimed.
+func (s DefaultInterestService) post(p domain.InterestPosting) *errs.AppError {
+	amount := p.RoundedAmount()
+	if amount <= 0 {
+		return nil
+	}
+
+	p.ClaimedOn = s.clk.NowAsString()
+	return s.uow.Do(func(repos domain.Repositories) *errs.AppError {
+		isClaimed, err := repos.Interest.ClaimPosting(p)
+		if err != nil {
+			return err
+		}
+		if !isClaimed {
+			return nil
+		}
+
+		transaction := domain.NewTransaction(p.AccountId, amount, p.PostingType, s.clk)
+		completedTransaction, err := repos.Accounts.Transact(transaction)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Error while posting %s for period %s to account %s", p.PostingType, p.Period, p.AccountId))
+			return err
+		}
+
+		completed := p
+		completed.TransactionId = completedTransaction.TransactionId
+		return repos.Interest.CompletePosting(completed)
+	})
+}
@@ -0,0 +1,297 @@
+package service
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	mocksDomain "github.com/aliciatay-zls/banking/backend/mocks/domain"
+	"go.uber.org/mock/gomock"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Package common variables and inputs
+type dummyClock struct { //clock that can be moved forward to simulate the passing of time
+	now time.Time
+}
+
+func (c *dummyClock) Now() time.Time {
+	return c.now
+}
+
+func (c *dummyClock) NowAsString() string {
+	return c.now.Format(clock.FormatDateTime)
+}
+
+// Test common variables and inputs
+var mockInterestRepo *mocksDomain.MockInterestRepository
+var interestClock *dummyClock
+var interestSvc DefaultInterestService
+
+var dummyInterestRates = domain.InterestRates{
+	{AccountType: dto.AccountTypeSaving, MinBalance: 0, AnnualRate: 0.01},
+}
+
+var dummyOverdraftCharges = domain.OverdraftCharges{AnnualRate: 0.365, MonthlyFee: 10}
+
+const dummyInterestBalance float64 = 36500 //accrues exactly 1.00 a day at 1%
+const dummyOverdrawnBalance float64 = -100 //accrues exactly 0.10 a day at 36.5%
+const dummyPeriod = "2023-01"
+
+func setupInterestServiceTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	mockInterestRepo = mocksDomain.NewMockInterestRepository(ctrl)
+	mockAccountRepo = mocksDomain.NewMockAccountRepository(ctrl)
+	interestClock = &dummyClock{time.Date(2023, 1, 2, 1, 0, 0, 0, time.UTC)}
+	uow := domain.NewUnitOfWorkStub(domain.Repositories{Accounts: mockAccountRepo, Interest: mockInterestRepo})
+	interestSvc = NewInterestService(mockInterestRepo, uow, dummyInterestRates, dummyOverdraftCharges, interestClock)
+
+	return func() {
+		mockInterestRepo = nil
+		mockAccountRepo = nil
+		defer ctrl.Finish()
+	}
+}
+
+// useInMemoryInterestRepo makes the mock repo behave like the real one for a single account with the given constant
+// balance, by keeping accruals and postings in memory.
+func useInMemoryInterestRepo(account domain.Account) {
+	accruals := map[string]domain.InterestAccrual{}
+	postings := map[string]domain.InterestPosting{}
+
+	mockInterestRepo.EXPECT().FindEndOfDayBalances(gomock.Any()).AnyTimes().Return([]domain.Account{account}, nil)
+	mockInterestRepo.EXPECT().SaveAccrual(gomock.Any()).AnyTimes().DoAndReturn(
+		func(a domain.InterestAccrual) (bool, *errs.AppError) {
+			key := a.AccountId + a.AccrualDate + a.AccrualType
+			if _, ok := accruals[key]; ok {
+				return false, nil
+			}
+			accruals[key] = a
+			return true, nil
+		})
+	mockInterestRepo.EXPECT().FindLastAccrualDate().AnyTimes().DoAndReturn(
+		func() (string, *errs.AppError) {
+			last := ""
+			for _, a := range accruals {
+				if a.AccrualDate > last {
+					last = a.AccrualDate
+				}
+			}
+			return last, nil
+		})
+	mockInterestRepo.EXPECT().FindAccrualTotals(gomock.Any()).AnyTimes().DoAndReturn(
+		func(period string) ([]domain.InterestPosting, *errs.AppError) {
+			totals := map[string]domain.InterestPosting{}
+			for _, a := range accruals {
+				if strings.HasPrefix(a.AccrualDate, period) {
+					p := totals[a.AccountId+a.AccrualType]
+					p.AccountId, p.Period, p.PostingType = a.AccountId, period, a.AccrualType
+					p.Amount += a.Amount
+					totals[a.AccountId+a.AccrualType] = p
+				}
+			}
+			result := make([]domain.InterestPosting, 0)
+			for _, p := range totals {
+				result = append(result, p)
+			}
+			return result, nil
+		})
+	mockInterestRepo.EXPECT().ClaimPosting(gomock.Any()).AnyTimes().DoAndReturn(
+		func(p domain.InterestPosting) (bool, *errs.AppError) {
+			key := p.AccountId + p.Period + p.PostingType
+			if _, ok := postings[key]; ok {
+				return false, nil
+			}
+			postings[key] = p
+			return true, nil
+		})
+	mockInterestRepo.EXPECT().CompletePosting(gomock.Any()).AnyTimes().Return(nil)
+}
+
+func TestDefaultInterestService_RunDailyJob_postsInterestOncePerMonth_when_runRepeatedlyOverMonths(t *testing.T) {
+	//Arrange
+	teardown := setupInterestServiceTest(t)
+	defer teardown()
+	useInMemoryInterestRepo(domain.Account{AccountId: dummyAccountId, AccountType: dto.AccountTypeSaving, Amount: dummyInterestBalance})
+
+	actualAmounts := make([]float64, 0)
+	mockAccountRepo.EXPECT().Transact(gomock.Any()).AnyTimes().DoAndReturn(
+		func(transaction domain.Transaction) (*domain.Transaction, *errs.AppError) {
+			if transaction.TransactionType != dto.TransactionTypeInterest {
+				t.Errorf("Expected transaction type %s but got %s", dto.TransactionTypeInterest, transaction.TransactionType)
+			}
+			actualAmounts = append(actualAmounts, transaction.Amount)
+			transaction.TransactionId = dummyTransactionId
+			return &transaction, nil
+		})
+
+	expectedAmounts := []float64{31, 28} //January 2023, February 2023
+
+	//Act
+	end := time.Date(2023, 3, 1, 23, 0, 0, 0, time.UTC)
+	for interestClock.now.Before(end) {
+		if err := interestSvc.RunDailyJob(); err != nil {
+			t.Fatal("Expected no error but got error while running daily job: " + err.Message)
+		}
+		if err := interestSvc.RunDailyJob(); err != nil { //re-run on the same day
+			t.Fatal("Expected no error but got error while re-running daily job: " + err.Message)
+		}
+		interestClock.now = interestClock.now.AddDate(0, 0, 1)
+	}
+
+	//Assert
+	if len(actualAmounts) != len(expectedAmounts) {
+		t.Fatalf("Expected %d interest transactions but got %d", len(expectedAmounts), len(actualAmounts))
+	}
+	for k := range expectedAmounts {
+		if actualAmounts[k] != expectedAmounts[k] {
+			t.Errorf("Expected interest of %v for month %d but got %v", expectedAmounts[k], k+1, actualAmounts[k])
+		}
+	}
+}
+
+func TestDefaultInterestService_RunDailyJob_chargesOverdraftInterestAndFeeOncePerMonth_when_checkingAccountOverdrawn(t *testing.T) {
+	//Arrange
+	teardown := setupInterestServiceTest(t)
+	defer teardown()
+	useInMemoryInterestRepo(domain.Account{AccountId: dummyAccountId, AccountType: dto.AccountTypeChecking, Amount: dummyOverdrawnBalance})
+
+	actualCharges := map[string]float64{}
+	mockAccountRepo.EXPECT().Transact(gomock.Any()).AnyTimes().DoAndReturn(
+		func(transaction domain.Transaction) (*domain.Transaction, *errs.AppError) {
+			if !transaction.IsDebit() {
+				t.Errorf("Expected overdraft charge to be a debit but got transaction type %s", transaction.TransactionType)
+			}
+			actualCharges[transaction.TransactionType] += transaction.Amount
+			transaction.TransactionId = dummyTransactionId
+			return &transaction, nil
+		})
+
+	expectedCharges := map[string]float64{
+		dto.TransactionTypeOverdraftInterest: 3.1,
+		dto.TransactionTypeFee:               dummyOverdraftCharges.MonthlyFee,
+	}
+
+	//Act
+	end := time.Date(2023, 2, 1, 23, 0, 0, 0, time.UTC)
+	for interestClock.now.Before(end) {
+		if err := interestSvc.RunDailyJob(); err != nil {
+			t.Fatal("Expected no error but got error while running daily job: " + err.Message)
+		}
+		if err := interestSvc.RunDailyJob(); err != nil {
+			t.Fatal("Expected no error but got error while re-running daily job: " + err.Message)
+		}
+		interestClock.now = interestClock.now.AddDate(0, 0, 1)
+	}
+
+	//Assert
+	if len(actualCharges) != len(expectedCharges) {
+		t.Fatalf("Expected %d types of charges but got %d", len(expectedCharges), len(actualCharges))
+	}
+	for k, v := range expectedCharges {
+		if actualCharges[k] != v {
+			t.Errorf("Expected %s of %v but got %v", k, v, actualCharges[k])
+		}
+	}
+}
+
+func TestDefaultInterestService_RunDailyJob_catchesUp_on_daysMissed(t *testing.T) {
+	//Arrange
+	teardown := setupInterestServiceTest(t)
+	defer teardown()
+
+	interestClock.now = time.Date(2023, 1, 30, 1, 0, 0, 0, time.UTC)
+	mockInterestRepo.EXPECT().FindLastAccrualDate().Return("2023-01-26", nil)
+	for _, date := range []string{"2023-01-26", "2023-01-27", "2023-01-28", "2023-01-29"} {
+		mockInterestRepo.EXPECT().FindEndOfDayBalances(date).Return([]domain.Account{}, nil)
+	}
+
+	//Act
+	err := interestSvc.RunDailyJob()
+
+	//Assert
+	if err != nil {
+		t.Error("Expected no error but got error: " + err.Message)
+	}
+}
+
+func TestDefaultInterestService_RunDailyJob_postsInterest_of_monthMissed(t *testing.T) {
+	//Arrange
+	teardown := setupInterestServiceTest(t)
+	defer teardown()
+	useInMemoryInterestRepo(domain.Account{AccountId: dummyAccountId, AccountType: dto.AccountTypeSaving, Amount: dummyInterestBalance})
+
+	var actualAmount float64
+	mockAccountRepo.EXPECT().Transact(gomock.Any()).DoAndReturn(
+		func(transaction domain.Transaction) (*domain.Transaction, *errs.AppError) {
+			actualAmount = transaction.Amount
+			transaction.TransactionId = dummyTransactionId
+			return &transaction, nil
+		})
+	if err := interestSvc.RunDailyJob(); err != nil { //accrues 2023-01-01
+		t.Fatal("Expected no error but got error while running daily job: " + err.Message)
+	}
+	interestClock.now = time.Date(2023, 2, 3, 1, 0, 0, 0, time.UTC)
+
+	//Act
+	err := interestSvc.RunDailyJob()
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while catching up: " + err.Message)
+	}
+	if actualAmount != 31 {
+		t.Errorf("Expected interest of 31 for January but got %v", actualAmount)
+	}
+}
+
+func TestDefaultInterestService_AccrueInterest_skips_accounts_withoutInterestRate(t *testing.T) {
+	//Arrange
+	teardown := setupInterestServiceTest(t)
+	defer teardown()
+
+	dummyAccounts := []domain.Account{
+		{AccountId: dummyAccountId, AccountType: dto.AccountTypeChecking, Amount: dummyInterestBalance},
+		{AccountId: "1980", AccountType: dto.AccountTypeSaving, Amount: 0},
+		{AccountId: "1981", AccountType: dto.AccountTypeSaving, Amount: dummyOverdrawnBalance},
+	}
+	mockInterestRepo.EXPECT().FindEndOfDayBalances("2023-01-01").Return(dummyAccounts, nil)
+	mockInterestRepo.EXPECT().SaveAccrual(gomock.Any()).Times(0)
+
+	//Act
+	err := interestSvc.AccrueInterest(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing accounts without interest: " + err.Message)
+	}
+}
+
+func TestDefaultInterestService_PostInterest_returns_error_and_doesNotCompletePosting_when_transact_fails(t *testing.T) {
+	//Arrange
+	teardown := setupInterestServiceTest(t)
+	defer teardown()
+
+	dummyPosting := domain.InterestPosting{AccountId: dummyAccountId, Period: dummyPeriod, PostingType: dto.TransactionTypeInterest,
+		Amount: 31, ClaimedOn: "2023-01-02 01:00:00"}
+	total := dummyPosting
+	total.ClaimedOn = ""
+	mockInterestRepo.EXPECT().FindAccrualTotals(dummyPeriod).Return([]domain.InterestPosting{total}, nil)
+	mockInterestRepo.EXPECT().ClaimPosting(dummyPosting).Return(true, nil)
+	dummyAppErr := errs.NewUnexpectedError("some error message")
+	mockAccountRepo.EXPECT().Transact(gomock.Any()).Return(nil, dummyAppErr)
+	mockInterestRepo.EXPECT().CompletePosting(gomock.Any()).Times(0)
+
+	//Act
+	err := interestSvc.PostInterest(dummyPeriod)
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing failed interest transaction")
+	}
+	if err.Message != dummyAppErr.Message {
+		t.Errorf("Expected error message to be \"%s\" but got \"%s\"", dummyAppErr.Message, err.Message)
+	}
+}
@@ -0,0 +1,214 @@
+package service
+
+import (
+	"fmt"
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/formValidator"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+)
+
+//go:generate mockgen -destination=../mocks/service/mock_paymentInitiationService.go -package=service github.com/aliciatay-zls/banking/backend/service PaymentInitiationService
+type PaymentInitiationService interface { //service (primary port)
+	InitiatePayments(dto.PaymentInitiationRequest, io.Reader) (*dto.Pain002Document, *errs.AppError)
+}
+
+type DefaultPaymentInitiationService struct { //business/domain object
+	accountRepo    domain.AccountRepository
+	accountService AccountService
+	clk            clock.Clock
+}
+
+func NewPaymentInitiationService(accountRepo domain.AccountRepository, accountService AccountService,
+	clk clock.Clock) DefaultPaymentInitiationService {
+	return DefaultPaymentInitiationService{accountRepo, accountService, clk}
+}
+
+// InitiatePayments reads the pain.001 message in the given body and makes each of its credit transfers as a transfer
+// between accounts of the bank, one by one. Only accounts of the given customer can be debited, and each transfer is
+// checked the same way as one made through the API. The returned pain.002 status report says which transfers were
+// made, with the ID of their debit transaction, and why the others were rejected. If the numbers of transfers or the
+// control sums in the message do not add up, none of its transfers, or none of the payment info's, are made.
+func (s DefaultPaymentInitiationService) InitiatePayments(request dto.PaymentInitiationRequest,
+	body io.Reader) (*dto.Pain002Document, *errs.AppError) {
+	if errsArr := formValidator.Struct(request); errsArr != nil {
+		logger.Error("Payment initiation request is invalid: " + errsArr[0].Error())
+		return nil, errs.NewValidationError("Customer ID must be a number.")
+	}
+
+	message, err := dto.ParsePain001(body)
+	if err != nil {
+		return nil, err
+	}
+
+	header := message.GroupHeader
+	report := dto.Pain002Report{
+		GroupHeader: dto.Pain002GroupHeader{
+			MessageId:    "PSR-" + s.clk.Now().Format("20060102150405") + "-" + request.CustomerId,
+			CreationTime: s.clk.Now().Format("2006-01-02T15:04:05"),
+		},
+		OriginalGroup: dto.Pain002OriginalGroup{MessageId: header.MessageId, MessageNameId: message.MessageNameId()},
+		PaymentInfos:  make([]dto.Pain002PaymentInfoStatus, 0),
+	}
+	if _, err := strconv.Atoi(header.NumberOfTransactions); err == nil { //only echoed if valid, to keep the report valid
+		report.OriginalGroup.NumberOfTransactions = header.NumberOfTransactions
+	}
+	if _, err := strconv.ParseFloat(header.ControlSum, 64); err == nil {
+		report.OriginalGroup.ControlSum = header.ControlSum
+	}
+
+	count, sum, ok := message.CountAndSum()
+	if reason := checkCountAndSum(count, sum, ok, header.NumberOfTransactions, header.ControlSum); reason != nil {
+		logger.Error(fmt.Sprintf("pain.001 message %s rejected (%s)", header.MessageId, reason.AdditionalInfo))
+		report.OriginalGroup.Status = dto.PaymentStatusRejected
+		report.OriginalGroup.Reasons = []dto.Pain002StatusReason{*reason}
+		document := dto.NewPain002Document(report)
+		return &document, nil
+	}
+
+	accepted, pending := 0, 0
+	for _, p := range message.PaymentInfos {
+		status, a, n := s.initiatePaymentInfo(request.CustomerId, p)
+		report.PaymentInfos = append(report.PaymentInfos, status)
+		accepted += a
+		pending += n
+	}
+	report.OriginalGroup.Status = dto.GroupStatus(accepted, pending, count)
+
+	document := dto.NewPain002Document(report)
+	return &document, nil
+}
+
+// initiatePaymentInfo makes the credit transfers of the given payment info from its debtor account, returning their
+// statuses, how many of them were made and how many are pending.
+func (s DefaultPaymentInitiationService) initiatePaymentInfo(customerId string,
+	p dto.Pain001PaymentInfo) (dto.Pain002PaymentInfoStatus, int, int) {
+	status := dto.Pain002PaymentInfoStatus{PaymentInfoId: p.PaymentInfoId, Transactions: make([]dto.Pain002TransactionStatus, 0)}
+	reject := func(reason dto.Pain002StatusReason) (dto.Pain002PaymentInfoStatus, int, int) {
+		status.Status = dto.PaymentStatusRejected
+		status.Reasons = []dto.Pain002StatusReason{reason}
+		status.Transactions = nil
+		return status, 0, 0
+	}
+
+	count, sum, ok := p.CountAndSum()
+	if reason := checkCountAndSum(count, sum, ok, p.NumberOfTransactions, p.ControlSum); reason != nil {
+		return reject(*reason)
+	}
+
+	if p.DebtorAccount.Id == "" {
+		return reject(dto.NewPain002StatusReason(dto.PaymentReasonIncorrectAccount,
+			"Debtor account should be identified by its account ID."))
+	}
+	debtor, err := s.accountRepo.FindById(p.DebtorAccount.Id)
+	if err != nil && err.Code != http.StatusNotFound {
+		return reject(dto.NewPain002StatusReason(dto.PaymentReasonNarrative, err.Message))
+	}
+	var holder *domain.AccountHolder
+	if err == nil {
+		holder, err = s.accountRepo.FindHolder(debtor.AccountId, customerId)
+	}
+	if err != nil || !holder.CanTransact() {
+		logger.Error("Debtor account " + p.DebtorAccount.Id + " not found for customer " + customerId)
+		return reject(dto.NewPain002StatusReason(dto.PaymentReasonIncorrectAccount, "Debtor account not found"))
+	}
+
+	accepted, pending := 0, 0
+	for _, t := range p.Transactions {
+		transactionStatus := s.initiateTransfer(customerId, *debtor, t)
+		switch transactionStatus.Status {
+		case dto.PaymentStatusAccepted:
+			accepted++
+		case dto.PaymentStatusPending:
+			pending++
+		}
+		status.Transactions = append(status.Transactions, transactionStatus)
+	}
+	status.Status = dto.GroupStatus(accepted, pending, count)
+	return status, accepted, pending
+}
+
+// initiateTransfer makes the given credit transfer from the given debtor account, returning its status. A transfer
+// held by sanctions screening is pending, since it is made once an admin clears it.
+func (s DefaultPaymentInitiationService) initiateTransfer(customerId string, debtor domain.Account,
+	t dto.Pain001CreditTransfer) dto.Pain002TransactionStatus {
+	status := dto.Pain002TransactionStatus{InstructionId: t.InstructionId, EndToEndId: t.EndToEndId}
+	reject := func(code string, info string) dto.Pain002TransactionStatus {
+		status.Status = dto.PaymentStatusRejected
+		status.Reasons = []dto.Pain002StatusReason{dto.NewPain002StatusReason(code, info)}
+		return status
+	}
+
+	amount, _ := t.Amount.Float() //checked to be a number along with the control sum
+	if amount <= dto.TransactionMinAmountAllowed || amount > dto.TransactionMaxAmountAllowed {
+		return reject(dto.PaymentReasonNotAllowedAmount, fmt.Sprintf("Transfer amount should be more than %.2f and at most %.2f.",
+			dto.TransactionMinAmountAllowed, dto.TransactionMaxAmountAllowed))
+	}
+	if t.Amount.Currency != debtor.Currency {
+		return reject(dto.PaymentReasonNotAllowedCurrency, "Amount should be in the currency of the debtor account, "+
+			debtor.Currency+".")
+	}
+
+	transferRequest := dto.TransferRequest{
+		AccountId:            debtor.AccountId,
+		CustomerId:           customerId,
+		DestinationAccountId: t.CreditorAccount.Id,
+		Amount:               amount,
+	}
+	if err := transferRequest.Validate(); err != nil { //only the creditor account can be invalid by now
+		return reject(dto.PaymentReasonInvalidCreditorAccount, "Creditor account should be another account of the bank, "+
+			"identified by its account ID.")
+	}
+
+	response, err := s.accountService.MakeTransfer(transferRequest)
+	if err != nil {
+		switch {
+		case err.Code == http.StatusNotFound:
+			return reject(dto.PaymentReasonInvalidCreditorAccount, "Creditor account not found")
+		case err == errInsufficientBalanceForTransfer:
+			return reject(dto.PaymentReasonInsufficientFunds, err.Message)
+		case err == errTransferHeld:
+			status.Status = dto.PaymentStatusPending
+			status.Reasons = []dto.Pain002StatusReason{dto.NewPain002StatusReason(dto.PaymentReasonNarrative, err.Message)}
+			return status
+		case err == errNotBeneficiary, err == errCustomerOnHold:
+			return reject(dto.PaymentReasonTransactionForbidden, err.Message)
+		default:
+			return reject(dto.PaymentReasonNarrative, err.Message)
+		}
+	}
+
+	status.Status = dto.PaymentStatusAccepted
+	status.StatusId = response.TransactionId
+	return status
+}
+
+// checkCountAndSum checks the number of transfers and sum of amounts of a message or payment info against the number
+// of transactions and control sum it gives, if any, returning the reason to reject it if they do not match.
+func checkCountAndSum(count int, sum float64, amountsValid bool, givenCount string,
+	givenSum string) *dto.Pain002StatusReason {
+	if !amountsValid {
+		reason := dto.NewPain002StatusReason(dto.PaymentReasonNotAllowedAmount, "Every amount should be a number.")
+		return &reason
+	}
+	if n, err := strconv.Atoi(givenCount); (givenCount != "" && (err != nil || n != count)) || count == 0 ||
+		count > dto.Pain001MaxTransactions {
+		reason := dto.NewPain002StatusReason(dto.PaymentReasonInvalidNumberOfTransactions,
+			fmt.Sprintf("Number of transactions should match the %d transactions given, of which there should be 1 to %d.",
+				count, dto.Pain001MaxTransactions))
+		return &reason
+	}
+	if controlSum, err := strconv.ParseFloat(givenSum, 64); givenSum != "" &&
+		(err != nil || math.Abs(controlSum-sum) >= 0.005) {
+		reason := dto.NewPain002StatusReason(dto.PaymentReasonInvalidControlSum,
+			fmt.Sprintf("Control sum should match the sum of the amounts given, %.2f.", sum))
+		return &reason
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	mocksDomain "github.com/aliciatay-zls/banking/backend/mocks/domain"
+	mocksService "github.com/aliciatay-zls/banking/backend/mocks/service"
+	"go.uber.org/mock/gomock"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Test common variables and inputs
+var paymentSvc DefaultPaymentInitiationService
+
+func setupPaymentInitiationServiceTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	mockAccountRepo = mocksDomain.NewMockAccountRepository(ctrl)
+	mockAccountService = mocksService.NewMockAccountService(ctrl)
+	paymentSvc = NewPaymentInitiationService(mockAccountRepo, mockAccountService,
+		&dummyClock{time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC)})
+
+	mockAccountRepo.EXPECT().FindById(dummyAccountId).AnyTimes().Return(&domain.Account{AccountId: dummyAccountId,
+		CustomerId: dummyCustomerId, Currency: "USD"}, nil)
+	mockAccountRepo.EXPECT().FindById("1980").AnyTimes().Return(&domain.Account{AccountId: "1980", CustomerId: "3",
+		Currency: "USD"}, nil)
+	mockAccountRepo.EXPECT().FindHolder(dummyAccountId, dummyCustomerId).AnyTimes().Return(&domain.AccountHolder{
+		AccountId: dummyAccountId, CustomerId: dummyCustomerId, Role: dto.AccountHolderRolePrimary}, nil)
+	mockAccountRepo.EXPECT().FindHolder("1980", dummyCustomerId).AnyTimes().Return(nil,
+		errs.NewNotFoundError("Account not found"))
+
+	return func() {
+		mockAccountRepo = nil
+		mockAccountService = nil
+		defer ctrl.Finish()
+	}
+}
+
+// newDummyPain001 makes a pain.001 message with the given group header elements and payment infos.
+func newDummyPain001(header string, paymentInfos ...string) string {
+	return `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:pain.001.001.03"><CstmrCdtTrfInitn>
+<GrpHdr><MsgId>MSG-1</MsgId><CreDtTm>2023-01-02T10:00:00</CreDtTm>` + header + `</GrpHdr>` +
+		strings.Join(paymentInfos, "") + `</CstmrCdtTrfInitn></Document>`
+}
+
+// newDummyPaymentInfo makes a payment info from the given debtor account with the given credit transfers.
+func newDummyPaymentInfo(debtorAccountId string, transfers ...string) string {
+	return `<PmtInf><PmtInfId>PMT-` + debtorAccountId + `</PmtInfId><PmtMtd>TRF</PmtMtd>
+<DbtrAcct><Id><Othr><Id>` + debtorAccountId + `</Id></Othr></Id></DbtrAcct>` + strings.Join(transfers, "") + `</PmtInf>`
+}
+
+// newDummyCreditTransfer makes a credit transfer of the given amount to the given creditor account.
+func newDummyCreditTransfer(endToEndId string, amount string, currency string, creditorAccountId string) string {
+	return `<CdtTrfTxInf><PmtId><EndToEndId>` + endToEndId + `</EndToEndId></PmtId>
+<Amt><InstdAmt Ccy="` + currency + `">` + amount + `</InstdAmt></Amt>
+<CdtrAcct><Id><Othr><Id>` + creditorAccountId + `</Id></Othr></Id></CdtrAcct></CdtTrfTxInf>`
+}
+
+func TestDefaultPaymentInitiationService_InitiatePayments_reports_each_transfer(t *testing.T) {
+	//Arrange
+	teardown := setupPaymentInitiationServiceTest(t)
+	defer teardown()
+
+	message := newDummyPain001("<NbOfTxs>5</NbOfTxs><CtrlSum>10350.00</CtrlSum>",
+		newDummyPaymentInfo(dummyAccountId,
+			newDummyCreditTransfer("E2E-1", "100.00", "USD", "2001"),
+			newDummyCreditTransfer("E2E-2", "150.00", "USD", "2002"),
+			newDummyCreditTransfer("E2E-3", "50.00", "EUR", "2001"),
+			newDummyCreditTransfer("E2E-4", "10000.00", "USD", dummyAccountId)),
+		newDummyPaymentInfo("1980", newDummyCreditTransfer("E2E-5", "50.00", "USD", "2001")))
+
+	mockAccountService.EXPECT().MakeTransfer(dto.TransferRequest{AccountId: dummyAccountId, CustomerId: dummyCustomerId,
+		DestinationAccountId: "2001", Amount: 100}).Return(&dto.TransactionResponse{TransactionId: "51"}, nil)
+	mockAccountService.EXPECT().MakeTransfer(dto.TransferRequest{AccountId: dummyAccountId, CustomerId: dummyCustomerId,
+		DestinationAccountId: "2002", Amount: 150}).Return(nil, errInsufficientBalanceForTransfer)
+
+	//Act
+	document, err := paymentSvc.InitiatePayments(dto.PaymentInitiationRequest{CustomerId: dummyCustomerId},
+		strings.NewReader(message))
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	report := document.Report
+	if report.OriginalGroup.MessageId != "MSG-1" || report.OriginalGroup.MessageNameId != "pain.001.001.03" ||
+		report.OriginalGroup.Status != dto.PaymentStatusPartiallyAccepted {
+		t.Errorf("Expected partially accepted message MSG-1 but got %+v", report.OriginalGroup)
+	}
+	if len(report.PaymentInfos) != 2 || report.PaymentInfos[0].Status != dto.PaymentStatusPartiallyAccepted ||
+		report.PaymentInfos[1].Status != dto.PaymentStatusRejected ||
+		report.PaymentInfos[1].Reasons[0].Code != dto.PaymentReasonIncorrectAccount {
+		t.Fatalf("Expected first payment info partially accepted and second rejected but got %+v", report.PaymentInfos)
+	}
+	transactions := report.PaymentInfos[0].Transactions
+	expected := []struct{ status, reason string }{
+		{dto.PaymentStatusAccepted, ""},
+		{dto.PaymentStatusRejected, dto.PaymentReasonInsufficientFunds},
+		{dto.PaymentStatusRejected, dto.PaymentReasonNotAllowedCurrency},
+		{dto.PaymentStatusRejected, dto.PaymentReasonInvalidCreditorAccount},
+	}
+	for k, e := range expected {
+		reason := ""
+		if len(transactions[k].Reasons) > 0 {
+			reason = transactions[k].Reasons[0].Code
+		}
+		if transactions[k].Status != e.status || reason != e.reason {
+			t.Errorf("Expected transfer %d to be %s (%s) but got %+v", k+1, e.status, e.reason, transactions[k])
+		}
+	}
+	if transactions[0].StatusId != "51" || transactions[0].EndToEndId != "E2E-1" {
+		t.Errorf("Expected accepted transfer with transaction ID but got %+v", transactions[0])
+	}
+}
+
+func TestDefaultPaymentInitiationService_InitiatePayments_reports_pending_when_transfer_heldBySanctionsScreening(t *testing.T) {
+	//Arrange
+	teardown := setupPaymentInitiationServiceTest(t)
+	defer teardown()
+
+	message := newDummyPain001("<NbOfTxs>1</NbOfTxs>",
+		newDummyPaymentInfo(dummyAccountId, newDummyCreditTransfer("E2E-1", "100.00", "USD", "2001")))
+	mockAccountService.EXPECT().MakeTransfer(gomock.Any()).Return(nil, errTransferHeld)
+
+	//Act
+	document, err := paymentSvc.InitiatePayments(dto.PaymentInitiationRequest{CustomerId: dummyCustomerId},
+		strings.NewReader(message))
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	report := document.Report
+	if report.OriginalGroup.Status != dto.PaymentStatusPending || report.PaymentInfos[0].Status != dto.PaymentStatusPending {
+		t.Errorf("Expected pending message and payment info but got %+v and %+v", report.OriginalGroup,
+			report.PaymentInfos[0])
+	}
+	if transaction := report.PaymentInfos[0].Transactions[0]; transaction.Status != dto.PaymentStatusPending {
+		t.Errorf("Expected pending transfer but got %+v", transaction)
+	}
+}
+
+func TestDefaultPaymentInitiationService_InitiatePayments_rejects_message_when_control_sum_wrong(t *testing.T) {
+	//Arrange
+	teardown := setupPaymentInitiationServiceTest(t)
+	defer teardown()
+
+	message := newDummyPain001("<NbOfTxs>1</NbOfTxs><CtrlSum>99.00</CtrlSum>",
+		newDummyPaymentInfo(dummyAccountId, newDummyCreditTransfer("E2E-1", "100.00", "USD", "2001")))
+	mockAccountService.EXPECT().MakeTransfer(gomock.Any()).Times(0)
+
+	//Act
+	document, err := paymentSvc.InitiatePayments(dto.PaymentInitiationRequest{CustomerId: dummyCustomerId},
+		strings.NewReader(message))
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	group := document.Report.OriginalGroup
+	if group.Status != dto.PaymentStatusRejected || group.Reasons[0].Code != dto.PaymentReasonInvalidControlSum ||
+		len(document.Report.PaymentInfos) != 0 {
+		t.Errorf("Expected message rejected for its control sum but got %+v", document.Report)
+	}
+}
+
+func TestDefaultPaymentInitiationService_InitiatePayments_returns_error_when_not_pain001(t *testing.T) {
+	//Arrange
+	teardown := setupPaymentInitiationServiceTest(t)
+	defer teardown()
+
+	message := `<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.02"><BkToCstmrStmt/></Document>`
+
+	//Act
+	_, err := paymentSvc.InitiatePayments(dto.PaymentInitiationRequest{CustomerId: dummyCustomerId},
+		strings.NewReader(message))
+
+	//Assert
+	if err == nil || err.Message != "Document should be a pain.001 customer credit transfer initiation." {
+		t.Errorf("Expected error for message of another kind but got %v", err)
+	}
+}
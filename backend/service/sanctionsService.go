@@ -0,0 +1,194 @@
+package service
+
+import (
+	"database/sql"
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"net/http"
+)
+
+//go:generate mockgen -destination=../mocks/service/mock_sanctionsService.go -package=service github.com/aliciatay-zls/banking/backend/service SanctionsService
+type SanctionsService interface { //service (primary port)
+	ScreenCustomers() *errs.AppError
+	ScreenCustomer(string) (*dto.CustomerScreeningResponse, *errs.AppError)
+	GetSanctionsHits(dto.SanctionsHitsRequest) (*dto.SanctionsHitsResponse, *errs.AppError)
+	GetSanctionsHit(string) (*dto.SanctionsHitResponse, *errs.AppError)
+	ResolveSanctionsHit(dto.ResolveSanctionsHitRequest) (*dto.SanctionsHitResponse, *errs.AppError)
+}
+
+type DefaultSanctionsService struct { //business/domain object
+	repo           domain.SanctionsRepository
+	customerRepo   domain.CustomerRepository
+	accountService AccountService
+	uow            domain.UnitOfWork
+	watchlist      domain.Watchlist
+	clk            clock.Clock
+}
+
+func NewSanctionsService(repo domain.SanctionsRepository, customerRepo domain.CustomerRepository,
+	accountService AccountService, uow domain.UnitOfWork, watchlist domain.Watchlist,
+	clk clock.Clock) DefaultSanctionsService {
+	return DefaultSanctionsService{repo, customerRepo, accountService, uow, watchlist, clk}
+}
+
+// ScreenCustomers screens every customer who is new or whose name, date of birth or country changed since they were
+// last screened, as well as every customer once the watchlist or thresholds change. Customers are registered and
+// updated outside this app, so this job is what picks up their changes. Customers who potentially match the
+// watchlist are put on hold.
+func (s DefaultSanctionsService) ScreenCustomers() *errs.AppError {
+	customers, err := s.customerRepo.FindAll("")
+	if err != nil {
+		return err
+	}
+	screenings, err := s.repo.FindScreenings()
+	if err != nil {
+		return err
+	}
+	fingerprints := make(map[string]string)
+	for _, screening := range screenings {
+		fingerprints[screening.CustomerId] = screening.Fingerprint
+	}
+
+	for _, c := range customers {
+		if fingerprints[c.Id] == s.watchlist.Fingerprint(c) {
+			continue
+		}
+		customer := c
+		if err = s.uow.Do(func(repos domain.Repositories) *errs.AppError {
+			_, _, err := screenCustomer(repos.Sanctions, s.watchlist, customer, s.clk)
+			return err
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ScreenCustomer screens the customer with the given ID straight away, e.g. right after they registered or changed
+// their details, and returns the watchlist entries they potentially are and whether they are on hold.
+func (s DefaultSanctionsService) ScreenCustomer(customerId string) (*dto.CustomerScreeningResponse, *errs.AppError) {
+	var matches []domain.SanctionsMatch
+	var onHold bool
+	err := s.uow.Do(func(repos domain.Repositories) *errs.AppError {
+		c, err := repos.Customers.FindById(customerId)
+		if err != nil {
+			return err
+		}
+		matches, onHold, err = screenCustomer(repos.Sanctions, s.watchlist, *c, s.clk)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.CustomerScreeningResponse{
+		CustomerId: customerId,
+		Matches:    domain.SanctionsMatchesToDTO(matches),
+		OnHold:     onHold,
+	}, nil
+}
+
+// GetSanctionsHits returns a page of the sanctions hits matching the given request, oldest first, so that the review
+// queue is worked through in the order the hits were found. If the page is full, the response says which after_id to
+// request the next page with.
+func (s DefaultSanctionsService) GetSanctionsHits(request dto.SanctionsHitsRequest) (*dto.SanctionsHitsResponse, *errs.AppError) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	filter := domain.SanctionsHitFilter{Status: request.Status, AfterId: request.AfterId, Limit: request.LimitOrDefault()}
+	hits, err := s.repo.FindHits(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	response := dto.SanctionsHitsResponse{Hits: make([]dto.SanctionsHitResponse, 0)}
+	for _, h := range hits {
+		response.Hits = append(response.Hits, h.ToDTO())
+	}
+	if len(hits) == filter.Limit {
+		response.NextAfterId = hits[len(hits)-1].HitId
+	}
+	return &response, nil
+}
+
+// GetSanctionsHit returns the sanctions hit with the given ID.
+func (s DefaultSanctionsService) GetSanctionsHit(hitId string) (*dto.SanctionsHitResponse, *errs.AppError) {
+	hit, err := s.repo.FindHitById(hitId)
+	if err != nil {
+		return nil, err
+	}
+
+	response := hit.ToDTO()
+	return &response, nil
+}
+
+// ResolveSanctionsHit closes the pending sanctions hit with the given ID with the resolution in the given request.
+// Clearing a customer's hit takes them off hold, while confirming it keeps them on hold for good. Clearing a held
+// transfer makes it, without screening it again, so the hit stays pending if the transfer cannot be made any more,
+// e.g. because the balance has since dropped.
+func (s DefaultSanctionsService) ResolveSanctionsHit(request dto.ResolveSanctionsHitRequest) (*dto.SanctionsHitResponse, *errs.AppError) {
+	var resolved domain.SanctionsHit
+	err := s.uow.Do(func(repos domain.Repositories) *errs.AppError {
+		hit, err := repos.Sanctions.FindHitById(request.HitId)
+		if err != nil {
+			return err
+		}
+		if resolved, err = hit.Resolve(request, s.clk); err != nil {
+			return err
+		}
+
+		if resolved.IsTransfer() && resolved.Status == dto.SanctionsHitStatusCleared {
+			transfer, err := accountServiceWithin(s.accountService, repos).MakeTransfer(resolved.ToTransferRequestDTO())
+			if err != nil {
+				return err
+			}
+			resolved.TransactionId = sql.NullString{String: transfer.TransactionId, Valid: true}
+		}
+		return repos.Sanctions.ResolveHit(resolved)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := resolved.ToDTO()
+	return &response, nil
+}
+
+// screenCustomer screens the given customer against the given watchlist if their details or the watchlist changed
+// since they were last screened, and puts them on hold if they potentially match it and are not on hold already. It
+// returns the watchlist entries they potentially are and whether they are on hold. Without a watchlist, it only
+// checks whether they are on hold.
+func screenCustomer(repo domain.SanctionsRepository, watchlist domain.Watchlist, c domain.Customer,
+	clk clock.Clock) ([]domain.SanctionsMatch, bool, *errs.AppError) {
+	onHold, err := repo.IsOnHold(c.Id)
+	if err != nil || watchlist.IsEmpty() {
+		return nil, onHold, err
+	}
+
+	matches := watchlist.Screen(c)
+	fingerprint := watchlist.Fingerprint(c)
+	previous, err := repo.FindScreening(c.Id)
+	if err != nil && err.Code != http.StatusNotFound {
+		return nil, false, err
+	}
+	if previous != nil && previous.Fingerprint == fingerprint {
+		return matches, onHold, nil
+	}
+
+	if len(matches) > 0 && !onHold {
+		logger.Info("Customer " + c.Id + " potentially matches the sanctions watchlist and was put on hold")
+		if _, err = repo.SaveHit(domain.NewCustomerSanctionsHit(c, matches, clk)); err != nil {
+			return nil, false, err
+		}
+		onHold = true
+	}
+	screening := domain.CustomerScreening{CustomerId: c.Id, Fingerprint: fingerprint, ScreeningDate: clk.NowAsString()}
+	if err = repo.SaveScreening(screening); err != nil {
+		return nil, false, err
+	}
+	return matches, onHold, nil
+}
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"database/sql"
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	mocksDomain "github.com/aliciatay-zls/banking/backend/mocks/domain"
+	mocksService "github.com/aliciatay-zls/banking/backend/mocks/service"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+// Test common variables and inputs
+var mockSanctionsRepo *mocksDomain.MockSanctionsRepository
+var mockSanctionsCustomerRepo *mocksDomain.MockCustomerRepository
+var mockSanctionsAccountService *mocksService.MockAccountService
+var sanctionsSvc DefaultSanctionsService
+
+const dummyHitId = "4"
+
+var dummyWatchlist = domain.Watchlist{
+	Entries:    []domain.WatchlistEntry{{ListId: "WL-1", Name: "Viktor Ardenko", DateOfBirth: "1971-03-09"}},
+	Thresholds: domain.DefaultSanctionsThresholds(),
+	Digest:     "abc",
+}
+
+func setupSanctionsServiceTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	mockSanctionsRepo = mocksDomain.NewMockSanctionsRepository(ctrl)
+	mockSanctionsCustomerRepo = mocksDomain.NewMockCustomerRepository(ctrl)
+	mockSanctionsAccountService = mocksService.NewMockAccountService(ctrl)
+	unitOfWork := domain.NewUnitOfWorkStub(domain.Repositories{Customers: mockSanctionsCustomerRepo,
+		Sanctions: mockSanctionsRepo})
+	sanctionsSvc = NewSanctionsService(mockSanctionsRepo, mockSanctionsCustomerRepo, mockSanctionsAccountService,
+		unitOfWork, dummyWatchlist, clock.StaticClock{})
+
+	return func() {
+		mockSanctionsRepo = nil
+		mockSanctionsCustomerRepo = nil
+		mockSanctionsAccountService = nil
+		defer ctrl.Finish()
+	}
+}
+
+func getDummyTransferSanctionsHit() domain.SanctionsHit {
+	return domain.SanctionsHit{HitId: dummyHitId, CustomerId: dummyCustomerId, Kind: dto.SanctionsHitKindTransfer,
+		AccountId:            sql.NullString{String: dummyAccountId, Valid: true},
+		DestinationAccountId: sql.NullString{String: "1980", Valid: true}, Amount: 500,
+		Matches: `[{"customer_id":"3","list_id":"WL-1","name":"Viktor Ardenko","score":1}]`,
+		Status:  dto.SanctionsHitStatusPending, CreationDate: "2006-01-02 15:04:05"}
+}
+
+func TestDefaultSanctionsService_ScreenCustomers_only_screens_changedCustomers(t *testing.T) {
+	//Arrange
+	teardown := setupSanctionsServiceTest(t)
+	defer teardown()
+
+	unchanged := domain.Customer{Id: "2000", Name: "Steve", DateOfBirth: "1978-12-15"}
+	changed := domain.Customer{Id: "2001", Name: "Victor Ardenko", DateOfBirth: "1971-03-09"}
+	mockSanctionsCustomerRepo.EXPECT().FindAll("").Return([]domain.Customer{unchanged, changed}, nil)
+	mockSanctionsRepo.EXPECT().FindScreenings().Return([]domain.CustomerScreening{
+		{CustomerId: "2000", Fingerprint: dummyWatchlist.Fingerprint(unchanged)},
+		{CustomerId: "2001", Fingerprint: "outdated"},
+	}, nil)
+	mockSanctionsRepo.EXPECT().IsOnHold("2001").Return(false, nil)
+	mockSanctionsRepo.EXPECT().FindScreening("2001").Return(&domain.CustomerScreening{CustomerId: "2001", Fingerprint: "outdated"}, nil)
+	mockSanctionsRepo.EXPECT().SaveHit(gomock.Any()).DoAndReturn(func(h domain.SanctionsHit) (*domain.SanctionsHit, *errs.AppError) {
+		if h.CustomerId != "2001" || h.Kind != dto.SanctionsHitKindCustomer || !h.IsPending() {
+			t.Errorf("Expected pending hit of customer 2001 but got %+v", h)
+		}
+		return &h, nil
+	})
+	mockSanctionsRepo.EXPECT().SaveScreening(domain.CustomerScreening{CustomerId: "2001",
+		Fingerprint: dummyWatchlist.Fingerprint(changed), ScreeningDate: "2006-01-02 15:04:05"}).Return(nil)
+
+	//Act
+	err := sanctionsSvc.ScreenCustomers()
+
+	//Assert
+	if err != nil {
+		t.Error("Expected no error but got error: " + err.Message)
+	}
+}
+
+func TestDefaultSanctionsService_ScreenCustomer_doesNotSave_secondHit_when_customer_onHold(t *testing.T) {
+	//Arrange
+	teardown := setupSanctionsServiceTest(t)
+	defer teardown()
+
+	customer := domain.Customer{Id: "2001", Name: "Viktor Ardenko", DateOfBirth: "1971-03-09"}
+	mockSanctionsCustomerRepo.EXPECT().FindById("2001").Return(&customer, nil)
+	mockSanctionsRepo.EXPECT().IsOnHold("2001").Return(true, nil)
+	mockSanctionsRepo.EXPECT().FindScreening("2001").Return(nil, errs.NewNotFoundError("Customer has not been screened"))
+	mockSanctionsRepo.EXPECT().SaveHit(gomock.Any()).Times(0)
+	mockSanctionsRepo.EXPECT().SaveScreening(gomock.Any()).Return(nil)
+
+	//Act
+	response, err := sanctionsSvc.ScreenCustomer("2001")
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if !response.OnHold || len(response.Matches) != 1 || response.Matches[0].ListId != "WL-1" {
+		t.Errorf("Expected customer on hold with 1 match but got %+v", *response)
+	}
+}
+
+func TestDefaultSanctionsService_ResolveSanctionsHit_makes_transfer_when_cleared(t *testing.T) {
+	//Arrange
+	teardown := setupSanctionsServiceTest(t)
+	defer teardown()
+
+	hit := getDummyTransferSanctionsHit()
+	mockSanctionsRepo.EXPECT().FindHitById(dummyHitId).Return(&hit, nil)
+	transfer := dto.TransferRequest{AccountId: dummyAccountId, CustomerId: dummyCustomerId, DestinationAccountId: "1980",
+		Amount: 500, OverrideBeneficiaryCheck: true, SkipSanctionsScreening: true}
+	mockSanctionsAccountService.EXPECT().MakeTransfer(transfer).Return(&dto.TransactionResponse{TransactionId: dummyTransactionId}, nil)
+	mockSanctionsRepo.EXPECT().ResolveHit(gomock.Any()).DoAndReturn(func(h domain.SanctionsHit) *errs.AppError {
+		if h.Status != dto.SanctionsHitStatusCleared || h.TransactionId.String != dummyTransactionId {
+			t.Errorf("Expected cleared hit with transaction %s but got %+v", dummyTransactionId, h)
+		}
+		return nil
+	})
+
+	request := dto.ResolveSanctionsHitRequest{HitId: dummyHitId, Resolution: dto.SanctionsHitStatusCleared}
+
+	//Act
+	response, err := sanctionsSvc.ResolveSanctionsHit(request)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if response.TransactionId != dummyTransactionId || len(response.Matches) != 1 {
+		t.Errorf("Expected hit with transaction %s and 1 match but got %+v", dummyTransactionId, *response)
+	}
+}
+
+func TestDefaultSanctionsService_ResolveSanctionsHit_doesNotMake_transfer_when_confirmed(t *testing.T) {
+	//Arrange
+	teardown := setupSanctionsServiceTest(t)
+	defer teardown()
+
+	hit := getDummyTransferSanctionsHit()
+	mockSanctionsRepo.EXPECT().FindHitById(dummyHitId).Return(&hit, nil)
+	mockSanctionsAccountService.EXPECT().MakeTransfer(gomock.Any()).Times(0)
+	mockSanctionsRepo.EXPECT().ResolveHit(gomock.Any()).Return(nil)
+
+	request := dto.ResolveSanctionsHitRequest{HitId: dummyHitId, Resolution: dto.SanctionsHitStatusConfirmed}
+
+	//Act
+	response, err := sanctionsSvc.ResolveSanctionsHit(request)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if response.Status != dto.SanctionsHitStatusConfirmed || response.TransactionId != "" {
+		t.Errorf("Expected confirmed hit without transaction but got %+v", *response)
+	}
+}
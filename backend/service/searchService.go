@@ -0,0 +1,110 @@
+package service
+
+import (
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+)
+
+//go:generate mockgen -destination=../mocks/service/mock_searchService.go -package=service github.com/aliciatay-zls/banking/backend/service SearchService
+type SearchService interface { //service (primary port)
+	SearchCustomers(dto.CustomerSearchRequest) (*dto.CustomerSearchResponse, *errs.AppError)
+	SearchAccounts(dto.AccountSearchRequest) (*dto.AccountSearchResponse, *errs.AppError)
+}
+
+type DefaultSearchService struct { //business/domain object
+	repo domain.SearchRepository
+}
+
+func NewSearchService(repo domain.SearchRepository) DefaultSearchService {
+	return DefaultSearchService{repo}
+}
+
+// SearchCustomers returns a page of the customers matching the given request. If there are more, the response has
+// the cursor to request the next page with.
+func (s DefaultSearchService) SearchCustomers(request dto.CustomerSearchRequest) (*dto.CustomerSearchResponse, *errs.AppError) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	search := domain.CustomerSearch{
+		Name:    request.Name,
+		Email:   request.Email,
+		Country: request.Country,
+		Sort:    request.Sort,
+		Limit:   request.LimitOrDefault() + 1, //one more than asked for, to know whether there is a next page
+	}
+	if request.Status != "" {
+		search.Status = domain.AsStatusValue(request.Status)
+	}
+	if request.Cursor != "" {
+		cursor, err := domain.DecodeSearchCursor(request.Cursor, request.Sort)
+		if err != nil {
+			return nil, err
+		}
+		search.After = cursor
+	}
+
+	customers, err := s.repo.SearchCustomers(search)
+	if err != nil {
+		return nil, err
+	}
+
+	response := dto.CustomerSearchResponse{Customers: make([]dto.CustomerResponse, 0)}
+	if len(customers) == search.Limit {
+		customers = customers[:len(customers)-1]
+		response.NextCursor = search.NextCursor(customers[len(customers)-1]).Encode()
+	}
+	for _, c := range customers {
+		response.Customers = append(response.Customers, *c.ToDTO())
+	}
+	return &response, nil
+}
+
+// SearchAccounts returns a page of the accounts matching the given request. If there are more, the response has the
+// cursor to request the next page with.
+func (s DefaultSearchService) SearchAccounts(request dto.AccountSearchRequest) (*dto.AccountSearchResponse, *errs.AppError) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	search := domain.AccountSearch{
+		CustomerId:  request.CustomerId,
+		AccountType: request.AccountType,
+		Currency:    request.Currency,
+		Sort:        request.Sort,
+		Limit:       request.LimitOrDefault() + 1, //one more than asked for, to know whether there is a next page
+	}
+	search.MinBalance, search.MaxBalance = request.MinMaxBalance()
+	if request.Status != "" {
+		search.Status = domain.AsStatusValue(request.Status)
+	}
+	if request.OpenedFrom != "" {
+		search.OpenedFrom = request.OpenedFrom + " 00:00:00"
+	}
+	if request.OpenedTo != "" {
+		search.OpenedTo = request.OpenedTo + " 23:59:59"
+	}
+	if request.Cursor != "" {
+		cursor, err := domain.DecodeSearchCursor(request.Cursor, request.Sort)
+		if err != nil {
+			return nil, err
+		}
+		search.After = cursor
+	}
+
+	accounts, err := s.repo.SearchAccounts(search)
+	if err != nil {
+		return nil, err
+	}
+
+	response := dto.AccountSearchResponse{Accounts: make([]dto.AccountSearchResult, 0)}
+	if len(accounts) == search.Limit {
+		accounts = accounts[:len(accounts)-1]
+		response.NextCursor = search.NextCursor(accounts[len(accounts)-1]).Encode()
+	}
+	for _, a := range accounts {
+		response.Accounts = append(response.Accounts, a.ToSearchResultDTO())
+	}
+	return &response, nil
+}
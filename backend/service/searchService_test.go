@@ -0,0 +1,105 @@
+package service
+
+import (
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	mocksDomain "github.com/aliciatay-zls/banking/backend/mocks/domain"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"testing"
+)
+
+// Test common variables and inputs
+var mockSearchRepo *mocksDomain.MockSearchRepository
+var searchSvc DefaultSearchService
+
+func setupSearchServiceTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	mockSearchRepo = mocksDomain.NewMockSearchRepository(ctrl)
+	searchSvc = NewSearchService(mockSearchRepo)
+
+	return func() {
+		mockSearchRepo = nil
+		defer ctrl.Finish()
+	}
+}
+
+func TestDefaultSearchService_SearchCustomers_returns_validationError_when_request_invalid(t *testing.T) {
+	//Arrange
+	teardown := setupSearchServiceTest(t)
+	defer teardown()
+
+	mockSearchRepo.EXPECT().SearchCustomers(gomock.Any()).Times(0)
+
+	//Act
+	_, err := searchSvc.SearchCustomers(dto.CustomerSearchRequest{Sort: "password"})
+
+	//Assert
+	if err == nil || err.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected validation error but got %v", err)
+	}
+}
+
+func TestDefaultSearchService_SearchCustomers_returns_nextCursor_when_more_results(t *testing.T) {
+	//Arrange
+	teardown := setupSearchServiceTest(t)
+	defer teardown()
+
+	expectedSearch := domain.CustomerSearch{Country: "united", Status: "1", Sort: "name", Limit: 3}
+	customers := []domain.Customer{{Id: "2001", Name: "Arian"}, {Id: "2003", Name: "Ben"}, {Id: "2004", Name: "Nina"}}
+	mockSearchRepo.EXPECT().SearchCustomers(expectedSearch).Return(customers, nil)
+	expectedCursor := expectedSearch.NextCursor(customers[1]).Encode()
+
+	//Act
+	response, err := searchSvc.SearchCustomers(dto.CustomerSearchRequest{Country: "united", Status: "active",
+		Sort: "name", Limit: "2"})
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if len(response.Customers) != 2 || response.Customers[1].Id != "2003" || response.NextCursor != expectedCursor {
+		t.Errorf("Expected customers 2001 and 2003 with cursor %s but got %+v", expectedCursor, response)
+	}
+}
+
+func TestDefaultSearchService_SearchAccounts_passes_cursor_and_date_range(t *testing.T) {
+	//Arrange
+	teardown := setupSearchServiceTest(t)
+	defer teardown()
+
+	cursor := domain.SearchCursor{Sort: "-amount", Value: "7000", Id: "95472"}
+	expectedSearch := domain.AccountSearch{Currency: "USD", OpenedFrom: "2020-08-01 00:00:00",
+		OpenedTo: "2020-08-31 23:59:59", Sort: "-amount", After: &cursor, Limit: dto.SearchDefaultLimit + 1}
+	accounts := []domain.Account{{AccountId: "95473", Amount: 5861.86, Status: "1"}}
+	mockSearchRepo.EXPECT().SearchAccounts(expectedSearch).Return(accounts, nil)
+
+	//Act
+	response, err := searchSvc.SearchAccounts(dto.AccountSearchRequest{Currency: "USD", OpenedFrom: "2020-08-01",
+		OpenedTo: "2020-08-31", Sort: "-amount", Cursor: cursor.Encode()})
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if len(response.Accounts) != 1 || response.Accounts[0].Status != "active" || response.NextCursor != "" {
+		t.Errorf("Expected only active account 95473 and no cursor but got %+v", response)
+	}
+}
+
+func TestDefaultSearchService_SearchAccounts_returns_error_when_repo_fails(t *testing.T) {
+	//Arrange
+	teardown := setupSearchServiceTest(t)
+	defer teardown()
+
+	mockSearchRepo.EXPECT().SearchAccounts(gomock.Any()).Return(nil, errs.NewUnexpectedError("Unexpected database error"))
+
+	//Act
+	_, err := searchSvc.SearchAccounts(dto.AccountSearchRequest{})
+
+	//Assert
+	if err == nil || err.Code != http.StatusInternalServerError {
+		t.Errorf("Expected unexpected error but got %v", err)
+	}
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"fmt"
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"net/http"
+)
+
+//go:generate mockgen -destination=../mocks/service/mock_standingOrderService.go -package=service github.com/aliciatay-zls/banking/backend/service StandingOrderService
+type StandingOrderService interface { //service (primary port)
+	CreateStandingOrder(dto.NewStandingOrderRequest) (*dto.StandingOrderResponse, *errs.AppError)
+	GetStandingOrders(string) ([]dto.StandingOrderResponse, *errs.AppError)
+	GetStandingOrderExecutions(string, string) ([]dto.StandingOrderExecutionResponse, *errs.AppError)
+	CancelStandingOrder(string, string) (*dto.StandingOrderResponse, *errs.AppError)
+	RunDueOrders() *errs.AppError
+}
+
+type DefaultStandingOrderService struct { //business/domain object
+	repo           domain.StandingOrderRepository
+	accountRepo    domain.AccountRepository
+	accountService AccountService
+	uow            domain.UnitOfWork
+	clk            clock.Clock
+}
+
+func NewStandingOrderService(repo domain.StandingOrderRepository, accountRepo domain.AccountRepository,
+	accountService AccountService, uow domain.UnitOfWork, clk clock.Clock) DefaultStandingOrderService {
+	return DefaultStandingOrderService{repo, accountRepo, accountService, uow, clk}
+}
+
+// CreateStandingOrder checks whether the given customer holds the given account, whether the destination account
+// exists and whether the given schedule is valid. If so, it saves a new standing order that transfers the given amount
+// on that schedule.
+func (s DefaultStandingOrderService) CreateStandingOrder(request dto.NewStandingOrderRequest) (*dto.StandingOrderResponse, *errs.AppError) {
+	if err := checkHolder(s.accountRepo, request.AccountId, request.CustomerId, true); err != nil {
+		return nil, err
+	}
+	if _, err := s.accountRepo.FindById(request.DestinationAccountId); err != nil {
+		return nil, err
+	}
+
+	order, err := domain.NewStandingOrder(request, s.clk)
+	if err != nil {
+		return nil, err
+	}
+
+	newOrder, err := s.repo.Save(*order)
+	if err != nil {
+		return nil, err
+	}
+	return newOrder.ToDTO(), nil
+}
+
+func (s DefaultStandingOrderService) GetStandingOrders(customerId string) ([]dto.StandingOrderResponse, *errs.AppError) {
+	orders, err := s.repo.FindAll(customerId)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.StandingOrderResponse, 0)
+	for _, o := range orders {
+		response = append(response, *o.ToDTO())
+	}
+	return response, nil
+}
+
+// GetStandingOrderExecutions returns the execution history of the given customer's standing order.
+func (s DefaultStandingOrderService) GetStandingOrderExecutions(customerId string, orderId string) ([]dto.StandingOrderExecutionResponse, *errs.AppError) {
+	if _, err := s.findOwnOrder(customerId, orderId); err != nil {
+		return nil, err
+	}
+
+	executions, err := s.repo.FindExecutions(orderId)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.StandingOrderExecutionResponse, 0)
+	for _, e := range executions {
+		response = append(response, *e.ToDTO())
+	}
+	return response, nil
+}
+
+// CancelStandingOrder stops the given customer's standing order from running again, if it is still active.
+func (s DefaultStandingOrderService) CancelStandingOrder(customerId string, orderId string) (*dto.StandingOrderResponse, *errs.AppError) {
+	order, err := s.findOwnOrder(customerId, orderId)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != domain.StandingOrderStatusActive {
+		return nil, errs.NewConflictError("Standing order is already " + order.Status)
+	}
+
+	if err = s.repo.UpdateStatus(orderId, domain.StandingOrderStatusCancelled); err != nil {
+		return nil, err
+	}
+
+	order.Status = domain.StandingOrderStatusCancelled
+	return order.ToDTO(), nil
+}
+
+// findOwnOrder retrieves the standing order with the given id, treating it as not found if it belongs to another
+// customer, since the auth server only checks the customer ID in the route.
+func (s DefaultStandingOrderService) findOwnOrder(customerId string, orderId string) (*domain.StandingOrder, *errs.AppError) {
+	order, err := s.repo.FindById(orderId)
+	if err != nil {
+		return nil, err
+	}
+	if order.CustomerId != customerId {
+		logger.Error(fmt.Sprintf("Standing order %s does not belong to customer %s", orderId, customerId))
+		return nil, errs.NewNotFoundError("Standing order not found")
+	}
+	return order, nil
+}
+
+// RunDueOrders makes the transfer for every standing order that is due according to the clock and records each
+// attempt in the standing order's execution history. Transfers that fail due to insufficient funds are retried later,
+// while standing orders whose accounts no longer exist are marked as failed. Occurrences that were missed while the
+// job was not running are caught up on. Unexpected errors do not stop other standing orders from running, and the
+// last of them is returned.
+func (s DefaultStandingOrderService) RunDueOrders() *errs.AppError {
+	now := s.clk.Now()
+	orders, err := s.repo.FindDue(now.Format(clock.FormatDateTime))
+	if err != nil {
+		return err
+	}
+
+	var lastErr *errs.AppError
+	for _, order := range orders {
+		for order.IsDue(now) {
+			if err = s.execute(&order); err != nil {
+				lastErr = err
+				break
+			}
+		}
+	}
+	return lastErr
+}
+
+// execute makes the transfer for the given standing order's pending occurrence and saves its outcome in one unit of
+// work, so that no transfer is made without being recorded in the execution history. It then updates the given
+// standing order accordingly. A transfer that is rejected, for example because the customer is on hold, is retried
+// like one the balance is insufficient for, while one that is held by sanctions screening counts as the occurrence,
+// since it is made once an admin clears it. Either way, the outcome is saved along with any sanctions hit.
+func (s DefaultStandingOrderService) execute(order *domain.StandingOrder) *errs.AppError {
+	var executed domain.StandingOrder
+	err := s.uow.Do(func(repos domain.Repositories) *errs.AppError {
+		executed = *order //starts over if the unit of work is retried
+
+		response, err := accountServiceWithin(s.accountService, repos).MakeTransfer(executed.ToTransferRequestDTO())
+		if err != nil && err.Code != http.StatusUnprocessableEntity && err.Code != http.StatusForbidden &&
+			err.Code != http.StatusNotFound {
+			logger.Error("Error while running standing order " + executed.StandingOrderId)
+			return err
+		}
+
+		var execution domain.StandingOrderExecution
+		if err == nil {
+			execution = domain.NewStandingOrderExecution(executed, response.TransactionId, "", s.clk)
+			executed.Succeed()
+		} else {
+			execution = domain.NewStandingOrderExecution(executed, "", err.Message, s.clk)
+			if err == errTransferHeld {
+				executed.Succeed()
+			} else if err.Code == http.StatusNotFound {
+				executed.Status = domain.StandingOrderStatusFailed
+			} else if !executed.Retry(s.clk.Now()) {
+				logger.Info("Skipping occurrence of standing order " + executed.StandingOrderId + " after retries failed")
+			}
+		}
+
+		return repos.StandingOrders.SaveExecution(executed, execution)
+	})
+	if err != nil {
+		return err
+	}
+
+	*order = executed
+	return nil
+}
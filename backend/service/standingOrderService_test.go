@@ -0,0 +1,285 @@
+package service
+
+import (
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	mocksDomain "github.com/aliciatay-zls/banking/backend/mocks/domain"
+	mocksService "github.com/aliciatay-zls/banking/backend/mocks/service"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// Test common variables and inputs
+var mockStandingOrderRepo *mocksDomain.MockStandingOrderRepository
+var mockAccountService *mocksService.MockAccountService
+var standingOrderClock *dummyClock
+var standingOrderSvc DefaultStandingOrderService
+
+const dummyStandingOrderId = "5"
+const dummyDestinationAccountId = "1980"
+
+func setupStandingOrderServiceTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	mockStandingOrderRepo = mocksDomain.NewMockStandingOrderRepository(ctrl)
+	mockAccountRepo = mocksDomain.NewMockAccountRepository(ctrl)
+	mockAccountService = mocksService.NewMockAccountService(ctrl)
+	standingOrderClock = &dummyClock{time.Date(2023, 1, 1, 0, 30, 0, 0, time.UTC)}
+	unitOfWork := domain.NewUnitOfWorkStub(domain.Repositories{Accounts: mockAccountRepo, StandingOrders: mockStandingOrderRepo})
+	standingOrderSvc = NewStandingOrderService(mockStandingOrderRepo, mockAccountRepo, mockAccountService, unitOfWork,
+		standingOrderClock)
+
+	return func() {
+		mockStandingOrderRepo = nil
+		mockAccountRepo = nil
+		mockAccountService = nil
+		defer ctrl.Finish()
+	}
+}
+
+// getDummyNewStandingOrderRequest returns a dto.NewStandingOrderRequest for the customer with id 2 wanting to
+// transfer 100 from the account with id 1977 to the account with id 1980 on the 15th of every month
+func getDummyNewStandingOrderRequest() dto.NewStandingOrderRequest {
+	return dto.NewStandingOrderRequest{
+		AccountId:            dummyAccountId,
+		CustomerId:           dummyCustomerId,
+		DestinationAccountId: dummyDestinationAccountId,
+		Amount:               100,
+		ScheduleType:         dto.StandingOrderScheduleMonthly,
+		DayOfMonth:           15,
+	}
+}
+
+// useInMemoryStandingOrderRepo makes the mock repo behave like the real one for the given standing order, by keeping
+// it and its executions in memory. It returns the executions saved so far.
+func useInMemoryStandingOrderRepo(order domain.StandingOrder) *[]domain.StandingOrderExecution {
+	executions := make([]domain.StandingOrderExecution, 0)
+
+	mockStandingOrderRepo.EXPECT().FindDue(gomock.Any()).AnyTimes().DoAndReturn(
+		func(now string) ([]domain.StandingOrder, *errs.AppError) {
+			if order.Status == domain.StandingOrderStatusActive && order.NextRunDate <= now {
+				return []domain.StandingOrder{order}, nil
+			}
+			return []domain.StandingOrder{}, nil
+		})
+	mockStandingOrderRepo.EXPECT().SaveExecution(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+		func(o domain.StandingOrder, e domain.StandingOrderExecution) *errs.AppError {
+			order = o
+			executions = append(executions, e)
+			return nil
+		})
+
+	return &executions
+}
+
+func TestDefaultStandingOrderService_CreateStandingOrder_returns_error_when_destinationAccount_notFound(t *testing.T) {
+	//Arrange
+	teardown := setupStandingOrderServiceTest(t)
+	defer teardown()
+
+	expectHolder(dummyCustomerId, dto.AccountHolderRolePrimary)
+	dummyAppErr := errs.NewNotFoundError("Account not found")
+	mockAccountRepo.EXPECT().FindById(dummyDestinationAccountId).Return(nil, dummyAppErr)
+	mockStandingOrderRepo.EXPECT().Save(gomock.Any()).Times(0)
+
+	//Act
+	_, err := standingOrderSvc.CreateStandingOrder(getDummyNewStandingOrderRequest())
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing non-existent destination account")
+	}
+	if err.Code != http.StatusNotFound {
+		t.Errorf("Expected status code %d but got %d", http.StatusNotFound, err.Code)
+	}
+}
+
+func TestDefaultStandingOrderService_CreateStandingOrder_returns_newStandingOrder_when_repo_succeeds(t *testing.T) {
+	//Arrange
+	teardown := setupStandingOrderServiceTest(t)
+	defer teardown()
+
+	expectHolder(dummyCustomerId, dto.AccountHolderRolePrimary)
+	mockAccountRepo.EXPECT().FindById(dummyDestinationAccountId).Return(&domain.Account{AccountId: dummyDestinationAccountId}, nil)
+	mockStandingOrderRepo.EXPECT().Save(gomock.Any()).DoAndReturn(
+		func(o domain.StandingOrder) (*domain.StandingOrder, *errs.AppError) {
+			o.StandingOrderId = dummyStandingOrderId
+			return &o, nil
+		})
+
+	//Act
+	response, err := standingOrderSvc.CreateStandingOrder(getDummyNewStandingOrderRequest())
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing successful creation: " + err.Message)
+	}
+	if response.StandingOrderId != dummyStandingOrderId || response.NextRunDate != "2023-01-15 00:00:00" {
+		t.Errorf("Expected standing order %s first running on 15 Jan 2023 but got %+v", dummyStandingOrderId, *response)
+	}
+}
+
+func TestDefaultStandingOrderService_CancelStandingOrder_returns_notFound_when_order_belongsToOtherCustomer(t *testing.T) {
+	//Arrange
+	teardown := setupStandingOrderServiceTest(t)
+	defer teardown()
+
+	dummyOrder := domain.StandingOrder{StandingOrderId: dummyStandingOrderId, CustomerId: "3", Status: domain.StandingOrderStatusActive}
+	mockStandingOrderRepo.EXPECT().FindById(dummyStandingOrderId).Return(&dummyOrder, nil)
+	mockStandingOrderRepo.EXPECT().UpdateStatus(gomock.Any(), gomock.Any()).Times(0)
+
+	//Act
+	_, err := standingOrderSvc.CancelStandingOrder(dummyCustomerId, dummyStandingOrderId)
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing cancelling another customer's standing order")
+	}
+	if err.Code != http.StatusNotFound {
+		t.Errorf("Expected status code %d but got %d", http.StatusNotFound, err.Code)
+	}
+}
+
+func TestDefaultStandingOrderService_RunDueOrders_runsMonthly_and_retries_when_fundsInsufficient(t *testing.T) {
+	//Arrange
+	teardown := setupStandingOrderServiceTest(t)
+	defer teardown()
+
+	request := getDummyNewStandingOrderRequest()
+	request.MaxOccurrences = 3
+	order, _ := domain.NewStandingOrder(request, standingOrderClock)
+	order.StandingOrderId = dummyStandingOrderId
+	executions := useInMemoryStandingOrderRepo(*order)
+
+	attemptDates := make([]string, 0)
+	mockAccountService.EXPECT().MakeTransfer(order.ToTransferRequestDTO()).AnyTimes().DoAndReturn(
+		func(r dto.TransferRequest) (*dto.TransactionResponse, *errs.AppError) {
+			now := standingOrderClock.NowAsString()
+			attemptDates = append(attemptDates, now[:10])
+			if now >= "2023-02-01" && now < "2023-02-17" { //funds for February only arrive on 17 Feb
+				return nil, errs.NewValidationError("Account balance insufficient to transfer given amount")
+			}
+			return &dto.TransactionResponse{TransactionId: dummyTransactionId}, nil
+		})
+
+	expectedAttemptDates := []string{"2023-01-15", "2023-02-15", "2023-02-16", "2023-02-17", "2023-03-15"}
+
+	//Act
+	end := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	for standingOrderClock.now.Before(end) {
+		if err := standingOrderSvc.RunDueOrders(); err != nil {
+			t.Fatal("Expected no error but got error while running due orders: " + err.Message)
+		}
+		standingOrderClock.now = standingOrderClock.now.Add(time.Hour)
+	}
+
+	//Assert
+	if len(attemptDates) != len(expectedAttemptDates) {
+		t.Fatalf("Expected %d transfer attempts but got %d: %v", len(expectedAttemptDates), len(attemptDates), attemptDates)
+	}
+	for k := range expectedAttemptDates {
+		if attemptDates[k] != expectedAttemptDates[k] {
+			t.Errorf("Expected attempt %d on %s but got %s", k+1, expectedAttemptDates[k], attemptDates[k])
+		}
+	}
+	if len(*executions) != len(expectedAttemptDates) {
+		t.Fatalf("Expected %d executions to be recorded but got %d", len(expectedAttemptDates), len(*executions))
+	}
+	if (*executions)[1].Status != domain.ExecutionStatusFailed || (*executions)[3].Status != domain.ExecutionStatusSucceeded {
+		t.Errorf("Expected failed execution followed by successful retry but got %+v", *executions)
+	}
+	if (*executions)[3].ScheduledDate != "2023-02-15 00:00:00" {
+		t.Errorf("Expected retry to be for the occurrence scheduled on 15 Feb but got %s", (*executions)[3].ScheduledDate)
+	}
+}
+
+func TestDefaultStandingOrderService_RunDueOrders_retries_dayAfterAttempt_when_catchingUp(t *testing.T) {
+	//Arrange
+	teardown := setupStandingOrderServiceTest(t)
+	defer teardown()
+
+	order, _ := domain.NewStandingOrder(getDummyNewStandingOrderRequest(), standingOrderClock)
+	order.StandingOrderId = dummyStandingOrderId
+	executions := useInMemoryStandingOrderRepo(*order)
+
+	mockAccountService.EXPECT().MakeTransfer(order.ToTransferRequestDTO()).AnyTimes().
+		Return(nil, errs.NewValidationError("Account balance insufficient to transfer given amount"))
+
+	//Act
+	standingOrderClock.now = time.Date(2023, 1, 20, 0, 0, 0, 0, time.UTC) //job was down since the occurrence on 15 Jan
+	err := standingOrderSvc.RunDueOrders()
+	standingOrderClock.now = standingOrderClock.now.Add(domain.StandingOrderRetryInterval - time.Hour)
+	errBeforeRetry := standingOrderSvc.RunDueOrders()
+
+	//Assert
+	if err != nil || errBeforeRetry != nil {
+		t.Fatalf("Expected no error but got %v and %v while running due orders", err, errBeforeRetry)
+	}
+	if len(*executions) != 1 {
+		t.Fatalf("Expected 1 failed attempt within a day of catching up but got %d: %+v", len(*executions), *executions)
+	}
+}
+
+func TestDefaultStandingOrderService_RunDueOrders_marksOrderFailed_when_account_notFound(t *testing.T) {
+	//Arrange
+	teardown := setupStandingOrderServiceTest(t)
+	defer teardown()
+
+	order := domain.StandingOrder{StandingOrderId: dummyStandingOrderId, ScheduleType: dto.StandingOrderScheduleMonthly,
+		DayOfMonth: 1, NextRunDate: "2023-01-01 00:00:00", Status: domain.StandingOrderStatusActive}
+	mockStandingOrderRepo.EXPECT().FindDue(gomock.Any()).Return([]domain.StandingOrder{order}, nil)
+	mockAccountService.EXPECT().MakeTransfer(gomock.Any()).Return(nil, errs.NewNotFoundError("Account not found"))
+
+	var savedOrder domain.StandingOrder
+	mockStandingOrderRepo.EXPECT().SaveExecution(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(o domain.StandingOrder, e domain.StandingOrderExecution) *errs.AppError {
+			savedOrder = o
+			return nil
+		})
+
+	//Act
+	err := standingOrderSvc.RunDueOrders()
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while running due orders: " + err.Message)
+	}
+	if savedOrder.Status != domain.StandingOrderStatusFailed {
+		t.Errorf("Expected status %s but got %s", domain.StandingOrderStatusFailed, savedOrder.Status)
+	}
+}
+
+func TestDefaultStandingOrderService_RunDueOrders_recordsExecution_and_movesOn_when_transfer_heldBySanctionsScreening(t *testing.T) {
+	//Arrange
+	teardown := setupStandingOrderServiceTest(t)
+	defer teardown()
+
+	order := domain.StandingOrder{StandingOrderId: dummyStandingOrderId, ScheduleType: dto.StandingOrderScheduleMonthly,
+		DayOfMonth: 1, NextRunDate: "2023-01-01 00:00:00", Status: domain.StandingOrderStatusActive}
+	mockStandingOrderRepo.EXPECT().FindDue(gomock.Any()).Return([]domain.StandingOrder{order}, nil)
+	mockAccountService.EXPECT().MakeTransfer(gomock.Any()).Return(nil, errTransferHeld)
+
+	var savedOrder domain.StandingOrder
+	var savedExecution domain.StandingOrderExecution
+	mockStandingOrderRepo.EXPECT().SaveExecution(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(o domain.StandingOrder, e domain.StandingOrderExecution) *errs.AppError {
+			savedOrder, savedExecution = o, e
+			return nil
+		})
+
+	//Act
+	err := standingOrderSvc.RunDueOrders()
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while running due orders: " + err.Message)
+	}
+	if savedExecution.Status != domain.ExecutionStatusFailed || savedExecution.Message != errTransferHeld.Message {
+		t.Errorf("Expected failed execution with message %q but got %+v", errTransferHeld.Message, savedExecution)
+	}
+	if savedOrder.Occurrences != 1 || savedOrder.RetryCount != 0 || savedOrder.NextRunDate != "2023-02-01 00:00:00" {
+		t.Errorf("Expected order to move on to the next occurrence but got %+v", savedOrder)
+	}
+}
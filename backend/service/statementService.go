@@ -0,0 +1,111 @@
+package service
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+)
+
+//go:generate mockgen -destination=../mocks/service/mock_statementService.go -package=service github.com/aliciatay-zls/banking/backend/service StatementService
+type StatementService interface { //service (primary port)
+	GetStatement(dto.StatementRequest) (*dto.StatementResponse, *errs.AppError)
+	ExportTransactions(dto.TransactionExportRequest) (*dto.StatementResponse, *errs.AppError)
+}
+
+type DefaultStatementService struct { //business/domain object
+	accountRepo domain.AccountRepository
+	delegations domain.DelegationRepository //nil where delegations are not available
+	clk         clock.Clock
+}
+
+func NewStatementService(accountRepo domain.AccountRepository, delegations domain.DelegationRepository,
+	clk clock.Clock) DefaultStatementService {
+	return DefaultStatementService{accountRepo, delegations, clk}
+}
+
+// GetStatement renders the statement of the given account for the requested period in the requested format, as a
+// camt.053 XML document or an MT940 message.
+func (s DefaultStatementService) GetStatement(request dto.StatementRequest) (*dto.StatementResponse, *errs.AppError) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	from, to := request.Period(s.clk.Now().Format(domain.FormatDate))
+	statement, err := s.makeStatement(request.CustomerId, request.AccountId, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Format == dto.StatementFormatMt940 {
+		return &dto.StatementResponse{
+			ContentType: "text/plain; charset=us-ascii",
+			Filename:    statement.Id() + ".sta",
+			Content:     statement.ToMt940(),
+		}, nil
+	}
+	content, renderErr := statement.ToCamt053()
+	if renderErr != nil {
+		logger.Error("Error while rendering camt.053 statement: " + renderErr.Error())
+		return nil, errs.NewUnexpectedError("Unexpected server error")
+	}
+	return &dto.StatementResponse{
+		ContentType: "application/xml; charset=utf-8",
+		Filename:    statement.Id() + ".xml",
+		Content:     content,
+	}, nil
+}
+
+// ExportTransactions renders the transactions of the given account made in the requested period, along with its
+// current balance, as an OFX or QIF file for personal finance tools.
+func (s DefaultStatementService) ExportTransactions(request dto.TransactionExportRequest) (*dto.StatementResponse, *errs.AppError) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	from, to := request.Period(s.clk.Now().Format(domain.FormatDate))
+	statement, err := s.makeStatement(request.CustomerId, request.AccountId, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Format == dto.ExportFormatQif {
+		return &dto.StatementResponse{
+			ContentType: "application/qif; charset=utf-8",
+			Filename:    statement.Id() + ".qif",
+			Content:     statement.ToQif(),
+		}, nil
+	}
+	content, renderErr := statement.ToOfx()
+	if renderErr != nil {
+		logger.Error("Error while rendering OFX export: " + renderErr.Error())
+		return nil, errs.NewUnexpectedError("Unexpected server error")
+	}
+	return &dto.StatementResponse{
+		ContentType: "application/x-ofx; charset=utf-8",
+		Filename:    statement.Id() + ".ofx",
+		Content:     content,
+	}, nil
+}
+
+// makeStatement makes the statement of the given account for the given period, as long as the given customer holds
+// the account, whatever their role, or was delegated it, whatever the scope.
+func (s DefaultStatementService) makeStatement(customerId string, accountId string, from string,
+	to string) (*domain.Statement, *errs.AppError) {
+	if err := checkHolderOrDelegate(s.accountRepo, s.delegations, accountId, customerId, s.clk.NowAsString()); err != nil {
+		return nil, err
+	}
+	account, err := s.accountRepo.FindById(accountId)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions, err := s.accountRepo.FindTransactions(accountId)
+	if err != nil {
+		return nil, err
+	}
+
+	statement := domain.NewStatement(*account, transactions, from, to, s.clk.NowAsString())
+	return &statement, nil
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	mocksDomain "github.com/aliciatay-zls/banking/backend/mocks/domain"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Test common variables and inputs
+var statementSvc DefaultStatementService
+
+func setupStatementServiceTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	mockAccountRepo = mocksDomain.NewMockAccountRepository(ctrl)
+	statementSvc = NewStatementService(mockAccountRepo, nil, &dummyClock{time.Date(2023, 3, 15, 9, 0, 0, 0, time.UTC)})
+
+	return func() {
+		mockAccountRepo = nil
+		defer ctrl.Finish()
+	}
+}
+
+func TestDefaultStatementService_GetStatement_renders_current_month_when_no_period_given(t *testing.T) {
+	//Arrange
+	teardown := setupStatementServiceTest(t)
+	defer teardown()
+
+	account := domain.Account{AccountId: dummyAccountId, CustomerId: dummyCustomerId, Currency: "USD", Amount: 50}
+	mockAccountRepo.EXPECT().FindHolder(dummyAccountId, dummyCustomerId).Return(&domain.AccountHolder{
+		AccountId: dummyAccountId, CustomerId: dummyCustomerId, Role: dto.AccountHolderRoleViewOnly}, nil)
+	mockAccountRepo.EXPECT().FindById(dummyAccountId).Return(&account, nil)
+	mockAccountRepo.EXPECT().FindTransactions(dummyAccountId).Return([]domain.Transaction{
+		{TransactionId: "1", Amount: 20, TransactionType: dto.TransactionTypeDeposit, TransactionDate: "2023-02-28 10:00:00"},
+		{TransactionId: "2", Amount: 30, TransactionType: dto.TransactionTypeDeposit, TransactionDate: "2023-03-01 10:00:00"},
+	}, nil)
+
+	//Act
+	response, err := statementSvc.GetStatement(dto.StatementRequest{AccountId: dummyAccountId, CustomerId: dummyCustomerId,
+		Format: dto.StatementFormatMt940})
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	content := string(response.Content)
+	if !strings.HasSuffix(response.Filename, "-20230301-20230315.sta") || !strings.Contains(content, ":60F:C230301USD20,00") ||
+		!strings.Contains(content, ":62F:C230315USD50,00") {
+		t.Errorf("Expected MT940 statement of 2023-03-01 to 2023-03-15 but got %s: %s", response.Filename, content)
+	}
+}
+
+func TestDefaultStatementService_GetStatement_returns_error_when_account_not_customers(t *testing.T) {
+	//Arrange
+	teardown := setupStatementServiceTest(t)
+	defer teardown()
+
+	mockAccountRepo.EXPECT().FindHolder(dummyAccountId, dummyCustomerId).Return(nil,
+		errs.NewNotFoundError("Account not found"))
+	mockAccountRepo.EXPECT().FindById(gomock.Any()).Times(0)
+	mockAccountRepo.EXPECT().FindTransactions(gomock.Any()).Times(0)
+
+	//Act
+	_, err := statementSvc.GetStatement(dto.StatementRequest{AccountId: dummyAccountId, CustomerId: dummyCustomerId,
+		Format: dto.StatementFormatCamt053})
+
+	//Assert
+	if err == nil || err.Code != http.StatusNotFound {
+		t.Errorf("Expected not found error but got %v", err)
+	}
+}
+
+func TestDefaultStatementService_ExportTransactions_renders_qif_of_requested_period(t *testing.T) {
+	//Arrange
+	teardown := setupStatementServiceTest(t)
+	defer teardown()
+
+	account := domain.Account{AccountId: dummyAccountId, CustomerId: dummyCustomerId, Currency: "USD", Amount: 70}
+	mockAccountRepo.EXPECT().FindHolder(dummyAccountId, dummyCustomerId).Return(&domain.AccountHolder{
+		AccountId: dummyAccountId, CustomerId: dummyCustomerId, Role: dto.AccountHolderRoleViewOnly}, nil)
+	mockAccountRepo.EXPECT().FindById(dummyAccountId).Return(&account, nil)
+	mockAccountRepo.EXPECT().FindTransactions(dummyAccountId).Return([]domain.Transaction{
+		{TransactionId: "1", Amount: 20, TransactionType: dto.TransactionTypeWithdrawal, TransactionDate: "2023-01-10 10:00:00"},
+		{TransactionId: "2", Amount: 90, TransactionType: dto.TransactionTypeDeposit, TransactionDate: "2023-02-10 10:00:00"},
+	}, nil)
+
+	//Act
+	response, err := statementSvc.ExportTransactions(dto.TransactionExportRequest{AccountId: dummyAccountId,
+		CustomerId: dummyCustomerId, Format: dto.ExportFormatQif, From: "2023-01-01", To: "2023-01-31"})
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	content := string(response.Content)
+	if !strings.HasSuffix(response.Filename, ".qif") || !strings.Contains(content, "$70.00") ||
+		!strings.Contains(content, "T-20.00\nN1\n") || strings.Contains(content, "N2\n") {
+		t.Errorf("Expected QIF of transaction 1 and current balance but got %s: %s", response.Filename, content)
+	}
+}
+
+func TestDefaultStatementService_GetStatement_renders_statement_for_delegate(t *testing.T) {
+	//Arrange
+	ctrl := gomock.NewController(t)
+	accountRepo := mocksDomain.NewMockAccountRepository(ctrl)
+	delegationRepo := mocksDomain.NewMockDelegationRepository(ctrl)
+	svc := NewStatementService(accountRepo, delegationRepo, &dummyClock{time.Date(2023, 3, 15, 9, 0, 0, 0, time.UTC)})
+
+	account := domain.Account{AccountId: dummyAccountId, CustomerId: dummyCustomerId, Currency: "USD", Amount: 50}
+	accountRepo.EXPECT().FindHolder(dummyAccountId, "3").Return(nil, errs.NewNotFoundError("Account not found"))
+	delegationRepo.EXPECT().FindActive(dummyAccountId, "3", "2023-03-15 09:00:00").Return(&domain.Delegation{
+		DelegationId: "4", AccountId: dummyAccountId, DelegateCustomerId: "3", Scope: dto.DelegationScopeViewOnly}, nil)
+	accountRepo.EXPECT().FindById(dummyAccountId).Return(&account, nil)
+	accountRepo.EXPECT().FindTransactions(dummyAccountId).Return([]domain.Transaction{}, nil)
+
+	//Act
+	_, err := svc.GetStatement(dto.StatementRequest{AccountId: dummyAccountId, CustomerId: "3",
+		Format: dto.StatementFormatMt940})
+
+	//Assert
+	if err != nil {
+		t.Error("Expected no error but got error: " + err.Message)
+	}
+}
@@ -0,0 +1,404 @@
+package service
+
+import (
+	"errors"
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+)
+
+// importCheckpointInterval is the number of rows imported between saves of a batch's checkpoint.
+const importCheckpointInterval = 100
+
+// importMaxAllOrNothingRows is the number of rows that an all-or-nothing batch can have, since they are all posted in
+// one database transaction.
+const importMaxAllOrNothingRows = 10000
+
+//go:generate mockgen -destination=../mocks/service/mock_transactionImportService.go -package=service github.com/aliciatay-zls/banking/backend/service TransactionImportService
+type TransactionImportService interface { //service (primary port)
+	Import(dto.TransactionImportRequest, io.Reader, func(dto.ImportReportLine)) *errs.AppError
+}
+
+type DefaultTransactionImportService struct { //business/domain object
+	repo           domain.ImportRepository
+	accountService AccountService
+	uow            domain.UnitOfWork
+	clk            clock.Clock
+}
+
+func NewTransactionImportService(repo domain.ImportRepository, accountService AccountService, uow domain.UnitOfWork,
+	clk clock.Clock) DefaultTransactionImportService {
+	return DefaultTransactionImportService{repo, accountService, uow, clk}
+}
+
+// importOutcome is what happened to a row of a batch in this run. Rows that already had a result when the batch was
+// resumed are skipped.
+type importOutcome struct {
+	seq     int //position of the row in the file
+	row     dto.ImportRow
+	result  *domain.ImportRowResult
+	skipped bool
+	err     *errs.AppError //unexpected error, after which the row has no result
+}
+
+// Import posts each row of the given file as a transaction, passing each line of the report to the given function as
+// soon as it is known. The function is always called from the goroutine that called Import. An error is returned
+// instead of a report if the request is invalid or the batch cannot be started. Once the report has started, an
+// error is returned if the import stopped part way, e.g. because the file could not be read any further.
+//
+// In best-effort mode, rows are posted one by one, with up to the requested number of rows being posted at the same
+// time, and rows that are rejected are skipped. The result of each row is saved in the same unit of work as its
+// transaction, and the batch's checkpoint is saved every so often, so that resuming the batch with the same file
+// carries on where it stopped without posting any row twice. In all-or-nothing mode, every row is posted in one unit
+// of work, which is undone if any row is rejected.
+func (s DefaultTransactionImportService) Import(request dto.TransactionImportRequest, file io.Reader,
+	report func(dto.ImportReportLine)) *errs.AppError {
+	if err := request.Validate(); err != nil {
+		return err
+	}
+	reader, err := dto.NewImportRowReader(request.Format, file)
+	if err != nil {
+		return err
+	}
+	batch, err := s.startBatch(request)
+	if err != nil {
+		return err
+	}
+	batchResponse := batch.ToDTO()
+	report(dto.ImportReportLine{Batch: &batchResponse})
+
+	errorCount := 0
+	if batch.Mode == domain.ImportModeAllOrNothing {
+		err = s.importAllOrNothing(batch, reader, report)
+	} else {
+		errorCount, err = s.importBestEffort(batch, reader, request.ConcurrencyOrDefault(), report)
+	}
+	if err != nil {
+		return err
+	}
+
+	counts, err := s.repo.CountRowResults(batch.BatchId)
+	if err != nil {
+		return err
+	}
+	summary := dto.ImportSummaryResponse{
+		ImportBatchResponse: batch.ToDTO(),
+		Posted:              counts[domain.ImportRowStatusPosted],
+		Rejected:            counts[domain.ImportRowStatusRejected],
+		Errors:              errorCount,
+	}
+	report(dto.ImportReportLine{Summary: &summary})
+	return nil
+}
+
+// startBatch creates a new batch, or finds the batch to resume if the request has a batch ID.
+func (s DefaultTransactionImportService) startBatch(request dto.TransactionImportRequest) (*domain.ImportBatch, *errs.AppError) {
+	if request.BatchId == "" {
+		return s.repo.SaveBatch(domain.NewImportBatch(request.Mode, s.clk))
+	}
+
+	batch, err := s.repo.FindBatchById(request.BatchId)
+	if err != nil {
+		return nil, err
+	}
+	if batch.Mode != request.Mode {
+		return nil, errs.NewValidationError("Mode must be the same as that of the batch being resumed.")
+	}
+	if !batch.CanResume() {
+		return nil, errs.NewConflictError("Batch has already been imported.")
+	}
+	batch.Status = domain.ImportBatchStatusRunning
+	batch.UpdateDate = s.clk.NowAsString()
+	if err = s.repo.UpdateBatch(*batch); err != nil {
+		return nil, err
+	}
+	return batch, nil
+}
+
+// importBestEffort posts the rows of the given batch that have no result yet using the given number of workers, and
+// returns the number of rows that failed unexpectedly.
+func (s DefaultTransactionImportService) importBestEffort(batch *domain.ImportBatch, reader dto.ImportRowReader,
+	concurrency int, report func(dto.ImportReportLine)) (int, *errs.AppError) {
+	done := make(map[int]bool) //rows after the checkpoint that already have a result
+	results, err := s.repo.FindRowResults(batch.BatchId, batch.Checkpoint)
+	if err != nil {
+		return 0, err
+	}
+	for _, r := range results {
+		done[r.RowNumber] = true
+	}
+
+	resumeFrom := batch.Checkpoint
+	rows := make(chan importOutcome)
+	outcomes := make(chan importOutcome)
+	var readErr error
+	go func() {
+		defer close(rows)
+		for seq := 0; ; seq++ {
+			row, err := reader.Next()
+			if err != nil {
+				if !errors.Is(err, io.EOF) {
+					readErr = err
+				}
+				return
+			}
+			rows <- importOutcome{seq: seq, row: row}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for k := 0; k < concurrency; k++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for o := range rows {
+				if o.row.RowNumber <= resumeFrom || done[o.row.RowNumber] {
+					o.skipped = true
+				} else {
+					o.result, o.err = s.importRow(batch.BatchId, o.row)
+				}
+				outcomes <- o
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(outcomes)
+	}()
+
+	tracker := newCheckpointTracker(batch.Checkpoint)
+	errorCount, sinceSave := 0, 0
+	for o := range outcomes {
+		if o.err != nil {
+			errorCount++
+			result := domain.NewImportRowResult(batch.BatchId, o.row, domain.ImportRowStatusError, "", o.err.Message, s.clk)
+			rowResponse := result.ToDTO()
+			report(dto.ImportReportLine{Row: &rowResponse})
+		} else if !o.skipped {
+			rowResponse := o.result.ToDTO()
+			report(dto.ImportReportLine{Row: &rowResponse})
+		}
+
+		tracker.finish(o.seq, o.row.RowNumber, o.err == nil)
+		if sinceSave++; sinceSave == importCheckpointInterval {
+			sinceSave = 0
+			s.saveCheckpoint(batch, tracker.checkpoint)
+		}
+	}
+
+	batch.Status = domain.ImportBatchStatusCompleted
+	if errorCount > 0 || readErr != nil {
+		batch.Status = domain.ImportBatchStatusIncomplete
+	}
+	batch.Checkpoint = tracker.checkpoint
+	batch.UpdateDate = s.clk.NowAsString()
+	if err = s.repo.UpdateBatch(*batch); err != nil {
+		return errorCount, err
+	}
+	if readErr != nil {
+		logger.Error("Error while reading import file: " + readErr.Error())
+		return errorCount, errs.NewValidationError("File could not be read to the end. Resume the batch to import the rest.")
+	}
+	return errorCount, nil
+}
+
+// importRow posts the given row of the given batch and saves its result in one unit of work. Rows that are invalid,
+// or that would have been refused if posted on their own, are rejected. An error is returned if the row could not be
+// imported for any other reason, in which case it has no result.
+func (s DefaultTransactionImportService) importRow(batchId string, row dto.ImportRow) (*domain.ImportRowResult, *errs.AppError) {
+	var result domain.ImportRowResult
+	err := s.uow.Do(func(repos domain.Repositories) *errs.AppError {
+		transactionId, err := s.postRow(row, repos)
+		if err != nil && !isRowRejection(err) {
+			return err
+		}
+
+		if err == nil {
+			result = domain.NewImportRowResult(batchId, row, domain.ImportRowStatusPosted, transactionId, "", s.clk)
+		} else {
+			result = domain.NewImportRowResult(batchId, row, domain.ImportRowStatusRejected, "", err.Message, s.clk)
+		}
+		return repos.Imports.SaveRowResult(result)
+	})
+	if err != nil {
+		logger.Error("Error while importing row " + strconv.Itoa(row.RowNumber) + " of batch " + batchId)
+		return nil, err
+	}
+	return &result, nil
+}
+
+// postRow checks the given row like a transaction request made through the API, including that the customer holds the
+// account with a role that may make transactions, and posts it with the given repositories. Rows cannot override fraud
+// screening, since they are not checked one by one by an admin.
+func (s DefaultTransactionImportService) postRow(row dto.ImportRow, repos domain.Repositories) (string, *errs.AppError) {
+	if row.ReadErrorMsg != "" {
+		return "", errs.NewValidationError(row.ReadErrorMsg)
+	}
+	if row.Request.OverrideScreening {
+		return "", errs.NewValidationError("Imported transactions cannot override fraud screening.")
+	}
+	if err := row.Request.Validate(); err != nil {
+		return "", err
+	}
+	holder, err := repos.Accounts.FindHolder(row.Request.AccountId, row.Request.CustomerId)
+	if err != nil {
+		return "", err
+	}
+	if !holder.CanTransact() {
+		return "", errs.NewAuthorizationError("View-only holders cannot make transactions")
+	}
+
+	response, err := accountServiceWithin(s.accountService, repos).MakeTransaction(row.Request)
+	if err != nil {
+		return "", err
+	}
+	return response.TransactionId, nil
+}
+
+// isRowRejection checks whether the given error from posting a row means that the row is rejected, because it is
+// invalid, refers to something that does not exist or is not allowed, rather than that it could not be imported.
+func isRowRejection(err *errs.AppError) bool {
+	return err.Code == http.StatusUnprocessableEntity || err.Code == http.StatusNotFound || err.Code == http.StatusForbidden
+}
+
+// saveCheckpoint saves the given checkpoint of the given batch. Failing to do so only means that a resumed batch
+// starts further back, so the import carries on.
+func (s DefaultTransactionImportService) saveCheckpoint(batch *domain.ImportBatch, checkpoint int) {
+	batch.Checkpoint = checkpoint
+	batch.UpdateDate = s.clk.NowAsString()
+	if err := s.repo.UpdateBatch(*batch); err != nil {
+		logger.Error("Error while saving checkpoint of import batch " + batch.BatchId)
+	}
+}
+
+// importAllOrNothing reads every row of the given batch and posts them all in one unit of work. If any row is
+// rejected, nothing is posted, and the report has the reasons that rows were rejected. Either way, the results are
+// only reported once the unit of work is over.
+func (s DefaultTransactionImportService) importAllOrNothing(batch *domain.ImportBatch, reader dto.ImportRowReader,
+	report func(dto.ImportReportLine)) *errs.AppError {
+	rows := make([]dto.ImportRow, 0)
+	for {
+		row, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			logger.Error("Error while reading import file: " + err.Error())
+			return s.failBatch(batch, errs.NewValidationError("File could not be read to the end, so nothing was posted."))
+		}
+		if len(rows) == importMaxAllOrNothingRows {
+			return s.failBatch(batch, errs.NewValidationError("File has too many rows to be imported all or nothing."))
+		}
+		rows = append(rows, row)
+	}
+
+	var results []domain.ImportRowResult
+	errRejected := errs.NewValidationError("Some rows were rejected, so nothing was posted.")
+	err := s.uow.Do(func(repos domain.Repositories) *errs.AppError {
+		results = make([]domain.ImportRowResult, 0) //starts over if the unit of work is retried
+		rejected := false
+		for _, row := range rows {
+			transactionId, err := s.postRow(row, repos)
+			if err != nil && !isRowRejection(err) {
+				return err
+			}
+			if err != nil {
+				rejected = true
+				results = append(results, domain.NewImportRowResult(batch.BatchId, row, domain.ImportRowStatusRejected,
+					"", err.Message, s.clk))
+			} else {
+				results = append(results, domain.NewImportRowResult(batch.BatchId, row, domain.ImportRowStatusPosted,
+					transactionId, "", s.clk))
+			}
+		}
+		if rejected {
+			return errRejected
+		}
+
+		for _, result := range results {
+			if err := repos.Imports.SaveRowResult(result); err != nil {
+				return err
+			}
+		}
+		batch.Status = domain.ImportBatchStatusCompleted
+		if len(rows) > 0 {
+			batch.Checkpoint = rows[len(rows)-1].RowNumber
+		}
+		batch.UpdateDate = s.clk.NowAsString()
+		return repos.Imports.UpdateBatch(*batch)
+	})
+	if err != nil && err != errRejected {
+		return s.failBatch(batch, err)
+	}
+	rejected := err == errRejected
+
+	for _, result := range results {
+		if rejected && result.Status == domain.ImportRowStatusPosted {
+			result.Status = domain.ImportRowStatusNotPosted
+			result.TransactionId.Valid, result.TransactionId.String = false, ""
+		}
+		rowResponse := result.ToDTO()
+		report(dto.ImportReportLine{Row: &rowResponse})
+	}
+	if rejected {
+		return s.failBatch(batch, nil)
+	}
+	return nil
+}
+
+// failBatch marks the given all-or-nothing batch as failed, since nothing was posted, and returns the given error.
+func (s DefaultTransactionImportService) failBatch(batch *domain.ImportBatch, err *errs.AppError) *errs.AppError {
+	batch.Status = domain.ImportBatchStatusFailed
+	batch.UpdateDate = s.clk.NowAsString()
+	if updateErr := s.repo.UpdateBatch(*batch); updateErr != nil {
+		return updateErr
+	}
+	return err
+}
+
+// checkpointTracker works out the checkpoint of a batch whose rows finish in any order. The checkpoint moves up to a
+// row once it and every row before it in the file have finished, and stops for good at a row that failed, so that
+// the row is tried again when the batch is resumed.
+type checkpointTracker struct {
+	checkpoint int
+	next       int                //position of the first row that has not finished
+	finished   map[int]importStep //rows after the next one that have finished, by position
+	stopped    bool
+}
+
+type importStep struct {
+	rowNumber int
+	ok        bool
+}
+
+func newCheckpointTracker(checkpoint int) *checkpointTracker {
+	return &checkpointTracker{checkpoint: checkpoint, finished: make(map[int]importStep)}
+}
+
+func (t *checkpointTracker) finish(seq int, rowNumber int, ok bool) {
+	if t.stopped {
+		return
+	}
+	t.finished[seq] = importStep{rowNumber, ok}
+	for {
+		step, found := t.finished[t.next]
+		if !found {
+			return
+		}
+		delete(t.finished, t.next)
+		t.next++
+		if !step.ok {
+			t.stopped = true
+			return
+		}
+		if step.rowNumber > t.checkpoint {
+			t.checkpoint = step.rowNumber
+		}
+	}
+}
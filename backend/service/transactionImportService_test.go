@@ -0,0 +1,272 @@
+package service
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	mocksDomain "github.com/aliciatay-zls/banking/backend/mocks/domain"
+	mocksService "github.com/aliciatay-zls/banking/backend/mocks/service"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// Test common variables and inputs
+var mockImportRepo *mocksDomain.MockImportRepository
+var importSvc DefaultTransactionImportService
+
+const dummyImportFile = "account_id,amount,transaction_type,customer_id\n" +
+	"1977,100,deposit,2\n" + //posted
+	"1977,-5,deposit,2\n" + //invalid amount
+	"1980,100,deposit,2\n" //account of another customer
+
+func setupTransactionImportServiceTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	mockImportRepo = mocksDomain.NewMockImportRepository(ctrl)
+	mockAccountRepo = mocksDomain.NewMockAccountRepository(ctrl)
+	mockAccountService = mocksService.NewMockAccountService(ctrl)
+	unitOfWork := domain.NewUnitOfWorkStub(domain.Repositories{Accounts: mockAccountRepo, Imports: mockImportRepo})
+	importSvc = NewTransactionImportService(mockImportRepo, mockAccountService, unitOfWork, clock.StaticClock{})
+
+	mockAccountRepo.EXPECT().FindHolder("1977", "2").AnyTimes().Return(&domain.AccountHolder{AccountId: "1977",
+		CustomerId: "2", Role: dto.AccountHolderRolePrimary}, nil)
+	mockAccountRepo.EXPECT().FindHolder("1980", "2").AnyTimes().Return(nil, errs.NewNotFoundError("Account not found"))
+
+	return func() {
+		mockImportRepo = nil
+		mockAccountRepo = nil
+		mockAccountService = nil
+		defer ctrl.Finish()
+	}
+}
+
+// runDummyImport imports the given file with the given request, returning the report's rows by row number and its
+// summary.
+func runDummyImport(request dto.TransactionImportRequest, file string) (map[int]dto.ImportRowResponse,
+	*dto.ImportSummaryResponse, *errs.AppError) {
+	rows := make(map[int]dto.ImportRowResponse)
+	var summary *dto.ImportSummaryResponse
+	err := importSvc.Import(request, strings.NewReader(file), func(line dto.ImportReportLine) {
+		if line.Row != nil {
+			rows[line.Row.RowNumber] = *line.Row
+		}
+		if line.Summary != nil {
+			summary = line.Summary
+		}
+	})
+	return rows, summary, err
+}
+
+func TestDefaultTransactionImportService_Import_bestEffort_posts_valid_rows_and_rejects_others(t *testing.T) {
+	//Arrange
+	teardown := setupTransactionImportServiceTest(t)
+	defer teardown()
+
+	mockImportRepo.EXPECT().SaveBatch(gomock.Any()).Return(&domain.ImportBatch{BatchId: "7", Mode: domain.ImportModeBestEffort}, nil)
+	mockImportRepo.EXPECT().FindRowResults("7", 0).Return(nil, nil)
+	mockAccountService.EXPECT().MakeTransaction(dto.TransactionRequest{AccountId: "1977", Amount: 100,
+		TransactionType: "deposit", CustomerId: "2"}).Return(&dto.TransactionResponse{TransactionId: "51"}, nil)
+	mockImportRepo.EXPECT().SaveRowResult(gomock.Any()).Times(3)
+	mockImportRepo.EXPECT().UpdateBatch(gomock.Any()).DoAndReturn(func(b domain.ImportBatch) *errs.AppError {
+		if b.Status != domain.ImportBatchStatusCompleted || b.Checkpoint != 4 {
+			t.Errorf("Expected completed batch with checkpoint 4 but got %+v", b)
+		}
+		return nil
+	})
+	mockImportRepo.EXPECT().CountRowResults("7").Return(map[string]int{"posted": 1, "rejected": 2}, nil)
+
+	//Act
+	rows, summary, err := runDummyImport(dto.TransactionImportRequest{Mode: domain.ImportModeBestEffort,
+		Format: dto.ImportFormatCsv}, dummyImportFile)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if rows[2].Status != "posted" || rows[2].TransactionId != "51" || rows[3].Status != "rejected" ||
+		rows[4].Message != "Account not found" {
+		t.Errorf("Expected row 2 posted and rows 3 and 4 rejected but got %+v", rows)
+	}
+	if summary == nil || summary.Posted != 1 || summary.Rejected != 2 || summary.Errors != 0 {
+		t.Errorf("Expected summary of 1 posted and 2 rejected but got %+v", summary)
+	}
+}
+
+func TestDefaultTransactionImportService_Import_bestEffort_rejects_rows_that_are_not_allowed(t *testing.T) {
+	//Arrange
+	teardown := setupTransactionImportServiceTest(t)
+	defer teardown()
+
+	file := "account_id,amount,transaction_type,customer_id\n" +
+		"1977,100,deposit,3\n" + //view-only holder
+		"1977,50,withdrawal,2\n" //customer on hold
+	mockAccountRepo.EXPECT().FindHolder("1977", "3").Return(&domain.AccountHolder{AccountId: "1977", CustomerId: "3",
+		Role: dto.AccountHolderRoleViewOnly}, nil)
+	mockImportRepo.EXPECT().SaveBatch(gomock.Any()).Return(&domain.ImportBatch{BatchId: "7", Mode: domain.ImportModeBestEffort}, nil)
+	mockImportRepo.EXPECT().FindRowResults("7", 0).Return(nil, nil)
+	mockAccountService.EXPECT().MakeTransaction(dto.TransactionRequest{AccountId: "1977", Amount: 50,
+		TransactionType: "withdrawal", CustomerId: "2"}).Return(nil, errCustomerOnHold)
+	mockImportRepo.EXPECT().SaveRowResult(gomock.Any()).Times(2)
+	mockImportRepo.EXPECT().UpdateBatch(gomock.Any())
+	mockImportRepo.EXPECT().CountRowResults("7").Return(map[string]int{"rejected": 2}, nil)
+
+	//Act
+	rows, summary, err := runDummyImport(dto.TransactionImportRequest{Mode: domain.ImportModeBestEffort,
+		Format: dto.ImportFormatCsv}, file)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if rows[2].Status != domain.ImportRowStatusRejected || rows[3].Status != domain.ImportRowStatusRejected ||
+		rows[3].Message != errCustomerOnHold.Message {
+		t.Errorf("Expected rows 2 and 3 rejected but got %+v", rows)
+	}
+	if summary == nil || summary.Rejected != 2 || summary.Errors != 0 {
+		t.Errorf("Expected summary of 2 rejected but got %+v", summary)
+	}
+}
+
+func TestDefaultTransactionImportService_Import_bestEffort_rejects_rows_that_override_screening(t *testing.T) {
+	//Arrange
+	teardown := setupTransactionImportServiceTest(t)
+	defer teardown()
+
+	file := `{"account_id": "1977", "amount": 100, "transaction_type": "withdrawal", "customer_id": "2", ` +
+		`"override_screening": true}` + "\n"
+	mockImportRepo.EXPECT().SaveBatch(gomock.Any()).Return(&domain.ImportBatch{BatchId: "7", Mode: domain.ImportModeBestEffort}, nil)
+	mockImportRepo.EXPECT().FindRowResults("7", 0).Return(nil, nil)
+	mockAccountService.EXPECT().MakeTransaction(gomock.Any()).Times(0)
+	mockImportRepo.EXPECT().SaveRowResult(gomock.Any())
+	mockImportRepo.EXPECT().UpdateBatch(gomock.Any())
+	mockImportRepo.EXPECT().CountRowResults("7").Return(map[string]int{"rejected": 1}, nil)
+
+	//Act
+	rows, _, err := runDummyImport(dto.TransactionImportRequest{Mode: domain.ImportModeBestEffort,
+		Format: dto.ImportFormatJsonl}, file)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if rows[1].Status != domain.ImportRowStatusRejected {
+		t.Errorf("Expected row 1 rejected but got %+v", rows)
+	}
+}
+
+func TestDefaultTransactionImportService_Import_bestEffort_resumes_after_rows_with_results(t *testing.T) {
+	//Arrange
+	teardown := setupTransactionImportServiceTest(t)
+	defer teardown()
+
+	batch := domain.ImportBatch{BatchId: "7", Mode: domain.ImportModeBestEffort, Status: "incomplete", Checkpoint: 2}
+	mockImportRepo.EXPECT().FindBatchById("7").Return(&batch, nil)
+	mockImportRepo.EXPECT().FindRowResults("7", 2).Return([]domain.ImportRowResult{{RowNumber: 4}}, nil)
+	mockImportRepo.EXPECT().SaveRowResult(gomock.Any()).Return(errs.NewUnexpectedError("Unexpected database error"))
+	gomock.InOrder(
+		mockImportRepo.EXPECT().UpdateBatch(gomock.Any()),
+		mockImportRepo.EXPECT().UpdateBatch(gomock.Any()).DoAndReturn(func(b domain.ImportBatch) *errs.AppError {
+			if b.Status != domain.ImportBatchStatusIncomplete || b.Checkpoint != 2 {
+				t.Errorf("Expected incomplete batch still at checkpoint 2 but got %+v", b)
+			}
+			return nil
+		}),
+	)
+	mockImportRepo.EXPECT().CountRowResults("7").Return(map[string]int{"posted": 1, "rejected": 1}, nil)
+
+	//Act
+	rows, summary, err := runDummyImport(dto.TransactionImportRequest{BatchId: "7", Mode: domain.ImportModeBestEffort,
+		Format: dto.ImportFormatCsv, Concurrency: "1"}, dummyImportFile)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if len(rows) != 1 || rows[3].Status != domain.ImportRowStatusError || summary.Errors != 1 {
+		t.Errorf("Expected only row 3 to be tried and fail but got %+v and %+v", rows, summary)
+	}
+}
+
+func TestDefaultTransactionImportService_Import_allOrNothing_posts_nothing_when_row_rejected(t *testing.T) {
+	//Arrange
+	teardown := setupTransactionImportServiceTest(t)
+	defer teardown()
+
+	mockImportRepo.EXPECT().SaveBatch(gomock.Any()).Return(&domain.ImportBatch{BatchId: "8", Mode: domain.ImportModeAllOrNothing}, nil)
+	mockAccountService.EXPECT().MakeTransaction(gomock.Any()).Return(&dto.TransactionResponse{TransactionId: "51"}, nil)
+	mockImportRepo.EXPECT().SaveRowResult(gomock.Any()).Times(0)
+	mockImportRepo.EXPECT().UpdateBatch(gomock.Any()).DoAndReturn(func(b domain.ImportBatch) *errs.AppError {
+		if b.Status != domain.ImportBatchStatusFailed {
+			t.Errorf("Expected failed batch but got %+v", b)
+		}
+		return nil
+	})
+	mockImportRepo.EXPECT().CountRowResults("8").Return(map[string]int{}, nil)
+
+	//Act
+	rows, summary, err := runDummyImport(dto.TransactionImportRequest{Mode: domain.ImportModeAllOrNothing,
+		Format: dto.ImportFormatCsv}, dummyImportFile)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if rows[2].Status != domain.ImportRowStatusNotPosted || rows[2].TransactionId != "" || rows[3].Status != "rejected" ||
+		rows[4].Status != "rejected" {
+		t.Errorf("Expected row 2 not posted and rows 3 and 4 rejected but got %+v", rows)
+	}
+	if summary.Status != domain.ImportBatchStatusFailed || summary.Posted != 0 {
+		t.Errorf("Expected failed batch with nothing posted but got %+v", summary)
+	}
+}
+
+func TestDefaultTransactionImportService_Import_returns_error_when_batch_cannot_be_resumed(t *testing.T) {
+	tests := []struct {
+		name         string
+		batch        domain.ImportBatch
+		expectedCode int
+	}{
+		{"completed", domain.ImportBatch{Mode: domain.ImportModeBestEffort, Status: "completed"}, http.StatusConflict},
+		{"other mode", domain.ImportBatch{Mode: domain.ImportModeAllOrNothing, Status: "failed"}, http.StatusUnprocessableEntity},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Arrange
+			teardown := setupTransactionImportServiceTest(t)
+			defer teardown()
+
+			mockImportRepo.EXPECT().FindBatchById("7").Return(&tc.batch, nil)
+			mockImportRepo.EXPECT().UpdateBatch(gomock.Any()).Times(0)
+
+			//Act
+			_, _, err := runDummyImport(dto.TransactionImportRequest{BatchId: "7", Mode: domain.ImportModeBestEffort,
+				Format: dto.ImportFormatCsv}, dummyImportFile)
+
+			//Assert
+			if err == nil || err.Code != tc.expectedCode {
+				t.Errorf("Expected error with code %d but got %v", tc.expectedCode, err)
+			}
+		})
+	}
+}
+
+func TestCheckpointTracker_finish_moves_checkpoint_over_finished_rows_only(t *testing.T) {
+	//Arrange
+	tracker := newCheckpointTracker(0)
+
+	//Act
+	tracker.finish(1, 3, true) //row 3 finishes before row 2
+	afterOutOfOrder := tracker.checkpoint
+	tracker.finish(0, 2, true)
+	afterInOrder := tracker.checkpoint
+	tracker.finish(2, 4, false)
+	tracker.finish(3, 5, true)
+
+	//Assert
+	if afterOutOfOrder != 0 || afterInOrder != 3 || tracker.checkpoint != 3 {
+		t.Errorf("Expected checkpoints 0, 3 and 3 but got %d, %d and %d", afterOutOfOrder, afterInOrder, tracker.checkpoint)
+	}
+}
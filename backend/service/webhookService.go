@@ -0,0 +1,243 @@
+package service
+
+import (
+	"fmt"
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"net/http"
+	"strconv"
+)
+
+// webhookBatchSize is the maximum number of webhook deliveries that are attempted each time the job runs.
+const webhookBatchSize = 100
+
+//go:generate mockgen -destination=../mocks/service/mock_webhookService.go -package=service github.com/aliciatay-zls/banking/backend/service WebhookService
+type WebhookService interface { //service (primary port)
+	CreateWebhook(dto.NewWebhookRequest) (*dto.WebhookResponse, *errs.AppError)
+	GetWebhooks(string) ([]dto.WebhookResponse, *errs.AppError)
+	DisableWebhook(string, string) (*dto.WebhookResponse, *errs.AppError)
+	GetWebhookDeliveries(string, string) ([]dto.WebhookDeliveryResponse, *errs.AppError)
+	ReplayDelivery(string, string, string) (*dto.WebhookDeliveryResponse, *errs.AppError)
+	Publish(domain.Event) *errs.AppError
+	DeliverDue() *errs.AppError
+}
+
+type DefaultWebhookService struct { //business/domain object
+	repo        domain.WebhookRepository
+	accountRepo domain.AccountRepository
+	sender      domain.WebhookSender
+	clk         clock.Clock
+}
+
+func NewWebhookService(repo domain.WebhookRepository, accountRepo domain.AccountRepository, sender domain.WebhookSender,
+	clk clock.Clock) DefaultWebhookService {
+	return DefaultWebhookService{repo, accountRepo, sender, clk}
+}
+
+// CreateWebhook saves a new subscription that delivers the given customer's events of the given types to the given
+// URL, signed with the given secret.
+func (s DefaultWebhookService) CreateWebhook(request dto.NewWebhookRequest) (*dto.WebhookResponse, *errs.AppError) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	subscription, err := s.repo.SaveSubscription(domain.NewWebhookSubscription(request, s.clk))
+	if err != nil {
+		return nil, err
+	}
+	return subscription.ToDTO(), nil
+}
+
+func (s DefaultWebhookService) GetWebhooks(customerId string) ([]dto.WebhookResponse, *errs.AppError) {
+	subscriptions, err := s.repo.FindSubscriptions(customerId)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.WebhookResponse, 0)
+	for _, sub := range subscriptions {
+		response = append(response, *sub.ToDTO())
+	}
+	return response, nil
+}
+
+// DisableWebhook stops events from being delivered to the given customer's webhook, if it is still active. Pending
+// deliveries are given up on when they are next due.
+func (s DefaultWebhookService) DisableWebhook(customerId string, webhookId string) (*dto.WebhookResponse, *errs.AppError) {
+	subscription, err := s.findOwnSubscription(customerId, webhookId)
+	if err != nil {
+		return nil, err
+	}
+	if subscription.Status != domain.WebhookStatusActive {
+		return nil, errs.NewConflictError("Webhook is already " + subscription.Status)
+	}
+
+	if err = s.repo.UpdateSubscriptionStatus(webhookId, domain.WebhookStatusDisabled); err != nil {
+		return nil, err
+	}
+
+	subscription.Status = domain.WebhookStatusDisabled
+	return subscription.ToDTO(), nil
+}
+
+// GetWebhookDeliveries returns the delivery log of the given customer's webhook.
+func (s DefaultWebhookService) GetWebhookDeliveries(customerId string, webhookId string) ([]dto.WebhookDeliveryResponse, *errs.AppError) {
+	if _, err := s.findOwnSubscription(customerId, webhookId); err != nil {
+		return nil, err
+	}
+
+	deliveries, err := s.repo.FindDeliveries(webhookId)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.WebhookDeliveryResponse, 0)
+	for _, d := range deliveries {
+		response = append(response, *d.ToDTO())
+	}
+	return response, nil
+}
+
+// ReplayDelivery schedules a delivery of the given customer's active webhook that was delivered or given up on to be
+// attempted again by the next run of the job.
+func (s DefaultWebhookService) ReplayDelivery(customerId string, webhookId string, deliveryId string) (*dto.WebhookDeliveryResponse, *errs.AppError) {
+	subscription, err := s.findOwnSubscription(customerId, webhookId)
+	if err != nil {
+		return nil, err
+	}
+	if subscription.Status != domain.WebhookStatusActive {
+		return nil, errs.NewConflictError("Webhook is " + subscription.Status)
+	}
+
+	delivery, err := s.repo.FindDeliveryById(deliveryId)
+	if err != nil {
+		return nil, err
+	}
+	if delivery.SubscriptionId != webhookId {
+		logger.Error(fmt.Sprintf("Delivery %s does not belong to webhook %s", deliveryId, webhookId))
+		return nil, errs.NewNotFoundError("Delivery not found")
+	}
+
+	if err = delivery.Replay(s.clk); err != nil {
+		return nil, err
+	}
+	if err = s.repo.UpdateDelivery(*delivery); err != nil {
+		return nil, err
+	}
+	return delivery.ToDTO(), nil
+}
+
+// findOwnSubscription retrieves the webhook subscription with the given id, treating it as not found if it belongs to
+// another customer, since the auth server only checks the customer ID in the route.
+func (s DefaultWebhookService) findOwnSubscription(customerId string, webhookId string) (*domain.WebhookSubscription, *errs.AppError) {
+	subscription, err := s.repo.FindSubscriptionById(webhookId)
+	if err != nil {
+		return nil, err
+	}
+	if subscription.CustomerId != customerId {
+		logger.Error(fmt.Sprintf("Webhook %s does not belong to customer %s", webhookId, customerId))
+		return nil, errs.NewNotFoundError("Webhook not found")
+	}
+	return subscription, nil
+}
+
+// Publish queues a delivery of the given event to each active webhook of the customer it concerns that accepts its
+// type. It is one of the publishers of the outbox relay, so the event may be published more than once, in which case
+// the deliveries already queued are left as they are. Events of accounts that no longer exist are skipped.
+func (s DefaultWebhookService) Publish(event domain.Event) *errs.AppError {
+	customerId := event.AggregateId
+	if event.AggregateType == domain.AggregateAccount {
+		account, err := s.accountRepo.FindById(event.AggregateId)
+		if err != nil {
+			if err.Code == http.StatusNotFound {
+				logger.Info("Skipping webhooks for event " + event.EventId + " of missing account")
+				return nil
+			}
+			return err
+		}
+		customerId = account.CustomerId
+	}
+
+	subscriptions, err := s.repo.FindSubscriptions(customerId)
+	if err != nil {
+		return err
+	}
+
+	deliveries := make([]domain.WebhookDelivery, 0)
+	for _, sub := range subscriptions {
+		if !sub.Accepts(event.EventType) {
+			continue
+		}
+		delivery, err := domain.NewWebhookDelivery(sub, event, s.clk)
+		if err != nil {
+			return err
+		}
+		deliveries = append(deliveries, *delivery)
+	}
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return s.repo.SaveDeliveries(deliveries)
+}
+
+// DeliverDue attempts every webhook delivery that is due according to the clock and records the outcome of each
+// attempt in the delivery log. Deliveries to webhooks that have been disabled since they were queued are given up
+// on. Unexpected errors do not stop other deliveries from being attempted, and the last of them is returned.
+func (s DefaultWebhookService) DeliverDue() *errs.AppError {
+	deliveries, err := s.repo.FindDueDeliveries(s.clk.NowAsString(), webhookBatchSize)
+	if err != nil {
+		return err
+	}
+
+	var lastErr *errs.AppError
+	subscriptions := make(map[string]*domain.WebhookSubscription)
+	for _, delivery := range deliveries {
+		subscription, ok := subscriptions[delivery.SubscriptionId]
+		if !ok {
+			if subscription, err = s.repo.FindSubscriptionById(delivery.SubscriptionId); err != nil {
+				lastErr = err
+				continue
+			}
+			subscriptions[delivery.SubscriptionId] = subscription
+		}
+
+		if err = s.deliver(*subscription, &delivery); err != nil {
+			lastErr = err
+		}
+	}
+	return lastErr
+}
+
+// deliver makes one attempt at sending the given delivery to the given subscription and saves its outcome.
+func (s DefaultWebhookService) deliver(subscription domain.WebhookSubscription, delivery *domain.WebhookDelivery) *errs.AppError {
+	if subscription.Status != domain.WebhookStatusActive {
+		delivery.Status = domain.DeliveryStatusDead
+		delivery.LastError.String, delivery.LastError.Valid = "Webhook is "+subscription.Status, true
+		return s.repo.UpdateDelivery(*delivery)
+	}
+
+	timestamp := strconv.FormatInt(s.clk.Now().Unix(), 10)
+	headers := map[string]string{
+		domain.WebhookHeaderDeliveryId: delivery.DeliveryId,
+		domain.WebhookHeaderEvent:      delivery.EventType,
+		domain.WebhookHeaderTimestamp:  timestamp,
+		domain.WebhookHeaderSignature:  domain.SignWebhook(subscription.Secret, timestamp, delivery.Payload),
+	}
+
+	code, err := s.sender.Send(subscription.Url, delivery.Payload, headers)
+	errMessage := ""
+	if err != nil {
+		errMessage = err.Message
+	} else if code < 200 || code >= 300 {
+		errMessage = fmt.Sprintf("Webhook responded with status %d", code)
+	}
+	delivery.RecordAttempt(code, errMessage, s.clk)
+	if delivery.Status == domain.DeliveryStatusDead {
+		logger.Info(fmt.Sprintf("Giving up on webhook delivery %s after %d attempts", delivery.DeliveryId, delivery.Attempts))
+	}
+
+	return s.repo.UpdateDelivery(*delivery)
+}
@@ -0,0 +1,233 @@
+package service
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	mocksDomain "github.com/aliciatay-zls/banking/backend/mocks/domain"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"testing"
+)
+
+// Test common variables and inputs
+var mockWebhookRepo *mocksDomain.MockWebhookRepository
+var mockWebhookSender *mocksDomain.MockWebhookSender
+var webhookSvc DefaultWebhookService
+
+const dummyWebhookId = "5"
+const dummyDeliveryId = "8"
+const dummyWebhookSecret = "0123456789abcdef"
+
+func setupWebhookServiceTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	mockWebhookRepo = mocksDomain.NewMockWebhookRepository(ctrl)
+	mockAccountRepo = mocksDomain.NewMockAccountRepository(ctrl)
+	mockWebhookSender = mocksDomain.NewMockWebhookSender(ctrl)
+	webhookSvc = NewWebhookService(mockWebhookRepo, mockAccountRepo, mockWebhookSender, clock.StaticClock{})
+
+	return func() {
+		mockWebhookRepo = nil
+		mockAccountRepo = nil
+		mockWebhookSender = nil
+		defer ctrl.Finish()
+	}
+}
+
+func getDummySubscription(url string) *domain.WebhookSubscription {
+	return &domain.WebhookSubscription{SubscriptionId: dummyWebhookId, CustomerId: dummyCustomerId, Url: url,
+		Secret: dummyWebhookSecret, Status: domain.WebhookStatusActive}
+}
+
+func getDummyDelivery() domain.WebhookDelivery {
+	delivery, _ := domain.NewWebhookDelivery(*getDummySubscription(""), getDummyEvent("1", dummyAccountId), clock.StaticClock{})
+	delivery.DeliveryId = dummyDeliveryId
+	return *delivery
+}
+
+func TestDefaultWebhookService_Publish_queues_deliveries_for_accepting_webhooks_of_accountOwner(t *testing.T) {
+	//Arrange
+	teardown := setupWebhookServiceTest(t)
+	defer teardown()
+
+	event := getDummyEvent("1", dummyAccountId)
+	accepting := *getDummySubscription("https://example.com/hooks")
+	other := accepting
+	other.SubscriptionId, other.EventTypes = "6", domain.EventAccountOpened
+	mockAccountRepo.EXPECT().FindById(dummyAccountId).Return(&domain.Account{AccountId: dummyAccountId, CustomerId: dummyCustomerId}, nil)
+	mockWebhookRepo.EXPECT().FindSubscriptions(dummyCustomerId).Return([]domain.WebhookSubscription{accepting, other}, nil)
+	mockWebhookRepo.EXPECT().SaveDeliveries(gomock.Any()).DoAndReturn(func(deliveries []domain.WebhookDelivery) *errs.AppError {
+		if len(deliveries) != 1 || deliveries[0].SubscriptionId != dummyWebhookId || deliveries[0].EventId != "1" {
+			t.Errorf("Expected one delivery of event 1 to webhook %s but got %+v", dummyWebhookId, deliveries)
+		}
+		return nil
+	})
+
+	//Act
+	err := webhookSvc.Publish(event)
+
+	//Assert
+	if err != nil {
+		t.Error("Expected no error but got error: " + err.Message)
+	}
+}
+
+func TestDefaultWebhookService_Publish_skips_event_when_account_missing(t *testing.T) {
+	//Arrange
+	teardown := setupWebhookServiceTest(t)
+	defer teardown()
+
+	logger.MuteLogger()
+	mockAccountRepo.EXPECT().FindById(dummyAccountId).Return(nil, errs.NewNotFoundError("Account not found"))
+
+	//Act
+	err := webhookSvc.Publish(getDummyEvent("1", dummyAccountId))
+
+	//Assert
+	if err != nil {
+		t.Error("Expected no error but got error: " + err.Message)
+	}
+}
+
+func TestDefaultWebhookService_DisableWebhook_returns_notFoundError_when_webhook_of_otherCustomer(t *testing.T) {
+	//Arrange
+	teardown := setupWebhookServiceTest(t)
+	defer teardown()
+
+	logger.MuteLogger()
+	mockWebhookRepo.EXPECT().FindSubscriptionById(dummyWebhookId).Return(getDummySubscription(""), nil)
+
+	//Act
+	_, err := webhookSvc.DisableWebhook("1000", dummyWebhookId)
+
+	//Assert
+	if err == nil || err.Code != http.StatusNotFound {
+		t.Errorf("Expected not found error but got %v", err)
+	}
+}
+
+func TestDefaultWebhookService_ReplayDelivery_reschedules_dead_delivery(t *testing.T) {
+	//Arrange
+	teardown := setupWebhookServiceTest(t)
+	defer teardown()
+
+	dead := getDummyDelivery()
+	dead.Status, dead.Attempts = domain.DeliveryStatusDead, domain.WebhookMaxAttempts
+	mockWebhookRepo.EXPECT().FindSubscriptionById(dummyWebhookId).Return(getDummySubscription(""), nil)
+	mockWebhookRepo.EXPECT().FindDeliveryById(dummyDeliveryId).Return(&dead, nil)
+	mockWebhookRepo.EXPECT().UpdateDelivery(gomock.Any()).DoAndReturn(func(d domain.WebhookDelivery) *errs.AppError {
+		if d.Status != domain.DeliveryStatusPending || d.Attempts != 0 || d.NextAttemptDate != dummyNow {
+			t.Errorf("Expected pending delivery due now but got %+v", d)
+		}
+		return nil
+	})
+
+	//Act
+	response, err := webhookSvc.ReplayDelivery(dummyCustomerId, dummyWebhookId, dummyDeliveryId)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error: " + err.Message)
+	}
+	if response.Status != domain.DeliveryStatusPending {
+		t.Errorf("Expected status %s but got %s", domain.DeliveryStatusPending, response.Status)
+	}
+}
+
+func TestDefaultWebhookService_ReplayDelivery_returns_notFoundError_when_delivery_of_otherWebhook(t *testing.T) {
+	//Arrange
+	teardown := setupWebhookServiceTest(t)
+	defer teardown()
+
+	logger.MuteLogger()
+	delivery := getDummyDelivery()
+	delivery.SubscriptionId = "6"
+	mockWebhookRepo.EXPECT().FindSubscriptionById(dummyWebhookId).Return(getDummySubscription(""), nil)
+	mockWebhookRepo.EXPECT().FindDeliveryById(dummyDeliveryId).Return(&delivery, nil)
+
+	//Act
+	_, err := webhookSvc.ReplayDelivery(dummyCustomerId, dummyWebhookId, dummyDeliveryId)
+
+	//Assert
+	if err == nil || err.Code != http.StatusNotFound {
+		t.Errorf("Expected not found error but got %v", err)
+	}
+}
+
+func TestDefaultWebhookService_DeliverDue_sends_signed_request_and_records_outcome(t *testing.T) {
+	tests := []struct {
+		name             string
+		responseCode     int
+		expectedStatus   string
+		expectedAttempts int
+	}{
+		{"receiver accepts", http.StatusOK, domain.DeliveryStatusDelivered, 0},
+		{"receiver fails", http.StatusServiceUnavailable, domain.DeliveryStatusPending, 1},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Arrange
+			teardown := setupWebhookServiceTest(t)
+			defer teardown()
+
+			delivery := getDummyDelivery()
+			mockWebhookSender.EXPECT().Send("https://example.com/hooks", delivery.Payload, gomock.Any()).
+				DoAndReturn(func(url string, body string, headers map[string]string) (int, *errs.AppError) {
+					expectedSignature := domain.SignWebhook(dummyWebhookSecret, headers[domain.WebhookHeaderTimestamp], body)
+					if headers[domain.WebhookHeaderSignature] != expectedSignature {
+						t.Errorf("Expected signature %s but got %s", expectedSignature, headers[domain.WebhookHeaderSignature])
+					}
+					if headers[domain.WebhookHeaderDeliveryId] != dummyDeliveryId {
+						t.Errorf("Expected delivery %s but got %s", dummyDeliveryId, headers[domain.WebhookHeaderDeliveryId])
+					}
+					return tc.responseCode, nil
+				})
+
+			mockWebhookRepo.EXPECT().FindDueDeliveries(dummyNow, webhookBatchSize).Return([]domain.WebhookDelivery{delivery}, nil)
+			mockWebhookRepo.EXPECT().FindSubscriptionById(dummyWebhookId).Return(getDummySubscription("https://example.com/hooks"), nil)
+			mockWebhookRepo.EXPECT().UpdateDelivery(gomock.Any()).DoAndReturn(func(d domain.WebhookDelivery) *errs.AppError {
+				if d.Status != tc.expectedStatus || d.Attempts != tc.expectedAttempts || d.ResponseCode.Int64 != int64(tc.responseCode) {
+					t.Errorf("Expected %s delivery with %d attempts and response code %d but got %+v",
+						tc.expectedStatus, tc.expectedAttempts, tc.responseCode, d)
+				}
+				return nil
+			})
+
+			//Act
+			err := webhookSvc.DeliverDue()
+
+			//Assert
+			if err != nil {
+				t.Error("Expected no error but got error: " + err.Message)
+			}
+		})
+	}
+}
+
+func TestDefaultWebhookService_DeliverDue_gives_up_on_deliveries_of_disabled_webhook(t *testing.T) {
+	//Arrange
+	teardown := setupWebhookServiceTest(t)
+	defer teardown()
+
+	disabled := getDummySubscription("https://example.com/hooks")
+	disabled.Status = domain.WebhookStatusDisabled
+	mockWebhookSender.EXPECT().Send(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	mockWebhookRepo.EXPECT().FindDueDeliveries(dummyNow, webhookBatchSize).Return([]domain.WebhookDelivery{getDummyDelivery()}, nil)
+	mockWebhookRepo.EXPECT().FindSubscriptionById(dummyWebhookId).Return(disabled, nil)
+	mockWebhookRepo.EXPECT().UpdateDelivery(gomock.Any()).DoAndReturn(func(d domain.WebhookDelivery) *errs.AppError {
+		if d.Status != domain.DeliveryStatusDead || d.LastAttemptDate.Valid {
+			t.Errorf("Expected dead delivery without attempt but got %+v", d)
+		}
+		return nil
+	})
+
+	//Act
+	err := webhookSvc.DeliverDue()
+
+	//Assert
+	if err != nil {
+		t.Error("Expected no error but got error: " + err.Message)
+	}
+}